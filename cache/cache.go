@@ -0,0 +1,20 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrCacheMiss = errors.New("cache miss")
+
+// Store はキャッシュするデータを、文字列のキーと有効期限を付けて保存する
+// 保存先（プロセス内のメモリ、Redisなど）は実装ごとに差し替えられる
+type Store interface {
+	// Get はキーのデータを返し、存在しないか有効期限を過ぎている場合はErrCacheMissを返す
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Set はキーにデータをttlの間保存し、既に存在する場合は置き換える
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Delete はキーのデータを削除し、存在しないキーは無視する
+	Delete(ctx context.Context, keys ...string) error
+}
@@ -0,0 +1,89 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+type lruEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+type lruImpl struct {
+	mu       sync.Mutex
+	capacity int
+	// entries は最近使った順に並べたlruEntryで、先頭が最も新しい
+	entries *list.List
+	index   map[string]*list.Element
+	now     func() time.Time
+}
+
+// NewLRU はプロセス内のメモリに最大capacity件を保存し、あふれた場合は最も長く使われていないデータを捨てるStoreを返す
+func NewLRU(capacity int) Store {
+	return &lruImpl{
+		capacity: max(capacity, 1),
+		entries:  list.New(),
+		index:    map[string]*list.Element{},
+		now:      time.Now,
+	}
+}
+
+func (c *lruImpl) Get(ctx context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.index[key]
+	if !ok {
+		return nil, ErrCacheMiss
+	}
+	entry := elem.Value.(*lruEntry)
+	if !c.now().Before(entry.expiresAt) {
+		c.remove(elem)
+		return nil, ErrCacheMiss
+	}
+	c.entries.MoveToFront(elem)
+
+	return entry.value, nil
+}
+
+func (c *lruImpl) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := c.now().Add(ttl)
+	if elem, ok := c.index[key]; ok {
+		entry := elem.Value.(*lruEntry)
+		entry.value, entry.expiresAt = value, expiresAt
+		c.entries.MoveToFront(elem)
+		return nil
+	}
+
+	c.index[key] = c.entries.PushFront(&lruEntry{key: key, value: value, expiresAt: expiresAt})
+	if c.entries.Len() > c.capacity {
+		c.remove(c.entries.Back())
+	}
+
+	return nil
+}
+
+func (c *lruImpl) Delete(ctx context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		if elem, ok := c.index[key]; ok {
+			c.remove(elem)
+		}
+	}
+
+	return nil
+}
+
+func (c *lruImpl) remove(elem *list.Element) {
+	c.entries.Remove(elem)
+	delete(c.index, elem.Value.(*lruEntry).key)
+}
@@ -0,0 +1,52 @@
+package cache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rentaro-m-b/ai-model-exam/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRU(t *testing.T) {
+	ctx := context.Background()
+	c := cache.NewLRU(2)
+
+	assert.NoError(t, c.Set(ctx, "a", []byte("1"), time.Minute))
+	assert.NoError(t, c.Set(ctx, "b", []byte("2"), time.Minute))
+	// 参照したデータは最近使ったものとして残す
+	v, err := c.Get(ctx, "a")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("1"), v)
+
+	// あふれた場合は最も長く使われていないデータを捨てる
+	assert.NoError(t, c.Set(ctx, "c", []byte("3"), time.Minute))
+	_, err = c.Get(ctx, "b")
+	assert.ErrorIs(t, err, cache.ErrCacheMiss)
+	v, err = c.Get(ctx, "c")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("3"), v)
+
+	// 置き換えと削除
+	assert.NoError(t, c.Set(ctx, "a", []byte("4"), time.Minute))
+	v, err = c.Get(ctx, "a")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("4"), v)
+	assert.NoError(t, c.Delete(ctx, "a", "unknown"))
+	_, err = c.Get(ctx, "a")
+	assert.ErrorIs(t, err, cache.ErrCacheMiss)
+}
+
+func TestLRUExpire(t *testing.T) {
+	ctx := context.Background()
+	c := cache.NewLRU(10)
+
+	assert.NoError(t, c.Set(ctx, "a", []byte("1"), 10*time.Millisecond))
+	_, err := c.Get(ctx, "a")
+	assert.NoError(t, err)
+
+	time.Sleep(20 * time.Millisecond)
+	_, err = c.Get(ctx, "a")
+	assert.ErrorIs(t, err, cache.ErrCacheMiss)
+}
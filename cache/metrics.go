@@ -0,0 +1,36 @@
+package cache
+
+import (
+	"sync/atomic"
+)
+
+// Metrics はキャッシュの参照のうち、データが見つかった回数（ヒット）と見つからなかった回数（ミス）を数える
+// 複数の処理から同時に更新できる
+type Metrics struct {
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+func (m *Metrics) Hit() {
+	m.hits.Add(1)
+}
+
+func (m *Metrics) Miss() {
+	m.misses.Add(1)
+}
+
+func (m *Metrics) Hits() int64 {
+	return m.hits.Load()
+}
+
+func (m *Metrics) Misses() int64 {
+	return m.misses.Load()
+}
+
+// Snapshot は現在の回数をexpvarなどで公開するための値として返す
+func (m *Metrics) Snapshot() any {
+	return map[string]int64{
+		"hits":   m.Hits(),
+		"misses": m.Misses(),
+	}
+}
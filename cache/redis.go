@@ -0,0 +1,52 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type redisImpl struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// NewRedis はRedisのプロトコルに対応したサーバにデータを保存するStoreを返す
+// 複数のサーバのプロセスでキャッシュを共有するため、更新による削除が全てのプロセスに反映される
+// prefixは同じサーバを使う他のアプリケーションとキーが重ならないよう、全てのキーの先頭に付ける
+func NewRedis(client redis.UniversalClient, prefix string) Store {
+	return &redisImpl{
+		client: client,
+		prefix: prefix,
+	}
+}
+
+func (c *redisImpl) Get(ctx context.Context, key string) ([]byte, error) {
+	value, err := c.client.Get(ctx, c.prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrCacheMiss
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+func (c *redisImpl) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return c.client.Set(ctx, c.prefix+key, value, ttl).Err()
+}
+
+func (c *redisImpl) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	prefixed := make([]string, 0, len(keys))
+	for _, key := range keys {
+		prefixed = append(prefixed, c.prefix+key)
+	}
+
+	return c.client.Del(ctx, prefixed...).Err()
+}
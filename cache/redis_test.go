@@ -0,0 +1,58 @@
+package cache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/rentaro-m-b/ai-model-exam/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedis(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+	c := cache.NewRedis(client, "test:")
+
+	_, err := c.Get(ctx, "a")
+	assert.ErrorIs(t, err, cache.ErrCacheMiss)
+
+	// キーの先頭にprefixを付けて保存する
+	assert.NoError(t, c.Set(ctx, "a", []byte("1"), time.Minute))
+	assert.NoError(t, c.Set(ctx, "b", []byte("2"), time.Minute))
+	stored, err := server.Get("test:a")
+	assert.NoError(t, err)
+	assert.Equal(t, "1", stored)
+	assert.Equal(t, time.Minute, server.TTL("test:a"))
+
+	v, err := c.Get(ctx, "a")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("1"), v)
+
+	assert.NoError(t, c.Delete(ctx, "a", "unknown"))
+	assert.NoError(t, c.Delete(ctx))
+	_, err = c.Get(ctx, "a")
+	assert.ErrorIs(t, err, cache.ErrCacheMiss)
+	_, err = c.Get(ctx, "b")
+	assert.NoError(t, err)
+
+	// 有効期限を過ぎたデータは返さない
+	server.FastForward(time.Minute)
+	_, err = c.Get(ctx, "b")
+	assert.ErrorIs(t, err, cache.ErrCacheMiss)
+}
+
+func TestRedisFailureUnavailable(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr(), MaxRetries: -1})
+	defer client.Close()
+	server.Close()
+
+	_, err := cache.NewRedis(client, "test:").Get(context.Background(), "a")
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, cache.ErrCacheMiss)
+}
@@ -11,17 +11,84 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-const createBook = `-- name: CreateBook :one
-INSERT INTO books (id, title, author, publisher, price)
-    VALUES (nextval('BOOK_ID_SEQ'), $1, $2, $3, $4)
-    RETURNING id, title, author, publisher, price
+const bulkUpdateBooks = `-- name: BulkUpdateBooks :many
+UPDATE books
+    SET publisher = COALESCE($1, publisher),
+        price = COALESCE($2, price)
+    WHERE id = ANY($3::int[])
+    RETURNING id, title, author, publisher, price,
+        subtitle, edition, publication_date, language, page_count, format, description, series, series_volume, work_id, isbn, updated_at
 `
 
-type CreateBookParams struct {
-	Title     pgtype.Text
-	Author    pgtype.Text
+type BulkUpdateBooksParams struct {
 	Publisher pgtype.Text
 	Price     pgtype.Int4
+	Ids       []int32
+}
+
+func (q *Queries) BulkUpdateBooks(ctx context.Context, arg BulkUpdateBooksParams) ([]Book, error) {
+	rows, err := q.db.Query(ctx, bulkUpdateBooks, arg.Publisher, arg.Price, arg.Ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Book
+	for rows.Next() {
+		var i Book
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Author,
+			&i.Publisher,
+			&i.Price,
+			&i.Subtitle,
+			&i.Edition,
+			&i.PublicationDate,
+			&i.Language,
+			&i.PageCount,
+			&i.Format,
+			&i.Description,
+			&i.Series,
+			&i.SeriesVolume,
+			&i.WorkID,
+			&i.Isbn,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const createBook = `-- name: CreateBook :one
+INSERT INTO books (
+        id, title, author, publisher, price,
+        subtitle, edition, publication_date, language, page_count, format, description, series, series_volume, isbn
+    )
+    VALUES (nextval('BOOK_ID_SEQ'), $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+    RETURNING id, title, author, publisher, price,
+        subtitle, edition, publication_date, language, page_count, format, description, series, series_volume, work_id, isbn, updated_at
+`
+
+type CreateBookParams struct {
+	Title           pgtype.Text
+	Author          pgtype.Text
+	Publisher       pgtype.Text
+	Price           pgtype.Int4
+	Subtitle        pgtype.Text
+	Edition         pgtype.Text
+	PublicationDate pgtype.Date
+	Language        pgtype.Text
+	PageCount       pgtype.Int4
+	Format          pgtype.Text
+	Description     pgtype.Text
+	Series          pgtype.Text
+	SeriesVolume    pgtype.Int4
+	Isbn            pgtype.Text
 }
 
 func (q *Queries) CreateBook(ctx context.Context, arg CreateBookParams) (Book, error) {
@@ -30,6 +97,16 @@ func (q *Queries) CreateBook(ctx context.Context, arg CreateBookParams) (Book, e
 		arg.Author,
 		arg.Publisher,
 		arg.Price,
+		arg.Subtitle,
+		arg.Edition,
+		arg.PublicationDate,
+		arg.Language,
+		arg.PageCount,
+		arg.Format,
+		arg.Description,
+		arg.Series,
+		arg.SeriesVolume,
+		arg.Isbn,
 	)
 	var i Book
 	err := row.Scan(
@@ -38,6 +115,18 @@ func (q *Queries) CreateBook(ctx context.Context, arg CreateBookParams) (Book, e
 		&i.Author,
 		&i.Publisher,
 		&i.Price,
+		&i.Subtitle,
+		&i.Edition,
+		&i.PublicationDate,
+		&i.Language,
+		&i.PageCount,
+		&i.Format,
+		&i.Description,
+		&i.Series,
+		&i.SeriesVolume,
+		&i.WorkID,
+		&i.Isbn,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
@@ -53,8 +142,109 @@ func (q *Queries) DeleteBookByID(ctx context.Context, id int32) error {
 	return err
 }
 
+const deleteBooksByIds = `-- name: DeleteBooksByIds :execrows
+DELETE
+    FROM books
+    WHERE id = ANY($1::int[])
+`
+
+func (q *Queries) DeleteBooksByIds(ctx context.Context, ids []int32) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteBooksByIds, ids)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const filterBooks = `-- name: FilterBooks :many
+WITH RECURSIVE descendants AS (
+    SELECT categories.id
+        FROM categories
+        WHERE categories.id = $1::integer
+    UNION ALL
+    SELECT children.id
+        FROM categories AS children
+        INNER JOIN descendants ON children.parent_id = descendants.id
+)
+SELECT id, title, author, publisher, price,
+        subtitle, edition, publication_date, language, page_count, format, description, series, series_volume, work_id, isbn, updated_at
+    FROM books
+    WHERE ($1::integer IS NULL OR books.id IN (
+        SELECT book_categories.book_id
+            FROM book_categories
+            INNER JOIN descendants ON descendants.id = book_categories.category_id
+    ))
+    AND ($2::text[] IS NULL OR books.id IN (
+        SELECT book_tags.book_id
+            FROM book_tags
+            INNER JOIN tags ON tags.id = book_tags.tag_id
+            WHERE tags.name = ANY($2::text[])
+            GROUP BY book_tags.book_id
+            HAVING NOT $3::boolean
+            OR count(*) = cardinality($2::text[])
+    ))
+    AND ($4::boolean IS NULL OR EXISTS (
+        SELECT 1
+            FROM inventories
+            WHERE inventories.book_id = books.id
+            AND inventories.quantity > 0
+    ) = $4::boolean)
+    ORDER BY id
+`
+
+type FilterBooksParams struct {
+	CategoryID   pgtype.Int4
+	Tags         []string
+	MatchAllTags bool
+	InStock      pgtype.Bool
+}
+
+func (q *Queries) FilterBooks(ctx context.Context, arg FilterBooksParams) ([]Book, error) {
+	rows, err := q.db.Query(ctx, filterBooks,
+		arg.CategoryID,
+		arg.Tags,
+		arg.MatchAllTags,
+		arg.InStock,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Book
+	for rows.Next() {
+		var i Book
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Author,
+			&i.Publisher,
+			&i.Price,
+			&i.Subtitle,
+			&i.Edition,
+			&i.PublicationDate,
+			&i.Language,
+			&i.PageCount,
+			&i.Format,
+			&i.Description,
+			&i.Series,
+			&i.SeriesVolume,
+			&i.WorkID,
+			&i.Isbn,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getBookByID = `-- name: GetBookByID :one
-SELECT id, title, author, publisher, price
+SELECT id, title, author, publisher, price,
+        subtitle, edition, publication_date, language, page_count, format, description, series, series_volume, work_id, isbn, updated_at
     FROM books
     WHERE id = $1
 `
@@ -68,12 +258,84 @@ func (q *Queries) GetBookByID(ctx context.Context, id int32) (Book, error) {
 		&i.Author,
 		&i.Publisher,
 		&i.Price,
+		&i.Subtitle,
+		&i.Edition,
+		&i.PublicationDate,
+		&i.Language,
+		&i.PageCount,
+		&i.Format,
+		&i.Description,
+		&i.Series,
+		&i.SeriesVolume,
+		&i.WorkID,
+		&i.Isbn,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getBookByIDForUpdate = `-- name: GetBookByIDForUpdate :one
+SELECT id, title, author, publisher, price,
+        subtitle, edition, publication_date, language, page_count, format, description, series, series_volume, work_id, isbn, updated_at
+    FROM books
+    WHERE id = $1
+    FOR UPDATE
+`
+
+func (q *Queries) GetBookByIDForUpdate(ctx context.Context, id int32) (Book, error) {
+	row := q.db.QueryRow(ctx, getBookByIDForUpdate, id)
+	var i Book
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Author,
+		&i.Publisher,
+		&i.Price,
+		&i.Subtitle,
+		&i.Edition,
+		&i.PublicationDate,
+		&i.Language,
+		&i.PageCount,
+		&i.Format,
+		&i.Description,
+		&i.Series,
+		&i.SeriesVolume,
+		&i.WorkID,
+		&i.Isbn,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
 
+const getBookCollectionVersion = `-- name: GetBookCollectionVersion :one
+SELECT version, updated_at,
+        GREATEST(
+            (SELECT max(effective_from) FROM book_prices WHERE effective_from <= now()),
+            (SELECT max(effective_to) FROM book_prices WHERE effective_to <= now()),
+            (SELECT max(starts_at) FROM promotions WHERE starts_at <= now()),
+            (SELECT max(ends_at) FROM promotions WHERE ends_at <= now()),
+            (SELECT max(effective_date)::timestamp AT TIME ZONE 'UTC' FROM exchange_rates WHERE effective_date <= (now() AT TIME ZONE 'UTC')::date),
+            'epoch'::timestamptz
+        )::timestamptz AS last_effective_at
+    FROM catalog_versions
+`
+
+type GetBookCollectionVersionRow struct {
+	Version         int64
+	UpdatedAt       pgtype.Timestamptz
+	LastEffectiveAt pgtype.Timestamptz
+}
+
+func (q *Queries) GetBookCollectionVersion(ctx context.Context) (GetBookCollectionVersionRow, error) {
+	row := q.db.QueryRow(ctx, getBookCollectionVersion)
+	var i GetBookCollectionVersionRow
+	err := row.Scan(&i.Version, &i.UpdatedAt, &i.LastEffectiveAt)
+	return i, err
+}
+
 const listBooks = `-- name: ListBooks :many
-SELECT id, title, author, publisher, price
+SELECT id, title, author, publisher, price,
+        subtitle, edition, publication_date, language, page_count, format, description, series, series_volume, work_id, isbn, updated_at
     FROM books
 `
 
@@ -92,6 +354,261 @@ func (q *Queries) ListBooks(ctx context.Context) ([]Book, error) {
 			&i.Author,
 			&i.Publisher,
 			&i.Price,
+			&i.Subtitle,
+			&i.Edition,
+			&i.PublicationDate,
+			&i.Language,
+			&i.PageCount,
+			&i.Format,
+			&i.Description,
+			&i.Series,
+			&i.SeriesVolume,
+			&i.WorkID,
+			&i.Isbn,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBooksByAuthors = `-- name: ListBooksByAuthors :many
+SELECT id, title, author, publisher, price,
+        subtitle, edition, publication_date, language, page_count, format, description, series, series_volume, work_id, isbn, updated_at
+    FROM books
+    WHERE author = ANY($1::text[])
+    ORDER BY id
+`
+
+func (q *Queries) ListBooksByAuthors(ctx context.Context, authors []string) ([]Book, error) {
+	rows, err := q.db.Query(ctx, listBooksByAuthors, authors)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Book
+	for rows.Next() {
+		var i Book
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Author,
+			&i.Publisher,
+			&i.Price,
+			&i.Subtitle,
+			&i.Edition,
+			&i.PublicationDate,
+			&i.Language,
+			&i.PageCount,
+			&i.Format,
+			&i.Description,
+			&i.Series,
+			&i.SeriesVolume,
+			&i.WorkID,
+			&i.Isbn,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBooksByFilter = `-- name: ListBooksByFilter :many
+SELECT id, title, author, publisher, price,
+        subtitle, edition, publication_date, language, page_count, format, description, series, series_volume, work_id, isbn, updated_at
+    FROM books
+    WHERE ($1::int[] IS NULL OR id = ANY($1::int[]))
+        AND ($2::text IS NULL OR author = $2)
+        AND ($3::text IS NULL OR publisher = $3)
+    ORDER BY id
+`
+
+type ListBooksByFilterParams struct {
+	Ids       []int32
+	Author    pgtype.Text
+	Publisher pgtype.Text
+}
+
+func (q *Queries) ListBooksByFilter(ctx context.Context, arg ListBooksByFilterParams) ([]Book, error) {
+	rows, err := q.db.Query(ctx, listBooksByFilter, arg.Ids, arg.Author, arg.Publisher)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Book
+	for rows.Next() {
+		var i Book
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Author,
+			&i.Publisher,
+			&i.Price,
+			&i.Subtitle,
+			&i.Edition,
+			&i.PublicationDate,
+			&i.Language,
+			&i.PageCount,
+			&i.Format,
+			&i.Description,
+			&i.Series,
+			&i.SeriesVolume,
+			&i.WorkID,
+			&i.Isbn,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBooksByIds = `-- name: ListBooksByIds :many
+SELECT id, title, author, publisher, price,
+        subtitle, edition, publication_date, language, page_count, format, description, series, series_volume, work_id, isbn, updated_at
+    FROM books
+    WHERE id = ANY($1::int[])
+    ORDER BY id
+`
+
+func (q *Queries) ListBooksByIds(ctx context.Context, ids []int32) ([]Book, error) {
+	rows, err := q.db.Query(ctx, listBooksByIds, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Book
+	for rows.Next() {
+		var i Book
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Author,
+			&i.Publisher,
+			&i.Price,
+			&i.Subtitle,
+			&i.Edition,
+			&i.PublicationDate,
+			&i.Language,
+			&i.PageCount,
+			&i.Format,
+			&i.Description,
+			&i.Series,
+			&i.SeriesVolume,
+			&i.WorkID,
+			&i.Isbn,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBooksByPublishers = `-- name: ListBooksByPublishers :many
+SELECT id, title, author, publisher, price,
+        subtitle, edition, publication_date, language, page_count, format, description, series, series_volume, work_id, isbn, updated_at
+    FROM books
+    WHERE publisher = ANY($1::text[])
+    ORDER BY id
+`
+
+func (q *Queries) ListBooksByPublishers(ctx context.Context, publishers []string) ([]Book, error) {
+	rows, err := q.db.Query(ctx, listBooksByPublishers, publishers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Book
+	for rows.Next() {
+		var i Book
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Author,
+			&i.Publisher,
+			&i.Price,
+			&i.Subtitle,
+			&i.Edition,
+			&i.PublicationDate,
+			&i.Language,
+			&i.PageCount,
+			&i.Format,
+			&i.Description,
+			&i.Series,
+			&i.SeriesVolume,
+			&i.WorkID,
+			&i.Isbn,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBooksByStock = `-- name: ListBooksByStock :many
+SELECT id, title, author, publisher, price,
+        subtitle, edition, publication_date, language, page_count, format, description, series, series_volume, work_id, isbn, updated_at
+    FROM books
+    WHERE EXISTS (
+        SELECT 1
+            FROM inventories
+            WHERE inventories.book_id = books.id
+            AND inventories.quantity > 0
+    ) = $1::boolean
+    ORDER BY id
+`
+
+func (q *Queries) ListBooksByStock(ctx context.Context, inStock bool) ([]Book, error) {
+	rows, err := q.db.Query(ctx, listBooksByStock, inStock)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Book
+	for rows.Next() {
+		var i Book
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Author,
+			&i.Publisher,
+			&i.Price,
+			&i.Subtitle,
+			&i.Edition,
+			&i.PublicationDate,
+			&i.Language,
+			&i.PageCount,
+			&i.Format,
+			&i.Description,
+			&i.Series,
+			&i.SeriesVolume,
+			&i.WorkID,
+			&i.Isbn,
+			&i.UpdatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -102,3 +619,270 @@ func (q *Queries) ListBooks(ctx context.Context) ([]Book, error) {
 	}
 	return items, nil
 }
+
+const listDuplicateBookCandidates = `-- name: ListDuplicateBookCandidates :many
+SELECT id, title, author, publisher, price,
+        subtitle, edition, publication_date, language, page_count, format, description, series, series_volume, work_id, isbn, updated_at
+    FROM books
+    WHERE isbn = $1
+    OR (
+        lower(coalesce(title, '') || ' ' || coalesce(author, '')) % $2::text
+        AND similarity(lower(coalesce(title, '') || ' ' || coalesce(author, '')), $2::text) >= $3::real
+    )
+    ORDER BY (isbn = $1) IS TRUE DESC,
+        similarity(lower(coalesce(title, '') || ' ' || coalesce(author, '')), $2::text) DESC,
+        id
+    LIMIT $4
+`
+
+type ListDuplicateBookCandidatesParams struct {
+	Isbn          pgtype.Text
+	TitleAuthor   string
+	MinSimilarity float32
+	MaxCandidates int32
+}
+
+func (q *Queries) ListDuplicateBookCandidates(ctx context.Context, arg ListDuplicateBookCandidatesParams) ([]Book, error) {
+	rows, err := q.db.Query(ctx, listDuplicateBookCandidates,
+		arg.Isbn,
+		arg.TitleAuthor,
+		arg.MinSimilarity,
+		arg.MaxCandidates,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Book
+	for rows.Next() {
+		var i Book
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Author,
+			&i.Publisher,
+			&i.Price,
+			&i.Subtitle,
+			&i.Edition,
+			&i.PublicationDate,
+			&i.Language,
+			&i.PageCount,
+			&i.Format,
+			&i.Description,
+			&i.Series,
+			&i.SeriesVolume,
+			&i.WorkID,
+			&i.Isbn,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockBooksByFilter = `-- name: LockBooksByFilter :many
+SELECT id, title, author, publisher, price,
+        subtitle, edition, publication_date, language, page_count, format, description, series, series_volume, work_id, isbn, updated_at
+    FROM books
+    WHERE ($1::int[] IS NULL OR id = ANY($1::int[]))
+        AND ($2::text IS NULL OR author = $2)
+        AND ($3::text IS NULL OR publisher = $3)
+    ORDER BY id
+    FOR UPDATE
+`
+
+type LockBooksByFilterParams struct {
+	Ids       []int32
+	Author    pgtype.Text
+	Publisher pgtype.Text
+}
+
+func (q *Queries) LockBooksByFilter(ctx context.Context, arg LockBooksByFilterParams) ([]Book, error) {
+	rows, err := q.db.Query(ctx, lockBooksByFilter, arg.Ids, arg.Author, arg.Publisher)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Book
+	for rows.Next() {
+		var i Book
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Author,
+			&i.Publisher,
+			&i.Price,
+			&i.Subtitle,
+			&i.Edition,
+			&i.PublicationDate,
+			&i.Language,
+			&i.PageCount,
+			&i.Format,
+			&i.Description,
+			&i.Series,
+			&i.SeriesVolume,
+			&i.WorkID,
+			&i.Isbn,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchBooks = `-- name: SearchBooks :many
+SELECT id, title, author, publisher, price,
+        subtitle, edition, publication_date, language, page_count, format, description, series, series_volume, work_id, isbn, updated_at
+    FROM books
+    WHERE ($1::text IS NULL OR title ILIKE '%' || $1::text || '%')
+    AND ($2::text IS NULL OR author = $2::text)
+    AND ($3::text IS NULL OR publisher = $3::text)
+    AND ($4::integer IS NULL OR price >= $4::integer)
+    AND ($5::integer IS NULL OR price <= $5::integer)
+    ORDER BY id
+    LIMIT $7
+    OFFSET $6
+`
+
+type SearchBooksParams struct {
+	Title     pgtype.Text
+	Author    pgtype.Text
+	Publisher pgtype.Text
+	MinPrice  pgtype.Int4
+	MaxPrice  pgtype.Int4
+	Offset    int32
+	Limit     int32
+}
+
+func (q *Queries) SearchBooks(ctx context.Context, arg SearchBooksParams) ([]Book, error) {
+	rows, err := q.db.Query(ctx, searchBooks,
+		arg.Title,
+		arg.Author,
+		arg.Publisher,
+		arg.MinPrice,
+		arg.MaxPrice,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Book
+	for rows.Next() {
+		var i Book
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Author,
+			&i.Publisher,
+			&i.Price,
+			&i.Subtitle,
+			&i.Edition,
+			&i.PublicationDate,
+			&i.Language,
+			&i.PageCount,
+			&i.Format,
+			&i.Description,
+			&i.Series,
+			&i.SeriesVolume,
+			&i.WorkID,
+			&i.Isbn,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateBook = `-- name: UpdateBook :one
+UPDATE books
+    SET title = COALESCE($1, title),
+        author = COALESCE($2, author),
+        publisher = COALESCE($3, publisher),
+        subtitle = COALESCE($4, subtitle),
+        edition = COALESCE($5, edition),
+        publication_date = COALESCE($6, publication_date),
+        language = COALESCE($7, language),
+        page_count = COALESCE($8, page_count),
+        format = COALESCE($9, format),
+        description = COALESCE($10, description),
+        series = COALESCE($11, series),
+        series_volume = COALESCE($12, series_volume),
+        isbn = COALESCE($13, isbn)
+    WHERE id = $14
+    RETURNING id, title, author, publisher, price,
+        subtitle, edition, publication_date, language, page_count, format, description, series, series_volume, work_id, isbn, updated_at
+`
+
+type UpdateBookParams struct {
+	Title           pgtype.Text
+	Author          pgtype.Text
+	Publisher       pgtype.Text
+	Subtitle        pgtype.Text
+	Edition         pgtype.Text
+	PublicationDate pgtype.Date
+	Language        pgtype.Text
+	PageCount       pgtype.Int4
+	Format          pgtype.Text
+	Description     pgtype.Text
+	Series          pgtype.Text
+	SeriesVolume    pgtype.Int4
+	Isbn            pgtype.Text
+	ID              int32
+}
+
+func (q *Queries) UpdateBook(ctx context.Context, arg UpdateBookParams) (Book, error) {
+	row := q.db.QueryRow(ctx, updateBook,
+		arg.Title,
+		arg.Author,
+		arg.Publisher,
+		arg.Subtitle,
+		arg.Edition,
+		arg.PublicationDate,
+		arg.Language,
+		arg.PageCount,
+		arg.Format,
+		arg.Description,
+		arg.Series,
+		arg.SeriesVolume,
+		arg.Isbn,
+		arg.ID,
+	)
+	var i Book
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Author,
+		&i.Publisher,
+		&i.Price,
+		&i.Subtitle,
+		&i.Edition,
+		&i.PublicationDate,
+		&i.Language,
+		&i.PageCount,
+		&i.Format,
+		&i.Description,
+		&i.Series,
+		&i.SeriesVolume,
+		&i.WorkID,
+		&i.Isbn,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,68 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: book_audit_log.sql
+
+package db
+
+import (
+	"context"
+)
+
+const createBookAuditLog = `-- name: CreateBookAuditLog :one
+INSERT INTO book_audit_logs (book_id, action, detail)
+    VALUES ($1, $2, $3)
+    RETURNING id, book_id, action, detail, created_at
+`
+
+type CreateBookAuditLogParams struct {
+	BookID int32
+	Action string
+	Detail []byte
+}
+
+func (q *Queries) CreateBookAuditLog(ctx context.Context, arg CreateBookAuditLogParams) (BookAuditLog, error) {
+	row := q.db.QueryRow(ctx, createBookAuditLog, arg.BookID, arg.Action, arg.Detail)
+	var i BookAuditLog
+	err := row.Scan(
+		&i.ID,
+		&i.BookID,
+		&i.Action,
+		&i.Detail,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listBookAuditLogsByBookID = `-- name: ListBookAuditLogsByBookID :many
+SELECT id, book_id, action, detail, created_at
+    FROM book_audit_logs
+    WHERE book_id = $1
+    ORDER BY id DESC
+`
+
+func (q *Queries) ListBookAuditLogsByBookID(ctx context.Context, bookID int32) ([]BookAuditLog, error) {
+	rows, err := q.db.Query(ctx, listBookAuditLogsByBookID, bookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []BookAuditLog
+	for rows.Next() {
+		var i BookAuditLog
+		if err := rows.Scan(
+			&i.ID,
+			&i.BookID,
+			&i.Action,
+			&i.Detail,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,266 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: book_merge.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createBookRedirect = `-- name: CreateBookRedirect :exec
+INSERT INTO book_redirects (source_id, target_id)
+    VALUES ($1, $2)
+`
+
+type CreateBookRedirectParams struct {
+	SourceID int32
+	TargetID int32
+}
+
+func (q *Queries) CreateBookRedirect(ctx context.Context, arg CreateBookRedirectParams) error {
+	_, err := q.db.Exec(ctx, createBookRedirect, arg.SourceID, arg.TargetID)
+	return err
+}
+
+const deleteConflictingReviews = `-- name: DeleteConflictingReviews :exec
+DELETE
+    FROM reviews
+    WHERE reviews.book_id = $1
+    AND reviews.member_id IN (
+        SELECT targets.member_id
+            FROM reviews AS targets
+            WHERE targets.book_id = $2
+    )
+`
+
+type DeleteConflictingReviewsParams struct {
+	SourceID int32
+	TargetID int32
+}
+
+func (q *Queries) DeleteConflictingReviews(ctx context.Context, arg DeleteConflictingReviewsParams) error {
+	_, err := q.db.Exec(ctx, deleteConflictingReviews, arg.SourceID, arg.TargetID)
+	return err
+}
+
+const expireConflictingReservations = `-- name: ExpireConflictingReservations :exec
+UPDATE reservations
+    SET status = 'expired'
+    WHERE reservations.book_id = $1
+    AND reservations.status IN ('waiting', 'held')
+    AND reservations.member_id IN (
+        SELECT targets.member_id
+            FROM reservations AS targets
+            WHERE targets.book_id = $2
+            AND targets.status IN ('waiting', 'held')
+    )
+`
+
+type ExpireConflictingReservationsParams struct {
+	SourceID int32
+	TargetID int32
+}
+
+func (q *Queries) ExpireConflictingReservations(ctx context.Context, arg ExpireConflictingReservationsParams) error {
+	_, err := q.db.Exec(ctx, expireConflictingReservations, arg.SourceID, arg.TargetID)
+	return err
+}
+
+const getBookRedirect = `-- name: GetBookRedirect :one
+SELECT target_id
+    FROM book_redirects
+    WHERE source_id = $1
+`
+
+func (q *Queries) GetBookRedirect(ctx context.Context, sourceID int32) (int32, error) {
+	row := q.db.QueryRow(ctx, getBookRedirect, sourceID)
+	var target_id int32
+	err := row.Scan(&target_id)
+	return target_id, err
+}
+
+const mergeBookCategories = `-- name: MergeBookCategories :exec
+INSERT INTO book_categories (book_id, category_id)
+    SELECT $1::integer, sources.category_id
+        FROM book_categories AS sources
+        WHERE sources.book_id = $2
+    ON CONFLICT DO NOTHING
+`
+
+type MergeBookCategoriesParams struct {
+	TargetID int32
+	SourceID int32
+}
+
+func (q *Queries) MergeBookCategories(ctx context.Context, arg MergeBookCategoriesParams) error {
+	_, err := q.db.Exec(ctx, mergeBookCategories, arg.TargetID, arg.SourceID)
+	return err
+}
+
+const mergeBookTags = `-- name: MergeBookTags :exec
+INSERT INTO book_tags (book_id, tag_id, created_at)
+    SELECT $1::integer, sources.tag_id, sources.created_at
+        FROM book_tags AS sources
+        WHERE sources.book_id = $2
+    ON CONFLICT DO NOTHING
+`
+
+type MergeBookTagsParams struct {
+	TargetID int32
+	SourceID int32
+}
+
+func (q *Queries) MergeBookTags(ctx context.Context, arg MergeBookTagsParams) error {
+	_, err := q.db.Exec(ctx, mergeBookTags, arg.TargetID, arg.SourceID)
+	return err
+}
+
+const mergeInventories = `-- name: MergeInventories :exec
+INSERT INTO inventories (book_id, location, quantity)
+    SELECT $1::integer, sources.location, sources.quantity
+        FROM inventories AS sources
+        WHERE sources.book_id = $2
+    ON CONFLICT (book_id, location) DO UPDATE
+        SET quantity = inventories.quantity + EXCLUDED.quantity
+`
+
+type MergeInventoriesParams struct {
+	TargetID int32
+	SourceID int32
+}
+
+func (q *Queries) MergeInventories(ctx context.Context, arg MergeInventoriesParams) error {
+	_, err := q.db.Exec(ctx, mergeInventories, arg.TargetID, arg.SourceID)
+	return err
+}
+
+const moveBookAuditLogs = `-- name: MoveBookAuditLogs :exec
+UPDATE book_audit_logs
+    SET book_id = $1
+    WHERE book_id = $2
+`
+
+type MoveBookAuditLogsParams struct {
+	TargetID int32
+	SourceID int32
+}
+
+func (q *Queries) MoveBookAuditLogs(ctx context.Context, arg MoveBookAuditLogsParams) error {
+	_, err := q.db.Exec(ctx, moveBookAuditLogs, arg.TargetID, arg.SourceID)
+	return err
+}
+
+const moveBookRedirects = `-- name: MoveBookRedirects :exec
+UPDATE book_redirects
+    SET target_id = $1
+    WHERE target_id = $2
+`
+
+type MoveBookRedirectsParams struct {
+	TargetID int32
+	SourceID int32
+}
+
+func (q *Queries) MoveBookRedirects(ctx context.Context, arg MoveBookRedirectsParams) error {
+	_, err := q.db.Exec(ctx, moveBookRedirects, arg.TargetID, arg.SourceID)
+	return err
+}
+
+const moveLoans = `-- name: MoveLoans :exec
+UPDATE loans
+    SET book_id = $1
+    WHERE book_id = $2
+`
+
+type MoveLoansParams struct {
+	TargetID int32
+	SourceID int32
+}
+
+func (q *Queries) MoveLoans(ctx context.Context, arg MoveLoansParams) error {
+	_, err := q.db.Exec(ctx, moveLoans, arg.TargetID, arg.SourceID)
+	return err
+}
+
+const movePromotions = `-- name: MovePromotions :exec
+UPDATE promotions
+    SET target_book_id = $1
+    WHERE target_book_id = $2
+`
+
+type MovePromotionsParams struct {
+	TargetID pgtype.Int4
+	SourceID pgtype.Int4
+}
+
+func (q *Queries) MovePromotions(ctx context.Context, arg MovePromotionsParams) error {
+	_, err := q.db.Exec(ctx, movePromotions, arg.TargetID, arg.SourceID)
+	return err
+}
+
+const moveReservations = `-- name: MoveReservations :exec
+UPDATE reservations
+    SET book_id = $1
+    WHERE book_id = $2
+`
+
+type MoveReservationsParams struct {
+	TargetID int32
+	SourceID int32
+}
+
+func (q *Queries) MoveReservations(ctx context.Context, arg MoveReservationsParams) error {
+	_, err := q.db.Exec(ctx, moveReservations, arg.TargetID, arg.SourceID)
+	return err
+}
+
+const moveReviews = `-- name: MoveReviews :exec
+UPDATE reviews
+    SET book_id = $1
+    WHERE book_id = $2
+`
+
+type MoveReviewsParams struct {
+	TargetID int32
+	SourceID int32
+}
+
+func (q *Queries) MoveReviews(ctx context.Context, arg MoveReviewsParams) error {
+	_, err := q.db.Exec(ctx, moveReviews, arg.TargetID, arg.SourceID)
+	return err
+}
+
+const moveStockMovements = `-- name: MoveStockMovements :exec
+UPDATE stock_movements
+    SET book_id = $1
+    WHERE book_id = $2
+`
+
+type MoveStockMovementsParams struct {
+	TargetID int32
+	SourceID int32
+}
+
+func (q *Queries) MoveStockMovements(ctx context.Context, arg MoveStockMovementsParams) error {
+	_, err := q.db.Exec(ctx, moveStockMovements, arg.TargetID, arg.SourceID)
+	return err
+}
+
+const recalculateBookRating = `-- name: RecalculateBookRating :exec
+INSERT INTO book_ratings (book_id, review_count, rating_sum)
+    SELECT $1::integer, count(*), coalesce(sum(rating), 0)
+        FROM reviews
+        WHERE book_id = $1
+    ON CONFLICT (book_id) DO UPDATE
+        SET review_count = EXCLUDED.review_count,
+            rating_sum = EXCLUDED.rating_sum
+`
+
+func (q *Queries) RecalculateBookRating(ctx context.Context, bookID int32) error {
+	_, err := q.db.Exec(ctx, recalculateBookRating, bookID)
+	return err
+}
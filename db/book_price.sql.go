@@ -0,0 +1,175 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: book_price.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const closeBookPrice = `-- name: CloseBookPrice :exec
+UPDATE book_prices
+    SET effective_to = $1
+    WHERE book_id = $2
+    AND effective_from < $1
+    AND (effective_to IS NULL OR effective_to > $1)
+`
+
+type CloseBookPriceParams struct {
+	EffectiveFrom pgtype.Timestamptz
+	BookID        int32
+}
+
+func (q *Queries) CloseBookPrice(ctx context.Context, arg CloseBookPriceParams) error {
+	_, err := q.db.Exec(ctx, closeBookPrice, arg.EffectiveFrom, arg.BookID)
+	return err
+}
+
+const createBookPrice = `-- name: CreateBookPrice :one
+INSERT INTO book_prices (book_id, currency, amount, effective_from, effective_to)
+    VALUES ($1, $2, $3, $4, $5)
+    RETURNING id, book_id, currency, amount, effective_from, effective_to
+`
+
+type CreateBookPriceParams struct {
+	BookID        int32
+	Currency      string
+	Amount        int64
+	EffectiveFrom pgtype.Timestamptz
+	EffectiveTo   pgtype.Timestamptz
+}
+
+func (q *Queries) CreateBookPrice(ctx context.Context, arg CreateBookPriceParams) (BookPrice, error) {
+	row := q.db.QueryRow(ctx, createBookPrice,
+		arg.BookID,
+		arg.Currency,
+		arg.Amount,
+		arg.EffectiveFrom,
+		arg.EffectiveTo,
+	)
+	var i BookPrice
+	err := row.Scan(
+		&i.ID,
+		&i.BookID,
+		&i.Currency,
+		&i.Amount,
+		&i.EffectiveFrom,
+		&i.EffectiveTo,
+	)
+	return i, err
+}
+
+const createInitialBookPrice = `-- name: CreateInitialBookPrice :exec
+INSERT INTO book_prices (book_id, currency, amount, effective_from)
+    VALUES ($1, $2, $3, now())
+`
+
+type CreateInitialBookPriceParams struct {
+	BookID   int32
+	Currency string
+	Amount   int64
+}
+
+func (q *Queries) CreateInitialBookPrice(ctx context.Context, arg CreateInitialBookPriceParams) error {
+	_, err := q.db.Exec(ctx, createInitialBookPrice, arg.BookID, arg.Currency, arg.Amount)
+	return err
+}
+
+const getNextBookPriceStart = `-- name: GetNextBookPriceStart :one
+SELECT effective_from
+    FROM book_prices
+    WHERE book_id = $1
+    AND effective_from > $2
+    ORDER BY effective_from
+    LIMIT 1
+`
+
+type GetNextBookPriceStartParams struct {
+	BookID        int32
+	EffectiveFrom pgtype.Timestamptz
+}
+
+func (q *Queries) GetNextBookPriceStart(ctx context.Context, arg GetNextBookPriceStartParams) (pgtype.Timestamptz, error) {
+	row := q.db.QueryRow(ctx, getNextBookPriceStart, arg.BookID, arg.EffectiveFrom)
+	var effective_from pgtype.Timestamptz
+	err := row.Scan(&effective_from)
+	return effective_from, err
+}
+
+const listBookPricesByBookID = `-- name: ListBookPricesByBookID :many
+SELECT id, book_id, currency, amount, effective_from, effective_to
+    FROM book_prices
+    WHERE book_id = $1
+    ORDER BY effective_from DESC
+`
+
+func (q *Queries) ListBookPricesByBookID(ctx context.Context, bookID int32) ([]BookPrice, error) {
+	rows, err := q.db.Query(ctx, listBookPricesByBookID, bookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []BookPrice
+	for rows.Next() {
+		var i BookPrice
+		if err := rows.Scan(
+			&i.ID,
+			&i.BookID,
+			&i.Currency,
+			&i.Amount,
+			&i.EffectiveFrom,
+			&i.EffectiveTo,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBookPricesInEffect = `-- name: ListBookPricesInEffect :many
+SELECT id, book_id, currency, amount, effective_from, effective_to
+    FROM book_prices
+    WHERE book_id = ANY($1::integer[])
+    AND effective_from <= $2
+    AND (effective_to IS NULL OR effective_to > $2)
+`
+
+type ListBookPricesInEffectParams struct {
+	BookIds []int32
+	At      pgtype.Timestamptz
+}
+
+func (q *Queries) ListBookPricesInEffect(ctx context.Context, arg ListBookPricesInEffectParams) ([]BookPrice, error) {
+	rows, err := q.db.Query(ctx, listBookPricesInEffect, arg.BookIds, arg.At)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []BookPrice
+	for rows.Next() {
+		var i BookPrice
+		if err := rows.Scan(
+			&i.ID,
+			&i.BookID,
+			&i.Currency,
+			&i.Amount,
+			&i.EffectiveFrom,
+			&i.EffectiveTo,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,371 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: category.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const countCategoriesByIDs = `-- name: CountCategoriesByIDs :one
+SELECT count(*)
+    FROM categories
+    WHERE id = ANY($1::integer[])
+`
+
+func (q *Queries) CountCategoriesByIDs(ctx context.Context, ids []int32) (int64, error) {
+	row := q.db.QueryRow(ctx, countCategoriesByIDs, ids)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createBookCategories = `-- name: CreateBookCategories :exec
+INSERT INTO book_categories (book_id, category_id)
+    SELECT $1, unnest($2::integer[])
+    ON CONFLICT DO NOTHING
+`
+
+type CreateBookCategoriesParams struct {
+	BookID      int32
+	CategoryIds []int32
+}
+
+func (q *Queries) CreateBookCategories(ctx context.Context, arg CreateBookCategoriesParams) error {
+	_, err := q.db.Exec(ctx, createBookCategories, arg.BookID, arg.CategoryIds)
+	return err
+}
+
+const createCategory = `-- name: CreateCategory :one
+INSERT INTO categories (name, parent_id)
+    VALUES ($1, $2)
+    RETURNING id, name, parent_id, created_at
+`
+
+type CreateCategoryParams struct {
+	Name     string
+	ParentID pgtype.Int4
+}
+
+func (q *Queries) CreateCategory(ctx context.Context, arg CreateCategoryParams) (Category, error) {
+	row := q.db.QueryRow(ctx, createCategory, arg.Name, arg.ParentID)
+	var i Category
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.ParentID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteBookCategories = `-- name: DeleteBookCategories :exec
+DELETE
+    FROM book_categories
+    WHERE book_id = $1
+`
+
+func (q *Queries) DeleteBookCategories(ctx context.Context, bookID int32) error {
+	_, err := q.db.Exec(ctx, deleteBookCategories, bookID)
+	return err
+}
+
+const deleteCategoryByID = `-- name: DeleteCategoryByID :execrows
+DELETE
+    FROM categories
+    WHERE id = $1
+`
+
+func (q *Queries) DeleteCategoryByID(ctx context.Context, id int32) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteCategoryByID, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getCategoryByID = `-- name: GetCategoryByID :one
+SELECT id, name, parent_id, created_at
+    FROM categories
+    WHERE id = $1
+`
+
+func (q *Queries) GetCategoryByID(ctx context.Context, id int32) (Category, error) {
+	row := q.db.QueryRow(ctx, getCategoryByID, id)
+	var i Category
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.ParentID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getCategoryByIDForUpdate = `-- name: GetCategoryByIDForUpdate :one
+SELECT id, name, parent_id, created_at
+    FROM categories
+    WHERE id = $1
+    FOR UPDATE
+`
+
+func (q *Queries) GetCategoryByIDForUpdate(ctx context.Context, id int32) (Category, error) {
+	row := q.db.QueryRow(ctx, getCategoryByIDForUpdate, id)
+	var i Category
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.ParentID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const isCategoryInSubtree = `-- name: IsCategoryInSubtree :one
+WITH RECURSIVE subtree AS (
+    SELECT categories.id
+        FROM categories
+        WHERE categories.id = $2
+    UNION ALL
+    SELECT children.id
+        FROM categories AS children
+        INNER JOIN subtree ON children.parent_id = subtree.id
+)
+SELECT (count(*) > 0)::boolean AS in_subtree
+    FROM subtree
+    WHERE subtree.id = $1::integer
+`
+
+type IsCategoryInSubtreeParams struct {
+	CategoryID int32
+	RootID     int32
+}
+
+func (q *Queries) IsCategoryInSubtree(ctx context.Context, arg IsCategoryInSubtreeParams) (bool, error) {
+	row := q.db.QueryRow(ctx, isCategoryInSubtree, arg.CategoryID, arg.RootID)
+	var in_subtree bool
+	err := row.Scan(&in_subtree)
+	return in_subtree, err
+}
+
+const listCategoriesByBookID = `-- name: ListCategoriesByBookID :many
+SELECT categories.id, categories.name, categories.parent_id, categories.created_at
+    FROM categories
+    INNER JOIN book_categories ON book_categories.category_id = categories.id
+    WHERE book_categories.book_id = $1
+    ORDER BY categories.id
+`
+
+func (q *Queries) ListCategoriesByBookID(ctx context.Context, bookID int32) ([]Category, error) {
+	rows, err := q.db.Query(ctx, listCategoriesByBookID, bookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Category
+	for rows.Next() {
+		var i Category
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.ParentID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCategoryAncestors = `-- name: ListCategoryAncestors :many
+WITH RECURSIVE ancestors AS (
+    SELECT categories.id, categories.name, categories.parent_id, categories.created_at, 0 AS distance
+        FROM categories
+        WHERE categories.id = (SELECT parents.parent_id FROM categories AS parents WHERE parents.id = $1)
+    UNION ALL
+    SELECT parents.id, parents.name, parents.parent_id, parents.created_at, ancestors.distance + 1
+        FROM categories AS parents
+        INNER JOIN ancestors ON parents.id = ancestors.parent_id
+)
+SELECT id, name, parent_id, created_at
+    FROM ancestors
+    ORDER BY distance DESC
+`
+
+type ListCategoryAncestorsRow struct {
+	ID        int32
+	Name      string
+	ParentID  pgtype.Int4
+	CreatedAt pgtype.Timestamptz
+}
+
+func (q *Queries) ListCategoryAncestors(ctx context.Context, id int32) ([]ListCategoryAncestorsRow, error) {
+	rows, err := q.db.Query(ctx, listCategoryAncestors, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListCategoryAncestorsRow
+	for rows.Next() {
+		var i ListCategoryAncestorsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.ParentID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCategoryTree = `-- name: ListCategoryTree :many
+WITH RECURSIVE tree AS (
+    SELECT categories.id, categories.name, categories.parent_id, categories.created_at, 0 AS depth, ARRAY[categories.name::text] AS path
+        FROM categories
+        WHERE categories.parent_id IS NULL
+    UNION ALL
+    SELECT children.id, children.name, children.parent_id, children.created_at, tree.depth + 1, tree.path || children.name::text
+        FROM categories AS children
+        INNER JOIN tree ON children.parent_id = tree.id
+)
+SELECT id, name, parent_id, created_at, depth::integer AS depth
+    FROM tree
+    ORDER BY path
+`
+
+type ListCategoryTreeRow struct {
+	ID        int32
+	Name      string
+	ParentID  pgtype.Int4
+	CreatedAt pgtype.Timestamptz
+	Depth     int32
+}
+
+func (q *Queries) ListCategoryTree(ctx context.Context) ([]ListCategoryTreeRow, error) {
+	rows, err := q.db.Query(ctx, listCategoryTree)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListCategoryTreeRow
+	for rows.Next() {
+		var i ListCategoryTreeRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.ParentID,
+			&i.CreatedAt,
+			&i.Depth,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listChildCategories = `-- name: ListChildCategories :many
+SELECT id, name, parent_id, created_at
+    FROM categories
+    WHERE parent_id = $1
+    ORDER BY name
+`
+
+func (q *Queries) ListChildCategories(ctx context.Context, parentID pgtype.Int4) ([]Category, error) {
+	rows, err := q.db.Query(ctx, listChildCategories, parentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Category
+	for rows.Next() {
+		var i Category
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.ParentID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockCategoryTree = `-- name: LockCategoryTree :exec
+SELECT pg_advisory_xact_lock(hashtext('categories'))
+`
+
+func (q *Queries) LockCategoryTree(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, lockCategoryTree)
+	return err
+}
+
+const updateCategoryName = `-- name: UpdateCategoryName :one
+UPDATE categories
+    SET name = $2
+    WHERE id = $1
+    RETURNING id, name, parent_id, created_at
+`
+
+type UpdateCategoryNameParams struct {
+	ID   int32
+	Name string
+}
+
+func (q *Queries) UpdateCategoryName(ctx context.Context, arg UpdateCategoryNameParams) (Category, error) {
+	row := q.db.QueryRow(ctx, updateCategoryName, arg.ID, arg.Name)
+	var i Category
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.ParentID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const updateCategoryParent = `-- name: UpdateCategoryParent :one
+UPDATE categories
+    SET parent_id = $2
+    WHERE id = $1
+    RETURNING id, name, parent_id, created_at
+`
+
+type UpdateCategoryParentParams struct {
+	ID       int32
+	ParentID pgtype.Int4
+}
+
+func (q *Queries) UpdateCategoryParent(ctx context.Context, arg UpdateCategoryParentParams) (Category, error) {
+	row := q.db.QueryRow(ctx, updateCategoryParent, arg.ID, arg.ParentID)
+	var i Category
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.ParentID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
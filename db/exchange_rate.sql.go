@@ -0,0 +1,68 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: exchange_rate.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const getExchangeRate = `-- name: GetExchangeRate :one
+SELECT id, base_currency, quote_currency, rate, effective_date, imported_at
+    FROM exchange_rates
+    WHERE (
+        (base_currency = $1 AND quote_currency = $2)
+        OR (base_currency = $2 AND quote_currency = $1)
+    )
+    AND effective_date <= $3
+    ORDER BY effective_date DESC, base_currency = $1 DESC
+    LIMIT 1
+`
+
+type GetExchangeRateParams struct {
+	FromCurrency string
+	ToCurrency   string
+	OnDate       pgtype.Date
+}
+
+func (q *Queries) GetExchangeRate(ctx context.Context, arg GetExchangeRateParams) (ExchangeRate, error) {
+	row := q.db.QueryRow(ctx, getExchangeRate, arg.FromCurrency, arg.ToCurrency, arg.OnDate)
+	var i ExchangeRate
+	err := row.Scan(
+		&i.ID,
+		&i.BaseCurrency,
+		&i.QuoteCurrency,
+		&i.Rate,
+		&i.EffectiveDate,
+		&i.ImportedAt,
+	)
+	return i, err
+}
+
+const upsertExchangeRate = `-- name: UpsertExchangeRate :exec
+INSERT INTO exchange_rates (base_currency, quote_currency, rate, effective_date)
+    VALUES ($1, $2, $3, $4)
+    ON CONFLICT (base_currency, quote_currency, effective_date)
+    DO UPDATE SET rate = EXCLUDED.rate, imported_at = now()
+`
+
+type UpsertExchangeRateParams struct {
+	BaseCurrency  string
+	QuoteCurrency string
+	Rate          pgtype.Numeric
+	EffectiveDate pgtype.Date
+}
+
+func (q *Queries) UpsertExchangeRate(ctx context.Context, arg UpsertExchangeRateParams) error {
+	_, err := q.db.Exec(ctx, upsertExchangeRate,
+		arg.BaseCurrency,
+		arg.QuoteCurrency,
+		arg.Rate,
+		arg.EffectiveDate,
+	)
+	return err
+}
@@ -0,0 +1,78 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: idempotency_key.sql
+
+package db
+
+import (
+	"context"
+)
+
+const createIdempotencyKey = `-- name: CreateIdempotencyKey :execrows
+INSERT INTO idempotency_keys (idempotency_key, request_hash, response_status, book_id, expires_at)
+    VALUES ($1, $2, $3, $4, now() + $5::float8 * interval '1 second')
+    ON CONFLICT (idempotency_key) DO UPDATE
+        SET request_hash = EXCLUDED.request_hash,
+            response_status = EXCLUDED.response_status,
+            book_id = EXCLUDED.book_id,
+            created_at = now(),
+            expires_at = EXCLUDED.expires_at
+        WHERE idempotency_keys.expires_at <= now()
+`
+
+type CreateIdempotencyKeyParams struct {
+	IdempotencyKey string
+	RequestHash    string
+	ResponseStatus int32
+	BookID         int32
+	TtlSeconds     float64
+}
+
+func (q *Queries) CreateIdempotencyKey(ctx context.Context, arg CreateIdempotencyKeyParams) (int64, error) {
+	result, err := q.db.Exec(ctx, createIdempotencyKey,
+		arg.IdempotencyKey,
+		arg.RequestHash,
+		arg.ResponseStatus,
+		arg.BookID,
+		arg.TtlSeconds,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteExpiredIdempotencyKeys = `-- name: DeleteExpiredIdempotencyKeys :execrows
+DELETE FROM idempotency_keys
+    WHERE expires_at <= now()
+`
+
+func (q *Queries) DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteExpiredIdempotencyKeys)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getIdempotencyKey = `-- name: GetIdempotencyKey :one
+SELECT idempotency_key, request_hash, response_status, book_id, created_at, expires_at
+    FROM idempotency_keys
+    WHERE idempotency_key = $1
+        AND expires_at > now()
+`
+
+func (q *Queries) GetIdempotencyKey(ctx context.Context, idempotencyKey string) (IdempotencyKey, error) {
+	row := q.db.QueryRow(ctx, getIdempotencyKey, idempotencyKey)
+	var i IdempotencyKey
+	err := row.Scan(
+		&i.IdempotencyKey,
+		&i.RequestHash,
+		&i.ResponseStatus,
+		&i.BookID,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
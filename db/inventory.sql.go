@@ -0,0 +1,164 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: inventory.sql
+
+package db
+
+import (
+	"context"
+)
+
+const createStockMovement = `-- name: CreateStockMovement :one
+INSERT INTO stock_movements (book_id, location, quantity_change, quantity_after, reason)
+    VALUES ($1, $2, $3, $4, $5)
+    RETURNING id, book_id, location, quantity_change, quantity_after, reason, created_at
+`
+
+type CreateStockMovementParams struct {
+	BookID         int32
+	Location       string
+	QuantityChange int32
+	QuantityAfter  int32
+	Reason         string
+}
+
+func (q *Queries) CreateStockMovement(ctx context.Context, arg CreateStockMovementParams) (StockMovement, error) {
+	row := q.db.QueryRow(ctx, createStockMovement,
+		arg.BookID,
+		arg.Location,
+		arg.QuantityChange,
+		arg.QuantityAfter,
+		arg.Reason,
+	)
+	var i StockMovement
+	err := row.Scan(
+		&i.ID,
+		&i.BookID,
+		&i.Location,
+		&i.QuantityChange,
+		&i.QuantityAfter,
+		&i.Reason,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const ensureInventory = `-- name: EnsureInventory :exec
+INSERT INTO inventories (book_id, location, quantity)
+    VALUES ($1, $2, 0)
+    ON CONFLICT (book_id, location) DO NOTHING
+`
+
+type EnsureInventoryParams struct {
+	BookID   int32
+	Location string
+}
+
+func (q *Queries) EnsureInventory(ctx context.Context, arg EnsureInventoryParams) error {
+	_, err := q.db.Exec(ctx, ensureInventory, arg.BookID, arg.Location)
+	return err
+}
+
+const getInventoryForUpdate = `-- name: GetInventoryForUpdate :one
+SELECT book_id, location, quantity
+    FROM inventories
+    WHERE book_id = $1
+    AND location = $2
+    FOR UPDATE
+`
+
+type GetInventoryForUpdateParams struct {
+	BookID   int32
+	Location string
+}
+
+func (q *Queries) GetInventoryForUpdate(ctx context.Context, arg GetInventoryForUpdateParams) (Inventory, error) {
+	row := q.db.QueryRow(ctx, getInventoryForUpdate, arg.BookID, arg.Location)
+	var i Inventory
+	err := row.Scan(&i.BookID, &i.Location, &i.Quantity)
+	return i, err
+}
+
+const listInventoriesByBookID = `-- name: ListInventoriesByBookID :many
+SELECT book_id, location, quantity
+    FROM inventories
+    WHERE book_id = $1
+    ORDER BY location
+`
+
+func (q *Queries) ListInventoriesByBookID(ctx context.Context, bookID int32) ([]Inventory, error) {
+	rows, err := q.db.Query(ctx, listInventoriesByBookID, bookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Inventory
+	for rows.Next() {
+		var i Inventory
+		if err := rows.Scan(&i.BookID, &i.Location, &i.Quantity); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStockMovementsByBookID = `-- name: ListStockMovementsByBookID :many
+SELECT id, book_id, location, quantity_change, quantity_after, reason, created_at
+    FROM stock_movements
+    WHERE book_id = $1
+    ORDER BY id
+`
+
+func (q *Queries) ListStockMovementsByBookID(ctx context.Context, bookID int32) ([]StockMovement, error) {
+	rows, err := q.db.Query(ctx, listStockMovementsByBookID, bookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []StockMovement
+	for rows.Next() {
+		var i StockMovement
+		if err := rows.Scan(
+			&i.ID,
+			&i.BookID,
+			&i.Location,
+			&i.QuantityChange,
+			&i.QuantityAfter,
+			&i.Reason,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateInventoryQuantity = `-- name: UpdateInventoryQuantity :one
+UPDATE inventories
+    SET quantity = $3
+    WHERE book_id = $1
+    AND location = $2
+    RETURNING book_id, location, quantity
+`
+
+type UpdateInventoryQuantityParams struct {
+	BookID   int32
+	Location string
+	Quantity int32
+}
+
+func (q *Queries) UpdateInventoryQuantity(ctx context.Context, arg UpdateInventoryQuantityParams) (Inventory, error) {
+	row := q.db.QueryRow(ctx, updateInventoryQuantity, arg.BookID, arg.Location, arg.Quantity)
+	var i Inventory
+	err := row.Scan(&i.BookID, &i.Location, &i.Quantity)
+	return i, err
+}
@@ -0,0 +1,278 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: job.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const cancelJob = `-- name: CancelJob :one
+UPDATE jobs
+    SET cancel_requested = true,
+        status = CASE WHEN status = 'queued' THEN 'canceled' ELSE status END,
+        finished_at = CASE WHEN status = 'queued' THEN now() ELSE finished_at END
+    WHERE id = $1
+        AND status IN ('queued', 'running')
+    RETURNING id, kind, params, status, progress_done, progress_total, cancel_requested, attempts, lease_until,
+        result, artifact_key, artifact_type, last_error, created_at, started_at, finished_at, committed_result
+`
+
+func (q *Queries) CancelJob(ctx context.Context, id int64) (Job, error) {
+	row := q.db.QueryRow(ctx, cancelJob, id)
+	var i Job
+	err := row.Scan(
+		&i.ID,
+		&i.Kind,
+		&i.Params,
+		&i.Status,
+		&i.ProgressDone,
+		&i.ProgressTotal,
+		&i.CancelRequested,
+		&i.Attempts,
+		&i.LeaseUntil,
+		&i.Result,
+		&i.ArtifactKey,
+		&i.ArtifactType,
+		&i.LastError,
+		&i.CreatedAt,
+		&i.StartedAt,
+		&i.FinishedAt,
+		&i.CommittedResult,
+	)
+	return i, err
+}
+
+const claimJob = `-- name: ClaimJob :one
+UPDATE jobs
+    SET status = 'running',
+        attempts = attempts + 1,
+        lease_until = now() + $1::interval,
+        started_at = COALESCE(started_at, now())
+    WHERE id = (
+        SELECT claimable.id
+            FROM jobs AS claimable
+            WHERE claimable.kind = ANY($2::varchar[])
+                AND (claimable.status = 'queued'
+                    OR (claimable.status = 'running' AND claimable.lease_until < now()))
+            ORDER BY claimable.id
+            LIMIT 1
+            FOR UPDATE SKIP LOCKED
+    )
+    RETURNING id, kind, params, attempts, cancel_requested, committed_result
+`
+
+type ClaimJobParams struct {
+	Lease pgtype.Interval
+	Kinds []string
+}
+
+type ClaimJobRow struct {
+	ID              int64
+	Kind            string
+	Params          []byte
+	Attempts        int32
+	CancelRequested bool
+	CommittedResult []byte
+}
+
+func (q *Queries) ClaimJob(ctx context.Context, arg ClaimJobParams) (ClaimJobRow, error) {
+	row := q.db.QueryRow(ctx, claimJob, arg.Lease, arg.Kinds)
+	var i ClaimJobRow
+	err := row.Scan(
+		&i.ID,
+		&i.Kind,
+		&i.Params,
+		&i.Attempts,
+		&i.CancelRequested,
+		&i.CommittedResult,
+	)
+	return i, err
+}
+
+const commitJobResult = `-- name: CommitJobResult :execrows
+UPDATE jobs
+    SET committed_result = $1
+    WHERE id = $2
+        AND attempts = $3
+        AND status = 'running'
+        AND committed_result IS NULL
+`
+
+type CommitJobResultParams struct {
+	CommittedResult []byte
+	ID              int64
+	Attempts        int32
+}
+
+func (q *Queries) CommitJobResult(ctx context.Context, arg CommitJobResultParams) (int64, error) {
+	result, err := q.db.Exec(ctx, commitJobResult, arg.CommittedResult, arg.ID, arg.Attempts)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const createJob = `-- name: CreateJob :one
+INSERT INTO jobs (kind, params)
+    VALUES ($1, $2)
+    RETURNING id, kind, params, status, progress_done, progress_total, cancel_requested, attempts, lease_until,
+        result, artifact_key, artifact_type, last_error, created_at, started_at, finished_at, committed_result
+`
+
+type CreateJobParams struct {
+	Kind   string
+	Params []byte
+}
+
+func (q *Queries) CreateJob(ctx context.Context, arg CreateJobParams) (Job, error) {
+	row := q.db.QueryRow(ctx, createJob, arg.Kind, arg.Params)
+	var i Job
+	err := row.Scan(
+		&i.ID,
+		&i.Kind,
+		&i.Params,
+		&i.Status,
+		&i.ProgressDone,
+		&i.ProgressTotal,
+		&i.CancelRequested,
+		&i.Attempts,
+		&i.LeaseUntil,
+		&i.Result,
+		&i.ArtifactKey,
+		&i.ArtifactType,
+		&i.LastError,
+		&i.CreatedAt,
+		&i.StartedAt,
+		&i.FinishedAt,
+		&i.CommittedResult,
+	)
+	return i, err
+}
+
+const extendJobLease = `-- name: ExtendJobLease :one
+UPDATE jobs
+    SET lease_until = now() + $1::interval
+    WHERE id = $2
+        AND attempts = $3
+        AND status = 'running'
+    RETURNING cancel_requested
+`
+
+type ExtendJobLeaseParams struct {
+	Lease    pgtype.Interval
+	ID       int64
+	Attempts int32
+}
+
+func (q *Queries) ExtendJobLease(ctx context.Context, arg ExtendJobLeaseParams) (bool, error) {
+	row := q.db.QueryRow(ctx, extendJobLease, arg.Lease, arg.ID, arg.Attempts)
+	var cancel_requested bool
+	err := row.Scan(&cancel_requested)
+	return cancel_requested, err
+}
+
+const finishJob = `-- name: FinishJob :execrows
+UPDATE jobs
+    SET status = $1,
+        result = $2,
+        artifact_key = $3,
+        artifact_type = $4,
+        last_error = $5,
+        lease_until = NULL,
+        finished_at = now()
+    WHERE id = $6
+        AND attempts = $7
+        AND status = 'running'
+`
+
+type FinishJobParams struct {
+	Status       string
+	Result       []byte
+	ArtifactKey  pgtype.Text
+	ArtifactType pgtype.Text
+	LastError    pgtype.Text
+	ID           int64
+	Attempts     int32
+}
+
+func (q *Queries) FinishJob(ctx context.Context, arg FinishJobParams) (int64, error) {
+	result, err := q.db.Exec(ctx, finishJob,
+		arg.Status,
+		arg.Result,
+		arg.ArtifactKey,
+		arg.ArtifactType,
+		arg.LastError,
+		arg.ID,
+		arg.Attempts,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getJobByID = `-- name: GetJobByID :one
+SELECT id, kind, params, status, progress_done, progress_total, cancel_requested, attempts, lease_until,
+        result, artifact_key, artifact_type, last_error, created_at, started_at, finished_at, committed_result
+    FROM jobs
+    WHERE id = $1
+`
+
+func (q *Queries) GetJobByID(ctx context.Context, id int64) (Job, error) {
+	row := q.db.QueryRow(ctx, getJobByID, id)
+	var i Job
+	err := row.Scan(
+		&i.ID,
+		&i.Kind,
+		&i.Params,
+		&i.Status,
+		&i.ProgressDone,
+		&i.ProgressTotal,
+		&i.CancelRequested,
+		&i.Attempts,
+		&i.LeaseUntil,
+		&i.Result,
+		&i.ArtifactKey,
+		&i.ArtifactType,
+		&i.LastError,
+		&i.CreatedAt,
+		&i.StartedAt,
+		&i.FinishedAt,
+		&i.CommittedResult,
+	)
+	return i, err
+}
+
+const updateJobProgress = `-- name: UpdateJobProgress :one
+UPDATE jobs
+    SET progress_done = $1,
+        progress_total = $2
+    WHERE id = $3
+        AND attempts = $4
+        AND status = 'running'
+    RETURNING cancel_requested
+`
+
+type UpdateJobProgressParams struct {
+	ProgressDone  int32
+	ProgressTotal pgtype.Int4
+	ID            int64
+	Attempts      int32
+}
+
+func (q *Queries) UpdateJobProgress(ctx context.Context, arg UpdateJobProgressParams) (bool, error) {
+	row := q.db.QueryRow(ctx, updateJobProgress,
+		arg.ProgressDone,
+		arg.ProgressTotal,
+		arg.ID,
+		arg.Attempts,
+	)
+	var cancel_requested bool
+	err := row.Scan(&cancel_requested)
+	return cancel_requested, err
+}
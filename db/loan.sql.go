@@ -0,0 +1,174 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: loan.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const countActiveLoansByMemberID = `-- name: CountActiveLoansByMemberID :one
+SELECT COUNT(*)::integer
+    FROM loans
+    WHERE member_id = $1
+    AND returned_at IS NULL
+`
+
+func (q *Queries) CountActiveLoansByMemberID(ctx context.Context, memberID int32) (int32, error) {
+	row := q.db.QueryRow(ctx, countActiveLoansByMemberID, memberID)
+	var column_1 int32
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const createLoan = `-- name: CreateLoan :one
+INSERT INTO loans (book_id, member_id, loaned_at, due_at)
+    VALUES ($1, $2, $3, $4)
+    RETURNING id, book_id, member_id, loaned_at, due_at, returned_at
+`
+
+type CreateLoanParams struct {
+	BookID   int32
+	MemberID int32
+	LoanedAt pgtype.Timestamptz
+	DueAt    pgtype.Timestamptz
+}
+
+func (q *Queries) CreateLoan(ctx context.Context, arg CreateLoanParams) (Loan, error) {
+	row := q.db.QueryRow(ctx, createLoan,
+		arg.BookID,
+		arg.MemberID,
+		arg.LoanedAt,
+		arg.DueAt,
+	)
+	var i Loan
+	err := row.Scan(
+		&i.ID,
+		&i.BookID,
+		&i.MemberID,
+		&i.LoanedAt,
+		&i.DueAt,
+		&i.ReturnedAt,
+	)
+	return i, err
+}
+
+const getBookAvailability = `-- name: GetBookAvailability :one
+SELECT
+    COALESCE((
+        SELECT SUM(quantity)
+            FROM inventories
+            WHERE inventories.book_id = $1
+    ), 0)::integer AS total,
+    (
+        SELECT COUNT(*)
+            FROM loans
+            WHERE loans.book_id = $1
+            AND loans.returned_at IS NULL
+    )::integer AS on_loan,
+    (
+        SELECT COUNT(*)
+            FROM reservations
+            WHERE reservations.book_id = $1
+            AND reservations.status = 'held'
+    )::integer AS on_hold
+`
+
+type GetBookAvailabilityRow struct {
+	Total  int32
+	OnLoan int32
+	OnHold int32
+}
+
+func (q *Queries) GetBookAvailability(ctx context.Context, bookID int32) (GetBookAvailabilityRow, error) {
+	row := q.db.QueryRow(ctx, getBookAvailability, bookID)
+	var i GetBookAvailabilityRow
+	err := row.Scan(&i.Total, &i.OnLoan, &i.OnHold)
+	return i, err
+}
+
+const getLoanByIDForUpdate = `-- name: GetLoanByIDForUpdate :one
+SELECT id, book_id, member_id, loaned_at, due_at, returned_at
+    FROM loans
+    WHERE id = $1
+    FOR UPDATE
+`
+
+func (q *Queries) GetLoanByIDForUpdate(ctx context.Context, id int32) (Loan, error) {
+	row := q.db.QueryRow(ctx, getLoanByIDForUpdate, id)
+	var i Loan
+	err := row.Scan(
+		&i.ID,
+		&i.BookID,
+		&i.MemberID,
+		&i.LoanedAt,
+		&i.DueAt,
+		&i.ReturnedAt,
+	)
+	return i, err
+}
+
+const listOverdueLoans = `-- name: ListOverdueLoans :many
+SELECT id, book_id, member_id, loaned_at, due_at, returned_at
+    FROM loans
+    WHERE returned_at IS NULL
+    AND due_at < $1
+    ORDER BY due_at
+`
+
+func (q *Queries) ListOverdueLoans(ctx context.Context, dueAt pgtype.Timestamptz) ([]Loan, error) {
+	rows, err := q.db.Query(ctx, listOverdueLoans, dueAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Loan
+	for rows.Next() {
+		var i Loan
+		if err := rows.Scan(
+			&i.ID,
+			&i.BookID,
+			&i.MemberID,
+			&i.LoanedAt,
+			&i.DueAt,
+			&i.ReturnedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const returnLoan = `-- name: ReturnLoan :one
+UPDATE loans
+    SET returned_at = $2
+    WHERE id = $1
+    RETURNING id, book_id, member_id, loaned_at, due_at, returned_at
+`
+
+type ReturnLoanParams struct {
+	ID         int32
+	ReturnedAt pgtype.Timestamptz
+}
+
+func (q *Queries) ReturnLoan(ctx context.Context, arg ReturnLoanParams) (Loan, error) {
+	row := q.db.QueryRow(ctx, returnLoan, arg.ID, arg.ReturnedAt)
+	var i Loan
+	err := row.Scan(
+		&i.ID,
+		&i.BookID,
+		&i.MemberID,
+		&i.LoanedAt,
+		&i.DueAt,
+		&i.ReturnedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,70 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: member.sql
+
+package db
+
+import (
+	"context"
+)
+
+const createMember = `-- name: CreateMember :one
+INSERT INTO members (name, email)
+    VALUES ($1, $2)
+    RETURNING id, name, email, created_at
+`
+
+type CreateMemberParams struct {
+	Name  string
+	Email string
+}
+
+func (q *Queries) CreateMember(ctx context.Context, arg CreateMemberParams) (Member, error) {
+	row := q.db.QueryRow(ctx, createMember, arg.Name, arg.Email)
+	var i Member
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getMemberByID = `-- name: GetMemberByID :one
+SELECT id, name, email, created_at
+    FROM members
+    WHERE id = $1
+`
+
+func (q *Queries) GetMemberByID(ctx context.Context, id int32) (Member, error) {
+	row := q.db.QueryRow(ctx, getMemberByID, id)
+	var i Member
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getMemberByIDForUpdate = `-- name: GetMemberByIDForUpdate :one
+SELECT id, name, email, created_at
+    FROM members
+    WHERE id = $1
+    FOR UPDATE
+`
+
+func (q *Queries) GetMemberByIDForUpdate(ctx context.Context, id int32) (Member, error) {
+	row := q.db.QueryRow(ctx, getMemberByIDForUpdate, id)
+	var i Member
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.CreatedAt,
+	)
+	return i, err
+}
@@ -9,14 +9,245 @@ import (
 )
 
 type Book struct {
+	ID              int32
+	Title           pgtype.Text
+	Author          pgtype.Text
+	Publisher       pgtype.Text
+	Price           pgtype.Int4
+	Subtitle        pgtype.Text
+	Edition         pgtype.Text
+	PublicationDate pgtype.Date
+	Language        pgtype.Text
+	PageCount       pgtype.Int4
+	Format          pgtype.Text
+	Description     pgtype.Text
+	Series          pgtype.Text
+	SeriesVolume    pgtype.Int4
+	WorkID          pgtype.Int4
+	Isbn            pgtype.Text
+	UpdatedAt       pgtype.Timestamptz
+}
+
+type BookAuditLog struct {
+	ID        int64
+	BookID    int32
+	Action    string
+	Detail    []byte
+	CreatedAt pgtype.Timestamptz
+}
+
+type BookCategory struct {
+	BookID     int32
+	CategoryID int32
+}
+
+type BookCover struct {
+	BookID      int32
+	ContentType string
+	Width       int32
+	Height      int32
+	ByteSize    int32
+	Checksum    string
+	UpdatedAt   pgtype.Timestamptz
+}
+
+type BookPrice struct {
+	ID            int32
+	BookID        int32
+	Currency      string
+	Amount        int64
+	EffectiveFrom pgtype.Timestamptz
+	EffectiveTo   pgtype.Timestamptz
+}
+
+type BookRating struct {
+	BookID      int32
+	ReviewCount int32
+	RatingSum   int32
+}
+
+type BookRedirect struct {
+	SourceID  int32
+	TargetID  int32
+	CreatedAt pgtype.Timestamptz
+}
+
+type BookTag struct {
+	BookID    int32
+	TagID     int32
+	CreatedAt pgtype.Timestamptz
+}
+
+type CatalogVersion struct {
+	ID        bool
+	Version   int64
+	UpdatedAt pgtype.Timestamptz
+}
+
+type Category struct {
 	ID        int32
-	Title     pgtype.Text
-	Author    pgtype.Text
-	Publisher pgtype.Text
-	Price     pgtype.Int4
+	Name      string
+	ParentID  pgtype.Int4
+	CreatedAt pgtype.Timestamptz
+}
+
+type ExchangeRate struct {
+	ID            int32
+	BaseCurrency  string
+	QuoteCurrency string
+	Rate          pgtype.Numeric
+	EffectiveDate pgtype.Date
+	ImportedAt    pgtype.Timestamptz
+}
+
+type IdempotencyKey struct {
+	IdempotencyKey string
+	RequestHash    string
+	ResponseStatus int32
+	BookID         int32
+	CreatedAt      pgtype.Timestamptz
+	ExpiresAt      pgtype.Timestamptz
+}
+
+type Inventory struct {
+	BookID   int32
+	Location string
+	Quantity int32
+}
+
+type Job struct {
+	ID              int64
+	Kind            string
+	Params          []byte
+	Status          string
+	ProgressDone    int32
+	ProgressTotal   pgtype.Int4
+	CancelRequested bool
+	Attempts        int32
+	LeaseUntil      pgtype.Timestamptz
+	Result          []byte
+	ArtifactKey     pgtype.Text
+	ArtifactType    pgtype.Text
+	LastError       pgtype.Text
+	CreatedAt       pgtype.Timestamptz
+	StartedAt       pgtype.Timestamptz
+	FinishedAt      pgtype.Timestamptz
+	CommittedResult []byte
+}
+
+type Loan struct {
+	ID         int32
+	BookID     int32
+	MemberID   int32
+	LoanedAt   pgtype.Timestamptz
+	DueAt      pgtype.Timestamptz
+	ReturnedAt pgtype.Timestamptz
+}
+
+type Member struct {
+	ID        int32
+	Name      string
+	Email     string
+	CreatedAt pgtype.Timestamptz
+}
+
+type OutboxEvent struct {
+	ID            int64
+	EventType     string
+	BookID        int32
+	Payload       []byte
+	OccurredAt    pgtype.Timestamptz
+	Attempts      int32
+	NextAttemptAt pgtype.Timestamptz
+	DeliveredAt   pgtype.Timestamptz
+	LastError     pgtype.Text
+	TxID          int64
+}
+
+type Promotion struct {
+	ID            int32
+	Name          string
+	DiscountType  string
+	DiscountValue int64
+	Currency      pgtype.Text
+	TargetType    string
+	TargetBookID  pgtype.Int4
+	TargetName    pgtype.Text
+	StartsAt      pgtype.Timestamptz
+	EndsAt        pgtype.Timestamptz
+	Priority      int32
+	Stackable     bool
+	CreatedAt     pgtype.Timestamptz
+}
+
+type Reservation struct {
+	ID            int32
+	BookID        int32
+	MemberID      int32
+	Status        string
+	CreatedAt     pgtype.Timestamptz
+	HeldAt        pgtype.Timestamptz
+	HoldExpiresAt pgtype.Timestamptz
+}
+
+type Review struct {
+	ID        int32
+	BookID    int32
+	MemberID  int32
+	Rating    int16
+	Body      string
+	CreatedAt pgtype.Timestamptz
+	UpdatedAt pgtype.Timestamptz
 }
 
 type SchemaMigration struct {
 	Version int64
 	Dirty   bool
 }
+
+type StockMovement struct {
+	ID             int32
+	BookID         int32
+	Location       string
+	QuantityChange int32
+	QuantityAfter  int32
+	Reason         string
+	CreatedAt      pgtype.Timestamptz
+}
+
+type Tag struct {
+	ID        int32
+	Name      string
+	CreatedAt pgtype.Timestamptz
+}
+
+type WebhookDelivery struct {
+	ID             int64
+	SubscriptionID int32
+	EventID        int64
+	EventType      string
+	Payload        []byte
+	Status         string
+	Attempts       int32
+	NextAttemptAt  pgtype.Timestamptz
+	ResponseStatus pgtype.Int4
+	LastError      pgtype.Text
+	CreatedAt      pgtype.Timestamptz
+	DeliveredAt    pgtype.Timestamptz
+}
+
+type WebhookSubscription struct {
+	ID         int32
+	Url        string
+	EventTypes []string
+	Secret     string
+	Active     bool
+	CreatedAt  pgtype.Timestamptz
+}
+
+type Work struct {
+	ID        int32
+	Title     string
+	Author    pgtype.Text
+	CreatedAt pgtype.Timestamptz
+}
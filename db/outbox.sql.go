@@ -0,0 +1,176 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: outbox.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const claimOutboxEvents = `-- name: ClaimOutboxEvents :many
+UPDATE outbox_events
+    SET attempts = attempts + 1,
+        next_attempt_at = $1
+    WHERE id IN (
+        SELECT id
+            FROM outbox_events
+            WHERE delivered_at IS NULL
+                AND next_attempt_at <= now()
+            ORDER BY id
+            LIMIT $2
+            FOR UPDATE SKIP LOCKED
+    )
+    RETURNING id, event_type, book_id, payload, occurred_at, attempts, next_attempt_at, delivered_at, last_error, tx_id
+`
+
+type ClaimOutboxEventsParams struct {
+	LeaseUntil pgtype.Timestamptz
+	MaxEvents  int32
+}
+
+func (q *Queries) ClaimOutboxEvents(ctx context.Context, arg ClaimOutboxEventsParams) ([]OutboxEvent, error) {
+	rows, err := q.db.Query(ctx, claimOutboxEvents, arg.LeaseUntil, arg.MaxEvents)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []OutboxEvent
+	for rows.Next() {
+		var i OutboxEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.EventType,
+			&i.BookID,
+			&i.Payload,
+			&i.OccurredAt,
+			&i.Attempts,
+			&i.NextAttemptAt,
+			&i.DeliveredAt,
+			&i.LastError,
+			&i.TxID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const createOutboxEvent = `-- name: CreateOutboxEvent :exec
+INSERT INTO outbox_events (event_type, book_id, payload)
+    VALUES ($1, $2, $3)
+`
+
+type CreateOutboxEventParams struct {
+	EventType string
+	BookID    int32
+	Payload   []byte
+}
+
+func (q *Queries) CreateOutboxEvent(ctx context.Context, arg CreateOutboxEventParams) error {
+	_, err := q.db.Exec(ctx, createOutboxEvent, arg.EventType, arg.BookID, arg.Payload)
+	return err
+}
+
+const getLatestOutboxEventCursor = `-- name: GetLatestOutboxEventCursor :one
+SELECT tx_id, id
+    FROM outbox_events
+    WHERE tx_id < pg_snapshot_xmin(pg_current_snapshot())::text::bigint
+    ORDER BY tx_id DESC, id DESC
+    LIMIT 1
+`
+
+type GetLatestOutboxEventCursorRow struct {
+	TxID int64
+	ID   int64
+}
+
+func (q *Queries) GetLatestOutboxEventCursor(ctx context.Context) (GetLatestOutboxEventCursorRow, error) {
+	row := q.db.QueryRow(ctx, getLatestOutboxEventCursor)
+	var i GetLatestOutboxEventCursorRow
+	err := row.Scan(&i.TxID, &i.ID)
+	return i, err
+}
+
+const listOutboxEventsAfter = `-- name: ListOutboxEventsAfter :many
+SELECT id, event_type, book_id, payload, occurred_at, attempts, next_attempt_at, delivered_at, last_error, tx_id
+    FROM outbox_events
+    WHERE (tx_id, id) > ($1::bigint, $2::bigint)
+        AND tx_id < pg_snapshot_xmin(pg_current_snapshot())::text::bigint
+    ORDER BY tx_id, id
+    LIMIT $3
+`
+
+type ListOutboxEventsAfterParams struct {
+	AfterTxID int64
+	AfterID   int64
+	MaxEvents int32
+}
+
+func (q *Queries) ListOutboxEventsAfter(ctx context.Context, arg ListOutboxEventsAfterParams) ([]OutboxEvent, error) {
+	rows, err := q.db.Query(ctx, listOutboxEventsAfter, arg.AfterTxID, arg.AfterID, arg.MaxEvents)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []OutboxEvent
+	for rows.Next() {
+		var i OutboxEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.EventType,
+			&i.BookID,
+			&i.Payload,
+			&i.OccurredAt,
+			&i.Attempts,
+			&i.NextAttemptAt,
+			&i.DeliveredAt,
+			&i.LastError,
+			&i.TxID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markOutboxEventDelivered = `-- name: MarkOutboxEventDelivered :exec
+UPDATE outbox_events
+    SET delivered_at = now(),
+        last_error = NULL
+    WHERE id = $1
+`
+
+func (q *Queries) MarkOutboxEventDelivered(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, markOutboxEventDelivered, id)
+	return err
+}
+
+const rescheduleOutboxEvent = `-- name: RescheduleOutboxEvent :exec
+UPDATE outbox_events
+    SET next_attempt_at = $2,
+        last_error = $3
+    WHERE id = $1
+`
+
+type RescheduleOutboxEventParams struct {
+	ID            int64
+	NextAttemptAt pgtype.Timestamptz
+	LastError     pgtype.Text
+}
+
+func (q *Queries) RescheduleOutboxEvent(ctx context.Context, arg RescheduleOutboxEventParams) error {
+	_, err := q.db.Exec(ctx, rescheduleOutboxEvent, arg.ID, arg.NextAttemptAt, arg.LastError)
+	return err
+}
@@ -0,0 +1,204 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: promotion.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createPromotion = `-- name: CreatePromotion :one
+INSERT INTO promotions (name, discount_type, discount_value, currency, target_type, target_book_id, target_name, starts_at, ends_at, priority, stackable)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+    RETURNING id, name, discount_type, discount_value, currency, target_type, target_book_id, target_name, starts_at, ends_at, priority, stackable, created_at
+`
+
+type CreatePromotionParams struct {
+	Name          string
+	DiscountType  string
+	DiscountValue int64
+	Currency      pgtype.Text
+	TargetType    string
+	TargetBookID  pgtype.Int4
+	TargetName    pgtype.Text
+	StartsAt      pgtype.Timestamptz
+	EndsAt        pgtype.Timestamptz
+	Priority      int32
+	Stackable     bool
+}
+
+func (q *Queries) CreatePromotion(ctx context.Context, arg CreatePromotionParams) (Promotion, error) {
+	row := q.db.QueryRow(ctx, createPromotion,
+		arg.Name,
+		arg.DiscountType,
+		arg.DiscountValue,
+		arg.Currency,
+		arg.TargetType,
+		arg.TargetBookID,
+		arg.TargetName,
+		arg.StartsAt,
+		arg.EndsAt,
+		arg.Priority,
+		arg.Stackable,
+	)
+	var i Promotion
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.DiscountType,
+		&i.DiscountValue,
+		&i.Currency,
+		&i.TargetType,
+		&i.TargetBookID,
+		&i.TargetName,
+		&i.StartsAt,
+		&i.EndsAt,
+		&i.Priority,
+		&i.Stackable,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deletePromotionByID = `-- name: DeletePromotionByID :execrows
+DELETE
+    FROM promotions
+    WHERE id = $1
+`
+
+func (q *Queries) DeletePromotionByID(ctx context.Context, id int32) (int64, error) {
+	result, err := q.db.Exec(ctx, deletePromotionByID, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getPromotionByID = `-- name: GetPromotionByID :one
+SELECT id, name, discount_type, discount_value, currency, target_type, target_book_id, target_name, starts_at, ends_at, priority, stackable, created_at
+    FROM promotions
+    WHERE id = $1
+`
+
+func (q *Queries) GetPromotionByID(ctx context.Context, id int32) (Promotion, error) {
+	row := q.db.QueryRow(ctx, getPromotionByID, id)
+	var i Promotion
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.DiscountType,
+		&i.DiscountValue,
+		&i.Currency,
+		&i.TargetType,
+		&i.TargetBookID,
+		&i.TargetName,
+		&i.StartsAt,
+		&i.EndsAt,
+		&i.Priority,
+		&i.Stackable,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listActivePromotionsByBookIDs = `-- name: ListActivePromotionsByBookIDs :many
+SELECT books.id AS book_id, promotions.id, promotions.name, promotions.discount_type, promotions.discount_value, promotions.currency, promotions.target_type, promotions.target_book_id, promotions.target_name, promotions.starts_at, promotions.ends_at, promotions.priority, promotions.stackable, promotions.created_at
+    FROM books
+    INNER JOIN promotions
+        ON (promotions.target_type = 'book' AND promotions.target_book_id = books.id)
+        OR (promotions.target_type = 'author' AND promotions.target_name = books.author)
+        OR (promotions.target_type = 'publisher' AND promotions.target_name = books.publisher)
+    WHERE books.id = ANY($1::integer[])
+    AND promotions.starts_at <= $2
+    AND (promotions.ends_at IS NULL OR promotions.ends_at > $2)
+    ORDER BY books.id, promotions.priority DESC, promotions.id
+`
+
+type ListActivePromotionsByBookIDsParams struct {
+	BookIds []int32
+	At      pgtype.Timestamptz
+}
+
+type ListActivePromotionsByBookIDsRow struct {
+	BookID    int32
+	Promotion Promotion
+}
+
+func (q *Queries) ListActivePromotionsByBookIDs(ctx context.Context, arg ListActivePromotionsByBookIDsParams) ([]ListActivePromotionsByBookIDsRow, error) {
+	rows, err := q.db.Query(ctx, listActivePromotionsByBookIDs, arg.BookIds, arg.At)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListActivePromotionsByBookIDsRow
+	for rows.Next() {
+		var i ListActivePromotionsByBookIDsRow
+		if err := rows.Scan(
+			&i.BookID,
+			&i.Promotion.ID,
+			&i.Promotion.Name,
+			&i.Promotion.DiscountType,
+			&i.Promotion.DiscountValue,
+			&i.Promotion.Currency,
+			&i.Promotion.TargetType,
+			&i.Promotion.TargetBookID,
+			&i.Promotion.TargetName,
+			&i.Promotion.StartsAt,
+			&i.Promotion.EndsAt,
+			&i.Promotion.Priority,
+			&i.Promotion.Stackable,
+			&i.Promotion.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPromotions = `-- name: ListPromotions :many
+SELECT id, name, discount_type, discount_value, currency, target_type, target_book_id, target_name, starts_at, ends_at, priority, stackable, created_at
+    FROM promotions
+    ORDER BY id
+`
+
+func (q *Queries) ListPromotions(ctx context.Context) ([]Promotion, error) {
+	rows, err := q.db.Query(ctx, listPromotions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Promotion
+	for rows.Next() {
+		var i Promotion
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.DiscountType,
+			&i.DiscountValue,
+			&i.Currency,
+			&i.TargetType,
+			&i.TargetBookID,
+			&i.TargetName,
+			&i.StartsAt,
+			&i.EndsAt,
+			&i.Priority,
+			&i.Stackable,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
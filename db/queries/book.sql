@@ -1,17 +1,23 @@
 -- name: CreateBook :one
-INSERT INTO books (id, title, author, publisher, price)
-    VALUES (nextval('BOOK_ID_SEQ'), $1, $2, $3, $4)
-    RETURNING id, title, author, publisher, price
+INSERT INTO books (
+        id, title, author, publisher, price,
+        subtitle, edition, publication_date, language, page_count, format, description, series, series_volume, isbn
+    )
+    VALUES (nextval('BOOK_ID_SEQ'), $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+    RETURNING id, title, author, publisher, price,
+        subtitle, edition, publication_date, language, page_count, format, description, series, series_volume, work_id, isbn, updated_at
 ;
 
 -- name: GetBookByID :one
-SELECT id, title, author, publisher, price
+SELECT id, title, author, publisher, price,
+        subtitle, edition, publication_date, language, page_count, format, description, series, series_volume, work_id, isbn, updated_at
     FROM books
     WHERE id = $1
 ;
 
 -- name: ListBooks :many
-SELECT id, title, author, publisher, price
+SELECT id, title, author, publisher, price,
+        subtitle, edition, publication_date, language, page_count, format, description, series, series_volume, work_id, isbn, updated_at
     FROM books
 ;
 
@@ -20,3 +26,182 @@ DELETE
     FROM books
     WHERE id = $1
 ; 
+
+-- name: SearchBooks :many
+SELECT id, title, author, publisher, price,
+        subtitle, edition, publication_date, language, page_count, format, description, series, series_volume, work_id, isbn, updated_at
+    FROM books
+    WHERE (sqlc.narg('title')::text IS NULL OR title ILIKE '%' || sqlc.narg('title')::text || '%')
+    AND (sqlc.narg('author')::text IS NULL OR author = sqlc.narg('author')::text)
+    AND (sqlc.narg('publisher')::text IS NULL OR publisher = sqlc.narg('publisher')::text)
+    AND (sqlc.narg('min_price')::integer IS NULL OR price >= sqlc.narg('min_price')::integer)
+    AND (sqlc.narg('max_price')::integer IS NULL OR price <= sqlc.narg('max_price')::integer)
+    ORDER BY id
+    LIMIT sqlc.arg('limit')
+    OFFSET sqlc.arg('offset')
+;
+
+-- name: ListBooksByAuthors :many
+SELECT id, title, author, publisher, price,
+        subtitle, edition, publication_date, language, page_count, format, description, series, series_volume, work_id, isbn, updated_at
+    FROM books
+    WHERE author = ANY(sqlc.arg('authors')::text[])
+    ORDER BY id
+;
+
+-- name: ListBooksByPublishers :many
+SELECT id, title, author, publisher, price,
+        subtitle, edition, publication_date, language, page_count, format, description, series, series_volume, work_id, isbn, updated_at
+    FROM books
+    WHERE publisher = ANY(sqlc.arg('publishers')::text[])
+    ORDER BY id
+;
+
+-- name: ListBooksByStock :many
+SELECT id, title, author, publisher, price,
+        subtitle, edition, publication_date, language, page_count, format, description, series, series_volume, work_id, isbn, updated_at
+    FROM books
+    WHERE EXISTS (
+        SELECT 1
+            FROM inventories
+            WHERE inventories.book_id = books.id
+            AND inventories.quantity > 0
+    ) = sqlc.arg('in_stock')::boolean
+    ORDER BY id
+;
+
+-- name: GetBookByIDForUpdate :one
+SELECT id, title, author, publisher, price,
+        subtitle, edition, publication_date, language, page_count, format, description, series, series_volume, work_id, isbn, updated_at
+    FROM books
+    WHERE id = $1
+    FOR UPDATE
+;
+
+-- name: FilterBooks :many
+WITH RECURSIVE descendants AS (
+    SELECT categories.id
+        FROM categories
+        WHERE categories.id = sqlc.narg('category_id')::integer
+    UNION ALL
+    SELECT children.id
+        FROM categories AS children
+        INNER JOIN descendants ON children.parent_id = descendants.id
+)
+SELECT id, title, author, publisher, price,
+        subtitle, edition, publication_date, language, page_count, format, description, series, series_volume, work_id, isbn, updated_at
+    FROM books
+    WHERE (sqlc.narg('category_id')::integer IS NULL OR books.id IN (
+        SELECT book_categories.book_id
+            FROM book_categories
+            INNER JOIN descendants ON descendants.id = book_categories.category_id
+    ))
+    AND (sqlc.narg('tags')::text[] IS NULL OR books.id IN (
+        SELECT book_tags.book_id
+            FROM book_tags
+            INNER JOIN tags ON tags.id = book_tags.tag_id
+            WHERE tags.name = ANY(sqlc.narg('tags')::text[])
+            GROUP BY book_tags.book_id
+            HAVING NOT sqlc.arg('match_all_tags')::boolean
+            OR count(*) = cardinality(sqlc.narg('tags')::text[])
+    ))
+    AND (sqlc.narg('in_stock')::boolean IS NULL OR EXISTS (
+        SELECT 1
+            FROM inventories
+            WHERE inventories.book_id = books.id
+            AND inventories.quantity > 0
+    ) = sqlc.narg('in_stock')::boolean)
+    ORDER BY id
+;
+
+-- name: UpdateBook :one
+UPDATE books
+    SET title = COALESCE(sqlc.narg('title'), title),
+        author = COALESCE(sqlc.narg('author'), author),
+        publisher = COALESCE(sqlc.narg('publisher'), publisher),
+        subtitle = COALESCE(sqlc.narg('subtitle'), subtitle),
+        edition = COALESCE(sqlc.narg('edition'), edition),
+        publication_date = COALESCE(sqlc.narg('publication_date'), publication_date),
+        language = COALESCE(sqlc.narg('language'), language),
+        page_count = COALESCE(sqlc.narg('page_count'), page_count),
+        format = COALESCE(sqlc.narg('format'), format),
+        description = COALESCE(sqlc.narg('description'), description),
+        series = COALESCE(sqlc.narg('series'), series),
+        series_volume = COALESCE(sqlc.narg('series_volume'), series_volume),
+        isbn = COALESCE(sqlc.narg('isbn'), isbn)
+    WHERE id = sqlc.arg('id')
+    RETURNING id, title, author, publisher, price,
+        subtitle, edition, publication_date, language, page_count, format, description, series, series_volume, work_id, isbn, updated_at
+;
+
+-- name: ListDuplicateBookCandidates :many
+SELECT id, title, author, publisher, price,
+        subtitle, edition, publication_date, language, page_count, format, description, series, series_volume, work_id, isbn, updated_at
+    FROM books
+    WHERE isbn = sqlc.narg('isbn')
+    OR (
+        lower(coalesce(title, '') || ' ' || coalesce(author, '')) % sqlc.arg('title_author')::text
+        AND similarity(lower(coalesce(title, '') || ' ' || coalesce(author, '')), sqlc.arg('title_author')::text) >= sqlc.arg('min_similarity')::real
+    )
+    ORDER BY (isbn = sqlc.narg('isbn')) IS TRUE DESC,
+        similarity(lower(coalesce(title, '') || ' ' || coalesce(author, '')), sqlc.arg('title_author')::text) DESC,
+        id
+    LIMIT sqlc.arg('max_candidates')
+;
+
+-- name: GetBookCollectionVersion :one
+SELECT version, updated_at,
+        GREATEST(
+            (SELECT max(effective_from) FROM book_prices WHERE effective_from <= now()),
+            (SELECT max(effective_to) FROM book_prices WHERE effective_to <= now()),
+            (SELECT max(starts_at) FROM promotions WHERE starts_at <= now()),
+            (SELECT max(ends_at) FROM promotions WHERE ends_at <= now()),
+            (SELECT max(effective_date)::timestamp AT TIME ZONE 'UTC' FROM exchange_rates WHERE effective_date <= (now() AT TIME ZONE 'UTC')::date),
+            'epoch'::timestamptz
+        )::timestamptz AS last_effective_at
+    FROM catalog_versions
+;
+
+-- name: ListBooksByIds :many
+SELECT id, title, author, publisher, price,
+        subtitle, edition, publication_date, language, page_count, format, description, series, series_volume, work_id, isbn, updated_at
+    FROM books
+    WHERE id = ANY(sqlc.arg('ids')::int[])
+    ORDER BY id
+;
+
+-- name: ListBooksByFilter :many
+SELECT id, title, author, publisher, price,
+        subtitle, edition, publication_date, language, page_count, format, description, series, series_volume, work_id, isbn, updated_at
+    FROM books
+    WHERE (sqlc.narg('ids')::int[] IS NULL OR id = ANY(sqlc.narg('ids')::int[]))
+        AND (sqlc.narg('author')::text IS NULL OR author = sqlc.narg('author'))
+        AND (sqlc.narg('publisher')::text IS NULL OR publisher = sqlc.narg('publisher'))
+    ORDER BY id
+;
+
+-- name: LockBooksByFilter :many
+SELECT id, title, author, publisher, price,
+        subtitle, edition, publication_date, language, page_count, format, description, series, series_volume, work_id, isbn, updated_at
+    FROM books
+    WHERE (sqlc.narg('ids')::int[] IS NULL OR id = ANY(sqlc.narg('ids')::int[]))
+        AND (sqlc.narg('author')::text IS NULL OR author = sqlc.narg('author'))
+        AND (sqlc.narg('publisher')::text IS NULL OR publisher = sqlc.narg('publisher'))
+    ORDER BY id
+    FOR UPDATE
+;
+
+-- name: BulkUpdateBooks :many
+UPDATE books
+    SET publisher = COALESCE(sqlc.narg('publisher'), publisher),
+        price = COALESCE(sqlc.narg('price'), price)
+    WHERE id = ANY(sqlc.arg('ids')::int[])
+    RETURNING id, title, author, publisher, price,
+        subtitle, edition, publication_date, language, page_count, format, description, series, series_volume, work_id, isbn, updated_at
+;
+
+-- name: DeleteBooksByIds :execrows
+DELETE
+    FROM books
+    WHERE id = ANY(sqlc.arg('ids')::int[])
+;
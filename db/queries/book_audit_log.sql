@@ -0,0 +1,12 @@
+-- name: CreateBookAuditLog :one
+INSERT INTO book_audit_logs (book_id, action, detail)
+    VALUES ($1, $2, $3)
+    RETURNING *
+;
+
+-- name: ListBookAuditLogsByBookID :many
+SELECT *
+    FROM book_audit_logs
+    WHERE book_id = $1
+    ORDER BY id DESC
+;
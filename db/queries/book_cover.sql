@@ -0,0 +1,25 @@
+-- name: GetBookCoverByBookID :one
+SELECT book_id, content_type, width, height, byte_size, checksum, updated_at
+    FROM book_covers
+    WHERE book_id = $1
+;
+
+-- name: GetBookCoverByBookIDForUpdate :one
+SELECT book_id, content_type, width, height, byte_size, checksum, updated_at
+    FROM book_covers
+    WHERE book_id = $1
+    FOR UPDATE
+;
+
+-- name: UpsertBookCover :one
+INSERT INTO book_covers (book_id, content_type, width, height, byte_size, checksum)
+    VALUES ($1, $2, $3, $4, $5, $6)
+    ON CONFLICT (book_id) DO UPDATE
+    SET content_type = EXCLUDED.content_type,
+        width = EXCLUDED.width,
+        height = EXCLUDED.height,
+        byte_size = EXCLUDED.byte_size,
+        checksum = EXCLUDED.checksum,
+        updated_at = now()
+    RETURNING book_id, content_type, width, height, byte_size, checksum, updated_at
+;
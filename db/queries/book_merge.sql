@@ -0,0 +1,111 @@
+-- name: MergeBookCategories :exec
+INSERT INTO book_categories (book_id, category_id)
+    SELECT sqlc.arg('target_id')::integer, sources.category_id
+        FROM book_categories AS sources
+        WHERE sources.book_id = sqlc.arg('source_id')
+    ON CONFLICT DO NOTHING
+;
+
+-- name: MergeBookTags :exec
+INSERT INTO book_tags (book_id, tag_id, created_at)
+    SELECT sqlc.arg('target_id')::integer, sources.tag_id, sources.created_at
+        FROM book_tags AS sources
+        WHERE sources.book_id = sqlc.arg('source_id')
+    ON CONFLICT DO NOTHING
+;
+
+-- name: MergeInventories :exec
+INSERT INTO inventories (book_id, location, quantity)
+    SELECT sqlc.arg('target_id')::integer, sources.location, sources.quantity
+        FROM inventories AS sources
+        WHERE sources.book_id = sqlc.arg('source_id')
+    ON CONFLICT (book_id, location) DO UPDATE
+        SET quantity = inventories.quantity + EXCLUDED.quantity
+;
+
+-- name: MoveStockMovements :exec
+UPDATE stock_movements
+    SET book_id = sqlc.arg('target_id')
+    WHERE book_id = sqlc.arg('source_id')
+;
+
+-- name: MoveLoans :exec
+UPDATE loans
+    SET book_id = sqlc.arg('target_id')
+    WHERE book_id = sqlc.arg('source_id')
+;
+
+-- name: ExpireConflictingReservations :exec
+UPDATE reservations
+    SET status = 'expired'
+    WHERE reservations.book_id = sqlc.arg('source_id')
+    AND reservations.status IN ('waiting', 'held')
+    AND reservations.member_id IN (
+        SELECT targets.member_id
+            FROM reservations AS targets
+            WHERE targets.book_id = sqlc.arg('target_id')
+            AND targets.status IN ('waiting', 'held')
+    )
+;
+
+-- name: MoveReservations :exec
+UPDATE reservations
+    SET book_id = sqlc.arg('target_id')
+    WHERE book_id = sqlc.arg('source_id')
+;
+
+-- name: DeleteConflictingReviews :exec
+DELETE
+    FROM reviews
+    WHERE reviews.book_id = sqlc.arg('source_id')
+    AND reviews.member_id IN (
+        SELECT targets.member_id
+            FROM reviews AS targets
+            WHERE targets.book_id = sqlc.arg('target_id')
+    )
+;
+
+-- name: MoveReviews :exec
+UPDATE reviews
+    SET book_id = sqlc.arg('target_id')
+    WHERE book_id = sqlc.arg('source_id')
+;
+
+-- name: RecalculateBookRating :exec
+INSERT INTO book_ratings (book_id, review_count, rating_sum)
+    SELECT sqlc.arg('book_id')::integer, count(*), coalesce(sum(rating), 0)
+        FROM reviews
+        WHERE book_id = sqlc.arg('book_id')
+    ON CONFLICT (book_id) DO UPDATE
+        SET review_count = EXCLUDED.review_count,
+            rating_sum = EXCLUDED.rating_sum
+;
+
+-- name: MovePromotions :exec
+UPDATE promotions
+    SET target_book_id = sqlc.arg('target_id')
+    WHERE target_book_id = sqlc.arg('source_id')
+;
+
+-- name: MoveBookAuditLogs :exec
+UPDATE book_audit_logs
+    SET book_id = sqlc.arg('target_id')
+    WHERE book_id = sqlc.arg('source_id')
+;
+
+-- name: MoveBookRedirects :exec
+UPDATE book_redirects
+    SET target_id = sqlc.arg('target_id')
+    WHERE target_id = sqlc.arg('source_id')
+;
+
+-- name: CreateBookRedirect :exec
+INSERT INTO book_redirects (source_id, target_id)
+    VALUES ($1, $2)
+;
+
+-- name: GetBookRedirect :one
+SELECT target_id
+    FROM book_redirects
+    WHERE source_id = $1
+;
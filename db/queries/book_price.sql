@@ -0,0 +1,42 @@
+-- name: CreateBookPrice :one
+INSERT INTO book_prices (book_id, currency, amount, effective_from, effective_to)
+    VALUES ($1, $2, $3, $4, $5)
+    RETURNING *
+;
+
+-- name: ListBookPricesByBookID :many
+SELECT id, book_id, currency, amount, effective_from, effective_to
+    FROM book_prices
+    WHERE book_id = $1
+    ORDER BY effective_from DESC
+;
+
+-- name: ListBookPricesInEffect :many
+SELECT id, book_id, currency, amount, effective_from, effective_to
+    FROM book_prices
+    WHERE book_id = ANY(sqlc.arg('book_ids')::integer[])
+    AND effective_from <= sqlc.arg('at')
+    AND (effective_to IS NULL OR effective_to > sqlc.arg('at'))
+;
+
+-- name: GetNextBookPriceStart :one
+SELECT effective_from
+    FROM book_prices
+    WHERE book_id = $1
+    AND effective_from > $2
+    ORDER BY effective_from
+    LIMIT 1
+;
+
+-- name: CloseBookPrice :exec
+UPDATE book_prices
+    SET effective_to = sqlc.arg('effective_from')
+    WHERE book_id = sqlc.arg('book_id')
+    AND effective_from < sqlc.arg('effective_from')
+    AND (effective_to IS NULL OR effective_to > sqlc.arg('effective_from'))
+;
+
+-- name: CreateInitialBookPrice :exec
+INSERT INTO book_prices (book_id, currency, amount, effective_from)
+    VALUES ($1, $2, $3, now())
+;
@@ -0,0 +1,120 @@
+-- name: CreateCategory :one
+INSERT INTO categories (name, parent_id)
+    VALUES ($1, $2)
+    RETURNING *
+;
+
+-- name: GetCategoryByID :one
+SELECT *
+    FROM categories
+    WHERE id = $1
+;
+
+-- name: GetCategoryByIDForUpdate :one
+SELECT *
+    FROM categories
+    WHERE id = $1
+    FOR UPDATE
+;
+
+-- name: ListCategoryTree :many
+WITH RECURSIVE tree AS (
+    SELECT categories.*, 0 AS depth, ARRAY[categories.name::text] AS path
+        FROM categories
+        WHERE categories.parent_id IS NULL
+    UNION ALL
+    SELECT children.*, tree.depth + 1, tree.path || children.name::text
+        FROM categories AS children
+        INNER JOIN tree ON children.parent_id = tree.id
+)
+SELECT id, name, parent_id, created_at, depth::integer AS depth
+    FROM tree
+    ORDER BY path
+;
+
+-- name: ListCategoryAncestors :many
+WITH RECURSIVE ancestors AS (
+    SELECT categories.*, 0 AS distance
+        FROM categories
+        WHERE categories.id = (SELECT parents.parent_id FROM categories AS parents WHERE parents.id = $1)
+    UNION ALL
+    SELECT parents.*, ancestors.distance + 1
+        FROM categories AS parents
+        INNER JOIN ancestors ON parents.id = ancestors.parent_id
+)
+SELECT id, name, parent_id, created_at
+    FROM ancestors
+    ORDER BY distance DESC
+;
+
+-- name: ListChildCategories :many
+SELECT *
+    FROM categories
+    WHERE parent_id = $1
+    ORDER BY name
+;
+
+-- name: IsCategoryInSubtree :one
+WITH RECURSIVE subtree AS (
+    SELECT categories.id
+        FROM categories
+        WHERE categories.id = sqlc.arg('root_id')
+    UNION ALL
+    SELECT children.id
+        FROM categories AS children
+        INNER JOIN subtree ON children.parent_id = subtree.id
+)
+SELECT (count(*) > 0)::boolean AS in_subtree
+    FROM subtree
+    WHERE subtree.id = sqlc.arg('category_id')::integer
+;
+
+-- name: UpdateCategoryName :one
+UPDATE categories
+    SET name = $2
+    WHERE id = $1
+    RETURNING *
+;
+
+-- name: UpdateCategoryParent :one
+UPDATE categories
+    SET parent_id = $2
+    WHERE id = $1
+    RETURNING *
+;
+
+-- name: DeleteCategoryByID :execrows
+DELETE
+    FROM categories
+    WHERE id = $1
+;
+
+-- name: ListCategoriesByBookID :many
+SELECT categories.*
+    FROM categories
+    INNER JOIN book_categories ON book_categories.category_id = categories.id
+    WHERE book_categories.book_id = $1
+    ORDER BY categories.id
+;
+
+-- name: DeleteBookCategories :exec
+DELETE
+    FROM book_categories
+    WHERE book_id = $1
+;
+
+-- name: CreateBookCategories :exec
+INSERT INTO book_categories (book_id, category_id)
+    SELECT sqlc.arg('book_id'), unnest(sqlc.arg('category_ids')::integer[])
+    ON CONFLICT DO NOTHING
+;
+
+-- name: CountCategoriesByIDs :one
+SELECT count(*)
+    FROM categories
+    WHERE id = ANY(sqlc.arg('ids')::integer[])
+;
+
+-- name: LockCategoryTree :exec
+SELECT pg_advisory_xact_lock(hashtext('categories'))
+;
@@ -0,0 +1,18 @@
+-- name: UpsertExchangeRate :exec
+INSERT INTO exchange_rates (base_currency, quote_currency, rate, effective_date)
+    VALUES ($1, $2, $3, $4)
+    ON CONFLICT (base_currency, quote_currency, effective_date)
+    DO UPDATE SET rate = EXCLUDED.rate, imported_at = now()
+;
+
+-- name: GetExchangeRate :one
+SELECT *
+    FROM exchange_rates
+    WHERE (
+        (base_currency = sqlc.arg('from_currency') AND quote_currency = sqlc.arg('to_currency'))
+        OR (base_currency = sqlc.arg('to_currency') AND quote_currency = sqlc.arg('from_currency'))
+    )
+    AND effective_date <= sqlc.arg('on_date')
+    ORDER BY effective_date DESC, base_currency = sqlc.arg('from_currency') DESC
+    LIMIT 1
+;
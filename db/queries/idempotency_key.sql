@@ -0,0 +1,23 @@
+-- name: GetIdempotencyKey :one
+SELECT *
+    FROM idempotency_keys
+    WHERE idempotency_key = $1
+        AND expires_at > now()
+;
+
+-- name: CreateIdempotencyKey :execrows
+INSERT INTO idempotency_keys (idempotency_key, request_hash, response_status, book_id, expires_at)
+    VALUES ($1, $2, $3, $4, now() + sqlc.arg(ttl_seconds)::float8 * interval '1 second')
+    ON CONFLICT (idempotency_key) DO UPDATE
+        SET request_hash = EXCLUDED.request_hash,
+            response_status = EXCLUDED.response_status,
+            book_id = EXCLUDED.book_id,
+            created_at = now(),
+            expires_at = EXCLUDED.expires_at
+        WHERE idempotency_keys.expires_at <= now()
+;
+
+-- name: DeleteExpiredIdempotencyKeys :execrows
+DELETE FROM idempotency_keys
+    WHERE expires_at <= now()
+;
@@ -0,0 +1,41 @@
+-- name: EnsureInventory :exec
+INSERT INTO inventories (book_id, location, quantity)
+    VALUES ($1, $2, 0)
+    ON CONFLICT (book_id, location) DO NOTHING
+;
+
+-- name: GetInventoryForUpdate :one
+SELECT book_id, location, quantity
+    FROM inventories
+    WHERE book_id = $1
+    AND location = $2
+    FOR UPDATE
+;
+
+-- name: UpdateInventoryQuantity :one
+UPDATE inventories
+    SET quantity = $3
+    WHERE book_id = $1
+    AND location = $2
+    RETURNING book_id, location, quantity
+;
+
+-- name: ListInventoriesByBookID :many
+SELECT book_id, location, quantity
+    FROM inventories
+    WHERE book_id = $1
+    ORDER BY location
+;
+
+-- name: CreateStockMovement :one
+INSERT INTO stock_movements (book_id, location, quantity_change, quantity_after, reason)
+    VALUES ($1, $2, $3, $4, $5)
+    RETURNING id, book_id, location, quantity_change, quantity_after, reason, created_at
+;
+
+-- name: ListStockMovementsByBookID :many
+SELECT id, book_id, location, quantity_change, quantity_after, reason, created_at
+    FROM stock_movements
+    WHERE book_id = $1
+    ORDER BY id
+;
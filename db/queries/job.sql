@@ -0,0 +1,85 @@
+-- name: CreateJob :one
+INSERT INTO jobs (kind, params)
+    VALUES ($1, $2)
+    RETURNING id, kind, params, status, progress_done, progress_total, cancel_requested, attempts, lease_until,
+        result, artifact_key, artifact_type, last_error, created_at, started_at, finished_at, committed_result
+;
+
+-- name: GetJobByID :one
+SELECT id, kind, params, status, progress_done, progress_total, cancel_requested, attempts, lease_until,
+        result, artifact_key, artifact_type, last_error, created_at, started_at, finished_at, committed_result
+    FROM jobs
+    WHERE id = $1
+;
+
+-- name: ClaimJob :one
+UPDATE jobs
+    SET status = 'running',
+        attempts = attempts + 1,
+        lease_until = now() + sqlc.arg(lease)::interval,
+        started_at = COALESCE(started_at, now())
+    WHERE id = (
+        SELECT claimable.id
+            FROM jobs AS claimable
+            WHERE claimable.kind = ANY(sqlc.arg('kinds')::varchar[])
+                AND (claimable.status = 'queued'
+                    OR (claimable.status = 'running' AND claimable.lease_until < now()))
+            ORDER BY claimable.id
+            LIMIT 1
+            FOR UPDATE SKIP LOCKED
+    )
+    RETURNING id, kind, params, attempts, cancel_requested, committed_result
+;
+
+-- name: ExtendJobLease :one
+UPDATE jobs
+    SET lease_until = now() + sqlc.arg(lease)::interval
+    WHERE id = sqlc.arg(id)
+        AND attempts = sqlc.arg(attempts)
+        AND status = 'running'
+    RETURNING cancel_requested
+;
+
+-- name: UpdateJobProgress :one
+UPDATE jobs
+    SET progress_done = sqlc.arg(progress_done),
+        progress_total = sqlc.arg(progress_total)
+    WHERE id = sqlc.arg(id)
+        AND attempts = sqlc.arg(attempts)
+        AND status = 'running'
+    RETURNING cancel_requested
+;
+
+-- name: CommitJobResult :execrows
+UPDATE jobs
+    SET committed_result = sqlc.arg(committed_result)
+    WHERE id = sqlc.arg(id)
+        AND attempts = sqlc.arg(attempts)
+        AND status = 'running'
+        AND committed_result IS NULL
+;
+
+-- name: FinishJob :execrows
+UPDATE jobs
+    SET status = sqlc.arg(status),
+        result = sqlc.narg(result),
+        artifact_key = sqlc.narg(artifact_key),
+        artifact_type = sqlc.narg(artifact_type),
+        last_error = sqlc.narg(last_error),
+        lease_until = NULL,
+        finished_at = now()
+    WHERE id = sqlc.arg(id)
+        AND attempts = sqlc.arg(attempts)
+        AND status = 'running'
+;
+
+-- name: CancelJob :one
+UPDATE jobs
+    SET cancel_requested = true,
+        status = CASE WHEN status = 'queued' THEN 'canceled' ELSE status END,
+        finished_at = CASE WHEN status = 'queued' THEN now() ELSE finished_at END
+    WHERE id = $1
+        AND status IN ('queued', 'running')
+    RETURNING id, kind, params, status, progress_done, progress_total, cancel_requested, attempts, lease_until,
+        result, artifact_key, artifact_type, last_error, created_at, started_at, finished_at, committed_result
+;
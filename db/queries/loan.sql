@@ -0,0 +1,55 @@
+-- name: CreateLoan :one
+INSERT INTO loans (book_id, member_id, loaned_at, due_at)
+    VALUES ($1, $2, $3, $4)
+    RETURNING id, book_id, member_id, loaned_at, due_at, returned_at
+;
+
+-- name: GetLoanByIDForUpdate :one
+SELECT id, book_id, member_id, loaned_at, due_at, returned_at
+    FROM loans
+    WHERE id = $1
+    FOR UPDATE
+;
+
+-- name: ReturnLoan :one
+UPDATE loans
+    SET returned_at = $2
+    WHERE id = $1
+    RETURNING id, book_id, member_id, loaned_at, due_at, returned_at
+;
+
+-- name: CountActiveLoansByMemberID :one
+SELECT COUNT(*)::integer
+    FROM loans
+    WHERE member_id = $1
+    AND returned_at IS NULL
+;
+
+-- name: ListOverdueLoans :many
+SELECT id, book_id, member_id, loaned_at, due_at, returned_at
+    FROM loans
+    WHERE returned_at IS NULL
+    AND due_at < $1
+    ORDER BY due_at
+;
+
+-- name: GetBookAvailability :one
+SELECT
+    COALESCE((
+        SELECT SUM(quantity)
+            FROM inventories
+            WHERE inventories.book_id = sqlc.arg('book_id')
+    ), 0)::integer AS total,
+    (
+        SELECT COUNT(*)
+            FROM loans
+            WHERE loans.book_id = sqlc.arg('book_id')
+            AND loans.returned_at IS NULL
+    )::integer AS on_loan,
+    (
+        SELECT COUNT(*)
+            FROM reservations
+            WHERE reservations.book_id = sqlc.arg('book_id')
+            AND reservations.status = 'held'
+    )::integer AS on_hold
+;
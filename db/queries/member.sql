@@ -0,0 +1,18 @@
+-- name: CreateMember :one
+INSERT INTO members (name, email)
+    VALUES ($1, $2)
+    RETURNING id, name, email, created_at
+;
+
+-- name: GetMemberByID :one
+SELECT id, name, email, created_at
+    FROM members
+    WHERE id = $1
+;
+
+-- name: GetMemberByIDForUpdate :one
+SELECT id, name, email, created_at
+    FROM members
+    WHERE id = $1
+    FOR UPDATE
+;
@@ -0,0 +1,51 @@
+-- name: CreateOutboxEvent :exec
+INSERT INTO outbox_events (event_type, book_id, payload)
+    VALUES ($1, $2, $3)
+;
+
+-- name: ClaimOutboxEvents :many
+UPDATE outbox_events
+    SET attempts = attempts + 1,
+        next_attempt_at = sqlc.arg(lease_until)
+    WHERE id IN (
+        SELECT id
+            FROM outbox_events
+            WHERE delivered_at IS NULL
+                AND next_attempt_at <= now()
+            ORDER BY id
+            LIMIT sqlc.arg(max_events)
+            FOR UPDATE SKIP LOCKED
+    )
+    RETURNING *
+;
+
+-- name: MarkOutboxEventDelivered :exec
+UPDATE outbox_events
+    SET delivered_at = now(),
+        last_error = NULL
+    WHERE id = $1
+;
+
+-- name: RescheduleOutboxEvent :exec
+UPDATE outbox_events
+    SET next_attempt_at = $2,
+        last_error = $3
+    WHERE id = $1
+;
+
+-- name: ListOutboxEventsAfter :many
+SELECT *
+    FROM outbox_events
+    WHERE (tx_id, id) > (sqlc.arg(after_tx_id)::bigint, sqlc.arg(after_id)::bigint)
+        AND tx_id < pg_snapshot_xmin(pg_current_snapshot())::text::bigint
+    ORDER BY tx_id, id
+    LIMIT sqlc.arg(max_events)
+;
+
+-- name: GetLatestOutboxEventCursor :one
+SELECT tx_id, id
+    FROM outbox_events
+    WHERE tx_id < pg_snapshot_xmin(pg_current_snapshot())::text::bigint
+    ORDER BY tx_id DESC, id DESC
+    LIMIT 1
+;
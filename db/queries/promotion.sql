@@ -0,0 +1,36 @@
+-- name: CreatePromotion :one
+INSERT INTO promotions (name, discount_type, discount_value, currency, target_type, target_book_id, target_name, starts_at, ends_at, priority, stackable)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+    RETURNING *
+;
+
+-- name: GetPromotionByID :one
+SELECT *
+    FROM promotions
+    WHERE id = $1
+;
+
+-- name: ListPromotions :many
+SELECT *
+    FROM promotions
+    ORDER BY id
+;
+
+-- name: DeletePromotionByID :execrows
+DELETE
+    FROM promotions
+    WHERE id = $1
+;
+
+-- name: ListActivePromotionsByBookIDs :many
+SELECT books.id AS book_id, sqlc.embed(promotions)
+    FROM books
+    INNER JOIN promotions
+        ON (promotions.target_type = 'book' AND promotions.target_book_id = books.id)
+        OR (promotions.target_type = 'author' AND promotions.target_name = books.author)
+        OR (promotions.target_type = 'publisher' AND promotions.target_name = books.publisher)
+    WHERE books.id = ANY(sqlc.arg('book_ids')::integer[])
+    AND promotions.starts_at <= sqlc.arg('at')
+    AND (promotions.ends_at IS NULL OR promotions.ends_at > sqlc.arg('at'))
+    ORDER BY books.id, promotions.priority DESC, promotions.id
+;
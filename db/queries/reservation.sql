@@ -0,0 +1,61 @@
+-- name: CreateReservation :one
+INSERT INTO reservations (book_id, member_id, created_at)
+    VALUES ($1, $2, $3)
+    RETURNING id, book_id, member_id, status, created_at, held_at, hold_expires_at
+;
+
+-- name: ListActiveReservationsByBookID :many
+SELECT id, book_id, member_id, status, created_at, held_at, hold_expires_at
+    FROM reservations
+    WHERE book_id = $1
+    AND status IN ('waiting', 'held')
+    ORDER BY status = 'held' DESC, id
+;
+
+-- name: GetReservationPosition :one
+SELECT COUNT(*)::integer
+    FROM reservations
+    WHERE book_id = $1
+    AND status = 'waiting'
+    AND id <= $2
+;
+
+-- name: GetNextWaitingReservation :one
+SELECT id, book_id, member_id, status, created_at, held_at, hold_expires_at
+    FROM reservations
+    WHERE book_id = $1
+    AND status = 'waiting'
+    ORDER BY id
+    LIMIT 1
+    FOR UPDATE
+;
+
+-- name: GetHeldReservationForUpdate :one
+SELECT id, book_id, member_id, status, created_at, held_at, hold_expires_at
+    FROM reservations
+    WHERE book_id = $1
+    AND member_id = $2
+    AND status = 'held'
+    FOR UPDATE
+;
+
+-- name: HoldReservation :one
+UPDATE reservations
+    SET status = 'held', held_at = $2, hold_expires_at = $3
+    WHERE id = $1
+    RETURNING id, book_id, member_id, status, created_at, held_at, hold_expires_at
+;
+
+-- name: FulfillReservation :exec
+UPDATE reservations
+    SET status = 'fulfilled'
+    WHERE id = $1
+;
+
+-- name: ExpireHolds :execrows
+UPDATE reservations
+    SET status = 'expired'
+    WHERE book_id = $1
+    AND status = 'held'
+    AND hold_expires_at < $2
+;
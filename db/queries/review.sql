@@ -0,0 +1,56 @@
+-- name: CreateReview :one
+INSERT INTO reviews (book_id, member_id, rating, body)
+    VALUES ($1, $2, $3, $4)
+    RETURNING id, book_id, member_id, rating, body, created_at, updated_at
+;
+
+-- name: GetReviewByID :one
+SELECT id, book_id, member_id, rating, body, created_at, updated_at
+    FROM reviews
+    WHERE id = $1
+;
+
+-- name: GetReviewByIDForUpdate :one
+SELECT id, book_id, member_id, rating, body, created_at, updated_at
+    FROM reviews
+    WHERE id = $1
+    FOR UPDATE
+;
+
+-- name: UpdateReview :one
+UPDATE reviews
+    SET rating = COALESCE(sqlc.narg('rating')::smallint, rating),
+        body = COALESCE(sqlc.narg('body')::text, body),
+        updated_at = now()
+    WHERE id = sqlc.arg('id')
+    RETURNING id, book_id, member_id, rating, body, created_at, updated_at
+;
+
+-- name: DeleteReviewByID :one
+DELETE
+    FROM reviews
+    WHERE id = $1
+    RETURNING id, book_id, member_id, rating, body, created_at, updated_at
+;
+
+-- name: ListReviewsByBookID :many
+SELECT id, book_id, member_id, rating, body, created_at, updated_at
+    FROM reviews
+    WHERE book_id = $1
+    ORDER BY created_at DESC, id DESC
+;
+
+-- name: AdjustBookRating :exec
+INSERT INTO book_ratings (book_id, review_count, rating_sum)
+    VALUES (sqlc.arg('book_id'), sqlc.arg('count_delta'), sqlc.arg('sum_delta'))
+    ON CONFLICT (book_id) DO UPDATE
+    SET review_count = book_ratings.review_count + EXCLUDED.review_count,
+        rating_sum = book_ratings.rating_sum + EXCLUDED.rating_sum
+;
+
+-- name: ListBookRatingsByBookIDs :many
+SELECT book_id, review_count, rating_sum
+    FROM book_ratings
+    WHERE book_id = ANY(sqlc.arg('book_ids')::integer[])
+    ORDER BY book_id
+;
@@ -0,0 +1,40 @@
+-- name: UpsertTag :one
+INSERT INTO tags (name)
+    VALUES ($1)
+    ON CONFLICT (name) DO UPDATE
+        SET name = EXCLUDED.name
+    RETURNING *
+;
+
+-- name: CreateBookTag :exec
+INSERT INTO book_tags (book_id, tag_id)
+    VALUES ($1, $2)
+    ON CONFLICT DO NOTHING
+;
+
+-- name: DeleteBookTagByName :execrows
+DELETE
+    FROM book_tags
+    USING tags
+    WHERE book_tags.tag_id = tags.id
+    AND book_tags.book_id = $1
+    AND tags.name = $2
+;
+
+-- name: ListTagsByBookID :many
+SELECT tags.*
+    FROM tags
+    INNER JOIN book_tags ON book_tags.tag_id = tags.id
+    WHERE book_tags.book_id = $1
+    ORDER BY tags.name
+;
+
+-- name: SearchTagsByPrefix :many
+SELECT tags.id, tags.name, count(book_tags.book_id) AS usage_count
+    FROM tags
+    LEFT JOIN book_tags ON book_tags.tag_id = tags.id
+    WHERE tags.name LIKE sqlc.arg('pattern')::text
+    GROUP BY tags.id
+    ORDER BY usage_count DESC, tags.name
+    LIMIT sqlc.arg('limit')
+;
@@ -0,0 +1,119 @@
+-- name: CreateWebhookSubscription :one
+INSERT INTO webhook_subscriptions (url, event_types, secret)
+    VALUES ($1, $2, $3)
+    RETURNING id, url, event_types, secret, active, created_at
+;
+
+-- name: ListWebhookSubscriptions :many
+SELECT id, url, event_types, secret, active, created_at
+    FROM webhook_subscriptions
+    ORDER BY id
+;
+
+-- name: GetWebhookSubscriptionByID :one
+SELECT id, url, event_types, secret, active, created_at
+    FROM webhook_subscriptions
+    WHERE id = $1
+;
+
+-- name: UpdateWebhookSubscription :one
+UPDATE webhook_subscriptions
+    SET url = COALESCE(sqlc.narg('url')::varchar, url),
+        event_types = COALESCE(sqlc.narg('event_types')::varchar[], event_types),
+        secret = COALESCE(sqlc.narg('secret')::varchar, secret),
+        active = COALESCE(sqlc.narg('active')::boolean, active)
+    WHERE id = sqlc.arg('id')
+    RETURNING id, url, event_types, secret, active, created_at
+;
+
+-- name: DeleteWebhookSubscription :execrows
+DELETE
+    FROM webhook_subscriptions
+    WHERE id = $1
+;
+
+-- name: CreateWebhookDeliveries :execrows
+INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload)
+    SELECT id, sqlc.arg('event_id'), sqlc.arg('event_type'), sqlc.arg('payload')
+        FROM webhook_subscriptions
+        WHERE active
+            AND sqlc.arg('event_type')::varchar = ANY(event_types)
+    ON CONFLICT (subscription_id, event_id) DO NOTHING
+;
+
+-- name: ClaimWebhookDeliveries :many
+UPDATE webhook_deliveries
+    SET attempts = webhook_deliveries.attempts + 1,
+        next_attempt_at = sqlc.arg(lease_until)
+    FROM webhook_subscriptions
+    WHERE webhook_subscriptions.id = webhook_deliveries.subscription_id
+        AND webhook_deliveries.id IN (
+            SELECT pending.id
+                FROM webhook_deliveries AS pending
+                WHERE pending.status = 'pending'
+                    AND pending.next_attempt_at <= now()
+                ORDER BY pending.id
+                LIMIT sqlc.arg(max_deliveries)
+                FOR UPDATE SKIP LOCKED
+        )
+    RETURNING webhook_deliveries.id, webhook_deliveries.event_id, webhook_deliveries.event_type,
+        webhook_deliveries.payload, webhook_deliveries.attempts, webhook_subscriptions.url, webhook_subscriptions.secret
+;
+
+-- name: ReleaseWebhookDeliveries :exec
+UPDATE webhook_deliveries
+    SET attempts = attempts - 1,
+        next_attempt_at = now()
+    WHERE id = ANY(sqlc.arg('ids')::bigint[])
+        AND status = 'pending'
+        AND next_attempt_at = sqlc.arg(lease_until)
+;
+
+-- name: MarkWebhookDeliverySucceeded :exec
+UPDATE webhook_deliveries
+    SET status = 'succeeded',
+        response_status = $2,
+        last_error = NULL,
+        delivered_at = now()
+    WHERE id = $1
+;
+
+-- name: MarkWebhookDeliveryFailed :exec
+UPDATE webhook_deliveries
+    SET status = $2,
+        next_attempt_at = $3,
+        response_status = $4,
+        last_error = $5
+    WHERE id = $1
+;
+
+-- name: ListWebhookDeliveries :many
+SELECT id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at,
+        response_status, last_error, created_at, delivered_at
+    FROM webhook_deliveries
+    WHERE subscription_id = sqlc.arg('subscription_id')
+        AND (sqlc.narg('status')::varchar IS NULL OR status = sqlc.narg('status')::varchar)
+    ORDER BY id DESC
+    LIMIT sqlc.arg('max_deliveries')
+;
+
+-- name: ListDeadWebhookDeliveries :many
+SELECT id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at,
+        response_status, last_error, created_at, delivered_at
+    FROM webhook_deliveries
+    WHERE status = 'dead'
+    ORDER BY id DESC
+    LIMIT sqlc.arg('max_deliveries')
+;
+
+-- name: RequeueWebhookDelivery :one
+UPDATE webhook_deliveries
+    SET status = 'pending',
+        attempts = 0,
+        next_attempt_at = now(),
+        delivered_at = NULL
+    WHERE id = sqlc.arg('id')
+        AND subscription_id = sqlc.arg('subscription_id')
+    RETURNING id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at,
+        response_status, last_error, created_at, delivered_at
+;
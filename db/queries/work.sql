@@ -0,0 +1,34 @@
+-- name: CreateWork :one
+INSERT INTO works (title, author)
+    VALUES ($1, $2)
+    RETURNING *
+;
+
+-- name: GetWorkByID :one
+SELECT *
+    FROM works
+    WHERE id = $1
+;
+
+-- name: ListBooksByWorkID :many
+SELECT id, title, author, publisher, price,
+        subtitle, edition, publication_date, language, page_count, format, description, series, series_volume, work_id, isbn, updated_at
+    FROM books
+    WHERE work_id = $1
+    ORDER BY publication_date NULLS LAST, id
+;
+
+-- name: UpdateBookWork :one
+UPDATE books
+    SET work_id = $2
+    WHERE id = $1
+    RETURNING id, title, author, publisher, price,
+        subtitle, edition, publication_date, language, page_count, format, description, series, series_volume, work_id, isbn, updated_at
+;
+
+-- name: ClearBookWork :execrows
+UPDATE books
+    SET work_id = NULL
+    WHERE id = $1
+    AND work_id = $2
+;
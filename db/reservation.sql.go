@@ -0,0 +1,209 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: reservation.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createReservation = `-- name: CreateReservation :one
+INSERT INTO reservations (book_id, member_id, created_at)
+    VALUES ($1, $2, $3)
+    RETURNING id, book_id, member_id, status, created_at, held_at, hold_expires_at
+`
+
+type CreateReservationParams struct {
+	BookID    int32
+	MemberID  int32
+	CreatedAt pgtype.Timestamptz
+}
+
+func (q *Queries) CreateReservation(ctx context.Context, arg CreateReservationParams) (Reservation, error) {
+	row := q.db.QueryRow(ctx, createReservation, arg.BookID, arg.MemberID, arg.CreatedAt)
+	var i Reservation
+	err := row.Scan(
+		&i.ID,
+		&i.BookID,
+		&i.MemberID,
+		&i.Status,
+		&i.CreatedAt,
+		&i.HeldAt,
+		&i.HoldExpiresAt,
+	)
+	return i, err
+}
+
+const expireHolds = `-- name: ExpireHolds :execrows
+UPDATE reservations
+    SET status = 'expired'
+    WHERE book_id = $1
+    AND status = 'held'
+    AND hold_expires_at < $2
+`
+
+type ExpireHoldsParams struct {
+	BookID        int32
+	HoldExpiresAt pgtype.Timestamptz
+}
+
+func (q *Queries) ExpireHolds(ctx context.Context, arg ExpireHoldsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, expireHolds, arg.BookID, arg.HoldExpiresAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const fulfillReservation = `-- name: FulfillReservation :exec
+UPDATE reservations
+    SET status = 'fulfilled'
+    WHERE id = $1
+`
+
+func (q *Queries) FulfillReservation(ctx context.Context, id int32) error {
+	_, err := q.db.Exec(ctx, fulfillReservation, id)
+	return err
+}
+
+const getHeldReservationForUpdate = `-- name: GetHeldReservationForUpdate :one
+SELECT id, book_id, member_id, status, created_at, held_at, hold_expires_at
+    FROM reservations
+    WHERE book_id = $1
+    AND member_id = $2
+    AND status = 'held'
+    FOR UPDATE
+`
+
+type GetHeldReservationForUpdateParams struct {
+	BookID   int32
+	MemberID int32
+}
+
+func (q *Queries) GetHeldReservationForUpdate(ctx context.Context, arg GetHeldReservationForUpdateParams) (Reservation, error) {
+	row := q.db.QueryRow(ctx, getHeldReservationForUpdate, arg.BookID, arg.MemberID)
+	var i Reservation
+	err := row.Scan(
+		&i.ID,
+		&i.BookID,
+		&i.MemberID,
+		&i.Status,
+		&i.CreatedAt,
+		&i.HeldAt,
+		&i.HoldExpiresAt,
+	)
+	return i, err
+}
+
+const getNextWaitingReservation = `-- name: GetNextWaitingReservation :one
+SELECT id, book_id, member_id, status, created_at, held_at, hold_expires_at
+    FROM reservations
+    WHERE book_id = $1
+    AND status = 'waiting'
+    ORDER BY id
+    LIMIT 1
+    FOR UPDATE
+`
+
+func (q *Queries) GetNextWaitingReservation(ctx context.Context, bookID int32) (Reservation, error) {
+	row := q.db.QueryRow(ctx, getNextWaitingReservation, bookID)
+	var i Reservation
+	err := row.Scan(
+		&i.ID,
+		&i.BookID,
+		&i.MemberID,
+		&i.Status,
+		&i.CreatedAt,
+		&i.HeldAt,
+		&i.HoldExpiresAt,
+	)
+	return i, err
+}
+
+const getReservationPosition = `-- name: GetReservationPosition :one
+SELECT COUNT(*)::integer
+    FROM reservations
+    WHERE book_id = $1
+    AND status = 'waiting'
+    AND id <= $2
+`
+
+type GetReservationPositionParams struct {
+	BookID int32
+	ID     int32
+}
+
+func (q *Queries) GetReservationPosition(ctx context.Context, arg GetReservationPositionParams) (int32, error) {
+	row := q.db.QueryRow(ctx, getReservationPosition, arg.BookID, arg.ID)
+	var column_1 int32
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const holdReservation = `-- name: HoldReservation :one
+UPDATE reservations
+    SET status = 'held', held_at = $2, hold_expires_at = $3
+    WHERE id = $1
+    RETURNING id, book_id, member_id, status, created_at, held_at, hold_expires_at
+`
+
+type HoldReservationParams struct {
+	ID            int32
+	HeldAt        pgtype.Timestamptz
+	HoldExpiresAt pgtype.Timestamptz
+}
+
+func (q *Queries) HoldReservation(ctx context.Context, arg HoldReservationParams) (Reservation, error) {
+	row := q.db.QueryRow(ctx, holdReservation, arg.ID, arg.HeldAt, arg.HoldExpiresAt)
+	var i Reservation
+	err := row.Scan(
+		&i.ID,
+		&i.BookID,
+		&i.MemberID,
+		&i.Status,
+		&i.CreatedAt,
+		&i.HeldAt,
+		&i.HoldExpiresAt,
+	)
+	return i, err
+}
+
+const listActiveReservationsByBookID = `-- name: ListActiveReservationsByBookID :many
+SELECT id, book_id, member_id, status, created_at, held_at, hold_expires_at
+    FROM reservations
+    WHERE book_id = $1
+    AND status IN ('waiting', 'held')
+    ORDER BY status = 'held' DESC, id
+`
+
+func (q *Queries) ListActiveReservationsByBookID(ctx context.Context, bookID int32) ([]Reservation, error) {
+	rows, err := q.db.Query(ctx, listActiveReservationsByBookID, bookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Reservation
+	for rows.Next() {
+		var i Reservation
+		if err := rows.Scan(
+			&i.ID,
+			&i.BookID,
+			&i.MemberID,
+			&i.Status,
+			&i.CreatedAt,
+			&i.HeldAt,
+			&i.HoldExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,221 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: review.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const adjustBookRating = `-- name: AdjustBookRating :exec
+INSERT INTO book_ratings (book_id, review_count, rating_sum)
+    VALUES ($1, $2, $3)
+    ON CONFLICT (book_id) DO UPDATE
+    SET review_count = book_ratings.review_count + EXCLUDED.review_count,
+        rating_sum = book_ratings.rating_sum + EXCLUDED.rating_sum
+`
+
+type AdjustBookRatingParams struct {
+	BookID     int32
+	CountDelta int32
+	SumDelta   int32
+}
+
+func (q *Queries) AdjustBookRating(ctx context.Context, arg AdjustBookRatingParams) error {
+	_, err := q.db.Exec(ctx, adjustBookRating, arg.BookID, arg.CountDelta, arg.SumDelta)
+	return err
+}
+
+const createReview = `-- name: CreateReview :one
+INSERT INTO reviews (book_id, member_id, rating, body)
+    VALUES ($1, $2, $3, $4)
+    RETURNING id, book_id, member_id, rating, body, created_at, updated_at
+`
+
+type CreateReviewParams struct {
+	BookID   int32
+	MemberID int32
+	Rating   int16
+	Body     string
+}
+
+func (q *Queries) CreateReview(ctx context.Context, arg CreateReviewParams) (Review, error) {
+	row := q.db.QueryRow(ctx, createReview,
+		arg.BookID,
+		arg.MemberID,
+		arg.Rating,
+		arg.Body,
+	)
+	var i Review
+	err := row.Scan(
+		&i.ID,
+		&i.BookID,
+		&i.MemberID,
+		&i.Rating,
+		&i.Body,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const deleteReviewByID = `-- name: DeleteReviewByID :one
+DELETE
+    FROM reviews
+    WHERE id = $1
+    RETURNING id, book_id, member_id, rating, body, created_at, updated_at
+`
+
+func (q *Queries) DeleteReviewByID(ctx context.Context, id int32) (Review, error) {
+	row := q.db.QueryRow(ctx, deleteReviewByID, id)
+	var i Review
+	err := row.Scan(
+		&i.ID,
+		&i.BookID,
+		&i.MemberID,
+		&i.Rating,
+		&i.Body,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getReviewByID = `-- name: GetReviewByID :one
+SELECT id, book_id, member_id, rating, body, created_at, updated_at
+    FROM reviews
+    WHERE id = $1
+`
+
+func (q *Queries) GetReviewByID(ctx context.Context, id int32) (Review, error) {
+	row := q.db.QueryRow(ctx, getReviewByID, id)
+	var i Review
+	err := row.Scan(
+		&i.ID,
+		&i.BookID,
+		&i.MemberID,
+		&i.Rating,
+		&i.Body,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getReviewByIDForUpdate = `-- name: GetReviewByIDForUpdate :one
+SELECT id, book_id, member_id, rating, body, created_at, updated_at
+    FROM reviews
+    WHERE id = $1
+    FOR UPDATE
+`
+
+func (q *Queries) GetReviewByIDForUpdate(ctx context.Context, id int32) (Review, error) {
+	row := q.db.QueryRow(ctx, getReviewByIDForUpdate, id)
+	var i Review
+	err := row.Scan(
+		&i.ID,
+		&i.BookID,
+		&i.MemberID,
+		&i.Rating,
+		&i.Body,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listBookRatingsByBookIDs = `-- name: ListBookRatingsByBookIDs :many
+SELECT book_id, review_count, rating_sum
+    FROM book_ratings
+    WHERE book_id = ANY($1::integer[])
+    ORDER BY book_id
+`
+
+func (q *Queries) ListBookRatingsByBookIDs(ctx context.Context, bookIds []int32) ([]BookRating, error) {
+	rows, err := q.db.Query(ctx, listBookRatingsByBookIDs, bookIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []BookRating
+	for rows.Next() {
+		var i BookRating
+		if err := rows.Scan(&i.BookID, &i.ReviewCount, &i.RatingSum); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listReviewsByBookID = `-- name: ListReviewsByBookID :many
+SELECT id, book_id, member_id, rating, body, created_at, updated_at
+    FROM reviews
+    WHERE book_id = $1
+    ORDER BY created_at DESC, id DESC
+`
+
+func (q *Queries) ListReviewsByBookID(ctx context.Context, bookID int32) ([]Review, error) {
+	rows, err := q.db.Query(ctx, listReviewsByBookID, bookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Review
+	for rows.Next() {
+		var i Review
+		if err := rows.Scan(
+			&i.ID,
+			&i.BookID,
+			&i.MemberID,
+			&i.Rating,
+			&i.Body,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateReview = `-- name: UpdateReview :one
+UPDATE reviews
+    SET rating = COALESCE($1::smallint, rating),
+        body = COALESCE($2::text, body),
+        updated_at = now()
+    WHERE id = $3
+    RETURNING id, book_id, member_id, rating, body, created_at, updated_at
+`
+
+type UpdateReviewParams struct {
+	Rating pgtype.Int2
+	Body   pgtype.Text
+	ID     int32
+}
+
+func (q *Queries) UpdateReview(ctx context.Context, arg UpdateReviewParams) (Review, error) {
+	row := q.db.QueryRow(ctx, updateReview, arg.Rating, arg.Body, arg.ID)
+	var i Review
+	err := row.Scan(
+		&i.ID,
+		&i.BookID,
+		&i.MemberID,
+		&i.Rating,
+		&i.Body,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -16,6 +16,126 @@ SET xmloption = content;
 SET client_min_messages = warning;
 SET row_security = off;
 
+--
+-- Name: pg_trgm; Type: EXTENSION; Schema: -; Owner: -
+--
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm WITH SCHEMA public;
+
+
+--
+-- Name: bump_catalog_version(); Type: FUNCTION; Schema: public; Owner: -
+--
+
+CREATE FUNCTION public.bump_catalog_version() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    IF current_setting('catalog_versions.bumped', true) = 'on' THEN
+        RETURN NULL;
+    END IF;
+    PERFORM set_config('catalog_versions.bumped', 'on', true);
+    UPDATE catalog_versions
+        SET version = version + 1,
+            updated_at = GREATEST(clock_timestamp(), date_trunc('second', updated_at) + interval '1 second');
+    RETURN NULL;
+END;
+$$;
+
+
+--
+-- Name: notify_outbox_event(); Type: FUNCTION; Schema: public; Owner: -
+--
+
+CREATE FUNCTION public.notify_outbox_event() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    PERFORM pg_notify('outbox_events', NEW.id::text);
+    RETURN NEW;
+END;
+$$;
+
+
+--
+-- Name: set_updated_at(); Type: FUNCTION; Schema: public; Owner: -
+--
+
+CREATE FUNCTION public.set_updated_at() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    NEW.updated_at := now();
+    RETURN NEW;
+END;
+$$;
+
+
+SET default_tablespace = '';
+
+SET default_table_access_method = heap;
+
+--
+-- Name: book_audit_logs; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.book_audit_logs (
+    id bigint NOT NULL,
+    book_id integer NOT NULL,
+    action character varying(20) NOT NULL,
+    detail jsonb DEFAULT '{}'::jsonb NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT book_audit_logs_action_check CHECK (((action)::text = ANY ((ARRAY['merge'::character varying, 'bulk_update'::character varying, 'bulk_delete'::character varying])::text[])))
+);
+
+
+--
+-- Name: book_audit_logs_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.book_audit_logs_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: book_audit_logs_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.book_audit_logs_id_seq OWNED BY public.book_audit_logs.id;
+
+
+--
+-- Name: book_categories; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.book_categories (
+    book_id integer NOT NULL,
+    category_id integer NOT NULL
+);
+
+
+--
+-- Name: book_covers; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.book_covers (
+    book_id integer NOT NULL,
+    content_type character varying(50) NOT NULL,
+    width integer NOT NULL,
+    height integer NOT NULL,
+    byte_size integer NOT NULL,
+    checksum character(64) NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT book_covers_byte_size_check CHECK ((byte_size > 0)),
+    CONSTRAINT book_covers_height_check CHECK ((height > 0)),
+    CONSTRAINT book_covers_width_check CHECK ((width > 0))
+);
+
+
 --
 -- Name: book_id_seq; Type: SEQUENCE; Schema: public; Owner: -
 --
@@ -28,9 +148,77 @@ CREATE SEQUENCE public.book_id_seq
     CACHE 1;
 
 
-SET default_tablespace = '';
+--
+-- Name: book_prices; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.book_prices (
+    id integer NOT NULL,
+    book_id integer NOT NULL,
+    currency character(3) NOT NULL,
+    amount bigint NOT NULL,
+    effective_from timestamp with time zone NOT NULL,
+    effective_to timestamp with time zone,
+    CONSTRAINT book_prices_amount_check CHECK ((amount >= 0)),
+    CONSTRAINT book_prices_check CHECK (((effective_to IS NULL) OR (effective_to > effective_from))),
+    CONSTRAINT book_prices_currency_check CHECK ((currency ~ '^[A-Z]{3}$'::text))
+);
+
+
+--
+-- Name: book_prices_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.book_prices_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: book_prices_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.book_prices_id_seq OWNED BY public.book_prices.id;
+
+
+--
+-- Name: book_ratings; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.book_ratings (
+    book_id integer NOT NULL,
+    review_count integer DEFAULT 0 NOT NULL,
+    rating_sum integer DEFAULT 0 NOT NULL,
+    CONSTRAINT book_ratings_rating_sum_check CHECK ((rating_sum >= 0)),
+    CONSTRAINT book_ratings_review_count_check CHECK ((review_count >= 0))
+);
+
+
+--
+-- Name: book_redirects; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.book_redirects (
+    source_id integer NOT NULL,
+    target_id integer NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+
+--
+-- Name: book_tags; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.book_tags (
+    book_id integer NOT NULL,
+    tag_id integer NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL
+);
 
-SET default_table_access_method = heap;
 
 --
 -- Name: books; Type: TABLE; Schema: public; Owner: -
@@ -41,34 +229,1394 @@ CREATE TABLE public.books (
     title character varying(100),
     author character varying(100),
     publisher character varying(100),
-    price integer
+    price integer,
+    subtitle character varying(255),
+    edition character varying(50),
+    publication_date date,
+    language character varying(35),
+    page_count integer,
+    format character varying(20),
+    description text,
+    series character varying(255),
+    series_volume integer,
+    work_id integer,
+    isbn character varying(13),
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT books_check CHECK (((series_volume IS NULL) OR (series IS NOT NULL))),
+    CONSTRAINT books_format_check CHECK (((format)::text = ANY ((ARRAY['hardcover'::character varying, 'paperback'::character varying, 'ebook'::character varying])::text[]))),
+    CONSTRAINT books_isbn_check CHECK (((isbn)::text ~ '^[0-9]{13}$'::text)),
+    CONSTRAINT books_page_count_check CHECK ((page_count > 0)),
+    CONSTRAINT books_series_volume_check CHECK ((series_volume > 0))
 );
 
 
 --
--- Name: schema_migrations; Type: TABLE; Schema: public; Owner: -
+-- Name: catalog_versions; Type: TABLE; Schema: public; Owner: -
 --
 
-CREATE TABLE public.schema_migrations (
+CREATE TABLE public.catalog_versions (
+    id boolean DEFAULT true NOT NULL,
     version bigint NOT NULL,
-    dirty boolean NOT NULL
+    updated_at timestamp with time zone NOT NULL,
+    CONSTRAINT catalog_versions_id_check CHECK (id)
 );
 
 
 --
--- Name: books books_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: categories; Type: TABLE; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.books
-    ADD CONSTRAINT books_pkey PRIMARY KEY (id);
+CREATE TABLE public.categories (
+    id integer NOT NULL,
+    name character varying(100) NOT NULL,
+    parent_id integer,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT categories_check CHECK ((parent_id <> id))
+);
 
 
 --
--- Name: schema_migrations schema_migrations_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: categories_id_seq; Type: SEQUENCE; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.schema_migrations
-    ADD CONSTRAINT schema_migrations_pkey PRIMARY KEY (version);
+CREATE SEQUENCE public.categories_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: categories_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.categories_id_seq OWNED BY public.categories.id;
+
+
+--
+-- Name: exchange_rates; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.exchange_rates (
+    id integer NOT NULL,
+    base_currency character(3) NOT NULL,
+    quote_currency character(3) NOT NULL,
+    rate numeric(20,10) NOT NULL,
+    effective_date date NOT NULL,
+    imported_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT exchange_rates_base_currency_check CHECK ((base_currency ~ '^[A-Z]{3}$'::text)),
+    CONSTRAINT exchange_rates_quote_currency_check CHECK ((quote_currency ~ '^[A-Z]{3}$'::text)),
+    CONSTRAINT exchange_rates_rate_check CHECK ((rate > (0)::numeric))
+);
+
+
+--
+-- Name: exchange_rates_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.exchange_rates_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: exchange_rates_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.exchange_rates_id_seq OWNED BY public.exchange_rates.id;
+
+
+--
+-- Name: idempotency_keys; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.idempotency_keys (
+    idempotency_key character varying(255) NOT NULL,
+    request_hash character(64) NOT NULL,
+    response_status integer NOT NULL,
+    book_id integer NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    expires_at timestamp with time zone NOT NULL
+);
+
+
+--
+-- Name: inventories; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.inventories (
+    book_id integer NOT NULL,
+    location character varying(100) NOT NULL,
+    quantity integer DEFAULT 0 NOT NULL,
+    CONSTRAINT inventories_quantity_check CHECK ((quantity >= 0))
+);
+
+
+--
+-- Name: jobs; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.jobs (
+    id bigint NOT NULL,
+    kind character varying(50) NOT NULL,
+    params jsonb NOT NULL,
+    status character varying(20) DEFAULT 'queued'::character varying NOT NULL,
+    progress_done integer DEFAULT 0 NOT NULL,
+    progress_total integer,
+    cancel_requested boolean DEFAULT false NOT NULL,
+    attempts integer DEFAULT 0 NOT NULL,
+    lease_until timestamp with time zone,
+    result jsonb,
+    artifact_key character varying(255),
+    artifact_type character varying(255),
+    last_error text,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    started_at timestamp with time zone,
+    finished_at timestamp with time zone,
+    committed_result jsonb,
+    CONSTRAINT jobs_status_check CHECK (((status)::text = ANY ((ARRAY['queued'::character varying, 'running'::character varying, 'succeeded'::character varying, 'failed'::character varying, 'canceled'::character varying])::text[])))
+);
+
+
+--
+-- Name: jobs_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.jobs_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: jobs_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.jobs_id_seq OWNED BY public.jobs.id;
+
+
+--
+-- Name: loans; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.loans (
+    id integer NOT NULL,
+    book_id integer NOT NULL,
+    member_id integer NOT NULL,
+    loaned_at timestamp with time zone DEFAULT now() NOT NULL,
+    due_at timestamp with time zone NOT NULL,
+    returned_at timestamp with time zone
+);
+
+
+--
+-- Name: loans_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.loans_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: loans_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.loans_id_seq OWNED BY public.loans.id;
+
+
+--
+-- Name: members; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.members (
+    id integer NOT NULL,
+    name character varying(100) NOT NULL,
+    email character varying(255) NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+
+--
+-- Name: members_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.members_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: members_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.members_id_seq OWNED BY public.members.id;
+
+
+--
+-- Name: outbox_events; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.outbox_events (
+    id bigint NOT NULL,
+    event_type character varying(50) NOT NULL,
+    book_id integer NOT NULL,
+    payload jsonb NOT NULL,
+    occurred_at timestamp with time zone DEFAULT now() NOT NULL,
+    attempts integer DEFAULT 0 NOT NULL,
+    next_attempt_at timestamp with time zone DEFAULT now() NOT NULL,
+    delivered_at timestamp with time zone,
+    last_error text,
+    tx_id bigint DEFAULT ((pg_current_xact_id())::text)::bigint NOT NULL
+);
+
+
+--
+-- Name: outbox_events_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.outbox_events_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: outbox_events_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.outbox_events_id_seq OWNED BY public.outbox_events.id;
+
+
+--
+-- Name: promotions; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.promotions (
+    id integer NOT NULL,
+    name character varying(255) NOT NULL,
+    discount_type character varying(20) NOT NULL,
+    discount_value bigint NOT NULL,
+    currency character(3),
+    target_type character varying(20) NOT NULL,
+    target_book_id integer,
+    target_name character varying(255),
+    starts_at timestamp with time zone NOT NULL,
+    ends_at timestamp with time zone,
+    priority integer DEFAULT 0 NOT NULL,
+    stackable boolean DEFAULT false NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT promotions_check CHECK ((((discount_type)::text <> 'percentage'::text) OR (discount_value <= 100))),
+    CONSTRAINT promotions_check1 CHECK ((((discount_type)::text <> 'fixed_amount'::text) OR (currency IS NOT NULL))),
+    CONSTRAINT promotions_check2 CHECK ((((target_type)::text = 'book'::text) = (target_book_id IS NOT NULL))),
+    CONSTRAINT promotions_check3 CHECK ((((target_type)::text = 'book'::text) = (target_name IS NULL))),
+    CONSTRAINT promotions_check4 CHECK (((ends_at IS NULL) OR (ends_at > starts_at))),
+    CONSTRAINT promotions_discount_type_check CHECK (((discount_type)::text = ANY ((ARRAY['percentage'::character varying, 'fixed_amount'::character varying])::text[]))),
+    CONSTRAINT promotions_discount_value_check CHECK ((discount_value > 0)),
+    CONSTRAINT promotions_target_type_check CHECK (((target_type)::text = ANY ((ARRAY['book'::character varying, 'author'::character varying, 'publisher'::character varying])::text[])))
+);
+
+
+--
+-- Name: promotions_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.promotions_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: promotions_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.promotions_id_seq OWNED BY public.promotions.id;
+
+
+--
+-- Name: reservations; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.reservations (
+    id integer NOT NULL,
+    book_id integer NOT NULL,
+    member_id integer NOT NULL,
+    status character varying(20) DEFAULT 'waiting'::character varying NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    held_at timestamp with time zone,
+    hold_expires_at timestamp with time zone,
+    CONSTRAINT reservations_status_check CHECK (((status)::text = ANY ((ARRAY['waiting'::character varying, 'held'::character varying, 'fulfilled'::character varying, 'expired'::character varying])::text[])))
+);
+
+
+--
+-- Name: reservations_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.reservations_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: reservations_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.reservations_id_seq OWNED BY public.reservations.id;
+
+
+--
+-- Name: reviews; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.reviews (
+    id integer NOT NULL,
+    book_id integer NOT NULL,
+    member_id integer NOT NULL,
+    rating smallint NOT NULL,
+    body text DEFAULT ''::text NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT reviews_rating_check CHECK (((rating >= 1) AND (rating <= 5)))
+);
+
+
+--
+-- Name: reviews_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.reviews_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: reviews_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.reviews_id_seq OWNED BY public.reviews.id;
+
+
+--
+-- Name: schema_migrations; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.schema_migrations (
+    version bigint NOT NULL,
+    dirty boolean NOT NULL
+);
+
+
+--
+-- Name: stock_movements; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.stock_movements (
+    id integer NOT NULL,
+    book_id integer NOT NULL,
+    location character varying(100) NOT NULL,
+    quantity_change integer NOT NULL,
+    quantity_after integer NOT NULL,
+    reason character varying(20) NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT stock_movements_reason_check CHECK (((reason)::text = ANY ((ARRAY['received'::character varying, 'sold'::character varying, 'damaged'::character varying, 'returned'::character varying])::text[])))
+);
+
+
+--
+-- Name: stock_movements_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.stock_movements_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: stock_movements_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.stock_movements_id_seq OWNED BY public.stock_movements.id;
+
+
+--
+-- Name: tags; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.tags (
+    id integer NOT NULL,
+    name character varying(50) NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+
+--
+-- Name: tags_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.tags_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: tags_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.tags_id_seq OWNED BY public.tags.id;
+
+
+--
+-- Name: webhook_deliveries; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.webhook_deliveries (
+    id bigint NOT NULL,
+    subscription_id integer NOT NULL,
+    event_id bigint NOT NULL,
+    event_type character varying(50) NOT NULL,
+    payload jsonb NOT NULL,
+    status character varying(20) DEFAULT 'pending'::character varying NOT NULL,
+    attempts integer DEFAULT 0 NOT NULL,
+    next_attempt_at timestamp with time zone DEFAULT now() NOT NULL,
+    response_status integer,
+    last_error text,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    delivered_at timestamp with time zone,
+    CONSTRAINT webhook_deliveries_status_check CHECK (((status)::text = ANY ((ARRAY['pending'::character varying, 'succeeded'::character varying, 'dead'::character varying])::text[])))
+);
+
+
+--
+-- Name: webhook_deliveries_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.webhook_deliveries_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: webhook_deliveries_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.webhook_deliveries_id_seq OWNED BY public.webhook_deliveries.id;
+
+
+--
+-- Name: webhook_subscriptions; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.webhook_subscriptions (
+    id integer NOT NULL,
+    url character varying(2048) NOT NULL,
+    event_types character varying(50)[] NOT NULL,
+    secret character varying(255) NOT NULL,
+    active boolean DEFAULT true NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+
+--
+-- Name: webhook_subscriptions_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.webhook_subscriptions_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: webhook_subscriptions_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.webhook_subscriptions_id_seq OWNED BY public.webhook_subscriptions.id;
+
+
+--
+-- Name: works; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.works (
+    id integer NOT NULL,
+    title character varying(255) NOT NULL,
+    author character varying(100),
+    created_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+
+--
+-- Name: works_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.works_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: works_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.works_id_seq OWNED BY public.works.id;
+
+
+--
+-- Name: book_audit_logs id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.book_audit_logs ALTER COLUMN id SET DEFAULT nextval('public.book_audit_logs_id_seq'::regclass);
+
+
+--
+-- Name: book_prices id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.book_prices ALTER COLUMN id SET DEFAULT nextval('public.book_prices_id_seq'::regclass);
+
+
+--
+-- Name: categories id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.categories ALTER COLUMN id SET DEFAULT nextval('public.categories_id_seq'::regclass);
+
+
+--
+-- Name: exchange_rates id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.exchange_rates ALTER COLUMN id SET DEFAULT nextval('public.exchange_rates_id_seq'::regclass);
+
+
+--
+-- Name: jobs id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.jobs ALTER COLUMN id SET DEFAULT nextval('public.jobs_id_seq'::regclass);
+
+
+--
+-- Name: loans id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.loans ALTER COLUMN id SET DEFAULT nextval('public.loans_id_seq'::regclass);
+
+
+--
+-- Name: members id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.members ALTER COLUMN id SET DEFAULT nextval('public.members_id_seq'::regclass);
+
+
+--
+-- Name: outbox_events id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.outbox_events ALTER COLUMN id SET DEFAULT nextval('public.outbox_events_id_seq'::regclass);
+
+
+--
+-- Name: promotions id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.promotions ALTER COLUMN id SET DEFAULT nextval('public.promotions_id_seq'::regclass);
+
+
+--
+-- Name: reservations id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.reservations ALTER COLUMN id SET DEFAULT nextval('public.reservations_id_seq'::regclass);
+
+
+--
+-- Name: reviews id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.reviews ALTER COLUMN id SET DEFAULT nextval('public.reviews_id_seq'::regclass);
+
+
+--
+-- Name: stock_movements id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.stock_movements ALTER COLUMN id SET DEFAULT nextval('public.stock_movements_id_seq'::regclass);
+
+
+--
+-- Name: tags id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.tags ALTER COLUMN id SET DEFAULT nextval('public.tags_id_seq'::regclass);
+
+
+--
+-- Name: webhook_deliveries id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.webhook_deliveries ALTER COLUMN id SET DEFAULT nextval('public.webhook_deliveries_id_seq'::regclass);
+
+
+--
+-- Name: webhook_subscriptions id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.webhook_subscriptions ALTER COLUMN id SET DEFAULT nextval('public.webhook_subscriptions_id_seq'::regclass);
+
+
+--
+-- Name: works id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.works ALTER COLUMN id SET DEFAULT nextval('public.works_id_seq'::regclass);
+
+
+--
+-- Name: book_audit_logs book_audit_logs_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.book_audit_logs
+    ADD CONSTRAINT book_audit_logs_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: book_categories book_categories_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.book_categories
+    ADD CONSTRAINT book_categories_pkey PRIMARY KEY (book_id, category_id);
+
+
+--
+-- Name: book_covers book_covers_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.book_covers
+    ADD CONSTRAINT book_covers_pkey PRIMARY KEY (book_id);
+
+
+--
+-- Name: book_prices book_prices_book_id_effective_from_key; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.book_prices
+    ADD CONSTRAINT book_prices_book_id_effective_from_key UNIQUE (book_id, effective_from);
+
+
+--
+-- Name: book_prices book_prices_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.book_prices
+    ADD CONSTRAINT book_prices_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: book_ratings book_ratings_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.book_ratings
+    ADD CONSTRAINT book_ratings_pkey PRIMARY KEY (book_id);
+
+
+--
+-- Name: book_redirects book_redirects_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.book_redirects
+    ADD CONSTRAINT book_redirects_pkey PRIMARY KEY (source_id);
+
+
+--
+-- Name: book_tags book_tags_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.book_tags
+    ADD CONSTRAINT book_tags_pkey PRIMARY KEY (book_id, tag_id);
+
+
+--
+-- Name: books books_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.books
+    ADD CONSTRAINT books_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: catalog_versions catalog_versions_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.catalog_versions
+    ADD CONSTRAINT catalog_versions_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: categories categories_parent_id_name_key; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.categories
+    ADD CONSTRAINT categories_parent_id_name_key UNIQUE NULLS NOT DISTINCT (parent_id, name);
+
+
+--
+-- Name: categories categories_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.categories
+    ADD CONSTRAINT categories_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: exchange_rates exchange_rates_base_currency_quote_currency_effective_date_key; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.exchange_rates
+    ADD CONSTRAINT exchange_rates_base_currency_quote_currency_effective_date_key UNIQUE (base_currency, quote_currency, effective_date);
+
+
+--
+-- Name: exchange_rates exchange_rates_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.exchange_rates
+    ADD CONSTRAINT exchange_rates_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: idempotency_keys idempotency_keys_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.idempotency_keys
+    ADD CONSTRAINT idempotency_keys_pkey PRIMARY KEY (idempotency_key);
+
+
+--
+-- Name: inventories inventories_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.inventories
+    ADD CONSTRAINT inventories_pkey PRIMARY KEY (book_id, location);
+
+
+--
+-- Name: jobs jobs_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.jobs
+    ADD CONSTRAINT jobs_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: loans loans_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.loans
+    ADD CONSTRAINT loans_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: members members_email_key; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.members
+    ADD CONSTRAINT members_email_key UNIQUE (email);
+
+
+--
+-- Name: members members_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.members
+    ADD CONSTRAINT members_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: outbox_events outbox_events_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.outbox_events
+    ADD CONSTRAINT outbox_events_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: promotions promotions_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.promotions
+    ADD CONSTRAINT promotions_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: reservations reservations_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.reservations
+    ADD CONSTRAINT reservations_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: reviews reviews_book_id_member_id_key; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.reviews
+    ADD CONSTRAINT reviews_book_id_member_id_key UNIQUE (book_id, member_id);
+
+
+--
+-- Name: reviews reviews_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.reviews
+    ADD CONSTRAINT reviews_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: schema_migrations schema_migrations_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.schema_migrations
+    ADD CONSTRAINT schema_migrations_pkey PRIMARY KEY (version);
+
+
+--
+-- Name: stock_movements stock_movements_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.stock_movements
+    ADD CONSTRAINT stock_movements_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: tags tags_name_key; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.tags
+    ADD CONSTRAINT tags_name_key UNIQUE (name);
+
+
+--
+-- Name: tags tags_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.tags
+    ADD CONSTRAINT tags_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: webhook_deliveries webhook_deliveries_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.webhook_deliveries
+    ADD CONSTRAINT webhook_deliveries_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: webhook_deliveries webhook_deliveries_subscription_id_event_id_key; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.webhook_deliveries
+    ADD CONSTRAINT webhook_deliveries_subscription_id_event_id_key UNIQUE (subscription_id, event_id);
+
+
+--
+-- Name: webhook_subscriptions webhook_subscriptions_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.webhook_subscriptions
+    ADD CONSTRAINT webhook_subscriptions_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: works works_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.works
+    ADD CONSTRAINT works_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: book_audit_logs_book_id_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX book_audit_logs_book_id_idx ON public.book_audit_logs USING btree (book_id, id);
+
+
+--
+-- Name: book_categories_category_id_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX book_categories_category_id_idx ON public.book_categories USING btree (category_id);
+
+
+--
+-- Name: book_prices_effective_from_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX book_prices_effective_from_idx ON public.book_prices USING btree (effective_from);
+
+
+--
+-- Name: book_prices_effective_to_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX book_prices_effective_to_idx ON public.book_prices USING btree (effective_to);
+
+
+--
+-- Name: book_redirects_target_id_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX book_redirects_target_id_idx ON public.book_redirects USING btree (target_id);
+
+
+--
+-- Name: book_tags_tag_id_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX book_tags_tag_id_idx ON public.book_tags USING btree (tag_id);
+
+
+--
+-- Name: books_isbn_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX books_isbn_idx ON public.books USING btree (isbn);
+
+
+--
+-- Name: books_title_author_trgm_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX books_title_author_trgm_idx ON public.books USING gin (lower((((COALESCE(title, ''::character varying))::text || ' '::text) || (COALESCE(author, ''::character varying))::text)) public.gin_trgm_ops);
+
+
+--
+-- Name: books_updated_at_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX books_updated_at_idx ON public.books USING btree (updated_at);
+
+
+--
+-- Name: books_work_id_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX books_work_id_idx ON public.books USING btree (work_id);
+
+
+--
+-- Name: exchange_rates_effective_date_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX exchange_rates_effective_date_idx ON public.exchange_rates USING btree (effective_date);
+
+
+--
+-- Name: idempotency_keys_expires_at_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX idempotency_keys_expires_at_idx ON public.idempotency_keys USING btree (expires_at);
+
+
+--
+-- Name: jobs_unfinished_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX jobs_unfinished_idx ON public.jobs USING btree (id) WHERE ((status)::text = ANY ((ARRAY['queued'::character varying, 'running'::character varying])::text[]));
+
+
+--
+-- Name: loans_active_book_id_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX loans_active_book_id_idx ON public.loans USING btree (book_id) WHERE (returned_at IS NULL);
+
+
+--
+-- Name: loans_active_member_id_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX loans_active_member_id_idx ON public.loans USING btree (member_id) WHERE (returned_at IS NULL);
+
+
+--
+-- Name: outbox_events_pending_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX outbox_events_pending_idx ON public.outbox_events USING btree (next_attempt_at, id) WHERE (delivered_at IS NULL);
+
+
+--
+-- Name: outbox_events_tx_id_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX outbox_events_tx_id_idx ON public.outbox_events USING btree (tx_id, id);
+
+
+--
+-- Name: promotions_ends_at_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX promotions_ends_at_idx ON public.promotions USING btree (ends_at);
+
+
+--
+-- Name: promotions_starts_at_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX promotions_starts_at_idx ON public.promotions USING btree (starts_at);
+
+
+--
+-- Name: promotions_target_book_id_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX promotions_target_book_id_idx ON public.promotions USING btree (target_book_id);
+
+
+--
+-- Name: promotions_target_type_target_name_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX promotions_target_type_target_name_idx ON public.promotions USING btree (target_type, target_name);
+
+
+--
+-- Name: reservations_active_book_id_member_id_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE UNIQUE INDEX reservations_active_book_id_member_id_idx ON public.reservations USING btree (book_id, member_id) WHERE ((status)::text = ANY ((ARRAY['waiting'::character varying, 'held'::character varying])::text[]));
+
+
+--
+-- Name: reviews_member_id_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX reviews_member_id_idx ON public.reviews USING btree (member_id);
+
+
+--
+-- Name: stock_movements_book_id_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX stock_movements_book_id_idx ON public.stock_movements USING btree (book_id);
+
+
+--
+-- Name: tags_name_pattern_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX tags_name_pattern_idx ON public.tags USING btree (name text_pattern_ops);
+
+
+--
+-- Name: webhook_deliveries_dead_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX webhook_deliveries_dead_idx ON public.webhook_deliveries USING btree (id) WHERE ((status)::text = 'dead'::text);
+
+
+--
+-- Name: webhook_deliveries_pending_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX webhook_deliveries_pending_idx ON public.webhook_deliveries USING btree (next_attempt_at, id) WHERE ((status)::text = 'pending'::text);
+
+
+--
+-- Name: book_categories book_categories_bump_catalog_version; Type: TRIGGER; Schema: public; Owner: -
+--
+
+CREATE CONSTRAINT TRIGGER book_categories_bump_catalog_version AFTER INSERT OR DELETE OR UPDATE ON public.book_categories DEFERRABLE INITIALLY DEFERRED FOR EACH ROW EXECUTE FUNCTION public.bump_catalog_version();
+
+
+--
+-- Name: book_prices book_prices_bump_catalog_version; Type: TRIGGER; Schema: public; Owner: -
+--
+
+CREATE CONSTRAINT TRIGGER book_prices_bump_catalog_version AFTER INSERT OR DELETE OR UPDATE ON public.book_prices DEFERRABLE INITIALLY DEFERRED FOR EACH ROW EXECUTE FUNCTION public.bump_catalog_version();
+
+
+--
+-- Name: book_ratings book_ratings_bump_catalog_version; Type: TRIGGER; Schema: public; Owner: -
+--
+
+CREATE CONSTRAINT TRIGGER book_ratings_bump_catalog_version AFTER INSERT OR DELETE OR UPDATE ON public.book_ratings DEFERRABLE INITIALLY DEFERRED FOR EACH ROW EXECUTE FUNCTION public.bump_catalog_version();
+
+
+--
+-- Name: book_tags book_tags_bump_catalog_version; Type: TRIGGER; Schema: public; Owner: -
+--
+
+CREATE CONSTRAINT TRIGGER book_tags_bump_catalog_version AFTER INSERT OR DELETE OR UPDATE ON public.book_tags DEFERRABLE INITIALLY DEFERRED FOR EACH ROW EXECUTE FUNCTION public.bump_catalog_version();
+
+
+--
+-- Name: books books_bump_catalog_version; Type: TRIGGER; Schema: public; Owner: -
+--
+
+CREATE CONSTRAINT TRIGGER books_bump_catalog_version AFTER INSERT OR DELETE OR UPDATE ON public.books DEFERRABLE INITIALLY DEFERRED FOR EACH ROW EXECUTE FUNCTION public.bump_catalog_version();
+
+
+--
+-- Name: books books_set_updated_at; Type: TRIGGER; Schema: public; Owner: -
+--
+
+CREATE TRIGGER books_set_updated_at BEFORE UPDATE ON public.books FOR EACH ROW EXECUTE FUNCTION public.set_updated_at();
+
+
+--
+-- Name: categories categories_bump_catalog_version; Type: TRIGGER; Schema: public; Owner: -
+--
+
+CREATE CONSTRAINT TRIGGER categories_bump_catalog_version AFTER INSERT OR DELETE OR UPDATE ON public.categories DEFERRABLE INITIALLY DEFERRED FOR EACH ROW EXECUTE FUNCTION public.bump_catalog_version();
+
+
+--
+-- Name: exchange_rates exchange_rates_bump_catalog_version; Type: TRIGGER; Schema: public; Owner: -
+--
+
+CREATE CONSTRAINT TRIGGER exchange_rates_bump_catalog_version AFTER INSERT OR DELETE OR UPDATE ON public.exchange_rates DEFERRABLE INITIALLY DEFERRED FOR EACH ROW EXECUTE FUNCTION public.bump_catalog_version();
+
+
+--
+-- Name: inventories inventories_bump_catalog_version; Type: TRIGGER; Schema: public; Owner: -
+--
+
+CREATE CONSTRAINT TRIGGER inventories_bump_catalog_version AFTER INSERT OR DELETE OR UPDATE ON public.inventories DEFERRABLE INITIALLY DEFERRED FOR EACH ROW EXECUTE FUNCTION public.bump_catalog_version();
+
+
+--
+-- Name: outbox_events outbox_events_notify; Type: TRIGGER; Schema: public; Owner: -
+--
+
+CREATE TRIGGER outbox_events_notify AFTER INSERT ON public.outbox_events FOR EACH ROW EXECUTE FUNCTION public.notify_outbox_event();
+
+
+--
+-- Name: promotions promotions_bump_catalog_version; Type: TRIGGER; Schema: public; Owner: -
+--
+
+CREATE CONSTRAINT TRIGGER promotions_bump_catalog_version AFTER INSERT OR DELETE OR UPDATE ON public.promotions DEFERRABLE INITIALLY DEFERRED FOR EACH ROW EXECUTE FUNCTION public.bump_catalog_version();
+
+
+--
+-- Name: tags tags_bump_catalog_version; Type: TRIGGER; Schema: public; Owner: -
+--
+
+CREATE CONSTRAINT TRIGGER tags_bump_catalog_version AFTER INSERT OR DELETE OR UPDATE ON public.tags DEFERRABLE INITIALLY DEFERRED FOR EACH ROW EXECUTE FUNCTION public.bump_catalog_version();
+
+
+--
+-- Name: book_categories book_categories_book_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.book_categories
+    ADD CONSTRAINT book_categories_book_id_fkey FOREIGN KEY (book_id) REFERENCES public.books(id) ON DELETE CASCADE;
+
+
+--
+-- Name: book_categories book_categories_category_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.book_categories
+    ADD CONSTRAINT book_categories_category_id_fkey FOREIGN KEY (category_id) REFERENCES public.categories(id) ON DELETE CASCADE;
+
+
+--
+-- Name: book_covers book_covers_book_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.book_covers
+    ADD CONSTRAINT book_covers_book_id_fkey FOREIGN KEY (book_id) REFERENCES public.books(id) ON DELETE CASCADE;
+
+
+--
+-- Name: book_prices book_prices_book_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.book_prices
+    ADD CONSTRAINT book_prices_book_id_fkey FOREIGN KEY (book_id) REFERENCES public.books(id) ON DELETE CASCADE;
+
+
+--
+-- Name: book_ratings book_ratings_book_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.book_ratings
+    ADD CONSTRAINT book_ratings_book_id_fkey FOREIGN KEY (book_id) REFERENCES public.books(id) ON DELETE CASCADE;
+
+
+--
+-- Name: book_redirects book_redirects_target_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.book_redirects
+    ADD CONSTRAINT book_redirects_target_id_fkey FOREIGN KEY (target_id) REFERENCES public.books(id) ON DELETE CASCADE;
+
+
+--
+-- Name: book_tags book_tags_book_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.book_tags
+    ADD CONSTRAINT book_tags_book_id_fkey FOREIGN KEY (book_id) REFERENCES public.books(id) ON DELETE CASCADE;
+
+
+--
+-- Name: book_tags book_tags_tag_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.book_tags
+    ADD CONSTRAINT book_tags_tag_id_fkey FOREIGN KEY (tag_id) REFERENCES public.tags(id) ON DELETE CASCADE;
+
+
+--
+-- Name: books books_work_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.books
+    ADD CONSTRAINT books_work_id_fkey FOREIGN KEY (work_id) REFERENCES public.works(id) ON DELETE SET NULL;
+
+
+--
+-- Name: categories categories_parent_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.categories
+    ADD CONSTRAINT categories_parent_id_fkey FOREIGN KEY (parent_id) REFERENCES public.categories(id);
+
+
+--
+-- Name: inventories inventories_book_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.inventories
+    ADD CONSTRAINT inventories_book_id_fkey FOREIGN KEY (book_id) REFERENCES public.books(id) ON DELETE CASCADE;
+
+
+--
+-- Name: loans loans_book_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.loans
+    ADD CONSTRAINT loans_book_id_fkey FOREIGN KEY (book_id) REFERENCES public.books(id) ON DELETE CASCADE;
+
+
+--
+-- Name: loans loans_member_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.loans
+    ADD CONSTRAINT loans_member_id_fkey FOREIGN KEY (member_id) REFERENCES public.members(id) ON DELETE CASCADE;
+
+
+--
+-- Name: promotions promotions_target_book_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.promotions
+    ADD CONSTRAINT promotions_target_book_id_fkey FOREIGN KEY (target_book_id) REFERENCES public.books(id) ON DELETE CASCADE;
+
+
+--
+-- Name: reservations reservations_book_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.reservations
+    ADD CONSTRAINT reservations_book_id_fkey FOREIGN KEY (book_id) REFERENCES public.books(id) ON DELETE CASCADE;
+
+
+--
+-- Name: reservations reservations_member_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.reservations
+    ADD CONSTRAINT reservations_member_id_fkey FOREIGN KEY (member_id) REFERENCES public.members(id) ON DELETE CASCADE;
+
+
+--
+-- Name: reviews reviews_book_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.reviews
+    ADD CONSTRAINT reviews_book_id_fkey FOREIGN KEY (book_id) REFERENCES public.books(id) ON DELETE CASCADE;
+
+
+--
+-- Name: reviews reviews_member_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.reviews
+    ADD CONSTRAINT reviews_member_id_fkey FOREIGN KEY (member_id) REFERENCES public.members(id) ON DELETE CASCADE;
+
+
+--
+-- Name: stock_movements stock_movements_book_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.stock_movements
+    ADD CONSTRAINT stock_movements_book_id_fkey FOREIGN KEY (book_id) REFERENCES public.books(id) ON DELETE CASCADE;
+
+
+--
+-- Name: webhook_deliveries webhook_deliveries_subscription_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.webhook_deliveries
+    ADD CONSTRAINT webhook_deliveries_subscription_id_fkey FOREIGN KEY (subscription_id) REFERENCES public.webhook_subscriptions(id) ON DELETE CASCADE;
 
 
 --
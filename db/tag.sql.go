@@ -0,0 +1,132 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: tag.sql
+
+package db
+
+import (
+	"context"
+)
+
+const createBookTag = `-- name: CreateBookTag :exec
+INSERT INTO book_tags (book_id, tag_id)
+    VALUES ($1, $2)
+    ON CONFLICT DO NOTHING
+`
+
+type CreateBookTagParams struct {
+	BookID int32
+	TagID  int32
+}
+
+func (q *Queries) CreateBookTag(ctx context.Context, arg CreateBookTagParams) error {
+	_, err := q.db.Exec(ctx, createBookTag, arg.BookID, arg.TagID)
+	return err
+}
+
+const deleteBookTagByName = `-- name: DeleteBookTagByName :execrows
+DELETE
+    FROM book_tags
+    USING tags
+    WHERE book_tags.tag_id = tags.id
+    AND book_tags.book_id = $1
+    AND tags.name = $2
+`
+
+type DeleteBookTagByNameParams struct {
+	BookID int32
+	Name   string
+}
+
+func (q *Queries) DeleteBookTagByName(ctx context.Context, arg DeleteBookTagByNameParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteBookTagByName, arg.BookID, arg.Name)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const listTagsByBookID = `-- name: ListTagsByBookID :many
+SELECT tags.id, tags.name, tags.created_at
+    FROM tags
+    INNER JOIN book_tags ON book_tags.tag_id = tags.id
+    WHERE book_tags.book_id = $1
+    ORDER BY tags.name
+`
+
+func (q *Queries) ListTagsByBookID(ctx context.Context, bookID int32) ([]Tag, error) {
+	rows, err := q.db.Query(ctx, listTagsByBookID, bookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Tag
+	for rows.Next() {
+		var i Tag
+		if err := rows.Scan(&i.ID, &i.Name, &i.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchTagsByPrefix = `-- name: SearchTagsByPrefix :many
+SELECT tags.id, tags.name, count(book_tags.book_id) AS usage_count
+    FROM tags
+    LEFT JOIN book_tags ON book_tags.tag_id = tags.id
+    WHERE tags.name LIKE $1::text
+    GROUP BY tags.id
+    ORDER BY usage_count DESC, tags.name
+    LIMIT $2
+`
+
+type SearchTagsByPrefixParams struct {
+	Pattern string
+	Limit   int32
+}
+
+type SearchTagsByPrefixRow struct {
+	ID         int32
+	Name       string
+	UsageCount int64
+}
+
+func (q *Queries) SearchTagsByPrefix(ctx context.Context, arg SearchTagsByPrefixParams) ([]SearchTagsByPrefixRow, error) {
+	rows, err := q.db.Query(ctx, searchTagsByPrefix, arg.Pattern, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SearchTagsByPrefixRow
+	for rows.Next() {
+		var i SearchTagsByPrefixRow
+		if err := rows.Scan(&i.ID, &i.Name, &i.UsageCount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertTag = `-- name: UpsertTag :one
+INSERT INTO tags (name)
+    VALUES ($1)
+    ON CONFLICT (name) DO UPDATE
+        SET name = EXCLUDED.name
+    RETURNING id, name, created_at
+`
+
+func (q *Queries) UpsertTag(ctx context.Context, name string) (Tag, error) {
+	row := q.db.QueryRow(ctx, upsertTag, name)
+	var i Tag
+	err := row.Scan(&i.ID, &i.Name, &i.CreatedAt)
+	return i, err
+}
@@ -0,0 +1,422 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: webhook.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const claimWebhookDeliveries = `-- name: ClaimWebhookDeliveries :many
+UPDATE webhook_deliveries
+    SET attempts = webhook_deliveries.attempts + 1,
+        next_attempt_at = $1
+    FROM webhook_subscriptions
+    WHERE webhook_subscriptions.id = webhook_deliveries.subscription_id
+        AND webhook_deliveries.id IN (
+            SELECT pending.id
+                FROM webhook_deliveries AS pending
+                WHERE pending.status = 'pending'
+                    AND pending.next_attempt_at <= now()
+                ORDER BY pending.id
+                LIMIT $2
+                FOR UPDATE SKIP LOCKED
+        )
+    RETURNING webhook_deliveries.id, webhook_deliveries.event_id, webhook_deliveries.event_type,
+        webhook_deliveries.payload, webhook_deliveries.attempts, webhook_subscriptions.url, webhook_subscriptions.secret
+`
+
+type ClaimWebhookDeliveriesParams struct {
+	LeaseUntil    pgtype.Timestamptz
+	MaxDeliveries int32
+}
+
+type ClaimWebhookDeliveriesRow struct {
+	ID        int64
+	EventID   int64
+	EventType string
+	Payload   []byte
+	Attempts  int32
+	Url       string
+	Secret    string
+}
+
+func (q *Queries) ClaimWebhookDeliveries(ctx context.Context, arg ClaimWebhookDeliveriesParams) ([]ClaimWebhookDeliveriesRow, error) {
+	rows, err := q.db.Query(ctx, claimWebhookDeliveries, arg.LeaseUntil, arg.MaxDeliveries)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ClaimWebhookDeliveriesRow
+	for rows.Next() {
+		var i ClaimWebhookDeliveriesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.EventID,
+			&i.EventType,
+			&i.Payload,
+			&i.Attempts,
+			&i.Url,
+			&i.Secret,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const createWebhookDeliveries = `-- name: CreateWebhookDeliveries :execrows
+INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload)
+    SELECT id, $1, $2, $3
+        FROM webhook_subscriptions
+        WHERE active
+            AND $2::varchar = ANY(event_types)
+    ON CONFLICT (subscription_id, event_id) DO NOTHING
+`
+
+type CreateWebhookDeliveriesParams struct {
+	EventID   int64
+	EventType string
+	Payload   []byte
+}
+
+func (q *Queries) CreateWebhookDeliveries(ctx context.Context, arg CreateWebhookDeliveriesParams) (int64, error) {
+	result, err := q.db.Exec(ctx, createWebhookDeliveries, arg.EventID, arg.EventType, arg.Payload)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const createWebhookSubscription = `-- name: CreateWebhookSubscription :one
+INSERT INTO webhook_subscriptions (url, event_types, secret)
+    VALUES ($1, $2, $3)
+    RETURNING id, url, event_types, secret, active, created_at
+`
+
+type CreateWebhookSubscriptionParams struct {
+	Url        string
+	EventTypes []string
+	Secret     string
+}
+
+func (q *Queries) CreateWebhookSubscription(ctx context.Context, arg CreateWebhookSubscriptionParams) (WebhookSubscription, error) {
+	row := q.db.QueryRow(ctx, createWebhookSubscription, arg.Url, arg.EventTypes, arg.Secret)
+	var i WebhookSubscription
+	err := row.Scan(
+		&i.ID,
+		&i.Url,
+		&i.EventTypes,
+		&i.Secret,
+		&i.Active,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteWebhookSubscription = `-- name: DeleteWebhookSubscription :execrows
+DELETE
+    FROM webhook_subscriptions
+    WHERE id = $1
+`
+
+func (q *Queries) DeleteWebhookSubscription(ctx context.Context, id int32) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteWebhookSubscription, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getWebhookSubscriptionByID = `-- name: GetWebhookSubscriptionByID :one
+SELECT id, url, event_types, secret, active, created_at
+    FROM webhook_subscriptions
+    WHERE id = $1
+`
+
+func (q *Queries) GetWebhookSubscriptionByID(ctx context.Context, id int32) (WebhookSubscription, error) {
+	row := q.db.QueryRow(ctx, getWebhookSubscriptionByID, id)
+	var i WebhookSubscription
+	err := row.Scan(
+		&i.ID,
+		&i.Url,
+		&i.EventTypes,
+		&i.Secret,
+		&i.Active,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listDeadWebhookDeliveries = `-- name: ListDeadWebhookDeliveries :many
+SELECT id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at,
+        response_status, last_error, created_at, delivered_at
+    FROM webhook_deliveries
+    WHERE status = 'dead'
+    ORDER BY id DESC
+    LIMIT $1
+`
+
+func (q *Queries) ListDeadWebhookDeliveries(ctx context.Context, maxDeliveries int32) ([]WebhookDelivery, error) {
+	rows, err := q.db.Query(ctx, listDeadWebhookDeliveries, maxDeliveries)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WebhookDelivery
+	for rows.Next() {
+		var i WebhookDelivery
+		if err := rows.Scan(
+			&i.ID,
+			&i.SubscriptionID,
+			&i.EventID,
+			&i.EventType,
+			&i.Payload,
+			&i.Status,
+			&i.Attempts,
+			&i.NextAttemptAt,
+			&i.ResponseStatus,
+			&i.LastError,
+			&i.CreatedAt,
+			&i.DeliveredAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWebhookDeliveries = `-- name: ListWebhookDeliveries :many
+SELECT id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at,
+        response_status, last_error, created_at, delivered_at
+    FROM webhook_deliveries
+    WHERE subscription_id = $1
+        AND ($2::varchar IS NULL OR status = $2::varchar)
+    ORDER BY id DESC
+    LIMIT $3
+`
+
+type ListWebhookDeliveriesParams struct {
+	SubscriptionID int32
+	Status         pgtype.Text
+	MaxDeliveries  int32
+}
+
+func (q *Queries) ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error) {
+	rows, err := q.db.Query(ctx, listWebhookDeliveries, arg.SubscriptionID, arg.Status, arg.MaxDeliveries)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WebhookDelivery
+	for rows.Next() {
+		var i WebhookDelivery
+		if err := rows.Scan(
+			&i.ID,
+			&i.SubscriptionID,
+			&i.EventID,
+			&i.EventType,
+			&i.Payload,
+			&i.Status,
+			&i.Attempts,
+			&i.NextAttemptAt,
+			&i.ResponseStatus,
+			&i.LastError,
+			&i.CreatedAt,
+			&i.DeliveredAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWebhookSubscriptions = `-- name: ListWebhookSubscriptions :many
+SELECT id, url, event_types, secret, active, created_at
+    FROM webhook_subscriptions
+    ORDER BY id
+`
+
+func (q *Queries) ListWebhookSubscriptions(ctx context.Context) ([]WebhookSubscription, error) {
+	rows, err := q.db.Query(ctx, listWebhookSubscriptions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WebhookSubscription
+	for rows.Next() {
+		var i WebhookSubscription
+		if err := rows.Scan(
+			&i.ID,
+			&i.Url,
+			&i.EventTypes,
+			&i.Secret,
+			&i.Active,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markWebhookDeliveryFailed = `-- name: MarkWebhookDeliveryFailed :exec
+UPDATE webhook_deliveries
+    SET status = $2,
+        next_attempt_at = $3,
+        response_status = $4,
+        last_error = $5
+    WHERE id = $1
+`
+
+type MarkWebhookDeliveryFailedParams struct {
+	ID             int64
+	Status         string
+	NextAttemptAt  pgtype.Timestamptz
+	ResponseStatus pgtype.Int4
+	LastError      pgtype.Text
+}
+
+func (q *Queries) MarkWebhookDeliveryFailed(ctx context.Context, arg MarkWebhookDeliveryFailedParams) error {
+	_, err := q.db.Exec(ctx, markWebhookDeliveryFailed,
+		arg.ID,
+		arg.Status,
+		arg.NextAttemptAt,
+		arg.ResponseStatus,
+		arg.LastError,
+	)
+	return err
+}
+
+const markWebhookDeliverySucceeded = `-- name: MarkWebhookDeliverySucceeded :exec
+UPDATE webhook_deliveries
+    SET status = 'succeeded',
+        response_status = $2,
+        last_error = NULL,
+        delivered_at = now()
+    WHERE id = $1
+`
+
+type MarkWebhookDeliverySucceededParams struct {
+	ID             int64
+	ResponseStatus pgtype.Int4
+}
+
+func (q *Queries) MarkWebhookDeliverySucceeded(ctx context.Context, arg MarkWebhookDeliverySucceededParams) error {
+	_, err := q.db.Exec(ctx, markWebhookDeliverySucceeded, arg.ID, arg.ResponseStatus)
+	return err
+}
+
+const releaseWebhookDeliveries = `-- name: ReleaseWebhookDeliveries :exec
+UPDATE webhook_deliveries
+    SET attempts = attempts - 1,
+        next_attempt_at = now()
+    WHERE id = ANY($1::bigint[])
+        AND status = 'pending'
+        AND next_attempt_at = $2
+`
+
+type ReleaseWebhookDeliveriesParams struct {
+	Ids        []int64
+	LeaseUntil pgtype.Timestamptz
+}
+
+func (q *Queries) ReleaseWebhookDeliveries(ctx context.Context, arg ReleaseWebhookDeliveriesParams) error {
+	_, err := q.db.Exec(ctx, releaseWebhookDeliveries, arg.Ids, arg.LeaseUntil)
+	return err
+}
+
+const requeueWebhookDelivery = `-- name: RequeueWebhookDelivery :one
+UPDATE webhook_deliveries
+    SET status = 'pending',
+        attempts = 0,
+        next_attempt_at = now(),
+        delivered_at = NULL
+    WHERE id = $1
+        AND subscription_id = $2
+    RETURNING id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at,
+        response_status, last_error, created_at, delivered_at
+`
+
+type RequeueWebhookDeliveryParams struct {
+	ID             int64
+	SubscriptionID int32
+}
+
+func (q *Queries) RequeueWebhookDelivery(ctx context.Context, arg RequeueWebhookDeliveryParams) (WebhookDelivery, error) {
+	row := q.db.QueryRow(ctx, requeueWebhookDelivery, arg.ID, arg.SubscriptionID)
+	var i WebhookDelivery
+	err := row.Scan(
+		&i.ID,
+		&i.SubscriptionID,
+		&i.EventID,
+		&i.EventType,
+		&i.Payload,
+		&i.Status,
+		&i.Attempts,
+		&i.NextAttemptAt,
+		&i.ResponseStatus,
+		&i.LastError,
+		&i.CreatedAt,
+		&i.DeliveredAt,
+	)
+	return i, err
+}
+
+const updateWebhookSubscription = `-- name: UpdateWebhookSubscription :one
+UPDATE webhook_subscriptions
+    SET url = COALESCE($1::varchar, url),
+        event_types = COALESCE($2::varchar[], event_types),
+        secret = COALESCE($3::varchar, secret),
+        active = COALESCE($4::boolean, active)
+    WHERE id = $5
+    RETURNING id, url, event_types, secret, active, created_at
+`
+
+type UpdateWebhookSubscriptionParams struct {
+	Url        pgtype.Text
+	EventTypes []string
+	Secret     pgtype.Text
+	Active     pgtype.Bool
+	ID         int32
+}
+
+func (q *Queries) UpdateWebhookSubscription(ctx context.Context, arg UpdateWebhookSubscriptionParams) (WebhookSubscription, error) {
+	row := q.db.QueryRow(ctx, updateWebhookSubscription,
+		arg.Url,
+		arg.EventTypes,
+		arg.Secret,
+		arg.Active,
+		arg.ID,
+	)
+	var i WebhookSubscription
+	err := row.Scan(
+		&i.ID,
+		&i.Url,
+		&i.EventTypes,
+		&i.Secret,
+		&i.Active,
+		&i.CreatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,157 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: work.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const clearBookWork = `-- name: ClearBookWork :execrows
+UPDATE books
+    SET work_id = NULL
+    WHERE id = $1
+    AND work_id = $2
+`
+
+type ClearBookWorkParams struct {
+	ID     int32
+	WorkID pgtype.Int4
+}
+
+func (q *Queries) ClearBookWork(ctx context.Context, arg ClearBookWorkParams) (int64, error) {
+	result, err := q.db.Exec(ctx, clearBookWork, arg.ID, arg.WorkID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const createWork = `-- name: CreateWork :one
+INSERT INTO works (title, author)
+    VALUES ($1, $2)
+    RETURNING id, title, author, created_at
+`
+
+type CreateWorkParams struct {
+	Title  string
+	Author pgtype.Text
+}
+
+func (q *Queries) CreateWork(ctx context.Context, arg CreateWorkParams) (Work, error) {
+	row := q.db.QueryRow(ctx, createWork, arg.Title, arg.Author)
+	var i Work
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Author,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getWorkByID = `-- name: GetWorkByID :one
+SELECT id, title, author, created_at
+    FROM works
+    WHERE id = $1
+`
+
+func (q *Queries) GetWorkByID(ctx context.Context, id int32) (Work, error) {
+	row := q.db.QueryRow(ctx, getWorkByID, id)
+	var i Work
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Author,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listBooksByWorkID = `-- name: ListBooksByWorkID :many
+SELECT id, title, author, publisher, price,
+        subtitle, edition, publication_date, language, page_count, format, description, series, series_volume, work_id, isbn, updated_at
+    FROM books
+    WHERE work_id = $1
+    ORDER BY publication_date NULLS LAST, id
+`
+
+func (q *Queries) ListBooksByWorkID(ctx context.Context, workID pgtype.Int4) ([]Book, error) {
+	rows, err := q.db.Query(ctx, listBooksByWorkID, workID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Book
+	for rows.Next() {
+		var i Book
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Author,
+			&i.Publisher,
+			&i.Price,
+			&i.Subtitle,
+			&i.Edition,
+			&i.PublicationDate,
+			&i.Language,
+			&i.PageCount,
+			&i.Format,
+			&i.Description,
+			&i.Series,
+			&i.SeriesVolume,
+			&i.WorkID,
+			&i.Isbn,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateBookWork = `-- name: UpdateBookWork :one
+UPDATE books
+    SET work_id = $2
+    WHERE id = $1
+    RETURNING id, title, author, publisher, price,
+        subtitle, edition, publication_date, language, page_count, format, description, series, series_volume, work_id, isbn, updated_at
+`
+
+type UpdateBookWorkParams struct {
+	ID     int32
+	WorkID pgtype.Int4
+}
+
+func (q *Queries) UpdateBookWork(ctx context.Context, arg UpdateBookWorkParams) (Book, error) {
+	row := q.db.QueryRow(ctx, updateBookWork, arg.ID, arg.WorkID)
+	var i Book
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Author,
+		&i.Publisher,
+		&i.Price,
+		&i.Subtitle,
+		&i.Edition,
+		&i.PublicationDate,
+		&i.Language,
+		&i.PageCount,
+		&i.Format,
+		&i.Description,
+		&i.Series,
+		&i.SeriesVolume,
+		&i.WorkID,
+		&i.Isbn,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,79 @@
+package event
+
+import (
+	"sync"
+)
+
+const (
+	defaultBufferSize = 64
+)
+
+// Subscription はBrokerが配信するイベントの購読
+type Subscription struct {
+	events chan Event
+}
+
+// Events はイベントを受け取るチャネルを返す
+// 受け取りが遅れてバッファがあふれた購読はBrokerが打ち切り、チャネルを閉じる
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Broker はイベントを購読中の全てのSubscriptionへ配信する
+// 配信はバッファが空いている購読にのみ行い、受け取りの遅い購読のために他の購読や配信元を待たせない
+type Broker struct {
+	mu            sync.Mutex
+	subscriptions map[*Subscription]struct{}
+	bufferSize    int
+}
+
+// NewBroker はbufferSizeが0以下の場合は既定値を使う
+func NewBroker(bufferSize int) *Broker {
+	if bufferSize <= 0 {
+		bufferSize = defaultBufferSize
+	}
+
+	return &Broker{
+		subscriptions: map[*Subscription]struct{}{},
+		bufferSize:    bufferSize,
+	}
+}
+
+func (b *Broker) Subscribe() *Subscription {
+	s := &Subscription{events: make(chan Event, b.bufferSize)}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscriptions[s] = struct{}{}
+
+	return s
+}
+
+// Unsubscribe は打ち切り済みの購読に対しては何もしない
+func (b *Broker) Unsubscribe(s *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.remove(s)
+}
+
+// Publish はイベントを全ての購読へ配信し、バッファがあふれた購読を打ち切る
+func (b *Broker) Publish(ev Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for s := range b.subscriptions {
+		select {
+		case s.events <- ev:
+		default:
+			b.remove(s)
+		}
+	}
+}
+
+func (b *Broker) remove(s *Subscription) {
+	if _, ok := b.subscriptions[s]; !ok {
+		return
+	}
+	delete(b.subscriptions, s)
+	close(s.events)
+}
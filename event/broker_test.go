@@ -0,0 +1,36 @@
+package event_test
+
+import (
+	"testing"
+
+	"github.com/rentaro-m-b/ai-model-exam/event"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBrokerPublish(t *testing.T) {
+	broker := event.NewBroker(2)
+	fast := broker.Subscribe()
+	slow := broker.Subscribe()
+
+	broker.Publish(event.Event{ID: 1})
+	broker.Publish(event.Event{ID: 2})
+	assert.Equal(t, int64(1), (<-fast.Events()).ID)
+	assert.Equal(t, int64(2), (<-fast.Events()).ID)
+
+	// バッファがあふれた購読のみ打ち切る
+	broker.Publish(event.Event{ID: 3})
+	assert.Equal(t, int64(3), (<-fast.Events()).ID)
+
+	var received []int64
+	for ev := range slow.Events() {
+		received = append(received, ev.ID)
+	}
+	assert.Equal(t, []int64{1, 2}, received)
+
+	// 打ち切り済みの購読の解除は何もしない
+	broker.Unsubscribe(slow)
+	broker.Unsubscribe(fast)
+	_, ok := <-fast.Events()
+	assert.False(t, ok)
+	broker.Publish(event.Event{ID: 4})
+}
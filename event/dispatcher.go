@@ -0,0 +1,145 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+	"time"
+)
+
+const (
+	defaultBatchSize    = 100
+	defaultPollInterval = time.Second
+	defaultLease        = time.Minute
+	defaultBaseBackoff  = time.Second
+	defaultMaxBackoff   = 10 * time.Minute
+)
+
+// Store はアウトボックスに登録されたイベントの取得と配信結果の記録を行う
+type Store interface {
+	// ClaimEvents は配信待ちのイベントを最大limit件取得し、leaseUntilまで他の配信処理に渡さないようにする
+	// 配信結果を記録する前に処理が止まった場合、leaseUntilを過ぎると再び配信の対象になる
+	ClaimEvents(ctx context.Context, limit int, leaseUntil time.Time) ([]Event, error)
+	MarkDelivered(ctx context.Context, id int64) error
+	// Reschedule は配信に失敗したイベントをretryAtに再配信するよう記録する
+	Reschedule(ctx context.Context, id int64, retryAt time.Time, cause string) error
+}
+
+// DispatcherConfig の未指定（ゼロ値）の項目には既定値を使う
+type DispatcherConfig struct {
+	// BatchSize は1回に取得するイベントの最大件数
+	BatchSize int
+	// PollInterval は配信待ちのイベントが無い場合に次の取得まで待つ時間
+	PollInterval time.Duration
+	// Lease は取得したイベントの配信にかけられる時間
+	Lease time.Duration
+	// BaseBackoff は初回の配信失敗から再配信までの時間で、失敗するごとに倍にする
+	BaseBackoff time.Duration
+	// MaxBackoff は再配信までの時間の上限
+	MaxBackoff time.Duration
+}
+
+// Dispatcher はアウトボックスのイベントを登録順に全てのSinkへ配信する
+// いずれかのSinkへの配信に失敗したイベントは、時間を空けて全てのSinkへ配信し直す
+type Dispatcher struct {
+	store  Store
+	sinks  []Sink
+	config DispatcherConfig
+	now    func() time.Time
+}
+
+func NewDispatcher(store Store, sinks []Sink, config *DispatcherConfig) *Dispatcher {
+	c := DispatcherConfig{}
+	if config != nil {
+		c = *config
+	}
+	if c.BatchSize <= 0 {
+		c.BatchSize = defaultBatchSize
+	}
+	if c.PollInterval <= 0 {
+		c.PollInterval = defaultPollInterval
+	}
+	if c.Lease <= 0 {
+		c.Lease = defaultLease
+	}
+	if c.BaseBackoff <= 0 {
+		c.BaseBackoff = defaultBaseBackoff
+	}
+	if c.MaxBackoff <= 0 {
+		c.MaxBackoff = defaultMaxBackoff
+	}
+
+	return &Dispatcher{
+		store:  store,
+		sinks:  sinks,
+		config: c,
+		now:    time.Now,
+	}
+}
+
+// Run はctxが終了するまでイベントを配信し続ける
+// 配信待ちのイベントが残っている間は待たずに次を取得する
+func (d *Dispatcher) Run(ctx context.Context) {
+	for {
+		n, err := d.DispatchOnce(ctx)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			log.Printf("Unable to execute DispatcherRun: %d\n", err)
+		}
+		if err == nil && n == d.config.BatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(d.config.PollInterval):
+		}
+	}
+}
+
+// DispatchOnce は配信待ちのイベントを1回分取得して配信し、取得した件数を返す
+func (d *Dispatcher) DispatchOnce(ctx context.Context) (int, error) {
+	events, err := d.store.ClaimEvents(ctx, d.config.BatchSize, d.now().Add(d.config.Lease))
+	if err != nil {
+		return 0, err
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].ID < events[j].ID })
+
+	for _, ev := range events {
+		if err := d.deliver(ctx, ev); err != nil {
+			log.Printf("Unable to deliver event %d: %d\n", ev.ID, err)
+			retryAt := d.now().Add(Backoff(d.config.BaseBackoff, d.config.MaxBackoff, ev.Attempts))
+			if err := d.store.Reschedule(ctx, ev.ID, retryAt, err.Error()); err != nil {
+				return len(events), err
+			}
+			continue
+		}
+		if err := d.store.MarkDelivered(ctx, ev.ID); err != nil {
+			return len(events), err
+		}
+	}
+
+	return len(events), nil
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, ev Event) error {
+	for _, sink := range d.sinks {
+		if err := sink.Deliver(ctx, ev); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Backoff はattempts回目の配信に失敗した後、再配信までに空ける時間を返す
+// 初回の失敗はbaseで、失敗するごとに倍にしてlimitを上限とする
+func Backoff(base time.Duration, limit time.Duration, attempts int) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay < limit; i++ {
+		delay *= 2
+	}
+
+	return min(delay, limit)
+}
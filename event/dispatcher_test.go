@@ -0,0 +1,130 @@
+package event_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rentaro-m-b/ai-model-exam/event"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryStore はイベントをメモリ上に保持するevent.Storeの実装
+type memoryStore struct {
+	pending     []event.Event
+	delivered   []int64
+	rescheduled map[int64]time.Time
+}
+
+func (s *memoryStore) ClaimEvents(ctx context.Context, limit int, leaseUntil time.Time) ([]event.Event, error) {
+	n := min(limit, len(s.pending))
+	claimed := s.pending[:n]
+	s.pending = s.pending[n:]
+	for i := range claimed {
+		claimed[i].Attempts++
+	}
+
+	return claimed, nil
+}
+
+func (s *memoryStore) MarkDelivered(ctx context.Context, id int64) error {
+	s.delivered = append(s.delivered, id)
+	return nil
+}
+
+func (s *memoryStore) Reschedule(ctx context.Context, id int64, retryAt time.Time, cause string) error {
+	s.rescheduled[id] = retryAt
+	return nil
+}
+
+func TestDispatchOnce(t *testing.T) {
+	store := &memoryStore{
+		// 取得順によらず登録順に配信する
+		pending: []event.Event{
+			{ID: 2, Type: event.TypeBookUpdated, BookID: 1},
+			{ID: 1, Type: event.TypeBookCreated, BookID: 1},
+		},
+		rescheduled: map[int64]time.Time{},
+	}
+	var received []int64
+	sink := event.SinkFunc(func(ctx context.Context, ev event.Event) error {
+		received = append(received, ev.ID)
+		return nil
+	})
+
+	d := event.NewDispatcher(store, []event.Sink{sink}, nil)
+	n, err := d.DispatchOnce(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []int64{1, 2}, received)
+	assert.Equal(t, []int64{1, 2}, store.delivered)
+	assert.Empty(t, store.rescheduled)
+}
+
+func TestDispatchOnceRetryBackoff(t *testing.T) {
+	store := &memoryStore{
+		pending: []event.Event{
+			{ID: 1, Type: event.TypeBookCreated, BookID: 1},
+			{ID: 2, Type: event.TypeBookCreated, BookID: 2, Attempts: 2},
+			{ID: 3, Type: event.TypeBookCreated, BookID: 3, Attempts: 20},
+		},
+		rescheduled: map[int64]time.Time{},
+	}
+	var received []int64
+	ok := event.SinkFunc(func(ctx context.Context, ev event.Event) error {
+		received = append(received, ev.ID)
+		return nil
+	})
+	failing := event.SinkFunc(func(ctx context.Context, ev event.Event) error {
+		return errors.New("sink error")
+	})
+
+	d := event.NewDispatcher(store, []event.Sink{ok, failing}, &event.DispatcherConfig{
+		BaseBackoff: time.Second,
+		MaxBackoff:  time.Minute,
+	})
+	before := time.Now()
+	n, err := d.DispatchOnce(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+	// 失敗したイベントは配信済みにせず、成功したSinkにも再び配信する
+	assert.Equal(t, []int64{1, 2, 3}, received)
+	assert.Empty(t, store.delivered)
+
+	// 1回目の失敗は1秒後、3回目の失敗は4秒後、それ以降は上限の1分後に再配信する
+	cases := map[int64]time.Duration{1: time.Second, 2: 4 * time.Second, 3: time.Minute}
+	for id, delay := range cases {
+		retryAt := store.rescheduled[id]
+		assert.WithinDuration(t, before.Add(delay), retryAt, time.Second)
+	}
+}
+
+func TestRun(t *testing.T) {
+	store := &memoryStore{
+		pending:     []event.Event{{ID: 1, Type: event.TypeBookCreated, BookID: 1}},
+		rescheduled: map[int64]time.Time{},
+	}
+	delivered := make(chan event.Event, 1)
+	sink := event.SinkFunc(func(ctx context.Context, ev event.Event) error {
+		delivered <- ev
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	d := event.NewDispatcher(store, []event.Sink{sink}, &event.DispatcherConfig{PollInterval: 10 * time.Millisecond})
+	go func() {
+		d.Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case ev := <-delivered:
+		assert.Equal(t, int64(1), ev.ID)
+	case <-time.After(time.Second):
+		t.Fatal("event was not delivered")
+	}
+	cancel()
+	<-done
+}
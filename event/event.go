@@ -0,0 +1,181 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rentaro-m-b/ai-model-exam/db"
+)
+
+// Type はドメインイベントの種類
+type Type string
+
+const (
+	TypeBookCreated Type = "book.created"
+	TypeBookUpdated Type = "book.updated"
+	TypeBookDeleted Type = "book.deleted"
+)
+
+// IsKnownType はtがこのサーバが発行するイベントの種類であるかを返す
+func IsKnownType(t string) bool {
+	switch Type(t) {
+	case TypeBookCreated, TypeBookUpdated, TypeBookDeleted:
+		return true
+	}
+
+	return false
+}
+
+// Event は書籍の変更を下流のシステムへ伝えるドメインイベント
+// 変更と同じトランザクションでアウトボックスに登録し、Dispatcherが登録順に配信する
+type Event struct {
+	// ID はアウトボックスでの連番で、登録前は0
+	ID int64
+	// TxID はアウトボックスに登録したトランザクションのID
+	TxID       int64
+	Type       Type
+	BookID     int32
+	Payload    json.RawMessage
+	OccurredAt time.Time
+	// Attempts は今回を含めた配信の試行回数
+	Attempts int
+}
+
+// Cursor はアウトボックスのイベントを読み出した位置
+// IDの連番は登録したトランザクションのコミット順と一致せず、小さいIDが後からコミットされることがあるため、
+// 登録したトランザクションのIDとイベントのIDの組で順序を決める
+type Cursor struct {
+	TxID int64
+	ID   int64
+}
+
+// Cursor はイベントの位置を返す
+func (e *Event) Cursor() Cursor {
+	return Cursor{TxID: e.TxID, ID: e.ID}
+}
+
+// After はcがoより後の位置であるかを返す
+func (c Cursor) After(o Cursor) bool {
+	if c.TxID != o.TxID {
+		return c.TxID > o.TxID
+	}
+	return c.ID > o.ID
+}
+
+// String はServer-Sent EventsのIDに使う "<トランザクションのID>-<イベントのID>" の形式で返す
+func (c Cursor) String() string {
+	return fmt.Sprintf("%d-%d", c.TxID, c.ID)
+}
+
+// ParseCursor はCursor.Stringの形式の位置を読み込む
+// トランザクションのIDを記録する前のイベントのIDのみの形式は、トランザクションのIDを0とする
+func ParseCursor(s string) (Cursor, error) {
+	txId, id, found := strings.Cut(s, "-")
+	if !found {
+		txId, id = "0", s
+	}
+	c := Cursor{}
+	var err error
+	if c.TxID, err = strconv.ParseInt(txId, 10, 64); err != nil {
+		return Cursor{}, err
+	}
+	if c.ID, err = strconv.ParseInt(id, 10, 64); err != nil {
+		return Cursor{}, err
+	}
+	if c.TxID < 0 || c.ID < 0 {
+		return Cursor{}, fmt.Errorf("invalid cursor %q", s)
+	}
+
+	return c, nil
+}
+
+// BookPayload は書籍の登録・更新イベントに載せる、変更後の書籍の内容
+type BookPayload struct {
+	ID        int32   `json:"id"`
+	Title     string  `json:"title"`
+	Author    string  `json:"author"`
+	Publisher string  `json:"publisher"`
+	Price     *int32  `json:"price"`
+	ISBN      *string `json:"isbn"`
+	WorkID    *int32  `json:"work_id"`
+}
+
+// BookDeletedPayload は書籍の削除イベントに載せる内容
+// 統合により削除された場合は統合先のIDを持つ
+type BookDeletedPayload struct {
+	ID         int32  `json:"id"`
+	MergedInto *int32 `json:"merged_into"`
+}
+
+func NewBookCreated(book *db.Book) (Event, error) {
+	return newEvent(TypeBookCreated, book.ID, newBookPayload(book))
+}
+
+func NewBookUpdated(book *db.Book) (Event, error) {
+	return newEvent(TypeBookUpdated, book.ID, newBookPayload(book))
+}
+
+// NewBookDeleted は書籍が削除されたことを表すイベントを返す
+func NewBookDeleted(bookId int32) (Event, error) {
+	return newEvent(TypeBookDeleted, bookId, BookDeletedPayload{ID: bookId})
+}
+
+// NewBookMerged は統合元の書籍が統合先へまとめられて削除されたことを表すイベントを返す
+func NewBookMerged(sourceId int32, targetId int32) (Event, error) {
+	return newEvent(TypeBookDeleted, sourceId, BookDeletedPayload{ID: sourceId, MergedInto: &targetId})
+}
+
+func newBookPayload(book *db.Book) BookPayload {
+	payload := BookPayload{
+		ID:        book.ID,
+		Title:     book.Title.String,
+		Author:    book.Author.String,
+		Publisher: book.Publisher.String,
+	}
+	if book.Price.Valid {
+		payload.Price = &book.Price.Int32
+	}
+	if book.Isbn.Valid {
+		payload.ISBN = &book.Isbn.String
+	}
+	if book.WorkID.Valid {
+		payload.WorkID = &book.WorkID.Int32
+	}
+
+	return payload
+}
+
+func newEvent(t Type, bookId int32, payload any) (Event, error) {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return Event{}, err
+	}
+
+	return Event{Type: t, BookID: bookId, Payload: b}, nil
+}
+
+// Sink はイベントの配信先
+// 配信は少なくとも1回行われ、失敗や再起動で同じイベントが複数回届くことがあるため、受け手はIDで重複を除く
+type Sink interface {
+	Deliver(ctx context.Context, ev Event) error
+}
+
+// SinkFunc は関数をSinkとして使うためのアダプタ
+type SinkFunc func(ctx context.Context, ev Event) error
+
+func (f SinkFunc) Deliver(ctx context.Context, ev Event) error {
+	return f(ctx, ev)
+}
+
+// LogSink はイベントをログに書き出す
+type LogSink struct{}
+
+func (LogSink) Deliver(ctx context.Context, ev Event) error {
+	log.Printf("Book event %d %s book=%d payload=%s\n", ev.ID, ev.Type, ev.BookID, ev.Payload)
+	return nil
+}
@@ -0,0 +1,29 @@
+package event_test
+
+import (
+	"testing"
+
+	"github.com/rentaro-m-b/ai-model-exam/event"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursor(t *testing.T) {
+	// トランザクションのIDを先に比べる
+	assert.True(t, event.Cursor{TxID: 2, ID: 1}.After(event.Cursor{TxID: 1, ID: 5}))
+	assert.True(t, event.Cursor{TxID: 1, ID: 6}.After(event.Cursor{TxID: 1, ID: 5}))
+	assert.False(t, event.Cursor{TxID: 1, ID: 5}.After(event.Cursor{TxID: 1, ID: 5}))
+
+	c, err := event.ParseCursor(event.Cursor{TxID: 100, ID: 5}.String())
+	assert.NoError(t, err)
+	assert.Equal(t, event.Cursor{TxID: 100, ID: 5}, c)
+
+	// トランザクションのIDを記録する前のイベントのIDのみの形式
+	c, err = event.ParseCursor("5")
+	assert.NoError(t, err)
+	assert.Equal(t, event.Cursor{ID: 5}, c)
+
+	for _, s := range []string{"", "abc", "1-", "-5", "1-2-3"} {
+		_, err := event.ParseCursor(s)
+		assert.Error(t, err, s)
+	}
+}
@@ -0,0 +1,126 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+)
+
+const (
+	defaultRelayPollInterval = 5 * time.Second
+)
+
+// Feed はアウトボックスに登録されたイベントをCursorの順に読み出す
+// 実行中のトランザクションが後からコミットするイベントを飛ばさないよう、コミットが確定した位置までのイベントのみを返す
+type Feed interface {
+	// LatestCursor は読み出せるイベントの最後の位置を返し、イベントが無い場合はゼロ値を返す
+	LatestCursor(ctx context.Context) (Cursor, error)
+	// ListEventsAfter はafterより後の位置のイベントをCursorの順に最大limit件返す
+	ListEventsAfter(ctx context.Context, after Cursor, limit int) ([]Event, error)
+}
+
+// Notifier はアウトボックスへのイベントの登録を待つ
+type Notifier interface {
+	// WaitForEvent はイベントが登録されるか、ctxが終了するまで待つ
+	WaitForEvent(ctx context.Context) error
+}
+
+// RelayConfig の未指定（ゼロ値）の項目には既定値を使う
+type RelayConfig struct {
+	// BatchSize は1回に読み出すイベントの最大件数
+	BatchSize int
+	// PollInterval は通知が無くても新しいイベントを読み出すまでの時間で、通知を取りこぼした場合に備える
+	PollInterval time.Duration
+}
+
+// Relay はアウトボックスに登録されたイベントを、通知を受けるたびに読み出してBrokerへ配信する
+// 配信先への到達は保証しないため、取りこぼした分は受け手がアウトボックスから位置を指定して読み直す
+type Relay struct {
+	feed     Feed
+	notifier Notifier
+	broker   *Broker
+	config   RelayConfig
+	// last は配信済みのイベントの最後の位置
+	last Cursor
+}
+
+func NewRelay(feed Feed, notifier Notifier, broker *Broker, config *RelayConfig) *Relay {
+	c := RelayConfig{}
+	if config != nil {
+		c = *config
+	}
+	if c.BatchSize <= 0 {
+		c.BatchSize = defaultBatchSize
+	}
+	if c.PollInterval <= 0 {
+		c.PollInterval = defaultRelayPollInterval
+	}
+
+	return &Relay{
+		feed:     feed,
+		notifier: notifier,
+		broker:   broker,
+		config:   c,
+	}
+}
+
+// Run はctxが終了するまで新しく登録されたイベントを配信し続ける
+// 開始前に登録済みのイベントは配信しない
+func (r *Relay) Run(ctx context.Context) {
+	for {
+		last, err := r.feed.LatestCursor(ctx)
+		if err == nil {
+			r.last = last
+			break
+		}
+		log.Printf("Unable to execute RelayRun: %d\n", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(r.config.PollInterval):
+		}
+	}
+
+	for {
+		if _, err := r.RelayOnce(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			log.Printf("Unable to execute RelayRun: %d\n", err)
+		}
+
+		waitCtx, cancel := context.WithTimeout(ctx, r.config.PollInterval)
+		err := r.notifier.WaitForEvent(waitCtx)
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+		// 待機中に接続が切れた場合は、次の待機で接続し直す前に間隔を空ける
+		if err != nil && !errors.Is(err, context.DeadlineExceeded) {
+			log.Printf("Unable to execute RelayRun: %d\n", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(r.config.PollInterval):
+			}
+		}
+	}
+}
+
+// RelayOnce は前回までに配信したイベントより後に登録されたイベントを全て配信し、配信した件数を返す
+func (r *Relay) RelayOnce(ctx context.Context) (int, error) {
+	n := 0
+	for {
+		events, err := r.feed.ListEventsAfter(ctx, r.last, r.config.BatchSize)
+		if err != nil {
+			return n, err
+		}
+		for _, ev := range events {
+			r.broker.Publish(ev)
+			r.last = ev.Cursor()
+		}
+		n += len(events)
+		if len(events) < r.config.BatchSize {
+			return n, nil
+		}
+	}
+}
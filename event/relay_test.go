@@ -0,0 +1,91 @@
+package event_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rentaro-m-b/ai-model-exam/event"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryFeed はイベントをメモリ上に保持するevent.Feedの実装
+type memoryFeed struct {
+	events chan []event.Event
+	stored []event.Event
+}
+
+func (f *memoryFeed) LatestCursor(ctx context.Context) (event.Cursor, error) {
+	if len(f.stored) == 0 {
+		return event.Cursor{}, nil
+	}
+	return f.stored[len(f.stored)-1].Cursor(), nil
+}
+
+// ListEventsAfter はstoredがCursorの順に並んでいるものとして返す
+func (f *memoryFeed) ListEventsAfter(ctx context.Context, after event.Cursor, limit int) ([]event.Event, error) {
+	var events []event.Event
+	for _, ev := range f.stored {
+		if ev.Cursor().After(after) && len(events) < limit {
+			events = append(events, ev)
+		}
+	}
+	return events, nil
+}
+
+// WaitForEvent は登録されたイベントをfeedに追加してから戻るevent.Notifierの実装
+func (f *memoryFeed) WaitForEvent(ctx context.Context) error {
+	select {
+	case events := <-f.events:
+		f.stored = append(f.stored, events...)
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func TestRelayOnce(t *testing.T) {
+	feed := &memoryFeed{stored: []event.Event{{TxID: 1, ID: 1}, {TxID: 1, ID: 2}, {TxID: 2, ID: 4}}}
+	broker := event.NewBroker(10)
+	sub := broker.Subscribe()
+
+	// 1回に読み出す件数を超える分も続けて読み出す
+	relay := event.NewRelay(feed, feed, broker, &event.RelayConfig{BatchSize: 2})
+	n, err := relay.RelayOnce(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+
+	// 配信済みのイベントは配信せず、配信済みのIDより小さいIDのイベントも後からコミットされた場合は配信する
+	feed.stored = append(feed.stored, event.Event{TxID: 3, ID: 3})
+	n, err = relay.RelayOnce(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	broker.Unsubscribe(sub)
+	var received []int64
+	for ev := range sub.Events() {
+		received = append(received, ev.ID)
+	}
+	assert.Equal(t, []int64{1, 2, 4, 3}, received)
+}
+
+func TestRelayRun(t *testing.T) {
+	// 開始前に登録済みのイベントは配信しない
+	feed := &memoryFeed{events: make(chan []event.Event), stored: []event.Event{{ID: 1}}}
+	broker := event.NewBroker(10)
+	sub := broker.Subscribe()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		event.NewRelay(feed, feed, broker, &event.RelayConfig{PollInterval: time.Minute}).Run(ctx)
+		close(done)
+	}()
+
+	feed.events <- []event.Event{{ID: 2}, {ID: 3}}
+	assert.Equal(t, int64(2), (<-sub.Events()).ID)
+	assert.Equal(t, int64(3), (<-sub.Events()).ID)
+
+	cancel()
+	<-done
+}
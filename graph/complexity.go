@@ -0,0 +1,136 @@
+package graph
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+const (
+	MaxComplexity = 1000
+	MaxDepth      = 6
+
+	defaultLimit = 20
+	maxLimit     = 100
+)
+
+// CheckComplexity はクエリを実行する前に深さと計算量を見積もり、上限を超えていればエラーを返す
+// フィールド1つにつきコスト1とし、一覧を返すフィールドは子のコストにlimitを掛ける
+// 構文エラーや操作の指定誤りは実行時に位置情報付きで報告されるため、ここでは扱わない
+func CheckComplexity(query string, operationName string, variables map[string]interface{}) error {
+	doc, err := parser.Parse(parser.ParseParams{Source: query})
+	if err != nil {
+		return nil
+	}
+
+	var operation *ast.OperationDefinition
+	fragments := map[string]*ast.FragmentDefinition{}
+	for _, def := range doc.Definitions {
+		switch d := def.(type) {
+		case *ast.OperationDefinition:
+			if operationName == "" || (d.Name != nil && d.Name.Value == operationName) {
+				operation = d
+			}
+		case *ast.FragmentDefinition:
+			fragments[d.Name.Value] = d
+		}
+	}
+	if operation == nil {
+		return nil
+	}
+
+	c := &complexityCalculator{
+		fragments: fragments,
+		variables: variables,
+	}
+	cost := c.selectionSet(operation.SelectionSet, 1)
+	if c.depth > MaxDepth {
+		return fmt.Errorf("query depth %d exceeds the maximum of %d", c.depth, MaxDepth)
+	}
+	if cost > MaxComplexity {
+		return fmt.Errorf("query complexity %d exceeds the maximum of %d", cost, MaxComplexity)
+	}
+
+	return nil
+}
+
+type complexityCalculator struct {
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+	visiting  map[string]bool
+	depth     int
+}
+
+func (c *complexityCalculator) selectionSet(set *ast.SelectionSet, depth int) int {
+	if set == nil {
+		return 0
+	}
+	if depth > c.depth {
+		c.depth = depth
+	}
+
+	cost := 0
+	for _, selection := range set.Selections {
+		switch s := selection.(type) {
+		case *ast.Field:
+			cost += 1 + c.multiplier(s)*c.selectionSet(s.SelectionSet, depth+1)
+		case *ast.InlineFragment:
+			cost += c.selectionSet(s.SelectionSet, depth)
+		case *ast.FragmentSpread:
+			name := s.Name.Value
+			fragment, ok := c.fragments[name]
+			if !ok || c.visiting[name] {
+				continue
+			}
+			if c.visiting == nil {
+				c.visiting = map[string]bool{}
+			}
+			c.visiting[name] = true
+			cost += c.selectionSet(fragment.SelectionSet, depth)
+			delete(c.visiting, name)
+		}
+	}
+
+	return cost
+}
+
+// 一覧を返すフィールドはlimit引数を持つため、その値を子のコストの倍率とする
+func (c *complexityCalculator) multiplier(field *ast.Field) int {
+	for _, arg := range field.Arguments {
+		if arg.Name.Value != "limit" {
+			continue
+		}
+		switch v := arg.Value.(type) {
+		case *ast.IntValue:
+			if n, err := strconv.Atoi(v.Value); err == nil {
+				return clampLimit(n)
+			}
+		case *ast.Variable:
+			switch n := c.variables[v.Name.Value].(type) {
+			case float64:
+				return clampLimit(int(n))
+			case int:
+				return clampLimit(n)
+			}
+		}
+		return defaultLimit
+	}
+	if field.Name.Value == "books" {
+		return defaultLimit
+	}
+
+	return 1
+}
+
+func clampLimit(n int) int {
+	if n < 1 {
+		return 1
+	}
+	if n > maxLimit {
+		return maxLimit
+	}
+
+	return n
+}
@@ -0,0 +1,65 @@
+package graph
+
+import (
+	"context"
+	"sync"
+)
+
+type BatchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+// Loader は同一リクエスト内で要求されたキーをまとめ、一度のBatchFunc呼び出しで解決する
+type Loader[K comparable, V any] struct {
+	batch   BatchFunc[K, V]
+	mu      sync.Mutex
+	pending []K
+	results map[K]*loaderResult[V]
+}
+
+type loaderResult[V any] struct {
+	value V
+	err   error
+	done  bool
+}
+
+func NewLoader[K comparable, V any](batch BatchFunc[K, V]) *Loader[K, V] {
+	return &Loader[K, V]{
+		batch:   batch,
+		results: map[K]*loaderResult[V]{},
+	}
+}
+
+// Load はキーを予約し、値を取り出す関数を返す
+// 返した関数が最初に呼ばれた時点で、それまでに予約された全てのキーをまとめて取得する
+func (l *Loader[K, V]) Load(ctx context.Context, key K) func() (V, error) {
+	l.mu.Lock()
+	r, ok := l.results[key]
+	if !ok {
+		r = &loaderResult[V]{}
+		l.results[key] = r
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (V, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if !r.done {
+			l.dispatch(ctx)
+		}
+
+		return r.value, r.err
+	}
+}
+
+func (l *Loader[K, V]) dispatch(ctx context.Context) {
+	keys := l.pending
+	l.pending = nil
+
+	values, err := l.batch(ctx, keys)
+	for _, key := range keys {
+		r := l.results[key]
+		r.value = values[key]
+		r.err = err
+		r.done = true
+	}
+}
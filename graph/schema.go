@@ -0,0 +1,332 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/guregu/null"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/handler/request"
+	"github.com/rentaro-m-b/ai-model-exam/usecase"
+)
+
+type Server struct {
+	schema  graphql.Schema
+	usecase usecase.BookUsecase
+}
+
+func NewServer(usecase usecase.BookUsecase) (*Server, error) {
+	schema, err := newSchema(usecase)
+	if err != nil {
+		log.Printf("Unable to execute GraphNewServer: %d\n", err)
+		return nil, err
+	}
+
+	return &Server{
+		schema:  schema,
+		usecase: usecase,
+	}, nil
+}
+
+func (s *Server) Do(ctx context.Context, query string, operationName string, variables map[string]interface{}) *graphql.Result {
+	if err := CheckComplexity(query, operationName, variables); err != nil {
+		return &graphql.Result{
+			Errors: []gqlerrors.FormattedError{gqlerrors.NewFormattedError(err.Error())},
+		}
+	}
+
+	return graphql.Do(graphql.Params{
+		Schema:         s.schema,
+		RequestString:  query,
+		VariableValues: variables,
+		OperationName:  operationName,
+		Context:        withLoaders(ctx, s.usecase),
+	})
+}
+
+type author struct {
+	name string
+}
+
+type publisher struct {
+	name string
+}
+
+type loadersKey struct{}
+
+type loaders struct {
+	booksByAuthor    *Loader[string, []db.Book]
+	booksByPublisher *Loader[string, []db.Book]
+}
+
+// ローダはリクエスト単位で作成し、リクエストをまたいで結果を共有しない
+func withLoaders(ctx context.Context, usecase usecase.BookUsecase) context.Context {
+	return context.WithValue(ctx, loadersKey{}, &loaders{
+		booksByAuthor: NewLoader(func(ctx context.Context, keys []string) (map[string][]db.Book, error) {
+			books, err := usecase.FetchBooksByAuthors(ctx, keys)
+			if err != nil {
+				return nil, err
+			}
+			res := map[string][]db.Book{}
+			for _, book := range books {
+				res[book.Author.String] = append(res[book.Author.String], book)
+			}
+			return res, nil
+		}),
+		booksByPublisher: NewLoader(func(ctx context.Context, keys []string) (map[string][]db.Book, error) {
+			books, err := usecase.FetchBooksByPublishers(ctx, keys)
+			if err != nil {
+				return nil, err
+			}
+			res := map[string][]db.Book{}
+			for _, book := range books {
+				res[book.Publisher.String] = append(res[book.Publisher.String], book)
+			}
+			return res, nil
+		}),
+	})
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+func newSchema(usecase usecase.BookUsecase) (graphql.Schema, error) {
+	bookType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Book",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Int),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return int(p.Source.(db.Book).ID), nil
+				},
+			},
+			"title": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(db.Book).Title.String, nil
+				},
+			},
+			"price": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Int),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return int(p.Source.(db.Book).Price.Int32), nil
+				},
+			},
+		},
+	})
+
+	limitArgument := &graphql.ArgumentConfig{
+		Type:         graphql.Int,
+		DefaultValue: defaultLimit,
+	}
+
+	authorType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Author",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(author).name, nil
+				},
+			},
+			"books": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(bookType))),
+				Args: graphql.FieldConfigArgument{
+					"limit": limitArgument,
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					thunk := loadersFrom(p.Context).booksByAuthor.Load(p.Context, p.Source.(author).name)
+					return func() (interface{}, error) {
+						books, err := thunk()
+						if err != nil {
+							return nil, err
+						}
+						return truncateBooks(books, p.Args["limit"].(int)), nil
+					}, nil
+				},
+			},
+		},
+	})
+
+	publisherType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Publisher",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(publisher).name, nil
+				},
+			},
+			"books": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(bookType))),
+				Args: graphql.FieldConfigArgument{
+					"limit": limitArgument,
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					thunk := loadersFrom(p.Context).booksByPublisher.Load(p.Context, p.Source.(publisher).name)
+					return func() (interface{}, error) {
+						books, err := thunk()
+						if err != nil {
+							return nil, err
+						}
+						return truncateBooks(books, p.Args["limit"].(int)), nil
+					}, nil
+				},
+			},
+		},
+	})
+
+	// BookとAuthor、Publisherは互いに参照し合うため、後からフィールドを追加する
+	bookType.AddFieldConfig("author", &graphql.Field{
+		Type: graphql.NewNonNull(authorType),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return author{name: p.Source.(db.Book).Author.String}, nil
+		},
+	})
+	bookType.AddFieldConfig("publisher", &graphql.Field{
+		Type: graphql.NewNonNull(publisherType),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return publisher{name: p.Source.(db.Book).Publisher.String}, nil
+		},
+	})
+
+	bookFilterType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "BookFilter",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"title":     &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"author":    &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"publisher": &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"minPrice":  &graphql.InputObjectFieldConfig{Type: graphql.Int},
+			"maxPrice":  &graphql.InputObjectFieldConfig{Type: graphql.Int},
+		},
+	})
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"book": &graphql.Field{
+				Type: bookType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					book, err := usecase.FindBookById(p.Context, p.Args["id"].(int))
+					if errors.Is(err, pgx.ErrNoRows) {
+						return nil, nil
+					}
+					if err != nil {
+						return nil, err
+					}
+					return *book, nil
+				},
+			},
+			"books": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(bookType))),
+				Args: graphql.FieldConfigArgument{
+					"filter": &graphql.ArgumentConfig{Type: bookFilterType},
+					"limit":  limitArgument,
+					"offset": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 0},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					param := db.SearchBooksParams{
+						Limit:  int32(clampLimit(p.Args["limit"].(int))),
+						Offset: int32(max(p.Args["offset"].(int), 0)),
+					}
+					if filter, ok := p.Args["filter"].(map[string]interface{}); ok {
+						param.Title = textArg(filter, "title")
+						param.Author = textArg(filter, "author")
+						param.Publisher = textArg(filter, "publisher")
+						param.MinPrice = intArg(filter, "minPrice")
+						param.MaxPrice = intArg(filter, "maxPrice")
+					}
+					books, err := usecase.SearchBooks(p.Context, &param)
+					if err != nil {
+						return nil, err
+					}
+					return books, nil
+				},
+			},
+		},
+	})
+
+	createBookInputType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "CreateBookInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"title":     &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"author":    &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"publisher": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"price":     &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.Int)},
+		},
+	})
+
+	mutationType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createBook": &graphql.Field{
+				Type: graphql.NewNonNull(bookType),
+				Args: graphql.FieldConfigArgument{
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(createBookInputType)},
+					"force": &graphql.ArgumentConfig{Type: graphql.Boolean, DefaultValue: false},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					input := p.Args["input"].(map[string]interface{})
+					body := request.CreateBookRequest{
+						Title:     null.StringFrom(input["title"].(string)),
+						Author:    null.StringFrom(input["author"].(string)),
+						Publisher: null.StringFrom(input["publisher"].(string)),
+						Price:     null.IntFrom(int64(input["price"].(int))),
+					}
+					if vs, ve := body.Validate(); ve != -1 {
+						return nil, fmt.Errorf("%s must not be blank.", vs)
+					}
+
+					param := db.CreateBookParams{
+						Title:     pgtype.Text{String: body.Title.String, Valid: true},
+						Author:    pgtype.Text{String: body.Author.String, Valid: true},
+						Publisher: pgtype.Text{String: body.Publisher.String, Valid: true},
+						Price:     pgtype.Int4{Int32: int32(body.Price.Int64), Valid: true},
+					}
+					book, err := usecase.CreateBook(p.Context, &param, p.Args["force"].(bool))
+					if err != nil {
+						return nil, err
+					}
+					return *book, nil
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{
+		Query:    queryType,
+		Mutation: mutationType,
+	})
+}
+
+func textArg(args map[string]interface{}, name string) pgtype.Text {
+	v, ok := args[name].(string)
+	return pgtype.Text{String: v, Valid: ok}
+}
+
+func intArg(args map[string]interface{}, name string) pgtype.Int4 {
+	v, ok := args[name].(int)
+	return pgtype.Int4{Int32: int32(v), Valid: ok}
+}
+
+func truncateBooks(books []db.Book, limit int) []db.Book {
+	limit = clampLimit(limit)
+	if len(books) > limit {
+		return books[:limit]
+	}
+	if books == nil {
+		return []db.Book{}
+	}
+
+	return books
+}
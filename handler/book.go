@@ -2,16 +2,24 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"net/url"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/labstack/echo/v4"
 	"github.com/rentaro-m-b/ai-model-exam/db"
 	"github.com/rentaro-m-b/ai-model-exam/handler/request"
 	"github.com/rentaro-m-b/ai-model-exam/handler/response"
+	"github.com/rentaro-m-b/ai-model-exam/money"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
 	"github.com/rentaro-m-b/ai-model-exam/usecase"
 )
 
@@ -19,20 +27,288 @@ type BookHandler interface {
 	FetchBooks(c echo.Context) error
 	CreateBook(c echo.Context) error
 	FindBookById(c echo.Context) error
+	UpdateBook(c echo.Context) error
+	MergeBook(c echo.Context) error
+	BulkUpdateBooks(c echo.Context) error
+	DeleteBooks(c echo.Context) error
+	FetchBookAuditLogs(c echo.Context) error
 }
 
 type bookHandlerImpl struct {
-	usecase usecase.BookUsecase
+	usecase      usecase.BookUsecase
+	priceUsecase usecase.PriceUsecase
 }
 
-func NewBookHandler(usecase usecase.BookUsecase) BookHandler {
+func NewBookHandler(usecase usecase.BookUsecase, priceUsecase usecase.PriceUsecase) BookHandler {
 	return &bookHandlerImpl{
-		usecase: usecase,
+		usecase:      usecase,
+		priceUsecase: priceUsecase,
 	}
 }
 
+// bookListCacheControl は書籍の一覧の配信時のCache-Controlヘッダ
+// CDNやクライアントのキャッシュを短時間使わせ、期限切れ後はETagによる再検証を求める
+const bookListCacheControl = "public, max-age=60, must-revalidate"
+
+// isNotModified は条件付きリクエストの検証子が現在の版と一致するかを返す
+// If-None-Matchがある場合はIf-Modified-Sinceを無視し、ETagを弱い比較で照合する
+func isNotModified(req *http.Request, etag string, lastModified time.Time) bool {
+	if inm := req.Header.Get("If-None-Match"); inm != "" {
+		for _, candidate := range strings.Split(inm, ",") {
+			candidate = strings.TrimSpace(candidate)
+			if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+		}
+		return false
+	}
+
+	ims, err := http.ParseTime(req.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+
+	return !lastModified.Truncate(time.Second).After(ims)
+}
+
+// bookListVariant は一覧の内容を変えるクエリパラメータを、指定の順序や表記によらない文字列に正規化する
+func bookListVariant(at time.Time, inStock pgtype.Bool, filter *db.FilterBooksParams, collapseByWork bool, currency string) string {
+	values := url.Values{}
+	if !at.IsZero() {
+		values.Set("at", at.UTC().Format(time.RFC3339Nano))
+	}
+	if inStock.Valid {
+		values.Set("in_stock", strconv.FormatBool(inStock.Bool))
+	}
+	if filter.CategoryID.Valid {
+		values.Set("category", strconv.Itoa(int(filter.CategoryID.Int32)))
+	}
+	if filter.Tags != nil {
+		tags := slices.Clone(filter.Tags)
+		slices.Sort(tags)
+		values.Set("tags", strings.Join(tags, ","))
+		if filter.MatchAllTags {
+			values.Set("tags_match", "all")
+		}
+	}
+	if collapseByWork {
+		values.Set("collapse", "work")
+	}
+	if currency != "" {
+		values.Set("currency", currency)
+	}
+
+	return values.Encode()
+}
+
+// parseAtParam は価格の基準日時を表すクエリパラメータatを解析する
+// 未指定の場合はゼロ値を返し、現在日時の価格が使われる
+func parseAtParam(c echo.Context) (time.Time, error) {
+	atParam := c.QueryParam("at")
+	if atParam == "" {
+		return time.Time{}, nil
+	}
+
+	return time.Parse(time.RFC3339, atParam)
+}
+
+// fetchPricing は書籍の指定日時に有効な価格と、プロモーション適用後の価格を取得する
+// クエリパラメータcurrencyが指定されていれば、価格をその通貨に換算した結果も取得する
+func (h *bookHandlerImpl) fetchPricing(c echo.Context, bookIds []int32, at time.Time) (*response.BookPricing, error) {
+	prices, err := h.priceUsecase.FetchPricesInEffect(context.Background(), bookIds, at)
+	if err != nil {
+		return nil, err
+	}
+	discounts, err := h.priceUsecase.ApplyPromotions(context.Background(), prices, at)
+	if err != nil {
+		return nil, err
+	}
+
+	var conversions map[int32]money.Conversion
+	if currency := c.QueryParam("currency"); currency != "" {
+		conversions, err = h.priceUsecase.ConvertPrices(context.Background(), prices, currency)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &response.BookPricing{
+		Prices:      prices,
+		Conversions: conversions,
+		Discounts:   discounts,
+	}, nil
+}
+
+func pricingError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, usecase.ErrUnsupportedCurrency):
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid currency parameter",
+		})
+	case errors.Is(err, usecase.ErrExchangeRateNotFound):
+		return c.JSON(http.StatusUnprocessableEntity, map[string]string{
+			"message": "Exchange rate not available",
+		})
+	}
+
+	return c.JSON(http.StatusInternalServerError, map[string]string{
+		"message": "Internal server error",
+	})
+}
+
 func (h *bookHandlerImpl) FetchBooks(c echo.Context) error {
-	books, err := h.usecase.FetchBooks(context.Background())
+	at, err := parseAtParam(c)
+	if err != nil {
+		log.Printf("Unable to execute BookHandlerFetchBooks: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid at parameter",
+		})
+	}
+
+	if idsParam := c.QueryParam("ids"); idsParam != "" {
+		return h.fetchBooksByIds(c, idsParam, at)
+	}
+
+	var inStock pgtype.Bool
+	if inStockParam := c.QueryParam("in_stock"); inStockParam != "" {
+		b, perr := strconv.ParseBool(inStockParam)
+		if perr != nil {
+			log.Printf("Unable to execute BookHandlerFetchBooks: %d\n", perr)
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"message": "Invalid in_stock parameter",
+			})
+		}
+		inStock = pgtype.Bool{Bool: b, Valid: true}
+	}
+
+	filter := db.FilterBooksParams{InStock: inStock}
+	if categoryParam := c.QueryParam("category"); categoryParam != "" {
+		categoryId, perr := strconv.Atoi(categoryParam)
+		if perr != nil {
+			log.Printf("Unable to execute BookHandlerFetchBooks: %d\n", perr)
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"message": "Invalid category parameter",
+			})
+		}
+		filter.CategoryID = pgtype.Int4{Int32: int32(categoryId), Valid: true}
+	}
+	if tagsParam := c.QueryParam("tags"); tagsParam != "" {
+		filter.Tags = strings.Split(tagsParam, ",")
+	}
+	switch c.QueryParam("tags_match") {
+	case "", "any":
+	case "all":
+		filter.MatchAllTags = true
+	default:
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid tags_match parameter",
+		})
+	}
+	var collapseByWork bool
+	switch c.QueryParam("collapse") {
+	case "":
+	case "work":
+		collapseByWork = true
+	default:
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid collapse parameter",
+		})
+	}
+
+	// 一覧の取得より前に版を取得し、取得中に更新されても古い版として次の再検証で取得し直させる
+	variant := bookListVariant(at, inStock, &filter, collapseByWork, c.QueryParam("currency"))
+	version, err := h.usecase.FetchBookCollectionVersion(context.Background(), variant)
+	if err != nil {
+		log.Printf("Unable to execute BookHandlerFetchBooks: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+	header := c.Response().Header()
+	header.Set("Cache-Control", bookListCacheControl)
+	header.Set("ETag", version.ETag)
+	header.Set("Last-Modified", version.LastModified.Format(http.TimeFormat))
+	if isNotModified(c.Request(), version.ETag, version.LastModified) {
+		return c.NoContent(http.StatusNotModified)
+	}
+
+	var books []db.Book
+	if filter.CategoryID.Valid || filter.Tags != nil {
+		books, err = h.usecase.FilterBooks(context.Background(), &filter)
+	} else if inStock.Valid {
+		books, err = h.usecase.FetchBooksByStock(context.Background(), inStock.Bool)
+	} else {
+		books, err = h.usecase.FetchBooks(context.Background())
+	}
+	if err != nil {
+		log.Printf("Unable to execute BookHandlerFetchBooks: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+	if collapseByWork {
+		books = usecase.CollapseByWork(books)
+	}
+
+	bookIds := make([]int32, 0, len(books))
+	for _, book := range books {
+		bookIds = append(bookIds, book.ID)
+	}
+	pricing, err := h.fetchPricing(c, bookIds, at)
+	if err != nil {
+		log.Printf("Unable to execute BookHandlerFetchBooks: %d\n", err)
+		return pricingError(c, err)
+	}
+	ratings, err := h.usecase.FetchBookRatings(context.Background(), bookIds)
+	if err != nil {
+		log.Printf("Unable to execute BookHandlerFetchBooks: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.ParseFetchBooksResponse(books, pricing, ratings))
+}
+
+// fetchBooksByIds は ?ids=1,2,3 で指定したIDの書籍を1回の問い合わせでまとめて返し、見つからなかったIDも返す
+// 一覧の絞り込みのクエリパラメータは使わず、条件付きリクエストにも対応しない
+func (h *bookHandlerImpl) fetchBooksByIds(c echo.Context, idsParam string, at time.Time) error {
+	var ids []int32
+	for _, idParam := range strings.Split(idsParam, ",") {
+		id, err := strconv.ParseInt(strings.TrimSpace(idParam), 10, 32)
+		if err != nil {
+			log.Printf("Unable to execute BookHandlerFetchBooks: %d\n", err)
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"message": "Invalid ids parameter",
+			})
+		}
+		ids = append(ids, int32(id))
+	}
+
+	batch, err := h.usecase.FetchBooksByIds(context.Background(), ids)
+	var tooLargeErr *usecase.BatchTooLargeError
+	if errors.As(err, &tooLargeErr) {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": fmt.Sprintf("Too many ids (max %d)", tooLargeErr.Max),
+		})
+	}
+	if err != nil {
+		log.Printf("Unable to execute BookHandlerFetchBooks: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	bookIds := make([]int32, 0, len(batch.Books))
+	for _, book := range batch.Books {
+		bookIds = append(bookIds, book.ID)
+	}
+	pricing, err := h.fetchPricing(c, bookIds, at)
+	if err != nil {
+		log.Printf("Unable to execute BookHandlerFetchBooks: %d\n", err)
+		return pricingError(c, err)
+	}
+	ratings, err := h.usecase.FetchBookRatings(context.Background(), bookIds)
 	if err != nil {
 		log.Printf("Unable to execute BookHandlerFetchBooks: %d\n", err)
 		return c.JSON(http.StatusInternalServerError, map[string]string{
@@ -40,11 +316,40 @@ func (h *bookHandlerImpl) FetchBooks(c echo.Context) error {
 		})
 	}
 
-	return c.JSON(http.StatusOK, response.ParseFetchBooksResponse(books))
+	return c.JSON(http.StatusOK, response.ParseFetchBooksByIdsResponse(batch, pricing, ratings))
 }
 
+const (
+	// headerIdempotencyKey は再試行で書籍が重複して登録されないよう、クライアントが登録ごとに一意に付けるキーのリクエストヘッダ
+	headerIdempotencyKey = "Idempotency-Key"
+	// headerIdempotentReplayed は登録せずに、同じキーで登録した時のレスポンスを返したことを表すレスポンスヘッダ
+	headerIdempotentReplayed = "Idempotent-Replayed"
+	maxIdempotencyKeyLength  = 255
+)
+
 // メモ：レスポンス値に改修の余地あり
+// 重複の疑われる書籍があれば409を返し、?force=trueの場合は確認せずに登録する
+// Idempotency-Keyヘッダがある場合は、同じキーでの再試行に最初の登録と同じ201とLocationヘッダを返し、同じキーを内容の異なるリクエストに使った場合は422を返す
 func (h *bookHandlerImpl) CreateBook(c echo.Context) error {
+	idempotencyKey := c.Request().Header.Get(headerIdempotencyKey)
+	if len(idempotencyKey) > maxIdempotencyKeyLength {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid Idempotency-Key",
+		})
+	}
+
+	var force bool
+	if forceParam := c.QueryParam("force"); forceParam != "" {
+		b, err := strconv.ParseBool(forceParam)
+		if err != nil {
+			log.Printf("Unable to execute BookHandlerCreateBook: %d\n", err)
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"message": "Invalid force parameter",
+			})
+		}
+		force = b
+	}
+
 	body := new(request.CreateBookRequest)
 	if err := c.Bind(body); err != nil {
 		log.Printf("Unable to execute BookHandlerCreateBook: %d\n", err)
@@ -52,37 +357,62 @@ func (h *bookHandlerImpl) CreateBook(c echo.Context) error {
 			"message": "Internal server error",
 		})
 	}
-	vs, ve := body.Validate()
-	if ve != -1 {
-		var errRes response.CreateBookErrorResponse
-		if ve == request.ValidationErrRequestFieldMissing {
-			errRes.Type = "about:none"
-			errRes.Detail = fmt.Sprintf("%s must not be none.", vs)
-		} else if ve == request.ValidationErrRequestFieldEmpty {
-			errRes.Type = "about:blank"
-			errRes.Detail = fmt.Sprintf("%s must not be blank.", vs)
-		}
-		errRes.Title = "request validation error is occurred."
-		errRes.Instance = "/books"
-
-		return c.JSON(http.StatusBadRequest, errRes)
+	if vs, ve := body.Validate(); ve != -1 {
+		return validationError(c, vs, ve)
 	}
 
+	metadata := parseBookMetadata(&body.BookMetadataRequest)
 	param := db.CreateBookParams{
-		Title:     pgtype.Text{String: body.Title.String, Valid: true},
-		Author:    pgtype.Text{String: body.Author.String, Valid: true},
-		Publisher: pgtype.Text{String: body.Publisher.String, Valid: true},
-		Price:     pgtype.Int4{Int32: int32(body.Price.Int64), Valid: true},
+		Title:           pgtype.Text{String: body.Title.String, Valid: true},
+		Author:          pgtype.Text{String: body.Author.String, Valid: true},
+		Publisher:       pgtype.Text{String: body.Publisher.String, Valid: true},
+		Price:           pgtype.Int4{Int32: int32(body.Price.Int64), Valid: true},
+		Subtitle:        metadata.Subtitle,
+		Edition:         metadata.Edition,
+		PublicationDate: metadata.PublicationDate,
+		Language:        metadata.Language,
+		PageCount:       metadata.PageCount,
+		Format:          metadata.Format,
+		Description:     metadata.Description,
+		Series:          metadata.Series,
+		SeriesVolume:    metadata.SeriesVolume,
+		Isbn:            metadata.ISBN,
 	}
 
-	book, err := h.usecase.CreateBook(context.Background(), &param)
+	var bookId int32
+	var err error
+	if idempotencyKey == "" {
+		var book *db.Book
+		book, err = h.usecase.CreateBook(context.Background(), &param, force)
+		if err == nil {
+			bookId = book.ID
+		}
+	} else {
+		var creation *usecase.IdempotentBookCreation
+		creation, err = h.usecase.CreateBookIdempotently(context.Background(), idempotencyKey, &param, force)
+		if err == nil {
+			bookId = creation.BookID
+			if creation.Replayed {
+				c.Response().Header().Set(headerIdempotentReplayed, "true")
+			}
+		}
+	}
+	var duplicateErr *usecase.DuplicateBookError
+	if errors.As(err, &duplicateErr) {
+		return c.JSON(http.StatusConflict, response.ParseDuplicateBooksResponse(c.Scheme()+"://"+c.Request().Host, duplicateErr.Candidates))
+	}
+	if errors.Is(err, usecase.ErrIdempotencyKeyReused) {
+		return c.JSON(http.StatusUnprocessableEntity, map[string]string{
+			"message": "Idempotency-Key was reused with a different request",
+		})
+	}
 	if err != nil {
 		log.Printf("Unable to execute BookHandlerCreateBook: %d\n", err)
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"message": "Internal server error",
 		})
 	}
-	location := fmt.Sprintf("%s/books/%d", c.Scheme()+"://"+c.Request().Host, book.ID)
+	location := fmt.Sprintf("%s/books/%d", c.Scheme()+"://"+c.Request().Host, bookId)
 	c.Response().Header().Set("Location", location)
 
 	return c.JSON(http.StatusCreated, nil)
@@ -97,8 +427,24 @@ func (h *bookHandlerImpl) FindBookById(c echo.Context) error {
 			"message": "Invalid book ID",
 		})
 	}
+	at, err := parseAtParam(c)
+	if err != nil {
+		log.Printf("Unable to execute BookHandlerFindBookById: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid at parameter",
+		})
+	}
 
 	book, err := h.usecase.FindBookById(context.Background(), id)
+	var mergedErr *usecase.BookMergedError
+	if errors.As(err, &mergedErr) {
+		// 統合された書籍は統合先へ恒久的に転送し、クエリパラメータは引き継ぐ
+		location := fmt.Sprintf("/books/%d", mergedErr.TargetID)
+		if query := c.Request().URL.RawQuery; query != "" {
+			location += "?" + query
+		}
+		return c.Redirect(http.StatusMovedPermanently, location)
+	}
 	if err != nil {
 		log.Printf("Unable to execute BookHandlerFindBookById: %d\n", err)
 		return c.JSON(http.StatusInternalServerError, map[string]string{
@@ -106,5 +452,281 @@ func (h *bookHandlerImpl) FindBookById(c echo.Context) error {
 		})
 	}
 
-	return c.JSON(http.StatusOK, response.ParseFindBookByIdResponse(book))
+	return h.bookDetail(c, book, at)
+}
+
+// bookDetail は書籍に価格と評価の集計を添えたレスポンスを返す
+func (h *bookHandlerImpl) bookDetail(c echo.Context, book *db.Book, at time.Time) error {
+	pricing, err := h.fetchPricing(c, []int32{book.ID}, at)
+	if err != nil {
+		log.Printf("Unable to execute BookHandlerBookDetail: %d\n", err)
+		return pricingError(c, err)
+	}
+	ratings, err := h.usecase.FetchBookRatings(context.Background(), []int32{book.ID})
+	if err != nil {
+		log.Printf("Unable to execute BookHandlerBookDetail: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.ParseFindBookByIdResponse(book, pricing, ratings))
+}
+
+// UpdateBook は指定された書誌情報のみを更新し、更新後の書籍を返す
+func (h *bookHandlerImpl) UpdateBook(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Printf("Unable to execute BookHandlerUpdateBook: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid book ID",
+		})
+	}
+
+	body := new(request.UpdateBookRequest)
+	if err := c.Bind(body); err != nil {
+		log.Printf("Unable to execute BookHandlerUpdateBook: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid request body",
+		})
+	}
+	if vs, ve := body.Validate(); ve != -1 {
+		return validationError(c, vs, ve)
+	}
+
+	metadata := parseBookMetadata(&body.BookMetadataRequest)
+	param := db.UpdateBookParams{
+		ID:              int32(id),
+		Title:           pgtype.Text{String: body.Title.String, Valid: body.Title.Valid},
+		Author:          pgtype.Text{String: body.Author.String, Valid: body.Author.Valid},
+		Publisher:       pgtype.Text{String: body.Publisher.String, Valid: body.Publisher.Valid},
+		Subtitle:        metadata.Subtitle,
+		Edition:         metadata.Edition,
+		PublicationDate: metadata.PublicationDate,
+		Language:        metadata.Language,
+		PageCount:       metadata.PageCount,
+		Format:          metadata.Format,
+		Description:     metadata.Description,
+		Series:          metadata.Series,
+		SeriesVolume:    metadata.SeriesVolume,
+		Isbn:            metadata.ISBN,
+	}
+
+	book, err := h.usecase.UpdateBook(context.Background(), &param)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Book not found",
+		})
+	}
+	if err != nil {
+		log.Printf("Unable to execute BookHandlerUpdateBook: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return h.bookDetail(c, book, time.Time{})
+}
+
+// parseBulkFilter は一括更新・一括削除の対象の条件をクエリパラメータから読み込む
+// ?ids=1,2,3・?author=・?publisher= を全て満たす書籍を対象とし、誤って全ての書籍を変更しないよう少なくとも1つの条件を求める
+// 不正なパラメータの場合は、レスポンスのメッセージを返す
+func parseBulkFilter(c echo.Context) (*db.ListBooksByFilterParams, string) {
+	filter := db.ListBooksByFilterParams{}
+	if idsParam := c.QueryParam("ids"); idsParam != "" {
+		for _, idParam := range strings.Split(idsParam, ",") {
+			id, err := strconv.ParseInt(strings.TrimSpace(idParam), 10, 32)
+			if err != nil {
+				return nil, "Invalid ids parameter"
+			}
+			filter.Ids = append(filter.Ids, int32(id))
+		}
+	}
+	if author := c.QueryParam("author"); author != "" {
+		filter.Author = pgtype.Text{String: author, Valid: true}
+	}
+	if publisher := c.QueryParam("publisher"); publisher != "" {
+		filter.Publisher = pgtype.Text{String: publisher, Valid: true}
+	}
+	if filter.Ids == nil && !filter.Author.Valid && !filter.Publisher.Valid {
+		return nil, "A filter parameter is required"
+	}
+
+	return &filter, ""
+}
+
+// parseBulkParams はparseBulkFilterの条件に加えて、dry_runの指定を読み込む
+func parseBulkParams(c echo.Context) (*db.ListBooksByFilterParams, bool, string) {
+	filter, message := parseBulkFilter(c)
+	if message != "" {
+		return nil, false, message
+	}
+
+	var dryRun bool
+	if dryRunParam := c.QueryParam("dry_run"); dryRunParam != "" {
+		b, err := strconv.ParseBool(dryRunParam)
+		if err != nil {
+			return nil, false, "Invalid dry_run parameter"
+		}
+		dryRun = b
+	}
+
+	return filter, dryRun, ""
+}
+
+// BulkUpdateBooks は条件に一致する書籍の出版社・価格を1つのトランザクションで更新し、更新した件数とIDを返す
+// ?dry_run=true の場合は更新せずに、更新の対象になる書籍の件数とIDを返す
+func (h *bookHandlerImpl) BulkUpdateBooks(c echo.Context) error {
+	filter, dryRun, message := parseBulkParams(c)
+	if message != "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": message,
+		})
+	}
+
+	body := new(request.BulkUpdateBooksRequest)
+	if err := c.Bind(body); err != nil {
+		log.Printf("Unable to execute BookHandlerBulkUpdateBooks: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid request body",
+		})
+	}
+	if vs, ve := body.Validate(); ve != -1 {
+		return validationError(c, vs, ve)
+	}
+
+	patch := repository.BookPatch{
+		Publisher: pgtype.Text{String: body.Publisher.String, Valid: body.Publisher.Valid},
+		Price:     pgtype.Int4{Int32: int32(body.Price.Int64), Valid: body.Price.Valid},
+	}
+	res, err := h.usecase.BulkUpdateBooks(context.Background(), filter, &patch, dryRun)
+	if err != nil {
+		log.Printf("Unable to execute BookHandlerBulkUpdateBooks: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.ParseBulkResultResponse(res))
+}
+
+// DeleteBooks は条件に一致する書籍を1つのトランザクションで削除し、削除した件数とIDを返す
+// ?dry_run=true の場合は削除せずに、削除の対象になる書籍の件数とIDを返す
+func (h *bookHandlerImpl) DeleteBooks(c echo.Context) error {
+	filter, dryRun, message := parseBulkParams(c)
+	if message != "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": message,
+		})
+	}
+
+	res, err := h.usecase.DeleteBooks(context.Background(), filter, dryRun)
+	if err != nil {
+		log.Printf("Unable to execute BookHandlerDeleteBooks: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.ParseBulkResultResponse(res))
+}
+
+// MergeBook はパスの書籍を統合元として統合先へまとめ、統合後の統合先の書籍を返す
+func (h *bookHandlerImpl) MergeBook(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Printf("Unable to execute BookHandlerMergeBook: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid book ID",
+		})
+	}
+
+	body := new(request.MergeBookRequest)
+	if err := c.Bind(body); err != nil {
+		log.Printf("Unable to execute BookHandlerMergeBook: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid request body",
+		})
+	}
+	if vs, ve := body.Validate(); ve != -1 {
+		return validationError(c, vs, ve)
+	}
+
+	book, err := h.usecase.MergeBook(context.Background(), id, int(body.TargetID.Int64))
+	switch {
+	case errors.Is(err, usecase.ErrMergeIntoSelf):
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Cannot merge a book into itself",
+		})
+	case errors.Is(err, repository.ErrMergeSourceNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Book not found",
+		})
+	case errors.Is(err, repository.ErrMergeTargetNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Target book not found",
+		})
+	case err != nil:
+		log.Printf("Unable to execute BookHandlerMergeBook: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return h.bookDetail(c, book, time.Time{})
+}
+
+func (h *bookHandlerImpl) FetchBookAuditLogs(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Printf("Unable to execute BookHandlerFetchBookAuditLogs: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid book ID",
+		})
+	}
+
+	logs, err := h.usecase.FetchBookAuditLogs(context.Background(), id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Book not found",
+		})
+	}
+	if err != nil {
+		log.Printf("Unable to execute BookHandlerFetchBookAuditLogs: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.ParseFetchBookAuditLogsResponse(logs))
+}
+
+// bookMetadata はリクエストの書誌情報を、登録・更新のパラメータの型に変換したもの
+// 省略された項目は無効値となる
+type bookMetadata struct {
+	Subtitle        pgtype.Text
+	Edition         pgtype.Text
+	PublicationDate pgtype.Date
+	Language        pgtype.Text
+	PageCount       pgtype.Int4
+	Format          pgtype.Text
+	Description     pgtype.Text
+	Series          pgtype.Text
+	SeriesVolume    pgtype.Int4
+	ISBN            pgtype.Text
+}
+
+func parseBookMetadata(m *request.BookMetadataRequest) bookMetadata {
+	return bookMetadata{
+		Subtitle:        pgtype.Text{String: m.Subtitle.String, Valid: m.Subtitle.Valid},
+		Edition:         pgtype.Text{String: m.Edition.String, Valid: m.Edition.Valid},
+		PublicationDate: pgtype.Date{Time: m.ParsePublicationDate(), Valid: m.PublicationDate.Valid},
+		Language:        pgtype.Text{String: m.Language.String, Valid: m.Language.Valid},
+		PageCount:       pgtype.Int4{Int32: int32(m.PageCount.Int64), Valid: m.PageCount.Valid},
+		Format:          pgtype.Text{String: m.Format.String, Valid: m.Format.Valid},
+		Description:     pgtype.Text{String: m.Description.String, Valid: m.Description.Valid},
+		Series:          pgtype.Text{String: m.Series.String, Valid: m.Series.Valid},
+		SeriesVolume:    pgtype.Int4{Int32: int32(m.SeriesVolume.Int64), Valid: m.SeriesVolume.Valid},
+		ISBN:            pgtype.Text{String: m.ParseISBN(), Valid: m.ISBN.Valid},
+	}
 }
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rentaro-m-b/ai-model-exam/event"
+	"github.com/rentaro-m-b/ai-model-exam/handler/response"
+	"github.com/rentaro-m-b/ai-model-exam/usecase"
+)
+
+type BookEventHandler interface {
+	StreamEvents(c echo.Context) error
+}
+
+type bookEventHandlerImpl struct {
+	usecase usecase.BookEventUsecase
+	// heartbeat はイベントが無い間に接続を保つためのコメントを送る間隔
+	heartbeat time.Duration
+}
+
+func NewBookEventHandler(usecase usecase.BookEventUsecase, heartbeat time.Duration) BookEventHandler {
+	return &bookEventHandlerImpl{
+		usecase:   usecase,
+		heartbeat: heartbeat,
+	}
+}
+
+// StreamEvents は書籍の変更イベントをServer-Sent Eventsで送り続ける
+// Last-Event-IDヘッダがある場合は、その位置より後のイベントをアウトボックスから読み出して送ってから、新しいイベントを送る
+// 受け取りが遅れて購読を打ち切られた場合は接続を閉じ、クライアントは最後に受け取ったIDから再接続する
+func (h *bookEventHandlerImpl) StreamEvents(c echo.Context) error {
+	var last event.Cursor
+	resume := false
+	if v := c.Request().Header.Get("Last-Event-ID"); v != "" {
+		cursor, err := event.ParseCursor(v)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"message": "Invalid Last-Event-ID",
+			})
+		}
+		last, resume = cursor, true
+	}
+
+	// 読み直しの間に登録されたイベントを取りこぼさないよう、読み直す前に購読を始める
+	sub := h.usecase.Subscribe()
+	defer h.usecase.Unsubscribe(sub)
+
+	ctx := c.Request().Context()
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+	res.Flush()
+
+	for resume {
+		events, err := h.usecase.FetchEventsAfter(ctx, last)
+		if err != nil {
+			log.Printf("Unable to execute BookEventHandlerStreamEvents: %d\n", err)
+			return nil
+		}
+		for _, ev := range events {
+			if err := writeBookEvent(res, &ev); err != nil {
+				return nil
+			}
+			last = ev.Cursor()
+		}
+		resume = len(events) > 0
+	}
+
+	ticker := time.NewTicker(h.heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if _, err := fmt.Fprint(res, ": heartbeat\n\n"); err != nil {
+				return nil
+			}
+			res.Flush()
+		case ev, ok := <-sub.Events():
+			if !ok {
+				return nil
+			}
+			// 読み直しで送ったイベントと、クライアントが受け取り済みのイベントは送らない
+			if !ev.Cursor().After(last) {
+				continue
+			}
+			if err := writeBookEvent(res, &ev); err != nil {
+				return nil
+			}
+			last = ev.Cursor()
+		}
+	}
+}
+
+func writeBookEvent(res *echo.Response, ev *event.Event) error {
+	data, err := json.Marshal(response.ParseBookEventResponse(ev))
+	if err != nil {
+		log.Printf("Unable to execute BookEventHandlerStreamEvents: %d\n", err)
+		return err
+	}
+	if _, err := fmt.Fprintf(res, "id: %s\nevent: %s\ndata: %s\n\n", ev.Cursor(), ev.Type, data); err != nil {
+		return err
+	}
+	res.Flush()
+
+	return nil
+}
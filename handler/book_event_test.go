@@ -0,0 +1,148 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/rentaro-m-b/ai-model-exam/event"
+	"github.com/rentaro-m-b/ai-model-exam/handler"
+	mock_usecase "github.com/rentaro-m-b/ai-model-exam/usecase/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newBookEvent(txId int64, id int64) event.Event {
+	return event.Event{
+		ID:         id,
+		TxID:       txId,
+		Type:       event.TypeBookUpdated,
+		BookID:     1,
+		Payload:    json.RawMessage(`{"id":1}`),
+		OccurredAt: time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func bookEventMessage(txId int64, id int64) string {
+	return fmt.Sprintf("id: %d-%d\nevent: book.updated\ndata: ", txId, id) +
+		fmt.Sprintf(`{"id":%d,"type":"book.updated","book_id":1,"occurred_at":"2024-07-01T00:00:00Z","data":{"id":1}}`, id) + "\n\n"
+}
+
+func TestStreamEvents(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// 購読のバッファをあふれさせ、送り終えたら接続を閉じるようにする
+	broker := event.NewBroker(2)
+	mockUc := mock_usecase.NewMockBookEventUsecase(ctrl)
+	mockUc.EXPECT().Subscribe().DoAndReturn(func() *event.Subscription {
+		sub := broker.Subscribe()
+		broker.Publish(newBookEvent(100, 1))
+		broker.Publish(newBookEvent(100, 2))
+		broker.Publish(newBookEvent(101, 3))
+		return sub
+	})
+	mockUc.EXPECT().Unsubscribe(gomock.Any()).Do(broker.Unsubscribe)
+
+	// Echoのコンテキストを作成
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/books/events", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewBookEventHandler(mockUc, time.Minute)
+	assert.NoError(t, h.StreamEvents(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "no-cache", rec.Header().Get("Cache-Control"))
+	assert.Equal(t, bookEventMessage(100, 1)+bookEventMessage(100, 2), rec.Body.String())
+}
+
+func TestStreamEventsResume(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// 読み直しの間に登録されたイベントは読み直しと購読の両方から届く
+	// IDの小さいイベントが後からコミットされた場合（ID 4）も、読み直した位置より後であれば送る
+	broker := event.NewBroker(3)
+	mockUc := mock_usecase.NewMockBookEventUsecase(ctrl)
+	mockUc.EXPECT().Subscribe().DoAndReturn(func() *event.Subscription {
+		sub := broker.Subscribe()
+		broker.Publish(newBookEvent(101, 7))
+		broker.Publish(newBookEvent(102, 4))
+		broker.Publish(newBookEvent(102, 8))
+		return sub
+	})
+	mockUc.EXPECT().Unsubscribe(gomock.Any()).Do(broker.Unsubscribe)
+	gomock.InOrder(
+		mockUc.EXPECT().FetchEventsAfter(gomock.Any(), event.Cursor{TxID: 100, ID: 5}).Return([]event.Event{newBookEvent(100, 6), newBookEvent(101, 7)}, nil),
+		mockUc.EXPECT().FetchEventsAfter(gomock.Any(), event.Cursor{TxID: 101, ID: 7}).DoAndReturn(func(ctx context.Context, after event.Cursor) ([]event.Event, error) {
+			broker.Publish(newBookEvent(103, 9))
+			return []event.Event{}, nil
+		}),
+	)
+
+	// Last-Event-IDヘッダを設定し、Echoのコンテキストを作成
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/books/events", nil)
+	req.Header.Set("Last-Event-ID", "100-5")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// ハンドラを作成し、テスト項目を検証（送信済みのイベントは送らない）
+	h := handler.NewBookEventHandler(mockUc, time.Minute)
+	assert.NoError(t, h.StreamEvents(c))
+	assert.Equal(t, bookEventMessage(100, 6)+bookEventMessage(101, 7)+bookEventMessage(102, 4)+bookEventMessage(102, 8), rec.Body.String())
+}
+
+func TestStreamEventsHeartbeat(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	broker := event.NewBroker(2)
+	mockUc := mock_usecase.NewMockBookEventUsecase(ctrl)
+	mockUc.EXPECT().Subscribe().DoAndReturn(broker.Subscribe)
+	mockUc.EXPECT().Unsubscribe(gomock.Any()).Do(broker.Unsubscribe)
+
+	// 切断を再現するためにキャンセルできるリクエストでEchoのコンテキストを作成
+	ctx, cancel := context.WithCancel(context.Background())
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/books/events", nil).WithContext(ctx)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewBookEventHandler(mockUc, 10*time.Millisecond)
+	time.AfterFunc(35*time.Millisecond, cancel)
+	assert.NoError(t, h.StreamEvents(c))
+	assert.Contains(t, rec.Body.String(), ": heartbeat\n\n")
+}
+
+func TestStreamEventsFailureInvalidLastEventID(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUc := mock_usecase.NewMockBookEventUsecase(ctrl)
+
+	// 不正なLast-Event-IDヘッダを設定し、Echoのコンテキストを作成
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/books/events", nil)
+	req.Header.Set("Last-Event-ID", "abc")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewBookEventHandler(mockUc, time.Minute)
+	assert.NoError(t, h.StreamEvents(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.JSONEq(t, `{"message": "Invalid Last-Event-ID"}`, rec.Body.String())
+}
@@ -4,23 +4,41 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/guregu/null"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/labstack/echo/v4"
 	"github.com/rentaro-m-b/ai-model-exam/db"
 	"github.com/rentaro-m-b/ai-model-exam/handler"
 	"github.com/rentaro-m-b/ai-model-exam/handler/request"
 	"github.com/rentaro-m-b/ai-model-exam/handler/response"
+	"github.com/rentaro-m-b/ai-model-exam/money"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	"github.com/rentaro-m-b/ai-model-exam/usecase"
 	mock_usecase "github.com/rentaro-m-b/ai-model-exam/usecase/mock"
 	"github.com/stretchr/testify/assert"
 )
 
+// expectBookCollectionVersion は書籍の一覧の版の取得を期待値に設定する
+func expectBookCollectionVersion(mockUc *mock_usecase.MockBookUsecase) *usecase.BookCollectionVersion {
+	version := &usecase.BookCollectionVersion{
+		ETag:         `W/"books-42-0-1a2b3c4d"`,
+		LastModified: time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+	}
+	mockUc.EXPECT().FetchBookCollectionVersion(gomock.Any(), gomock.Any()).Return(version, nil)
+
+	return version
+}
+
 func TestFetchBooks(t *testing.T) {
 	// モックコントローラを作成
 	ctrl := gomock.NewController(t)
@@ -28,6 +46,8 @@ func TestFetchBooks(t *testing.T) {
 
 	// ユースケースのモックを作成し、期待値を設定
 	mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+	mockPriceUc := mock_usecase.NewMockPriceUsecase(ctrl)
+	expectBookCollectionVersion(mockUc)
 	expectsUc := []db.Book{
 		{
 			ID:        1,
@@ -45,6 +65,29 @@ func TestFetchBooks(t *testing.T) {
 		},
 	}
 	mockUc.EXPECT().FetchBooks(gomock.Any()).Return(expectsUc, nil)
+	pricesUc := map[int32]db.BookPrice{
+		1: {
+			ID:            1,
+			BookID:        1,
+			Currency:      "USD",
+			Amount:        1999,
+			EffectiveFrom: pgtype.Timestamptz{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+		},
+	}
+	mockPriceUc.EXPECT().FetchPricesInEffect(gomock.Any(), []int32{1, 2}, time.Time{}).Return(pricesUc, nil)
+	usd, _ := money.LookupCurrency("USD")
+	discountsUc := map[int32]usecase.DiscountedPrice{
+		1: {
+			Original:     money.Money{Amount: 1999, Currency: usd},
+			Money:        money.Money{Amount: 1799, Currency: usd},
+			PromotionIDs: []int32{3},
+		},
+	}
+	mockPriceUc.EXPECT().ApplyPromotions(gomock.Any(), pricesUc, time.Time{}).Return(discountsUc, nil)
+	ratingsUc := map[int32]db.BookRating{
+		1: {BookID: 1, ReviewCount: 3, RatingSum: 13},
+	}
+	mockUc.EXPECT().FetchBookRatings(gomock.Any(), []int32{1, 2}).Return(ratingsUc, nil)
 
 	// Echoのインスタンス、リクエスト、レスポンスを作成
 	e := echo.New()
@@ -53,16 +96,160 @@ func TestFetchBooks(t *testing.T) {
 	c := e.NewContext(req, rec)
 
 	// ハンドラを作成し、テスト項目を検証
-	h := handler.NewBookHandler(mockUc)
-	expects := response.ParseFetchBooksResponse(expectsUc)
+	h := handler.NewBookHandler(mockUc, mockPriceUc)
+	expects := response.ParseFetchBooksResponse(expectsUc, &response.BookPricing{Prices: pricesUc, Discounts: discountsUc}, ratingsUc)
 	assert.NoError(t, h.FetchBooks(c))
 	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, 4.33, *expects.Books[0].AverageRating)
+	assert.Nil(t, expects.Books[1].AverageRating)
+	assert.Equal(t, "public, max-age=60, must-revalidate", rec.Header().Get("Cache-Control"))
+	assert.Equal(t, `W/"books-42-0-1a2b3c4d"`, rec.Header().Get("ETag"))
+	assert.Equal(t, "Mon, 01 Jul 2024 00:00:00 GMT", rec.Header().Get("Last-Modified"))
 	var res *response.FetchBooksResponses
 	err := json.NewDecoder(rec.Body).Decode(&res)
 	assert.NoError(t, err)
 	assert.Equal(t, expects, res)
 }
 
+func TestFetchBooksByIds(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+	mockPriceUc := mock_usecase.NewMockPriceUsecase(ctrl)
+	batchUc := usecase.BookBatch{
+		Books: []db.Book{
+			{
+				ID:        3,
+				Title:     pgtype.Text{String: "test title 3", Valid: true},
+				Author:    pgtype.Text{String: "test author 3", Valid: true},
+				Publisher: pgtype.Text{String: "test publisher 3", Valid: true},
+				Price:     pgtype.Int4{Int32: 300, Valid: true},
+			},
+		},
+		MissingIDs: []int32{1},
+	}
+	mockUc.EXPECT().FetchBooksByIds(gomock.Any(), []int32{3, 1}).Return(&batchUc, nil)
+	mockPriceUc.EXPECT().FetchPricesInEffect(gomock.Any(), []int32{3}, time.Time{}).Return(map[int32]db.BookPrice{}, nil)
+	mockPriceUc.EXPECT().ApplyPromotions(gomock.Any(), map[int32]db.BookPrice{}, time.Time{}).Return(map[int32]usecase.DiscountedPrice{}, nil)
+	mockUc.EXPECT().FetchBookRatings(gomock.Any(), []int32{3}).Return(map[int32]db.BookRating{}, nil)
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/books?ids=3,1", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewBookHandler(mockUc, mockPriceUc)
+	assert.NoError(t, h.FetchBooks(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get("ETag"))
+	var res map[string]any
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&res))
+	assert.Len(t, res["books"], 1)
+	assert.Equal(t, float64(3), res["books"].([]any)[0].(map[string]any)["id"])
+	assert.Equal(t, []any{float64(1)}, res["missing_ids"])
+}
+
+func TestFetchBooksByIdsFailureInvalidIds(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成
+	mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+	mockPriceUc := mock_usecase.NewMockPriceUsecase(ctrl)
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/books?ids=1,a", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewBookHandler(mockUc, mockPriceUc)
+	assert.NoError(t, h.FetchBooks(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.JSONEq(t, `{"message": "Invalid ids parameter"}`, rec.Body.String())
+}
+
+func TestFetchBooksByIdsFailureTooLarge(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+	mockPriceUc := mock_usecase.NewMockPriceUsecase(ctrl)
+	mockUc.EXPECT().FetchBooksByIds(gomock.Any(), []int32{1, 2, 3}).Return(nil, &usecase.BatchTooLargeError{Max: 2})
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/books?ids=1,2,3", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewBookHandler(mockUc, mockPriceUc)
+	assert.NoError(t, h.FetchBooks(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.JSONEq(t, `{"message": "Too many ids (max 2)"}`, rec.Body.String())
+}
+
+func TestFetchBooksNotModified(t *testing.T) {
+	cases := []struct {
+		name   string
+		header string
+		value  string
+		status int
+	}{
+		{"etag match", "If-None-Match", `W/"books-42-0-1a2b3c4d"`, http.StatusNotModified},
+		{"etag match in list", "If-None-Match", `"other", "books-42-0-1a2b3c4d"`, http.StatusNotModified},
+		{"etag wildcard", "If-None-Match", "*", http.StatusNotModified},
+		{"etag mismatch", "If-None-Match", `W/"books-41-0-1a2b3c4d"`, http.StatusOK},
+		{"not modified since", "If-Modified-Since", "Mon, 01 Jul 2024 00:00:00 GMT", http.StatusNotModified},
+		{"modified since", "If-Modified-Since", "Sun, 30 Jun 2024 23:59:59 GMT", http.StatusOK},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// モックコントローラを作成
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// ユースケースのモックを作成し、期待値を設定（304の場合は一覧を取得しない）
+			mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+			mockPriceUc := mock_usecase.NewMockPriceUsecase(ctrl)
+			expectBookCollectionVersion(mockUc)
+			if tc.status == http.StatusOK {
+				mockUc.EXPECT().FetchBooks(gomock.Any()).Return([]db.Book{}, nil)
+				mockPriceUc.EXPECT().FetchPricesInEffect(gomock.Any(), []int32{}, time.Time{}).Return(map[int32]db.BookPrice{}, nil)
+				mockPriceUc.EXPECT().ApplyPromotions(gomock.Any(), map[int32]db.BookPrice{}, time.Time{}).Return(map[int32]usecase.DiscountedPrice{}, nil)
+				mockUc.EXPECT().FetchBookRatings(gomock.Any(), []int32{}).Return(map[int32]db.BookRating{}, nil)
+			}
+
+			// 条件付きリクエストのヘッダを設定し、Echoのコンテキストを作成
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/books", nil)
+			req.Header.Set(tc.header, tc.value)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			// ハンドラを作成し、テスト項目を検証
+			h := handler.NewBookHandler(mockUc, mockPriceUc)
+			assert.NoError(t, h.FetchBooks(c))
+			assert.Equal(t, tc.status, rec.Code)
+			assert.Equal(t, `W/"books-42-0-1a2b3c4d"`, rec.Header().Get("ETag"))
+			if tc.status == http.StatusNotModified {
+				assert.Empty(t, rec.Body.String())
+			}
+		})
+	}
+}
+
 func TestFetchBooksFailure(t *testing.T) {
 	// モックコントローラを作成
 	ctrl := gomock.NewController(t)
@@ -70,6 +257,8 @@ func TestFetchBooksFailure(t *testing.T) {
 
 	// ユースケースのモックを作成し、期待値を設定
 	mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+	mockPriceUc := mock_usecase.NewMockPriceUsecase(ctrl)
+	expectBookCollectionVersion(mockUc)
 	mockUc.EXPECT().FetchBooks(gomock.Any()).Return(nil, fmt.Errorf("error"))
 
 	// Echoのインスタンス、リクエスト、レスポンスを作成
@@ -79,13 +268,75 @@ func TestFetchBooksFailure(t *testing.T) {
 	c := e.NewContext(req, rec)
 
 	// ハンドラを作成し、テスト項目を検証
-	h := handler.NewBookHandler(mockUc)
+	h := handler.NewBookHandler(mockUc, mockPriceUc)
 	assert.NoError(t, h.FetchBooks(c))
 	assert.Equal(t, http.StatusInternalServerError, rec.Code)
 	expectErrorMessage := `{"message": "Internal server error"}`
 	assert.JSONEq(t, expectErrorMessage, rec.Body.String())
 }
 
+func TestFetchBooksInStock(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+	mockPriceUc := mock_usecase.NewMockPriceUsecase(ctrl)
+	expectBookCollectionVersion(mockUc)
+	expectsUc := []db.Book{
+		{
+			ID:        1,
+			Title:     pgtype.Text{String: "test title 1", Valid: true},
+			Author:    pgtype.Text{String: "test author 1", Valid: true},
+			Publisher: pgtype.Text{String: "test publisher 1", Valid: true},
+			Price:     pgtype.Int4{Int32: 100, Valid: true},
+		},
+	}
+	mockUc.EXPECT().FetchBooksByStock(gomock.Any(), true).Return(expectsUc, nil)
+	mockPriceUc.EXPECT().FetchPricesInEffect(gomock.Any(), []int32{1}, time.Time{}).Return(map[int32]db.BookPrice{}, nil)
+	mockPriceUc.EXPECT().ApplyPromotions(gomock.Any(), map[int32]db.BookPrice{}, time.Time{}).Return(map[int32]usecase.DiscountedPrice{}, nil)
+	mockUc.EXPECT().FetchBookRatings(gomock.Any(), []int32{1}).Return(map[int32]db.BookRating{}, nil)
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/books?in_stock=true", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewBookHandler(mockUc, mockPriceUc)
+	expects := response.ParseFetchBooksResponse(expectsUc, nil, nil)
+	assert.NoError(t, h.FetchBooks(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var res *response.FetchBooksResponses
+	err := json.NewDecoder(rec.Body).Decode(&res)
+	assert.NoError(t, err)
+	assert.Equal(t, expects, res)
+}
+
+func TestFetchBooksFailureInvalidInStock(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成
+	mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+	mockPriceUc := mock_usecase.NewMockPriceUsecase(ctrl)
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/books?in_stock=maybe", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewBookHandler(mockUc, mockPriceUc)
+	assert.NoError(t, h.FetchBooks(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.JSONEq(t, `{"message": "Invalid in_stock parameter"}`, rec.Body.String())
+}
+
 func TestCreateBook(t *testing.T) {
 	// モックコントローラを作成
 	ctrl := gomock.NewController(t)
@@ -93,6 +344,7 @@ func TestCreateBook(t *testing.T) {
 
 	// ユースケースのモックを作成し、期待値を設定
 	mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+	mockPriceUc := mock_usecase.NewMockPriceUsecase(ctrl)
 	paramUc := db.CreateBookParams{
 		Title:     pgtype.Text{String: "test title 1", Valid: true},
 		Author:    pgtype.Text{String: "test author 1", Valid: true},
@@ -106,7 +358,7 @@ func TestCreateBook(t *testing.T) {
 		Publisher: pgtype.Text{String: "test publisher 1", Valid: true},
 		Price:     pgtype.Int4{Int32: 100, Valid: true},
 	}
-	mockUc.EXPECT().CreateBook(gomock.Any(), &paramUc).Return(&expectUc, nil)
+	mockUc.EXPECT().CreateBook(gomock.Any(), &paramUc, false).Return(&expectUc, nil)
 
 	// リクエストボディを設定
 	param := request.CreateBookRequest{
@@ -125,7 +377,7 @@ func TestCreateBook(t *testing.T) {
 	c := e.NewContext(req, rec)
 
 	// ハンドラを作成し、テスト項目を検証
-	h := handler.NewBookHandler(mockUc)
+	h := handler.NewBookHandler(mockUc, mockPriceUc)
 	assert.NoError(t, h.CreateBook(c))
 	assert.Equal(t, http.StatusCreated, rec.Code)
 	expectLocation := "http://example.com/books/1"
@@ -139,6 +391,8 @@ func TestCreateBookFailure(t *testing.T) {
 
 	// ユースケースのモックを作成し、期待値を設定
 	mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+	mockPriceUc := mock_usecase.NewMockPriceUsecase(ctrl)
+	expectBookCollectionVersion(mockUc)
 	mockUc.EXPECT().FetchBooks(gomock.Any()).Return(nil, fmt.Errorf("error"))
 
 	// Echoのインスタンス、リクエスト、レスポンスを作成
@@ -148,7 +402,7 @@ func TestCreateBookFailure(t *testing.T) {
 	c := e.NewContext(req, rec)
 
 	// ハンドラを作成し、テスト項目を検証
-	h := handler.NewBookHandler(mockUc)
+	h := handler.NewBookHandler(mockUc, mockPriceUc)
 	assert.NoError(t, h.FetchBooks(c))
 	assert.Equal(t, http.StatusInternalServerError, rec.Code)
 	expectErrorMessage := `{"message": "Internal server error"}`
@@ -162,6 +416,7 @@ func TestCreateBookFailureValidationNone(t *testing.T) {
 
 	// ユースケースのモックを作成
 	mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+	mockPriceUc := mock_usecase.NewMockPriceUsecase(ctrl)
 
 	// リクエストボディを設定
 	param := request.CreateBookRequest{
@@ -180,7 +435,7 @@ func TestCreateBookFailureValidationNone(t *testing.T) {
 	c := e.NewContext(req, rec)
 
 	// ハンドラを作成し、テスト項目を検証
-	h := handler.NewBookHandler(mockUc)
+	h := handler.NewBookHandler(mockUc, mockPriceUc)
 	assert.NoError(t, h.CreateBook(c))
 	assert.Equal(t, http.StatusBadRequest, rec.Code)
 	var res response.CreateBookErrorResponse
@@ -203,6 +458,7 @@ func TestCreateBookFailureValidationEmpty(t *testing.T) {
 
 	// ユースケースのモックを作成
 	mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+	mockPriceUc := mock_usecase.NewMockPriceUsecase(ctrl)
 
 	// リクエストボディを設定
 	param := request.CreateBookRequest{
@@ -221,7 +477,7 @@ func TestCreateBookFailureValidationEmpty(t *testing.T) {
 	c := e.NewContext(req, rec)
 
 	// ハンドラを作成し、テスト項目を検証
-	h := handler.NewBookHandler(mockUc)
+	h := handler.NewBookHandler(mockUc, mockPriceUc)
 	assert.NoError(t, h.CreateBook(c))
 	assert.Equal(t, http.StatusBadRequest, rec.Code)
 	var res response.CreateBookErrorResponse
@@ -243,6 +499,7 @@ func TestFindBookById(t *testing.T) {
 
 	// ユースケースのモックを作成し、期待値を設定
 	mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+	mockPriceUc := mock_usecase.NewMockPriceUsecase(ctrl)
 	idUc := 1
 	expectUc := db.Book{
 		ID:        1,
@@ -252,6 +509,27 @@ func TestFindBookById(t *testing.T) {
 		Price:     pgtype.Int4{Int32: 200, Valid: true},
 	}
 	mockUc.EXPECT().FindBookById(gomock.Any(), idUc).Return(&expectUc, nil)
+	priceUc := db.BookPrice{
+		ID:            1,
+		BookID:        1,
+		Currency:      "JPY",
+		Amount:        200,
+		EffectiveFrom: pgtype.Timestamptz{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+	}
+	mockPriceUc.EXPECT().FetchPricesInEffect(gomock.Any(), []int32{1}, time.Time{}).Return(map[int32]db.BookPrice{1: priceUc}, nil)
+	jpy, _ := money.LookupCurrency("JPY")
+	discountsUc := map[int32]usecase.DiscountedPrice{
+		1: {
+			Original:     money.Money{Amount: 200, Currency: jpy},
+			Money:        money.Money{Amount: 200, Currency: jpy},
+			PromotionIDs: []int32{},
+		},
+	}
+	mockPriceUc.EXPECT().ApplyPromotions(gomock.Any(), map[int32]db.BookPrice{1: priceUc}, time.Time{}).Return(discountsUc, nil)
+	ratingsUc := map[int32]db.BookRating{
+		1: {BookID: 1, ReviewCount: 2, RatingSum: 9},
+	}
+	mockUc.EXPECT().FetchBookRatings(gomock.Any(), []int32{1}).Return(ratingsUc, nil)
 
 	// パスパラメータを設定
 	id := 1
@@ -266,8 +544,11 @@ func TestFindBookById(t *testing.T) {
 	c.SetParamValues(strconv.Itoa(id))
 
 	// ハンドラを作成し、テスト項目を検証
-	h := handler.NewBookHandler(mockUc)
-	expect := response.ParseFindBookByIdResponse(&expectUc)
+	h := handler.NewBookHandler(mockUc, mockPriceUc)
+	expect := response.ParseFindBookByIdResponse(&expectUc, &response.BookPricing{
+		Prices:    map[int32]db.BookPrice{1: priceUc},
+		Discounts: discountsUc,
+	}, ratingsUc)
 	assert.NoError(t, h.FindBookById(c))
 	assert.Equal(t, http.StatusOK, rec.Code)
 	var res *response.FindBookByIdResponse
@@ -285,6 +566,7 @@ func TestFindBookByIdFailure(t *testing.T) {
 
 	// ユースケースのモックを作成し、期待値を設定
 	mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+	mockPriceUc := mock_usecase.NewMockPriceUsecase(ctrl)
 	mockUc.EXPECT().FindBookById(gomock.Any(), idUc).Return(nil, fmt.Errorf("error"))
 
 	// パスパラメータを設定
@@ -299,9 +581,1070 @@ func TestFindBookByIdFailure(t *testing.T) {
 	c.SetParamValues(strconv.Itoa(id))
 
 	// ハンドラを作成し、テスト項目を検証
-	h := handler.NewBookHandler(mockUc)
+	h := handler.NewBookHandler(mockUc, mockPriceUc)
 	assert.NoError(t, h.FindBookById(c))
 	assert.Equal(t, http.StatusInternalServerError, rec.Code)
 	expectErrorMessage := `{"message": "Internal server error"}`
 	assert.JSONEq(t, expectErrorMessage, rec.Body.String())
 }
+
+func TestFindBookByIdAt(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+	mockPriceUc := mock_usecase.NewMockPriceUsecase(ctrl)
+	expectUc := db.Book{
+		ID:    1,
+		Title: pgtype.Text{String: "test title 1", Valid: true},
+		Price: pgtype.Int4{Int32: 200, Valid: true},
+	}
+	mockUc.EXPECT().FindBookById(gomock.Any(), 1).Return(&expectUc, nil)
+	at := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	mockPriceUc.EXPECT().FetchPricesInEffect(gomock.Any(), []int32{1}, at).Return(map[int32]db.BookPrice{}, nil)
+	mockPriceUc.EXPECT().ApplyPromotions(gomock.Any(), map[int32]db.BookPrice{}, at).Return(map[int32]usecase.DiscountedPrice{}, nil)
+	mockUc.EXPECT().FetchBookRatings(gomock.Any(), []int32{1}).Return(map[int32]db.BookRating{}, nil)
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/books/1?at=2025-04-01T00:00:00Z", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(strconv.Itoa(1))
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewBookHandler(mockUc, mockPriceUc)
+	assert.NoError(t, h.FindBookById(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"id": 1, "title": "test title 1", "author": "", "publisher": "", "price": 200, "current_price": null, "converted_price": null, "discounted_price": null, "average_rating": null, "review_count": 0, "work_id": null, "isbn": null, "subtitle": null, "edition": null, "publication_date": null, "language": null, "page_count": null, "format": null, "description": null, "series": null, "series_volume": null}`, rec.Body.String())
+}
+
+func TestFindBookByIdScheduledPriceInEffect(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+	mockPriceUc := mock_usecase.NewMockPriceUsecase(ctrl)
+	expectUc := db.Book{
+		ID:    1,
+		Title: pgtype.Text{String: "test title 1", Valid: true},
+		Price: pgtype.Int4{Int32: 200, Valid: true},
+	}
+	mockUc.EXPECT().FindBookById(gomock.Any(), 1).Return(&expectUc, nil)
+	// 予約した価格に切り替わっており、books.priceとは異なる
+	priceUc := db.BookPrice{
+		ID:            2,
+		BookID:        1,
+		Currency:      "JPY",
+		Amount:        150,
+		EffectiveFrom: pgtype.Timestamptz{Time: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+	}
+	mockPriceUc.EXPECT().FetchPricesInEffect(gomock.Any(), []int32{1}, time.Time{}).Return(map[int32]db.BookPrice{1: priceUc}, nil)
+	mockPriceUc.EXPECT().ApplyPromotions(gomock.Any(), map[int32]db.BookPrice{1: priceUc}, time.Time{}).Return(map[int32]usecase.DiscountedPrice{}, nil)
+	mockUc.EXPECT().FetchBookRatings(gomock.Any(), []int32{1}).Return(map[int32]db.BookRating{}, nil)
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/books/1", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(strconv.Itoa(1))
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewBookHandler(mockUc, mockPriceUc)
+	assert.NoError(t, h.FindBookById(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var res *response.FindBookByIdResponse
+	err := json.NewDecoder(rec.Body).Decode(&res)
+	assert.NoError(t, err)
+	assert.Equal(t, 150, res.Price)
+	assert.Equal(t, int64(150), res.CurrentPrice.Amount)
+}
+
+func TestFetchBooksFailureInvalidAt(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成
+	mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+	mockPriceUc := mock_usecase.NewMockPriceUsecase(ctrl)
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/books?at=yesterday", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewBookHandler(mockUc, mockPriceUc)
+	assert.NoError(t, h.FetchBooks(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.JSONEq(t, `{"message": "Invalid at parameter"}`, rec.Body.String())
+}
+
+func TestFindBookByIdCurrency(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+	mockPriceUc := mock_usecase.NewMockPriceUsecase(ctrl)
+	expectUc := db.Book{
+		ID:    1,
+		Title: pgtype.Text{String: "test title 1", Valid: true},
+		Price: pgtype.Int4{Int32: 3080, Valid: true},
+	}
+	priceUc := db.BookPrice{
+		ID:            1,
+		BookID:        1,
+		Currency:      "JPY",
+		Amount:        3080,
+		EffectiveFrom: pgtype.Timestamptz{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+	}
+	jpy, _ := money.LookupCurrency("JPY")
+	usd, _ := money.LookupCurrency("USD")
+	mockUc.EXPECT().FindBookById(gomock.Any(), 1).Return(&expectUc, nil)
+	mockPriceUc.EXPECT().FetchPricesInEffect(gomock.Any(), []int32{1}, time.Time{}).Return(map[int32]db.BookPrice{1: priceUc}, nil)
+	mockPriceUc.EXPECT().ApplyPromotions(gomock.Any(), map[int32]db.BookPrice{1: priceUc}, time.Time{}).Return(map[int32]usecase.DiscountedPrice{
+		1: {
+			Original:     money.Money{Amount: 3080, Currency: jpy},
+			Money:        money.Money{Amount: 2772, Currency: jpy},
+			PromotionIDs: []int32{5},
+		},
+	}, nil)
+	mockPriceUc.EXPECT().ConvertPrices(gomock.Any(), map[int32]db.BookPrice{1: priceUc}, "USD").Return(map[int32]money.Conversion{
+		1: {
+			Original: money.Money{Amount: 3080, Currency: jpy},
+			Money:    money.Money{Amount: 2000, Currency: usd},
+			Rate:     big.NewRat(1, 154),
+			RateDate: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}, nil)
+	mockUc.EXPECT().FetchBookRatings(gomock.Any(), []int32{1}).Return(map[int32]db.BookRating{}, nil)
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/books/1?currency=USD", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(strconv.Itoa(1))
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewBookHandler(mockUc, mockPriceUc)
+	assert.NoError(t, h.FindBookById(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{
+		"id": 1, "title": "test title 1", "author": "", "publisher": "", "price": 3080,
+		"current_price": {"id": 1, "amount": 3080, "currency": "JPY", "minor_units": 0, "decimal": "3080", "effective_from": "2024-01-01T00:00:00Z", "effective_to": null},
+		"converted_price": {"amount": 2000, "currency": "USD", "minor_units": 2, "decimal": "20.00", "rate": "0.0064935065", "rate_date": "2024-05-01"},
+		"discounted_price": {"amount": 2772, "currency": "JPY", "minor_units": 0, "decimal": "2772", "applied_promotion_ids": [5]},
+		"average_rating": null, "review_count": 0, "work_id": null, "isbn": null,
+		"subtitle": null, "edition": null, "publication_date": null, "language": null, "page_count": null,
+		"format": null, "description": null, "series": null, "series_volume": null
+	}`, rec.Body.String())
+}
+
+func TestFetchBooksFailureExchangeRateNotFound(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+	mockPriceUc := mock_usecase.NewMockPriceUsecase(ctrl)
+	expectBookCollectionVersion(mockUc)
+	mockUc.EXPECT().FetchBooks(gomock.Any()).Return([]db.Book{{ID: 1}}, nil)
+	mockPriceUc.EXPECT().FetchPricesInEffect(gomock.Any(), []int32{1}, time.Time{}).Return(map[int32]db.BookPrice{}, nil)
+	mockPriceUc.EXPECT().ApplyPromotions(gomock.Any(), map[int32]db.BookPrice{}, time.Time{}).Return(map[int32]usecase.DiscountedPrice{}, nil)
+	mockPriceUc.EXPECT().ConvertPrices(gomock.Any(), map[int32]db.BookPrice{}, "EUR").Return(nil, usecase.ErrExchangeRateNotFound)
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/books?currency=EUR", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewBookHandler(mockUc, mockPriceUc)
+	assert.NoError(t, h.FetchBooks(c))
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.JSONEq(t, `{"message": "Exchange rate not available"}`, rec.Body.String())
+}
+
+func TestFetchBooksByCategory(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+	mockPriceUc := mock_usecase.NewMockPriceUsecase(ctrl)
+	expectBookCollectionVersion(mockUc)
+	expectsUc := []db.Book{
+		{
+			ID:    1,
+			Title: pgtype.Text{String: "test title 1", Valid: true},
+			Price: pgtype.Int4{Int32: 100, Valid: true},
+		},
+	}
+	mockUc.EXPECT().FilterBooks(gomock.Any(), &db.FilterBooksParams{
+		CategoryID: pgtype.Int4{Int32: 3, Valid: true},
+		InStock:    pgtype.Bool{Bool: true, Valid: true},
+	}).Return(expectsUc, nil)
+	mockPriceUc.EXPECT().FetchPricesInEffect(gomock.Any(), []int32{1}, time.Time{}).Return(map[int32]db.BookPrice{}, nil)
+	mockPriceUc.EXPECT().ApplyPromotions(gomock.Any(), map[int32]db.BookPrice{}, time.Time{}).Return(map[int32]usecase.DiscountedPrice{}, nil)
+	mockUc.EXPECT().FetchBookRatings(gomock.Any(), []int32{1}).Return(map[int32]db.BookRating{}, nil)
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/books?category=3&in_stock=true", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewBookHandler(mockUc, mockPriceUc)
+	assert.NoError(t, h.FetchBooks(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var res *response.FetchBooksResponses
+	err := json.NewDecoder(rec.Body).Decode(&res)
+	assert.NoError(t, err)
+	assert.Equal(t, response.ParseFetchBooksResponse(expectsUc, nil, nil), res)
+}
+
+func TestFetchBooksFailureInvalidCategory(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成
+	mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+	mockPriceUc := mock_usecase.NewMockPriceUsecase(ctrl)
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/books?category=mystery", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewBookHandler(mockUc, mockPriceUc)
+	assert.NoError(t, h.FetchBooks(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.JSONEq(t, `{"message": "Invalid category parameter"}`, rec.Body.String())
+}
+
+func TestFetchBooksByTags(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+	mockPriceUc := mock_usecase.NewMockPriceUsecase(ctrl)
+	expectBookCollectionVersion(mockUc)
+	mockUc.EXPECT().FilterBooks(gomock.Any(), &db.FilterBooksParams{
+		Tags:         []string{"sf", "space"},
+		MatchAllTags: true,
+	}).Return([]db.Book{}, nil)
+	mockPriceUc.EXPECT().FetchPricesInEffect(gomock.Any(), []int32{}, time.Time{}).Return(map[int32]db.BookPrice{}, nil)
+	mockPriceUc.EXPECT().ApplyPromotions(gomock.Any(), map[int32]db.BookPrice{}, time.Time{}).Return(map[int32]usecase.DiscountedPrice{}, nil)
+	mockUc.EXPECT().FetchBookRatings(gomock.Any(), []int32{}).Return(map[int32]db.BookRating{}, nil)
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/books?tags=sf,space&tags_match=all", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewBookHandler(mockUc, mockPriceUc)
+	assert.NoError(t, h.FetchBooks(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestFetchBooksVariant(t *testing.T) {
+	// 指定の順序や表記が異なっても、同じ一覧には同じ正規化したクエリパラメータで版を取得する
+	for _, query := range []string{
+		"tags=space,sf&tags_match=all&currency=USD&at=2024-07-01T09:00:00%2B09:00",
+		"at=2024-07-01T00:00:00Z&currency=USD&tags_match=all&tags=sf,space",
+	} {
+		t.Run(query, func(t *testing.T) {
+			// モックコントローラを作成
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// ユースケースのモックを作成し、期待値を設定（304を返させて一覧は取得しない）
+			mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+			mockPriceUc := mock_usecase.NewMockPriceUsecase(ctrl)
+			mockUc.EXPECT().FetchBookCollectionVersion(gomock.Any(), "at=2024-07-01T00%3A00%3A00Z&currency=USD&tags=sf%2Cspace&tags_match=all").Return(&usecase.BookCollectionVersion{
+				ETag:         `W/"books-42-0-1a2b3c4d"`,
+				LastModified: time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+			}, nil)
+
+			// Echoのインスタンス、リクエスト、レスポンスを作成
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/books?"+query, nil)
+			req.Header.Set("If-None-Match", `W/"books-42-0-1a2b3c4d"`)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			// ハンドラを作成し、テスト項目を検証
+			h := handler.NewBookHandler(mockUc, mockPriceUc)
+			assert.NoError(t, h.FetchBooks(c))
+			assert.Equal(t, http.StatusNotModified, rec.Code)
+		})
+	}
+}
+
+func TestFetchBooksFailureInvalidTagsMatch(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成
+	mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+	mockPriceUc := mock_usecase.NewMockPriceUsecase(ctrl)
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/books?tags=sf&tags_match=none", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewBookHandler(mockUc, mockPriceUc)
+	assert.NoError(t, h.FetchBooks(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.JSONEq(t, `{"message": "Invalid tags_match parameter"}`, rec.Body.String())
+}
+
+func TestCreateBookWithMetadata(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+	mockPriceUc := mock_usecase.NewMockPriceUsecase(ctrl)
+	paramUc := db.CreateBookParams{
+		Title:           pgtype.Text{String: "test title 1", Valid: true},
+		Author:          pgtype.Text{String: "test author 1", Valid: true},
+		Publisher:       pgtype.Text{String: "test publisher 1", Valid: true},
+		Price:           pgtype.Int4{Int32: 100, Valid: true},
+		PublicationDate: pgtype.Date{Time: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+		Language:        pgtype.Text{String: "ja-JP", Valid: true},
+		PageCount:       pgtype.Int4{Int32: 320, Valid: true},
+		Format:          pgtype.Text{String: "paperback", Valid: true},
+		Series:          pgtype.Text{String: "test series", Valid: true},
+		SeriesVolume:    pgtype.Int4{Int32: 2, Valid: true},
+	}
+	mockUc.EXPECT().CreateBook(gomock.Any(), &paramUc, false).Return(&db.Book{ID: 1}, nil)
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	reqBody := `{
+		"title": "test title 1", "author": "test author 1", "publisher": "test publisher 1", "price": 100,
+		"publication_date": "2024-04-01", "language": "ja-JP", "page_count": 320, "format": "paperback",
+		"series": "test series", "series_volume": 2
+	}`
+	req := httptest.NewRequest(http.MethodPost, "/books", bytes.NewReader([]byte(reqBody)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewBookHandler(mockUc, mockPriceUc)
+	assert.NoError(t, h.CreateBook(c))
+	assert.Equal(t, http.StatusCreated, rec.Code)
+}
+
+func TestCreateBookFailureValidationMetadata(t *testing.T) {
+	cases := []struct {
+		metadata string
+		detail   string
+	}{
+		{`"publication_date": "2024/04/01"`, "publication_date is invalid."},
+		{`"language": "japanese language"`, "language is invalid."},
+		{`"language": ""`, "language must not be blank."},
+		{`"page_count": 0`, "page_count is invalid."},
+		{`"format": "audiobook"`, "format is invalid."},
+		{`"series_volume": 2`, "series must not be none."},
+		{`"isbn": "978-4-274-21788-4"`, "isbn is invalid."},
+		{`"isbn": "4-274-21788-X"`, "isbn is invalid."},
+	}
+	for _, tc := range cases {
+		// モックコントローラを作成
+		ctrl := gomock.NewController(t)
+
+		// ユースケースのモックを作成
+		mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+		mockPriceUc := mock_usecase.NewMockPriceUsecase(ctrl)
+
+		// Echoのインスタンス、リクエスト、レスポンスを作成
+		e := echo.New()
+		reqBody := `{"title": "test title 1", "author": "test author 1", "publisher": "test publisher 1", "price": 100, ` + tc.metadata + `}`
+		req := httptest.NewRequest(http.MethodPost, "/books", bytes.NewReader([]byte(reqBody)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		// ハンドラを作成し、テスト項目を検証
+		h := handler.NewBookHandler(mockUc, mockPriceUc)
+		assert.NoError(t, h.CreateBook(c))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		var res response.ValidationErrorResponse
+		assert.NoError(t, json.NewDecoder(rec.Body).Decode(&res))
+		assert.Equal(t, tc.detail, res.Detail, tc.metadata)
+		ctrl.Finish()
+	}
+}
+
+func TestUpdateBook(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+	mockPriceUc := mock_usecase.NewMockPriceUsecase(ctrl)
+	paramUc := db.UpdateBookParams{
+		ID:          1,
+		Subtitle:    pgtype.Text{String: "test subtitle", Valid: true},
+		Edition:     pgtype.Text{String: "2nd", Valid: true},
+		Format:      pgtype.Text{String: "ebook", Valid: true},
+		Description: pgtype.Text{String: "test description", Valid: true},
+	}
+	expectUc := db.Book{
+		ID:          1,
+		Title:       pgtype.Text{String: "test title 1", Valid: true},
+		Price:       pgtype.Int4{Int32: 200, Valid: true},
+		Subtitle:    pgtype.Text{String: "test subtitle", Valid: true},
+		Edition:     pgtype.Text{String: "2nd", Valid: true},
+		Format:      pgtype.Text{String: "ebook", Valid: true},
+		Description: pgtype.Text{String: "test description", Valid: true},
+	}
+	mockUc.EXPECT().UpdateBook(gomock.Any(), &paramUc).Return(&expectUc, nil)
+	mockPriceUc.EXPECT().FetchPricesInEffect(gomock.Any(), []int32{1}, gomock.Any()).Return(map[int32]db.BookPrice{}, nil)
+	mockPriceUc.EXPECT().ApplyPromotions(gomock.Any(), map[int32]db.BookPrice{}, gomock.Any()).Return(map[int32]usecase.DiscountedPrice{}, nil)
+	mockUc.EXPECT().FetchBookRatings(gomock.Any(), []int32{1}).Return(map[int32]db.BookRating{}, nil)
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	reqBody := `{"subtitle": "test subtitle", "edition": "2nd", "format": "ebook", "description": "test description"}`
+	req := httptest.NewRequest(http.MethodPatch, "/books/1", bytes.NewReader([]byte(reqBody)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(strconv.Itoa(1))
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewBookHandler(mockUc, mockPriceUc)
+	assert.NoError(t, h.UpdateBook(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{
+		"id": 1, "title": "test title 1", "author": "", "publisher": "", "price": 200,
+		"current_price": null, "converted_price": null, "discounted_price": null,
+		"average_rating": null, "review_count": 0, "work_id": null, "isbn": null,
+		"subtitle": "test subtitle", "edition": "2nd", "publication_date": null, "language": null, "page_count": null,
+		"format": "ebook", "description": "test description", "series": null, "series_volume": null, "isbn": null
+	}`, rec.Body.String())
+}
+
+func TestUpdateBookFailureNotFound(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+	mockPriceUc := mock_usecase.NewMockPriceUsecase(ctrl)
+	mockUc.EXPECT().UpdateBook(gomock.Any(), gomock.Any()).Return(nil, pgx.ErrNoRows)
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPatch, "/books/999", bytes.NewReader([]byte(`{"title": "test title 2"}`)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(strconv.Itoa(999))
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewBookHandler(mockUc, mockPriceUc)
+	assert.NoError(t, h.UpdateBook(c))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestFetchBooksCollapseByWork(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+	mockPriceUc := mock_usecase.NewMockPriceUsecase(ctrl)
+	expectBookCollectionVersion(mockUc)
+	work := pgtype.Int4{Int32: 1, Valid: true}
+	mockUc.EXPECT().FetchBooks(gomock.Any()).Return([]db.Book{
+		{ID: 1, WorkID: work, PublicationDate: pgtype.Date{Time: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), Valid: true}},
+		{ID: 2},
+		{ID: 3, WorkID: work, PublicationDate: pgtype.Date{Time: time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC), Valid: true}},
+	}, nil)
+	mockPriceUc.EXPECT().FetchPricesInEffect(gomock.Any(), []int32{2, 3}, time.Time{}).Return(map[int32]db.BookPrice{}, nil)
+	mockPriceUc.EXPECT().ApplyPromotions(gomock.Any(), map[int32]db.BookPrice{}, time.Time{}).Return(map[int32]usecase.DiscountedPrice{}, nil)
+	mockUc.EXPECT().FetchBookRatings(gomock.Any(), []int32{2, 3}).Return(map[int32]db.BookRating{}, nil)
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/books?collapse=work", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewBookHandler(mockUc, mockPriceUc)
+	assert.NoError(t, h.FetchBooks(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestFetchBooksFailureInvalidCollapse(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成
+	mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+	mockPriceUc := mock_usecase.NewMockPriceUsecase(ctrl)
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/books?collapse=series", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewBookHandler(mockUc, mockPriceUc)
+	assert.NoError(t, h.FetchBooks(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.JSONEq(t, `{"message": "Invalid collapse parameter"}`, rec.Body.String())
+}
+
+func TestCreateBookNormalizesISBN(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	// ISBN-10はISBN-13に変換して登録する
+	mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+	mockPriceUc := mock_usecase.NewMockPriceUsecase(ctrl)
+	paramUc := db.CreateBookParams{
+		Title:     pgtype.Text{String: "test title 1", Valid: true},
+		Author:    pgtype.Text{String: "test author 1", Valid: true},
+		Publisher: pgtype.Text{String: "test publisher 1", Valid: true},
+		Price:     pgtype.Int4{Int32: 100, Valid: true},
+		Isbn:      pgtype.Text{String: "9784274217883", Valid: true},
+	}
+	mockUc.EXPECT().CreateBook(gomock.Any(), &paramUc, true).Return(&db.Book{ID: 1}, nil)
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	reqBody := `{"title": "test title 1", "author": "test author 1", "publisher": "test publisher 1", "price": 100, "isbn": "4-274-21788-4"}`
+	req := httptest.NewRequest(http.MethodPost, "/books?force=true", bytes.NewReader([]byte(reqBody)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewBookHandler(mockUc, mockPriceUc)
+	assert.NoError(t, h.CreateBook(c))
+	assert.Equal(t, http.StatusCreated, rec.Code)
+}
+
+func TestCreateBookFailureDuplicate(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+	mockPriceUc := mock_usecase.NewMockPriceUsecase(ctrl)
+	mockUc.EXPECT().CreateBook(gomock.Any(), gomock.Any(), false).Return(nil, &usecase.DuplicateBookError{
+		Candidates: []db.Book{
+			{
+				ID:        3,
+				Title:     pgtype.Text{String: "test title 1", Valid: true},
+				Author:    pgtype.Text{String: "test author 1", Valid: true},
+				Publisher: pgtype.Text{String: "test publisher 1", Valid: true},
+				Isbn:      pgtype.Text{String: "9784274217883", Valid: true},
+			},
+		},
+	})
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	reqBody := `{"title": "test title 1", "author": "test author 1", "publisher": "test publisher 1", "price": 100}`
+	req := httptest.NewRequest(http.MethodPost, "/books", bytes.NewReader([]byte(reqBody)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewBookHandler(mockUc, mockPriceUc)
+	assert.NoError(t, h.CreateBook(c))
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.JSONEq(t, `{
+		"message": "Possible duplicate books found",
+		"duplicates": [{
+			"id": 3, "title": "test title 1", "author": "test author 1", "publisher": "test publisher 1",
+			"isbn": "9784274217883", "href": "http://example.com/books/3"
+		}]
+	}`, rec.Body.String())
+}
+
+func TestCreateBookFailureInvalidForce(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成
+	mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+	mockPriceUc := mock_usecase.NewMockPriceUsecase(ctrl)
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/books?force=maybe", bytes.NewReader([]byte(`{}`)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewBookHandler(mockUc, mockPriceUc)
+	assert.NoError(t, h.CreateBook(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.JSONEq(t, `{"message": "Invalid force parameter"}`, rec.Body.String())
+}
+
+func TestCreateBookIdempotently(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+	mockPriceUc := mock_usecase.NewMockPriceUsecase(ctrl)
+	paramUc := db.CreateBookParams{
+		Title:     pgtype.Text{String: "test title 1", Valid: true},
+		Author:    pgtype.Text{String: "test author 1", Valid: true},
+		Publisher: pgtype.Text{String: "test publisher 1", Valid: true},
+		Price:     pgtype.Int4{Int32: 100, Valid: true},
+	}
+	gomock.InOrder(
+		mockUc.EXPECT().CreateBookIdempotently(gomock.Any(), "key-1", &paramUc, false).Return(&usecase.IdempotentBookCreation{BookID: 1}, nil),
+		mockUc.EXPECT().CreateBookIdempotently(gomock.Any(), "key-1", &paramUc, false).Return(&usecase.IdempotentBookCreation{BookID: 1, Replayed: true}, nil),
+	)
+
+	// リクエストボディを設定
+	reqBody := []byte(`{"title": "test title 1", "author": "test author 1", "publisher": "test publisher 1", "price": 100}`)
+
+	// ハンドラを作成し、初回と再試行で同じレスポンスを返すことを検証
+	e := echo.New()
+	h := handler.NewBookHandler(mockUc, mockPriceUc)
+	for _, replayed := range []string{"", "true"} {
+		req := httptest.NewRequest(http.MethodPost, "/books", bytes.NewReader(reqBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set("Idempotency-Key", "key-1")
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		assert.NoError(t, h.CreateBook(c))
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, "http://example.com/books/1", rec.Header().Get("Location"))
+		assert.Equal(t, replayed, rec.Header().Get("Idempotent-Replayed"))
+	}
+}
+
+func TestCreateBookFailureIdempotencyKeyReused(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+	mockPriceUc := mock_usecase.NewMockPriceUsecase(ctrl)
+	mockUc.EXPECT().CreateBookIdempotently(gomock.Any(), "key-1", gomock.Any(), false).Return(nil, usecase.ErrIdempotencyKeyReused)
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	reqBody := []byte(`{"title": "test title 2", "author": "test author 1", "publisher": "test publisher 1", "price": 100}`)
+	req := httptest.NewRequest(http.MethodPost, "/books", bytes.NewReader(reqBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("Idempotency-Key", "key-1")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewBookHandler(mockUc, mockPriceUc)
+	assert.NoError(t, h.CreateBook(c))
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.JSONEq(t, `{"message": "Idempotency-Key was reused with a different request"}`, rec.Body.String())
+	assert.Empty(t, rec.Header().Get("Location"))
+}
+
+func TestCreateBookFailureInvalidIdempotencyKey(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成
+	mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+	mockPriceUc := mock_usecase.NewMockPriceUsecase(ctrl)
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/books", bytes.NewReader([]byte(`{}`)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("Idempotency-Key", strings.Repeat("k", 256))
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewBookHandler(mockUc, mockPriceUc)
+	assert.NoError(t, h.CreateBook(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.JSONEq(t, `{"message": "Invalid Idempotency-Key"}`, rec.Body.String())
+}
+
+func TestBulkUpdateBooks(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+	mockPriceUc := mock_usecase.NewMockPriceUsecase(ctrl)
+	filter := db.ListBooksByFilterParams{
+		Ids:       []int32{1, 2, 3},
+		Publisher: pgtype.Text{String: "old publisher", Valid: true},
+	}
+	patch := repository.BookPatch{
+		Publisher: pgtype.Text{String: "new publisher", Valid: true},
+		Price:     pgtype.Int4{Int32: 300, Valid: true},
+	}
+	mockUc.EXPECT().BulkUpdateBooks(gomock.Any(), &filter, &patch, false).Return(&usecase.BulkResult{Affected: 2, BookIDs: []int32{1, 3}}, nil)
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	reqBody := `{"publisher": "new publisher", "price": 300}`
+	req := httptest.NewRequest(http.MethodPatch, "/books?ids=1,2,3&publisher=old+publisher", bytes.NewReader([]byte(reqBody)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewBookHandler(mockUc, mockPriceUc)
+	assert.NoError(t, h.BulkUpdateBooks(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"affected": 2, "book_ids": [1, 3], "dry_run": false}`, rec.Body.String())
+}
+
+func TestBulkUpdateBooksDryRun(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+	mockPriceUc := mock_usecase.NewMockPriceUsecase(ctrl)
+	filter := db.ListBooksByFilterParams{Author: pgtype.Text{String: "test author", Valid: true}}
+	patch := repository.BookPatch{Price: pgtype.Int4{Int32: 0, Valid: true}}
+	mockUc.EXPECT().BulkUpdateBooks(gomock.Any(), &filter, &patch, true).Return(&usecase.BulkResult{Affected: 1, BookIDs: []int32{4}, DryRun: true}, nil)
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPatch, "/books?author=test+author&dry_run=true", bytes.NewReader([]byte(`{"price": 0}`)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewBookHandler(mockUc, mockPriceUc)
+	assert.NoError(t, h.BulkUpdateBooks(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"affected": 1, "book_ids": [4], "dry_run": true}`, rec.Body.String())
+}
+
+func TestBulkUpdateBooksFailureInvalidParams(t *testing.T) {
+	cases := []struct {
+		query   string
+		message string
+	}{
+		{"", "A filter parameter is required"},
+		{"?dry_run=true", "A filter parameter is required"},
+		{"?ids=1,x", "Invalid ids parameter"},
+		{"?ids=1&dry_run=maybe", "Invalid dry_run parameter"},
+	}
+	for _, tc := range cases {
+		// モックコントローラを作成
+		ctrl := gomock.NewController(t)
+
+		// ユースケースのモックを作成
+		mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+		mockPriceUc := mock_usecase.NewMockPriceUsecase(ctrl)
+
+		// Echoのインスタンス、リクエスト、レスポンスを作成
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPatch, "/books"+tc.query, bytes.NewReader([]byte(`{"price": 300}`)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		// ハンドラを作成し、テスト項目を検証
+		h := handler.NewBookHandler(mockUc, mockPriceUc)
+		assert.NoError(t, h.BulkUpdateBooks(c))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, tc.query)
+		assert.JSONEq(t, fmt.Sprintf(`{"message": %q}`, tc.message), rec.Body.String(), tc.query)
+		ctrl.Finish()
+	}
+}
+
+func TestBulkUpdateBooksFailureValidation(t *testing.T) {
+	cases := []struct {
+		body   string
+		detail string
+	}{
+		{`{}`, "publisher or price must not be none."},
+		{`{"publisher": ""}`, "publisher must not be blank."},
+		{`{"price": -1}`, "price is invalid."},
+	}
+	for _, tc := range cases {
+		// モックコントローラを作成
+		ctrl := gomock.NewController(t)
+
+		// ユースケースのモックを作成
+		mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+		mockPriceUc := mock_usecase.NewMockPriceUsecase(ctrl)
+
+		// Echoのインスタンス、リクエスト、レスポンスを作成
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPatch, "/books?ids=1", bytes.NewReader([]byte(tc.body)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		// ハンドラを作成し、テスト項目を検証
+		h := handler.NewBookHandler(mockUc, mockPriceUc)
+		assert.NoError(t, h.BulkUpdateBooks(c))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, tc.body)
+		var res response.ValidationErrorResponse
+		assert.NoError(t, json.NewDecoder(rec.Body).Decode(&res))
+		assert.Equal(t, tc.detail, res.Detail, tc.body)
+		ctrl.Finish()
+	}
+}
+
+func TestDeleteBooks(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+	mockPriceUc := mock_usecase.NewMockPriceUsecase(ctrl)
+	filter := db.ListBooksByFilterParams{Publisher: pgtype.Text{String: "test publisher", Valid: true}}
+	mockUc.EXPECT().DeleteBooks(gomock.Any(), &filter, false).Return(&usecase.BulkResult{Affected: 0, BookIDs: []int32{}}, nil)
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodDelete, "/books?publisher=test+publisher", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewBookHandler(mockUc, mockPriceUc)
+	assert.NoError(t, h.DeleteBooks(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"affected": 0, "book_ids": [], "dry_run": false}`, rec.Body.String())
+}
+
+func TestDeleteBooksFailureNoFilter(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成
+	mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+	mockPriceUc := mock_usecase.NewMockPriceUsecase(ctrl)
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodDelete, "/books?dry_run=true", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewBookHandler(mockUc, mockPriceUc)
+	assert.NoError(t, h.DeleteBooks(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.JSONEq(t, `{"message": "A filter parameter is required"}`, rec.Body.String())
+}
+
+func TestMergeBook(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+	mockPriceUc := mock_usecase.NewMockPriceUsecase(ctrl)
+	expectUc := db.Book{
+		ID:        1,
+		Title:     pgtype.Text{String: "test title 1", Valid: true},
+		Author:    pgtype.Text{String: "test author 1", Valid: true},
+		Publisher: pgtype.Text{String: "test publisher 1", Valid: true},
+		Price:     pgtype.Int4{Int32: 200, Valid: true},
+	}
+	mockUc.EXPECT().MergeBook(gomock.Any(), 2, 1).Return(&expectUc, nil)
+	mockPriceUc.EXPECT().FetchPricesInEffect(gomock.Any(), []int32{1}, gomock.Any()).Return(map[int32]db.BookPrice{}, nil)
+	mockPriceUc.EXPECT().ApplyPromotions(gomock.Any(), map[int32]db.BookPrice{}, gomock.Any()).Return(map[int32]usecase.DiscountedPrice{}, nil)
+	mockUc.EXPECT().FetchBookRatings(gomock.Any(), []int32{1}).Return(map[int32]db.BookRating{}, nil)
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/books/2/merge", bytes.NewReader([]byte(`{"target_id": 1}`)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(strconv.Itoa(2))
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewBookHandler(mockUc, mockPriceUc)
+	assert.NoError(t, h.MergeBook(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{
+		"id": 1, "title": "test title 1", "author": "test author 1", "publisher": "test publisher 1", "price": 200,
+		"current_price": null, "converted_price": null, "discounted_price": null,
+		"average_rating": null, "review_count": 0, "work_id": null, "isbn": null,
+		"subtitle": null, "edition": null, "publication_date": null, "language": null, "page_count": null,
+		"format": null, "description": null, "series": null, "series_volume": null
+	}`, rec.Body.String())
+}
+
+func TestMergeBookFailure(t *testing.T) {
+	cases := []struct {
+		name    string
+		reqBody string
+		err     error
+		code    int
+		resBody string
+	}{
+		{"target missing", `{}`, nil, http.StatusBadRequest, `{
+			"type": "about:none", "title": "request validation error is occurred.",
+			"detail": "target_id must not be none.", "instance": "/books/2/merge"
+		}`},
+		{"self", `{"target_id": 2}`, usecase.ErrMergeIntoSelf, http.StatusBadRequest, `{"message": "Cannot merge a book into itself"}`},
+		{"source not found", `{"target_id": 1}`, repository.ErrMergeSourceNotFound, http.StatusNotFound, `{"message": "Book not found"}`},
+		{"target not found", `{"target_id": 1}`, repository.ErrMergeTargetNotFound, http.StatusNotFound, `{"message": "Target book not found"}`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// モックコントローラを作成
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// ユースケースのモックを作成し、期待値を設定
+			mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+			mockPriceUc := mock_usecase.NewMockPriceUsecase(ctrl)
+			if tc.err != nil {
+				mockUc.EXPECT().MergeBook(gomock.Any(), 2, gomock.Any()).Return(nil, tc.err)
+			}
+
+			// Echoのインスタンス、リクエスト、レスポンスを作成
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/books/2/merge", bytes.NewReader([]byte(tc.reqBody)))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(strconv.Itoa(2))
+
+			// ハンドラを作成し、テスト項目を検証
+			h := handler.NewBookHandler(mockUc, mockPriceUc)
+			assert.NoError(t, h.MergeBook(c))
+			assert.Equal(t, tc.code, rec.Code)
+			assert.JSONEq(t, tc.resBody, rec.Body.String())
+		})
+	}
+}
+
+func TestFindBookByIdMerged(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+	mockPriceUc := mock_usecase.NewMockPriceUsecase(ctrl)
+	mockUc.EXPECT().FindBookById(gomock.Any(), 2).Return(nil, &usecase.BookMergedError{TargetID: 1})
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/books/2?currency=USD", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(strconv.Itoa(2))
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewBookHandler(mockUc, mockPriceUc)
+	assert.NoError(t, h.FindBookById(c))
+	assert.Equal(t, http.StatusMovedPermanently, rec.Code)
+	assert.Equal(t, "/books/1?currency=USD", rec.Header().Get(echo.HeaderLocation))
+}
+
+func TestFetchBookAuditLogs(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+	mockPriceUc := mock_usecase.NewMockPriceUsecase(ctrl)
+	createdAt := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	expectUc := []db.BookAuditLog{
+		{
+			ID:        1,
+			BookID:    1,
+			Action:    repository.BookAuditActionMerge,
+			Detail:    []byte(`{"source_id": 2, "target_id": 1}`),
+			CreatedAt: pgtype.Timestamptz{Time: createdAt, Valid: true},
+		},
+	}
+	mockUc.EXPECT().FetchBookAuditLogs(gomock.Any(), 1).Return(expectUc, nil)
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/books/1/audit-logs", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(strconv.Itoa(1))
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewBookHandler(mockUc, mockPriceUc)
+	assert.NoError(t, h.FetchBookAuditLogs(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"audit_logs": [
+		{"id": 1, "action": "merge", "detail": {"source_id": 2, "target_id": 1}, "created_at": "2024-07-01T00:00:00Z"}
+	]}`, rec.Body.String())
+}
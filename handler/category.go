@@ -0,0 +1,304 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/handler/request"
+	"github.com/rentaro-m-b/ai-model-exam/handler/response"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	"github.com/rentaro-m-b/ai-model-exam/usecase"
+)
+
+type CategoryHandler interface {
+	CreateCategory(c echo.Context) error
+	FetchCategories(c echo.Context) error
+	FindCategoryById(c echo.Context) error
+	UpdateCategory(c echo.Context) error
+	MoveCategory(c echo.Context) error
+	DeleteCategory(c echo.Context) error
+	FetchBookCategories(c echo.Context) error
+	SetBookCategories(c echo.Context) error
+}
+
+type categoryHandlerImpl struct {
+	usecase usecase.CategoryUsecase
+}
+
+func NewCategoryHandler(usecase usecase.CategoryUsecase) CategoryHandler {
+	return &categoryHandlerImpl{
+		usecase: usecase,
+	}
+}
+
+func (h *categoryHandlerImpl) CreateCategory(c echo.Context) error {
+	body := new(request.CreateCategoryRequest)
+	if err := c.Bind(body); err != nil {
+		log.Printf("Unable to execute CategoryHandlerCreateCategory: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid request body",
+		})
+	}
+	if vs, ve := body.Validate(); ve != -1 {
+		return validationError(c, vs, ve)
+	}
+
+	param := db.CreateCategoryParams{
+		Name:     body.Name.String,
+		ParentID: pgtype.Int4{Int32: int32(body.ParentID.Int64), Valid: body.ParentID.Valid},
+	}
+
+	category, err := h.usecase.CreateCategory(context.Background(), &param)
+	switch {
+	case errors.Is(err, repository.ErrParentCategoryNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Parent category not found",
+		})
+	case isUniqueViolation(err):
+		return c.JSON(http.StatusConflict, map[string]string{
+			"message": "Category name already exists under the parent",
+		})
+	case err != nil:
+		log.Printf("Unable to execute CategoryHandlerCreateCategory: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+	location := fmt.Sprintf("%s/categories/%d", c.Scheme()+"://"+c.Request().Host, category.ID)
+	c.Response().Header().Set("Location", location)
+
+	return c.JSON(http.StatusCreated, response.ParseCategoryResponse(category))
+}
+
+func (h *categoryHandlerImpl) FetchCategories(c echo.Context) error {
+	categories, err := h.usecase.FetchCategoryTree(context.Background())
+	if err != nil {
+		log.Printf("Unable to execute CategoryHandlerFetchCategories: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.ParseFetchCategoriesResponse(categories))
+}
+
+func (h *categoryHandlerImpl) FindCategoryById(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Printf("Unable to execute CategoryHandlerFindCategoryById: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid category ID",
+		})
+	}
+
+	detail, err := h.usecase.FindCategoryById(context.Background(), id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Category not found",
+		})
+	}
+	if err != nil {
+		log.Printf("Unable to execute CategoryHandlerFindCategoryById: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.ParseCategoryDetailResponse(detail))
+}
+
+func (h *categoryHandlerImpl) UpdateCategory(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Printf("Unable to execute CategoryHandlerUpdateCategory: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid category ID",
+		})
+	}
+
+	body := new(request.UpdateCategoryRequest)
+	if err := c.Bind(body); err != nil {
+		log.Printf("Unable to execute CategoryHandlerUpdateCategory: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid request body",
+		})
+	}
+	if vs, ve := body.Validate(); ve != -1 {
+		return validationError(c, vs, ve)
+	}
+
+	category, err := h.usecase.RenameCategory(context.Background(), id, body.Name.String)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Category not found",
+		})
+	case isUniqueViolation(err):
+		return c.JSON(http.StatusConflict, map[string]string{
+			"message": "Category name already exists under the parent",
+		})
+	case err != nil:
+		log.Printf("Unable to execute CategoryHandlerUpdateCategory: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.ParseCategoryResponse(category))
+}
+
+func (h *categoryHandlerImpl) MoveCategory(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Printf("Unable to execute CategoryHandlerMoveCategory: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid category ID",
+		})
+	}
+
+	body := new(request.MoveCategoryRequest)
+	if err := c.Bind(body); err != nil {
+		log.Printf("Unable to execute CategoryHandlerMoveCategory: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid request body",
+		})
+	}
+	if vs, ve := body.Validate(); ve != -1 {
+		return validationError(c, vs, ve)
+	}
+
+	parentId := pgtype.Int4{Int32: int32(body.ParentID.Int64), Valid: body.ParentID.Valid}
+	category, err := h.usecase.MoveCategory(context.Background(), id, parentId)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Category not found",
+		})
+	case errors.Is(err, repository.ErrParentCategoryNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Parent category not found",
+		})
+	case errors.Is(err, repository.ErrCategoryCycle):
+		return c.JSON(http.StatusConflict, map[string]string{
+			"message": "Category cannot be moved into its own subtree",
+		})
+	case isUniqueViolation(err):
+		return c.JSON(http.StatusConflict, map[string]string{
+			"message": "Category name already exists under the parent",
+		})
+	case err != nil:
+		log.Printf("Unable to execute CategoryHandlerMoveCategory: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.ParseCategoryResponse(category))
+}
+
+func (h *categoryHandlerImpl) DeleteCategory(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Printf("Unable to execute CategoryHandlerDeleteCategory: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid category ID",
+		})
+	}
+
+	err = h.usecase.DeleteCategory(context.Background(), id)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Category not found",
+		})
+	case isForeignKeyViolation(err):
+		return c.JSON(http.StatusConflict, map[string]string{
+			"message": "Category has subcategories",
+		})
+	case err != nil:
+		log.Printf("Unable to execute CategoryHandlerDeleteCategory: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *categoryHandlerImpl) FetchBookCategories(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Printf("Unable to execute CategoryHandlerFetchBookCategories: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid book ID",
+		})
+	}
+
+	categories, err := h.usecase.FetchBookCategories(context.Background(), id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Book not found",
+		})
+	}
+	if err != nil {
+		log.Printf("Unable to execute CategoryHandlerFetchBookCategories: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.ParseFetchBookCategoriesResponse(categories))
+}
+
+func (h *categoryHandlerImpl) SetBookCategories(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Printf("Unable to execute CategoryHandlerSetBookCategories: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid book ID",
+		})
+	}
+
+	body := new(request.SetBookCategoriesRequest)
+	if err := c.Bind(body); err != nil {
+		log.Printf("Unable to execute CategoryHandlerSetBookCategories: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid request body",
+		})
+	}
+	if vs, ve := body.Validate(); ve != -1 {
+		return validationError(c, vs, ve)
+	}
+
+	categoryIds := make([]int32, 0, len(body.CategoryIDs))
+	for _, categoryId := range body.CategoryIDs {
+		categoryIds = append(categoryIds, int32(categoryId))
+	}
+
+	categories, err := h.usecase.SetBookCategories(context.Background(), id, categoryIds)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Book not found",
+		})
+	case errors.Is(err, repository.ErrCategoryNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Category not found",
+		})
+	case err != nil:
+		log.Printf("Unable to execute CategoryHandlerSetBookCategories: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.ParseFetchBookCategoriesResponse(categories))
+}
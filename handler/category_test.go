@@ -0,0 +1,183 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/guregu/null"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/handler"
+	"github.com/rentaro-m-b/ai-model-exam/handler/request"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	mock_usecase "github.com/rentaro-m-b/ai-model-exam/usecase/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCategoryContext(method string, path string, id int, body any) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	reqBody, _ := json.Marshal(body)
+	req := httptest.NewRequest(method, path, bytes.NewReader(reqBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(strconv.Itoa(id))
+
+	return c, rec
+}
+
+func TestCreateCategory(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockCategoryUsecase(ctrl)
+	paramUc := db.CreateCategoryParams{
+		Name:     "mystery",
+		ParentID: pgtype.Int4{Int32: 1, Valid: true},
+	}
+	mockUc.EXPECT().CreateCategory(gomock.Any(), &paramUc).Return(&db.Category{ID: 2, Name: "mystery", ParentID: paramUc.ParentID}, nil)
+
+	// リクエストボディを設定し、Echoのコンテキストを作成
+	e := echo.New()
+	reqBody, _ := json.Marshal(request.CreateCategoryRequest{
+		Name:     null.NewString("mystery", true),
+		ParentID: null.NewInt(1, true),
+	})
+	req := httptest.NewRequest(http.MethodPost, "/categories", bytes.NewReader(reqBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewCategoryHandler(mockUc)
+	assert.NoError(t, h.CreateCategory(c))
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "http://example.com/categories/2", rec.Header().Get("Location"))
+	assert.JSONEq(t, `{"id": 2, "name": "mystery", "parent_id": 1}`, rec.Body.String())
+}
+
+func TestFetchCategories(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockCategoryUsecase(ctrl)
+	mockUc.EXPECT().FetchCategoryTree(gomock.Any()).Return([]db.ListCategoryTreeRow{
+		{ID: 1, Name: "fiction", Depth: 0},
+		{ID: 2, Name: "mystery", ParentID: pgtype.Int4{Int32: 1, Valid: true}, Depth: 1},
+		{ID: 3, Name: "cozy", ParentID: pgtype.Int4{Int32: 2, Valid: true}, Depth: 2},
+		{ID: 4, Name: "sf", ParentID: pgtype.Int4{Int32: 1, Valid: true}, Depth: 1},
+		{ID: 5, Name: "history", Depth: 0},
+	}, nil)
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/categories", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewCategoryHandler(mockUc)
+	assert.NoError(t, h.FetchCategories(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"categories": [
+		{"id": 1, "name": "fiction", "children": [
+			{"id": 2, "name": "mystery", "children": [
+				{"id": 3, "name": "cozy", "children": []}
+			]},
+			{"id": 4, "name": "sf", "children": []}
+		]},
+		{"id": 5, "name": "history", "children": []}
+	]}`, rec.Body.String())
+}
+
+func TestMoveCategoryFailureCycle(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockCategoryUsecase(ctrl)
+	mockUc.EXPECT().MoveCategory(gomock.Any(), 1, pgtype.Int4{Int32: 3, Valid: true}).Return(nil, repository.ErrCategoryCycle)
+
+	// リクエストボディを設定し、Echoのコンテキストを作成
+	c, rec := newCategoryContext(http.MethodPost, "/categories/1/move", 1, request.MoveCategoryRequest{
+		ParentID: null.NewInt(3, true),
+	})
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewCategoryHandler(mockUc)
+	assert.NoError(t, h.MoveCategory(c))
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.JSONEq(t, `{"message": "Category cannot be moved into its own subtree"}`, rec.Body.String())
+}
+
+func TestMoveCategoryToRoot(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockCategoryUsecase(ctrl)
+	mockUc.EXPECT().MoveCategory(gomock.Any(), 2, pgtype.Int4{}).Return(&db.Category{ID: 2, Name: "mystery"}, nil)
+
+	// リクエストボディを設定し、Echoのコンテキストを作成
+	c, rec := newCategoryContext(http.MethodPost, "/categories/2/move", 2, map[string]any{"parent_id": nil})
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewCategoryHandler(mockUc)
+	assert.NoError(t, h.MoveCategory(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"id": 2, "name": "mystery", "parent_id": null}`, rec.Body.String())
+}
+
+func TestDeleteCategoryFailureHasSubcategories(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockCategoryUsecase(ctrl)
+	mockUc.EXPECT().DeleteCategory(gomock.Any(), 1).Return(&pgconn.PgError{Code: "23503"})
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	c, rec := newCategoryContext(http.MethodDelete, "/categories/1", 1, nil)
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewCategoryHandler(mockUc)
+	assert.NoError(t, h.DeleteCategory(c))
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.JSONEq(t, `{"message": "Category has subcategories"}`, rec.Body.String())
+}
+
+func TestSetBookCategoriesFailureCategoryNotFound(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockCategoryUsecase(ctrl)
+	mockUc.EXPECT().SetBookCategories(gomock.Any(), 1, []int32{2, 99}).Return(nil, repository.ErrCategoryNotFound)
+
+	// リクエストボディを設定し、Echoのコンテキストを作成
+	c, rec := newCategoryContext(http.MethodPut, "/books/1/categories", 1, request.SetBookCategoriesRequest{
+		CategoryIDs: []int64{2, 99},
+	})
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewCategoryHandler(mockUc)
+	assert.NoError(t, h.SetBookCategories(c))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.JSONEq(t, `{"message": "Category not found"}`, rec.Body.String())
+}
@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/rentaro-m-b/ai-model-exam/handler/response"
+	"github.com/rentaro-m-b/ai-model-exam/usecase"
+)
+
+const (
+	// maxCoverBytes は表紙画像のファイルサイズの上限
+	maxCoverBytes = 5 << 20
+	// maxCoverRequestBytes はマルチパートの境界やヘッダを含めたリクエスト全体の上限
+	maxCoverRequestBytes = maxCoverBytes + 1<<20
+	// coverCacheControl は表紙画像の配信時のCache-Controlヘッダ
+	// 置き換えに追従できるよう、期限切れ後はETagによる再検証を求める
+	coverCacheControl = "public, max-age=86400, must-revalidate"
+)
+
+type CoverHandler interface {
+	UploadCover(c echo.Context) error
+	FetchCover(c echo.Context) error
+}
+
+type coverHandlerImpl struct {
+	usecase usecase.CoverUsecase
+}
+
+func NewCoverHandler(usecase usecase.CoverUsecase) CoverHandler {
+	return &coverHandlerImpl{
+		usecase: usecase,
+	}
+}
+
+func coverTooLarge(c echo.Context) error {
+	return c.JSON(http.StatusRequestEntityTooLarge, map[string]string{
+		"message": "Cover image too large",
+	})
+}
+
+// UploadCover はマルチパートのcoverフィールドで送られた画像を書籍の表紙画像として登録する
+func (h *coverHandlerImpl) UploadCover(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Printf("Unable to execute CoverHandlerUploadCover: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid book ID",
+		})
+	}
+
+	c.Request().Body = http.MaxBytesReader(c.Response(), c.Request().Body, maxCoverRequestBytes)
+	fh, err := c.FormFile("cover")
+	if err != nil {
+		log.Printf("Unable to execute CoverHandlerUploadCover: %d\n", err)
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return coverTooLarge(c)
+		}
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid cover file",
+		})
+	}
+	if fh.Size > maxCoverBytes {
+		return coverTooLarge(c)
+	}
+
+	file, err := fh.Open()
+	if err != nil {
+		log.Printf("Unable to execute CoverHandlerUploadCover: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+	defer file.Close()
+	data, err := io.ReadAll(io.LimitReader(file, maxCoverBytes+1))
+	if err != nil {
+		log.Printf("Unable to execute CoverHandlerUploadCover: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+	if len(data) > maxCoverBytes {
+		return coverTooLarge(c)
+	}
+
+	cover, err := h.usecase.UploadCover(context.Background(), id, data)
+	switch {
+	case errors.Is(err, usecase.ErrUnsupportedImageType):
+		return c.JSON(http.StatusUnsupportedMediaType, map[string]string{
+			"message": "Cover image must be JPEG, PNG or GIF",
+		})
+	case errors.Is(err, usecase.ErrInvalidImage):
+		return c.JSON(http.StatusUnprocessableEntity, map[string]string{
+			"message": "Cover image cannot be decoded",
+		})
+	case errors.Is(err, usecase.ErrImageTooLarge):
+		return coverTooLarge(c)
+	case errors.Is(err, pgx.ErrNoRows):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Book not found",
+		})
+	case err != nil:
+		log.Printf("Unable to execute CoverHandlerUploadCover: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.ParseCoverResponse(cover))
+}
+
+// FetchCover は表紙画像を配信する
+// If-None-MatchやIf-Modified-Sinceが一致する場合は304を返す
+func (h *coverHandlerImpl) FetchCover(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Printf("Unable to execute CoverHandlerFetchCover: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid book ID",
+		})
+	}
+
+	cover, err := h.usecase.FetchCover(context.Background(), id, c.QueryParam("size"))
+	switch {
+	case errors.Is(err, usecase.ErrUnsupportedCoverSize):
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid size parameter",
+		})
+	case errors.Is(err, pgx.ErrNoRows):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Cover not found",
+		})
+	case err != nil:
+		log.Printf("Unable to execute CoverHandlerFetchCover: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	header := c.Response().Header()
+	header.Set(echo.HeaderContentType, cover.ContentType)
+	header.Set("Cache-Control", coverCacheControl)
+	header.Set("ETag", cover.ETag)
+	http.ServeContent(c.Response(), c.Request(), "", cover.UpdatedAt, bytes.NewReader(cover.Data))
+
+	return nil
+}
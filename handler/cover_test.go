@@ -0,0 +1,194 @@
+package handler_test
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/handler"
+	"github.com/rentaro-m-b/ai-model-exam/usecase"
+	mock_usecase "github.com/rentaro-m-b/ai-model-exam/usecase/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newUploadCoverContext(data []byte) (echo.Context, *httptest.ResponseRecorder) {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	part, _ := w.CreateFormFile("cover", "cover.png")
+	part.Write(data)
+	w.Close()
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPut, "/books/1/cover", &body)
+	req.Header.Set(echo.HeaderContentType, w.FormDataContentType())
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(strconv.Itoa(1))
+
+	return c, rec
+}
+
+func newFetchCoverContext(target string, header http.Header) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(strconv.Itoa(1))
+
+	return c, rec
+}
+
+func TestUploadCover(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockCoverUsecase(ctrl)
+	data := []byte("\x89PNG\r\n\x1a\ncover")
+	mockUc.EXPECT().UploadCover(gomock.Any(), 1, data).Return(&db.BookCover{
+		BookID:      1,
+		ContentType: "image/png",
+		Width:       800,
+		Height:      1200,
+		ByteSize:    int32(len(data)),
+		Checksum:    "abc",
+		UpdatedAt:   pgtype.Timestamptz{Time: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+	}, nil)
+
+	// マルチパートのリクエストボディを設定し、Echoのコンテキストを作成
+	c, rec := newUploadCoverContext(data)
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewCoverHandler(mockUc)
+	assert.NoError(t, h.UploadCover(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{
+		"book_id": 1, "content_type": "image/png", "width": 800, "height": 1200, "byte_size": 13,
+		"updated_at": "2024-06-01T00:00:00Z",
+		"urls": {
+			"original": "/books/1/cover", "small": "/books/1/cover?size=small",
+			"medium": "/books/1/cover?size=medium", "large": "/books/1/cover?size=large"
+		}
+	}`, rec.Body.String())
+}
+
+func TestUploadCoverFailureTooLarge(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成
+	mockUc := mock_usecase.NewMockCoverUsecase(ctrl)
+
+	// 上限を超える大きさのファイルを設定し、Echoのコンテキストを作成
+	c, rec := newUploadCoverContext(make([]byte, 5<<20+1))
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewCoverHandler(mockUc)
+	assert.NoError(t, h.UploadCover(c))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	assert.JSONEq(t, `{"message": "Cover image too large"}`, rec.Body.String())
+}
+
+func TestUploadCoverFailureUnsupportedType(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockCoverUsecase(ctrl)
+	mockUc.EXPECT().UploadCover(gomock.Any(), 1, gomock.Any()).Return(nil, usecase.ErrUnsupportedImageType)
+
+	// リクエストボディを設定し、Echoのコンテキストを作成
+	c, rec := newUploadCoverContext([]byte("plain text"))
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewCoverHandler(mockUc)
+	assert.NoError(t, h.UploadCover(c))
+	assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+}
+
+func TestFetchCover(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockCoverUsecase(ctrl)
+	mockUc.EXPECT().FetchCover(gomock.Any(), 1, "small").Return(&usecase.CoverImage{
+		Data:        []byte("small cover"),
+		ContentType: "image/jpeg",
+		ETag:        `"abc-small"`,
+		UpdatedAt:   time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+	}, nil)
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	c, rec := newFetchCoverContext("/books/1/cover?size=small", nil)
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewCoverHandler(mockUc)
+	assert.NoError(t, h.FetchCover(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "image/jpeg", rec.Header().Get("Content-Type"))
+	assert.Equal(t, `"abc-small"`, rec.Header().Get("ETag"))
+	assert.Equal(t, "public, max-age=86400, must-revalidate", rec.Header().Get("Cache-Control"))
+	assert.Equal(t, "Sat, 01 Jun 2024 00:00:00 GMT", rec.Header().Get("Last-Modified"))
+	assert.Equal(t, "small cover", rec.Body.String())
+}
+
+func TestFetchCoverNotModified(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockCoverUsecase(ctrl)
+	mockUc.EXPECT().FetchCover(gomock.Any(), 1, "").Return(&usecase.CoverImage{
+		Data:        []byte("original cover"),
+		ContentType: "image/png",
+		ETag:        `"abc-original"`,
+		UpdatedAt:   time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+	}, nil)
+
+	// ETagが一致する場合は本文を返さない
+	c, rec := newFetchCoverContext("/books/1/cover", http.Header{"If-None-Match": {`"abc-original"`}})
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewCoverHandler(mockUc)
+	assert.NoError(t, h.FetchCover(c))
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Empty(t, rec.Body.String())
+}
+
+func TestFetchCoverFailureInvalidSize(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockCoverUsecase(ctrl)
+	mockUc.EXPECT().FetchCover(gomock.Any(), 1, "huge").Return(nil, usecase.ErrUnsupportedCoverSize)
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	c, rec := newFetchCoverContext("/books/1/cover?size=huge", nil)
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewCoverHandler(mockUc)
+	assert.NoError(t, h.FetchCover(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.JSONEq(t, `{"message": "Invalid size parameter"}`, rec.Body.String())
+}
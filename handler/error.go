@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/labstack/echo/v4"
+	"github.com/rentaro-m-b/ai-model-exam/handler/request"
+	"github.com/rentaro-m-b/ai-model-exam/handler/response"
+)
+
+func validationError(c echo.Context, vs string, ve request.ValidationError) error {
+	var errRes response.ValidationErrorResponse
+	switch ve {
+	case request.ValidationErrRequestFieldMissing:
+		errRes.Type = "about:none"
+		errRes.Detail = fmt.Sprintf("%s must not be none.", vs)
+	case request.ValidationErrRequestFieldEmpty:
+		errRes.Type = "about:blank"
+		errRes.Detail = fmt.Sprintf("%s must not be blank.", vs)
+	case request.ValidationErrRequestFieldInvalid:
+		errRes.Type = "about:blank"
+		errRes.Detail = fmt.Sprintf("%s is invalid.", vs)
+	}
+	errRes.Title = "request validation error is occurred."
+	errRes.Instance = c.Request().URL.Path
+
+	return c.JSON(http.StatusBadRequest, errRes)
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+func isForeignKeyViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23503"
+}
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/handler/request"
+	"github.com/rentaro-m-b/ai-model-exam/handler/response"
+	"github.com/rentaro-m-b/ai-model-exam/usecase"
+)
+
+type ExchangeRateHandler interface {
+	ImportRates(c echo.Context) error
+}
+
+type exchangeRateHandlerImpl struct {
+	usecase usecase.ExchangeRateUsecase
+}
+
+func NewExchangeRateHandler(usecase usecase.ExchangeRateUsecase) ExchangeRateHandler {
+	return &exchangeRateHandlerImpl{
+		usecase: usecase,
+	}
+}
+
+func (h *exchangeRateHandlerImpl) ImportRates(c echo.Context) error {
+	body := new(request.ImportExchangeRatesRequest)
+	if err := c.Bind(body); err != nil {
+		log.Printf("Unable to execute ExchangeRateHandlerImportRates: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid request body",
+		})
+	}
+	if vs, ve := body.Validate(); ve != -1 {
+		return validationError(c, vs, ve)
+	}
+
+	params, err := parseExchangeRates(body)
+	if err != nil {
+		log.Printf("Unable to execute ExchangeRateHandlerImportRates: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid request body",
+		})
+	}
+
+	count, err := h.usecase.ImportRates(context.Background(), params)
+	switch {
+	case errors.Is(err, usecase.ErrUnsupportedCurrency):
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Unsupported currency",
+		})
+	case errors.Is(err, usecase.ErrSameCurrencyPair):
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Base and quote currency must differ",
+		})
+	case err != nil:
+		log.Printf("Unable to execute ExchangeRateHandlerImportRates: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, &response.ImportExchangeRatesResponse{Imported: count})
+}
+
+// parseExchangeRates は検証済みのリクエストのレートを、取り込むレートに変換する
+func parseExchangeRates(body *request.ImportExchangeRatesRequest) ([]db.UpsertExchangeRateParams, error) {
+	params := make([]db.UpsertExchangeRateParams, 0, len(body.Rates))
+	for _, rate := range body.Rates {
+		var numeric pgtype.Numeric
+		if err := numeric.Scan(rate.Rate.String); err != nil {
+			return nil, err
+		}
+		// 日付の形式はValidateで検証済み
+		effectiveDate, _ := time.Parse(time.DateOnly, rate.EffectiveDate.String)
+		params = append(params, db.UpsertExchangeRateParams{
+			BaseCurrency:  rate.BaseCurrency.String,
+			QuoteCurrency: rate.QuoteCurrency.String,
+			Rate:          numeric,
+			EffectiveDate: pgtype.Date{Time: effectiveDate, Valid: true},
+		})
+	}
+
+	return params, nil
+}
@@ -0,0 +1,132 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/guregu/null"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/handler"
+	"github.com/rentaro-m-b/ai-model-exam/handler/request"
+	"github.com/rentaro-m-b/ai-model-exam/handler/response"
+	"github.com/rentaro-m-b/ai-model-exam/usecase"
+	mock_usecase "github.com/rentaro-m-b/ai-model-exam/usecase/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newImportRatesContext(param request.ImportExchangeRatesRequest) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	reqBody, _ := json.Marshal(param)
+	req := httptest.NewRequest(http.MethodPost, "/admin/exchange-rates/import", bytes.NewReader(reqBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	return e.NewContext(req, rec), rec
+}
+
+func TestImportRates(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockExchangeRateUsecase(ctrl)
+	mockUc.EXPECT().ImportRates(gomock.Any(), []db.UpsertExchangeRateParams{
+		{
+			BaseCurrency:  "USD",
+			QuoteCurrency: "JPY",
+			Rate:          pgtype.Numeric{Int: big.NewInt(15423), Exp: -2, Valid: true},
+			EffectiveDate: pgtype.Date{Time: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+		},
+	}).Return(1, nil)
+
+	// リクエストボディを設定し、Echoのコンテキストを作成
+	c, rec := newImportRatesContext(request.ImportExchangeRatesRequest{
+		Rates: []request.ExchangeRateRequest{
+			{
+				BaseCurrency:  null.NewString("USD", true),
+				QuoteCurrency: null.NewString("JPY", true),
+				Rate:          null.NewString("154.23", true),
+				EffectiveDate: null.NewString("2024-05-01", true),
+			},
+		},
+	})
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewExchangeRateHandler(mockUc)
+	assert.NoError(t, h.ImportRates(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"imported": 1}`, rec.Body.String())
+}
+
+func TestImportRatesFailureValidationInvalid(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成
+	mockUc := mock_usecase.NewMockExchangeRateUsecase(ctrl)
+
+	// リクエストボディを設定し、Echoのコンテキストを作成
+	c, rec := newImportRatesContext(request.ImportExchangeRatesRequest{
+		Rates: []request.ExchangeRateRequest{
+			{
+				BaseCurrency:  null.NewString("USD", true),
+				QuoteCurrency: null.NewString("JPY", true),
+				Rate:          null.NewString("154.23", true),
+				EffectiveDate: null.NewString("2024-05-01", true),
+			},
+			{
+				BaseCurrency:  null.NewString("EUR", true),
+				QuoteCurrency: null.NewString("JPY", true),
+				Rate:          null.NewString("-1", true),
+				EffectiveDate: null.NewString("2024-05-01", true),
+			},
+		},
+	})
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewExchangeRateHandler(mockUc)
+	assert.NoError(t, h.ImportRates(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	var res response.ValidationErrorResponse
+	err := json.NewDecoder(rec.Body).Decode(&res)
+	assert.NoError(t, err)
+	assert.Equal(t, "rates[1].rate is invalid.", res.Detail)
+}
+
+func TestImportRatesFailureUnsupportedCurrency(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockExchangeRateUsecase(ctrl)
+	mockUc.EXPECT().ImportRates(gomock.Any(), gomock.Any()).Return(0, usecase.ErrUnsupportedCurrency)
+
+	// リクエストボディを設定し、Echoのコンテキストを作成
+	c, rec := newImportRatesContext(request.ImportExchangeRatesRequest{
+		Rates: []request.ExchangeRateRequest{
+			{
+				BaseCurrency:  null.NewString("XXX", true),
+				QuoteCurrency: null.NewString("JPY", true),
+				Rate:          null.NewString("1", true),
+				EffectiveDate: null.NewString("2024-05-01", true),
+			},
+		},
+	})
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewExchangeRateHandler(mockUc)
+	assert.NoError(t, h.ImportRates(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.JSONEq(t, `{"message": "Unsupported currency"}`, rec.Body.String())
+}
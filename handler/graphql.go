@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rentaro-m-b/ai-model-exam/graph"
+	"github.com/rentaro-m-b/ai-model-exam/handler/request"
+)
+
+type GraphQLHandler interface {
+	Query(c echo.Context) error
+}
+
+type graphQLHandlerImpl struct {
+	server *graph.Server
+}
+
+func NewGraphQLHandler(server *graph.Server) GraphQLHandler {
+	return &graphQLHandlerImpl{
+		server: server,
+	}
+}
+
+func (h *graphQLHandlerImpl) Query(c echo.Context) error {
+	body := new(request.GraphQLRequest)
+	if err := c.Bind(body); err != nil {
+		log.Printf("Unable to execute GraphQLHandlerQuery: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid request body",
+		})
+	}
+	if body.Query == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "query must not be blank.",
+		})
+	}
+
+	result := h.server.Do(context.Background(), body.Query, body.OperationName, body.Variables)
+
+	return c.JSON(http.StatusOK, result)
+}
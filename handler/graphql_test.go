@@ -0,0 +1,240 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/graph"
+	"github.com/rentaro-m-b/ai-model-exam/handler"
+	"github.com/rentaro-m-b/ai-model-exam/handler/request"
+	mock_usecase "github.com/rentaro-m-b/ai-model-exam/usecase/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func executeGraphQL(t *testing.T, mockUc *mock_usecase.MockBookUsecase, param request.GraphQLRequest) *httptest.ResponseRecorder {
+	server, err := graph.NewServer(mockUc)
+	assert.NoError(t, err)
+
+	e := echo.New()
+	reqBody, _ := json.Marshal(param)
+	req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(reqBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	h := handler.NewGraphQLHandler(server)
+	assert.NoError(t, h.Query(c))
+
+	return rec
+}
+
+func TestGraphQLBooks(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+	books := []db.Book{
+		{
+			ID:        1,
+			Title:     pgtype.Text{String: "test title 1", Valid: true},
+			Author:    pgtype.Text{String: "test author 1", Valid: true},
+			Publisher: pgtype.Text{String: "test publisher 1", Valid: true},
+			Price:     pgtype.Int4{Int32: 100, Valid: true},
+		},
+		{
+			ID:        2,
+			Title:     pgtype.Text{String: "test title 2", Valid: true},
+			Author:    pgtype.Text{String: "test author 2", Valid: true},
+			Publisher: pgtype.Text{String: "test publisher 1", Valid: true},
+			Price:     pgtype.Int4{Int32: 200, Valid: true},
+		},
+	}
+	paramUc := db.SearchBooksParams{
+		Publisher: pgtype.Text{String: "test publisher 1", Valid: true},
+		Limit:     10,
+		Offset:    0,
+	}
+	mockUc.EXPECT().SearchBooks(gomock.Any(), &paramUc).Return(books, nil)
+	// 著者ごとの書籍は一度の呼び出しにまとめて取得される
+	mockUc.EXPECT().FetchBooksByAuthors(gomock.Any(), []string{"test author 1", "test author 2"}).Return(books, nil).Times(1)
+
+	// リクエストボディを設定
+	param := request.GraphQLRequest{
+		Query: `query ($publisher: String) {
+			books(filter: {publisher: $publisher}, limit: 10) {
+				id
+				title
+				author { name books { id } }
+				publisher { name }
+				price
+			}
+		}`,
+		Variables: map[string]interface{}{"publisher": "test publisher 1"},
+	}
+
+	// ハンドラを実行し、テスト項目を検証
+	rec := executeGraphQL(t, mockUc, param)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	expect := `{"data": {"books": [
+		{"id": 1, "title": "test title 1", "author": {"name": "test author 1", "books": [{"id": 1}]}, "publisher": {"name": "test publisher 1"}, "price": 100},
+		{"id": 2, "title": "test title 2", "author": {"name": "test author 2", "books": [{"id": 2}]}, "publisher": {"name": "test publisher 1"}, "price": 200}
+	]}}`
+	assert.JSONEq(t, expect, rec.Body.String())
+}
+
+func TestGraphQLBook(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+	book := db.Book{
+		ID:        1,
+		Title:     pgtype.Text{String: "test title 1", Valid: true},
+		Author:    pgtype.Text{String: "test author 1", Valid: true},
+		Publisher: pgtype.Text{String: "test publisher 1", Valid: true},
+		Price:     pgtype.Int4{Int32: 100, Valid: true},
+	}
+	mockUc.EXPECT().FindBookById(gomock.Any(), 1).Return(&book, nil)
+
+	// リクエストボディを設定
+	param := request.GraphQLRequest{
+		Query: `{ book(id: 1) { id title publisher { name } } }`,
+	}
+
+	// ハンドラを実行し、テスト項目を検証
+	rec := executeGraphQL(t, mockUc, param)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	expect := `{"data": {"book": {"id": 1, "title": "test title 1", "publisher": {"name": "test publisher 1"}}}}`
+	assert.JSONEq(t, expect, rec.Body.String())
+}
+
+func TestGraphQLBookFailure(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+	mockUc.EXPECT().FindBookById(gomock.Any(), 1).Return(nil, fmt.Errorf("error"))
+
+	// リクエストボディを設定
+	param := request.GraphQLRequest{
+		Query: `{ book(id: 1) { id } }`,
+	}
+
+	// ハンドラを実行し、テスト項目を検証
+	rec := executeGraphQL(t, mockUc, param)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var res struct {
+		Data   map[string]interface{}   `json:"data"`
+		Errors []map[string]interface{} `json:"errors"`
+	}
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&res))
+	assert.Nil(t, res.Data["book"])
+	assert.Len(t, res.Errors, 1)
+}
+
+func TestGraphQLCreateBook(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+	paramUc := db.CreateBookParams{
+		Title:     pgtype.Text{String: "test title 1", Valid: true},
+		Author:    pgtype.Text{String: "test author 1", Valid: true},
+		Publisher: pgtype.Text{String: "test publisher 1", Valid: true},
+		Price:     pgtype.Int4{Int32: 100, Valid: true},
+	}
+	expectUc := db.Book{
+		ID:        1,
+		Title:     pgtype.Text{String: "test title 1", Valid: true},
+		Author:    pgtype.Text{String: "test author 1", Valid: true},
+		Publisher: pgtype.Text{String: "test publisher 1", Valid: true},
+		Price:     pgtype.Int4{Int32: 100, Valid: true},
+	}
+	mockUc.EXPECT().CreateBook(gomock.Any(), &paramUc, false).Return(&expectUc, nil)
+
+	// リクエストボディを設定
+	param := request.GraphQLRequest{
+		Query: `mutation {
+			createBook(input: {title: "test title 1", author: "test author 1", publisher: "test publisher 1", price: 100}) { id }
+		}`,
+	}
+
+	// ハンドラを実行し、テスト項目を検証
+	rec := executeGraphQL(t, mockUc, param)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"data": {"createBook": {"id": 1}}}`, rec.Body.String())
+}
+
+func TestGraphQLCreateBookFailureValidationEmpty(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成
+	mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+
+	// リクエストボディを設定
+	param := request.GraphQLRequest{
+		Query: `mutation {
+			createBook(input: {title: "", author: "test author 1", publisher: "test publisher 1", price: 100}) { id }
+		}`,
+	}
+
+	// ハンドラを実行し、テスト項目を検証
+	rec := executeGraphQL(t, mockUc, param)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "title must not be blank.")
+}
+
+func TestGraphQLFailureComplexity(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成（呼び出されないこと）
+	mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+
+	// リクエストボディを設定
+	param := request.GraphQLRequest{
+		Query: `{
+			books(limit: 100) {
+				author { books(limit: 100) { title } }
+			}
+		}`,
+	}
+
+	// ハンドラを実行し、テスト項目を検証
+	rec := executeGraphQL(t, mockUc, param)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "query complexity")
+}
+
+func TestGraphQLFailureEmptyQuery(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成
+	mockUc := mock_usecase.NewMockBookUsecase(ctrl)
+
+	// ハンドラを実行し、テスト項目を検証
+	rec := executeGraphQL(t, mockUc, request.GraphQLRequest{})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.JSONEq(t, `{"message": "query must not be blank."}`, rec.Body.String())
+}
@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/rentaro-m-b/ai-model-exam/handler/request"
+	"github.com/rentaro-m-b/ai-model-exam/handler/response"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	"github.com/rentaro-m-b/ai-model-exam/usecase"
+)
+
+type InventoryHandler interface {
+	FetchInventories(c echo.Context) error
+	FetchStockMovements(c echo.Context) error
+	AdjustStock(c echo.Context) error
+}
+
+type inventoryHandlerImpl struct {
+	usecase usecase.InventoryUsecase
+}
+
+func NewInventoryHandler(usecase usecase.InventoryUsecase) InventoryHandler {
+	return &inventoryHandlerImpl{
+		usecase: usecase,
+	}
+}
+
+func (h *inventoryHandlerImpl) FetchInventories(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Printf("Unable to execute InventoryHandlerFetchInventories: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid book ID",
+		})
+	}
+
+	inventories, err := h.usecase.FetchInventories(context.Background(), id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Book not found",
+		})
+	}
+	if err != nil {
+		log.Printf("Unable to execute InventoryHandlerFetchInventories: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.ParseFetchInventoriesResponse(inventories))
+}
+
+func (h *inventoryHandlerImpl) FetchStockMovements(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Printf("Unable to execute InventoryHandlerFetchStockMovements: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid book ID",
+		})
+	}
+
+	movements, err := h.usecase.FetchStockMovements(context.Background(), id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Book not found",
+		})
+	}
+	if err != nil {
+		log.Printf("Unable to execute InventoryHandlerFetchStockMovements: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.ParseFetchStockMovementsResponse(movements))
+}
+
+func (h *inventoryHandlerImpl) AdjustStock(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Printf("Unable to execute InventoryHandlerAdjustStock: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid book ID",
+		})
+	}
+
+	body := new(request.AdjustStockRequest)
+	if err := c.Bind(body); err != nil {
+		log.Printf("Unable to execute InventoryHandlerAdjustStock: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid request body",
+		})
+	}
+	if vs, ve := body.Validate(); ve != -1 {
+		return validationError(c, vs, ve)
+	}
+
+	movement, err := h.usecase.AdjustStock(context.Background(), id, body.Location.String, int(body.Quantity.Int64), body.Reason.String)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Book not found",
+		})
+	}
+	if errors.Is(err, repository.ErrInsufficientStock) {
+		return c.JSON(http.StatusConflict, map[string]string{
+			"message": "Insufficient stock",
+		})
+	}
+	if errors.Is(err, repository.ErrStockOverflow) {
+		return c.JSON(http.StatusConflict, map[string]string{
+			"message": "Stock quantity exceeds the limit",
+		})
+	}
+	if err != nil {
+		log.Printf("Unable to execute InventoryHandlerAdjustStock: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.JSON(http.StatusCreated, response.ParseStockMovementResponse(movement))
+}
@@ -0,0 +1,252 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/guregu/null"
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/handler"
+	"github.com/rentaro-m-b/ai-model-exam/handler/request"
+	"github.com/rentaro-m-b/ai-model-exam/handler/response"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	mock_usecase "github.com/rentaro-m-b/ai-model-exam/usecase/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFetchInventories(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockInventoryUsecase(ctrl)
+	expectsUc := []db.Inventory{
+		{BookID: 1, Location: "osaka", Quantity: 0},
+		{BookID: 1, Location: "tokyo", Quantity: 3},
+	}
+	mockUc.EXPECT().FetchInventories(gomock.Any(), 1).Return(expectsUc, nil)
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/books/1/inventories", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(strconv.Itoa(1))
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewInventoryHandler(mockUc)
+	assert.NoError(t, h.FetchInventories(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var res *response.FetchInventoriesResponses
+	err := json.NewDecoder(rec.Body).Decode(&res)
+	assert.NoError(t, err)
+	assert.Equal(t, response.ParseFetchInventoriesResponse(expectsUc), res)
+}
+
+func TestFetchInventoriesFailureNotFound(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockInventoryUsecase(ctrl)
+	mockUc.EXPECT().FetchInventories(gomock.Any(), 1).Return(nil, pgx.ErrNoRows)
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/books/1/inventories", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(strconv.Itoa(1))
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewInventoryHandler(mockUc)
+	assert.NoError(t, h.FetchInventories(c))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.JSONEq(t, `{"message": "Book not found"}`, rec.Body.String())
+}
+
+func TestFetchStockMovementsFailure(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockInventoryUsecase(ctrl)
+	mockUc.EXPECT().FetchStockMovements(gomock.Any(), 1).Return(nil, fmt.Errorf("error"))
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/books/1/stock-movements", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(strconv.Itoa(1))
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewInventoryHandler(mockUc)
+	assert.NoError(t, h.FetchStockMovements(c))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.JSONEq(t, `{"message": "Internal server error"}`, rec.Body.String())
+}
+
+func newAdjustStockContext(param request.AdjustStockRequest) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	reqBody, _ := json.Marshal(param)
+	req := httptest.NewRequest(http.MethodPost, "/books/1/inventories/adjustments", bytes.NewReader(reqBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(strconv.Itoa(1))
+
+	return c, rec
+}
+
+func TestAdjustStock(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockInventoryUsecase(ctrl)
+	expectUc := db.StockMovement{
+		ID:             1,
+		BookID:         1,
+		Location:       "tokyo",
+		QuantityChange: 5,
+		QuantityAfter:  5,
+		Reason:         "received",
+	}
+	mockUc.EXPECT().AdjustStock(gomock.Any(), 1, "tokyo", 5, "received").Return(&expectUc, nil)
+
+	// リクエストボディを設定し、Echoのコンテキストを作成
+	c, rec := newAdjustStockContext(request.AdjustStockRequest{
+		Location: null.NewString("tokyo", true),
+		Quantity: null.NewInt(5, true),
+		Reason:   null.NewString("received", true),
+	})
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewInventoryHandler(mockUc)
+	assert.NoError(t, h.AdjustStock(c))
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var res *response.StockMovementResponse
+	err := json.NewDecoder(rec.Body).Decode(&res)
+	assert.NoError(t, err)
+	assert.Equal(t, response.ParseStockMovementResponse(&expectUc), res)
+}
+
+func TestAdjustStockFailureInsufficientStock(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockInventoryUsecase(ctrl)
+	mockUc.EXPECT().AdjustStock(gomock.Any(), 1, "tokyo", 5, "sold").Return(nil, repository.ErrInsufficientStock)
+
+	// リクエストボディを設定し、Echoのコンテキストを作成
+	c, rec := newAdjustStockContext(request.AdjustStockRequest{
+		Location: null.NewString("tokyo", true),
+		Quantity: null.NewInt(5, true),
+		Reason:   null.NewString("sold", true),
+	})
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewInventoryHandler(mockUc)
+	assert.NoError(t, h.AdjustStock(c))
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.JSONEq(t, `{"message": "Insufficient stock"}`, rec.Body.String())
+}
+
+func TestAdjustStockFailureValidationInvalid(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成
+	mockUc := mock_usecase.NewMockInventoryUsecase(ctrl)
+
+	// リクエストボディを設定し、Echoのコンテキストを作成
+	c, rec := newAdjustStockContext(request.AdjustStockRequest{
+		Location: null.NewString("tokyo", true),
+		Quantity: null.NewInt(5, true),
+		Reason:   null.NewString("lost", true),
+	})
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewInventoryHandler(mockUc)
+	assert.NoError(t, h.AdjustStock(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	var res response.ValidationErrorResponse
+	err := json.NewDecoder(rec.Body).Decode(&res)
+	assert.NoError(t, err)
+	expect := response.ValidationErrorResponse{
+		Type:     "about:blank",
+		Title:    "request validation error is occurred.",
+		Detail:   "reason is invalid.",
+		Instance: "/books/1/inventories/adjustments",
+	}
+	assert.Equal(t, expect, res)
+}
+
+func TestAdjustStockFailureValidationQuantityTooLarge(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成
+	mockUc := mock_usecase.NewMockInventoryUsecase(ctrl)
+
+	// リクエストボディを設定し、Echoのコンテキストを作成
+	c, rec := newAdjustStockContext(request.AdjustStockRequest{
+		Location: null.NewString("tokyo", true),
+		Quantity: null.NewInt(math.MaxInt32+1, true),
+		Reason:   null.NewString("received", true),
+	})
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewInventoryHandler(mockUc)
+	assert.NoError(t, h.AdjustStock(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	var res response.ValidationErrorResponse
+	err := json.NewDecoder(rec.Body).Decode(&res)
+	assert.NoError(t, err)
+	assert.Equal(t, "quantity is invalid.", res.Detail)
+}
+
+func TestAdjustStockFailureOverflow(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockInventoryUsecase(ctrl)
+	mockUc.EXPECT().AdjustStock(gomock.Any(), 1, "tokyo", 5, "received").Return(nil, repository.ErrStockOverflow)
+
+	// リクエストボディを設定し、Echoのコンテキストを作成
+	c, rec := newAdjustStockContext(request.AdjustStockRequest{
+		Location: null.NewString("tokyo", true),
+		Quantity: null.NewInt(5, true),
+		Reason:   null.NewString("received", true),
+	})
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewInventoryHandler(mockUc)
+	assert.NoError(t, h.AdjustStock(c))
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.JSONEq(t, `{"message": "Stock quantity exceeds the limit"}`, rec.Body.String())
+}
@@ -0,0 +1,259 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/handler/request"
+	"github.com/rentaro-m-b/ai-model-exam/handler/response"
+	"github.com/rentaro-m-b/ai-model-exam/job"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	"github.com/rentaro-m-b/ai-model-exam/usecase"
+)
+
+type JobHandler interface {
+	EnqueueBookExport(c echo.Context) error
+	EnqueueBookBulkUpdate(c echo.Context) error
+	EnqueueBookBulkDelete(c echo.Context) error
+	EnqueueExchangeRateImport(c echo.Context) error
+	FindJobById(c echo.Context) error
+	CancelJob(c echo.Context) error
+	FetchJobArtifact(c echo.Context) error
+}
+
+type jobHandlerImpl struct {
+	usecase usecase.JobUsecase
+}
+
+func NewJobHandler(usecase usecase.JobUsecase) JobHandler {
+	return &jobHandlerImpl{
+		usecase: usecase,
+	}
+}
+
+// jobAccepted は登録したジョブを202で返し、状況を確認するURLをLocationヘッダで返す
+func jobAccepted(c echo.Context, j *db.Job) error {
+	location := fmt.Sprintf("%s/jobs/%d", c.Scheme()+"://"+c.Request().Host, j.ID)
+	c.Response().Header().Set("Location", location)
+
+	return c.JSON(http.StatusAccepted, response.ParseJobResponse(j))
+}
+
+// EnqueueBookExport は全ての書籍をCSVに書き出すジョブを登録する
+func (h *jobHandlerImpl) EnqueueBookExport(c echo.Context) error {
+	j, err := h.usecase.EnqueueBookExport(context.Background())
+	if err != nil {
+		log.Printf("Unable to execute JobHandlerEnqueueBookExport: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return jobAccepted(c, j)
+}
+
+// EnqueueBookBulkUpdate はPATCH /booksと同じ条件と値で一括更新するジョブを登録する
+// 対象の確認は ?dry_run=true を付けたPATCH /booksで行う
+func (h *jobHandlerImpl) EnqueueBookBulkUpdate(c echo.Context) error {
+	filter, message := parseBulkFilter(c)
+	if message != "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": message,
+		})
+	}
+
+	body := new(request.BulkUpdateBooksRequest)
+	if err := c.Bind(body); err != nil {
+		log.Printf("Unable to execute JobHandlerEnqueueBookBulkUpdate: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid request body",
+		})
+	}
+	if vs, ve := body.Validate(); ve != -1 {
+		return validationError(c, vs, ve)
+	}
+
+	patch := repository.BookPatch{
+		Publisher: pgtype.Text{String: body.Publisher.String, Valid: body.Publisher.Valid},
+		Price:     pgtype.Int4{Int32: int32(body.Price.Int64), Valid: body.Price.Valid},
+	}
+	j, err := h.usecase.EnqueueBookBulkUpdate(context.Background(), filter, &patch)
+	if err != nil {
+		log.Printf("Unable to execute JobHandlerEnqueueBookBulkUpdate: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return jobAccepted(c, j)
+}
+
+// EnqueueBookBulkDelete はDELETE /booksと同じ条件で一括削除するジョブを登録する
+func (h *jobHandlerImpl) EnqueueBookBulkDelete(c echo.Context) error {
+	filter, message := parseBulkFilter(c)
+	if message != "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": message,
+		})
+	}
+
+	j, err := h.usecase.EnqueueBookBulkDelete(context.Background(), filter)
+	if err != nil {
+		log.Printf("Unable to execute JobHandlerEnqueueBookBulkDelete: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return jobAccepted(c, j)
+}
+
+// EnqueueExchangeRateImport はPOST /admin/exchange-rates/importと同じリクエストで、為替レートを取り込むジョブを登録する
+func (h *jobHandlerImpl) EnqueueExchangeRateImport(c echo.Context) error {
+	body := new(request.ImportExchangeRatesRequest)
+	if err := c.Bind(body); err != nil {
+		log.Printf("Unable to execute JobHandlerEnqueueExchangeRateImport: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid request body",
+		})
+	}
+	if vs, ve := body.Validate(); ve != -1 {
+		return validationError(c, vs, ve)
+	}
+
+	params, err := parseExchangeRates(body)
+	if err != nil {
+		log.Printf("Unable to execute JobHandlerEnqueueExchangeRateImport: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid request body",
+		})
+	}
+
+	j, err := h.usecase.EnqueueExchangeRateImport(context.Background(), params)
+	switch {
+	case errors.Is(err, usecase.ErrUnsupportedCurrency):
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Unsupported currency",
+		})
+	case errors.Is(err, usecase.ErrSameCurrencyPair):
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Base and quote currency must differ",
+		})
+	case err != nil:
+		log.Printf("Unable to execute JobHandlerEnqueueExchangeRateImport: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return jobAccepted(c, j)
+}
+
+func (h *jobHandlerImpl) FindJobById(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Printf("Unable to execute JobHandlerFindJobById: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid job ID",
+		})
+	}
+
+	j, err := h.usecase.FindJobById(context.Background(), id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Job not found",
+		})
+	}
+	if err != nil {
+		log.Printf("Unable to execute JobHandlerFindJobById: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.ParseJobResponse(j))
+}
+
+// CancelJob は実行待ちのジョブをキャンセルして200を返し、実行中のジョブにはキャンセルを要求して202を返す
+// 実行中のジョブはワーカーが中断した時点でキャンセル済みになる
+func (h *jobHandlerImpl) CancelJob(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Printf("Unable to execute JobHandlerCancelJob: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid job ID",
+		})
+	}
+
+	j, err := h.usecase.CancelJob(context.Background(), id)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Job not found",
+		})
+	case errors.Is(err, usecase.ErrJobFinished):
+		return c.JSON(http.StatusConflict, map[string]string{
+			"message": "Job already finished",
+		})
+	case err != nil:
+		log.Printf("Unable to execute JobHandlerCancelJob: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	if j.Status == job.StatusCanceled {
+		return c.JSON(http.StatusOK, response.ParseJobResponse(j))
+	}
+	return c.JSON(http.StatusAccepted, response.ParseJobResponse(j))
+}
+
+// FetchJobArtifact はジョブが生成したファイルを、ジョブのIDをファイル名として添付ファイルで返す
+func (h *jobHandlerImpl) FetchJobArtifact(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Printf("Unable to execute JobHandlerFetchJobArtifact: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid job ID",
+		})
+	}
+
+	artifact, err := h.usecase.FetchJobArtifact(context.Background(), id)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Job not found",
+		})
+	case errors.Is(err, usecase.ErrJobArtifactNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Artifact not found",
+		})
+	case err != nil:
+		log.Printf("Unable to execute JobHandlerFetchJobArtifact: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	filename := fmt.Sprintf("job-%d", id)
+	if exts, _ := mime.ExtensionsByType(artifact.ContentType); len(exts) > 0 {
+		filename += exts[0]
+	}
+	header := c.Response().Header()
+	header.Set(echo.HeaderContentType, artifact.ContentType)
+	header.Set(echo.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	http.ServeContent(c.Response(), c.Request(), "", time.Time{}, bytes.NewReader(artifact.Data))
+
+	return nil
+}
@@ -0,0 +1,288 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/guregu/null"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/handler"
+	"github.com/rentaro-m-b/ai-model-exam/handler/request"
+	"github.com/rentaro-m-b/ai-model-exam/job"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	"github.com/rentaro-m-b/ai-model-exam/usecase"
+	mock_usecase "github.com/rentaro-m-b/ai-model-exam/usecase/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newJobContext(method string, target string, id string, body any) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	reqBody, _ := json.Marshal(body)
+	req := httptest.NewRequest(method, target, bytes.NewReader(reqBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(id)
+
+	return c, rec
+}
+
+func queuedJob(id int64, kind string) *db.Job {
+	return &db.Job{
+		ID:        id,
+		Kind:      kind,
+		Params:    []byte(`{}`),
+		Status:    job.StatusQueued,
+		CreatedAt: pgtype.Timestamptz{Time: time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+	}
+}
+
+func TestEnqueueBookExport(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockJobUsecase(ctrl)
+	mockUc.EXPECT().EnqueueBookExport(gomock.Any()).Return(queuedJob(1, usecase.JobKindBookExport), nil)
+
+	// Echoのコンテキストを作成
+	c, rec := newJobContext(http.MethodPost, "/books/exports", "", nil)
+
+	// ハンドラを作成し、テスト項目を検証（202と状況を確認するURLを返す）
+	h := handler.NewJobHandler(mockUc)
+	assert.NoError(t, h.EnqueueBookExport(c))
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Equal(t, "http://example.com/jobs/1", rec.Header().Get("Location"))
+	assert.JSONEq(t, `{
+		"id": 1, "kind": "book_export", "status": "queued", "progress": {"done": 0, "total": null},
+		"cancel_requested": false, "attempts": 0, "result": null, "error": null, "artifact_url": null,
+		"created_at": "2024-07-01T00:00:00Z", "started_at": null, "finished_at": null
+	}`, rec.Body.String())
+}
+
+func TestEnqueueBookBulkUpdate(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockJobUsecase(ctrl)
+	filter := db.ListBooksByFilterParams{Author: pgtype.Text{String: "author", Valid: true}}
+	patch := repository.BookPatch{Price: pgtype.Int4{Int32: 300, Valid: true}}
+	mockUc.EXPECT().EnqueueBookBulkUpdate(gomock.Any(), &filter, &patch).Return(queuedJob(2, usecase.JobKindBookBulkUpdate), nil)
+
+	// リクエストボディを設定し、Echoのコンテキストを作成
+	c, rec := newJobContext(http.MethodPost, "/books/bulk-updates?author=author", "", request.BulkUpdateBooksRequest{
+		Price: null.IntFrom(300),
+	})
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewJobHandler(mockUc)
+	assert.NoError(t, h.EnqueueBookBulkUpdate(c))
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Equal(t, "http://example.com/jobs/2", rec.Header().Get("Location"))
+}
+
+func TestEnqueueBookBulkDeleteFailureNoFilter(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成（呼ばれないこと）
+	mockUc := mock_usecase.NewMockJobUsecase(ctrl)
+
+	// Echoのコンテキストを作成
+	c, rec := newJobContext(http.MethodPost, "/books/bulk-deletes", "", nil)
+
+	// ハンドラを作成し、テスト項目を検証（条件の無い一括削除は受け付けない）
+	h := handler.NewJobHandler(mockUc)
+	assert.NoError(t, h.EnqueueBookBulkDelete(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestEnqueueExchangeRateImportFailureUnsupportedCurrency(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockJobUsecase(ctrl)
+	mockUc.EXPECT().EnqueueExchangeRateImport(gomock.Any(), gomock.Any()).Return(nil, usecase.ErrUnsupportedCurrency)
+
+	// リクエストボディを設定し、Echoのコンテキストを作成
+	c, rec := newJobContext(http.MethodPost, "/admin/exchange-rates/import-jobs", "", request.ImportExchangeRatesRequest{
+		Rates: []request.ExchangeRateRequest{
+			{
+				BaseCurrency:  null.NewString("XXX", true),
+				QuoteCurrency: null.NewString("JPY", true),
+				Rate:          null.NewString("1", true),
+				EffectiveDate: null.NewString("2024-05-01", true),
+			},
+		},
+	})
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewJobHandler(mockUc)
+	assert.NoError(t, h.EnqueueExchangeRateImport(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.JSONEq(t, `{"message": "Unsupported currency"}`, rec.Body.String())
+}
+
+func TestFindJobById(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockJobUsecase(ctrl)
+	j := queuedJob(1, usecase.JobKindBookExport)
+	j.Status = job.StatusSucceeded
+	j.ProgressDone = 2
+	j.ProgressTotal = pgtype.Int4{Int32: 2, Valid: true}
+	j.Attempts = 1
+	j.Result = []byte(`{"exported": 2}`)
+	j.ArtifactKey = pgtype.Text{String: job.ArtifactKey(1), Valid: true}
+	j.ArtifactType = pgtype.Text{String: "text/csv; charset=utf-8", Valid: true}
+	j.StartedAt = pgtype.Timestamptz{Time: time.Date(2024, 7, 1, 0, 0, 1, 0, time.UTC), Valid: true}
+	j.FinishedAt = pgtype.Timestamptz{Time: time.Date(2024, 7, 1, 0, 0, 2, 0, time.UTC), Valid: true}
+	mockUc.EXPECT().FindJobById(gomock.Any(), int64(1)).Return(j, nil)
+
+	// Echoのコンテキストを作成
+	c, rec := newJobContext(http.MethodGet, "/jobs/1", "1", nil)
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewJobHandler(mockUc)
+	assert.NoError(t, h.FindJobById(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{
+		"id": 1, "kind": "book_export", "status": "succeeded", "progress": {"done": 2, "total": 2},
+		"cancel_requested": false, "attempts": 1, "result": {"exported": 2}, "error": null,
+		"artifact_url": "/jobs/1/artifact", "created_at": "2024-07-01T00:00:00Z",
+		"started_at": "2024-07-01T00:00:01Z", "finished_at": "2024-07-01T00:00:02Z"
+	}`, rec.Body.String())
+}
+
+func TestFindJobByIdFailureNotFound(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockJobUsecase(ctrl)
+	mockUc.EXPECT().FindJobById(gomock.Any(), int64(9)).Return(nil, pgx.ErrNoRows)
+
+	// Echoのコンテキストを作成
+	c, rec := newJobContext(http.MethodGet, "/jobs/9", "9", nil)
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewJobHandler(mockUc)
+	assert.NoError(t, h.FindJobById(c))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.JSONEq(t, `{"message": "Job not found"}`, rec.Body.String())
+}
+
+func TestCancelJob(t *testing.T) {
+	cases := []struct {
+		name   string
+		status string
+		code   int
+	}{
+		{"queued job is canceled", job.StatusCanceled, http.StatusOK},
+		{"running job is requested to cancel", job.StatusRunning, http.StatusAccepted},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// モックコントローラを作成
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// ユースケースのモックを作成し、期待値を設定
+			mockUc := mock_usecase.NewMockJobUsecase(ctrl)
+			j := queuedJob(1, usecase.JobKindBookExport)
+			j.Status = tc.status
+			j.CancelRequested = tc.status == job.StatusRunning
+			mockUc.EXPECT().CancelJob(gomock.Any(), int64(1)).Return(j, nil)
+
+			// Echoのコンテキストを作成
+			c, rec := newJobContext(http.MethodPost, "/jobs/1/cancel", "1", nil)
+
+			// ハンドラを作成し、テスト項目を検証
+			h := handler.NewJobHandler(mockUc)
+			assert.NoError(t, h.CancelJob(c))
+			assert.Equal(t, tc.code, rec.Code)
+		})
+	}
+}
+
+func TestCancelJobFailureFinished(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockJobUsecase(ctrl)
+	mockUc.EXPECT().CancelJob(gomock.Any(), int64(1)).Return(nil, usecase.ErrJobFinished)
+
+	// Echoのコンテキストを作成
+	c, rec := newJobContext(http.MethodPost, "/jobs/1/cancel", "1", nil)
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewJobHandler(mockUc)
+	assert.NoError(t, h.CancelJob(c))
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.JSONEq(t, `{"message": "Job already finished"}`, rec.Body.String())
+}
+
+func TestFetchJobArtifact(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockJobUsecase(ctrl)
+	mockUc.EXPECT().FetchJobArtifact(gomock.Any(), int64(1)).Return(&usecase.JobArtifact{
+		Data:        []byte("id,title\n1,title\n"),
+		ContentType: "text/csv; charset=utf-8",
+	}, nil)
+
+	// Echoのコンテキストを作成
+	c, rec := newJobContext(http.MethodGet, "/jobs/1/artifact", "1", nil)
+
+	// ハンドラを作成し、テスト項目を検証（ジョブのIDをファイル名として添付ファイルで返す）
+	h := handler.NewJobHandler(mockUc)
+	assert.NoError(t, h.FetchJobArtifact(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, "attachment; filename=job-1.csv", rec.Header().Get(echo.HeaderContentDisposition))
+	assert.Equal(t, "id,title\n1,title\n", rec.Body.String())
+}
+
+func TestFetchJobArtifactFailureNotFound(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockJobUsecase(ctrl)
+	mockUc.EXPECT().FetchJobArtifact(gomock.Any(), int64(1)).Return(nil, usecase.ErrJobArtifactNotFound)
+
+	// Echoのコンテキストを作成
+	c, rec := newJobContext(http.MethodGet, "/jobs/1/artifact", "1", nil)
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewJobHandler(mockUc)
+	assert.NoError(t, h.FetchJobArtifact(c))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.JSONEq(t, `{"message": "Artifact not found"}`, rec.Body.String())
+}
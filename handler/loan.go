@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/rentaro-m-b/ai-model-exam/handler/request"
+	"github.com/rentaro-m-b/ai-model-exam/handler/response"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	"github.com/rentaro-m-b/ai-model-exam/usecase"
+)
+
+type LoanHandler interface {
+	Checkout(c echo.Context) error
+	ReturnLoan(c echo.Context) error
+	FetchOverdueLoans(c echo.Context) error
+	FindAvailability(c echo.Context) error
+}
+
+type loanHandlerImpl struct {
+	usecase usecase.LoanUsecase
+}
+
+func NewLoanHandler(usecase usecase.LoanUsecase) LoanHandler {
+	return &loanHandlerImpl{
+		usecase: usecase,
+	}
+}
+
+func (h *loanHandlerImpl) Checkout(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Printf("Unable to execute LoanHandlerCheckout: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid book ID",
+		})
+	}
+
+	body := new(request.CheckoutRequest)
+	if err := c.Bind(body); err != nil {
+		log.Printf("Unable to execute LoanHandlerCheckout: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid request body",
+		})
+	}
+	if vs, ve := body.Validate(); ve != -1 {
+		return validationError(c, vs, ve)
+	}
+
+	loan, err := h.usecase.Checkout(context.Background(), id, int(body.MemberID.Int64))
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Book or member not found",
+		})
+	case errors.Is(err, repository.ErrLoanLimitExceeded):
+		return c.JSON(http.StatusConflict, map[string]string{
+			"message": "Loan limit exceeded",
+		})
+	case errors.Is(err, repository.ErrNoCopyAvailable):
+		return c.JSON(http.StatusConflict, map[string]string{
+			"message": "No copy available",
+		})
+	case err != nil:
+		log.Printf("Unable to execute LoanHandlerCheckout: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.JSON(http.StatusCreated, response.ParseLoanResponse(loan))
+}
+
+func (h *loanHandlerImpl) ReturnLoan(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Printf("Unable to execute LoanHandlerReturnLoan: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid loan ID",
+		})
+	}
+
+	loan, err := h.usecase.ReturnLoan(context.Background(), id)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Loan not found",
+		})
+	case errors.Is(err, repository.ErrLoanAlreadyReturned):
+		return c.JSON(http.StatusConflict, map[string]string{
+			"message": "Loan already returned",
+		})
+	case err != nil:
+		log.Printf("Unable to execute LoanHandlerReturnLoan: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.ParseLoanResponse(loan))
+}
+
+func (h *loanHandlerImpl) FetchOverdueLoans(c echo.Context) error {
+	loans, err := h.usecase.FetchOverdueLoans(context.Background())
+	if err != nil {
+		log.Printf("Unable to execute LoanHandlerFetchOverdueLoans: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.ParseFetchOverdueLoansResponse(loans))
+}
+
+func (h *loanHandlerImpl) FindAvailability(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Printf("Unable to execute LoanHandlerFindAvailability: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid book ID",
+		})
+	}
+
+	availability, err := h.usecase.FindAvailability(context.Background(), id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Book not found",
+		})
+	}
+	if err != nil {
+		log.Printf("Unable to execute LoanHandlerFindAvailability: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.ParseFindAvailabilityResponse(availability))
+}
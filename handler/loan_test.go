@@ -0,0 +1,192 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/guregu/null"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/handler"
+	"github.com/rentaro-m-b/ai-model-exam/handler/request"
+	"github.com/rentaro-m-b/ai-model-exam/handler/response"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	mock_usecase "github.com/rentaro-m-b/ai-model-exam/usecase/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCheckoutContext(param request.CheckoutRequest) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	reqBody, _ := json.Marshal(param)
+	req := httptest.NewRequest(http.MethodPost, "/books/1/loans", bytes.NewReader(reqBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(strconv.Itoa(1))
+
+	return c, rec
+}
+
+func TestCheckout(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockLoanUsecase(ctrl)
+	loanedAt := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	expectUc := db.Loan{
+		ID:       1,
+		BookID:   1,
+		MemberID: 2,
+		LoanedAt: pgtype.Timestamptz{Time: loanedAt, Valid: true},
+		DueAt:    pgtype.Timestamptz{Time: time.Date(2024, 1, 15, 23, 59, 59, 0, time.UTC), Valid: true},
+	}
+	mockUc.EXPECT().Checkout(gomock.Any(), 1, 2).Return(&expectUc, nil)
+
+	// リクエストボディを設定し、Echoのコンテキストを作成
+	c, rec := newCheckoutContext(request.CheckoutRequest{
+		MemberID: null.NewInt(2, true),
+	})
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewLoanHandler(mockUc)
+	assert.NoError(t, h.Checkout(c))
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var res *response.LoanResponse
+	err := json.NewDecoder(rec.Body).Decode(&res)
+	assert.NoError(t, err)
+	assert.Equal(t, response.ParseLoanResponse(&expectUc), res)
+}
+
+func TestCheckoutFailureLoanLimitExceeded(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockLoanUsecase(ctrl)
+	mockUc.EXPECT().Checkout(gomock.Any(), 1, 2).Return(nil, repository.ErrLoanLimitExceeded)
+
+	// リクエストボディを設定し、Echoのコンテキストを作成
+	c, rec := newCheckoutContext(request.CheckoutRequest{
+		MemberID: null.NewInt(2, true),
+	})
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewLoanHandler(mockUc)
+	assert.NoError(t, h.Checkout(c))
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.JSONEq(t, `{"message": "Loan limit exceeded"}`, rec.Body.String())
+}
+
+func TestCheckoutFailureValidationNone(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成
+	mockUc := mock_usecase.NewMockLoanUsecase(ctrl)
+
+	// リクエストボディを設定し、Echoのコンテキストを作成
+	c, rec := newCheckoutContext(request.CheckoutRequest{})
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewLoanHandler(mockUc)
+	assert.NoError(t, h.Checkout(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	var res response.ValidationErrorResponse
+	err := json.NewDecoder(rec.Body).Decode(&res)
+	assert.NoError(t, err)
+	assert.Equal(t, "member_id must not be none.", res.Detail)
+}
+
+func TestReturnLoanFailureAlreadyReturned(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockLoanUsecase(ctrl)
+	mockUc.EXPECT().ReturnLoan(gomock.Any(), 1).Return(nil, repository.ErrLoanAlreadyReturned)
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/loans/1/return", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(strconv.Itoa(1))
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewLoanHandler(mockUc)
+	assert.NoError(t, h.ReturnLoan(c))
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.JSONEq(t, `{"message": "Loan already returned"}`, rec.Body.String())
+}
+
+func TestFetchOverdueLoans(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockLoanUsecase(ctrl)
+	expectsUc := []db.Loan{
+		{
+			ID:       1,
+			BookID:   1,
+			MemberID: 2,
+			LoanedAt: pgtype.Timestamptz{Time: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC), Valid: true},
+			DueAt:    pgtype.Timestamptz{Time: time.Date(2024, 1, 15, 23, 59, 59, 0, time.UTC), Valid: true},
+		},
+	}
+	mockUc.EXPECT().FetchOverdueLoans(gomock.Any()).Return(expectsUc, nil)
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/loans/overdue", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewLoanHandler(mockUc)
+	assert.NoError(t, h.FetchOverdueLoans(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var res *response.FetchOverdueLoansResponses
+	err := json.NewDecoder(rec.Body).Decode(&res)
+	assert.NoError(t, err)
+	assert.Equal(t, response.ParseFetchOverdueLoansResponse(expectsUc), res)
+}
+
+func TestFindAvailability(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockLoanUsecase(ctrl)
+	mockUc.EXPECT().FindAvailability(gomock.Any(), 1).Return(&db.GetBookAvailabilityRow{Total: 3, OnLoan: 1, OnHold: 1}, nil)
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/books/1/availability", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(strconv.Itoa(1))
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewLoanHandler(mockUc)
+	assert.NoError(t, h.FindAvailability(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"total": 3, "on_loan": 1, "on_hold": 1, "available": 1}`, rec.Body.String())
+}
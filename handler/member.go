@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/handler/request"
+	"github.com/rentaro-m-b/ai-model-exam/handler/response"
+	"github.com/rentaro-m-b/ai-model-exam/usecase"
+)
+
+type MemberHandler interface {
+	CreateMember(c echo.Context) error
+	FindMemberById(c echo.Context) error
+}
+
+type memberHandlerImpl struct {
+	usecase usecase.MemberUsecase
+}
+
+func NewMemberHandler(usecase usecase.MemberUsecase) MemberHandler {
+	return &memberHandlerImpl{
+		usecase: usecase,
+	}
+}
+
+func (h *memberHandlerImpl) CreateMember(c echo.Context) error {
+	body := new(request.CreateMemberRequest)
+	if err := c.Bind(body); err != nil {
+		log.Printf("Unable to execute MemberHandlerCreateMember: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid request body",
+		})
+	}
+	if vs, ve := body.Validate(); ve != -1 {
+		return validationError(c, vs, ve)
+	}
+
+	param := db.CreateMemberParams{
+		Name:  body.Name.String,
+		Email: body.Email.String,
+	}
+
+	member, err := h.usecase.CreateMember(context.Background(), &param)
+	if isUniqueViolation(err) {
+		return c.JSON(http.StatusConflict, map[string]string{
+			"message": "Email is already registered",
+		})
+	}
+	if err != nil {
+		log.Printf("Unable to execute MemberHandlerCreateMember: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+	location := fmt.Sprintf("%s/members/%d", c.Scheme()+"://"+c.Request().Host, member.ID)
+	c.Response().Header().Set("Location", location)
+
+	return c.JSON(http.StatusCreated, nil)
+}
+
+func (h *memberHandlerImpl) FindMemberById(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Printf("Unable to execute MemberHandlerFindMemberById: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid member ID",
+		})
+	}
+
+	member, err := h.usecase.FindMemberById(context.Background(), id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Member not found",
+		})
+	}
+	if err != nil {
+		log.Printf("Unable to execute MemberHandlerFindMemberById: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.ParseFindMemberByIdResponse(member))
+}
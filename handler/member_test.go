@@ -0,0 +1,103 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/guregu/null"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/labstack/echo/v4"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/handler"
+	"github.com/rentaro-m-b/ai-model-exam/handler/request"
+	mock_usecase "github.com/rentaro-m-b/ai-model-exam/usecase/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCreateMemberContext(param request.CreateMemberRequest) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	reqBody, _ := json.Marshal(param)
+	req := httptest.NewRequest(http.MethodPost, "/members", bytes.NewReader(reqBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	return e.NewContext(req, rec), rec
+}
+
+func TestCreateMember(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockMemberUsecase(ctrl)
+	paramUc := db.CreateMemberParams{
+		Name:  "test member 1",
+		Email: "member1@example.com",
+	}
+	mockUc.EXPECT().CreateMember(gomock.Any(), &paramUc).Return(&db.Member{ID: 1}, nil)
+
+	// リクエストボディを設定し、Echoのコンテキストを作成
+	c, rec := newCreateMemberContext(request.CreateMemberRequest{
+		Name:  null.NewString("test member 1", true),
+		Email: null.NewString("member1@example.com", true),
+	})
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewMemberHandler(mockUc)
+	assert.NoError(t, h.CreateMember(c))
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "http://example.com/members/1", rec.Header().Get("Location"))
+}
+
+func TestCreateMemberFailureDuplicateEmail(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockMemberUsecase(ctrl)
+	mockUc.EXPECT().CreateMember(gomock.Any(), gomock.Any()).Return(nil, &pgconn.PgError{Code: "23505"})
+
+	// リクエストボディを設定し、Echoのコンテキストを作成
+	c, rec := newCreateMemberContext(request.CreateMemberRequest{
+		Name:  null.NewString("test member 1", true),
+		Email: null.NewString("member1@example.com", true),
+	})
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewMemberHandler(mockUc)
+	assert.NoError(t, h.CreateMember(c))
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.JSONEq(t, `{"message": "Email is already registered"}`, rec.Body.String())
+}
+
+func TestFindMemberByIdFailureNotFound(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockMemberUsecase(ctrl)
+	mockUc.EXPECT().FindMemberById(gomock.Any(), 1).Return(nil, pgx.ErrNoRows)
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/members/:id", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(strconv.Itoa(1))
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewMemberHandler(mockUc)
+	assert.NoError(t, h.FindMemberById(c))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.JSONEq(t, `{"message": "Member not found"}`, rec.Body.String())
+}
@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/rentaro-m-b/ai-model-exam/handler/request"
+	"github.com/rentaro-m-b/ai-model-exam/handler/response"
+	"github.com/rentaro-m-b/ai-model-exam/usecase"
+)
+
+type PriceHandler interface {
+	FetchPrices(c echo.Context) error
+	SchedulePrice(c echo.Context) error
+}
+
+type priceHandlerImpl struct {
+	usecase usecase.PriceUsecase
+}
+
+func NewPriceHandler(usecase usecase.PriceUsecase) PriceHandler {
+	return &priceHandlerImpl{
+		usecase: usecase,
+	}
+}
+
+func (h *priceHandlerImpl) FetchPrices(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Printf("Unable to execute PriceHandlerFetchPrices: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid book ID",
+		})
+	}
+
+	prices, err := h.usecase.FetchPrices(context.Background(), id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Book not found",
+		})
+	}
+	if err != nil {
+		log.Printf("Unable to execute PriceHandlerFetchPrices: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.ParseFetchPricesResponse(prices))
+}
+
+func (h *priceHandlerImpl) SchedulePrice(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Printf("Unable to execute PriceHandlerSchedulePrice: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid book ID",
+		})
+	}
+
+	body := new(request.SchedulePriceRequest)
+	if err := c.Bind(body); err != nil {
+		log.Printf("Unable to execute PriceHandlerSchedulePrice: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid request body",
+		})
+	}
+	if vs, ve := body.Validate(); ve != -1 {
+		return validationError(c, vs, ve)
+	}
+
+	price, err := h.usecase.SchedulePrice(context.Background(), id, body.Currency.String, body.Amount.Int64, body.EffectiveFrom.Time)
+	switch {
+	case errors.Is(err, usecase.ErrUnsupportedCurrency):
+		return validationError(c, "currency", request.ValidationErrRequestFieldInvalid)
+	case errors.Is(err, usecase.ErrPriceInPast):
+		return validationError(c, "effective_from", request.ValidationErrRequestFieldInvalid)
+	case errors.Is(err, pgx.ErrNoRows):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Book not found",
+		})
+	case isUniqueViolation(err):
+		return c.JSON(http.StatusConflict, map[string]string{
+			"message": "Price already scheduled at effective_from",
+		})
+	case err != nil:
+		log.Printf("Unable to execute PriceHandlerSchedulePrice: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.JSON(http.StatusCreated, response.ParsePriceResponse(price))
+}
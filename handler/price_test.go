@@ -0,0 +1,183 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/guregu/null"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/handler"
+	"github.com/rentaro-m-b/ai-model-exam/handler/request"
+	"github.com/rentaro-m-b/ai-model-exam/handler/response"
+	"github.com/rentaro-m-b/ai-model-exam/usecase"
+	mock_usecase "github.com/rentaro-m-b/ai-model-exam/usecase/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSchedulePriceContext(param request.SchedulePriceRequest) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	reqBody, _ := json.Marshal(param)
+	req := httptest.NewRequest(http.MethodPost, "/books/1/prices", bytes.NewReader(reqBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(strconv.Itoa(1))
+
+	return c, rec
+}
+
+func TestFetchPrices(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockPriceUsecase(ctrl)
+	changedAt := pgtype.Timestamptz{Time: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), Valid: true}
+	mockUc.EXPECT().FetchPrices(gomock.Any(), 1).Return([]db.BookPrice{
+		{ID: 2, BookID: 1, Currency: "USD", Amount: 1999, EffectiveFrom: changedAt},
+		{
+			ID:            1,
+			BookID:        1,
+			Currency:      "JPY",
+			Amount:        3080,
+			EffectiveFrom: pgtype.Timestamptz{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+			EffectiveTo:   changedAt,
+		},
+	}, nil)
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/books/1/prices", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(strconv.Itoa(1))
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewPriceHandler(mockUc)
+	assert.NoError(t, h.FetchPrices(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"prices": [
+		{"id": 2, "amount": 1999, "currency": "USD", "minor_units": 2, "decimal": "19.99", "effective_from": "2024-04-01T00:00:00Z", "effective_to": null},
+		{"id": 1, "amount": 3080, "currency": "JPY", "minor_units": 0, "decimal": "3080", "effective_from": "2024-01-01T00:00:00Z", "effective_to": "2024-04-01T00:00:00Z"}
+	]}`, rec.Body.String())
+}
+
+func TestFetchPricesFailureNotFound(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockPriceUsecase(ctrl)
+	mockUc.EXPECT().FetchPrices(gomock.Any(), 1).Return(nil, pgx.ErrNoRows)
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/books/1/prices", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(strconv.Itoa(1))
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewPriceHandler(mockUc)
+	assert.NoError(t, h.FetchPrices(c))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.JSONEq(t, `{"message": "Book not found"}`, rec.Body.String())
+}
+
+func TestSchedulePrice(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockPriceUsecase(ctrl)
+	effectiveFrom := time.Date(2030, 4, 1, 0, 0, 0, 0, time.UTC)
+	expectUc := db.BookPrice{
+		ID:            3,
+		BookID:        1,
+		Currency:      "USD",
+		Amount:        1999,
+		EffectiveFrom: pgtype.Timestamptz{Time: effectiveFrom, Valid: true},
+	}
+	mockUc.EXPECT().SchedulePrice(gomock.Any(), 1, "USD", int64(1999), effectiveFrom).Return(&expectUc, nil)
+
+	// リクエストボディを設定し、Echoのコンテキストを作成
+	c, rec := newSchedulePriceContext(request.SchedulePriceRequest{
+		Currency:      null.NewString("USD", true),
+		Amount:        null.NewInt(1999, true),
+		EffectiveFrom: null.NewTime(effectiveFrom, true),
+	})
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewPriceHandler(mockUc)
+	assert.NoError(t, h.SchedulePrice(c))
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var res *response.PriceResponse
+	err := json.NewDecoder(rec.Body).Decode(&res)
+	assert.NoError(t, err)
+	assert.Equal(t, response.ParsePriceResponse(&expectUc), res)
+}
+
+func TestSchedulePriceFailureValidationInvalid(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成
+	mockUc := mock_usecase.NewMockPriceUsecase(ctrl)
+
+	// リクエストボディを設定し、Echoのコンテキストを作成
+	c, rec := newSchedulePriceContext(request.SchedulePriceRequest{
+		Currency: null.NewString("usd", true),
+		Amount:   null.NewInt(1999, true),
+	})
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewPriceHandler(mockUc)
+	assert.NoError(t, h.SchedulePrice(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	var res response.ValidationErrorResponse
+	err := json.NewDecoder(rec.Body).Decode(&res)
+	assert.NoError(t, err)
+	assert.Equal(t, "currency is invalid.", res.Detail)
+}
+
+func TestSchedulePriceFailureInPast(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockPriceUsecase(ctrl)
+	mockUc.EXPECT().SchedulePrice(gomock.Any(), 1, "JPY", int64(2800), gomock.Any()).Return(nil, usecase.ErrPriceInPast)
+
+	// リクエストボディを設定し、Echoのコンテキストを作成
+	c, rec := newSchedulePriceContext(request.SchedulePriceRequest{
+		Currency:      null.NewString("JPY", true),
+		Amount:        null.NewInt(2800, true),
+		EffectiveFrom: null.NewTime(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), true),
+	})
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewPriceHandler(mockUc)
+	assert.NoError(t, h.SchedulePrice(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	var res response.ValidationErrorResponse
+	err := json.NewDecoder(rec.Body).Decode(&res)
+	assert.NoError(t, err)
+	assert.Equal(t, "effective_from is invalid.", res.Detail)
+}
@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/handler/request"
+	"github.com/rentaro-m-b/ai-model-exam/handler/response"
+	"github.com/rentaro-m-b/ai-model-exam/usecase"
+)
+
+type PromotionHandler interface {
+	CreatePromotion(c echo.Context) error
+	FetchPromotions(c echo.Context) error
+	FindPromotionById(c echo.Context) error
+	DeletePromotion(c echo.Context) error
+}
+
+type promotionHandlerImpl struct {
+	usecase usecase.PromotionUsecase
+}
+
+func NewPromotionHandler(usecase usecase.PromotionUsecase) PromotionHandler {
+	return &promotionHandlerImpl{
+		usecase: usecase,
+	}
+}
+
+func (h *promotionHandlerImpl) CreatePromotion(c echo.Context) error {
+	body := new(request.CreatePromotionRequest)
+	if err := c.Bind(body); err != nil {
+		log.Printf("Unable to execute PromotionHandlerCreatePromotion: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid request body",
+		})
+	}
+	if vs, ve := body.Validate(); ve != -1 {
+		return validationError(c, vs, ve)
+	}
+
+	param := db.CreatePromotionParams{
+		Name:          body.Name.String,
+		DiscountType:  body.DiscountType.String,
+		DiscountValue: body.DiscountValue.Int64,
+		Currency:      pgtype.Text{String: body.Currency.String, Valid: body.Currency.Valid},
+		TargetType:    body.TargetType.String,
+		TargetBookID:  pgtype.Int4{Int32: int32(body.TargetBookID.Int64), Valid: body.TargetBookID.Valid},
+		TargetName:    pgtype.Text{String: body.TargetName.String, Valid: body.TargetName.Valid},
+		StartsAt:      pgtype.Timestamptz{Time: body.StartsAt.Time, Valid: true},
+		EndsAt:        pgtype.Timestamptz{Time: body.EndsAt.Time, Valid: body.EndsAt.Valid},
+		Priority:      int32(body.Priority.Int64),
+		Stackable:     body.Stackable.Bool,
+	}
+
+	promotion, err := h.usecase.CreatePromotion(context.Background(), &param)
+	switch {
+	case errors.Is(err, usecase.ErrUnsupportedCurrency):
+		return validationError(c, "currency", request.ValidationErrRequestFieldInvalid)
+	case errors.Is(err, pgx.ErrNoRows):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Book not found",
+		})
+	case err != nil:
+		log.Printf("Unable to execute PromotionHandlerCreatePromotion: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+	location := fmt.Sprintf("%s/promotions/%d", c.Scheme()+"://"+c.Request().Host, promotion.ID)
+	c.Response().Header().Set("Location", location)
+
+	return c.JSON(http.StatusCreated, response.ParsePromotionResponse(promotion))
+}
+
+func (h *promotionHandlerImpl) FetchPromotions(c echo.Context) error {
+	promotions, err := h.usecase.FetchPromotions(context.Background())
+	if err != nil {
+		log.Printf("Unable to execute PromotionHandlerFetchPromotions: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.ParseFetchPromotionsResponse(promotions))
+}
+
+func (h *promotionHandlerImpl) FindPromotionById(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Printf("Unable to execute PromotionHandlerFindPromotionById: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid promotion ID",
+		})
+	}
+
+	promotion, err := h.usecase.FindPromotionById(context.Background(), id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Promotion not found",
+		})
+	}
+	if err != nil {
+		log.Printf("Unable to execute PromotionHandlerFindPromotionById: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.ParsePromotionResponse(promotion))
+}
+
+func (h *promotionHandlerImpl) DeletePromotion(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Printf("Unable to execute PromotionHandlerDeletePromotion: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid promotion ID",
+		})
+	}
+
+	err = h.usecase.DeletePromotion(context.Background(), id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Promotion not found",
+		})
+	}
+	if err != nil {
+		log.Printf("Unable to execute PromotionHandlerDeletePromotion: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,190 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/guregu/null"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/handler"
+	"github.com/rentaro-m-b/ai-model-exam/handler/request"
+	mock_usecase "github.com/rentaro-m-b/ai-model-exam/usecase/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCreatePromotionContext(param request.CreatePromotionRequest) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	reqBody, _ := json.Marshal(param)
+	req := httptest.NewRequest(http.MethodPost, "/promotions", bytes.NewReader(reqBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	return e.NewContext(req, rec), rec
+}
+
+func TestCreatePromotion(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockPromotionUsecase(ctrl)
+	startsAt := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	paramUc := db.CreatePromotionParams{
+		Name:          "book sale",
+		DiscountType:  "fixed_amount",
+		DiscountValue: 100,
+		Currency:      pgtype.Text{String: "JPY", Valid: true},
+		TargetType:    "book",
+		TargetBookID:  pgtype.Int4{Int32: 1, Valid: true},
+		StartsAt:      pgtype.Timestamptz{Time: startsAt, Valid: true},
+		Priority:      2,
+		Stackable:     true,
+	}
+	mockUc.EXPECT().CreatePromotion(gomock.Any(), &paramUc).Return(&db.Promotion{
+		ID:            1,
+		Name:          paramUc.Name,
+		DiscountType:  paramUc.DiscountType,
+		DiscountValue: paramUc.DiscountValue,
+		Currency:      paramUc.Currency,
+		TargetType:    paramUc.TargetType,
+		TargetBookID:  paramUc.TargetBookID,
+		StartsAt:      paramUc.StartsAt,
+		Priority:      paramUc.Priority,
+		Stackable:     paramUc.Stackable,
+	}, nil)
+
+	// リクエストボディを設定し、Echoのコンテキストを作成
+	c, rec := newCreatePromotionContext(request.CreatePromotionRequest{
+		Name:          null.NewString("book sale", true),
+		DiscountType:  null.NewString("fixed_amount", true),
+		DiscountValue: null.NewInt(100, true),
+		Currency:      null.NewString("JPY", true),
+		TargetType:    null.NewString("book", true),
+		TargetBookID:  null.NewInt(1, true),
+		StartsAt:      null.NewTime(startsAt, true),
+		Priority:      null.NewInt(2, true),
+		Stackable:     null.NewBool(true, true),
+	})
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewPromotionHandler(mockUc)
+	assert.NoError(t, h.CreatePromotion(c))
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "http://example.com/promotions/1", rec.Header().Get("Location"))
+	assert.JSONEq(t, `{
+		"id": 1, "name": "book sale", "discount_type": "fixed_amount", "discount_value": 100, "currency": "JPY",
+		"target_type": "book", "target_book_id": 1, "target_name": null,
+		"starts_at": "2024-03-01T00:00:00Z", "ends_at": null, "priority": 2, "stackable": true
+	}`, rec.Body.String())
+}
+
+func TestCreatePromotionFailureValidation(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成
+	mockUc := mock_usecase.NewMockPromotionUsecase(ctrl)
+
+	// リクエストボディを設定し、Echoのコンテキストを作成
+	c, rec := newCreatePromotionContext(request.CreatePromotionRequest{
+		Name:          null.NewString("spring sale", true),
+		DiscountType:  null.NewString("percentage", true),
+		DiscountValue: null.NewInt(120, true),
+		TargetType:    null.NewString("publisher", true),
+		TargetName:    null.NewString("test publisher 1", true),
+		StartsAt:      null.NewTime(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), true),
+	})
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewPromotionHandler(mockUc)
+	assert.NoError(t, h.CreatePromotion(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.JSONEq(t, `{
+		"type": "about:blank", "title": "request validation error is occurred.",
+		"detail": "discount_value is invalid.", "instance": "/promotions"
+	}`, rec.Body.String())
+}
+
+func TestCreatePromotionFailureBookNotFound(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockPromotionUsecase(ctrl)
+	mockUc.EXPECT().CreatePromotion(gomock.Any(), gomock.Any()).Return(nil, pgx.ErrNoRows)
+
+	// リクエストボディを設定し、Echoのコンテキストを作成
+	c, rec := newCreatePromotionContext(request.CreatePromotionRequest{
+		Name:          null.NewString("book sale", true),
+		DiscountType:  null.NewString("percentage", true),
+		DiscountValue: null.NewInt(10, true),
+		TargetType:    null.NewString("book", true),
+		TargetBookID:  null.NewInt(99, true),
+		StartsAt:      null.NewTime(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), true),
+	})
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewPromotionHandler(mockUc)
+	assert.NoError(t, h.CreatePromotion(c))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.JSONEq(t, `{"message": "Book not found"}`, rec.Body.String())
+}
+
+func TestDeletePromotion(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockPromotionUsecase(ctrl)
+	mockUc.EXPECT().DeletePromotion(gomock.Any(), 1).Return(nil)
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodDelete, "/promotions/1", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(strconv.Itoa(1))
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewPromotionHandler(mockUc)
+	assert.NoError(t, h.DeletePromotion(c))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func TestFindPromotionByIdFailureNotFound(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockPromotionUsecase(ctrl)
+	mockUc.EXPECT().FindPromotionById(gomock.Any(), 99).Return(nil, pgx.ErrNoRows)
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/promotions/99", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(strconv.Itoa(99))
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewPromotionHandler(mockUc)
+	assert.NoError(t, h.FindPromotionById(c))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.JSONEq(t, `{"message": "Promotion not found"}`, rec.Body.String())
+}
@@ -1,14 +1,200 @@
 package request
 
 import (
+	"math"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
 	"github.com/guregu/null"
 )
 
+const (
+	// bookNameMaxLength はtitle・author・publisherの桁数
+	bookNameMaxLength        = 100
+	bookSubtitleMaxLength    = 255
+	bookEditionMaxLength     = 50
+	bookLanguageMaxLength    = 35
+	bookDescriptionMaxLength = 10000
+	bookSeriesMaxLength      = 255
+)
+
+// languageTagPattern はBCP 47（RFC 5646）の言語タグの形式
+// 言語・文字体系・地域・変種・拡張・私用の各サブタグを、大文字と小文字を区別せずに受け付ける
+var languageTagPattern = regexp.MustCompile(`(?i)^([a-z]{2,3}(-[a-z]{3}){0,3}|[a-z]{4,8})(-[a-z]{4})?(-([a-z]{2}|[0-9]{3}))?(-([a-z0-9]{5,8}|[0-9][a-z0-9]{3}))*(-[0-9a-wy-z](-[a-z0-9]{2,8})+)*(-x(-[a-z0-9]{1,8})+)?$`)
+
+// bookFormats は書籍の形態として受け付ける値
+var bookFormats = map[string]bool{
+	"hardcover": true,
+	"paperback": true,
+	"ebook":     true,
+}
+
+// BookMetadataRequest は書籍の登録・更新で共通して指定できる書誌情報
+// いずれの項目も省略でき、publication_dateはYYYY-MM-DD形式で、isbnはISBN-10またはISBN-13（ハイフン区切り可）で指定する
+type BookMetadataRequest struct {
+	ISBN            null.String `json:"isbn"`
+	Subtitle        null.String `json:"subtitle"`
+	Edition         null.String `json:"edition"`
+	PublicationDate null.String `json:"publication_date"`
+	Language        null.String `json:"language"`
+	PageCount       null.Int    `json:"page_count"`
+	Format          null.String `json:"format"`
+	Description     null.String `json:"description"`
+	Series          null.String `json:"series"`
+	SeriesVolume    null.Int    `json:"series_volume"`
+}
+
+// ParsePublicationDate は検証済みの出版日を返す
+func (rec *BookMetadataRequest) ParsePublicationDate() time.Time {
+	date, _ := time.Parse(time.DateOnly, rec.PublicationDate.String)
+	return date
+}
+
+// ParseISBN は検証済みのISBNをハイフンを除いたISBN-13の形式で返す
+func (rec *BookMetadataRequest) ParseISBN() string {
+	isbn, _ := normalizeISBN(rec.ISBN.String)
+	return isbn
+}
+
+func (rec *BookMetadataRequest) validate() (string, ValidationError) {
+	if rec.ISBN.Valid {
+		if rec.ISBN.String == "" {
+			return "isbn", ValidationErrRequestFieldEmpty
+		} else if _, ok := normalizeISBN(rec.ISBN.String); !ok {
+			return "isbn", ValidationErrRequestFieldInvalid
+		}
+	}
+
+	if vs, ve := validateOptionalText("subtitle", rec.Subtitle, bookSubtitleMaxLength); ve != -1 {
+		return vs, ve
+	}
+	if vs, ve := validateOptionalText("edition", rec.Edition, bookEditionMaxLength); ve != -1 {
+		return vs, ve
+	}
+
+	if rec.PublicationDate.Valid {
+		if rec.PublicationDate.String == "" {
+			return "publication_date", ValidationErrRequestFieldEmpty
+		} else if _, err := time.Parse(time.DateOnly, rec.PublicationDate.String); err != nil {
+			return "publication_date", ValidationErrRequestFieldInvalid
+		}
+	}
+
+	if rec.Language.Valid {
+		if rec.Language.String == "" {
+			return "language", ValidationErrRequestFieldEmpty
+		} else if len(rec.Language.String) > bookLanguageMaxLength || !languageTagPattern.MatchString(rec.Language.String) {
+			return "language", ValidationErrRequestFieldInvalid
+		}
+	}
+
+	if rec.PageCount.Valid && rec.PageCount.Int64 <= 0 {
+		return "page_count", ValidationErrRequestFieldInvalid
+	}
+
+	if rec.Format.Valid {
+		if rec.Format.String == "" {
+			return "format", ValidationErrRequestFieldEmpty
+		} else if !bookFormats[rec.Format.String] {
+			return "format", ValidationErrRequestFieldInvalid
+		}
+	}
+
+	if rec.Description.Valid && utf8.RuneCountInString(rec.Description.String) > bookDescriptionMaxLength {
+		return "description", ValidationErrRequestFieldInvalid
+	}
+
+	if vs, ve := validateOptionalText("series", rec.Series, bookSeriesMaxLength); ve != -1 {
+		return vs, ve
+	}
+	// 巻数はシリーズ名と併せて指定する
+	if rec.SeriesVolume.Valid {
+		if rec.SeriesVolume.Int64 <= 0 {
+			return "series_volume", ValidationErrRequestFieldInvalid
+		} else if !rec.Series.Valid {
+			return "series", ValidationErrRequestFieldMissing
+		}
+	}
+
+	return "", -1
+}
+
+// normalizeISBN はハイフンと空白を除いたISBNのチェックディジットを検証し、ISBN-13の形式にして返す
+// ISBN-10は先頭に978を付けてチェックディジットを計算し直す
+func normalizeISBN(s string) (string, bool) {
+	isbn := strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(s))
+	switch len(isbn) {
+	case 10:
+		sum := 0
+		for i, r := range isbn {
+			var d int
+			switch {
+			case r >= '0' && r <= '9':
+				d = int(r - '0')
+			case r == 'X' && i == 9:
+				d = 10
+			default:
+				return "", false
+			}
+			sum += d * (10 - i)
+		}
+		if sum%11 != 0 {
+			return "", false
+		}
+		isbn13 := "978" + isbn[:9]
+		return isbn13 + string(rune('0'+isbn13CheckDigit(isbn13))), true
+	case 13:
+		for _, r := range isbn {
+			if r < '0' || r > '9' {
+				return "", false
+			}
+		}
+		if isbn13CheckDigit(isbn[:12]) != int(isbn[12]-'0') {
+			return "", false
+		}
+		return isbn, true
+	}
+
+	return "", false
+}
+
+// isbn13CheckDigit はISBN-13の先頭12桁からチェックディジットを計算する
+func isbn13CheckDigit(digits string) int {
+	sum := 0
+	for i := 0; i < 12; i++ {
+		d := int(digits[i] - '0')
+		if i%2 == 1 {
+			d *= 3
+		}
+		sum += d
+	}
+
+	return (10 - sum%10) % 10
+}
+
+// validateOptionalText は省略可能な文字列の項目を検証する
+// 指定された場合は空文字列でなく、maxLength文字以下であることを求める
+func validateOptionalText(name string, value null.String, maxLength int) (string, ValidationError) {
+	if !value.Valid {
+		return "", -1
+	}
+	if value.String == "" {
+		return name, ValidationErrRequestFieldEmpty
+	} else if utf8.RuneCountInString(value.String) > maxLength {
+		return name, ValidationErrRequestFieldInvalid
+	}
+
+	return "", -1
+}
+
 type CreateBookRequest struct {
 	Title     null.String `json:"title"`
 	Author    null.String `json:"author"`
 	Publisher null.String `json:"publisher"`
 	Price     null.Int    `json:"price"`
+	BookMetadataRequest
 }
 
 func (rec *CreateBookRequest) Validate() (string, ValidationError) {
@@ -34,5 +220,64 @@ func (rec *CreateBookRequest) Validate() (string, ValidationError) {
 		return "price", ValidationErrRequestFieldMissing
 	}
 
+	return rec.BookMetadataRequest.validate()
+}
+
+// UpdateBookRequest は指定された項目のみを更新する
+// 価格は価格履歴で管理するため、ここでは変更できない
+type UpdateBookRequest struct {
+	Title     null.String `json:"title"`
+	Author    null.String `json:"author"`
+	Publisher null.String `json:"publisher"`
+	BookMetadataRequest
+}
+
+func (rec *UpdateBookRequest) Validate() (string, ValidationError) {
+	if vs, ve := validateOptionalText("title", rec.Title, bookNameMaxLength); ve != -1 {
+		return vs, ve
+	}
+	if vs, ve := validateOptionalText("author", rec.Author, bookNameMaxLength); ve != -1 {
+		return vs, ve
+	}
+	if vs, ve := validateOptionalText("publisher", rec.Publisher, bookNameMaxLength); ve != -1 {
+		return vs, ve
+	}
+
+	return rec.BookMetadataRequest.validate()
+}
+
+// MergeBookRequest の統合先は統合元（パスの書籍）とは別の書籍を指定する
+type MergeBookRequest struct {
+	TargetID null.Int `json:"target_id"`
+}
+
+func (rec *MergeBookRequest) Validate() (string, ValidationError) {
+	if !rec.TargetID.Valid {
+		return "target_id", ValidationErrRequestFieldMissing
+	} else if rec.TargetID.Int64 <= 0 {
+		return "target_id", ValidationErrRequestFieldInvalid
+	}
+
+	return "", -1
+}
+
+// BulkUpdateBooksRequest は一括更新で対象の書籍に設定する値で、publisherとpriceの少なくとも一方を指定する
+// priceは書籍の価格と併せて、既定の通貨の価格履歴に即時に有効な価格として登録する
+type BulkUpdateBooksRequest struct {
+	Publisher null.String `json:"publisher"`
+	Price     null.Int    `json:"price"`
+}
+
+func (rec *BulkUpdateBooksRequest) Validate() (string, ValidationError) {
+	if !rec.Publisher.Valid && !rec.Price.Valid {
+		return "publisher or price", ValidationErrRequestFieldMissing
+	}
+	if vs, ve := validateOptionalText("publisher", rec.Publisher, bookNameMaxLength); ve != -1 {
+		return vs, ve
+	}
+	if rec.Price.Valid && (rec.Price.Int64 < 0 || rec.Price.Int64 > math.MaxInt32) {
+		return "price", ValidationErrRequestFieldInvalid
+	}
+
 	return "", -1
 }
@@ -0,0 +1,77 @@
+package request
+
+import (
+	"unicode/utf8"
+
+	"github.com/guregu/null"
+)
+
+// categoryNameMaxLength はカテゴリ名の最大文字数（categories.nameの桁数）
+const categoryNameMaxLength = 100
+
+type CreateCategoryRequest struct {
+	Name     null.String `json:"name"`
+	ParentID null.Int    `json:"parent_id"`
+}
+
+func (rec *CreateCategoryRequest) Validate() (string, ValidationError) {
+	if vs, ve := validateCategoryName(rec.Name); ve != -1 {
+		return vs, ve
+	}
+
+	if rec.ParentID.Valid && rec.ParentID.Int64 <= 0 {
+		return "parent_id", ValidationErrRequestFieldInvalid
+	}
+
+	return "", -1
+}
+
+type UpdateCategoryRequest struct {
+	Name null.String `json:"name"`
+}
+
+func (rec *UpdateCategoryRequest) Validate() (string, ValidationError) {
+	return validateCategoryName(rec.Name)
+}
+
+// MoveCategoryRequest の親カテゴリIDが省略またはnullの場合はルートへ移動する
+type MoveCategoryRequest struct {
+	ParentID null.Int `json:"parent_id"`
+}
+
+func (rec *MoveCategoryRequest) Validate() (string, ValidationError) {
+	if rec.ParentID.Valid && rec.ParentID.Int64 <= 0 {
+		return "parent_id", ValidationErrRequestFieldInvalid
+	}
+
+	return "", -1
+}
+
+type SetBookCategoriesRequest struct {
+	CategoryIDs []int64 `json:"category_ids"`
+}
+
+func (rec *SetBookCategoriesRequest) Validate() (string, ValidationError) {
+	if rec.CategoryIDs == nil {
+		return "category_ids", ValidationErrRequestFieldMissing
+	}
+	for _, id := range rec.CategoryIDs {
+		if id <= 0 {
+			return "category_ids", ValidationErrRequestFieldInvalid
+		}
+	}
+
+	return "", -1
+}
+
+func validateCategoryName(name null.String) (string, ValidationError) {
+	if !name.Valid {
+		return "name", ValidationErrRequestFieldMissing
+	} else if name.String == "" {
+		return "name", ValidationErrRequestFieldEmpty
+	} else if utf8.RuneCountInString(name.String) > categoryNameMaxLength {
+		return "name", ValidationErrRequestFieldInvalid
+	}
+
+	return "", -1
+}
@@ -5,4 +5,5 @@ type ValidationError int
 const (
 	ValidationErrRequestFieldMissing ValidationError = iota
 	ValidationErrRequestFieldEmpty
+	ValidationErrRequestFieldInvalid
 )
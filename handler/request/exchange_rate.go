@@ -0,0 +1,72 @@
+package request
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/guregu/null"
+)
+
+type ExchangeRateRequest struct {
+	BaseCurrency  null.String `json:"base_currency"`
+	QuoteCurrency null.String `json:"quote_currency"`
+	Rate          null.String `json:"rate"`
+	EffectiveDate null.String `json:"effective_date"`
+}
+
+type ImportExchangeRatesRequest struct {
+	Rates []ExchangeRateRequest `json:"rates"`
+}
+
+func (rec *ImportExchangeRatesRequest) Validate() (string, ValidationError) {
+	if rec.Rates == nil {
+		return "rates", ValidationErrRequestFieldMissing
+	} else if len(rec.Rates) == 0 {
+		return "rates", ValidationErrRequestFieldEmpty
+	}
+
+	for i, rate := range rec.Rates {
+		if vs, ve := rate.validate(); ve != -1 {
+			return fmt.Sprintf("rates[%d].%s", i, vs), ve
+		}
+	}
+
+	return "", -1
+}
+
+func (rec *ExchangeRateRequest) validate() (string, ValidationError) {
+	if !rec.BaseCurrency.Valid {
+		return "base_currency", ValidationErrRequestFieldMissing
+	} else if rec.BaseCurrency.String == "" {
+		return "base_currency", ValidationErrRequestFieldEmpty
+	} else if !currencyCodePattern.MatchString(rec.BaseCurrency.String) {
+		return "base_currency", ValidationErrRequestFieldInvalid
+	}
+
+	if !rec.QuoteCurrency.Valid {
+		return "quote_currency", ValidationErrRequestFieldMissing
+	} else if rec.QuoteCurrency.String == "" {
+		return "quote_currency", ValidationErrRequestFieldEmpty
+	} else if !currencyCodePattern.MatchString(rec.QuoteCurrency.String) {
+		return "quote_currency", ValidationErrRequestFieldInvalid
+	}
+
+	if !rec.Rate.Valid {
+		return "rate", ValidationErrRequestFieldMissing
+	} else if rec.Rate.String == "" {
+		return "rate", ValidationErrRequestFieldEmpty
+	} else if r, ok := new(big.Rat).SetString(rec.Rate.String); !ok || r.Sign() <= 0 {
+		return "rate", ValidationErrRequestFieldInvalid
+	}
+
+	if !rec.EffectiveDate.Valid {
+		return "effective_date", ValidationErrRequestFieldMissing
+	} else if rec.EffectiveDate.String == "" {
+		return "effective_date", ValidationErrRequestFieldEmpty
+	} else if _, err := time.Parse(time.DateOnly, rec.EffectiveDate.String); err != nil {
+		return "effective_date", ValidationErrRequestFieldInvalid
+	}
+
+	return "", -1
+}
@@ -0,0 +1,38 @@
+package request
+
+import (
+	"math"
+
+	"github.com/guregu/null"
+)
+
+type AdjustStockRequest struct {
+	Location null.String `json:"location"`
+	Quantity null.Int    `json:"quantity"`
+	Reason   null.String `json:"reason"`
+}
+
+func (rec *AdjustStockRequest) Validate() (string, ValidationError) {
+	if !rec.Location.Valid {
+		return "location", ValidationErrRequestFieldMissing
+	} else if rec.Location.String == "" {
+		return "location", ValidationErrRequestFieldEmpty
+	}
+
+	if !rec.Quantity.Valid {
+		return "quantity", ValidationErrRequestFieldMissing
+	} else if rec.Quantity.Int64 <= 0 || rec.Quantity.Int64 > math.MaxInt32 {
+		return "quantity", ValidationErrRequestFieldInvalid
+	}
+
+	if !rec.Reason.Valid {
+		return "reason", ValidationErrRequestFieldMissing
+	}
+	switch rec.Reason.String {
+	case "received", "sold", "damaged", "returned":
+	default:
+		return "reason", ValidationErrRequestFieldInvalid
+	}
+
+	return "", -1
+}
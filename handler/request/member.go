@@ -0,0 +1,30 @@
+package request
+
+import (
+	"strings"
+
+	"github.com/guregu/null"
+)
+
+type CreateMemberRequest struct {
+	Name  null.String `json:"name"`
+	Email null.String `json:"email"`
+}
+
+func (rec *CreateMemberRequest) Validate() (string, ValidationError) {
+	if !rec.Name.Valid {
+		return "name", ValidationErrRequestFieldMissing
+	} else if rec.Name.String == "" {
+		return "name", ValidationErrRequestFieldEmpty
+	}
+
+	if !rec.Email.Valid {
+		return "email", ValidationErrRequestFieldMissing
+	} else if rec.Email.String == "" {
+		return "email", ValidationErrRequestFieldEmpty
+	} else if !strings.Contains(rec.Email.String, "@") {
+		return "email", ValidationErrRequestFieldInvalid
+	}
+
+	return "", -1
+}
@@ -0,0 +1,33 @@
+package request
+
+import (
+	"regexp"
+
+	"github.com/guregu/null"
+)
+
+var currencyCodePattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+type SchedulePriceRequest struct {
+	Currency      null.String `json:"currency"`
+	Amount        null.Int    `json:"amount"`
+	EffectiveFrom null.Time   `json:"effective_from"`
+}
+
+func (rec *SchedulePriceRequest) Validate() (string, ValidationError) {
+	if !rec.Currency.Valid {
+		return "currency", ValidationErrRequestFieldMissing
+	} else if rec.Currency.String == "" {
+		return "currency", ValidationErrRequestFieldEmpty
+	} else if !currencyCodePattern.MatchString(rec.Currency.String) {
+		return "currency", ValidationErrRequestFieldInvalid
+	}
+
+	if !rec.Amount.Valid {
+		return "amount", ValidationErrRequestFieldMissing
+	} else if rec.Amount.Int64 < 0 {
+		return "amount", ValidationErrRequestFieldInvalid
+	}
+
+	return "", -1
+}
@@ -0,0 +1,95 @@
+package request
+
+import (
+	"github.com/guregu/null"
+)
+
+type CreatePromotionRequest struct {
+	Name          null.String `json:"name"`
+	DiscountType  null.String `json:"discount_type"`
+	DiscountValue null.Int    `json:"discount_value"`
+	Currency      null.String `json:"currency"`
+	TargetType    null.String `json:"target_type"`
+	TargetBookID  null.Int    `json:"target_book_id"`
+	TargetName    null.String `json:"target_name"`
+	StartsAt      null.Time   `json:"starts_at"`
+	EndsAt        null.Time   `json:"ends_at"`
+	Priority      null.Int    `json:"priority"`
+	Stackable     null.Bool   `json:"stackable"`
+}
+
+func (rec *CreatePromotionRequest) Validate() (string, ValidationError) {
+	if !rec.Name.Valid {
+		return "name", ValidationErrRequestFieldMissing
+	} else if rec.Name.String == "" {
+		return "name", ValidationErrRequestFieldEmpty
+	}
+
+	if !rec.DiscountType.Valid {
+		return "discount_type", ValidationErrRequestFieldMissing
+	} else if rec.DiscountType.String == "" {
+		return "discount_type", ValidationErrRequestFieldEmpty
+	}
+
+	if !rec.DiscountValue.Valid {
+		return "discount_value", ValidationErrRequestFieldMissing
+	} else if rec.DiscountValue.Int64 <= 0 {
+		return "discount_value", ValidationErrRequestFieldInvalid
+	}
+
+	switch rec.DiscountType.String {
+	case "percentage":
+		if rec.DiscountValue.Int64 > 100 {
+			return "discount_value", ValidationErrRequestFieldInvalid
+		}
+		if rec.Currency.Valid {
+			return "currency", ValidationErrRequestFieldInvalid
+		}
+	case "fixed_amount":
+		if !rec.Currency.Valid {
+			return "currency", ValidationErrRequestFieldMissing
+		} else if rec.Currency.String == "" {
+			return "currency", ValidationErrRequestFieldEmpty
+		} else if !currencyCodePattern.MatchString(rec.Currency.String) {
+			return "currency", ValidationErrRequestFieldInvalid
+		}
+	default:
+		return "discount_type", ValidationErrRequestFieldInvalid
+	}
+
+	if !rec.TargetType.Valid {
+		return "target_type", ValidationErrRequestFieldMissing
+	} else if rec.TargetType.String == "" {
+		return "target_type", ValidationErrRequestFieldEmpty
+	}
+
+	switch rec.TargetType.String {
+	case "book":
+		if !rec.TargetBookID.Valid {
+			return "target_book_id", ValidationErrRequestFieldMissing
+		}
+		if rec.TargetName.Valid {
+			return "target_name", ValidationErrRequestFieldInvalid
+		}
+	case "author", "publisher":
+		if !rec.TargetName.Valid {
+			return "target_name", ValidationErrRequestFieldMissing
+		} else if rec.TargetName.String == "" {
+			return "target_name", ValidationErrRequestFieldEmpty
+		}
+		if rec.TargetBookID.Valid {
+			return "target_book_id", ValidationErrRequestFieldInvalid
+		}
+	default:
+		return "target_type", ValidationErrRequestFieldInvalid
+	}
+
+	if !rec.StartsAt.Valid {
+		return "starts_at", ValidationErrRequestFieldMissing
+	}
+	if rec.EndsAt.Valid && !rec.EndsAt.Time.After(rec.StartsAt.Time) {
+		return "ends_at", ValidationErrRequestFieldInvalid
+	}
+
+	return "", -1
+}
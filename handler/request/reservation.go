@@ -0,0 +1,17 @@
+package request
+
+import (
+	"github.com/guregu/null"
+)
+
+type ReserveRequest struct {
+	MemberID null.Int `json:"member_id"`
+}
+
+func (rec *ReserveRequest) Validate() (string, ValidationError) {
+	if !rec.MemberID.Valid {
+		return "member_id", ValidationErrRequestFieldMissing
+	}
+
+	return "", -1
+}
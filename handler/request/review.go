@@ -0,0 +1,63 @@
+package request
+
+import (
+	"unicode/utf8"
+
+	"github.com/guregu/null"
+)
+
+const (
+	reviewRatingMin     = 1
+	reviewRatingMax     = 5
+	reviewBodyMaxLength = 2000
+)
+
+type CreateReviewRequest struct {
+	MemberID null.Int    `json:"member_id"`
+	Rating   null.Int    `json:"rating"`
+	Body     null.String `json:"body"`
+}
+
+func (rec *CreateReviewRequest) Validate() (string, ValidationError) {
+	if !rec.MemberID.Valid {
+		return "member_id", ValidationErrRequestFieldMissing
+	}
+
+	if !rec.Rating.Valid {
+		return "rating", ValidationErrRequestFieldMissing
+	} else if !validReviewRating(rec.Rating.Int64) {
+		return "rating", ValidationErrRequestFieldInvalid
+	}
+
+	if rec.Body.Valid && utf8.RuneCountInString(rec.Body.String) > reviewBodyMaxLength {
+		return "body", ValidationErrRequestFieldInvalid
+	}
+
+	return "", -1
+}
+
+// UpdateReviewRequest は指定された項目のみを更新する
+type UpdateReviewRequest struct {
+	Rating null.Int    `json:"rating"`
+	Body   null.String `json:"body"`
+}
+
+func (rec *UpdateReviewRequest) Validate() (string, ValidationError) {
+	if !rec.Rating.Valid && !rec.Body.Valid {
+		return "rating", ValidationErrRequestFieldMissing
+	}
+
+	if rec.Rating.Valid && !validReviewRating(rec.Rating.Int64) {
+		return "rating", ValidationErrRequestFieldInvalid
+	}
+
+	if rec.Body.Valid && utf8.RuneCountInString(rec.Body.String) > reviewBodyMaxLength {
+		return "body", ValidationErrRequestFieldInvalid
+	}
+
+	return "", -1
+}
+
+func validReviewRating(rating int64) bool {
+	return rating >= reviewRatingMin && rating <= reviewRatingMax
+}
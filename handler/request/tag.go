@@ -0,0 +1,30 @@
+package request
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/guregu/null"
+)
+
+// tagNameMaxLength はタグ名の最大文字数（tags.nameの桁数）
+const tagNameMaxLength = 50
+
+type AddBookTagRequest struct {
+	Name null.String `json:"name"`
+}
+
+// Validate はタグ名を検証する
+// 書籍の絞り込みではタグ名をカンマ区切りで指定するため、カンマを含む名前は受け付けない
+func (rec *AddBookTagRequest) Validate() (string, ValidationError) {
+	name := strings.TrimSpace(rec.Name.String)
+	if !rec.Name.Valid {
+		return "name", ValidationErrRequestFieldMissing
+	} else if name == "" {
+		return "name", ValidationErrRequestFieldEmpty
+	} else if utf8.RuneCountInString(name) > tagNameMaxLength || strings.Contains(name, ",") {
+		return "name", ValidationErrRequestFieldInvalid
+	}
+
+	return "", -1
+}
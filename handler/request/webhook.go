@@ -0,0 +1,88 @@
+package request
+
+import (
+	"net/url"
+	"unicode/utf8"
+
+	"github.com/guregu/null"
+	"github.com/rentaro-m-b/ai-model-exam/event"
+)
+
+const (
+	// webhookURLMaxLength はURLの最大文字数（webhook_subscriptions.urlの桁数）
+	webhookURLMaxLength = 2048
+	// webhookSecretMinLength は推測されにくい署名の鍵とするための最小文字数
+	webhookSecretMinLength = 16
+	// webhookSecretMaxLength は署名の鍵の最大文字数（webhook_subscriptions.secretの桁数）
+	webhookSecretMaxLength = 255
+)
+
+type CreateWebhookRequest struct {
+	URL        null.String `json:"url"`
+	EventTypes []string    `json:"event_types"`
+	Secret     null.String `json:"secret"`
+}
+
+func (rec *CreateWebhookRequest) Validate() (string, ValidationError) {
+	if !rec.URL.Valid {
+		return "url", ValidationErrRequestFieldMissing
+	}
+	if rec.EventTypes == nil {
+		return "event_types", ValidationErrRequestFieldMissing
+	}
+	if !rec.Secret.Valid {
+		return "secret", ValidationErrRequestFieldMissing
+	}
+
+	return validateWebhook(rec.URL, rec.EventTypes, rec.Secret)
+}
+
+// UpdateWebhookRequest は指定された項目のみを更新する
+type UpdateWebhookRequest struct {
+	URL        null.String `json:"url"`
+	EventTypes []string    `json:"event_types"`
+	Secret     null.String `json:"secret"`
+	Active     null.Bool   `json:"active"`
+}
+
+func (rec *UpdateWebhookRequest) Validate() (string, ValidationError) {
+	return validateWebhook(rec.URL, rec.EventTypes, rec.Secret)
+}
+
+// validateWebhook は指定された項目を検証する
+// URLはhttpかhttpsの絶対URL、イベントの種類は1件以上の既知の種類に限る
+func validateWebhook(rawURL null.String, eventTypes []string, secret null.String) (string, ValidationError) {
+	if rawURL.Valid {
+		if rawURL.String == "" {
+			return "url", ValidationErrRequestFieldEmpty
+		}
+		u, err := url.Parse(rawURL.String)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" ||
+			utf8.RuneCountInString(rawURL.String) > webhookURLMaxLength {
+			return "url", ValidationErrRequestFieldInvalid
+		}
+	}
+
+	if eventTypes != nil {
+		if len(eventTypes) == 0 {
+			return "event_types", ValidationErrRequestFieldEmpty
+		}
+		for _, t := range eventTypes {
+			if !event.IsKnownType(t) {
+				return "event_types", ValidationErrRequestFieldInvalid
+			}
+		}
+	}
+
+	if secret.Valid {
+		if secret.String == "" {
+			return "secret", ValidationErrRequestFieldEmpty
+		}
+		n := utf8.RuneCountInString(secret.String)
+		if n < webhookSecretMinLength || n > webhookSecretMaxLength {
+			return "secret", ValidationErrRequestFieldInvalid
+		}
+	}
+
+	return "", -1
+}
@@ -0,0 +1,28 @@
+package request
+
+import (
+	"unicode/utf8"
+
+	"github.com/guregu/null"
+)
+
+// workTitleMaxLength は作品名の最大文字数（works.titleの桁数）
+const workTitleMaxLength = 255
+
+// CreateWorkRequest の著者は省略できる
+type CreateWorkRequest struct {
+	Title  null.String `json:"title"`
+	Author null.String `json:"author"`
+}
+
+func (rec *CreateWorkRequest) Validate() (string, ValidationError) {
+	if !rec.Title.Valid {
+		return "title", ValidationErrRequestFieldMissing
+	} else if rec.Title.String == "" {
+		return "title", ValidationErrRequestFieldEmpty
+	} else if utf8.RuneCountInString(rec.Title.String) > workTitleMaxLength {
+		return "title", ValidationErrRequestFieldInvalid
+	}
+
+	return validateOptionalText("author", rec.Author, bookNameMaxLength)
+}
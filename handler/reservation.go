@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/rentaro-m-b/ai-model-exam/handler/request"
+	"github.com/rentaro-m-b/ai-model-exam/handler/response"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	"github.com/rentaro-m-b/ai-model-exam/usecase"
+)
+
+type ReservationHandler interface {
+	Reserve(c echo.Context) error
+	FetchReservations(c echo.Context) error
+}
+
+type reservationHandlerImpl struct {
+	usecase usecase.ReservationUsecase
+}
+
+func NewReservationHandler(usecase usecase.ReservationUsecase) ReservationHandler {
+	return &reservationHandlerImpl{
+		usecase: usecase,
+	}
+}
+
+func (h *reservationHandlerImpl) Reserve(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Printf("Unable to execute ReservationHandlerReserve: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid book ID",
+		})
+	}
+
+	body := new(request.ReserveRequest)
+	if err := c.Bind(body); err != nil {
+		log.Printf("Unable to execute ReservationHandlerReserve: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid request body",
+		})
+	}
+	if vs, ve := body.Validate(); ve != -1 {
+		return validationError(c, vs, ve)
+	}
+
+	reservation, position, err := h.usecase.Reserve(context.Background(), id, int(body.MemberID.Int64))
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Book or member not found",
+		})
+	case errors.Is(err, repository.ErrCopyAvailable):
+		return c.JSON(http.StatusConflict, map[string]string{
+			"message": "Copy is available for loan",
+		})
+	case isUniqueViolation(err):
+		return c.JSON(http.StatusConflict, map[string]string{
+			"message": "Member has already reserved this book",
+		})
+	case err != nil:
+		log.Printf("Unable to execute ReservationHandlerReserve: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.JSON(http.StatusCreated, response.ParseReservationResponse(reservation, position))
+}
+
+func (h *reservationHandlerImpl) FetchReservations(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Printf("Unable to execute ReservationHandlerFetchReservations: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid book ID",
+		})
+	}
+
+	reservations, err := h.usecase.FetchReservations(context.Background(), id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Book not found",
+		})
+	}
+	if err != nil {
+		log.Printf("Unable to execute ReservationHandlerFetchReservations: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.ParseFetchReservationsResponse(reservations))
+}
@@ -0,0 +1,140 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/guregu/null"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/handler"
+	"github.com/rentaro-m-b/ai-model-exam/handler/request"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	mock_usecase "github.com/rentaro-m-b/ai-model-exam/usecase/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newReserveContext(param request.ReserveRequest) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	reqBody, _ := json.Marshal(param)
+	req := httptest.NewRequest(http.MethodPost, "/books/1/reservations", bytes.NewReader(reqBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(strconv.Itoa(1))
+
+	return c, rec
+}
+
+func TestReserve(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockReservationUsecase(ctrl)
+	expectUc := db.Reservation{
+		ID:        1,
+		BookID:    1,
+		MemberID:  2,
+		Status:    "waiting",
+		CreatedAt: pgtype.Timestamptz{Time: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC), Valid: true},
+	}
+	mockUc.EXPECT().Reserve(gomock.Any(), 1, 2).Return(&expectUc, 2, nil)
+
+	// リクエストボディを設定し、Echoのコンテキストを作成
+	c, rec := newReserveContext(request.ReserveRequest{
+		MemberID: null.NewInt(2, true),
+	})
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewReservationHandler(mockUc)
+	assert.NoError(t, h.Reserve(c))
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.JSONEq(t, `{"id": 1, "book_id": 1, "member_id": 2, "status": "waiting", "position": 2, "created_at": "2024-01-01T10:00:00Z", "hold_expires_at": null}`, rec.Body.String())
+}
+
+func TestReserveFailureCopyAvailable(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockReservationUsecase(ctrl)
+	mockUc.EXPECT().Reserve(gomock.Any(), 1, 2).Return(nil, 0, repository.ErrCopyAvailable)
+
+	// リクエストボディを設定し、Echoのコンテキストを作成
+	c, rec := newReserveContext(request.ReserveRequest{
+		MemberID: null.NewInt(2, true),
+	})
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewReservationHandler(mockUc)
+	assert.NoError(t, h.Reserve(c))
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.JSONEq(t, `{"message": "Copy is available for loan"}`, rec.Body.String())
+}
+
+func TestReserveFailureNotFound(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockReservationUsecase(ctrl)
+	mockUc.EXPECT().Reserve(gomock.Any(), 1, 2).Return(nil, 0, pgx.ErrNoRows)
+
+	// リクエストボディを設定し、Echoのコンテキストを作成
+	c, rec := newReserveContext(request.ReserveRequest{
+		MemberID: null.NewInt(2, true),
+	})
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewReservationHandler(mockUc)
+	assert.NoError(t, h.Reserve(c))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.JSONEq(t, `{"message": "Book or member not found"}`, rec.Body.String())
+}
+
+func TestFetchReservations(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockReservationUsecase(ctrl)
+	createdAt := pgtype.Timestamptz{Time: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC), Valid: true}
+	holdExpiresAt := pgtype.Timestamptz{Time: time.Date(2024, 1, 4, 10, 0, 0, 0, time.UTC), Valid: true}
+	mockUc.EXPECT().FetchReservations(gomock.Any(), 1).Return([]db.Reservation{
+		{ID: 1, BookID: 1, MemberID: 2, Status: "held", CreatedAt: createdAt, HeldAt: createdAt, HoldExpiresAt: holdExpiresAt},
+		{ID: 2, BookID: 1, MemberID: 3, Status: "waiting", CreatedAt: createdAt},
+		{ID: 3, BookID: 1, MemberID: 4, Status: "waiting", CreatedAt: createdAt},
+	}, nil)
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/books/1/reservations", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(strconv.Itoa(1))
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewReservationHandler(mockUc)
+	assert.NoError(t, h.FetchReservations(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"reservations": [
+		{"id": 1, "book_id": 1, "member_id": 2, "status": "held", "position": null, "created_at": "2024-01-01T10:00:00Z", "hold_expires_at": "2024-01-04T10:00:00Z"},
+		{"id": 2, "book_id": 1, "member_id": 3, "status": "waiting", "position": 1, "created_at": "2024-01-01T10:00:00Z", "hold_expires_at": null},
+		{"id": 3, "book_id": 1, "member_id": 4, "status": "waiting", "position": 2, "created_at": "2024-01-01T10:00:00Z", "hold_expires_at": null}
+	]}`, rec.Body.String())
+}
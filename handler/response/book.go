@@ -1,7 +1,11 @@
 package response
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/usecase"
 )
 
 type FetchBooksResponses struct {
@@ -9,28 +13,63 @@ type FetchBooksResponses struct {
 }
 
 type FetchBooksResponse struct {
-	ID        int    `json:"id"`
-	Title     string `json:"title"`
-	Author    string `json:"author"`
-	Publisher string `json:"publisher"`
-	Price     int    `json:"price"`
+	ID              int                      `json:"id"`
+	Title           string                   `json:"title"`
+	Author          string                   `json:"author"`
+	Publisher       string                   `json:"publisher"`
+	Price           int                      `json:"price"`
+	CurrentPrice    *PriceResponse           `json:"current_price"`
+	ConvertedPrice  *ConvertedPriceResponse  `json:"converted_price"`
+	DiscountedPrice *DiscountedPriceResponse `json:"discounted_price"`
+	AverageRating   *float64                 `json:"average_rating"`
+	ReviewCount     int                      `json:"review_count"`
+	WorkID          *int                     `json:"work_id"`
+	BookMetadataResponse
 }
 
-func ParseFetchBooksResponse(books []db.Book) *FetchBooksResponses {
+func ParseFetchBooksResponse(books []db.Book, pricing *BookPricing, ratings map[int32]db.BookRating) *FetchBooksResponses {
 	var res FetchBooksResponses
 	for _, book := range books {
 		res.Books = append(res.Books, FetchBooksResponse{
-			ID:        int(book.ID),
-			Title:     book.Title.String,
-			Author:    book.Author.String,
-			Publisher: book.Publisher.String,
-			Price:     int(book.Price.Int32),
+			ID:                   int(book.ID),
+			Title:                book.Title.String,
+			Author:               book.Author.String,
+			Publisher:            book.Publisher.String,
+			Price:                pricing.legacyPrice(&book),
+			CurrentPrice:         pricing.currentPrice(book.ID),
+			ConvertedPrice:       pricing.convertedPrice(book.ID),
+			DiscountedPrice:      pricing.discountedPrice(book.ID),
+			AverageRating:        averageRating(ratings, book.ID),
+			ReviewCount:          reviewCount(ratings, book.ID),
+			WorkID:               workID(&book),
+			BookMetadataResponse: ParseBookMetadataResponse(&book),
 		})
 	}
 
 	return &res
 }
 
+// FetchBooksByIdsResponse はIDを指定して取得した書籍と、見つからなかったIDを表す
+type FetchBooksByIdsResponse struct {
+	Books      []FetchBooksResponse `json:"books"`
+	MissingIDs []int                `json:"missing_ids"`
+}
+
+func ParseFetchBooksByIdsResponse(batch *usecase.BookBatch, pricing *BookPricing, ratings map[int32]db.BookRating) *FetchBooksByIdsResponse {
+	res := FetchBooksByIdsResponse{
+		Books:      ParseFetchBooksResponse(batch.Books, pricing, ratings).Books,
+		MissingIDs: make([]int, 0, len(batch.MissingIDs)),
+	}
+	if res.Books == nil {
+		res.Books = []FetchBooksResponse{}
+	}
+	for _, id := range batch.MissingIDs {
+		res.MissingIDs = append(res.MissingIDs, int(id))
+	}
+
+	return &res
+}
+
 type CreateBookErrorResponse struct {
 	Type     string `json:"type"`
 	Title    string `json:"title"`
@@ -38,20 +77,153 @@ type CreateBookErrorResponse struct {
 	Instance string `json:"instance"`
 }
 
+// DuplicateBookResponse は重複の疑われる既存の書籍と、その書籍へのリンクを表す
+type DuplicateBookResponse struct {
+	ID        int     `json:"id"`
+	Title     string  `json:"title"`
+	Author    string  `json:"author"`
+	Publisher string  `json:"publisher"`
+	ISBN      *string `json:"isbn"`
+	Href      string  `json:"href"`
+}
+
+type DuplicateBooksResponse struct {
+	Message    string                  `json:"message"`
+	Duplicates []DuplicateBookResponse `json:"duplicates"`
+}
+
+func ParseDuplicateBooksResponse(baseURL string, books []db.Book) *DuplicateBooksResponse {
+	res := &DuplicateBooksResponse{
+		Message:    "Possible duplicate books found",
+		Duplicates: []DuplicateBookResponse{},
+	}
+	for _, book := range books {
+		duplicate := DuplicateBookResponse{
+			ID:        int(book.ID),
+			Title:     book.Title.String,
+			Author:    book.Author.String,
+			Publisher: book.Publisher.String,
+			Href:      fmt.Sprintf("%s/books/%d", baseURL, book.ID),
+		}
+		if book.Isbn.Valid {
+			duplicate.ISBN = &book.Isbn.String
+		}
+		res.Duplicates = append(res.Duplicates, duplicate)
+	}
+
+	return res
+}
+
 type FindBookByIdResponse struct {
-	ID        int    `json:"id"`
-	Title     string `json:"title"`
-	Author    string `json:"author"`
-	Publisher string `json:"publisher"`
-	Price     int    `json:"price"`
+	ID              int                      `json:"id"`
+	Title           string                   `json:"title"`
+	Author          string                   `json:"author"`
+	Publisher       string                   `json:"publisher"`
+	Price           int                      `json:"price"`
+	CurrentPrice    *PriceResponse           `json:"current_price"`
+	ConvertedPrice  *ConvertedPriceResponse  `json:"converted_price"`
+	DiscountedPrice *DiscountedPriceResponse `json:"discounted_price"`
+	AverageRating   *float64                 `json:"average_rating"`
+	ReviewCount     int                      `json:"review_count"`
+	WorkID          *int                     `json:"work_id"`
+	BookMetadataResponse
 }
 
-func ParseFindBookByIdResponse(book *db.Book) *FindBookByIdResponse {
+func ParseFindBookByIdResponse(book *db.Book, pricing *BookPricing, ratings map[int32]db.BookRating) *FindBookByIdResponse {
 	return &FindBookByIdResponse{
-		ID:        int(book.ID),
-		Title:     book.Title.String,
-		Author:    book.Author.String,
-		Publisher: book.Publisher.String,
-		Price:     int(book.Price.Int32),
+		ID:                   int(book.ID),
+		Title:                book.Title.String,
+		Author:               book.Author.String,
+		Publisher:            book.Publisher.String,
+		Price:                pricing.legacyPrice(book),
+		CurrentPrice:         pricing.currentPrice(book.ID),
+		ConvertedPrice:       pricing.convertedPrice(book.ID),
+		DiscountedPrice:      pricing.discountedPrice(book.ID),
+		AverageRating:        averageRating(ratings, book.ID),
+		ReviewCount:          reviewCount(ratings, book.ID),
+		WorkID:               workID(book),
+		BookMetadataResponse: ParseBookMetadataResponse(book),
+	}
+}
+
+// BookMetadataResponse は書籍の書誌情報を表し、未登録の項目はnullとなる
+type BookMetadataResponse struct {
+	Subtitle        *string `json:"subtitle"`
+	Edition         *string `json:"edition"`
+	PublicationDate *string `json:"publication_date"`
+	Language        *string `json:"language"`
+	PageCount       *int    `json:"page_count"`
+	Format          *string `json:"format"`
+	Description     *string `json:"description"`
+	Series          *string `json:"series"`
+	SeriesVolume    *int    `json:"series_volume"`
+	ISBN            *string `json:"isbn"`
+}
+
+func ParseBookMetadataResponse(book *db.Book) BookMetadataResponse {
+	var res BookMetadataResponse
+	if book.Subtitle.Valid {
+		res.Subtitle = &book.Subtitle.String
+	}
+	if book.Edition.Valid {
+		res.Edition = &book.Edition.String
+	}
+	if book.PublicationDate.Valid {
+		publicationDate := book.PublicationDate.Time.Format(time.DateOnly)
+		res.PublicationDate = &publicationDate
+	}
+	if book.Language.Valid {
+		res.Language = &book.Language.String
+	}
+	if book.PageCount.Valid {
+		pageCount := int(book.PageCount.Int32)
+		res.PageCount = &pageCount
+	}
+	if book.Format.Valid {
+		res.Format = &book.Format.String
+	}
+	if book.Description.Valid {
+		res.Description = &book.Description.String
+	}
+	if book.Series.Valid {
+		res.Series = &book.Series.String
+	}
+	if book.SeriesVolume.Valid {
+		seriesVolume := int(book.SeriesVolume.Int32)
+		res.SeriesVolume = &seriesVolume
+	}
+	if book.Isbn.Valid {
+		res.ISBN = &book.Isbn.String
+	}
+
+	return res
+}
+
+// workID は書籍が属する作品のIDを返し、作品に属さない場合はnilを返す
+func workID(book *db.Book) *int {
+	if !book.WorkID.Valid {
+		return nil
+	}
+	id := int(book.WorkID.Int32)
+	return &id
+}
+
+// BulkResultResponse は一括更新・一括削除の対象になった書籍の件数とID
+type BulkResultResponse struct {
+	Affected int   `json:"affected"`
+	BookIDs  []int `json:"book_ids"`
+	DryRun   bool  `json:"dry_run"`
+}
+
+func ParseBulkResultResponse(res *usecase.BulkResult) *BulkResultResponse {
+	bookIds := make([]int, 0, len(res.BookIDs))
+	for _, id := range res.BookIDs {
+		bookIds = append(bookIds, int(id))
+	}
+
+	return &BulkResultResponse{
+		Affected: res.Affected,
+		BookIDs:  bookIds,
+		DryRun:   res.DryRun,
 	}
 }
@@ -0,0 +1,39 @@
+package response
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/rentaro-m-b/ai-model-exam/db"
+)
+
+type BookAuditLogResponse struct {
+	ID        int             `json:"id"`
+	Action    string          `json:"action"`
+	Detail    json.RawMessage `json:"detail"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+func ParseBookAuditLogResponse(log *db.BookAuditLog) *BookAuditLogResponse {
+	return &BookAuditLogResponse{
+		ID:        int(log.ID),
+		Action:    log.Action,
+		Detail:    json.RawMessage(log.Detail),
+		CreatedAt: log.CreatedAt.Time,
+	}
+}
+
+type FetchBookAuditLogsResponses struct {
+	AuditLogs []BookAuditLogResponse `json:"audit_logs"`
+}
+
+func ParseFetchBookAuditLogsResponse(logs []db.BookAuditLog) *FetchBookAuditLogsResponses {
+	res := FetchBookAuditLogsResponses{
+		AuditLogs: []BookAuditLogResponse{},
+	}
+	for _, log := range logs {
+		res.AuditLogs = append(res.AuditLogs, *ParseBookAuditLogResponse(&log))
+	}
+
+	return &res
+}
@@ -0,0 +1,27 @@
+package response
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/rentaro-m-b/ai-model-exam/event"
+)
+
+// BookEventResponse はServer-Sent Eventsで送る書籍の変更イベントのdata
+type BookEventResponse struct {
+	ID         int64           `json:"id"`
+	Type       string          `json:"type"`
+	BookID     int             `json:"book_id"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+func ParseBookEventResponse(ev *event.Event) *BookEventResponse {
+	return &BookEventResponse{
+		ID:         ev.ID,
+		Type:       string(ev.Type),
+		BookID:     int(ev.BookID),
+		OccurredAt: ev.OccurredAt,
+		Data:       ev.Payload,
+	}
+}
@@ -0,0 +1,93 @@
+package response
+
+import (
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/usecase"
+)
+
+type CategoryResponse struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	ParentID *int   `json:"parent_id"`
+}
+
+func ParseCategoryResponse(category *db.Category) *CategoryResponse {
+	res := &CategoryResponse{
+		ID:   int(category.ID),
+		Name: category.Name,
+	}
+	if category.ParentID.Valid {
+		parentID := int(category.ParentID.Int32)
+		res.ParentID = &parentID
+	}
+
+	return res
+}
+
+func parseCategoryResponses(categories []db.Category) []CategoryResponse {
+	res := []CategoryResponse{}
+	for _, category := range categories {
+		res = append(res, *ParseCategoryResponse(&category))
+	}
+
+	return res
+}
+
+type CategoryDetailResponse struct {
+	CategoryResponse
+	Path     []CategoryResponse `json:"path"`
+	Children []CategoryResponse `json:"children"`
+}
+
+func ParseCategoryDetailResponse(detail *usecase.CategoryDetail) *CategoryDetailResponse {
+	return &CategoryDetailResponse{
+		CategoryResponse: *ParseCategoryResponse(&detail.Category),
+		Path:             parseCategoryResponses(detail.Ancestors),
+		Children:         parseCategoryResponses(detail.Children),
+	}
+}
+
+type CategoryTreeResponse struct {
+	ID       int                     `json:"id"`
+	Name     string                  `json:"name"`
+	Children []*CategoryTreeResponse `json:"children"`
+}
+
+type FetchCategoriesResponses struct {
+	Categories []*CategoryTreeResponse `json:"categories"`
+}
+
+// ParseFetchCategoriesResponse は深さ優先の順に並んだカテゴリを入れ子のツリーに組み立てる
+// 親は必ず子より先に現れるため、一度の走査で組み立てられる
+func ParseFetchCategoriesResponse(categories []db.ListCategoryTreeRow) *FetchCategoriesResponses {
+	res := FetchCategoriesResponses{
+		Categories: []*CategoryTreeResponse{},
+	}
+	nodes := make(map[int32]*CategoryTreeResponse, len(categories))
+	for _, category := range categories {
+		node := &CategoryTreeResponse{
+			ID:       int(category.ID),
+			Name:     category.Name,
+			Children: []*CategoryTreeResponse{},
+		}
+		nodes[category.ID] = node
+
+		if parent, ok := nodes[category.ParentID.Int32]; ok && category.ParentID.Valid {
+			parent.Children = append(parent.Children, node)
+		} else {
+			res.Categories = append(res.Categories, node)
+		}
+	}
+
+	return &res
+}
+
+type FetchBookCategoriesResponses struct {
+	Categories []CategoryResponse `json:"categories"`
+}
+
+func ParseFetchBookCategoriesResponse(categories []db.Category) *FetchBookCategoriesResponses {
+	return &FetchBookCategoriesResponses{
+		Categories: parseCategoryResponses(categories),
+	}
+}
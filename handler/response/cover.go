@@ -0,0 +1,40 @@
+package response
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/thumbnail"
+)
+
+type CoverResponse struct {
+	BookID      int               `json:"book_id"`
+	ContentType string            `json:"content_type"`
+	Width       int               `json:"width"`
+	Height      int               `json:"height"`
+	ByteSize    int               `json:"byte_size"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+	URLs        map[string]string `json:"urls"`
+}
+
+// ParseCoverResponse は表紙画像の情報と、元の画像および各大きさのサムネイルの取得先を返す
+func ParseCoverResponse(cover *db.BookCover) *CoverResponse {
+	path := fmt.Sprintf("/books/%d/cover", cover.BookID)
+	urls := map[string]string{
+		"original": path,
+	}
+	for _, size := range thumbnail.Sizes {
+		urls[size.Name] = fmt.Sprintf("%s?size=%s", path, size.Name)
+	}
+
+	return &CoverResponse{
+		BookID:      int(cover.BookID),
+		ContentType: cover.ContentType,
+		Width:       int(cover.Width),
+		Height:      int(cover.Height),
+		ByteSize:    int(cover.ByteSize),
+		UpdatedAt:   cover.UpdatedAt.Time,
+		URLs:        urls,
+	}
+}
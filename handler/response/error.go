@@ -0,0 +1,8 @@
+package response
+
+type ValidationErrorResponse struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Detail   string `json:"detail"`
+	Instance string `json:"instance"`
+}
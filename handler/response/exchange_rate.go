@@ -0,0 +1,5 @@
+package response
+
+type ImportExchangeRatesResponse struct {
+	Imported int `json:"imported"`
+}
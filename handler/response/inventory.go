@@ -0,0 +1,67 @@
+package response
+
+import (
+	"time"
+
+	"github.com/rentaro-m-b/ai-model-exam/db"
+)
+
+type FetchInventoriesResponses struct {
+	Inventories []FetchInventoriesResponse `json:"inventories"`
+}
+
+type FetchInventoriesResponse struct {
+	Location string `json:"location"`
+	Quantity int    `json:"quantity"`
+}
+
+func ParseFetchInventoriesResponse(inventories []db.Inventory) *FetchInventoriesResponses {
+	res := FetchInventoriesResponses{
+		Inventories: []FetchInventoriesResponse{},
+	}
+	for _, inventory := range inventories {
+		res.Inventories = append(res.Inventories, FetchInventoriesResponse{
+			Location: inventory.Location,
+			Quantity: int(inventory.Quantity),
+		})
+	}
+
+	return &res
+}
+
+type StockMovementResponse struct {
+	ID             int       `json:"id"`
+	BookID         int       `json:"book_id"`
+	Location       string    `json:"location"`
+	QuantityChange int       `json:"quantity_change"`
+	QuantityAfter  int       `json:"quantity_after"`
+	Reason         string    `json:"reason"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+func ParseStockMovementResponse(movement *db.StockMovement) *StockMovementResponse {
+	return &StockMovementResponse{
+		ID:             int(movement.ID),
+		BookID:         int(movement.BookID),
+		Location:       movement.Location,
+		QuantityChange: int(movement.QuantityChange),
+		QuantityAfter:  int(movement.QuantityAfter),
+		Reason:         movement.Reason,
+		CreatedAt:      movement.CreatedAt.Time,
+	}
+}
+
+type FetchStockMovementsResponses struct {
+	Movements []StockMovementResponse `json:"movements"`
+}
+
+func ParseFetchStockMovementsResponse(movements []db.StockMovement) *FetchStockMovementsResponses {
+	res := FetchStockMovementsResponses{
+		Movements: []StockMovementResponse{},
+	}
+	for _, movement := range movements {
+		res.Movements = append(res.Movements, *ParseStockMovementResponse(&movement))
+	}
+
+	return &res
+}
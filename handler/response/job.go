@@ -0,0 +1,63 @@
+package response
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/rentaro-m-b/ai-model-exam/db"
+)
+
+// JobProgressResponse はジョブの処理済みの件数と全体の件数で、全体の件数は判明するまでnull
+type JobProgressResponse struct {
+	Done  int  `json:"done"`
+	Total *int `json:"total"`
+}
+
+type JobResponse struct {
+	ID              int                 `json:"id"`
+	Kind            string              `json:"kind"`
+	Status          string              `json:"status"`
+	Progress        JobProgressResponse `json:"progress"`
+	CancelRequested bool                `json:"cancel_requested"`
+	Attempts        int                 `json:"attempts"`
+	Result          json.RawMessage     `json:"result"`
+	Error           *string             `json:"error"`
+	ArtifactURL     *string             `json:"artifact_url"`
+	CreatedAt       time.Time           `json:"created_at"`
+	StartedAt       *time.Time          `json:"started_at"`
+	FinishedAt      *time.Time          `json:"finished_at"`
+}
+
+// ParseJobResponse はジョブが生成したファイルがあれば、ダウンロードするURLを返す
+func ParseJobResponse(j *db.Job) *JobResponse {
+	res := &JobResponse{
+		ID:              int(j.ID),
+		Kind:            j.Kind,
+		Status:          j.Status,
+		Progress:        JobProgressResponse{Done: int(j.ProgressDone)},
+		CancelRequested: j.CancelRequested,
+		Attempts:        int(j.Attempts),
+		Result:          json.RawMessage(j.Result),
+		CreatedAt:       j.CreatedAt.Time,
+	}
+	if j.ProgressTotal.Valid {
+		total := int(j.ProgressTotal.Int32)
+		res.Progress.Total = &total
+	}
+	if j.LastError.Valid {
+		res.Error = &j.LastError.String
+	}
+	if j.ArtifactKey.Valid {
+		artifactURL := fmt.Sprintf("/jobs/%d/artifact", j.ID)
+		res.ArtifactURL = &artifactURL
+	}
+	if j.StartedAt.Valid {
+		res.StartedAt = &j.StartedAt.Time
+	}
+	if j.FinishedAt.Valid {
+		res.FinishedAt = &j.FinishedAt.Time
+	}
+
+	return res
+}
@@ -0,0 +1,62 @@
+package response
+
+import (
+	"time"
+
+	"github.com/rentaro-m-b/ai-model-exam/db"
+)
+
+type LoanResponse struct {
+	ID         int        `json:"id"`
+	BookID     int        `json:"book_id"`
+	MemberID   int        `json:"member_id"`
+	LoanedAt   time.Time  `json:"loaned_at"`
+	DueAt      time.Time  `json:"due_at"`
+	ReturnedAt *time.Time `json:"returned_at"`
+}
+
+func ParseLoanResponse(loan *db.Loan) *LoanResponse {
+	res := &LoanResponse{
+		ID:       int(loan.ID),
+		BookID:   int(loan.BookID),
+		MemberID: int(loan.MemberID),
+		LoanedAt: loan.LoanedAt.Time,
+		DueAt:    loan.DueAt.Time,
+	}
+	if loan.ReturnedAt.Valid {
+		res.ReturnedAt = &loan.ReturnedAt.Time
+	}
+
+	return res
+}
+
+type FetchOverdueLoansResponses struct {
+	Loans []LoanResponse `json:"loans"`
+}
+
+func ParseFetchOverdueLoansResponse(loans []db.Loan) *FetchOverdueLoansResponses {
+	res := FetchOverdueLoansResponses{
+		Loans: []LoanResponse{},
+	}
+	for _, loan := range loans {
+		res.Loans = append(res.Loans, *ParseLoanResponse(&loan))
+	}
+
+	return &res
+}
+
+type FindAvailabilityResponse struct {
+	Total     int `json:"total"`
+	OnLoan    int `json:"on_loan"`
+	OnHold    int `json:"on_hold"`
+	Available int `json:"available"`
+}
+
+func ParseFindAvailabilityResponse(availability *db.GetBookAvailabilityRow) *FindAvailabilityResponse {
+	return &FindAvailabilityResponse{
+		Total:     int(availability.Total),
+		OnLoan:    int(availability.OnLoan),
+		OnHold:    int(availability.OnHold),
+		Available: max(int(availability.Total-availability.OnLoan-availability.OnHold), 0),
+	}
+}
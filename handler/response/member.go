@@ -0,0 +1,19 @@
+package response
+
+import (
+	"github.com/rentaro-m-b/ai-model-exam/db"
+)
+
+type FindMemberByIdResponse struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+func ParseFindMemberByIdResponse(member *db.Member) *FindMemberByIdResponse {
+	return &FindMemberByIdResponse{
+		ID:    int(member.ID),
+		Name:  member.Name,
+		Email: member.Email,
+	}
+}
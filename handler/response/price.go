@@ -0,0 +1,145 @@
+package response
+
+import (
+	"time"
+
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/money"
+	"github.com/rentaro-m-b/ai-model-exam/usecase"
+)
+
+type PriceResponse struct {
+	ID            int        `json:"id"`
+	Amount        int64      `json:"amount"`
+	Currency      string     `json:"currency"`
+	MinorUnits    int        `json:"minor_units"`
+	Decimal       string     `json:"decimal"`
+	EffectiveFrom time.Time  `json:"effective_from"`
+	EffectiveTo   *time.Time `json:"effective_to"`
+}
+
+func ParsePriceResponse(price *db.BookPrice) *PriceResponse {
+	currency, _ := money.LookupCurrency(price.Currency)
+	res := &PriceResponse{
+		ID:            int(price.ID),
+		Amount:        price.Amount,
+		Currency:      price.Currency,
+		MinorUnits:    currency.MinorUnits,
+		Decimal:       money.Money{Amount: price.Amount, Currency: currency}.Decimal(),
+		EffectiveFrom: price.EffectiveFrom.Time,
+	}
+	if price.EffectiveTo.Valid {
+		res.EffectiveTo = &price.EffectiveTo.Time
+	}
+
+	return res
+}
+
+type FetchPricesResponses struct {
+	Prices []PriceResponse `json:"prices"`
+}
+
+func ParseFetchPricesResponse(prices []db.BookPrice) *FetchPricesResponses {
+	res := FetchPricesResponses{
+		Prices: []PriceResponse{},
+	}
+	for _, price := range prices {
+		res.Prices = append(res.Prices, *ParsePriceResponse(&price))
+	}
+
+	return &res
+}
+
+type ConvertedPriceResponse struct {
+	Amount     int64   `json:"amount"`
+	Currency   string  `json:"currency"`
+	MinorUnits int     `json:"minor_units"`
+	Decimal    string  `json:"decimal"`
+	Rate       string  `json:"rate"`
+	RateDate   *string `json:"rate_date"`
+}
+
+// ParseConvertedPriceResponse は換算後の価格を返す
+// 換算元と換算先が同じ通貨の場合はレートを用いないため、レートの適用日はnullとなる
+func ParseConvertedPriceResponse(conversion *money.Conversion) *ConvertedPriceResponse {
+	res := &ConvertedPriceResponse{
+		Amount:     conversion.Money.Amount,
+		Currency:   conversion.Money.Currency.Code,
+		MinorUnits: conversion.Money.Currency.MinorUnits,
+		Decimal:    conversion.Money.Decimal(),
+		Rate:       money.FormatRate(conversion.Rate),
+	}
+	if !conversion.RateDate.IsZero() {
+		rateDate := conversion.RateDate.Format(time.DateOnly)
+		res.RateDate = &rateDate
+	}
+
+	return res
+}
+
+type DiscountedPriceResponse struct {
+	Amount              int64   `json:"amount"`
+	Currency            string  `json:"currency"`
+	MinorUnits          int     `json:"minor_units"`
+	Decimal             string  `json:"decimal"`
+	AppliedPromotionIDs []int32 `json:"applied_promotion_ids"`
+}
+
+func ParseDiscountedPriceResponse(discounted *usecase.DiscountedPrice) *DiscountedPriceResponse {
+	return &DiscountedPriceResponse{
+		Amount:              discounted.Money.Amount,
+		Currency:            discounted.Money.Currency.Code,
+		MinorUnits:          discounted.Money.Currency.MinorUnits,
+		Decimal:             discounted.Money.Decimal(),
+		AppliedPromotionIDs: discounted.PromotionIDs,
+	}
+}
+
+// BookPricing は書籍ごとの価格情報をまとめたもの
+// いずれのマップも書籍IDをキーとし、含まれない書籍の項目はnullとなる（BookPricing自体がnilの場合も同様）
+type BookPricing struct {
+	Prices      map[int32]db.BookPrice
+	Conversions map[int32]money.Conversion
+	Discounts   map[int32]usecase.DiscountedPrice
+}
+
+func (p *BookPricing) currentPrice(bookId int32) *PriceResponse {
+	if p == nil {
+		return nil
+	}
+	if price, ok := p.Prices[bookId]; ok {
+		return ParsePriceResponse(&price)
+	}
+	return nil
+}
+
+// legacyPrice は旧来のpriceの値を返す
+// 予約した価格の切り替わりはbooks.priceに反映されないため、有効な価格がある場合はcurrent_priceの金額を返す
+func (p *BookPricing) legacyPrice(book *db.Book) int {
+	if p != nil {
+		if price, ok := p.Prices[book.ID]; ok {
+			return int(price.Amount)
+		}
+	}
+	return int(book.Price.Int32)
+}
+
+func (p *BookPricing) convertedPrice(bookId int32) *ConvertedPriceResponse {
+	if p == nil {
+		return nil
+	}
+	if conversion, ok := p.Conversions[bookId]; ok {
+		return ParseConvertedPriceResponse(&conversion)
+	}
+	return nil
+}
+
+func (p *BookPricing) discountedPrice(bookId int32) *DiscountedPriceResponse {
+	if p == nil {
+		return nil
+	}
+	if discounted, ok := p.Discounts[bookId]; ok {
+		return ParseDiscountedPriceResponse(&discounted)
+	}
+	return nil
+}
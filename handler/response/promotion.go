@@ -0,0 +1,65 @@
+package response
+
+import (
+	"time"
+
+	"github.com/rentaro-m-b/ai-model-exam/db"
+)
+
+type PromotionResponse struct {
+	ID            int        `json:"id"`
+	Name          string     `json:"name"`
+	DiscountType  string     `json:"discount_type"`
+	DiscountValue int64      `json:"discount_value"`
+	Currency      *string    `json:"currency"`
+	TargetType    string     `json:"target_type"`
+	TargetBookID  *int       `json:"target_book_id"`
+	TargetName    *string    `json:"target_name"`
+	StartsAt      time.Time  `json:"starts_at"`
+	EndsAt        *time.Time `json:"ends_at"`
+	Priority      int        `json:"priority"`
+	Stackable     bool       `json:"stackable"`
+}
+
+func ParsePromotionResponse(promotion *db.Promotion) *PromotionResponse {
+	res := &PromotionResponse{
+		ID:            int(promotion.ID),
+		Name:          promotion.Name,
+		DiscountType:  promotion.DiscountType,
+		DiscountValue: promotion.DiscountValue,
+		TargetType:    promotion.TargetType,
+		StartsAt:      promotion.StartsAt.Time,
+		Priority:      int(promotion.Priority),
+		Stackable:     promotion.Stackable,
+	}
+	if promotion.Currency.Valid {
+		res.Currency = &promotion.Currency.String
+	}
+	if promotion.TargetBookID.Valid {
+		targetBookID := int(promotion.TargetBookID.Int32)
+		res.TargetBookID = &targetBookID
+	}
+	if promotion.TargetName.Valid {
+		res.TargetName = &promotion.TargetName.String
+	}
+	if promotion.EndsAt.Valid {
+		res.EndsAt = &promotion.EndsAt.Time
+	}
+
+	return res
+}
+
+type FetchPromotionsResponses struct {
+	Promotions []PromotionResponse `json:"promotions"`
+}
+
+func ParseFetchPromotionsResponse(promotions []db.Promotion) *FetchPromotionsResponses {
+	res := FetchPromotionsResponses{
+		Promotions: []PromotionResponse{},
+	}
+	for _, promotion := range promotions {
+		res.Promotions = append(res.Promotions, *ParsePromotionResponse(&promotion))
+	}
+
+	return &res
+}
@@ -0,0 +1,58 @@
+package response
+
+import (
+	"time"
+
+	"github.com/rentaro-m-b/ai-model-exam/db"
+)
+
+type ReservationResponse struct {
+	ID            int        `json:"id"`
+	BookID        int        `json:"book_id"`
+	MemberID      int        `json:"member_id"`
+	Status        string     `json:"status"`
+	Position      *int       `json:"position"`
+	CreatedAt     time.Time  `json:"created_at"`
+	HoldExpiresAt *time.Time `json:"hold_expires_at"`
+}
+
+func ParseReservationResponse(reservation *db.Reservation, position int) *ReservationResponse {
+	res := &ReservationResponse{
+		ID:        int(reservation.ID),
+		BookID:    int(reservation.BookID),
+		MemberID:  int(reservation.MemberID),
+		Status:    reservation.Status,
+		CreatedAt: reservation.CreatedAt.Time,
+	}
+	if position > 0 {
+		res.Position = &position
+	}
+	if reservation.HoldExpiresAt.Valid {
+		res.HoldExpiresAt = &reservation.HoldExpiresAt.Time
+	}
+
+	return res
+}
+
+type FetchReservationsResponses struct {
+	Reservations []ReservationResponse `json:"reservations"`
+}
+
+// ParseFetchReservationsResponse は取置中・予約順に並んだ予約に、待ち行列内の順番を付けて返す
+// 取置中の予約は順番を持たない
+func ParseFetchReservationsResponse(reservations []db.Reservation) *FetchReservationsResponses {
+	res := FetchReservationsResponses{
+		Reservations: []ReservationResponse{},
+	}
+	position := 0
+	for _, reservation := range reservations {
+		if reservation.Status == "waiting" {
+			position++
+			res.Reservations = append(res.Reservations, *ParseReservationResponse(&reservation, position))
+		} else {
+			res.Reservations = append(res.Reservations, *ParseReservationResponse(&reservation, 0))
+		}
+	}
+
+	return &res
+}
@@ -0,0 +1,60 @@
+package response
+
+import (
+	"math"
+	"time"
+
+	"github.com/rentaro-m-b/ai-model-exam/db"
+)
+
+type ReviewResponse struct {
+	ID        int       `json:"id"`
+	BookID    int       `json:"book_id"`
+	MemberID  int       `json:"member_id"`
+	Rating    int       `json:"rating"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func ParseReviewResponse(review *db.Review) *ReviewResponse {
+	return &ReviewResponse{
+		ID:        int(review.ID),
+		BookID:    int(review.BookID),
+		MemberID:  int(review.MemberID),
+		Rating:    int(review.Rating),
+		Body:      review.Body,
+		CreatedAt: review.CreatedAt.Time,
+		UpdatedAt: review.UpdatedAt.Time,
+	}
+}
+
+type FetchBookReviewsResponses struct {
+	Reviews []ReviewResponse `json:"reviews"`
+}
+
+func ParseFetchBookReviewsResponse(reviews []db.Review) *FetchBookReviewsResponses {
+	res := FetchBookReviewsResponses{
+		Reviews: []ReviewResponse{},
+	}
+	for _, review := range reviews {
+		res.Reviews = append(res.Reviews, *ParseReviewResponse(&review))
+	}
+
+	return &res
+}
+
+// averageRating は評価の平均を小数第2位までに丸めて返し、レビューが無い場合はnilを返す
+func averageRating(ratings map[int32]db.BookRating, bookId int32) *float64 {
+	rating, ok := ratings[bookId]
+	if !ok || rating.ReviewCount == 0 {
+		return nil
+	}
+
+	average := math.Round(float64(rating.RatingSum)/float64(rating.ReviewCount)*100) / 100
+	return &average
+}
+
+func reviewCount(ratings map[int32]db.BookRating, bookId int32) int {
+	return int(ratings[bookId].ReviewCount)
+}
@@ -0,0 +1,57 @@
+package response
+
+import (
+	"github.com/rentaro-m-b/ai-model-exam/db"
+)
+
+type TagResponse struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func ParseTagResponse(tag *db.Tag) *TagResponse {
+	return &TagResponse{
+		ID:   int(tag.ID),
+		Name: tag.Name,
+	}
+}
+
+type FetchBookTagsResponses struct {
+	Tags []TagResponse `json:"tags"`
+}
+
+func ParseFetchBookTagsResponse(tags []db.Tag) *FetchBookTagsResponses {
+	res := FetchBookTagsResponses{
+		Tags: []TagResponse{},
+	}
+	for _, tag := range tags {
+		res.Tags = append(res.Tags, *ParseTagResponse(&tag))
+	}
+
+	return &res
+}
+
+type TagSuggestionResponse struct {
+	ID         int    `json:"id"`
+	Name       string `json:"name"`
+	UsageCount int    `json:"usage_count"`
+}
+
+type FetchTagSuggestionsResponses struct {
+	Tags []TagSuggestionResponse `json:"tags"`
+}
+
+func ParseFetchTagSuggestionsResponse(tags []db.SearchTagsByPrefixRow) *FetchTagSuggestionsResponses {
+	res := FetchTagSuggestionsResponses{
+		Tags: []TagSuggestionResponse{},
+	}
+	for _, tag := range tags {
+		res.Tags = append(res.Tags, TagSuggestionResponse{
+			ID:         int(tag.ID),
+			Name:       tag.Name,
+			UsageCount: int(tag.UsageCount),
+		})
+	}
+
+	return &res
+}
@@ -0,0 +1,102 @@
+package response
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+)
+
+// WebhookResponse は署名の鍵を含めない
+type WebhookResponse struct {
+	ID         int       `json:"id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	Active     bool      `json:"active"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func ParseWebhookResponse(subscription *db.WebhookSubscription) *WebhookResponse {
+	return &WebhookResponse{
+		ID:         int(subscription.ID),
+		URL:        subscription.Url,
+		EventTypes: subscription.EventTypes,
+		Active:     subscription.Active,
+		CreatedAt:  subscription.CreatedAt.Time,
+	}
+}
+
+type FetchWebhooksResponses struct {
+	Webhooks []WebhookResponse `json:"webhooks"`
+}
+
+func ParseFetchWebhooksResponse(subscriptions []db.WebhookSubscription) *FetchWebhooksResponses {
+	res := FetchWebhooksResponses{
+		Webhooks: []WebhookResponse{},
+	}
+	for _, subscription := range subscriptions {
+		res.Webhooks = append(res.Webhooks, *ParseWebhookResponse(&subscription))
+	}
+
+	return &res
+}
+
+type WebhookDeliveryResponse struct {
+	ID             int             `json:"id"`
+	WebhookID      int             `json:"webhook_id"`
+	EventID        int             `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at"`
+	ResponseStatus *int            `json:"response_status"`
+	LastError      *string         `json:"last_error"`
+	CreatedAt      time.Time       `json:"created_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at"`
+}
+
+// ParseWebhookDeliveryResponse は配信待ちの配信にのみ次回の送信日時を返す
+func ParseWebhookDeliveryResponse(delivery *db.WebhookDelivery) *WebhookDeliveryResponse {
+	res := &WebhookDeliveryResponse{
+		ID:        int(delivery.ID),
+		WebhookID: int(delivery.SubscriptionID),
+		EventID:   int(delivery.EventID),
+		EventType: delivery.EventType,
+		Payload:   json.RawMessage(delivery.Payload),
+		Status:    delivery.Status,
+		Attempts:  int(delivery.Attempts),
+		CreatedAt: delivery.CreatedAt.Time,
+	}
+	if delivery.Status == repository.WebhookDeliveryStatusPending && delivery.NextAttemptAt.Valid {
+		res.NextAttemptAt = &delivery.NextAttemptAt.Time
+	}
+	if delivery.ResponseStatus.Valid {
+		responseStatus := int(delivery.ResponseStatus.Int32)
+		res.ResponseStatus = &responseStatus
+	}
+	if delivery.LastError.Valid {
+		res.LastError = &delivery.LastError.String
+	}
+	if delivery.DeliveredAt.Valid {
+		res.DeliveredAt = &delivery.DeliveredAt.Time
+	}
+
+	return res
+}
+
+type FetchWebhookDeliveriesResponses struct {
+	Deliveries []WebhookDeliveryResponse `json:"deliveries"`
+}
+
+func ParseFetchWebhookDeliveriesResponse(deliveries []db.WebhookDelivery) *FetchWebhookDeliveriesResponses {
+	res := FetchWebhookDeliveriesResponses{
+		Deliveries: []WebhookDeliveryResponse{},
+	}
+	for _, delivery := range deliveries {
+		res.Deliveries = append(res.Deliveries, *ParseWebhookDeliveryResponse(&delivery))
+	}
+
+	return &res
+}
@@ -0,0 +1,60 @@
+package response
+
+import (
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/usecase"
+)
+
+type WorkResponse struct {
+	ID     int     `json:"id"`
+	Title  string  `json:"title"`
+	Author *string `json:"author"`
+}
+
+func ParseWorkResponse(work *db.Work) *WorkResponse {
+	res := &WorkResponse{
+		ID:    int(work.ID),
+		Title: work.Title,
+	}
+	if work.Author.Valid {
+		res.Author = &work.Author.String
+	}
+
+	return res
+}
+
+// EditionResponse は作品に属する版を表す
+type EditionResponse struct {
+	ID        int    `json:"id"`
+	Title     string `json:"title"`
+	Author    string `json:"author"`
+	Publisher string `json:"publisher"`
+	BookMetadataResponse
+}
+
+func ParseEditionResponse(book *db.Book) *EditionResponse {
+	return &EditionResponse{
+		ID:                   int(book.ID),
+		Title:                book.Title.String,
+		Author:               book.Author.String,
+		Publisher:            book.Publisher.String,
+		BookMetadataResponse: ParseBookMetadataResponse(book),
+	}
+}
+
+type WorkDetailResponse struct {
+	WorkResponse
+	Editions []EditionResponse `json:"editions"`
+}
+
+func ParseWorkDetailResponse(detail *usecase.WorkDetail) *WorkDetailResponse {
+	res := &WorkDetailResponse{
+		WorkResponse: *ParseWorkResponse(&detail.Work),
+		Editions:     []EditionResponse{},
+	}
+	for _, edition := range detail.Editions {
+		res.Editions = append(res.Editions, *ParseEditionResponse(&edition))
+	}
+
+	return res
+}
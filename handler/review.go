@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/handler/request"
+	"github.com/rentaro-m-b/ai-model-exam/handler/response"
+	"github.com/rentaro-m-b/ai-model-exam/usecase"
+)
+
+type ReviewHandler interface {
+	FetchBookReviews(c echo.Context) error
+	CreateReview(c echo.Context) error
+	UpdateReview(c echo.Context) error
+	DeleteReview(c echo.Context) error
+}
+
+type reviewHandlerImpl struct {
+	usecase usecase.ReviewUsecase
+}
+
+func NewReviewHandler(usecase usecase.ReviewUsecase) ReviewHandler {
+	return &reviewHandlerImpl{
+		usecase: usecase,
+	}
+}
+
+func (h *reviewHandlerImpl) FetchBookReviews(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Printf("Unable to execute ReviewHandlerFetchBookReviews: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid book ID",
+		})
+	}
+
+	reviews, err := h.usecase.FetchBookReviews(context.Background(), id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Book not found",
+		})
+	}
+	if err != nil {
+		log.Printf("Unable to execute ReviewHandlerFetchBookReviews: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.ParseFetchBookReviewsResponse(reviews))
+}
+
+func (h *reviewHandlerImpl) CreateReview(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Printf("Unable to execute ReviewHandlerCreateReview: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid book ID",
+		})
+	}
+
+	body := new(request.CreateReviewRequest)
+	if err := c.Bind(body); err != nil {
+		log.Printf("Unable to execute ReviewHandlerCreateReview: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid request body",
+		})
+	}
+	if vs, ve := body.Validate(); ve != -1 {
+		return validationError(c, vs, ve)
+	}
+
+	param := db.CreateReviewParams{
+		BookID:   int32(id),
+		MemberID: int32(body.MemberID.Int64),
+		Rating:   int16(body.Rating.Int64),
+		Body:     body.Body.String,
+	}
+
+	review, err := h.usecase.CreateReview(context.Background(), &param)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Book or member not found",
+		})
+	case isUniqueViolation(err):
+		return c.JSON(http.StatusConflict, map[string]string{
+			"message": "Member has already reviewed this book",
+		})
+	case err != nil:
+		log.Printf("Unable to execute ReviewHandlerCreateReview: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.JSON(http.StatusCreated, response.ParseReviewResponse(review))
+}
+
+func (h *reviewHandlerImpl) UpdateReview(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Printf("Unable to execute ReviewHandlerUpdateReview: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid review ID",
+		})
+	}
+
+	body := new(request.UpdateReviewRequest)
+	if err := c.Bind(body); err != nil {
+		log.Printf("Unable to execute ReviewHandlerUpdateReview: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid request body",
+		})
+	}
+	if vs, ve := body.Validate(); ve != -1 {
+		return validationError(c, vs, ve)
+	}
+
+	param := db.UpdateReviewParams{
+		ID:     int32(id),
+		Rating: pgtype.Int2{Int16: int16(body.Rating.Int64), Valid: body.Rating.Valid},
+		Body:   pgtype.Text{String: body.Body.String, Valid: body.Body.Valid},
+	}
+
+	review, err := h.usecase.UpdateReview(context.Background(), &param)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Review not found",
+		})
+	}
+	if err != nil {
+		log.Printf("Unable to execute ReviewHandlerUpdateReview: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.ParseReviewResponse(review))
+}
+
+func (h *reviewHandlerImpl) DeleteReview(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Printf("Unable to execute ReviewHandlerDeleteReview: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid review ID",
+		})
+	}
+
+	err = h.usecase.DeleteReview(context.Background(), id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Review not found",
+		})
+	}
+	if err != nil {
+		log.Printf("Unable to execute ReviewHandlerDeleteReview: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
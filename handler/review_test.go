@@ -0,0 +1,146 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/guregu/null"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/handler"
+	"github.com/rentaro-m-b/ai-model-exam/handler/request"
+	mock_usecase "github.com/rentaro-m-b/ai-model-exam/usecase/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateReview(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockReviewUsecase(ctrl)
+	createdAt := pgtype.Timestamptz{Time: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), Valid: true}
+	mockUc.EXPECT().CreateReview(gomock.Any(), &db.CreateReviewParams{
+		BookID:   1,
+		MemberID: 2,
+		Rating:   4,
+		Body:     "good",
+	}).Return(&db.Review{ID: 3, BookID: 1, MemberID: 2, Rating: 4, Body: "good", CreatedAt: createdAt, UpdatedAt: createdAt}, nil)
+
+	// リクエストボディを設定し、Echoのコンテキストを作成
+	c, rec := newCategoryContext(http.MethodPost, "/books/1/reviews", 1, request.CreateReviewRequest{
+		MemberID: null.NewInt(2, true),
+		Rating:   null.NewInt(4, true),
+		Body:     null.NewString("good", true),
+	})
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewReviewHandler(mockUc)
+	assert.NoError(t, h.CreateReview(c))
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.JSONEq(t, `{
+		"id": 3, "book_id": 1, "member_id": 2, "rating": 4, "body": "good",
+		"created_at": "2024-06-01T00:00:00Z", "updated_at": "2024-06-01T00:00:00Z"
+	}`, rec.Body.String())
+}
+
+func TestCreateReviewFailureValidation(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成
+	mockUc := mock_usecase.NewMockReviewUsecase(ctrl)
+
+	// リクエストボディを設定し、Echoのコンテキストを作成
+	c, rec := newCategoryContext(http.MethodPost, "/books/1/reviews", 1, request.CreateReviewRequest{
+		MemberID: null.NewInt(2, true),
+		Rating:   null.NewInt(6, true),
+	})
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewReviewHandler(mockUc)
+	assert.NoError(t, h.CreateReview(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.JSONEq(t, `{
+		"type": "about:blank", "title": "request validation error is occurred.",
+		"detail": "rating is invalid.", "instance": "/books/1/reviews"
+	}`, rec.Body.String())
+}
+
+func TestCreateReviewFailureDuplicate(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockReviewUsecase(ctrl)
+	mockUc.EXPECT().CreateReview(gomock.Any(), gomock.Any()).Return(nil, &pgconn.PgError{Code: "23505"})
+
+	// リクエストボディを設定し、Echoのコンテキストを作成
+	c, rec := newCategoryContext(http.MethodPost, "/books/1/reviews", 1, request.CreateReviewRequest{
+		MemberID: null.NewInt(2, true),
+		Rating:   null.NewInt(5, true),
+	})
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewReviewHandler(mockUc)
+	assert.NoError(t, h.CreateReview(c))
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.JSONEq(t, `{"message": "Member has already reviewed this book"}`, rec.Body.String())
+}
+
+func TestUpdateReviewFailureNotFound(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockReviewUsecase(ctrl)
+	mockUc.EXPECT().UpdateReview(gomock.Any(), &db.UpdateReviewParams{
+		ID:     99,
+		Rating: pgtype.Int2{Int16: 3, Valid: true},
+	}).Return(nil, pgx.ErrNoRows)
+
+	// リクエストボディを設定し、Echoのコンテキストを作成
+	c, rec := newCategoryContext(http.MethodPatch, "/reviews/99", 99, request.UpdateReviewRequest{
+		Rating: null.NewInt(3, true),
+	})
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewReviewHandler(mockUc)
+	assert.NoError(t, h.UpdateReview(c))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.JSONEq(t, `{"message": "Review not found"}`, rec.Body.String())
+}
+
+func TestDeleteReview(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockReviewUsecase(ctrl)
+	mockUc.EXPECT().DeleteReview(gomock.Any(), 3).Return(nil)
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodDelete, "/reviews/3", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(strconv.Itoa(3))
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewReviewHandler(mockUc)
+	assert.NoError(t, h.DeleteReview(c))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
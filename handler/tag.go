@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/rentaro-m-b/ai-model-exam/handler/request"
+	"github.com/rentaro-m-b/ai-model-exam/handler/response"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	"github.com/rentaro-m-b/ai-model-exam/usecase"
+)
+
+type TagHandler interface {
+	FetchBookTags(c echo.Context) error
+	AddBookTag(c echo.Context) error
+	RemoveBookTag(c echo.Context) error
+	SuggestTags(c echo.Context) error
+}
+
+type tagHandlerImpl struct {
+	usecase usecase.TagUsecase
+}
+
+func NewTagHandler(usecase usecase.TagUsecase) TagHandler {
+	return &tagHandlerImpl{
+		usecase: usecase,
+	}
+}
+
+func (h *tagHandlerImpl) FetchBookTags(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Printf("Unable to execute TagHandlerFetchBookTags: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid book ID",
+		})
+	}
+
+	tags, err := h.usecase.FetchBookTags(context.Background(), id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Book not found",
+		})
+	}
+	if err != nil {
+		log.Printf("Unable to execute TagHandlerFetchBookTags: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.ParseFetchBookTagsResponse(tags))
+}
+
+func (h *tagHandlerImpl) AddBookTag(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Printf("Unable to execute TagHandlerAddBookTag: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid book ID",
+		})
+	}
+
+	body := new(request.AddBookTagRequest)
+	if err := c.Bind(body); err != nil {
+		log.Printf("Unable to execute TagHandlerAddBookTag: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid request body",
+		})
+	}
+	if vs, ve := body.Validate(); ve != -1 {
+		return validationError(c, vs, ve)
+	}
+
+	tag, err := h.usecase.AddBookTag(context.Background(), id, body.Name.String)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Book not found",
+		})
+	}
+	if err != nil {
+		log.Printf("Unable to execute TagHandlerAddBookTag: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.JSON(http.StatusCreated, response.ParseTagResponse(tag))
+}
+
+func (h *tagHandlerImpl) RemoveBookTag(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Printf("Unable to execute TagHandlerRemoveBookTag: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid book ID",
+		})
+	}
+
+	err = h.usecase.RemoveBookTag(context.Background(), id, c.Param("name"))
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Book not found",
+		})
+	case errors.Is(err, repository.ErrTagNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Tag not found",
+		})
+	case err != nil:
+		log.Printf("Unable to execute TagHandlerRemoveBookTag: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *tagHandlerImpl) SuggestTags(c echo.Context) error {
+	var limit int
+	if limitParam := c.QueryParam("limit"); limitParam != "" {
+		l, err := strconv.Atoi(limitParam)
+		if err != nil || l <= 0 {
+			log.Printf("Unable to execute TagHandlerSuggestTags: %d\n", err)
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"message": "Invalid limit parameter",
+			})
+		}
+		limit = l
+	}
+
+	tags, err := h.usecase.SuggestTags(context.Background(), c.QueryParam("prefix"), limit)
+	if err != nil {
+		log.Printf("Unable to execute TagHandlerSuggestTags: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.ParseFetchTagSuggestionsResponse(tags))
+}
@@ -0,0 +1,135 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/guregu/null"
+	"github.com/labstack/echo/v4"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/handler"
+	"github.com/rentaro-m-b/ai-model-exam/handler/request"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	mock_usecase "github.com/rentaro-m-b/ai-model-exam/usecase/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddBookTag(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockTagUsecase(ctrl)
+	mockUc.EXPECT().AddBookTag(gomock.Any(), 1, "Golang").Return(&db.Tag{ID: 2, Name: "golang"}, nil)
+
+	// リクエストボディを設定し、Echoのコンテキストを作成
+	c, rec := newCategoryContext(http.MethodPost, "/books/1/tags", 1, request.AddBookTagRequest{
+		Name: null.NewString("Golang", true),
+	})
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewTagHandler(mockUc)
+	assert.NoError(t, h.AddBookTag(c))
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.JSONEq(t, `{"id": 2, "name": "golang"}`, rec.Body.String())
+}
+
+func TestAddBookTagFailureValidation(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成
+	mockUc := mock_usecase.NewMockTagUsecase(ctrl)
+
+	// リクエストボディを設定し、Echoのコンテキストを作成
+	c, rec := newCategoryContext(http.MethodPost, "/books/1/tags", 1, request.AddBookTagRequest{
+		Name: null.NewString("sf,space", true),
+	})
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewTagHandler(mockUc)
+	assert.NoError(t, h.AddBookTag(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.JSONEq(t, `{
+		"type": "about:blank", "title": "request validation error is occurred.",
+		"detail": "name is invalid.", "instance": "/books/1/tags"
+	}`, rec.Body.String())
+}
+
+func TestRemoveBookTagFailureTagNotFound(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockTagUsecase(ctrl)
+	mockUc.EXPECT().RemoveBookTag(gomock.Any(), 1, "golang").Return(repository.ErrTagNotFound)
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodDelete, "/books/1/tags/golang", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id", "name")
+	c.SetParamValues(strconv.Itoa(1), "golang")
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewTagHandler(mockUc)
+	assert.NoError(t, h.RemoveBookTag(c))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.JSONEq(t, `{"message": "Tag not found"}`, rec.Body.String())
+}
+
+func TestSuggestTags(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockTagUsecase(ctrl)
+	mockUc.EXPECT().SuggestTags(gomock.Any(), "go", 5).Return([]db.SearchTagsByPrefixRow{
+		{ID: 2, Name: "golang", UsageCount: 12},
+		{ID: 1, Name: "google", UsageCount: 3},
+	}, nil)
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/tags?prefix=go&limit=5", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewTagHandler(mockUc)
+	assert.NoError(t, h.SuggestTags(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"tags": [
+		{"id": 2, "name": "golang", "usage_count": 12},
+		{"id": 1, "name": "google", "usage_count": 3}
+	]}`, rec.Body.String())
+}
+
+func TestSuggestTagsFailureInvalidLimit(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成
+	mockUc := mock_usecase.NewMockTagUsecase(ctrl)
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/tags?prefix=go&limit=0", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewTagHandler(mockUc)
+	assert.NoError(t, h.SuggestTags(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.JSONEq(t, `{"message": "Invalid limit parameter"}`, rec.Body.String())
+}
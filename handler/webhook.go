@@ -0,0 +1,263 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/handler/request"
+	"github.com/rentaro-m-b/ai-model-exam/handler/response"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	"github.com/rentaro-m-b/ai-model-exam/usecase"
+)
+
+type WebhookHandler interface {
+	CreateWebhook(c echo.Context) error
+	FetchWebhooks(c echo.Context) error
+	FindWebhookById(c echo.Context) error
+	UpdateWebhook(c echo.Context) error
+	DeleteWebhook(c echo.Context) error
+	FetchDeliveries(c echo.Context) error
+	FetchDeadLetters(c echo.Context) error
+	Redeliver(c echo.Context) error
+}
+
+type webhookHandlerImpl struct {
+	usecase usecase.WebhookUsecase
+}
+
+func NewWebhookHandler(usecase usecase.WebhookUsecase) WebhookHandler {
+	return &webhookHandlerImpl{
+		usecase: usecase,
+	}
+}
+
+func (h *webhookHandlerImpl) CreateWebhook(c echo.Context) error {
+	body := new(request.CreateWebhookRequest)
+	if err := c.Bind(body); err != nil {
+		log.Printf("Unable to execute WebhookHandlerCreateWebhook: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid request body",
+		})
+	}
+	if vs, ve := body.Validate(); ve != -1 {
+		return validationError(c, vs, ve)
+	}
+
+	param := db.CreateWebhookSubscriptionParams{
+		Url:        body.URL.String,
+		EventTypes: body.EventTypes,
+		Secret:     body.Secret.String,
+	}
+
+	subscription, err := h.usecase.CreateWebhook(context.Background(), &param)
+	if err != nil {
+		log.Printf("Unable to execute WebhookHandlerCreateWebhook: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+	location := fmt.Sprintf("%s/webhooks/%d", c.Scheme()+"://"+c.Request().Host, subscription.ID)
+	c.Response().Header().Set("Location", location)
+
+	return c.JSON(http.StatusCreated, response.ParseWebhookResponse(subscription))
+}
+
+func (h *webhookHandlerImpl) FetchWebhooks(c echo.Context) error {
+	subscriptions, err := h.usecase.FetchWebhooks(context.Background())
+	if err != nil {
+		log.Printf("Unable to execute WebhookHandlerFetchWebhooks: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.ParseFetchWebhooksResponse(subscriptions))
+}
+
+func (h *webhookHandlerImpl) FindWebhookById(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Printf("Unable to execute WebhookHandlerFindWebhookById: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid webhook ID",
+		})
+	}
+
+	subscription, err := h.usecase.FindWebhookById(context.Background(), id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Webhook not found",
+		})
+	}
+	if err != nil {
+		log.Printf("Unable to execute WebhookHandlerFindWebhookById: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.ParseWebhookResponse(subscription))
+}
+
+// UpdateWebhook は指定された項目のみを更新する
+// 購読を止める場合は削除せずにactiveをfalseにすれば、配信の記録を残したまま新しいイベントの配信を止められる
+func (h *webhookHandlerImpl) UpdateWebhook(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Printf("Unable to execute WebhookHandlerUpdateWebhook: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid webhook ID",
+		})
+	}
+
+	body := new(request.UpdateWebhookRequest)
+	if err := c.Bind(body); err != nil {
+		log.Printf("Unable to execute WebhookHandlerUpdateWebhook: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid request body",
+		})
+	}
+	if vs, ve := body.Validate(); ve != -1 {
+		return validationError(c, vs, ve)
+	}
+
+	param := db.UpdateWebhookSubscriptionParams{
+		ID:         int32(id),
+		Url:        pgtype.Text{String: body.URL.String, Valid: body.URL.Valid},
+		EventTypes: body.EventTypes,
+		Secret:     pgtype.Text{String: body.Secret.String, Valid: body.Secret.Valid},
+		Active:     pgtype.Bool{Bool: body.Active.Bool, Valid: body.Active.Valid},
+	}
+
+	subscription, err := h.usecase.UpdateWebhook(context.Background(), &param)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Webhook not found",
+		})
+	}
+	if err != nil {
+		log.Printf("Unable to execute WebhookHandlerUpdateWebhook: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.ParseWebhookResponse(subscription))
+}
+
+func (h *webhookHandlerImpl) DeleteWebhook(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Printf("Unable to execute WebhookHandlerDeleteWebhook: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid webhook ID",
+		})
+	}
+
+	err = h.usecase.DeleteWebhook(context.Background(), id)
+	if errors.Is(err, repository.ErrWebhookNotFound) {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Webhook not found",
+		})
+	}
+	if err != nil {
+		log.Printf("Unable to execute WebhookHandlerDeleteWebhook: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// FetchDeliveries は購読の配信の記録を新しい順に返す
+func (h *webhookHandlerImpl) FetchDeliveries(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Printf("Unable to execute WebhookHandlerFetchDeliveries: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid webhook ID",
+		})
+	}
+	status := c.QueryParam("status")
+	switch status {
+	case "", repository.WebhookDeliveryStatusPending, repository.WebhookDeliveryStatusSucceeded, repository.WebhookDeliveryStatusDead:
+	default:
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid status parameter",
+		})
+	}
+
+	deliveries, err := h.usecase.FetchDeliveries(context.Background(), id, status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Webhook not found",
+		})
+	}
+	if err != nil {
+		log.Printf("Unable to execute WebhookHandlerFetchDeliveries: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.ParseFetchWebhookDeliveriesResponse(deliveries))
+}
+
+// FetchDeadLetters は最大回数まで失敗して再送を止めた配信を、全ての購読から新しい順に返す
+func (h *webhookHandlerImpl) FetchDeadLetters(c echo.Context) error {
+	deliveries, err := h.usecase.FetchDeadLetters(context.Background())
+	if err != nil {
+		log.Printf("Unable to execute WebhookHandlerFetchDeadLetters: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.ParseFetchWebhookDeliveriesResponse(deliveries))
+}
+
+// Redeliver は配信を配信待ちに戻し、送信は非同期に行うため202を返す
+func (h *webhookHandlerImpl) Redeliver(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Printf("Unable to execute WebhookHandlerRedeliver: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid webhook ID",
+		})
+	}
+	deliveryId, err := strconv.ParseInt(c.Param("delivery_id"), 10, 64)
+	if err != nil {
+		log.Printf("Unable to execute WebhookHandlerRedeliver: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid delivery ID",
+		})
+	}
+
+	delivery, err := h.usecase.Redeliver(context.Background(), id, deliveryId)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Webhook not found",
+		})
+	case errors.Is(err, usecase.ErrWebhookDeliveryNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Delivery not found",
+		})
+	case err != nil:
+		log.Printf("Unable to execute WebhookHandlerRedeliver: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.JSON(http.StatusAccepted, response.ParseWebhookDeliveryResponse(delivery))
+}
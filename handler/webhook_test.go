@@ -0,0 +1,243 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/guregu/null"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/handler"
+	"github.com/rentaro-m-b/ai-model-exam/handler/request"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	"github.com/rentaro-m-b/ai-model-exam/usecase"
+	mock_usecase "github.com/rentaro-m-b/ai-model-exam/usecase/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateWebhook(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockWebhookUsecase(ctrl)
+	paramUc := db.CreateWebhookSubscriptionParams{
+		Url:        "https://example.com/hook",
+		EventTypes: []string{"book.created", "book.updated"},
+		Secret:     "0123456789abcdef",
+	}
+	createdAt := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	mockUc.EXPECT().CreateWebhook(gomock.Any(), &paramUc).Return(&db.WebhookSubscription{
+		ID:         1,
+		Url:        paramUc.Url,
+		EventTypes: paramUc.EventTypes,
+		Secret:     paramUc.Secret,
+		Active:     true,
+		CreatedAt:  pgtype.Timestamptz{Time: createdAt, Valid: true},
+	}, nil)
+
+	// リクエストボディを設定し、Echoのコンテキストを作成
+	c, rec := newCategoryContext(http.MethodPost, "/webhooks", 0, request.CreateWebhookRequest{
+		URL:        null.StringFrom("https://example.com/hook"),
+		EventTypes: []string{"book.created", "book.updated"},
+		Secret:     null.StringFrom("0123456789abcdef"),
+	})
+
+	// ハンドラを作成し、テスト項目を検証（署名の鍵は返さない）
+	h := handler.NewWebhookHandler(mockUc)
+	assert.NoError(t, h.CreateWebhook(c))
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "http://example.com/webhooks/1", rec.Header().Get("Location"))
+	assert.JSONEq(t, `{
+		"id": 1, "url": "https://example.com/hook", "event_types": ["book.created", "book.updated"],
+		"active": true, "created_at": "2024-07-01T00:00:00Z"
+	}`, rec.Body.String())
+}
+
+func TestCreateWebhookFailureValidation(t *testing.T) {
+	cases := []struct {
+		name   string
+		body   request.CreateWebhookRequest
+		detail string
+	}{
+		{
+			"url missing",
+			request.CreateWebhookRequest{EventTypes: []string{"book.created"}, Secret: null.StringFrom("0123456789abcdef")},
+			"url must not be none.",
+		},
+		{
+			"url not http",
+			request.CreateWebhookRequest{URL: null.StringFrom("ftp://example.com"), EventTypes: []string{"book.created"}, Secret: null.StringFrom("0123456789abcdef")},
+			"url is invalid.",
+		},
+		{
+			"unknown event type",
+			request.CreateWebhookRequest{URL: null.StringFrom("https://example.com/hook"), EventTypes: []string{"book.archived"}, Secret: null.StringFrom("0123456789abcdef")},
+			"event_types is invalid.",
+		},
+		{
+			"empty event types",
+			request.CreateWebhookRequest{URL: null.StringFrom("https://example.com/hook"), EventTypes: []string{}, Secret: null.StringFrom("0123456789abcdef")},
+			"event_types must not be blank.",
+		},
+		{
+			"short secret",
+			request.CreateWebhookRequest{URL: null.StringFrom("https://example.com/hook"), EventTypes: []string{"book.created"}, Secret: null.StringFrom("secret")},
+			"secret is invalid.",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// モックコントローラを作成
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// リクエストボディを設定し、Echoのコンテキストを作成
+			mockUc := mock_usecase.NewMockWebhookUsecase(ctrl)
+			c, rec := newCategoryContext(http.MethodPost, "/webhooks", 0, tc.body)
+
+			// ハンドラを作成し、テスト項目を検証
+			h := handler.NewWebhookHandler(mockUc)
+			assert.NoError(t, h.CreateWebhook(c))
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			assert.Contains(t, rec.Body.String(), tc.detail)
+		})
+	}
+}
+
+func TestDeleteWebhookFailureNotFound(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockWebhookUsecase(ctrl)
+	mockUc.EXPECT().DeleteWebhook(gomock.Any(), 99).Return(repository.ErrWebhookNotFound)
+
+	// Echoのコンテキストを作成
+	c, rec := newCategoryContext(http.MethodDelete, "/webhooks/99", 99, nil)
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewWebhookHandler(mockUc)
+	assert.NoError(t, h.DeleteWebhook(c))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.JSONEq(t, `{"message": "Webhook not found"}`, rec.Body.String())
+}
+
+func TestFetchWebhookDeliveries(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockWebhookUsecase(ctrl)
+	createdAt := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	mockUc.EXPECT().FetchDeliveries(gomock.Any(), 1, "dead").Return([]db.WebhookDelivery{
+		{
+			ID:             3,
+			SubscriptionID: 1,
+			EventID:        10,
+			EventType:      "book.created",
+			Payload:        []byte(`{"id": 10}`),
+			Status:         repository.WebhookDeliveryStatusDead,
+			Attempts:       8,
+			NextAttemptAt:  pgtype.Timestamptz{Time: createdAt.Add(time.Hour), Valid: true},
+			ResponseStatus: pgtype.Int4{Int32: 500, Valid: true},
+			LastError:      pgtype.Text{String: "unexpected status code 500", Valid: true},
+			CreatedAt:      pgtype.Timestamptz{Time: createdAt, Valid: true},
+		},
+	}, nil)
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/webhooks/1/deliveries?status=dead", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(strconv.Itoa(1))
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewWebhookHandler(mockUc)
+	assert.NoError(t, h.FetchDeliveries(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"deliveries": [{
+		"id": 3, "webhook_id": 1, "event_id": 10, "event_type": "book.created", "payload": {"id": 10},
+		"status": "dead", "attempts": 8, "next_attempt_at": null, "response_status": 500,
+		"last_error": "unexpected status code 500", "created_at": "2024-07-01T00:00:00Z", "delivered_at": null
+	}]}`, rec.Body.String())
+}
+
+func TestFetchWebhookDeliveriesFailureInvalidStatus(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Echoのインスタンス、リクエスト、レスポンスを作成
+	mockUc := mock_usecase.NewMockWebhookUsecase(ctrl)
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/webhooks/1/deliveries?status=failed", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(strconv.Itoa(1))
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewWebhookHandler(mockUc)
+	assert.NoError(t, h.FetchDeliveries(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.JSONEq(t, `{"message": "Invalid status parameter"}`, rec.Body.String())
+}
+
+func TestRedeliver(t *testing.T) {
+	cases := []struct {
+		name    string
+		err     error
+		code    int
+		message string
+	}{
+		{"accepted", nil, http.StatusAccepted, ""},
+		{"delivery not found", usecase.ErrWebhookDeliveryNotFound, http.StatusNotFound, `{"message": "Delivery not found"}`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// モックコントローラを作成
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// ユースケースのモックを作成し、期待値を設定
+			mockUc := mock_usecase.NewMockWebhookUsecase(ctrl)
+			var delivery *db.WebhookDelivery
+			if tc.err == nil {
+				delivery = &db.WebhookDelivery{
+					ID: 3, SubscriptionID: 1, EventID: 10, EventType: "book.created", Payload: []byte(`{}`),
+					Status: repository.WebhookDeliveryStatusPending,
+				}
+			}
+			mockUc.EXPECT().Redeliver(gomock.Any(), 1, int64(3)).Return(delivery, tc.err)
+
+			// Echoのコンテキストを作成
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/webhooks/1/deliveries/3/redeliver", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id", "delivery_id")
+			c.SetParamValues("1", "3")
+
+			// ハンドラを作成し、テスト項目を検証
+			h := handler.NewWebhookHandler(mockUc)
+			assert.NoError(t, h.Redeliver(c))
+			assert.Equal(t, tc.code, rec.Code)
+			if tc.message != "" {
+				assert.JSONEq(t, tc.message, rec.Body.String())
+			} else {
+				assert.Contains(t, rec.Body.String(), `"status":"pending"`)
+			}
+		})
+	}
+}
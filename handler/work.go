@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/handler/request"
+	"github.com/rentaro-m-b/ai-model-exam/handler/response"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	"github.com/rentaro-m-b/ai-model-exam/usecase"
+)
+
+type WorkHandler interface {
+	CreateWork(c echo.Context) error
+	FindWorkById(c echo.Context) error
+	LinkBook(c echo.Context) error
+	UnlinkBook(c echo.Context) error
+}
+
+type workHandlerImpl struct {
+	usecase usecase.WorkUsecase
+}
+
+func NewWorkHandler(usecase usecase.WorkUsecase) WorkHandler {
+	return &workHandlerImpl{
+		usecase: usecase,
+	}
+}
+
+func (h *workHandlerImpl) CreateWork(c echo.Context) error {
+	body := new(request.CreateWorkRequest)
+	if err := c.Bind(body); err != nil {
+		log.Printf("Unable to execute WorkHandlerCreateWork: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid request body",
+		})
+	}
+	if vs, ve := body.Validate(); ve != -1 {
+		return validationError(c, vs, ve)
+	}
+
+	param := db.CreateWorkParams{
+		Title:  body.Title.String,
+		Author: pgtype.Text{String: body.Author.String, Valid: body.Author.Valid},
+	}
+
+	work, err := h.usecase.CreateWork(context.Background(), &param)
+	if err != nil {
+		log.Printf("Unable to execute WorkHandlerCreateWork: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+	location := fmt.Sprintf("%s/works/%d", c.Scheme()+"://"+c.Request().Host, work.ID)
+	c.Response().Header().Set("Location", location)
+
+	return c.JSON(http.StatusCreated, response.ParseWorkResponse(work))
+}
+
+func (h *workHandlerImpl) FindWorkById(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Printf("Unable to execute WorkHandlerFindWorkById: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid work ID",
+		})
+	}
+
+	detail, err := h.usecase.FindWorkById(context.Background(), id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Work not found",
+		})
+	}
+	if err != nil {
+		log.Printf("Unable to execute WorkHandlerFindWorkById: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.ParseWorkDetailResponse(detail))
+}
+
+// LinkBook は書籍を作品の版として紐付ける
+// 既に別の作品に属する書籍は、指定された作品へ付け替える
+func (h *workHandlerImpl) LinkBook(c echo.Context) error {
+	id, bookId, err := parseWorkBookParams(c)
+	if err != nil {
+		log.Printf("Unable to execute WorkHandlerLinkBook: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid work or book ID",
+		})
+	}
+
+	book, err := h.usecase.LinkBook(context.Background(), id, bookId)
+	switch {
+	case errors.Is(err, repository.ErrWorkNotFound), isForeignKeyViolation(err):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Work not found",
+		})
+	case errors.Is(err, pgx.ErrNoRows):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Book not found",
+		})
+	case err != nil:
+		log.Printf("Unable to execute WorkHandlerLinkBook: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, response.ParseEditionResponse(book))
+}
+
+func (h *workHandlerImpl) UnlinkBook(c echo.Context) error {
+	id, bookId, err := parseWorkBookParams(c)
+	if err != nil {
+		log.Printf("Unable to execute WorkHandlerUnlinkBook: %d\n", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"message": "Invalid work or book ID",
+		})
+	}
+
+	err = h.usecase.UnlinkBook(context.Background(), id, bookId)
+	if errors.Is(err, repository.ErrEditionNotFound) {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"message": "Book is not an edition of the work",
+		})
+	}
+	if err != nil {
+		log.Printf("Unable to execute WorkHandlerUnlinkBook: %d\n", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"message": "Internal server error",
+		})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func parseWorkBookParams(c echo.Context) (int, int, error) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return 0, 0, err
+	}
+	bookId, err := strconv.Atoi(c.Param("book_id"))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return id, bookId, nil
+}
@@ -0,0 +1,179 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/guregu/null"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/handler"
+	"github.com/rentaro-m-b/ai-model-exam/handler/request"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	"github.com/rentaro-m-b/ai-model-exam/usecase"
+	mock_usecase "github.com/rentaro-m-b/ai-model-exam/usecase/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+// newWorkBookContext は作品と書籍のIDをパスパラメータに持つEchoのコンテキストを作成する
+func newWorkBookContext(method string, id int, bookId int) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, "/works/"+strconv.Itoa(id)+"/books/"+strconv.Itoa(bookId), nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id", "book_id")
+	c.SetParamValues(strconv.Itoa(id), strconv.Itoa(bookId))
+
+	return c, rec
+}
+
+func TestCreateWork(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockWorkUsecase(ctrl)
+	paramUc := db.CreateWorkParams{Title: "test work"}
+	mockUc.EXPECT().CreateWork(gomock.Any(), &paramUc).Return(&db.Work{ID: 1, Title: "test work"}, nil)
+
+	// リクエストボディを設定し、Echoのコンテキストを作成
+	c, rec := newCategoryContext(http.MethodPost, "/works", 0, request.CreateWorkRequest{
+		Title: null.NewString("test work", true),
+	})
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewWorkHandler(mockUc)
+	assert.NoError(t, h.CreateWork(c))
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "http://example.com/works/1", rec.Header().Get("Location"))
+	assert.JSONEq(t, `{"id": 1, "title": "test work", "author": null}`, rec.Body.String())
+}
+
+func TestFindWorkById(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockWorkUsecase(ctrl)
+	mockUc.EXPECT().FindWorkById(gomock.Any(), 1).Return(&usecase.WorkDetail{
+		Work: db.Work{ID: 1, Title: "test work", Author: pgtype.Text{String: "test author", Valid: true}},
+		Editions: []db.Book{
+			{
+				ID:       3,
+				Title:    pgtype.Text{String: "test title 1", Valid: true},
+				Edition:  pgtype.Text{String: "2nd", Valid: true},
+				Language: pgtype.Text{String: "en", Valid: true},
+				WorkID:   pgtype.Int4{Int32: 1, Valid: true},
+			},
+		},
+	}, nil)
+
+	// Echoのコンテキストを作成
+	c, rec := newCategoryContext(http.MethodGet, "/works/1", 1, nil)
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewWorkHandler(mockUc)
+	assert.NoError(t, h.FindWorkById(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{
+		"id": 1, "title": "test work", "author": "test author",
+		"editions": [{
+			"id": 3, "title": "test title 1", "author": "", "publisher": "",
+			"subtitle": null, "edition": "2nd", "publication_date": null, "language": "en", "page_count": null,
+			"format": null, "description": null, "series": null, "series_volume": null, "isbn": null
+		}]
+	}`, rec.Body.String())
+}
+
+func TestFindWorkByIdFailureNotFound(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockWorkUsecase(ctrl)
+	mockUc.EXPECT().FindWorkById(gomock.Any(), 99).Return(nil, pgx.ErrNoRows)
+
+	// Echoのコンテキストを作成
+	c, rec := newCategoryContext(http.MethodGet, "/works/99", 99, nil)
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewWorkHandler(mockUc)
+	assert.NoError(t, h.FindWorkById(c))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestLinkBook(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockWorkUsecase(ctrl)
+	mockUc.EXPECT().LinkBook(gomock.Any(), 1, 3).Return(&db.Book{ID: 3, WorkID: pgtype.Int4{Int32: 1, Valid: true}}, nil)
+
+	// Echoのコンテキストを作成
+	c, rec := newWorkBookContext(http.MethodPut, 1, 3)
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewWorkHandler(mockUc)
+	assert.NoError(t, h.LinkBook(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestLinkBookFailureNotFound(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		message string
+	}{
+		{name: "作品が存在しない", err: repository.ErrWorkNotFound, message: "Work not found"},
+		{name: "書籍が存在しない", err: pgx.ErrNoRows, message: "Book not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// モックコントローラを作成
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// ユースケースのモックを作成し、期待値を設定
+			mockUc := mock_usecase.NewMockWorkUsecase(ctrl)
+			mockUc.EXPECT().LinkBook(gomock.Any(), 1, 3).Return(nil, tt.err)
+
+			// Echoのコンテキストを作成
+			c, rec := newWorkBookContext(http.MethodPut, 1, 3)
+
+			// ハンドラを作成し、テスト項目を検証
+			h := handler.NewWorkHandler(mockUc)
+			assert.NoError(t, h.LinkBook(c))
+			assert.Equal(t, http.StatusNotFound, rec.Code)
+			assert.JSONEq(t, `{"message": "`+tt.message+`"}`, rec.Body.String())
+		})
+	}
+}
+
+func TestUnlinkBookFailureNotEdition(t *testing.T) {
+	// モックコントローラを作成
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// ユースケースのモックを作成し、期待値を設定
+	mockUc := mock_usecase.NewMockWorkUsecase(ctrl)
+	mockUc.EXPECT().UnlinkBook(gomock.Any(), 1, 3).Return(repository.ErrEditionNotFound)
+
+	// Echoのコンテキストを作成
+	c, rec := newWorkBookContext(http.MethodDelete, 1, 3)
+
+	// ハンドラを作成し、テスト項目を検証
+	h := handler.NewWorkHandler(mockUc)
+	assert.NoError(t, h.UnlinkBook(c))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
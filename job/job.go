@@ -0,0 +1,83 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// ジョブの状態
+const (
+	StatusQueued    = "queued"
+	StatusRunning   = "running"
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+	StatusCanceled  = "canceled"
+)
+
+// ErrLeaseLost はリースの期限が切れ、ジョブが他のワーカーに取得されたことを表す
+var ErrLeaseLost = errors.New("job lease lost")
+
+// Job はワーカーが実行する1件のジョブ
+type Job struct {
+	ID     int64
+	Kind   string
+	Params json.RawMessage
+	// Attempts は今回を含めた実行の試行回数で、リースを持つワーカーの確認にも使う
+	Attempts int
+	// CancelRequested は実行中のワーカーが止まった後にキャンセルが要求されたことを表す
+	CancelRequested bool
+	// CommittedResult は前回までの試行が、変更と同じトランザクションで記録した結果
+	// 変更はコミット済みのため、実行し直さずにこの結果で終了する
+	CommittedResult json.RawMessage
+}
+
+// Result はジョブの実行結果
+type Result struct {
+	// Data はジョブの結果としてJSONで記録する値
+	Data any
+	// Artifact はジョブが生成したファイルで、nilの場合は保存しない
+	Artifact     []byte
+	ArtifactType string
+}
+
+// Outcome はジョブの終了時に記録する内容
+type Outcome struct {
+	Status       string
+	Result       json.RawMessage
+	ArtifactKey  string
+	ArtifactType string
+	Error        string
+}
+
+// Store は実行待ちのジョブの取得と実行状況の記録を行う
+// 取得したジョブの記録はJob.Attemptsが一致する場合のみ行い、一致しなければErrLeaseLostを返す
+type Store interface {
+	// ClaimJob はkindsのいずれかの種類の実行待ちのジョブを1件取得し、leaseの間は他のワーカーに渡さないようにする
+	// 実行中のままリースの期限が切れたジョブも取得し、実行待ちのジョブが無ければnilを返す
+	// リースの期限は、期限切れの判定と同じくストアの時計で求める
+	ClaimJob(ctx context.Context, kinds []string, lease time.Duration) (*Job, error)
+	// ExtendLease はリースを現在からleaseの間に延長し、キャンセルが要求されていれば真を返す
+	ExtendLease(ctx context.Context, job *Job, lease time.Duration) (bool, error)
+	// ReportProgress は処理済みの件数と全体の件数を記録し、キャンセルが要求されていれば真を返す
+	ReportProgress(ctx context.Context, job *Job, done int, total int) (bool, error)
+	Finish(ctx context.Context, job *Job, outcome *Outcome) error
+}
+
+// Progress はジョブの進捗を記録する
+type Progress interface {
+	// Report は処理済みの件数と全体の件数を記録する
+	// キャンセルが要求されていれば、ジョブに渡したctxを終了させる
+	Report(ctx context.Context, done int, total int)
+}
+
+// Handler は種類ごとのジョブを実行する
+// ctxの終了（キャンセルの要求を含む）を受けて、処理を中断してctxのエラーを返す
+type Handler func(ctx context.Context, job *Job, progress Progress) (*Result, error)
+
+// ArtifactKey はジョブが生成したファイルの保存先のキー
+func ArtifactKey(id int64) string {
+	return "jobs/" + strconv.FormatInt(id, 10)
+}
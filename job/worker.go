@@ -0,0 +1,257 @@
+package job
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rentaro-m-b/ai-model-exam/storage"
+)
+
+const (
+	defaultConcurrency  = 1
+	defaultPollInterval = time.Second
+	defaultLease        = time.Minute
+	defaultMaxAttempts  = 3
+)
+
+// WorkerConfig の未指定（ゼロ値）の項目には既定値を使う
+type WorkerConfig struct {
+	// Concurrency は1つのワーカーで同時に実行するジョブの数
+	Concurrency int
+	// PollInterval は実行待ちのジョブが無い場合に次の取得まで待つ時間
+	PollInterval time.Duration
+	// Lease は取得したジョブを他のワーカーに渡さない時間で、実行中はLeaseの3分の1ごとに延長する
+	Lease time.Duration
+	// MaxAttempts は実行中にワーカーが止まったジョブを、失敗とするまでに実行を試みる回数
+	MaxAttempts int
+}
+
+// Worker は実行待ちのジョブを取得し、種類ごとのHandlerで実行する
+// 複数のプロセスで動かしても、1件のジョブを同時に実行するのは1つのワーカーのみ
+type Worker struct {
+	store     Store
+	handlers  map[string]Handler
+	kinds     []string
+	artifacts storage.BlobStorage
+	config    WorkerConfig
+}
+
+func NewWorker(store Store, handlers map[string]Handler, artifacts storage.BlobStorage, config *WorkerConfig) *Worker {
+	c := WorkerConfig{}
+	if config != nil {
+		c = *config
+	}
+	if c.Concurrency <= 0 {
+		c.Concurrency = defaultConcurrency
+	}
+	if c.PollInterval <= 0 {
+		c.PollInterval = defaultPollInterval
+	}
+	if c.Lease <= 0 {
+		c.Lease = defaultLease
+	}
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = defaultMaxAttempts
+	}
+
+	kinds := make([]string, 0, len(handlers))
+	for kind := range handlers {
+		kinds = append(kinds, kind)
+	}
+	slices.Sort(kinds)
+
+	return &Worker{
+		store:     store,
+		handlers:  handlers,
+		kinds:     kinds,
+		artifacts: artifacts,
+		config:    c,
+	}
+}
+
+// Run はctxが終了するまで、最大Concurrency件のジョブを並行して実行し続ける
+func (w *Worker) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for range w.config.Concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.poll(ctx)
+		}()
+	}
+	wg.Wait()
+}
+
+// poll はctxが終了するまで、ジョブを1件ずつ取得して実行する
+func (w *Worker) poll(ctx context.Context) {
+	for {
+		claimed, err := w.RunOnce(ctx)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			log.Printf("Unable to execute WorkerRun: %d\n", err)
+		}
+		if err == nil && claimed {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(w.config.PollInterval):
+		}
+	}
+}
+
+// RunOnce は実行待ちのジョブを1件取得して実行し、ジョブを取得したかを返す
+func (w *Worker) RunOnce(ctx context.Context) (bool, error) {
+	job, err := w.store.ClaimJob(ctx, w.kinds, w.config.Lease)
+	if err != nil || job == nil {
+		return false, err
+	}
+
+	outcome, err := w.execute(ctx, job)
+	if err != nil {
+		return true, err
+	}
+
+	// 停止の途中で終了したジョブも結果を記録できるよう、ctxの終了を引き継がない
+	return true, w.store.Finish(context.WithoutCancel(ctx), job, outcome)
+}
+
+// execute はジョブを実行し、終了時に記録する内容を返す
+// 停止によりctxが終了した場合やリースを失った場合はエラーを返し、リースの期限が切れた後に他のワーカーが実行し直す
+func (w *Worker) execute(ctx context.Context, job *Job) (*Outcome, error) {
+	if job.CommittedResult != nil {
+		return &Outcome{Status: StatusSucceeded, Result: job.CommittedResult}, nil
+	}
+	if job.CancelRequested {
+		return &Outcome{Status: StatusCanceled}, nil
+	}
+	if job.Attempts > w.config.MaxAttempts {
+		return &Outcome{Status: StatusFailed, Error: "job was abandoned too many times"}, nil
+	}
+
+	jobCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	r := &run{store: w.store, job: job, cancel: cancel}
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		w.extendLease(ctx, r, stop)
+	}()
+	result, err := w.handle(jobCtx, job, r)
+	close(stop)
+	wg.Wait()
+
+	switch {
+	case r.lost.Load() || errors.Is(err, ErrLeaseLost):
+		return nil, ErrLeaseLost
+	case err != nil && r.canceled.Load():
+		return &Outcome{Status: StatusCanceled}, nil
+	case err != nil && ctx.Err() != nil:
+		return nil, ctx.Err()
+	case err != nil:
+		log.Printf("Unable to run job %d: %d\n", job.ID, err)
+		return &Outcome{Status: StatusFailed, Error: err.Error()}, nil
+	}
+
+	return w.succeed(ctx, job, result), nil
+}
+
+// handle はジョブの種類のHandlerを呼び出し、パニックはエラーとして返す
+func (w *Worker) handle(ctx context.Context, job *Job, progress Progress) (result *Result, err error) {
+	handler, ok := w.handlers[job.Kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown job kind %q", job.Kind)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("job panicked: %v", p)
+		}
+	}()
+
+	return handler(ctx, job, progress)
+}
+
+// succeed は実行結果をJSONに変換し、生成したファイルを保存する
+// 保存できなかった場合は失敗として記録する
+func (w *Worker) succeed(ctx context.Context, job *Job, result *Result) *Outcome {
+	outcome := &Outcome{Status: StatusSucceeded}
+	if result == nil {
+		return outcome
+	}
+
+	if result.Data != nil {
+		b, err := json.Marshal(result.Data)
+		if err != nil {
+			log.Printf("Unable to run job %d: %d\n", job.ID, err)
+			return &Outcome{Status: StatusFailed, Error: err.Error()}
+		}
+		outcome.Result = b
+	}
+	if result.Artifact != nil {
+		key := ArtifactKey(job.ID)
+		if err := w.artifacts.Put(ctx, key, bytes.NewReader(result.Artifact)); err != nil {
+			log.Printf("Unable to run job %d: %d\n", job.ID, err)
+			return &Outcome{Status: StatusFailed, Error: "unable to save artifact"}
+		}
+		outcome.ArtifactKey = key
+		outcome.ArtifactType = result.ArtifactType
+	}
+
+	return outcome
+}
+
+// extendLease はstopが閉じられるまでリースを延長し続ける
+func (w *Worker) extendLease(ctx context.Context, r *run, stop <-chan struct{}) {
+	ticker := time.NewTicker(w.config.Lease / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.observe(w.store.ExtendLease(ctx, r.job, w.config.Lease))
+		}
+	}
+}
+
+// run は実行中のジョブの状態で、Handlerに渡すProgressを実装する
+type run struct {
+	store  Store
+	job    *Job
+	cancel context.CancelFunc
+	// canceled はキャンセルが要求されたこと、lost はリースを失ったことを表す
+	canceled atomic.Bool
+	lost     atomic.Bool
+}
+
+func (r *run) Report(ctx context.Context, done int, total int) {
+	r.observe(r.store.ReportProgress(ctx, r.job, done, total))
+}
+
+// observe はキャンセルが要求された場合やリースを失った場合に、ジョブに渡したctxを終了させる
+// 記録の一時的な失敗ではジョブを中断しない
+func (r *run) observe(cancelRequested bool, err error) {
+	switch {
+	case errors.Is(err, ErrLeaseLost):
+		r.lost.Store(true)
+		r.cancel()
+	case err != nil:
+		log.Printf("Unable to record progress of job %d: %d\n", r.job.ID, err)
+	case cancelRequested:
+		r.canceled.Store(true)
+		r.cancel()
+	}
+}
@@ -0,0 +1,305 @@
+package job_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rentaro-m-b/ai-model-exam/job"
+	"github.com/rentaro-m-b/ai-model-exam/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+// progress は記録されたジョブの進捗
+type progress struct {
+	done  int
+	total int
+}
+
+// memoryStore はジョブをメモリ上に保持するjob.Storeの実装
+type memoryStore struct {
+	mu       sync.Mutex
+	pending  []job.Job
+	progress map[int64]progress
+	finished map[int64]job.Outcome
+	// cancelRequested はキャンセルが要求されたジョブ、lost はリースを失ったジョブ
+	cancelRequested map[int64]bool
+	lost            map[int64]bool
+}
+
+func newMemoryStore(jobs ...job.Job) *memoryStore {
+	return &memoryStore{
+		pending:         jobs,
+		progress:        map[int64]progress{},
+		finished:        map[int64]job.Outcome{},
+		cancelRequested: map[int64]bool{},
+		lost:            map[int64]bool{},
+	}
+}
+
+func (s *memoryStore) ClaimJob(ctx context.Context, kinds []string, lease time.Duration) (*job.Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.pending) == 0 {
+		return nil, nil
+	}
+	claimed := s.pending[0]
+	s.pending = s.pending[1:]
+	claimed.Attempts++
+
+	return &claimed, nil
+}
+
+func (s *memoryStore) ExtendLease(ctx context.Context, j *job.Job, lease time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.lost[j.ID] {
+		return false, job.ErrLeaseLost
+	}
+
+	return s.cancelRequested[j.ID], nil
+}
+
+func (s *memoryStore) ReportProgress(ctx context.Context, j *job.Job, done int, total int) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.lost[j.ID] {
+		return false, job.ErrLeaseLost
+	}
+	s.progress[j.ID] = progress{done: done, total: total}
+
+	return s.cancelRequested[j.ID], nil
+}
+
+func (s *memoryStore) Finish(ctx context.Context, j *job.Job, outcome *job.Outcome) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.finished[j.ID] = *outcome
+	return nil
+}
+
+func TestRunOnce(t *testing.T) {
+	store := newMemoryStore(job.Job{ID: 1, Kind: "export", Params: []byte(`{"format":"csv"}`)})
+	artifacts, err := storage.NewLocalStorage(t.TempDir())
+	assert.NoError(t, err)
+	handlers := map[string]job.Handler{
+		"export": func(ctx context.Context, j *job.Job, p job.Progress) (*job.Result, error) {
+			assert.JSONEq(t, `{"format":"csv"}`, string(j.Params))
+			assert.Equal(t, 1, j.Attempts)
+			p.Report(ctx, 2, 2)
+			return &job.Result{
+				Data:         map[string]int{"exported": 2},
+				Artifact:     []byte("id\n1\n2\n"),
+				ArtifactType: "text/csv",
+			}, nil
+		},
+	}
+	w := job.NewWorker(store, handlers, artifacts, nil)
+
+	claimed, err := w.RunOnce(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, claimed)
+	assert.Equal(t, progress{done: 2, total: 2}, store.progress[1])
+	outcome := store.finished[1]
+	assert.Equal(t, job.StatusSucceeded, outcome.Status)
+	assert.JSONEq(t, `{"exported":2}`, string(outcome.Result))
+	assert.Equal(t, "jobs/1", outcome.ArtifactKey)
+	assert.Equal(t, "text/csv", outcome.ArtifactType)
+
+	rc, err := artifacts.Get(context.Background(), outcome.ArtifactKey)
+	assert.NoError(t, err)
+	defer rc.Close()
+	b, err := io.ReadAll(rc)
+	assert.NoError(t, err)
+	assert.Equal(t, "id\n1\n2\n", string(b))
+}
+
+func TestRunOnceCommittedResult(t *testing.T) {
+	// 前回の試行が変更をコミットした後に止まったジョブは、実行し直さずに記録済みの結果で終了する
+	store := newMemoryStore(job.Job{ID: 1, Kind: "bulk", Attempts: 3, CancelRequested: true, CommittedResult: []byte(`{"affected":2}`)})
+	handlers := map[string]job.Handler{
+		"bulk": func(ctx context.Context, j *job.Job, p job.Progress) (*job.Result, error) {
+			t.Error("job was run again")
+			return nil, nil
+		},
+	}
+	w := job.NewWorker(store, handlers, nil, nil)
+
+	claimed, err := w.RunOnce(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, claimed)
+	outcome := store.finished[1]
+	assert.Equal(t, job.StatusSucceeded, outcome.Status)
+	assert.JSONEq(t, `{"affected":2}`, string(outcome.Result))
+}
+
+func TestRunOnceNoJob(t *testing.T) {
+	w := job.NewWorker(newMemoryStore(), map[string]job.Handler{}, nil, nil)
+
+	claimed, err := w.RunOnce(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, claimed)
+}
+
+func TestRunOnceFailure(t *testing.T) {
+	store := newMemoryStore(
+		job.Job{ID: 1, Kind: "fail"},
+		job.Job{ID: 2, Kind: "panic"},
+		job.Job{ID: 3, Kind: "unknown"},
+	)
+	handlers := map[string]job.Handler{
+		"fail": func(ctx context.Context, j *job.Job, p job.Progress) (*job.Result, error) {
+			return nil, errors.New("error")
+		},
+		"panic": func(ctx context.Context, j *job.Job, p job.Progress) (*job.Result, error) {
+			panic("boom")
+		},
+	}
+	w := job.NewWorker(store, handlers, nil, nil)
+
+	// 失敗したジョブは再実行せずに、原因を記録する
+	for range 3 {
+		_, err := w.RunOnce(context.Background())
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, job.Outcome{Status: job.StatusFailed, Error: "error"}, store.finished[1])
+	assert.Equal(t, job.Outcome{Status: job.StatusFailed, Error: "job panicked: boom"}, store.finished[2])
+	assert.Equal(t, job.Outcome{Status: job.StatusFailed, Error: `unknown job kind "unknown"`}, store.finished[3])
+}
+
+func TestRunOnceCancel(t *testing.T) {
+	store := newMemoryStore(job.Job{ID: 1, Kind: "import"})
+	store.cancelRequested[1] = true
+	handlers := map[string]job.Handler{
+		"import": func(ctx context.Context, j *job.Job, p job.Progress) (*job.Result, error) {
+			// 進捗の記録でキャンセルの要求を受け、ctxが終了する
+			p.Report(ctx, 1, 10)
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	}
+	w := job.NewWorker(store, handlers, nil, nil)
+
+	_, err := w.RunOnce(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, job.Outcome{Status: job.StatusCanceled}, store.finished[1])
+}
+
+func TestRunOnceCancelByLease(t *testing.T) {
+	store := newMemoryStore(job.Job{ID: 1, Kind: "import"})
+	store.cancelRequested[1] = true
+	handlers := map[string]job.Handler{
+		"import": func(ctx context.Context, j *job.Job, p job.Progress) (*job.Result, error) {
+			// 進捗を記録しないジョブも、リースの延長でキャンセルの要求を受ける
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	}
+	w := job.NewWorker(store, handlers, nil, &job.WorkerConfig{Lease: 30 * time.Millisecond})
+
+	_, err := w.RunOnce(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, job.Outcome{Status: job.StatusCanceled}, store.finished[1])
+}
+
+func TestRunOnceCancelRequestedBeforeRun(t *testing.T) {
+	store := newMemoryStore(job.Job{ID: 1, Kind: "import", CancelRequested: true})
+	handlers := map[string]job.Handler{
+		"import": func(ctx context.Context, j *job.Job, p job.Progress) (*job.Result, error) {
+			t.Error("canceled job must not run")
+			return nil, nil
+		},
+	}
+	w := job.NewWorker(store, handlers, nil, nil)
+
+	_, err := w.RunOnce(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, job.Outcome{Status: job.StatusCanceled}, store.finished[1])
+}
+
+func TestRunOnceAbandoned(t *testing.T) {
+	// 実行中にワーカーが止まり、MaxAttempts回取得されたジョブは失敗とする
+	store := newMemoryStore(job.Job{ID: 1, Kind: "import", Attempts: 2})
+	handlers := map[string]job.Handler{
+		"import": func(ctx context.Context, j *job.Job, p job.Progress) (*job.Result, error) {
+			t.Error("abandoned job must not run")
+			return nil, nil
+		},
+	}
+	w := job.NewWorker(store, handlers, nil, &job.WorkerConfig{MaxAttempts: 2})
+
+	_, err := w.RunOnce(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, job.StatusFailed, store.finished[1].Status)
+}
+
+func TestRunOnceLeaseLost(t *testing.T) {
+	store := newMemoryStore(job.Job{ID: 1, Kind: "import"})
+	store.lost[1] = true
+	handlers := map[string]job.Handler{
+		"import": func(ctx context.Context, j *job.Job, p job.Progress) (*job.Result, error) {
+			p.Report(ctx, 1, 10)
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	}
+	w := job.NewWorker(store, handlers, nil, nil)
+
+	// リースを失ったジョブは、取得した他のワーカーに任せて記録しない
+	_, err := w.RunOnce(context.Background())
+	assert.ErrorIs(t, err, job.ErrLeaseLost)
+	assert.Empty(t, store.finished)
+}
+
+func TestRunOnceShutdown(t *testing.T) {
+	store := newMemoryStore(job.Job{ID: 1, Kind: "import"})
+	ctx, cancel := context.WithCancel(context.Background())
+	handlers := map[string]job.Handler{
+		"import": func(ctx context.Context, j *job.Job, p job.Progress) (*job.Result, error) {
+			cancel()
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	}
+	w := job.NewWorker(store, handlers, nil, nil)
+
+	// 停止により中断したジョブは記録せず、リースの期限が切れた後に実行し直す
+	_, err := w.RunOnce(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, store.finished)
+}
+
+func TestRunConcurrency(t *testing.T) {
+	store := newMemoryStore(job.Job{ID: 1, Kind: "wait"}, job.Job{ID: 2, Kind: "wait"})
+	// 2件のジョブが同時に実行されていなければ、いずれも終了しない
+	var started sync.WaitGroup
+	started.Add(2)
+	handlers := map[string]job.Handler{
+		"wait": func(ctx context.Context, j *job.Job, p job.Progress) (*job.Result, error) {
+			started.Done()
+			started.Wait()
+			return nil, nil
+		},
+	}
+	w := job.NewWorker(store, handlers, nil, &job.WorkerConfig{Concurrency: 2, PollInterval: 10 * time.Millisecond})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		w.Run(ctx)
+		close(done)
+	}()
+	assert.Eventually(t, func() bool {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		return len(store.finished) == 2
+	}, time.Second, 10*time.Millisecond)
+	cancel()
+	<-done
+
+	assert.Equal(t, job.StatusSucceeded, store.finished[1].Status)
+	assert.Equal(t, job.StatusSucceeded, store.finished[2].Status)
+}
@@ -2,15 +2,93 @@ package main
 
 import (
 	"context"
+	"expvar"
 	"log"
+	"net"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/labstack/echo/v4"
+	"github.com/redis/go-redis/v9"
+	"github.com/rentaro-m-b/ai-model-exam/cache"
 	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/event"
+	"github.com/rentaro-m-b/ai-model-exam/job"
+	"github.com/rentaro-m-b/ai-model-exam/money"
+	"github.com/rentaro-m-b/ai-model-exam/ratelimit"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
 	"github.com/rentaro-m-b/ai-model-exam/routes"
+	"github.com/rentaro-m-b/ai-model-exam/storage"
+	"github.com/rentaro-m-b/ai-model-exam/usecase"
+	"github.com/rentaro-m-b/ai-model-exam/webhook"
 )
 
+const (
+	// defaultBookCacheSize はプロセス内にキャッシュする書籍の既定の件数
+	defaultBookCacheSize = 1000
+	// defaultBookWriteLimit と defaultAdminLimit はクライアントごとの流量の既定の上限
+	defaultBookWriteLimit = "60/1m"
+	defaultAdminLimit     = "10/1m"
+	// idempotencyKeyPurgeInterval は有効期限を過ぎた冪等キーを削除する間隔
+	idempotencyKeyPurgeInterval = time.Hour
+	// defaultJobWorkerConcurrency はジョブを同時に実行する既定の数
+	defaultJobWorkerConcurrency = 2
+	// redisKeyPrefix は同じRedisを使う他のアプリケーションとキーが重ならないよう、全てのキーの先頭に付ける
+	redisKeyPrefix = "ai-model-exam:"
+)
+
+// parseRateLimit は環境変数nameに "30/1m" の形式で指定された流量の上限を読み込み、未指定の場合はfallbackを使う
+func parseRateLimit(name string, fallback string) ratelimit.Limit {
+	v := os.Getenv(name)
+	if v == "" {
+		v = fallback
+	}
+	limit, err := ratelimit.ParseLimit(v)
+	if err != nil {
+		log.Fatalf("Invalid %s: %v\n", name, err)
+	}
+
+	return limit
+}
+
+// parseIPExtractor は環境変数TRUSTED_PROXIESにカンマ区切りで指定された信頼するプロキシのCIDRから、クライアントのIPアドレスの取得方法を返す
+// 未指定の場合はクライアントが偽装できるX-Forwarded-Forなどのヘッダを使わず、接続元のIPアドレスを使う
+func parseIPExtractor() echo.IPExtractor {
+	v := os.Getenv("TRUSTED_PROXIES")
+	if v == "" {
+		return echo.ExtractIPDirect()
+	}
+
+	options := []echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+	for _, cidr := range strings.Split(v, ",") {
+		_, ipRange, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err != nil {
+			log.Fatalf("Invalid TRUSTED_PROXIES: %v\n", err)
+		}
+		options = append(options, echo.TrustIPRange(ipRange))
+	}
+
+	return echo.ExtractIPFromXFFHeader(options...)
+}
+
+// purgeExpiredIdempotencyKeys はctxが終了するまで、有効期限を過ぎた冪等キーを定期的に削除する
+func purgeExpiredIdempotencyKeys(ctx context.Context, bookUsecase usecase.BookUsecase) {
+	ticker := time.NewTicker(idempotencyKeyPurgeInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// 失敗した場合は次の間隔で削除し直す
+			bookUsecase.PurgeExpiredIdempotencyKeys(ctx)
+		}
+	}
+}
+
 func main() {
 	file, err := os.Create("app.log")
 	if err != nil {
@@ -30,10 +108,142 @@ func main() {
 		log.Fatalf("Unable to connect to database: %v\n", err)
 	}
 	defer pool.Close()
-	db := db.New(pool)
+
+	priceRounding, err := money.ParseRoundingMode(os.Getenv("PRICE_ROUNDING_MODE"))
+	if err != nil {
+		log.Fatalf("Invalid PRICE_ROUNDING_MODE: %v\n", err)
+	}
+
+	coverStorageDir := os.Getenv("COVER_STORAGE_DIR")
+	if coverStorageDir == "" {
+		coverStorageDir = "covers"
+	}
+	coverStorage, err := storage.NewLocalStorage(coverStorageDir)
+	if err != nil {
+		log.Fatalf("Unable to prepare cover storage: %v\n", err)
+	}
+
+	jobArtifactDir := os.Getenv("JOB_ARTIFACT_DIR")
+	if jobArtifactDir == "" {
+		jobArtifactDir = "job-artifacts"
+	}
+	jobArtifactStorage, err := storage.NewLocalStorage(jobArtifactDir)
+	if err != nil {
+		log.Fatalf("Unable to prepare job artifact storage: %v\n", err)
+	}
+	jobWorkerConcurrency := defaultJobWorkerConcurrency
+	if v := os.Getenv("JOB_WORKER_CONCURRENCY"); v != "" {
+		jobWorkerConcurrency, err = strconv.Atoi(v)
+		if err != nil {
+			log.Fatalf("Invalid JOB_WORKER_CONCURRENCY: %v\n", err)
+		}
+	}
+
+	// REDIS_URLが指定されていれば、書籍のキャッシュと流量の制限を複数のサーバで共有する
+	var redisClient *redis.Client
+	if redisURL := os.Getenv("REDIS_URL"); redisURL != "" {
+		opts, err := redis.ParseURL(redisURL)
+		if err != nil {
+			log.Fatalf("Invalid REDIS_URL: %v\n", err)
+		}
+		redisClient = redis.NewClient(opts)
+		defer redisClient.Close()
+	}
+
+	bookCacheTTL := time.Duration(0)
+	if v := os.Getenv("BOOK_CACHE_TTL"); v != "" {
+		bookCacheTTL, err = time.ParseDuration(v)
+		if err != nil {
+			log.Fatalf("Invalid BOOK_CACHE_TTL: %v\n", err)
+		}
+	}
+	bookCacheSize := defaultBookCacheSize
+	if v := os.Getenv("BOOK_CACHE_SIZE"); v != "" {
+		bookCacheSize, err = strconv.Atoi(v)
+		if err != nil {
+			log.Fatalf("Invalid BOOK_CACHE_SIZE: %v\n", err)
+		}
+	}
+	bookCache := cache.NewLRU(bookCacheSize)
+	if redisClient != nil {
+		bookCache = cache.NewRedis(redisClient, redisKeyPrefix)
+	}
+	bookCacheMetrics := &cache.Metrics{}
+	expvar.Publish("book_cache", expvar.Func(bookCacheMetrics.Snapshot))
+
+	rateLimitStore := ratelimit.NewMemoryStore()
+	if redisClient != nil {
+		rateLimitStore = ratelimit.NewRedisStore(redisClient, redisKeyPrefix+"ratelimit:")
+	}
+	bookWriteLimit := parseRateLimit("RATE_LIMIT_BOOK_WRITES", defaultBookWriteLimit)
+	adminLimit := parseRateLimit("RATE_LIMIT_ADMIN", defaultAdminLimit)
+
+	// 書籍の変更イベントをアウトボックスから配信し、購読されたWebhookへ送信する
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	queries := db.New(pool)
+	outboxRepository := repository.NewOutboxRepository(queries)
+	webhookRepository := repository.NewWebhookRepository(queries)
+	sinks := []event.Sink{event.LogSink{}, webhook.NewSink(webhookRepository)}
+	dispatcher := event.NewDispatcher(outboxRepository, sinks, nil)
+	go dispatcher.Run(ctx)
+	go webhook.NewDeliverer(webhookRepository, nil).Run(ctx)
+
+	// 有効期限を過ぎた冪等キーを定期的に削除する
+	bookUsecaseConfig := usecase.BookUsecaseConfig{}
+	if v := os.Getenv("IDEMPOTENCY_KEY_TTL"); v != "" {
+		bookUsecaseConfig.IdempotencyKeyTTL, err = time.ParseDuration(v)
+		if err != nil {
+			log.Fatalf("Invalid IDEMPOTENCY_KEY_TTL: %v\n", err)
+		}
+	}
+	if v := os.Getenv("BOOK_BATCH_MAX_SIZE"); v != "" {
+		bookUsecaseConfig.MaxBatchSize, err = strconv.Atoi(v)
+		if err != nil {
+			log.Fatalf("Invalid BOOK_BATCH_MAX_SIZE: %v\n", err)
+		}
+	}
+	// ジョブの書籍の変更がAPIのキャッシュに残らないよう、APIと同じリポジトリでキャッシュを通す
+	bookRepository := repository.NewCachedBookRepository(repository.NewBookRepository(queries, pool), bookCache, &repository.BookCacheConfig{
+		TTL:     bookCacheTTL,
+		Metrics: bookCacheMetrics,
+	})
+	bookUsecase := usecase.NewBookUsecase(bookRepository, &bookUsecaseConfig)
+	go purgeExpiredIdempotencyKeys(ctx, bookUsecase)
+
+	// 実行待ちのジョブをJOB_WORKER_CONCURRENCY件まで同時に実行する
+	jobHandlers := usecase.NewJobHandlers(bookUsecase, usecase.NewExchangeRateUsecase(repository.NewExchangeRateRepository(queries, pool)))
+	jobWorker := job.NewWorker(repository.NewJobRepository(queries), jobHandlers, jobArtifactStorage, &job.WorkerConfig{Concurrency: jobWorkerConcurrency})
+	go jobWorker.Run(ctx)
+
+	// 登録の通知を受けたイベントをServer-Sent Eventsの接続へ配信する
+	bookEvents := event.NewBroker(0)
+	go event.NewRelay(outboxRepository, repository.NewOutboxNotifier(pool), bookEvents, nil).Run(ctx)
+
+	// 返却された書籍を予約者のために取り置く期間は、貸出と予約で同じ期間とする
+	var holdPeriod time.Duration
+	if v := os.Getenv("RESERVATION_HOLD_PERIOD"); v != "" {
+		holdPeriod, err = time.ParseDuration(v)
+		if err != nil {
+			log.Fatalf("Invalid RESERVATION_HOLD_PERIOD: %v\n", err)
+		}
+	}
 
 	e := echo.New()
-	routes.Init(e, db)
+	e.IPExtractor = parseIPExtractor()
+	routes.Init(e, pool, &routes.Config{
+		PriceRounding:            priceRounding,
+		CoverStorage:             coverStorage,
+		BookEvents:               bookEvents,
+		BookRepository:           bookRepository,
+		RateLimitStore:           rateLimitStore,
+		BookWriteLimit:           bookWriteLimit,
+		AdminLimit:               adminLimit,
+		BookUsecaseConfig:        bookUsecaseConfig,
+		LoanUsecaseConfig:        usecase.LoanUsecaseConfig{HoldPeriod: holdPeriod},
+		ReservationUsecaseConfig: usecase.ReservationUsecaseConfig{HoldPeriod: holdPeriod},
+		JobArtifactStorage:       jobArtifactStorage,
+	})
 
 	// サーバー開始
 	e.Logger.Fatal(e.Start(":8080"))
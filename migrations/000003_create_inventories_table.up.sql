@@ -0,0 +1,18 @@
+CREATE TABLE inventories (
+    book_id integer NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    location varchar(100) NOT NULL,
+    quantity integer NOT NULL DEFAULT 0 CHECK (quantity >= 0),
+    PRIMARY KEY (book_id, location)
+);
+
+CREATE TABLE stock_movements (
+    id serial PRIMARY KEY,
+    book_id integer NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    location varchar(100) NOT NULL,
+    quantity_change integer NOT NULL,
+    quantity_after integer NOT NULL,
+    reason varchar(20) NOT NULL CHECK (reason IN ('received', 'sold', 'damaged', 'returned')),
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX stock_movements_book_id_idx ON stock_movements (book_id);
@@ -0,0 +1,18 @@
+CREATE TABLE members (
+    id serial PRIMARY KEY,
+    name varchar(100) NOT NULL,
+    email varchar(255) NOT NULL UNIQUE,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE TABLE loans (
+    id serial PRIMARY KEY,
+    book_id integer NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    member_id integer NOT NULL REFERENCES members (id) ON DELETE CASCADE,
+    loaned_at timestamp with time zone NOT NULL DEFAULT now(),
+    due_at timestamp with time zone NOT NULL,
+    returned_at timestamp with time zone
+);
+
+CREATE INDEX loans_active_book_id_idx ON loans (book_id) WHERE returned_at IS NULL;
+CREATE INDEX loans_active_member_id_idx ON loans (member_id) WHERE returned_at IS NULL;
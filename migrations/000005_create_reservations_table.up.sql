@@ -0,0 +1,11 @@
+CREATE TABLE reservations (
+    id serial PRIMARY KEY,
+    book_id integer NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    member_id integer NOT NULL REFERENCES members (id) ON DELETE CASCADE,
+    status varchar(20) NOT NULL DEFAULT 'waiting' CHECK (status IN ('waiting', 'held', 'fulfilled', 'expired')),
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    held_at timestamp with time zone,
+    hold_expires_at timestamp with time zone
+);
+
+CREATE UNIQUE INDEX reservations_active_book_id_member_id_idx ON reservations (book_id, member_id) WHERE status IN ('waiting', 'held');
@@ -0,0 +1,17 @@
+CREATE TABLE book_prices (
+    id serial PRIMARY KEY,
+    book_id integer NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    currency char(3) NOT NULL CHECK (currency ~ '^[A-Z]{3}$'),
+    amount bigint NOT NULL CHECK (amount >= 0),
+    effective_from timestamp with time zone NOT NULL,
+    effective_to timestamp with time zone,
+    CHECK (effective_to IS NULL OR effective_to > effective_from),
+    UNIQUE (book_id, effective_from)
+);
+
+-- 既存の価格は円建てとして、マイグレーション時点から有効な価格に移行する
+INSERT INTO book_prices (book_id, currency, amount, effective_from)
+    SELECT id, 'JPY', price, now()
+        FROM books
+        WHERE price IS NOT NULL
+;
@@ -0,0 +1,9 @@
+CREATE TABLE exchange_rates (
+    id serial PRIMARY KEY,
+    base_currency char(3) NOT NULL CHECK (base_currency ~ '^[A-Z]{3}$'),
+    quote_currency char(3) NOT NULL CHECK (quote_currency ~ '^[A-Z]{3}$'),
+    rate numeric(20, 10) NOT NULL CHECK (rate > 0),
+    effective_date date NOT NULL,
+    imported_at timestamp with time zone NOT NULL DEFAULT now(),
+    UNIQUE (base_currency, quote_currency, effective_date)
+);
@@ -0,0 +1,23 @@
+CREATE TABLE promotions (
+    id serial PRIMARY KEY,
+    name varchar(255) NOT NULL,
+    discount_type varchar(20) NOT NULL CHECK (discount_type IN ('percentage', 'fixed_amount')),
+    discount_value bigint NOT NULL CHECK (discount_value > 0),
+    currency char(3),
+    target_type varchar(20) NOT NULL CHECK (target_type IN ('book', 'author', 'publisher')),
+    target_book_id integer REFERENCES books (id) ON DELETE CASCADE,
+    target_name varchar(255),
+    starts_at timestamp with time zone NOT NULL,
+    ends_at timestamp with time zone,
+    priority integer NOT NULL DEFAULT 0,
+    stackable boolean NOT NULL DEFAULT false,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    CHECK (discount_type <> 'percentage' OR discount_value <= 100),
+    CHECK (discount_type <> 'fixed_amount' OR currency IS NOT NULL),
+    CHECK ((target_type = 'book') = (target_book_id IS NOT NULL)),
+    CHECK ((target_type = 'book') = (target_name IS NULL)),
+    CHECK (ends_at IS NULL OR ends_at > starts_at)
+);
+
+CREATE INDEX promotions_target_book_id_idx ON promotions (target_book_id);
+CREATE INDEX promotions_target_type_target_name_idx ON promotions (target_type, target_name);
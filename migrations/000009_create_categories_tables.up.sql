@@ -0,0 +1,16 @@
+CREATE TABLE categories (
+    id serial PRIMARY KEY,
+    name varchar(100) NOT NULL,
+    parent_id integer REFERENCES categories (id),
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    CHECK (parent_id <> id),
+    UNIQUE NULLS NOT DISTINCT (parent_id, name)
+);
+
+CREATE TABLE book_categories (
+    book_id integer NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    category_id integer NOT NULL REFERENCES categories (id) ON DELETE CASCADE,
+    PRIMARY KEY (book_id, category_id)
+);
+
+CREATE INDEX book_categories_category_id_idx ON book_categories (category_id);
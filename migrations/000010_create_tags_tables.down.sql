@@ -0,0 +1,2 @@
+DROP TABLE book_tags;
+DROP TABLE tags;
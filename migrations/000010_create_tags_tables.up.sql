@@ -0,0 +1,16 @@
+CREATE TABLE tags (
+    id serial PRIMARY KEY,
+    name varchar(50) NOT NULL UNIQUE,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX tags_name_pattern_idx ON tags (name text_pattern_ops);
+
+CREATE TABLE book_tags (
+    book_id integer NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    tag_id integer NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (book_id, tag_id)
+);
+
+CREATE INDEX book_tags_tag_id_idx ON book_tags (tag_id);
@@ -0,0 +1,2 @@
+DROP TABLE book_ratings;
+DROP TABLE reviews;
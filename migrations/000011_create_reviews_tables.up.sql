@@ -0,0 +1,18 @@
+CREATE TABLE reviews (
+    id serial PRIMARY KEY,
+    book_id integer NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    member_id integer NOT NULL REFERENCES members (id) ON DELETE CASCADE,
+    rating smallint NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    body text NOT NULL DEFAULT '',
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now(),
+    UNIQUE (book_id, member_id)
+);
+
+CREATE INDEX reviews_member_id_idx ON reviews (member_id);
+
+CREATE TABLE book_ratings (
+    book_id integer PRIMARY KEY REFERENCES books (id) ON DELETE CASCADE,
+    review_count integer NOT NULL DEFAULT 0 CHECK (review_count >= 0),
+    rating_sum integer NOT NULL DEFAULT 0 CHECK (rating_sum >= 0)
+);
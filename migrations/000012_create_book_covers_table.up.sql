@@ -0,0 +1,9 @@
+CREATE TABLE book_covers (
+    book_id integer PRIMARY KEY REFERENCES books (id) ON DELETE CASCADE,
+    content_type varchar(50) NOT NULL,
+    width integer NOT NULL CHECK (width > 0),
+    height integer NOT NULL CHECK (height > 0),
+    byte_size integer NOT NULL CHECK (byte_size > 0),
+    checksum char(64) NOT NULL,
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
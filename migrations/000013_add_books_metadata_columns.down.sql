@@ -0,0 +1,10 @@
+ALTER TABLE books
+    DROP COLUMN subtitle,
+    DROP COLUMN edition,
+    DROP COLUMN publication_date,
+    DROP COLUMN language,
+    DROP COLUMN page_count,
+    DROP COLUMN format,
+    DROP COLUMN description,
+    DROP COLUMN series,
+    DROP COLUMN series_volume;
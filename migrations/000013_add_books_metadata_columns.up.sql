@@ -0,0 +1,11 @@
+ALTER TABLE books
+    ADD COLUMN subtitle varchar(255),
+    ADD COLUMN edition varchar(50),
+    ADD COLUMN publication_date date,
+    ADD COLUMN language varchar(35),
+    ADD COLUMN page_count integer CHECK (page_count > 0),
+    ADD COLUMN format varchar(20) CHECK (format IN ('hardcover', 'paperback', 'ebook')),
+    ADD COLUMN description text,
+    ADD COLUMN series varchar(255),
+    ADD COLUMN series_volume integer CHECK (series_volume > 0),
+    ADD CHECK (series_volume IS NULL OR series IS NOT NULL);
@@ -0,0 +1,3 @@
+ALTER TABLE books
+    DROP COLUMN work_id;
+DROP TABLE works;
@@ -0,0 +1,11 @@
+CREATE TABLE works (
+    id serial PRIMARY KEY,
+    title varchar(255) NOT NULL,
+    author varchar(100),
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+ALTER TABLE books
+    ADD COLUMN work_id integer REFERENCES works (id) ON DELETE SET NULL;
+
+CREATE INDEX books_work_id_idx ON books (work_id);
@@ -0,0 +1,3 @@
+DROP INDEX books_title_author_trgm_idx;
+ALTER TABLE books
+    DROP COLUMN isbn;
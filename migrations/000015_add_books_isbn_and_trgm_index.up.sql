@@ -0,0 +1,9 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE books
+    ADD COLUMN isbn varchar(13) CHECK (isbn ~ '^[0-9]{13}$');
+
+CREATE INDEX books_isbn_idx ON books (isbn);
+
+CREATE INDEX books_title_author_trgm_idx ON books
+    USING gin (lower(coalesce(title, '') || ' ' || coalesce(author, '')) gin_trgm_ops);
@@ -0,0 +1,2 @@
+DROP TABLE book_audit_logs;
+DROP TABLE book_redirects;
@@ -0,0 +1,17 @@
+CREATE TABLE book_redirects (
+    source_id integer PRIMARY KEY,
+    target_id integer NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX book_redirects_target_id_idx ON book_redirects (target_id);
+
+CREATE TABLE book_audit_logs (
+    id bigserial PRIMARY KEY,
+    book_id integer NOT NULL,
+    action varchar(20) NOT NULL CHECK (action IN ('merge')),
+    detail jsonb NOT NULL DEFAULT '{}',
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX book_audit_logs_book_id_idx ON book_audit_logs (book_id, id);
@@ -0,0 +1,13 @@
+CREATE TABLE outbox_events (
+    id bigserial PRIMARY KEY,
+    event_type varchar(50) NOT NULL,
+    book_id integer NOT NULL,
+    payload jsonb NOT NULL,
+    occurred_at timestamp with time zone NOT NULL DEFAULT now(),
+    attempts integer NOT NULL DEFAULT 0,
+    next_attempt_at timestamp with time zone NOT NULL DEFAULT now(),
+    delivered_at timestamp with time zone,
+    last_error text
+);
+
+CREATE INDEX outbox_events_pending_idx ON outbox_events (next_attempt_at, id) WHERE delivered_at IS NULL;
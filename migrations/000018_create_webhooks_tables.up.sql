@@ -0,0 +1,27 @@
+CREATE TABLE webhook_subscriptions (
+    id serial PRIMARY KEY,
+    url varchar(2048) NOT NULL,
+    event_types varchar(50)[] NOT NULL,
+    secret varchar(255) NOT NULL,
+    active boolean NOT NULL DEFAULT true,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE TABLE webhook_deliveries (
+    id bigserial PRIMARY KEY,
+    subscription_id integer NOT NULL REFERENCES webhook_subscriptions (id) ON DELETE CASCADE,
+    event_id bigint NOT NULL,
+    event_type varchar(50) NOT NULL,
+    payload jsonb NOT NULL,
+    status varchar(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'succeeded', 'dead')),
+    attempts integer NOT NULL DEFAULT 0,
+    next_attempt_at timestamp with time zone NOT NULL DEFAULT now(),
+    response_status integer,
+    last_error text,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    delivered_at timestamp with time zone,
+    UNIQUE (subscription_id, event_id)
+);
+
+CREATE INDEX webhook_deliveries_pending_idx ON webhook_deliveries (next_attempt_at, id) WHERE status = 'pending';
+CREATE INDEX webhook_deliveries_dead_idx ON webhook_deliveries (id) WHERE status = 'dead';
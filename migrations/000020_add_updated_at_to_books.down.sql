@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS books_set_updated_at ON books;
+DROP FUNCTION IF EXISTS set_updated_at();
+ALTER TABLE books DROP COLUMN IF EXISTS updated_at;
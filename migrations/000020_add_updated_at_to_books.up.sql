@@ -0,0 +1,14 @@
+ALTER TABLE books ADD COLUMN updated_at timestamp with time zone NOT NULL DEFAULT now();
+
+CREATE FUNCTION set_updated_at() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    NEW.updated_at := now();
+    RETURN NEW;
+END;
+$$;
+
+CREATE TRIGGER books_set_updated_at BEFORE UPDATE ON books FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+CREATE INDEX books_updated_at_idx ON books (updated_at);
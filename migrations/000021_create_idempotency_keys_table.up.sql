@@ -0,0 +1,10 @@
+CREATE TABLE idempotency_keys (
+    idempotency_key varchar(255) PRIMARY KEY,
+    request_hash char(64) NOT NULL,
+    response_status integer NOT NULL,
+    book_id integer NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    expires_at timestamp with time zone NOT NULL
+);
+
+CREATE INDEX idempotency_keys_expires_at_idx ON idempotency_keys (expires_at);
@@ -0,0 +1,3 @@
+DELETE FROM book_audit_logs WHERE action IN ('bulk_update', 'bulk_delete');
+ALTER TABLE book_audit_logs DROP CONSTRAINT book_audit_logs_action_check;
+ALTER TABLE book_audit_logs ADD CONSTRAINT book_audit_logs_action_check CHECK (action = 'merge');
@@ -0,0 +1,2 @@
+ALTER TABLE book_audit_logs DROP CONSTRAINT book_audit_logs_action_check;
+ALTER TABLE book_audit_logs ADD CONSTRAINT book_audit_logs_action_check CHECK (action IN ('merge', 'bulk_update', 'bulk_delete'));
@@ -0,0 +1,20 @@
+CREATE TABLE jobs (
+    id bigserial PRIMARY KEY,
+    kind varchar(50) NOT NULL,
+    params jsonb NOT NULL,
+    status varchar(20) NOT NULL DEFAULT 'queued' CHECK (status IN ('queued', 'running', 'succeeded', 'failed', 'canceled')),
+    progress_done integer NOT NULL DEFAULT 0,
+    progress_total integer,
+    cancel_requested boolean NOT NULL DEFAULT false,
+    attempts integer NOT NULL DEFAULT 0,
+    lease_until timestamp with time zone,
+    result jsonb,
+    artifact_key varchar(255),
+    artifact_type varchar(255),
+    last_error text,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    started_at timestamp with time zone,
+    finished_at timestamp with time zone
+);
+
+CREATE INDEX jobs_unfinished_idx ON jobs (id) WHERE status IN ('queued', 'running');
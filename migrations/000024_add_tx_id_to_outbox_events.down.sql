@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS outbox_events_tx_id_idx;
+ALTER TABLE outbox_events DROP COLUMN IF EXISTS tx_id;
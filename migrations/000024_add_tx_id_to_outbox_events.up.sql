@@ -0,0 +1,4 @@
+ALTER TABLE outbox_events ADD COLUMN tx_id bigint NOT NULL DEFAULT 0;
+ALTER TABLE outbox_events ALTER COLUMN tx_id SET DEFAULT pg_current_xact_id()::text::bigint;
+
+CREATE INDEX outbox_events_tx_id_idx ON outbox_events (tx_id, id);
@@ -0,0 +1,17 @@
+DROP INDEX IF EXISTS exchange_rates_effective_date_idx;
+DROP INDEX IF EXISTS promotions_ends_at_idx;
+DROP INDEX IF EXISTS promotions_starts_at_idx;
+DROP INDEX IF EXISTS book_prices_effective_to_idx;
+DROP INDEX IF EXISTS book_prices_effective_from_idx;
+DROP TRIGGER IF EXISTS book_categories_bump_catalog_version ON book_categories;
+DROP TRIGGER IF EXISTS categories_bump_catalog_version ON categories;
+DROP TRIGGER IF EXISTS book_tags_bump_catalog_version ON book_tags;
+DROP TRIGGER IF EXISTS tags_bump_catalog_version ON tags;
+DROP TRIGGER IF EXISTS inventories_bump_catalog_version ON inventories;
+DROP TRIGGER IF EXISTS book_ratings_bump_catalog_version ON book_ratings;
+DROP TRIGGER IF EXISTS exchange_rates_bump_catalog_version ON exchange_rates;
+DROP TRIGGER IF EXISTS promotions_bump_catalog_version ON promotions;
+DROP TRIGGER IF EXISTS book_prices_bump_catalog_version ON book_prices;
+DROP TRIGGER IF EXISTS books_bump_catalog_version ON books;
+DROP FUNCTION IF EXISTS bump_catalog_version();
+DROP TABLE IF EXISTS catalog_versions;
@@ -0,0 +1,39 @@
+CREATE TABLE catalog_versions (
+    id boolean PRIMARY KEY DEFAULT true CHECK (id),
+    version bigint NOT NULL,
+    updated_at timestamp with time zone NOT NULL
+);
+
+INSERT INTO catalog_versions (version, updated_at) VALUES (1, now());
+
+CREATE FUNCTION bump_catalog_version() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    IF current_setting('catalog_versions.bumped', true) = 'on' THEN
+        RETURN NULL;
+    END IF;
+    PERFORM set_config('catalog_versions.bumped', 'on', true);
+    UPDATE catalog_versions
+        SET version = version + 1,
+            updated_at = GREATEST(clock_timestamp(), date_trunc('second', updated_at) + interval '1 second');
+    RETURN NULL;
+END;
+$$;
+
+CREATE CONSTRAINT TRIGGER books_bump_catalog_version AFTER INSERT OR DELETE OR UPDATE ON books DEFERRABLE INITIALLY DEFERRED FOR EACH ROW EXECUTE FUNCTION bump_catalog_version();
+CREATE CONSTRAINT TRIGGER book_prices_bump_catalog_version AFTER INSERT OR DELETE OR UPDATE ON book_prices DEFERRABLE INITIALLY DEFERRED FOR EACH ROW EXECUTE FUNCTION bump_catalog_version();
+CREATE CONSTRAINT TRIGGER promotions_bump_catalog_version AFTER INSERT OR DELETE OR UPDATE ON promotions DEFERRABLE INITIALLY DEFERRED FOR EACH ROW EXECUTE FUNCTION bump_catalog_version();
+CREATE CONSTRAINT TRIGGER exchange_rates_bump_catalog_version AFTER INSERT OR DELETE OR UPDATE ON exchange_rates DEFERRABLE INITIALLY DEFERRED FOR EACH ROW EXECUTE FUNCTION bump_catalog_version();
+CREATE CONSTRAINT TRIGGER book_ratings_bump_catalog_version AFTER INSERT OR DELETE OR UPDATE ON book_ratings DEFERRABLE INITIALLY DEFERRED FOR EACH ROW EXECUTE FUNCTION bump_catalog_version();
+CREATE CONSTRAINT TRIGGER inventories_bump_catalog_version AFTER INSERT OR DELETE OR UPDATE ON inventories DEFERRABLE INITIALLY DEFERRED FOR EACH ROW EXECUTE FUNCTION bump_catalog_version();
+CREATE CONSTRAINT TRIGGER tags_bump_catalog_version AFTER INSERT OR DELETE OR UPDATE ON tags DEFERRABLE INITIALLY DEFERRED FOR EACH ROW EXECUTE FUNCTION bump_catalog_version();
+CREATE CONSTRAINT TRIGGER book_tags_bump_catalog_version AFTER INSERT OR DELETE OR UPDATE ON book_tags DEFERRABLE INITIALLY DEFERRED FOR EACH ROW EXECUTE FUNCTION bump_catalog_version();
+CREATE CONSTRAINT TRIGGER categories_bump_catalog_version AFTER INSERT OR DELETE OR UPDATE ON categories DEFERRABLE INITIALLY DEFERRED FOR EACH ROW EXECUTE FUNCTION bump_catalog_version();
+CREATE CONSTRAINT TRIGGER book_categories_bump_catalog_version AFTER INSERT OR DELETE OR UPDATE ON book_categories DEFERRABLE INITIALLY DEFERRED FOR EACH ROW EXECUTE FUNCTION bump_catalog_version();
+
+CREATE INDEX book_prices_effective_from_idx ON book_prices (effective_from);
+CREATE INDEX book_prices_effective_to_idx ON book_prices (effective_to);
+CREATE INDEX promotions_starts_at_idx ON promotions (starts_at);
+CREATE INDEX promotions_ends_at_idx ON promotions (ends_at);
+CREATE INDEX exchange_rates_effective_date_idx ON exchange_rates (effective_date);
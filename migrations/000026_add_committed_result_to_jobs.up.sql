@@ -0,0 +1 @@
+ALTER TABLE jobs ADD COLUMN committed_result jsonb;
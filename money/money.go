@@ -0,0 +1,156 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// DefaultCurrency は通貨の指定がない価格（books.price など）に用いる通貨
+const DefaultCurrency = "JPY"
+
+// Currency はISO 4217の通貨コードと補助単位の桁数を表す
+type Currency struct {
+	Code       string
+	MinorUnits int
+}
+
+// currencies は取り扱う通貨の一覧
+// 金額は全て補助単位（円・セントなど最小の単位）の整数で保持する
+var currencies = map[string]Currency{
+	"JPY": {Code: "JPY", MinorUnits: 0},
+	"USD": {Code: "USD", MinorUnits: 2},
+	"EUR": {Code: "EUR", MinorUnits: 2},
+	"GBP": {Code: "GBP", MinorUnits: 2},
+	"CNY": {Code: "CNY", MinorUnits: 2},
+	"KRW": {Code: "KRW", MinorUnits: 0},
+	"KWD": {Code: "KWD", MinorUnits: 3},
+}
+
+// LookupCurrency は通貨コードに対応する通貨を返す
+func LookupCurrency(code string) (Currency, bool) {
+	currency, ok := currencies[code]
+	return currency, ok
+}
+
+// Money は補助単位の金額と通貨の組を表す
+type Money struct {
+	Amount   int64
+	Currency Currency
+}
+
+// Decimal は金額を補助単位の桁数に合わせた10進数の文字列で返す（例：1999 USD -> "19.99"）
+func (m Money) Decimal() string {
+	if m.Currency.MinorUnits == 0 {
+		return fmt.Sprintf("%d", m.Amount)
+	}
+
+	sign := ""
+	amount := m.Amount
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	digits := fmt.Sprintf("%0*d", m.Currency.MinorUnits+1, amount)
+	point := len(digits) - m.Currency.MinorUnits
+
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency.Code
+}
+
+// RoundingMode は通貨換算で補助単位未満の端数を丸める方法
+type RoundingMode int
+
+const (
+	// RoundHalfUp は0.5以上を絶対値の大きい方へ丸める（四捨五入）
+	RoundHalfUp RoundingMode = iota
+	// RoundHalfEven は0.5ちょうどを偶数側へ丸める（銀行丸め）
+	RoundHalfEven
+	// RoundDown は端数を切り捨てる
+	RoundDown
+	// RoundUp は端数を切り上げる
+	RoundUp
+)
+
+var ErrInvalidRoundingMode = errors.New("invalid rounding mode")
+
+// ParseRoundingMode は設定値の文字列を丸め方に変換する
+// 空文字の場合は四捨五入とする
+func ParseRoundingMode(s string) (RoundingMode, error) {
+	switch s {
+	case "", "half_up":
+		return RoundHalfUp, nil
+	case "half_even":
+		return RoundHalfEven, nil
+	case "down":
+		return RoundDown, nil
+	case "up":
+		return RoundUp, nil
+	}
+
+	return 0, ErrInvalidRoundingMode
+}
+
+// round は有理数を丸め方に従って整数に丸める
+func round(r *big.Rat, mode RoundingMode) int64 {
+	num := new(big.Int).Abs(r.Num())
+	den := r.Denom()
+	quo, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+
+	if rem.Sign() != 0 {
+		// 端数の2倍と分母を比べ、0.5未満・ちょうど・超過を判定する
+		cmp := new(big.Int).Lsh(rem, 1).Cmp(den)
+		roundAway := false
+		switch mode {
+		case RoundHalfUp:
+			roundAway = cmp >= 0
+		case RoundHalfEven:
+			roundAway = cmp > 0 || (cmp == 0 && quo.Bit(0) == 1)
+		case RoundUp:
+			roundAway = true
+		}
+		if roundAway {
+			quo.Add(quo, big.NewInt(1))
+		}
+	}
+
+	if r.Sign() < 0 {
+		quo.Neg(quo)
+	}
+
+	return quo.Int64()
+}
+
+// Convert は金額を換算レート（1単位の換算元通貨に対する換算先通貨の量）で換算し、換算先通貨の補助単位に丸める
+func Convert(m Money, rate *big.Rat, to Currency, mode RoundingMode) Money {
+	amount := new(big.Rat).SetInt64(m.Amount)
+	amount.Mul(amount, rate)
+	amount.Mul(amount, new(big.Rat).SetFrac(pow10(to.MinorUnits), pow10(m.Currency.MinorUnits)))
+
+	return Money{Amount: round(amount, mode), Currency: to}
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// Conversion は通貨換算の結果と、換算に用いたレートを表す
+type Conversion struct {
+	Original Money
+	Money    Money
+	Rate     *big.Rat
+	RateDate time.Time
+}
+
+// FormatRate はレートを小数点以下10桁までの10進数の文字列で返す（末尾の0は省く）
+func FormatRate(rate *big.Rat) string {
+	s := rate.FloatString(10)
+	s = strings.TrimRight(s, "0")
+
+	return strings.TrimSuffix(s, ".")
+}
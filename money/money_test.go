@@ -0,0 +1,90 @@
+package money_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/rentaro-m-b/ai-model-exam/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookupCurrency(t *testing.T) {
+	currency, ok := money.LookupCurrency("USD")
+	assert.True(t, ok)
+	assert.Equal(t, money.Currency{Code: "USD", MinorUnits: 2}, currency)
+
+	_, ok = money.LookupCurrency("XXX")
+	assert.False(t, ok)
+}
+
+func TestDecimal(t *testing.T) {
+	jpy, _ := money.LookupCurrency("JPY")
+	usd, _ := money.LookupCurrency("USD")
+	kwd, _ := money.LookupCurrency("KWD")
+
+	cases := []struct {
+		money  money.Money
+		expect string
+	}{
+		{money.Money{Amount: 3080, Currency: jpy}, "3080"},
+		{money.Money{Amount: 1999, Currency: usd}, "19.99"},
+		{money.Money{Amount: 5, Currency: usd}, "0.05"},
+		{money.Money{Amount: -150, Currency: usd}, "-1.50"},
+		{money.Money{Amount: 1234, Currency: kwd}, "1.234"},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expect, c.money.Decimal())
+	}
+	assert.Equal(t, "19.99 USD", money.Money{Amount: 1999, Currency: usd}.String())
+}
+
+func TestParseRoundingMode(t *testing.T) {
+	cases := map[string]money.RoundingMode{
+		"":          money.RoundHalfUp,
+		"half_up":   money.RoundHalfUp,
+		"half_even": money.RoundHalfEven,
+		"down":      money.RoundDown,
+		"up":        money.RoundUp,
+	}
+	for s, expect := range cases {
+		mode, err := money.ParseRoundingMode(s)
+		assert.NoError(t, err)
+		assert.Equal(t, expect, mode)
+	}
+
+	_, err := money.ParseRoundingMode("ceil")
+	assert.ErrorIs(t, err, money.ErrInvalidRoundingMode)
+}
+
+func TestConvert(t *testing.T) {
+	jpy, _ := money.LookupCurrency("JPY")
+	usd, _ := money.LookupCurrency("USD")
+
+	// 3080円を1円=0.0066ドルで換算すると20.328ドル
+	rate := big.NewRat(66, 10000)
+	cases := []struct {
+		mode   money.RoundingMode
+		expect int64
+	}{
+		{money.RoundHalfUp, 2033},
+		{money.RoundHalfEven, 2033},
+		{money.RoundDown, 2032},
+		{money.RoundUp, 2033},
+	}
+	for _, c := range cases {
+		converted := money.Convert(money.Money{Amount: 3080, Currency: jpy}, rate, usd, c.mode)
+		assert.Equal(t, money.Money{Amount: c.expect, Currency: usd}, converted)
+	}
+
+	// 19.99ドルを1ドル=150円で換算すると2998.5円
+	halfway := money.Money{Amount: 1999, Currency: usd}
+	assert.Equal(t, int64(2999), money.Convert(halfway, big.NewRat(150, 1), jpy, money.RoundHalfUp).Amount)
+	assert.Equal(t, int64(2998), money.Convert(halfway, big.NewRat(150, 1), jpy, money.RoundHalfEven).Amount)
+	assert.Equal(t, int64(2998), money.Convert(halfway, big.NewRat(150, 1), jpy, money.RoundDown).Amount)
+}
+
+func TestFormatRate(t *testing.T) {
+	assert.Equal(t, "151.23", money.FormatRate(big.NewRat(15123, 100)))
+	assert.Equal(t, "150", money.FormatRate(big.NewRat(150, 1)))
+	assert.Equal(t, "0.0066124446", money.FormatRate(new(big.Rat).Inv(big.NewRat(15123, 100))))
+}
@@ -0,0 +1,65 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval は満たされたバケットを捨てる間隔
+const sweepInterval = time.Minute
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+	// fullAt はこの時刻以降にバケットが満たされ、捨てても結果が変わらなくなる
+	fullAt time.Time
+}
+
+type memoryStoreImpl struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemoryStore はプロセス内のメモリにバケットを保持するStoreを返す
+// 上限はプロセスごとに数えるため、複数のサーバで動かす場合はサーバの数だけ緩くなる
+func NewMemoryStore() Store {
+	return &memoryStoreImpl{
+		buckets: map[string]*bucket{},
+		now:     time.Now,
+	}
+}
+
+func (s *memoryStoreImpl) Take(ctx context.Context, key string, limit Limit) (*Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Capacity()), updatedAt: now}
+		s.buckets[key] = b
+	}
+	tokens, res := take(b.tokens, now.Sub(b.updatedAt), limit)
+	b.tokens, b.updatedAt, b.fullAt = tokens, now, now.Add(res.Reset)
+
+	return res, nil
+}
+
+// sweep は満たされたバケットを捨て、リクエストの途絶えたクライアントの分のメモリを解放する
+func (s *memoryStoreImpl) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for key, b := range s.buckets {
+		if !now.Before(b.fullAt) {
+			delete(s.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rentaro-m-b/ai-model-exam/ratelimit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	store := ratelimit.NewMemoryStore()
+	limit := ratelimit.Limit{Requests: 2, Per: time.Hour}
+
+	// バケットの大きさまでは続けて許可する
+	for want := 1; want >= 0; want-- {
+		res, err := store.Take(ctx, "a", limit)
+		assert.NoError(t, err)
+		assert.True(t, res.Allowed)
+		assert.Equal(t, 2, res.Limit)
+		assert.Equal(t, want, res.Remaining)
+	}
+
+	// 空になったら、トークンが1つ補充されるまでの時間を返して拒否する
+	res, err := store.Take(ctx, "a", limit)
+	assert.NoError(t, err)
+	assert.False(t, res.Allowed)
+	assert.Equal(t, 0, res.Remaining)
+	assert.InDelta(t, 30*time.Minute, res.RetryAfter, float64(time.Second))
+	assert.InDelta(t, time.Hour, res.Reset, float64(time.Second))
+
+	// キーごとに別のバケットを使う
+	res, err = store.Take(ctx, "b", limit)
+	assert.NoError(t, err)
+	assert.True(t, res.Allowed)
+}
+
+func TestMemoryStoreRefill(t *testing.T) {
+	ctx := context.Background()
+	store := ratelimit.NewMemoryStore()
+	limit := ratelimit.Limit{Requests: 1, Per: 50 * time.Millisecond}
+
+	res, err := store.Take(ctx, "a", limit)
+	assert.NoError(t, err)
+	assert.True(t, res.Allowed)
+	res, err = store.Take(ctx, "a", limit)
+	assert.NoError(t, err)
+	assert.False(t, res.Allowed)
+
+	// 時間が経てばトークンが補充される
+	time.Sleep(res.RetryAfter + 10*time.Millisecond)
+	res, err = store.Take(ctx, "a", limit)
+	assert.NoError(t, err)
+	assert.True(t, res.Allowed)
+}
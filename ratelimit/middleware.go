@@ -0,0 +1,99 @@
+package ratelimit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// HeaderAPIKey はクライアントを識別するAPIキーを送るリクエストヘッダ
+const HeaderAPIKey = "X-API-Key"
+
+// Config はルートのグループに適用する流量の上限
+type Config struct {
+	// Name はルートのグループの名前で、グループごとに別のバケットを使うためにキーの先頭に付ける
+	Name  string
+	Limit Limit
+	Store Store
+	// KeyFunc はクライアントのIPアドレスのバケットに加えて数えるキーを返し、空文字列の場合は数えない
+	// 未指定の場合はKeyByAPIKeyを使う
+	KeyFunc func(c echo.Context) string
+}
+
+// KeyByAPIKey はAPIキーがあればAPIキーをキーにし、ストアに平文で残さないようハッシュ化する
+// このサーバはAPIキーを検証しないため、APIキーのバケットはIPアドレスのバケットに加えて数え、任意のキーを送っても上限を逃れられないようにする
+func KeyByAPIKey(c echo.Context) string {
+	key := c.Request().Header.Get(HeaderAPIKey)
+	if key == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(key))
+
+	return "key:" + hex.EncodeToString(sum[:16])
+}
+
+// Middleware はクライアントごとにトークンバケットで流量を制限し、上限を超えたリクエストに429を返す
+// IPアドレスのバケットとKeyFuncのキーのバケットの両方を数え、いずれかが上限を超えれば拒否する
+// 全てのレスポンスにRateLimit-*ヘッダを残りの少ない方のバケットで、429にはRetry-Afterヘッダを付ける
+// ストアが使えない場合は、制限のために全てのリクエストを止めないよう許可する
+// 上限かストアが未指定の場合は制限しない
+func Middleware(config Config) echo.MiddlewareFunc {
+	if config.Store == nil || !config.Limit.Valid() {
+		return func(next echo.HandlerFunc) echo.HandlerFunc {
+			return next
+		}
+	}
+	keyFunc := config.KeyFunc
+	if keyFunc == nil {
+		keyFunc = KeyByAPIKey
+	}
+	policy := fmt.Sprintf("%d;w=%d", config.Limit.Requests, ceilSeconds(config.Limit.Per))
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			keys := []string{"ip:" + c.RealIP()}
+			if key := keyFunc(c); key != "" {
+				keys = append(keys, key)
+			}
+			var res *Result
+			for _, key := range keys {
+				r, err := config.Store.Take(c.Request().Context(), config.Name+":"+key, config.Limit)
+				if err != nil {
+					log.Printf("Unable to execute RateLimitMiddleware: %d\n", err)
+					return next(c)
+				}
+				if res == nil || r.Remaining < res.Remaining || !r.Allowed {
+					res = r
+				}
+				if !r.Allowed {
+					break
+				}
+			}
+
+			header := c.Response().Header()
+			header.Set("RateLimit-Limit", strconv.Itoa(res.Limit))
+			header.Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+			header.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(res.Reset)))
+			header.Set("RateLimit-Policy", policy)
+			if !res.Allowed {
+				header.Set("Retry-After", strconv.Itoa(ceilSeconds(res.RetryAfter)))
+				return c.JSON(http.StatusTooManyRequests, map[string]string{
+					"message": "Too many requests",
+				})
+			}
+
+			return next(c)
+		}
+	}
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
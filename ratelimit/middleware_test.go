@@ -0,0 +1,145 @@
+package ratelimit_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rentaro-m-b/ai-model-exam/ratelimit"
+	"github.com/stretchr/testify/assert"
+)
+
+type failingStore struct{}
+
+func (failingStore) Take(ctx context.Context, key string, limit ratelimit.Limit) (*ratelimit.Result, error) {
+	return nil, errors.New("unavailable")
+}
+
+func newRateLimitedServer(config ratelimit.Config) *echo.Echo {
+	e := echo.New()
+	g := e.Group("", ratelimit.Middleware(config))
+	g.GET("/", func(c echo.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	return e
+}
+
+func serve(e *echo.Echo, apiKey string, remoteAddr string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = remoteAddr
+	if apiKey != "" {
+		req.Header.Set(ratelimit.HeaderAPIKey, apiKey)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestMiddleware(t *testing.T) {
+	// 準備
+	e := newRateLimitedServer(ratelimit.Config{
+		Name:  "test",
+		Limit: ratelimit.Limit{Requests: 1, Per: time.Minute},
+		Store: ratelimit.NewMemoryStore(),
+	})
+
+	// 実行・検証
+	rec := serve(e, "", "192.0.2.1:1234")
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "0", rec.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "60", rec.Header().Get("RateLimit-Reset"))
+	assert.Equal(t, "1;w=60", rec.Header().Get("RateLimit-Policy"))
+	assert.Empty(t, rec.Header().Get("Retry-After"))
+
+	rec = serve(e, "", "192.0.2.1:5678")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "60", rec.Header().Get("Retry-After"))
+	assert.JSONEq(t, `{"message":"Too many requests"}`, rec.Body.String())
+
+	// 別のIPアドレスのクライアントは別に数える
+	rec = serve(e, "", "192.0.2.2:1234")
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	// APIキーを送るクライアントは、IPアドレスとAPIキーの両方で数える
+	rec = serve(e, "secret", "192.0.2.1:1234")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	rec = serve(e, "secret", "192.0.2.3:1234")
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	rec = serve(e, "secret", "192.0.2.4:1234")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+}
+
+func TestMiddlewareRandomAPIKeys(t *testing.T) {
+	// 準備
+	e := newRateLimitedServer(ratelimit.Config{
+		Name:  "test",
+		Limit: ratelimit.Limit{Requests: 2, Per: time.Minute},
+		Store: ratelimit.NewMemoryStore(),
+	})
+
+	// 実行・検証（検証されないAPIキーを毎回変えても、IPアドレスの上限を逃れられない）
+	for i, apiKey := range []string{"random-1", "random-2", "random-3"} {
+		rec := serve(e, apiKey, "192.0.2.1:1234")
+		if i < 2 {
+			assert.Equal(t, http.StatusNoContent, rec.Code)
+		} else {
+			assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		}
+	}
+}
+
+func TestMiddlewareForwardedForSpoofed(t *testing.T) {
+	// 準備
+	e := newRateLimitedServer(ratelimit.Config{
+		Name:  "test",
+		Limit: ratelimit.Limit{Requests: 1, Per: time.Minute},
+		Store: ratelimit.NewMemoryStore(),
+	})
+	e.IPExtractor = echo.ExtractIPDirect()
+
+	// 実行・検証（X-Forwarded-Forを変えても、同じ接続元のIPアドレスとして数える）
+	for i, forwardedFor := range []string{"198.51.100.1", "198.51.100.2"} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = "192.0.2.1:1234"
+		req.Header.Set(echo.HeaderXForwardedFor, forwardedFor)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		if i == 0 {
+			assert.Equal(t, http.StatusNoContent, rec.Code)
+		} else {
+			assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		}
+	}
+}
+
+func TestMiddlewareStoreUnavailable(t *testing.T) {
+	// 準備
+	e := newRateLimitedServer(ratelimit.Config{
+		Name:  "test",
+		Limit: ratelimit.Limit{Requests: 1, Per: time.Minute},
+		Store: failingStore{},
+	})
+
+	// 実行・検証
+	rec := serve(e, "", "192.0.2.1:1234")
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Empty(t, rec.Header().Get("RateLimit-Limit"))
+}
+
+func TestMiddlewareDisabled(t *testing.T) {
+	// 準備
+	e := newRateLimitedServer(ratelimit.Config{Name: "test"})
+
+	// 実行・検証
+	for i := 0; i < 3; i++ {
+		rec := serve(e, "", "192.0.2.1:1234")
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	}
+}
@@ -0,0 +1,105 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidLimit = errors.New("invalid rate limit")
+
+// Limit はトークンバケットによる流量の上限を表す
+// バケットはBurst個のトークンを持ち、Perの間にRequests個の割合で補充される
+type Limit struct {
+	// Requests はPerの間に許可するリクエストの数
+	Requests int
+	Per      time.Duration
+	// Burst は連続して許可するリクエストの最大数で、0の場合はRequestsと同じ
+	Burst int
+}
+
+// ParseLimit は "30/1m" のように、リクエストの数と期間を "/" で区切った文字列を解析する
+func ParseLimit(s string) (Limit, error) {
+	requests, per, ok := strings.Cut(s, "/")
+	if !ok {
+		return Limit{}, ErrInvalidLimit
+	}
+	n, err := strconv.Atoi(requests)
+	if err != nil {
+		return Limit{}, ErrInvalidLimit
+	}
+	d, err := time.ParseDuration(per)
+	if err != nil {
+		return Limit{}, ErrInvalidLimit
+	}
+	limit := Limit{Requests: n, Per: d}
+	if !limit.Valid() {
+		return Limit{}, ErrInvalidLimit
+	}
+
+	return limit, nil
+}
+
+func (l Limit) Valid() bool {
+	return l.Requests > 0 && l.Per > 0 && l.Burst >= 0
+}
+
+// Capacity はバケットが持てるトークンの数
+func (l Limit) Capacity() int {
+	if l.Burst > 0 {
+		return l.Burst
+	}
+
+	return l.Requests
+}
+
+// Rate は1秒あたりに補充されるトークンの数
+func (l Limit) Rate() float64 {
+	return float64(l.Requests) / l.Per.Seconds()
+}
+
+// Result はリクエストを許可したかと、レスポンスヘッダで伝える残りの量を表す
+type Result struct {
+	Allowed bool
+	// Limit はバケットが持てるトークンの数
+	Limit int
+	// Remaining は続けて許可できるリクエストの数
+	Remaining int
+	// RetryAfter は拒否した場合に、次のリクエストが許可されるまでの時間
+	RetryAfter time.Duration
+	// Reset はバケットのトークンが満たされるまでの時間
+	Reset time.Duration
+}
+
+// Store はキーごとのバケットを保持する
+// 複数のサーバのプロセスで上限を共有する場合は、共有のストア（Redisなど）の実装に差し替える
+type Store interface {
+	// Take はキーのバケットからトークンを1つ取り出し、取り出せなければ拒否した結果を返す
+	Take(ctx context.Context, key string, limit Limit) (*Result, error)
+}
+
+// take はtokens個のトークンを持つバケットからelapsedの間の補充後に1つ取り出し、取り出した後のトークンの数と結果を返す
+func take(tokens float64, elapsed time.Duration, limit Limit) (float64, *Result) {
+	capacity := float64(limit.Capacity())
+	rate := limit.Rate()
+	tokens = math.Min(capacity, tokens+max(elapsed.Seconds(), 0)*rate)
+
+	res := &Result{Limit: limit.Capacity()}
+	if tokens >= 1 {
+		tokens--
+		res.Allowed = true
+	} else {
+		res.RetryAfter = seconds((1 - tokens) / rate)
+	}
+	res.Remaining = int(math.Floor(tokens))
+	res.Reset = seconds((capacity - tokens) / rate)
+
+	return tokens, res
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(math.Ceil(s * float64(time.Second)))
+}
@@ -0,0 +1,27 @@
+package ratelimit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rentaro-m-b/ai-model-exam/ratelimit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLimit(t *testing.T) {
+	limit, err := ratelimit.ParseLimit("30/1m")
+	assert.NoError(t, err)
+	assert.Equal(t, ratelimit.Limit{Requests: 30, Per: time.Minute}, limit)
+	assert.Equal(t, 30, limit.Capacity())
+	assert.Equal(t, 0.5, limit.Rate())
+
+	// バーストを指定した場合はバケットの大きさになる
+	assert.Equal(t, 5, ratelimit.Limit{Requests: 30, Per: time.Minute, Burst: 5}.Capacity())
+}
+
+func TestParseLimitFailure(t *testing.T) {
+	for _, s := range []string{"", "30", "x/1m", "30/x", "0/1m", "30/0s", "-1/1m"} {
+		_, err := ratelimit.ParseLimit(s)
+		assert.ErrorIs(t, err, ratelimit.ErrInvalidLimit, s)
+	}
+}
@@ -0,0 +1,73 @@
+package ratelimit
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// takeScript はtakeと同じ計算をRedis上で不可分に行う
+// 時刻はサーバ間の時計のずれの影響を受けないよう、Redisの時刻を使う
+var takeScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local now = redis.call('TIME')
+local now_us = tonumber(now[1]) * 1000000 + tonumber(now[2])
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'updated_at')
+local tokens = tonumber(state[1])
+local updated_at = tonumber(state[2])
+if tokens == nil or updated_at == nil then
+    tokens = capacity
+    updated_at = now_us
+end
+
+local elapsed = math.max(now_us - updated_at, 0) / 1000000
+tokens = math.min(capacity, tokens + elapsed * rate)
+local allowed = 0
+if tokens >= 1 then
+    tokens = tokens - 1
+    allowed = 1
+end
+
+local ttl_ms = math.ceil((capacity - tokens) / rate * 1000)
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'updated_at', now_us)
+redis.call('PEXPIRE', KEYS[1], math.max(ttl_ms, 1))
+
+return {allowed, tostring(tokens)}
+`)
+
+type redisStoreImpl struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// NewRedisStore はRedisにバケットを保持し、複数のサーバのプロセスで上限を共有するStoreを返す
+// prefixは同じサーバを使う他のアプリケーションとキーが重ならないよう、全てのキーの先頭に付ける
+func NewRedisStore(client redis.UniversalClient, prefix string) Store {
+	return &redisStoreImpl{
+		client: client,
+		prefix: prefix,
+	}
+}
+
+func (s *redisStoreImpl) Take(ctx context.Context, key string, limit Limit) (*Result, error) {
+	values, err := takeScript.Run(ctx, s.client, []string{s.prefix + key}, limit.Capacity(), limit.Rate()).Slice()
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := strconv.ParseFloat(values[1].(string), 64)
+	if err != nil {
+		return nil, err
+	}
+
+	// 補充後・取り出す前のトークンの数に戻し、残りの量をtakeと同じ計算で求める
+	before := tokens
+	if values[0].(int64) == 1 {
+		before++
+	}
+	_, res := take(before, 0, limit)
+
+	return res, nil
+}
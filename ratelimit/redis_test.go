@@ -0,0 +1,55 @@
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/rentaro-m-b/ai-model-exam/ratelimit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisStore(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	server.SetTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+	store := ratelimit.NewRedisStore(client, "test:")
+	limit := ratelimit.Limit{Requests: 2, Per: time.Minute}
+
+	for want := 1; want >= 0; want-- {
+		res, err := store.Take(ctx, "a", limit)
+		assert.NoError(t, err)
+		assert.True(t, res.Allowed)
+		assert.Equal(t, want, res.Remaining)
+	}
+	res, err := store.Take(ctx, "a", limit)
+	assert.NoError(t, err)
+	assert.False(t, res.Allowed)
+	assert.Equal(t, 30*time.Second, res.RetryAfter)
+	assert.Equal(t, time.Minute, res.Reset)
+
+	// キーの先頭にprefixを付け、満たされるまでの時間で有効期限を切る
+	assert.True(t, server.Exists("test:a"))
+	assert.Equal(t, time.Minute, server.TTL("test:a"))
+
+	// Redisの時刻で補充する
+	server.SetTime(time.Date(2024, 1, 1, 0, 0, 30, 0, time.UTC))
+	res, err = store.Take(ctx, "a", limit)
+	assert.NoError(t, err)
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 0, res.Remaining)
+}
+
+func TestRedisStoreFailureUnavailable(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr(), MaxRetries: -1})
+	defer client.Close()
+	server.Close()
+
+	_, err := ratelimit.NewRedisStore(client, "test:").Take(context.Background(), "a", ratelimit.Limit{Requests: 1, Per: time.Second})
+	assert.Error(t, err)
+}
@@ -4,22 +4,52 @@ import (
 	"context"
 	"log"
 
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/event"
+	"github.com/rentaro-m-b/ai-model-exam/job"
+	"github.com/rentaro-m-b/ai-model-exam/money"
 )
 
 type BookRepository interface {
 	ListBooks(ctx context.Context) ([]db.Book, error)
 	CreateBook(ctx context.Context, param *db.CreateBookParams) (*db.Book, error)
 	GetBookById(ctx context.Context, id int) (*db.Book, error)
+	UpdateBook(ctx context.Context, param *db.UpdateBookParams) (*db.Book, error)
+	LinkWork(ctx context.Context, id int, workId int) (*db.Book, error)
+	UnlinkWork(ctx context.Context, id int, workId int) error
+	SearchBooks(ctx context.Context, param *db.SearchBooksParams) ([]db.Book, error)
+	ListBooksByIds(ctx context.Context, ids []int32) ([]db.Book, error)
+	ListBooksByAuthors(ctx context.Context, authors []string) ([]db.Book, error)
+	ListBooksByPublishers(ctx context.Context, publishers []string) ([]db.Book, error)
+	ListBooksByStock(ctx context.Context, inStock bool) ([]db.Book, error)
+	FilterBooks(ctx context.Context, param *db.FilterBooksParams) ([]db.Book, error)
+	ListBookRatings(ctx context.Context, bookIds []int32) ([]db.BookRating, error)
+	ListDuplicateCandidates(ctx context.Context, param *db.ListDuplicateBookCandidatesParams) ([]db.Book, error)
+	MergeBook(ctx context.Context, sourceId int, targetId int) (*db.Book, error)
+	ListBooksByFilter(ctx context.Context, filter *db.ListBooksByFilterParams) ([]db.Book, error)
+	BulkUpdateBooks(ctx context.Context, filter *db.ListBooksByFilterParams, patch *BookPatch) ([]db.Book, error)
+	DeleteBooks(ctx context.Context, filter *db.ListBooksByFilterParams) ([]db.Book, error)
+	CommitJobResult(ctx context.Context, j *job.Job, result []byte) error
+	GetBookRedirect(ctx context.Context, id int) (int32, error)
+	ListBookAuditLogs(ctx context.Context, bookId int) ([]db.BookAuditLog, error)
+	GetBookCollectionVersion(ctx context.Context) (*db.GetBookCollectionVersionRow, error)
+	WithTx(ctx context.Context, fn func(repo BookRepository) error) error
+	AddEvents(ctx context.Context, events ...event.Event) error
+	GetIdempotencyKey(ctx context.Context, key string) (*db.IdempotencyKey, error)
+	SaveIdempotencyKey(ctx context.Context, param *db.CreateIdempotencyKeyParams) (bool, error)
+	DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error)
 }
 
 type bookRepositoryImpl struct {
-	queries *db.Queries
+	queries  *db.Queries
+	beginner TxBeginner
 }
 
-func NewBookRepository(db *db.Queries) BookRepository {
+func NewBookRepository(db *db.Queries, beginner TxBeginner) BookRepository {
 	return &bookRepositoryImpl{
-		queries: db,
+		queries:  db,
+		beginner: beginner,
 	}
 }
 
@@ -33,8 +63,25 @@ func (r *bookRepositoryImpl) ListBooks(ctx context.Context) ([]db.Book, error) {
 	return books, nil
 }
 
+// CreateBook は書籍を登録し、価格が指定されていれば同じトランザクションで価格履歴の初期値を登録する
 func (r *bookRepositoryImpl) CreateBook(ctx context.Context, param *db.CreateBookParams) (*db.Book, error) {
-	book, err := r.queries.CreateBook(ctx, *param)
+	var book db.Book
+	err := withTx(ctx, r.beginner, r.queries, func(q *db.Queries) error {
+		var err error
+		book, err = q.CreateBook(ctx, *param)
+		if err != nil {
+			return err
+		}
+		if !book.Price.Valid {
+			return nil
+		}
+
+		return q.CreateInitialBookPrice(ctx, db.CreateInitialBookPriceParams{
+			BookID:   book.ID,
+			Currency: money.DefaultCurrency,
+			Amount:   int64(book.Price.Int32),
+		})
+	})
 	if err != nil {
 		log.Printf("Unable to execute BookRepositoryCreateBook: %d\n", err)
 		return nil, err
@@ -52,3 +99,217 @@ func (r *bookRepositoryImpl) GetBookById(ctx context.Context, id int) (*db.Book,
 
 	return &book, nil
 }
+
+// UpdateBook は無効値でない項目のみを更新し、書籍が存在しない場合はpgx.ErrNoRowsを返す
+func (r *bookRepositoryImpl) UpdateBook(ctx context.Context, param *db.UpdateBookParams) (*db.Book, error) {
+	book, err := r.queries.UpdateBook(ctx, *param)
+	if err != nil {
+		log.Printf("Unable to execute BookRepositoryUpdateBook: %d\n", err)
+		return nil, err
+	}
+
+	return &book, nil
+}
+
+// LinkWork は書籍を作品の版として紐付け、書籍が存在しない場合はpgx.ErrNoRowsを返す
+// 既に別の作品に属する書籍は、指定された作品へ付け替える
+func (r *bookRepositoryImpl) LinkWork(ctx context.Context, id int, workId int) (*db.Book, error) {
+	book, err := r.queries.UpdateBookWork(ctx, db.UpdateBookWorkParams{
+		ID:     int32(id),
+		WorkID: pgtype.Int4{Int32: int32(workId), Valid: true},
+	})
+	if err != nil {
+		log.Printf("Unable to execute BookRepositoryLinkWork: %d\n", err)
+		return nil, err
+	}
+
+	return &book, nil
+}
+
+// UnlinkWork は書籍がその作品に属していない場合にErrEditionNotFoundを返す
+func (r *bookRepositoryImpl) UnlinkWork(ctx context.Context, id int, workId int) error {
+	count, err := r.queries.ClearBookWork(ctx, db.ClearBookWorkParams{
+		ID:     int32(id),
+		WorkID: pgtype.Int4{Int32: int32(workId), Valid: true},
+	})
+	if err != nil {
+		log.Printf("Unable to execute BookRepositoryUnlinkWork: %d\n", err)
+		return err
+	}
+	if count == 0 {
+		return ErrEditionNotFound
+	}
+
+	return nil
+}
+
+func (r *bookRepositoryImpl) SearchBooks(ctx context.Context, param *db.SearchBooksParams) ([]db.Book, error) {
+	books, err := r.queries.SearchBooks(ctx, *param)
+	if err != nil {
+		log.Printf("Unable to execute BookRepositorySearchBooks: %d\n", err)
+		return nil, err
+	}
+
+	return books, nil
+}
+
+// ListBooksByIds は指定したIDの書籍をID順に返し、存在しないIDは無視する
+func (r *bookRepositoryImpl) ListBooksByIds(ctx context.Context, ids []int32) ([]db.Book, error) {
+	books, err := r.queries.ListBooksByIds(ctx, ids)
+	if err != nil {
+		log.Printf("Unable to execute BookRepositoryListBooksByIds: %d\n", err)
+		return nil, err
+	}
+
+	return books, nil
+}
+
+func (r *bookRepositoryImpl) ListBooksByAuthors(ctx context.Context, authors []string) ([]db.Book, error) {
+	books, err := r.queries.ListBooksByAuthors(ctx, authors)
+	if err != nil {
+		log.Printf("Unable to execute BookRepositoryListBooksByAuthors: %d\n", err)
+		return nil, err
+	}
+
+	return books, nil
+}
+
+func (r *bookRepositoryImpl) ListBooksByPublishers(ctx context.Context, publishers []string) ([]db.Book, error) {
+	books, err := r.queries.ListBooksByPublishers(ctx, publishers)
+	if err != nil {
+		log.Printf("Unable to execute BookRepositoryListBooksByPublishers: %d\n", err)
+		return nil, err
+	}
+
+	return books, nil
+}
+
+func (r *bookRepositoryImpl) ListBooksByStock(ctx context.Context, inStock bool) ([]db.Book, error) {
+	books, err := r.queries.ListBooksByStock(ctx, inStock)
+	if err != nil {
+		log.Printf("Unable to execute BookRepositoryListBooksByStock: %d\n", err)
+		return nil, err
+	}
+
+	return books, nil
+}
+
+// FilterBooks は指定された条件を全て満たす書籍を返し、無効値の条件は絞り込みに用いない
+// カテゴリは子孫のカテゴリも含めて絞り込み、タグはMatchAllTagsに応じていずれか、または全てを持つ書籍に絞り込む
+func (r *bookRepositoryImpl) FilterBooks(ctx context.Context, param *db.FilterBooksParams) ([]db.Book, error) {
+	books, err := r.queries.FilterBooks(ctx, *param)
+	if err != nil {
+		log.Printf("Unable to execute BookRepositoryFilterBooks: %d\n", err)
+		return nil, err
+	}
+
+	return books, nil
+}
+
+// ListBookRatings は書籍ごとのレビュー件数と評価の合計を返し、レビューが登録されたことのない書籍は含まない
+func (r *bookRepositoryImpl) ListBookRatings(ctx context.Context, bookIds []int32) ([]db.BookRating, error) {
+	ratings, err := r.queries.ListBookRatingsByBookIDs(ctx, bookIds)
+	if err != nil {
+		log.Printf("Unable to execute BookRepositoryListBookRatings: %d\n", err)
+		return nil, err
+	}
+
+	return ratings, nil
+}
+
+// ListDuplicateCandidates はISBNが一致する書籍と、タイトルと著者を連結した文字列のトライグラム類似度が
+// 下限以上の書籍を、ISBNの一致・類似度の高い順に返す
+func (r *bookRepositoryImpl) ListDuplicateCandidates(ctx context.Context, param *db.ListDuplicateBookCandidatesParams) ([]db.Book, error) {
+	books, err := r.queries.ListDuplicateBookCandidates(ctx, *param)
+	if err != nil {
+		log.Printf("Unable to execute BookRepositoryListDuplicateCandidates: %d\n", err)
+		return nil, err
+	}
+
+	return books, nil
+}
+
+// GetBookCollectionVersion は一覧に関わる表の変更のたびに進む版と変更日時に加え、現在までに有効になった価格・プロモーション・為替レートの最新の切り替わり日時を返す
+// 切り替わりが無い場合はUNIX時間の起点を返す
+func (r *bookRepositoryImpl) GetBookCollectionVersion(ctx context.Context) (*db.GetBookCollectionVersionRow, error) {
+	version, err := r.queries.GetBookCollectionVersion(ctx)
+	if err != nil {
+		log.Printf("Unable to execute BookRepositoryGetBookCollectionVersion: %d\n", err)
+		return nil, err
+	}
+
+	return &version, nil
+}
+
+// WithTx はトランザクション内で動くBookRepositoryをfnに渡し、fnがエラーを返さなければコミットする
+// fnの中で呼んだメソッドが自身でトランザクションを使う場合は、セーブポイントとして入れ子になる
+func (r *bookRepositoryImpl) WithTx(ctx context.Context, fn func(repo BookRepository) error) error {
+	tx, err := r.beginner.Begin(ctx)
+	if err != nil {
+		log.Printf("Unable to execute BookRepositoryWithTx: %d\n", err)
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(&bookRepositoryImpl{queries: r.queries.WithTx(tx), beginner: tx}); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		log.Printf("Unable to execute BookRepositoryWithTx: %d\n", err)
+		return err
+	}
+
+	return nil
+}
+
+// AddEvents はドメインイベントをアウトボックスに登録する
+// 書籍の変更と同じトランザクションで登録するため、WithTxのfnの中で呼び出す
+func (r *bookRepositoryImpl) AddEvents(ctx context.Context, events ...event.Event) error {
+	for _, ev := range events {
+		err := r.queries.CreateOutboxEvent(ctx, db.CreateOutboxEventParams{
+			EventType: string(ev.Type),
+			BookID:    ev.BookID,
+			Payload:   ev.Payload,
+		})
+		if err != nil {
+			log.Printf("Unable to execute BookRepositoryAddEvents: %d\n", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetIdempotencyKey は有効期限内の冪等キーを返し、無い場合はpgx.ErrNoRowsを返す
+func (r *bookRepositoryImpl) GetIdempotencyKey(ctx context.Context, key string) (*db.IdempotencyKey, error) {
+	idempotencyKey, err := r.queries.GetIdempotencyKey(ctx, key)
+	if err != nil {
+		log.Printf("Unable to execute BookRepositoryGetIdempotencyKey: %d\n", err)
+		return nil, err
+	}
+
+	return &idempotencyKey, nil
+}
+
+// SaveIdempotencyKey は冪等キーと登録の結果を保存し、有効期限内の同じキーが既にあれば保存せずにfalseを返す
+// 同じキーを保存中のトランザクションがあれば、その終了を待ってから判定する
+func (r *bookRepositoryImpl) SaveIdempotencyKey(ctx context.Context, param *db.CreateIdempotencyKeyParams) (bool, error) {
+	n, err := r.queries.CreateIdempotencyKey(ctx, *param)
+	if err != nil {
+		log.Printf("Unable to execute BookRepositorySaveIdempotencyKey: %d\n", err)
+		return false, err
+	}
+
+	return n > 0, nil
+}
+
+// DeleteExpiredIdempotencyKeys は有効期限を過ぎた冪等キーを削除し、削除した件数を返す
+func (r *bookRepositoryImpl) DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error) {
+	n, err := r.queries.DeleteExpiredIdempotencyKeys(ctx)
+	if err != nil {
+		log.Printf("Unable to execute BookRepositoryDeleteExpiredIdempotencyKeys: %d\n", err)
+		return 0, err
+	}
+
+	return n, nil
+}
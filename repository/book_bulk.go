@@ -0,0 +1,230 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/job"
+	"github.com/rentaro-m-b/ai-model-exam/money"
+)
+
+const (
+	// BookAuditActionBulkUpdate と BookAuditActionBulkDelete は書籍の一括更新・一括削除を表す監査ログの操作名
+	BookAuditActionBulkUpdate = "bulk_update"
+	BookAuditActionBulkDelete = "bulk_delete"
+)
+
+// BookPatch は一括更新で書籍に設定する値で、Validでない項目は変更しない
+type BookPatch struct {
+	Publisher pgtype.Text
+	Price     pgtype.Int4
+}
+
+// bookPatchValues は一括更新で変更できる項目の値で、価格は価格履歴の有効な価格とその通貨
+type bookPatchValues struct {
+	Publisher string `json:"publisher"`
+	Price     *int64 `json:"price"`
+	Currency  string `json:"currency,omitempty"`
+}
+
+func newBookPatchValues(book *db.Book, price *db.BookPrice) bookPatchValues {
+	values := bookPatchValues{Publisher: book.Publisher.String}
+	if price != nil {
+		values.Price = &price.Amount
+		values.Currency = price.Currency
+	}
+
+	return values
+}
+
+// bulkUpdateAuditDetail は一括更新の監査ログに記録する、書籍ごとの変更前後の値
+type bulkUpdateAuditDetail struct {
+	Before bookPatchValues `json:"before"`
+	After  bookPatchValues `json:"after"`
+}
+
+// bulkDeleteAuditDetail は一括削除の監査ログに記録する内容
+// 書籍は削除されるため、主な書誌情報を残しておく
+type bulkDeleteAuditDetail struct {
+	Title     string  `json:"title"`
+	Author    string  `json:"author"`
+	Publisher string  `json:"publisher"`
+	ISBN      *string `json:"isbn"`
+}
+
+// ListBooksByFilter は条件に一致する書籍をID順に返し、Validでない（nilの）条件は使わない
+func (r *bookRepositoryImpl) ListBooksByFilter(ctx context.Context, filter *db.ListBooksByFilterParams) ([]db.Book, error) {
+	books, err := r.queries.ListBooksByFilter(ctx, *filter)
+	if err != nil {
+		log.Printf("Unable to execute BookRepositoryListBooksByFilter: %d\n", err)
+		return nil, err
+	}
+
+	return books, nil
+}
+
+// BulkUpdateBooks は条件に一致する書籍をロックしてpatchの値に更新し、更新後の書籍をID順に返す
+// 価格を指定した場合は、現在有効な価格が既定の通貨の指定の価格と異なる書籍の価格履歴に、即時に有効な価格を登録する
+// 書籍ごとに変更前後の出版社と有効な価格を監査ログに登録する
+func (r *bookRepositoryImpl) BulkUpdateBooks(ctx context.Context, filter *db.ListBooksByFilterParams, patch *BookPatch) ([]db.Book, error) {
+	var updated []db.Book
+	err := withTx(ctx, r.beginner, r.queries, func(q *db.Queries) error {
+		locked, err := q.LockBooksByFilter(ctx, db.LockBooksByFilterParams(*filter))
+		if err != nil || len(locked) == 0 {
+			return err
+		}
+		before := make(map[int32]db.Book, len(locked))
+		ids := make([]int32, 0, len(locked))
+		for _, book := range locked {
+			before[book.ID] = book
+			ids = append(ids, book.ID)
+		}
+
+		updated, err = q.BulkUpdateBooks(ctx, db.BulkUpdateBooksParams{
+			Publisher: patch.Publisher,
+			Price:     patch.Price,
+			Ids:       ids,
+		})
+		if err != nil {
+			return err
+		}
+		slices.SortFunc(updated, func(a, b db.Book) int {
+			return int(a.ID - b.ID)
+		})
+
+		// books.priceは価格履歴で予約した価格の切り替わりを反映しないため、比較と監査ログには現在有効な価格を使う
+		now := time.Now()
+		prices, err := q.ListBookPricesInEffect(ctx, db.ListBookPricesInEffectParams{
+			BookIds: ids,
+			At:      pgtype.Timestamptz{Time: now, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+		inEffect := make(map[int32]db.BookPrice, len(prices))
+		for _, price := range prices {
+			inEffect[price.BookID] = price
+		}
+		for _, book := range updated {
+			old := before[book.ID]
+			var current *db.BookPrice
+			if price, ok := inEffect[book.ID]; ok {
+				current = &price
+			}
+			next := current
+			if patch.Price.Valid && (current == nil || current.Currency != money.DefaultCurrency || current.Amount != int64(patch.Price.Int32)) {
+				scheduled, err := schedulePrice(ctx, q, &SchedulePriceParams{
+					BookID:        book.ID,
+					Currency:      money.DefaultCurrency,
+					Amount:        int64(patch.Price.Int32),
+					EffectiveFrom: now,
+				})
+				if err != nil {
+					return err
+				}
+				next = &scheduled
+			}
+
+			b, err := json.Marshal(bulkUpdateAuditDetail{
+				Before: newBookPatchValues(&old, current),
+				After:  newBookPatchValues(&book, next),
+			})
+			if err != nil {
+				return err
+			}
+			_, err = q.CreateBookAuditLog(ctx, db.CreateBookAuditLogParams{
+				BookID: book.ID,
+				Action: BookAuditActionBulkUpdate,
+				Detail: b,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		log.Printf("Unable to execute BookRepositoryBulkUpdateBooks: %d\n", err)
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// DeleteBooks は条件に一致する書籍をロックして削除し、削除した書籍をID順に返す
+// 書籍に紐づくカテゴリ・タグ・価格履歴・在庫・貸出・予約・レビューなどは外部キーにより併せて削除される
+// 書籍ごとに削除前の書誌情報を監査ログに登録する
+func (r *bookRepositoryImpl) DeleteBooks(ctx context.Context, filter *db.ListBooksByFilterParams) ([]db.Book, error) {
+	var deleted []db.Book
+	err := withTx(ctx, r.beginner, r.queries, func(q *db.Queries) error {
+		var err error
+		deleted, err = q.LockBooksByFilter(ctx, db.LockBooksByFilterParams(*filter))
+		if err != nil || len(deleted) == 0 {
+			return err
+		}
+		ids := make([]int32, 0, len(deleted))
+		for _, book := range deleted {
+			ids = append(ids, book.ID)
+		}
+
+		if _, err := q.DeleteBooksByIds(ctx, ids); err != nil {
+			return err
+		}
+		for _, book := range deleted {
+			detail := bulkDeleteAuditDetail{
+				Title:     book.Title.String,
+				Author:    book.Author.String,
+				Publisher: book.Publisher.String,
+			}
+			if book.Isbn.Valid {
+				detail.ISBN = &book.Isbn.String
+			}
+			b, err := json.Marshal(detail)
+			if err != nil {
+				return err
+			}
+			_, err = q.CreateBookAuditLog(ctx, db.CreateBookAuditLogParams{
+				BookID: book.ID,
+				Action: BookAuditActionBulkDelete,
+				Detail: b,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		log.Printf("Unable to execute BookRepositoryDeleteBooks: %d\n", err)
+		return nil, err
+	}
+
+	return deleted, nil
+}
+
+// CommitJobResult は一括更新・一括削除と同じトランザクションでジョブの結果を記録し、実行し直したジョブが変更を繰り返さないようにする
+// ジョブのリースを失っていた場合はjob.ErrLeaseLostを返し、トランザクションを取り消させる
+func (r *bookRepositoryImpl) CommitJobResult(ctx context.Context, j *job.Job, result []byte) error {
+	n, err := r.queries.CommitJobResult(ctx, db.CommitJobResultParams{
+		CommittedResult: result,
+		ID:              j.ID,
+		Attempts:        int32(j.Attempts),
+	})
+	if err != nil {
+		log.Printf("Unable to execute BookRepositoryCommitJobResult: %d\n", err)
+		return err
+	}
+	if n == 0 {
+		log.Printf("Unable to execute BookRepositoryCommitJobResult: %d\n", job.ErrLeaseLost)
+		return job.ErrLeaseLost
+	}
+
+	return nil
+}
@@ -0,0 +1,281 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/job"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+var auditLogColumns = []string{"id", "book_id", "action", "detail", "created_at"}
+
+func TestListBooksByFilter(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	filter := db.ListBooksByFilterParams{Publisher: pgtype.Text{String: "test publisher", Valid: true}}
+	expect := db.Book{
+		ID:        1,
+		Title:     pgtype.Text{String: "test title 1", Valid: true},
+		Publisher: pgtype.Text{String: "test publisher", Valid: true},
+	}
+
+	// 指定していない条件はNULLとして渡す
+	mock.ExpectQuery(`-- name: ListBooksByFilter :many`).
+		WithArgs([]int32(nil), pgtype.Text{}, filter.Publisher).
+		WillReturnRows(bookRow(expect))
+
+	repo := repository.NewBookRepository(db.New(mock), mock)
+	books, err := repo.ListBooksByFilter(context.Background(), &filter)
+	assert.NoError(t, err)
+	assert.Equal(t, []db.Book{expect}, books)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestBulkUpdateBooks(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	filter := db.ListBooksByFilterParams{Publisher: pgtype.Text{String: "old publisher", Valid: true}}
+	patch := repository.BookPatch{
+		Publisher: pgtype.Text{String: "new publisher", Valid: true},
+		Price:     pgtype.Int4{Int32: 300, Valid: true},
+	}
+	before := []db.Book{
+		{ID: 1, Publisher: pgtype.Text{String: "old publisher", Valid: true}, Price: pgtype.Int4{Int32: 100, Valid: true}},
+		{ID: 2, Publisher: pgtype.Text{String: "old publisher", Valid: true}, Price: pgtype.Int4{Int32: 300, Valid: true}},
+	}
+	after := []db.Book{
+		{ID: 1, Publisher: pgtype.Text{String: "new publisher", Valid: true}, Price: pgtype.Int4{Int32: 300, Valid: true}},
+		{ID: 2, Publisher: pgtype.Text{String: "new publisher", Valid: true}, Price: pgtype.Int4{Int32: 300, Valid: true}},
+	}
+	now := pgtype.Timestamptz{Time: time.Now(), Valid: true}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`-- name: LockBooksByFilter :many`).
+		WithArgs([]int32(nil), pgtype.Text{}, filter.Publisher).
+		WillReturnRows(bookRow(before...))
+	// 更新の結果の順序に依らず、ID順に記録する
+	mock.ExpectQuery(`-- name: BulkUpdateBooks :many`).
+		WithArgs(patch.Publisher, patch.Price, []int32{1, 2}).
+		WillReturnRows(bookRow(after[1], after[0]))
+	// 現在有効な価格が変わる書籍のみ、価格履歴に登録する
+	mock.ExpectQuery(`-- name: ListBookPricesInEffect :many`).
+		WithArgs([]int32{1, 2}, pgxmock.AnyArg()).
+		WillReturnRows(pgxmock.NewRows(priceColumns).
+			AddRow(int32(3), int32(1), "JPY", int64(100), now, pgtype.Timestamptz{}).
+			AddRow(int32(4), int32(2), "JPY", int64(300), now, pgtype.Timestamptz{}))
+	mock.ExpectQuery(`-- name: GetNextBookPriceStart :one`).
+		WithArgs(int32(1), pgxmock.AnyArg()).
+		WillReturnError(pgx.ErrNoRows)
+	mock.ExpectExec(`-- name: CloseBookPrice :exec`).
+		WithArgs(pgxmock.AnyArg(), int32(1)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectQuery(`-- name: CreateBookPrice :one`).
+		WithArgs(int32(1), "JPY", int64(300), pgxmock.AnyArg(), pgtype.Timestamptz{}).
+		WillReturnRows(pgxmock.NewRows(priceColumns).AddRow(int32(5), int32(1), "JPY", int64(300), now, pgtype.Timestamptz{}))
+	detail1 := []byte(`{"before":{"publisher":"old publisher","price":100,"currency":"JPY"},"after":{"publisher":"new publisher","price":300,"currency":"JPY"}}`)
+	mock.ExpectQuery(`-- name: CreateBookAuditLog :one`).
+		WithArgs(int32(1), repository.BookAuditActionBulkUpdate, detail1).
+		WillReturnRows(pgxmock.NewRows(auditLogColumns).AddRow(int64(1), int32(1), repository.BookAuditActionBulkUpdate, detail1, now))
+	detail2 := []byte(`{"before":{"publisher":"old publisher","price":300,"currency":"JPY"},"after":{"publisher":"new publisher","price":300,"currency":"JPY"}}`)
+	mock.ExpectQuery(`-- name: CreateBookAuditLog :one`).
+		WithArgs(int32(2), repository.BookAuditActionBulkUpdate, detail2).
+		WillReturnRows(pgxmock.NewRows(auditLogColumns).AddRow(int64(2), int32(2), repository.BookAuditActionBulkUpdate, detail2, now))
+	mock.ExpectCommit()
+
+	repo := repository.NewBookRepository(db.New(mock), mock)
+	books, err := repo.BulkUpdateBooks(context.Background(), &filter, &patch)
+	assert.NoError(t, err)
+	assert.Equal(t, after, books)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestBulkUpdateBooksScheduledPriceInEffect(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	filter := db.ListBooksByFilterParams{Ids: []int32{1}}
+	patch := repository.BookPatch{Price: pgtype.Int4{Int32: 100, Valid: true}}
+	// books.priceは元の価格のままでも、予約した価格が有効になっていれば元の価格に戻す
+	book := db.Book{ID: 1, Price: pgtype.Int4{Int32: 100, Valid: true}}
+	now := pgtype.Timestamptz{Time: time.Now(), Valid: true}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`-- name: LockBooksByFilter :many`).
+		WithArgs([]int32{1}, pgtype.Text{}, pgtype.Text{}).
+		WillReturnRows(bookRow(book))
+	mock.ExpectQuery(`-- name: BulkUpdateBooks :many`).
+		WithArgs(patch.Publisher, patch.Price, []int32{1}).
+		WillReturnRows(bookRow(book))
+	mock.ExpectQuery(`-- name: ListBookPricesInEffect :many`).
+		WithArgs([]int32{1}, pgxmock.AnyArg()).
+		WillReturnRows(pgxmock.NewRows(priceColumns).AddRow(int32(3), int32(1), "JPY", int64(80), now, pgtype.Timestamptz{}))
+	mock.ExpectQuery(`-- name: GetNextBookPriceStart :one`).
+		WithArgs(int32(1), pgxmock.AnyArg()).
+		WillReturnError(pgx.ErrNoRows)
+	mock.ExpectExec(`-- name: CloseBookPrice :exec`).
+		WithArgs(pgxmock.AnyArg(), int32(1)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectQuery(`-- name: CreateBookPrice :one`).
+		WithArgs(int32(1), "JPY", int64(100), pgxmock.AnyArg(), pgtype.Timestamptz{}).
+		WillReturnRows(pgxmock.NewRows(priceColumns).AddRow(int32(5), int32(1), "JPY", int64(100), now, pgtype.Timestamptz{}))
+	// 監査ログにもbooks.priceではなく、有効な価格を記録する
+	detail := []byte(`{"before":{"publisher":"","price":80,"currency":"JPY"},"after":{"publisher":"","price":100,"currency":"JPY"}}`)
+	mock.ExpectQuery(`-- name: CreateBookAuditLog :one`).
+		WithArgs(int32(1), repository.BookAuditActionBulkUpdate, detail).
+		WillReturnRows(pgxmock.NewRows(auditLogColumns).AddRow(int64(1), int32(1), repository.BookAuditActionBulkUpdate, detail, now))
+	mock.ExpectCommit()
+
+	repo := repository.NewBookRepository(db.New(mock), mock)
+	books, err := repo.BulkUpdateBooks(context.Background(), &filter, &patch)
+	assert.NoError(t, err)
+	assert.Equal(t, []db.Book{book}, books)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestBulkUpdateBooksNoMatch(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	filter := db.ListBooksByFilterParams{Ids: []int32{99}}
+	patch := repository.BookPatch{Publisher: pgtype.Text{String: "new publisher", Valid: true}}
+
+	// 一致する書籍が無ければ更新しない
+	mock.ExpectBegin()
+	mock.ExpectQuery(`-- name: LockBooksByFilter :many`).
+		WithArgs([]int32{99}, pgtype.Text{}, pgtype.Text{}).
+		WillReturnRows(pgxmock.NewRows(bookColumns))
+	mock.ExpectCommit()
+
+	repo := repository.NewBookRepository(db.New(mock), mock)
+	books, err := repo.BulkUpdateBooks(context.Background(), &filter, &patch)
+	assert.NoError(t, err)
+	assert.Empty(t, books)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestBulkUpdateBooksFailureRollback(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	filter := db.ListBooksByFilterParams{Ids: []int32{1}}
+	patch := repository.BookPatch{Publisher: pgtype.Text{String: "new publisher", Valid: true}}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`-- name: LockBooksByFilter :many`).
+		WithArgs([]int32{1}, pgtype.Text{}, pgtype.Text{}).
+		WillReturnRows(bookRow(db.Book{ID: 1}))
+	mock.ExpectQuery(`-- name: BulkUpdateBooks :many`).
+		WithArgs(patch.Publisher, patch.Price, []int32{1}).
+		WillReturnError(errors.New("error"))
+	mock.ExpectRollback()
+
+	repo := repository.NewBookRepository(db.New(mock), mock)
+	books, err := repo.BulkUpdateBooks(context.Background(), &filter, &patch)
+	assert.Error(t, err)
+	assert.Nil(t, books)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestDeleteBooks(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	filter := db.ListBooksByFilterParams{Author: pgtype.Text{String: "test author", Valid: true}}
+	deleted := []db.Book{
+		{
+			ID:        1,
+			Title:     pgtype.Text{String: "test title 1", Valid: true},
+			Author:    pgtype.Text{String: "test author", Valid: true},
+			Publisher: pgtype.Text{String: "test publisher", Valid: true},
+			Isbn:      pgtype.Text{String: "9784873119045", Valid: true},
+		},
+	}
+	now := pgtype.Timestamptz{Time: time.Now(), Valid: true}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`-- name: LockBooksByFilter :many`).
+		WithArgs([]int32(nil), filter.Author, pgtype.Text{}).
+		WillReturnRows(bookRow(deleted...))
+	mock.ExpectExec(`-- name: DeleteBooksByIds :execrows`).
+		WithArgs([]int32{1}).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	detail := []byte(`{"title":"test title 1","author":"test author","publisher":"test publisher","isbn":"9784873119045"}`)
+	mock.ExpectQuery(`-- name: CreateBookAuditLog :one`).
+		WithArgs(int32(1), repository.BookAuditActionBulkDelete, detail).
+		WillReturnRows(pgxmock.NewRows(auditLogColumns).AddRow(int64(1), int32(1), repository.BookAuditActionBulkDelete, detail, now))
+	mock.ExpectCommit()
+
+	repo := repository.NewBookRepository(db.New(mock), mock)
+	books, err := repo.DeleteBooks(context.Background(), &filter)
+	assert.NoError(t, err)
+	assert.Equal(t, deleted, books)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestCommitJobResultFailureLeaseLost(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	// 他のワーカーがジョブを取得し直していれば、結果を記録せずにリースを失ったことを返す
+	result := []byte(`{"affected":1,"book_ids":[1]}`)
+	mock.ExpectExec(`-- name: CommitJobResult :execrows`).
+		WithArgs(result, int64(5), int32(1)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+	repo := repository.NewBookRepository(db.New(mock), mock)
+	err = repo.CommitJobResult(context.Background(), &job.Job{ID: 5, Attempts: 1}, result)
+	assert.ErrorIs(t, err, job.ErrLeaseLost)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
@@ -0,0 +1,287 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"slices"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/rentaro-m-b/ai-model-exam/cache"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	defaultBookCacheTTL = 30 * time.Second
+	bookListCacheKey    = "books"
+)
+
+func bookCacheKey(id int) string {
+	return "book:" + strconv.Itoa(id)
+}
+
+// BookCacheConfig の未指定（ゼロ値）の項目には既定値を使う
+type BookCacheConfig struct {
+	// TTL は書籍をキャッシュする時間
+	TTL time.Duration
+	// Metrics はキャッシュのヒットとミスを数える
+	Metrics *cache.Metrics
+}
+
+// cachedBookRepositoryImpl はGetBookByIdとListBooksの結果をキャッシュするBookRepositoryのデコレータ
+// 書籍を登録・更新・統合・一括更新・一括削除した場合と、作品との紐付けを変更した場合は、影響するキャッシュを削除する
+type cachedBookRepositoryImpl struct {
+	BookRepository
+	cache   cache.Store
+	ttl     time.Duration
+	metrics *cache.Metrics
+	group   *singleflight.Group
+	// generation はキャッシュを削除するたびに進め、取得中に削除された場合は取得した値をキャッシュしない
+	generation *atomic.Uint64
+	// pending はトランザクション中に更新した書籍のキャッシュのキーで、コミット後に削除する
+	// トランザクションの外ではnil
+	pending *[]string
+}
+
+func NewCachedBookRepository(repository BookRepository, store cache.Store, config *BookCacheConfig) BookRepository {
+	c := BookCacheConfig{}
+	if config != nil {
+		c = *config
+	}
+	if c.TTL <= 0 {
+		c.TTL = defaultBookCacheTTL
+	}
+	if c.Metrics == nil {
+		c.Metrics = &cache.Metrics{}
+	}
+
+	return &cachedBookRepositoryImpl{
+		BookRepository: repository,
+		cache:          store,
+		ttl:            c.TTL,
+		metrics:        c.Metrics,
+		group:          &singleflight.Group{},
+		generation:     &atomic.Uint64{},
+	}
+}
+
+func (r *cachedBookRepositoryImpl) ListBooks(ctx context.Context) ([]db.Book, error) {
+	if r.pending != nil {
+		return r.BookRepository.ListBooks(ctx)
+	}
+
+	books, err := readThrough(ctx, r, bookListCacheKey, r.BookRepository.ListBooks)
+	if err != nil {
+		return nil, err
+	}
+
+	// 同時に取得した呼び出し元の間で配列を共有しないよう複製する
+	return slices.Clone(books), nil
+}
+
+// GetBookById は書籍が存在しない場合をキャッシュしないため、統合された書籍のリダイレクトは常にデータベースから引く
+func (r *cachedBookRepositoryImpl) GetBookById(ctx context.Context, id int) (*db.Book, error) {
+	if r.pending != nil {
+		return r.BookRepository.GetBookById(ctx, id)
+	}
+
+	book, err := readThrough(ctx, r, bookCacheKey(id), func(ctx context.Context) (db.Book, error) {
+		book, err := r.BookRepository.GetBookById(ctx, id)
+		if err != nil {
+			return db.Book{}, err
+		}
+		return *book, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &book, nil
+}
+
+func (r *cachedBookRepositoryImpl) CreateBook(ctx context.Context, param *db.CreateBookParams) (*db.Book, error) {
+	book, err := r.BookRepository.CreateBook(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+	r.invalidate(ctx, bookListCacheKey)
+
+	return book, nil
+}
+
+func (r *cachedBookRepositoryImpl) UpdateBook(ctx context.Context, param *db.UpdateBookParams) (*db.Book, error) {
+	book, err := r.BookRepository.UpdateBook(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+	r.invalidate(ctx, bookCacheKey(int(param.ID)), bookListCacheKey)
+
+	return book, nil
+}
+
+func (r *cachedBookRepositoryImpl) LinkWork(ctx context.Context, id int, workId int) (*db.Book, error) {
+	book, err := r.BookRepository.LinkWork(ctx, id, workId)
+	if err != nil {
+		return nil, err
+	}
+	r.invalidate(ctx, bookCacheKey(id), bookListCacheKey)
+
+	return book, nil
+}
+
+func (r *cachedBookRepositoryImpl) UnlinkWork(ctx context.Context, id int, workId int) error {
+	if err := r.BookRepository.UnlinkWork(ctx, id, workId); err != nil {
+		return err
+	}
+	r.invalidate(ctx, bookCacheKey(id), bookListCacheKey)
+
+	return nil
+}
+
+func (r *cachedBookRepositoryImpl) MergeBook(ctx context.Context, sourceId int, targetId int) (*db.Book, error) {
+	book, err := r.BookRepository.MergeBook(ctx, sourceId, targetId)
+	if err != nil {
+		return nil, err
+	}
+	r.invalidate(ctx, bookCacheKey(sourceId), bookCacheKey(targetId), bookListCacheKey)
+
+	return book, nil
+}
+
+func (r *cachedBookRepositoryImpl) BulkUpdateBooks(ctx context.Context, filter *db.ListBooksByFilterParams, patch *BookPatch) ([]db.Book, error) {
+	books, err := r.BookRepository.BulkUpdateBooks(ctx, filter, patch)
+	if err != nil {
+		return nil, err
+	}
+	r.invalidate(ctx, bookCacheKeys(books)...)
+
+	return books, nil
+}
+
+func (r *cachedBookRepositoryImpl) DeleteBooks(ctx context.Context, filter *db.ListBooksByFilterParams) ([]db.Book, error) {
+	books, err := r.BookRepository.DeleteBooks(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	r.invalidate(ctx, bookCacheKeys(books)...)
+
+	return books, nil
+}
+
+// bookCacheKeys は一括で変更した書籍と一覧のキャッシュのキーを返し、変更した書籍が無ければ空を返す
+func bookCacheKeys(books []db.Book) []string {
+	if len(books) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(books)+1)
+	for _, book := range books {
+		keys = append(keys, bookCacheKey(int(book.ID)))
+	}
+
+	return append(keys, bookListCacheKey)
+}
+
+// WithTx はトランザクション中の読み込みにキャッシュを使わず、更新したキャッシュはコミット後に削除する
+func (r *cachedBookRepositoryImpl) WithTx(ctx context.Context, fn func(repo BookRepository) error) error {
+	if r.pending != nil {
+		return r.BookRepository.WithTx(ctx, func(repo BookRepository) error {
+			return fn(r.withRepository(repo, r.pending))
+		})
+	}
+
+	var pending []string
+	err := r.BookRepository.WithTx(ctx, func(repo BookRepository) error {
+		return fn(r.withRepository(repo, &pending))
+	})
+	// コミットに失敗した場合も、更新が反映されたかが分からないため削除する
+	r.invalidate(ctx, pending...)
+
+	return err
+}
+
+func (r *cachedBookRepositoryImpl) withRepository(repo BookRepository, pending *[]string) *cachedBookRepositoryImpl {
+	return &cachedBookRepositoryImpl{
+		BookRepository: repo,
+		cache:          r.cache,
+		ttl:            r.ttl,
+		metrics:        r.metrics,
+		group:          r.group,
+		generation:     r.generation,
+		pending:        pending,
+	}
+}
+
+// invalidate はキャッシュを削除し、実行中の取得の結果を以降の呼び出し元と共有せず、キャッシュにも書き込ませないようにする
+// 削除に失敗しても更新は済んでいるため、エラーは返さずにキャッシュが切れるのを待つ
+func (r *cachedBookRepositoryImpl) invalidate(ctx context.Context, keys ...string) {
+	if r.pending != nil {
+		*r.pending = append(*r.pending, keys...)
+		return
+	}
+	if len(keys) == 0 {
+		return
+	}
+
+	r.generation.Add(1)
+	for _, key := range keys {
+		r.group.Forget(key)
+	}
+	if err := r.cache.Delete(ctx, keys...); err != nil {
+		log.Printf("Unable to execute CachedBookRepositoryInvalidate: %d\n", err)
+	}
+}
+
+// readThrough はキャッシュにあればその値を返し、無ければfetchで取得してキャッシュする
+// 同じキーの取得が同時に行われた場合は、最初の呼び出しの結果を共有してデータベースへの問い合わせを1回にまとめる
+// キャッシュの読み書きに失敗した場合はデータベースから取得した値を返す
+func readThrough[T any](ctx context.Context, r *cachedBookRepositoryImpl, key string, fetch func(ctx context.Context) (T, error)) (T, error) {
+	b, err := r.cache.Get(ctx, key)
+	if err == nil {
+		var v T
+		if err := json.Unmarshal(b, &v); err == nil {
+			r.metrics.Hit()
+			return v, nil
+		}
+		log.Printf("Unable to execute CachedBookRepositoryReadThrough: %d\n", err)
+	} else if !errors.Is(err, cache.ErrCacheMiss) {
+		log.Printf("Unable to execute CachedBookRepositoryReadThrough: %d\n", err)
+	}
+	r.metrics.Miss()
+
+	v, err, _ := r.group.Do(key, func() (any, error) {
+		generation := r.generation.Load()
+		v, err := fetch(ctx)
+		if err != nil {
+			return v, err
+		}
+		// 取得中に更新された場合は、更新前の値の可能性があるためキャッシュしない
+		if r.generation.Load() != generation {
+			return v, nil
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			log.Printf("Unable to execute CachedBookRepositoryReadThrough: %d\n", err)
+			return v, nil
+		}
+		if err := r.cache.Set(ctx, key, b, r.ttl); err != nil {
+			log.Printf("Unable to execute CachedBookRepositoryReadThrough: %d\n", err)
+		}
+		// 書き込みの直前に更新されてキャッシュの削除が先に済んだ場合は、書き込んだ値を削除し直す
+		if r.generation.Load() != generation {
+			if err := r.cache.Delete(ctx, key); err != nil {
+				log.Printf("Unable to execute CachedBookRepositoryReadThrough: %d\n", err)
+			}
+		}
+		return v, nil
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return v.(T), nil
+}
@@ -0,0 +1,303 @@
+package repository_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/redis/go-redis/v9"
+	"github.com/rentaro-m-b/ai-model-exam/cache"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	mock_repository "github.com/rentaro-m-b/ai-model-exam/repository/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCachedGetBookById(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	book := db.Book{
+		ID:              1,
+		Title:           pgtype.Text{String: "test title 1", Valid: true},
+		Price:           pgtype.Int4{Int32: 1000, Valid: true},
+		PublicationDate: pgtype.Date{Time: time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+	}
+	mockRepo := mock_repository.NewMockBookRepository(ctrl)
+	mockRepo.EXPECT().GetBookById(gomock.Any(), 1).Return(&book, nil).Times(1)
+
+	// 2回目以降はキャッシュから返す
+	metrics := &cache.Metrics{}
+	repo := repository.NewCachedBookRepository(mockRepo, cache.NewLRU(10), &repository.BookCacheConfig{Metrics: metrics})
+	for range 3 {
+		got, err := repo.GetBookById(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, &book, got)
+	}
+	assert.Equal(t, int64(2), metrics.Hits())
+	assert.Equal(t, int64(1), metrics.Misses())
+}
+
+func TestCachedGetBookByIdFailureNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// 存在しない書籍はキャッシュしない
+	mockRepo := mock_repository.NewMockBookRepository(ctrl)
+	mockRepo.EXPECT().GetBookById(gomock.Any(), 1).Return(nil, pgx.ErrNoRows).Times(2)
+
+	repo := repository.NewCachedBookRepository(mockRepo, cache.NewLRU(10), nil)
+	for range 2 {
+		_, err := repo.GetBookById(context.Background(), 1)
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+	}
+}
+
+func TestCachedGetBookByIdSingleflight(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// 同時に取得した場合はデータベースへの問い合わせを1回にまとめる
+	const callers = 10
+	var started sync.WaitGroup
+	started.Add(callers)
+	mockRepo := mock_repository.NewMockBookRepository(ctrl)
+	mockRepo.EXPECT().GetBookById(gomock.Any(), 1).DoAndReturn(func(ctx context.Context, id int) (*db.Book, error) {
+		started.Wait()
+		time.Sleep(10 * time.Millisecond)
+		return &db.Book{ID: 1}, nil
+	}).Times(1)
+
+	metrics := &cache.Metrics{}
+	repo := repository.NewCachedBookRepository(mockRepo, cache.NewLRU(10), &repository.BookCacheConfig{Metrics: metrics})
+	var wg sync.WaitGroup
+	for range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			started.Done()
+			book, err := repo.GetBookById(context.Background(), 1)
+			assert.NoError(t, err)
+			assert.Equal(t, int32(1), book.ID)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int64(callers), metrics.Hits()+metrics.Misses())
+}
+
+func TestCachedListBooksInvalidate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockBookRepository(ctrl)
+	repo := repository.NewCachedBookRepository(mockRepo, cache.NewLRU(10), nil)
+	ctx := context.Background()
+
+	// 登録・更新・統合のたびに一覧をデータベースから取得し直す
+	gomock.InOrder(
+		mockRepo.EXPECT().ListBooks(gomock.Any()).Return([]db.Book{{ID: 1}}, nil),
+		mockRepo.EXPECT().CreateBook(gomock.Any(), gomock.Any()).Return(&db.Book{ID: 2}, nil),
+		mockRepo.EXPECT().ListBooks(gomock.Any()).Return([]db.Book{{ID: 1}, {ID: 2}}, nil),
+		mockRepo.EXPECT().UpdateBook(gomock.Any(), gomock.Any()).Return(&db.Book{ID: 2}, nil),
+		mockRepo.EXPECT().ListBooks(gomock.Any()).Return([]db.Book{{ID: 1}, {ID: 2}}, nil),
+		mockRepo.EXPECT().MergeBook(gomock.Any(), 2, 1).Return(&db.Book{ID: 1}, nil),
+		mockRepo.EXPECT().ListBooks(gomock.Any()).Return([]db.Book{{ID: 1}}, nil),
+	)
+
+	books, err := repo.ListBooks(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, books, 1)
+	books, err = repo.ListBooks(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, books, 1)
+
+	_, err = repo.CreateBook(ctx, &db.CreateBookParams{})
+	assert.NoError(t, err)
+	books, err = repo.ListBooks(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, books, 2)
+
+	_, err = repo.UpdateBook(ctx, &db.UpdateBookParams{ID: 2})
+	assert.NoError(t, err)
+	_, err = repo.ListBooks(ctx)
+	assert.NoError(t, err)
+
+	_, err = repo.MergeBook(ctx, 2, 1)
+	assert.NoError(t, err)
+	books, err = repo.ListBooks(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, books, 1)
+}
+
+func TestCachedBulkInvalidate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockBookRepository(ctrl)
+	store := cache.NewLRU(10)
+	repo := repository.NewCachedBookRepository(mockRepo, store, nil)
+	ctx := context.Background()
+
+	mockRepo.EXPECT().GetBookById(gomock.Any(), 1).Return(&db.Book{ID: 1}, nil)
+	mockRepo.EXPECT().GetBookById(gomock.Any(), 2).Return(&db.Book{ID: 2}, nil)
+	mockRepo.EXPECT().ListBooks(gomock.Any()).Return([]db.Book{{ID: 1}, {ID: 2}}, nil)
+	for _, id := range []int{1, 2} {
+		_, err := repo.GetBookById(ctx, id)
+		assert.NoError(t, err)
+	}
+	_, err := repo.ListBooks(ctx)
+	assert.NoError(t, err)
+
+	// 一括更新では変更した書籍と一覧のキャッシュのみを削除する
+	mockRepo.EXPECT().BulkUpdateBooks(gomock.Any(), gomock.Any(), gomock.Any()).Return([]db.Book{{ID: 1}}, nil)
+	_, err = repo.BulkUpdateBooks(ctx, &db.ListBooksByFilterParams{Ids: []int32{1}}, &repository.BookPatch{})
+	assert.NoError(t, err)
+	_, err = store.Get(ctx, "book:1")
+	assert.ErrorIs(t, err, cache.ErrCacheMiss)
+	_, err = store.Get(ctx, "book:2")
+	assert.NoError(t, err)
+	_, err = store.Get(ctx, "books")
+	assert.ErrorIs(t, err, cache.ErrCacheMiss)
+
+	// 一括削除では削除した書籍のキャッシュを削除する
+	mockRepo.EXPECT().DeleteBooks(gomock.Any(), gomock.Any()).Return([]db.Book{{ID: 2}}, nil)
+	_, err = repo.DeleteBooks(ctx, &db.ListBooksByFilterParams{Ids: []int32{2}})
+	assert.NoError(t, err)
+	_, err = store.Get(ctx, "book:2")
+	assert.ErrorIs(t, err, cache.ErrCacheMiss)
+}
+
+func TestCachedLinkWorkInvalidate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockBookRepository(ctrl)
+	repo := repository.NewCachedBookRepository(mockRepo, cache.NewLRU(10), nil)
+	ctx := context.Background()
+
+	// 作品との紐付けを変更するたびに書籍をデータベースから取得し直す
+	work := pgtype.Int4{Int32: 1, Valid: true}
+	gomock.InOrder(
+		mockRepo.EXPECT().GetBookById(gomock.Any(), 3).Return(&db.Book{ID: 3}, nil),
+		mockRepo.EXPECT().LinkWork(gomock.Any(), 3, 1).Return(&db.Book{ID: 3, WorkID: work}, nil),
+		mockRepo.EXPECT().GetBookById(gomock.Any(), 3).Return(&db.Book{ID: 3, WorkID: work}, nil),
+		mockRepo.EXPECT().UnlinkWork(gomock.Any(), 3, 1).Return(nil),
+		mockRepo.EXPECT().GetBookById(gomock.Any(), 3).Return(&db.Book{ID: 3}, nil),
+	)
+
+	book, err := repo.GetBookById(ctx, 3)
+	assert.NoError(t, err)
+	assert.False(t, book.WorkID.Valid)
+
+	_, err = repo.LinkWork(ctx, 3, 1)
+	assert.NoError(t, err)
+	book, err = repo.GetBookById(ctx, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, work, book.WorkID)
+
+	assert.NoError(t, repo.UnlinkWork(ctx, 3, 1))
+	book, err = repo.GetBookById(ctx, 3)
+	assert.NoError(t, err)
+	assert.False(t, book.WorkID.Valid)
+}
+
+func TestCachedGetBookByIdInvalidatedDuringFetch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockBookRepository(ctrl)
+	store := cache.NewLRU(10)
+	repo := repository.NewCachedBookRepository(mockRepo, store, nil)
+	ctx := context.Background()
+
+	// 取得中に更新された場合は、取得した更新前の値をキャッシュしない
+	gomock.InOrder(
+		mockRepo.EXPECT().GetBookById(gomock.Any(), 1).DoAndReturn(func(ctx context.Context, id int) (*db.Book, error) {
+			mockRepo.EXPECT().UpdateBook(gomock.Any(), gomock.Any()).Return(&db.Book{ID: 1}, nil)
+			_, err := repo.UpdateBook(ctx, &db.UpdateBookParams{ID: 1})
+			assert.NoError(t, err)
+			return &db.Book{ID: 1, Title: pgtype.Text{String: "old title", Valid: true}}, nil
+		}),
+		mockRepo.EXPECT().GetBookById(gomock.Any(), 1).Return(&db.Book{ID: 1, Title: pgtype.Text{String: "new title", Valid: true}}, nil),
+	)
+
+	book, err := repo.GetBookById(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "old title", book.Title.String)
+	_, err = store.Get(ctx, "book:1")
+	assert.ErrorIs(t, err, cache.ErrCacheMiss)
+
+	book, err = repo.GetBookById(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "new title", book.Title.String)
+}
+
+func TestCachedWithTx(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockBookRepository(ctrl)
+	txRepo := mock_repository.NewMockBookRepository(ctrl)
+	store := cache.NewLRU(10)
+	repo := repository.NewCachedBookRepository(mockRepo, store, nil)
+	ctx := context.Background()
+
+	mockRepo.EXPECT().GetBookById(gomock.Any(), 1).Return(&db.Book{ID: 1}, nil)
+	_, err := repo.GetBookById(ctx, 1)
+	assert.NoError(t, err)
+
+	// トランザクション中はキャッシュを使わず、コミットするまでキャッシュを削除しない
+	mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(repository.BookRepository) error) error {
+		return fn(txRepo)
+	})
+	txRepo.EXPECT().GetBookById(gomock.Any(), 1).Return(&db.Book{ID: 1}, nil)
+	txRepo.EXPECT().UpdateBook(gomock.Any(), gomock.Any()).Return(&db.Book{ID: 1}, nil)
+	err = repo.WithTx(ctx, func(tx repository.BookRepository) error {
+		if _, err := tx.GetBookById(ctx, 1); err != nil {
+			return err
+		}
+		if _, err := tx.UpdateBook(ctx, &db.UpdateBookParams{ID: 1}); err != nil {
+			return err
+		}
+		_, err := store.Get(ctx, "book:1")
+		assert.NoError(t, err)
+		return nil
+	})
+	assert.NoError(t, err)
+
+	_, err = store.Get(ctx, "book:1")
+	assert.ErrorIs(t, err, cache.ErrCacheMiss)
+}
+
+func TestCachedGetBookByIdRedis(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+
+	// Redisに保存した書籍を別のプロセスから読み出しても同じ値になる
+	book := db.Book{
+		ID:              1,
+		Title:           pgtype.Text{String: "test title 1", Valid: true},
+		PublicationDate: pgtype.Date{Time: time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+		Isbn:            pgtype.Text{String: "9784873119045", Valid: true},
+	}
+	mockRepo := mock_repository.NewMockBookRepository(ctrl)
+	mockRepo.EXPECT().GetBookById(gomock.Any(), 1).Return(&book, nil).Times(1)
+
+	ctx := context.Background()
+	_, err := repository.NewCachedBookRepository(mockRepo, cache.NewRedis(client, "test:"), nil).GetBookById(ctx, 1)
+	assert.NoError(t, err)
+	assert.True(t, server.Exists("test:book:1"))
+
+	got, err := repository.NewCachedBookRepository(mockRepo, cache.NewRedis(client, "test:"), nil).GetBookById(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, &book, got)
+}
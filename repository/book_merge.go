@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+)
+
+var (
+	ErrMergeSourceNotFound = errors.New("merge source book not found")
+	ErrMergeTargetNotFound = errors.New("merge target book not found")
+)
+
+// BookAuditActionMerge は書籍の統合を表す監査ログの操作名
+const BookAuditActionMerge = "merge"
+
+// mergeAuditDetail は統合の監査ログに記録する内容
+// 統合元の書籍は削除されるため、主な書誌情報を残しておく
+type mergeAuditDetail struct {
+	SourceID        int32   `json:"source_id"`
+	TargetID        int32   `json:"target_id"`
+	SourceTitle     string  `json:"source_title"`
+	SourceAuthor    string  `json:"source_author"`
+	SourcePublisher string  `json:"source_publisher"`
+	SourceISBN      *string `json:"source_isbn"`
+}
+
+// MergeBook は統合元の書籍を統合先へまとめ、統合後の統合先の書籍を返す
+// カテゴリ・タグ・在庫・入出庫履歴・貸出・予約・レビュー・プロモーション・監査ログを統合先へ付け替えたうえで
+// 統合元を削除し、統合元のIDから統合先へのリダイレクトと統合の監査ログを同じトランザクションで登録する
+//   - 同じ会員のレビューが両方にある場合は統合先のレビューを残す
+//   - 同じ会員の有効な予約が両方にある場合は統合元の予約を期限切れにする
+//   - 同じ拠点の在庫は数量を合算する
+//   - 価格履歴と表紙画像は統合先のものを使い、統合元の登録は削除する
+func (r *bookRepositoryImpl) MergeBook(ctx context.Context, sourceId int, targetId int) (*db.Book, error) {
+	var target db.Book
+	err := withTx(ctx, r.beginner, r.queries, func(q *db.Queries) error {
+		source, locked, err := lockMergeBooks(ctx, q, int32(sourceId), int32(targetId))
+		if err != nil {
+			return err
+		}
+		target = locked
+
+		ids := db.MergeBookCategoriesParams{SourceID: source.ID, TargetID: target.ID}
+		if err := q.MergeBookCategories(ctx, ids); err != nil {
+			return err
+		}
+		if err := q.MergeBookTags(ctx, db.MergeBookTagsParams(ids)); err != nil {
+			return err
+		}
+		if err := q.MergeInventories(ctx, db.MergeInventoriesParams(ids)); err != nil {
+			return err
+		}
+		if err := q.MoveStockMovements(ctx, db.MoveStockMovementsParams(ids)); err != nil {
+			return err
+		}
+		if err := q.MoveLoans(ctx, db.MoveLoansParams(ids)); err != nil {
+			return err
+		}
+		if err := q.ExpireConflictingReservations(ctx, db.ExpireConflictingReservationsParams{SourceID: source.ID, TargetID: target.ID}); err != nil {
+			return err
+		}
+		if err := q.MoveReservations(ctx, db.MoveReservationsParams(ids)); err != nil {
+			return err
+		}
+		if err := q.DeleteConflictingReviews(ctx, db.DeleteConflictingReviewsParams{SourceID: source.ID, TargetID: target.ID}); err != nil {
+			return err
+		}
+		if err := q.MoveReviews(ctx, db.MoveReviewsParams(ids)); err != nil {
+			return err
+		}
+		if err := q.RecalculateBookRating(ctx, target.ID); err != nil {
+			return err
+		}
+		if err := q.MovePromotions(ctx, db.MovePromotionsParams{
+			SourceID: pgtype.Int4{Int32: source.ID, Valid: true},
+			TargetID: pgtype.Int4{Int32: target.ID, Valid: true},
+		}); err != nil {
+			return err
+		}
+		if err := q.MoveBookAuditLogs(ctx, db.MoveBookAuditLogsParams(ids)); err != nil {
+			return err
+		}
+		if err := q.MoveBookRedirects(ctx, db.MoveBookRedirectsParams(ids)); err != nil {
+			return err
+		}
+
+		// 統合先が作品に属していなければ、統合元の作品を引き継ぐ
+		if !target.WorkID.Valid && source.WorkID.Valid {
+			if target, err = q.UpdateBookWork(ctx, db.UpdateBookWorkParams{ID: target.ID, WorkID: source.WorkID}); err != nil {
+				return err
+			}
+		}
+
+		if err := q.DeleteBookByID(ctx, source.ID); err != nil {
+			return err
+		}
+		if err := q.CreateBookRedirect(ctx, db.CreateBookRedirectParams{SourceID: source.ID, TargetID: target.ID}); err != nil {
+			return err
+		}
+
+		detail := mergeAuditDetail{
+			SourceID:        source.ID,
+			TargetID:        target.ID,
+			SourceTitle:     source.Title.String,
+			SourceAuthor:    source.Author.String,
+			SourcePublisher: source.Publisher.String,
+		}
+		if source.Isbn.Valid {
+			detail.SourceISBN = &source.Isbn.String
+		}
+		b, err := json.Marshal(detail)
+		if err != nil {
+			return err
+		}
+		_, err = q.CreateBookAuditLog(ctx, db.CreateBookAuditLogParams{
+			BookID: target.ID,
+			Action: BookAuditActionMerge,
+			Detail: b,
+		})
+		return err
+	})
+	if err != nil {
+		log.Printf("Unable to execute BookRepositoryMergeBook: %d\n", err)
+		return nil, err
+	}
+
+	return &target, nil
+}
+
+// lockMergeBooks は統合元と統合先の書籍をIDの小さい順にロックする
+// 逆向きの統合が同時に行われてもデッドロックしないよう、ロックの順序を揃える
+func lockMergeBooks(ctx context.Context, q *db.Queries, sourceId int32, targetId int32) (db.Book, db.Book, error) {
+	ids := []int32{sourceId, targetId}
+	if targetId < sourceId {
+		ids = []int32{targetId, sourceId}
+	}
+
+	books := make(map[int32]db.Book, len(ids))
+	for _, id := range ids {
+		book, err := q.GetBookByIDForUpdate(ctx, id)
+		switch {
+		case errors.Is(err, pgx.ErrNoRows) && id == sourceId:
+			return db.Book{}, db.Book{}, ErrMergeSourceNotFound
+		case errors.Is(err, pgx.ErrNoRows):
+			return db.Book{}, db.Book{}, ErrMergeTargetNotFound
+		case err != nil:
+			return db.Book{}, db.Book{}, err
+		}
+		books[id] = book
+	}
+
+	return books[sourceId], books[targetId], nil
+}
+
+// GetBookRedirect は統合により削除された書籍の統合先のIDを返し、統合されていない場合はpgx.ErrNoRowsを返す
+func (r *bookRepositoryImpl) GetBookRedirect(ctx context.Context, id int) (int32, error) {
+	targetId, err := r.queries.GetBookRedirect(ctx, int32(id))
+	if err != nil {
+		log.Printf("Unable to execute BookRepositoryGetBookRedirect: %d\n", err)
+		return 0, err
+	}
+
+	return targetId, nil
+}
+
+// ListBookAuditLogs は書籍の監査ログを新しい順に返す
+func (r *bookRepositoryImpl) ListBookAuditLogs(ctx context.Context, bookId int) ([]db.BookAuditLog, error) {
+	logs, err := r.queries.ListBookAuditLogsByBookID(ctx, int32(bookId))
+	if err != nil {
+		log.Printf("Unable to execute BookRepositoryListBookAuditLogs: %d\n", err)
+		return nil, err
+	}
+
+	return logs, nil
+}
@@ -0,0 +1,160 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeBook(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	source := db.Book{
+		ID:        2,
+		Title:     pgtype.Text{String: "test title", Valid: true},
+		Author:    pgtype.Text{String: "test author", Valid: true},
+		Publisher: pgtype.Text{String: "test publisher", Valid: true},
+		Price:     pgtype.Int4{Int32: 200, Valid: true},
+		WorkID:    pgtype.Int4{Int32: 5, Valid: true},
+		Isbn:      pgtype.Text{String: "9784873119045", Valid: true},
+	}
+	target := db.Book{
+		ID:        1,
+		Title:     pgtype.Text{String: "test title", Valid: true},
+		Author:    pgtype.Text{String: "test author", Valid: true},
+		Publisher: pgtype.Text{String: "test publisher", Valid: true},
+		Price:     pgtype.Int4{Int32: 200, Valid: true},
+	}
+	expect := target
+	expect.WorkID = source.WorkID
+
+	mock.ExpectBegin()
+	// IDの小さい統合先から順にロックする
+	mock.ExpectQuery(`-- name: GetBookByIDForUpdate :one`).
+		WithArgs(target.ID).
+		WillReturnRows(bookRow(target))
+	mock.ExpectQuery(`-- name: GetBookByIDForUpdate :one`).
+		WithArgs(source.ID).
+		WillReturnRows(bookRow(source))
+	for _, name := range []string{
+		"MergeBookCategories", "MergeBookTags", "MergeInventories", "MoveStockMovements", "MoveLoans",
+	} {
+		mock.ExpectExec(`-- name: `+name+` :exec`).
+			WithArgs(target.ID, source.ID).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	}
+	mock.ExpectExec(`-- name: ExpireConflictingReservations :exec`).
+		WithArgs(source.ID, target.ID).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+	mock.ExpectExec(`-- name: MoveReservations :exec`).
+		WithArgs(target.ID, source.ID).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec(`-- name: DeleteConflictingReviews :exec`).
+		WithArgs(source.ID, target.ID).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	mock.ExpectExec(`-- name: MoveReviews :exec`).
+		WithArgs(target.ID, source.ID).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec(`-- name: RecalculateBookRating :exec`).
+		WithArgs(target.ID).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectExec(`-- name: MovePromotions :exec`).
+		WithArgs(pgtype.Int4{Int32: target.ID, Valid: true}, pgtype.Int4{Int32: source.ID, Valid: true}).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+	for _, name := range []string{"MoveBookAuditLogs", "MoveBookRedirects"} {
+		mock.ExpectExec(`-- name: `+name+` :exec`).
+			WithArgs(target.ID, source.ID).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+	}
+	// 統合先は作品に属していないため、統合元の作品を引き継ぐ
+	mock.ExpectQuery(`-- name: UpdateBookWork :one`).
+		WithArgs(target.ID, source.WorkID).
+		WillReturnRows(bookRow(expect))
+	mock.ExpectExec(`-- name: DeleteBookByID :exec`).
+		WithArgs(source.ID).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	mock.ExpectExec(`-- name: CreateBookRedirect :exec`).
+		WithArgs(source.ID, target.ID).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	detail := []byte(`{"source_id":2,"target_id":1,"source_title":"test title","source_author":"test author","source_publisher":"test publisher","source_isbn":"9784873119045"}`)
+	mock.ExpectQuery(`-- name: CreateBookAuditLog :one`).
+		WithArgs(target.ID, repository.BookAuditActionMerge, detail).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "book_id", "action", "detail", "created_at"}).
+			AddRow(int64(1), target.ID, repository.BookAuditActionMerge, detail, pgtype.Timestamptz{Time: time.Now(), Valid: true}))
+	mock.ExpectCommit()
+
+	repo := repository.NewBookRepository(db.New(mock), mock)
+	book, err := repo.MergeBook(context.Background(), int(source.ID), int(target.ID))
+	assert.NoError(t, err)
+	assert.Equal(t, &expect, book)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestMergeBookFailureTargetNotFound(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	source := db.Book{
+		ID:        1,
+		Title:     pgtype.Text{String: "test title", Valid: true},
+		Author:    pgtype.Text{String: "test author", Valid: true},
+		Publisher: pgtype.Text{String: "test publisher", Valid: true},
+		Price:     pgtype.Int4{Int32: 200, Valid: true},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`-- name: GetBookByIDForUpdate :one`).
+		WithArgs(source.ID).
+		WillReturnRows(bookRow(source))
+	mock.ExpectQuery(`-- name: GetBookByIDForUpdate :one`).
+		WithArgs(int32(99)).
+		WillReturnError(pgx.ErrNoRows)
+	mock.ExpectRollback()
+
+	repo := repository.NewBookRepository(db.New(mock), mock)
+	book, err := repo.MergeBook(context.Background(), int(source.ID), 99)
+	assert.ErrorIs(t, err, repository.ErrMergeTargetNotFound)
+	assert.Nil(t, book)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestGetBookRedirect(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	mock.ExpectQuery(`-- name: GetBookRedirect :one`).
+		WithArgs(int32(2)).
+		WillReturnRows(pgxmock.NewRows([]string{"target_id"}).AddRow(int32(1)))
+
+	repo := repository.NewBookRepository(db.New(mock), mock)
+	targetId, err := repo.GetBookRedirect(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), targetId)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
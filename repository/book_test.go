@@ -4,10 +4,13 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/pashagolub/pgxmock/v4"
 	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/event"
 	"github.com/rentaro-m-b/ai-model-exam/repository"
 	"github.com/stretchr/testify/assert"
 )
@@ -19,13 +22,6 @@ func TestListBooks(t *testing.T) {
 	}
 	defer mock.Close()
 
-	columns := []string{
-		"id",
-		"title",
-		"author",
-		"publisher",
-		"price",
-	}
 	expects := []db.Book{
 		{
 			ID:        1,
@@ -43,25 +39,17 @@ func TestListBooks(t *testing.T) {
 		},
 	}
 
-	rows := pgxmock.NewRows(columns)
-	for _, expect := range expects {
-		rows.AddRow(
-			expect.ID,
-			expect.Title,
-			expect.Author,
-			expect.Publisher,
-			expect.Price,
-		)
-	}
+	rows := bookRow(expects...)
 	sql := `
 	-- name: ListBooks :many
-	SELECT id, title, author, publisher, price
+	SELECT id, title, author, publisher, price,
+        subtitle, edition, publication_date, language, page_count, format, description, series, series_volume, work_id, isbn, updated_at
     FROM books
 	`
 	mock.ExpectQuery(sql).
 		WillReturnRows(rows)
 
-	repo := repository.NewBookRepository(db.New(mock))
+	repo := repository.NewBookRepository(db.New(mock), mock)
 	books, err := repo.ListBooks(context.Background())
 	assert.NoError(t, err)
 	assert.Equal(t, expects[0], books[0])
@@ -81,13 +69,14 @@ func TestListBooksFailure(t *testing.T) {
 
 	sql := `
 	-- name: ListBooks :many
-	SELECT id, title, author, publisher, price
+	SELECT id, title, author, publisher, price,
+        subtitle, edition, publication_date, language, page_count, format, description, series, series_volume, work_id, isbn, updated_at
     FROM books
 	`
 	mock.ExpectQuery(sql).
 		WillReturnError(fmt.Errorf("query error"))
 
-	repo := repository.NewBookRepository(db.New(mock))
+	repo := repository.NewBookRepository(db.New(mock), mock)
 	books, err := repo.ListBooks(context.Background())
 	assert.Error(t, err)
 	assert.Nil(t, books)
@@ -118,26 +107,32 @@ func TestCreateBook(t *testing.T) {
 		Price:     pgtype.Int4{Int32: 200, Valid: true},
 	}
 
-	columns := []string{
-		"id",
-		"title",
-		"author",
-		"publisher",
-		"price",
-	}
-	rows := pgxmock.NewRows(columns).AddRow(expect.ID, expect.Title, expect.Author, expect.Publisher, expect.Price)
+	rows := bookRow(expect)
 
 	sql := `
 	-- name: CreateBook :one
-	INSERT INTO books \(id, title, author, publisher, price\)
-    VALUES \(nextval\('BOOK_ID_SEQ'\), \$1, \$2, \$3, \$4\)
-    RETURNING id, title, author, publisher, price
+	INSERT INTO books \(
+        id, title, author, publisher, price,
+        subtitle, edition, publication_date, language, page_count, format, description, series, series_volume, isbn
+    \)
+    VALUES \(nextval\('BOOK_ID_SEQ'\), \$1, \$2, \$3, \$4, \$5, \$6, \$7, \$8, \$9, \$10, \$11, \$12, \$13, \$14\)
+    RETURNING id, title, author, publisher, price,
+        subtitle, edition, publication_date, language, page_count, format, description, series, series_volume, work_id, isbn, updated_at
 	`
+	mock.ExpectBegin()
 	mock.ExpectQuery(sql).
-		WithArgs(param.Title, param.Author, param.Publisher, param.Price).
+		WithArgs(
+			param.Title, param.Author, param.Publisher, param.Price,
+			param.Subtitle, param.Edition, param.PublicationDate, param.Language, param.PageCount,
+			param.Format, param.Description, param.Series, param.SeriesVolume, param.Isbn,
+		).
 		WillReturnRows(rows)
+	mock.ExpectExec(`-- name: CreateInitialBookPrice :exec`).
+		WithArgs(expect.ID, "JPY", int64(200)).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectCommit()
 
-	repo := repository.NewBookRepository(db.New(mock))
+	repo := repository.NewBookRepository(db.New(mock), mock)
 	book, err := repo.CreateBook(context.Background(), &param)
 	assert.NoError(t, err)
 	assert.Equal(t, &expect, book)
@@ -163,15 +158,25 @@ func TestCreateBookFailure(t *testing.T) {
 
 	sql := `
 	-- name: CreateBook :one
-	INSERT INTO books \(id, title, author, publisher, price\)
-    VALUES \(nextval\('BOOK_ID_SEQ'\), \$1, \$2, \$3, \$4\)
-    RETURNING id, title, author, publisher, price
+	INSERT INTO books \(
+        id, title, author, publisher, price,
+        subtitle, edition, publication_date, language, page_count, format, description, series, series_volume, isbn
+    \)
+    VALUES \(nextval\('BOOK_ID_SEQ'\), \$1, \$2, \$3, \$4, \$5, \$6, \$7, \$8, \$9, \$10, \$11, \$12, \$13, \$14\)
+    RETURNING id, title, author, publisher, price,
+        subtitle, edition, publication_date, language, page_count, format, description, series, series_volume, work_id, isbn, updated_at
 	`
+	mock.ExpectBegin()
 	mock.ExpectQuery(sql).
-		WithArgs(param.Title, param.Author, param.Publisher, param.Price).
+		WithArgs(
+			param.Title, param.Author, param.Publisher, param.Price,
+			param.Subtitle, param.Edition, param.PublicationDate, param.Language, param.PageCount,
+			param.Format, param.Description, param.Series, param.SeriesVolume, param.Isbn,
+		).
 		WillReturnError(fmt.Errorf("query error"))
+	mock.ExpectRollback()
 
-	repo := repository.NewBookRepository(db.New(mock))
+	repo := repository.NewBookRepository(db.New(mock), mock)
 	book, err := repo.CreateBook(context.Background(), &param)
 	assert.Error(t, err)
 	assert.Nil(t, book)
@@ -197,17 +202,11 @@ func TestGetBookById(t *testing.T) {
 		Price:     pgtype.Int4{Int32: 200, Valid: true},
 	}
 
-	columns := []string{
-		"id",
-		"title",
-		"author",
-		"publisher",
-		"price",
-	}
-	rows := pgxmock.NewRows(columns).AddRow(expect.ID, expect.Title, expect.Author, expect.Publisher, expect.Price)
+	rows := bookRow(expect)
 
 	sql := `-- name: GetBookByID :one
-	SELECT id, title, author, publisher, price
+	SELECT id, title, author, publisher, price,
+        subtitle, edition, publication_date, language, page_count, format, description, series, series_volume, work_id, isbn, updated_at
 		FROM books
 		WHERE id = \$1
 	`
@@ -215,7 +214,7 @@ func TestGetBookById(t *testing.T) {
 		WithArgs(int32(id)).
 		WillReturnRows(rows)
 
-	repo := repository.NewBookRepository(db.New(mock))
+	repo := repository.NewBookRepository(db.New(mock), mock)
 	book, err := repo.GetBookById(context.Background(), id)
 	assert.NoError(t, err)
 	assert.Equal(t, &expect, book)
@@ -235,14 +234,15 @@ func TestGetBookByIdFailure(t *testing.T) {
 	id := 1
 
 	sql := `-- name: GetBookByID :one
-	SELECT id, title, author, publisher, price
+	SELECT id, title, author, publisher, price,
+        subtitle, edition, publication_date, language, page_count, format, description, series, series_volume, work_id, isbn, updated_at
 		FROM books
 		WHERE id = \$1
 	`
 	mock.ExpectQuery(sql).
 		WithArgs(int32(id)).
 		WillReturnError(fmt.Errorf("query error"))
-	repo := repository.NewBookRepository(db.New(mock))
+	repo := repository.NewBookRepository(db.New(mock), mock)
 	book, err := repo.GetBookById(context.Background(), id)
 	assert.Error(t, err)
 	assert.Nil(t, book)
@@ -251,3 +251,477 @@ func TestGetBookByIdFailure(t *testing.T) {
 		t.Errorf("didn't execute query: %v", err)
 	}
 }
+
+func TestSearchBooks(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	param := db.SearchBooksParams{
+		Title:  pgtype.Text{String: "title", Valid: true},
+		Limit:  20,
+		Offset: 0,
+	}
+	expect := db.Book{
+		ID:        1,
+		Title:     pgtype.Text{String: "test title 1", Valid: true},
+		Author:    pgtype.Text{String: "test author 1", Valid: true},
+		Publisher: pgtype.Text{String: "test publisher 1", Valid: true},
+		Price:     pgtype.Int4{Int32: 100, Valid: true},
+	}
+
+	rows := bookRow(expect)
+
+	sql := `-- name: SearchBooks :many`
+	mock.ExpectQuery(sql).
+		WithArgs(param.Title, param.Author, param.Publisher, param.MinPrice, param.MaxPrice, param.Offset, param.Limit).
+		WillReturnRows(rows)
+
+	repo := repository.NewBookRepository(db.New(mock), mock)
+	books, err := repo.SearchBooks(context.Background(), &param)
+	assert.NoError(t, err)
+	assert.Equal(t, []db.Book{expect}, books)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestSearchBooksFailure(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	param := db.SearchBooksParams{
+		Limit:  20,
+		Offset: 0,
+	}
+
+	sql := `-- name: SearchBooks :many`
+	mock.ExpectQuery(sql).
+		WithArgs(param.Title, param.Author, param.Publisher, param.MinPrice, param.MaxPrice, param.Offset, param.Limit).
+		WillReturnError(fmt.Errorf("query error"))
+
+	repo := repository.NewBookRepository(db.New(mock), mock)
+	books, err := repo.SearchBooks(context.Background(), &param)
+	assert.Error(t, err)
+	assert.Nil(t, books)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestListBooksByIds(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	ids := []int32{1, 2}
+	expect := db.Book{
+		ID:        1,
+		Title:     pgtype.Text{String: "test title 1", Valid: true},
+		Author:    pgtype.Text{String: "test author 1", Valid: true},
+		Publisher: pgtype.Text{String: "test publisher 1", Valid: true},
+		Price:     pgtype.Int4{Int32: 100, Valid: true},
+	}
+
+	rows := bookRow(expect)
+
+	sql := `-- name: ListBooksByIds :many
+	SELECT id, title, author, publisher, price,
+        subtitle, edition, publication_date, language, page_count, format, description, series, series_volume, work_id, isbn, updated_at
+		FROM books
+		WHERE id = ANY\(\$1::int\[\]\)
+		ORDER BY id
+	`
+	mock.ExpectQuery(sql).
+		WithArgs(ids).
+		WillReturnRows(rows)
+
+	repo := repository.NewBookRepository(db.New(mock), mock)
+	books, err := repo.ListBooksByIds(context.Background(), ids)
+	assert.NoError(t, err)
+	assert.Equal(t, []db.Book{expect}, books)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestListBooksByAuthors(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	authors := []string{"test author 1"}
+	expect := db.Book{
+		ID:        1,
+		Title:     pgtype.Text{String: "test title 1", Valid: true},
+		Author:    pgtype.Text{String: "test author 1", Valid: true},
+		Publisher: pgtype.Text{String: "test publisher 1", Valid: true},
+		Price:     pgtype.Int4{Int32: 100, Valid: true},
+	}
+
+	rows := bookRow(expect)
+
+	sql := `-- name: ListBooksByAuthors :many
+	SELECT id, title, author, publisher, price,
+        subtitle, edition, publication_date, language, page_count, format, description, series, series_volume, work_id, isbn, updated_at
+		FROM books
+		WHERE author = ANY\(\$1::text\[\]\)
+		ORDER BY id
+	`
+	mock.ExpectQuery(sql).
+		WithArgs(authors).
+		WillReturnRows(rows)
+
+	repo := repository.NewBookRepository(db.New(mock), mock)
+	books, err := repo.ListBooksByAuthors(context.Background(), authors)
+	assert.NoError(t, err)
+	assert.Equal(t, []db.Book{expect}, books)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestUpdateBook(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	param := db.UpdateBookParams{
+		ID:           1,
+		Series:       pgtype.Text{String: "test series", Valid: true},
+		SeriesVolume: pgtype.Int4{Int32: 3, Valid: true},
+	}
+	expect := db.Book{
+		ID:           1,
+		Title:        pgtype.Text{String: "test title 1", Valid: true},
+		Price:        pgtype.Int4{Int32: 100, Valid: true},
+		Series:       pgtype.Text{String: "test series", Valid: true},
+		SeriesVolume: pgtype.Int4{Int32: 3, Valid: true},
+	}
+
+	mock.ExpectQuery(`-- name: UpdateBook :one`).
+		WithArgs(
+			param.Title, param.Author, param.Publisher,
+			param.Subtitle, param.Edition, param.PublicationDate, param.Language, param.PageCount,
+			param.Format, param.Description, param.Series, param.SeriesVolume, param.Isbn, param.ID,
+		).
+		WillReturnRows(bookRow(expect))
+
+	repo := repository.NewBookRepository(db.New(mock), mock)
+	book, err := repo.UpdateBook(context.Background(), &param)
+	assert.NoError(t, err)
+	assert.Equal(t, &expect, book)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestUpdateBookFailureNotFound(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	param := db.UpdateBookParams{
+		ID:    999,
+		Title: pgtype.Text{String: "test title 2", Valid: true},
+	}
+	mock.ExpectQuery(`-- name: UpdateBook :one`).
+		WithArgs(
+			param.Title, param.Author, param.Publisher,
+			param.Subtitle, param.Edition, param.PublicationDate, param.Language, param.PageCount,
+			param.Format, param.Description, param.Series, param.SeriesVolume, param.Isbn, param.ID,
+		).
+		WillReturnError(pgx.ErrNoRows)
+
+	repo := repository.NewBookRepository(db.New(mock), mock)
+	book, err := repo.UpdateBook(context.Background(), &param)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+	assert.Nil(t, book)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestLinkWork(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	expect := db.Book{ID: 3, WorkID: pgtype.Int4{Int32: 1, Valid: true}}
+
+	mock.ExpectQuery(`-- name: UpdateBookWork :one`).
+		WithArgs(int32(3), expect.WorkID).
+		WillReturnRows(bookRow(expect))
+
+	repo := repository.NewBookRepository(db.New(mock), mock)
+	book, err := repo.LinkWork(context.Background(), 3, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, &expect, book)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestUnlinkWorkNotFound(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	mock.ExpectExec(`-- name: ClearBookWork :execrows`).
+		WithArgs(int32(3), pgtype.Int4{Int32: 1, Valid: true}).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+	repo := repository.NewBookRepository(db.New(mock), mock)
+	err = repo.UnlinkWork(context.Background(), 3, 1)
+	assert.ErrorIs(t, err, repository.ErrEditionNotFound)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestListDuplicateCandidates(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	param := db.ListDuplicateBookCandidatesParams{
+		Isbn:          pgtype.Text{String: "9784274217883", Valid: true},
+		TitleAuthor:   "test title 1 test author 1",
+		MinSimilarity: 0.6,
+		MaxCandidates: 5,
+	}
+	expects := []db.Book{
+		{ID: 3, Isbn: param.Isbn},
+		{ID: 5, Title: pgtype.Text{String: "Test Title 1", Valid: true}},
+	}
+
+	mock.ExpectQuery(`-- name: ListDuplicateBookCandidates :many`).
+		WithArgs(param.Isbn, param.TitleAuthor, param.MinSimilarity, param.MaxCandidates).
+		WillReturnRows(bookRow(expects...))
+
+	repo := repository.NewBookRepository(db.New(mock), mock)
+	books, err := repo.ListDuplicateCandidates(context.Background(), &param)
+	assert.NoError(t, err)
+	assert.Equal(t, expects, books)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestWithTxAddEvents(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	param := db.CreateBookParams{
+		Title:     pgtype.Text{String: "test title 1", Valid: true},
+		Author:    pgtype.Text{String: "test author 1", Valid: true},
+		Publisher: pgtype.Text{String: "test publisher 1", Valid: true},
+	}
+	expect := db.Book{
+		ID:        1,
+		Title:     pgtype.Text{String: "test title 1", Valid: true},
+		Author:    pgtype.Text{String: "test author 1", Valid: true},
+		Publisher: pgtype.Text{String: "test publisher 1", Valid: true},
+	}
+	ev, err := event.NewBookCreated(&expect)
+	assert.NoError(t, err)
+
+	mock.ExpectBegin()
+	// CreateBook自身のトランザクションはセーブポイントになる
+	mock.ExpectBegin()
+	mock.ExpectQuery(`-- name: CreateBook :one`).
+		WithArgs(
+			param.Title, param.Author, param.Publisher, param.Price,
+			param.Subtitle, param.Edition, param.PublicationDate, param.Language, param.PageCount,
+			param.Format, param.Description, param.Series, param.SeriesVolume, param.Isbn,
+		).
+		WillReturnRows(bookRow(expect))
+	mock.ExpectCommit()
+	mock.ExpectExec(`-- name: CreateOutboxEvent :exec`).
+		WithArgs(string(event.TypeBookCreated), expect.ID, []byte(ev.Payload)).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectCommit()
+
+	repo := repository.NewBookRepository(db.New(mock), mock)
+	err = repo.WithTx(context.Background(), func(repo repository.BookRepository) error {
+		book, err := repo.CreateBook(context.Background(), &param)
+		if err != nil {
+			return err
+		}
+		assert.Equal(t, &expect, book)
+
+		return repo.AddEvents(context.Background(), ev)
+	})
+	assert.NoError(t, err)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestWithTxFailureRollback(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	expect := db.Book{ID: 1, Title: pgtype.Text{String: "test title 1", Valid: true}}
+	ev, err := event.NewBookUpdated(&expect)
+	assert.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`-- name: CreateOutboxEvent :exec`).
+		WithArgs(string(event.TypeBookUpdated), expect.ID, []byte(ev.Payload)).
+		WillReturnError(fmt.Errorf("exec error"))
+	mock.ExpectRollback()
+
+	repo := repository.NewBookRepository(db.New(mock), mock)
+	err = repo.WithTx(context.Background(), func(repo repository.BookRepository) error {
+		return repo.AddEvents(context.Background(), ev)
+	})
+	assert.Error(t, err)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestGetBookCollectionVersion(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	updatedAt := pgtype.Timestamptz{Time: time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), Valid: true}
+	lastEffectiveAt := pgtype.Timestamptz{Time: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), Valid: true}
+	mock.ExpectQuery(`-- name: GetBookCollectionVersion :one`).
+		WillReturnRows(pgxmock.NewRows([]string{"version", "updated_at", "last_effective_at"}).AddRow(int64(42), updatedAt, lastEffectiveAt))
+
+	repo := repository.NewBookRepository(db.New(mock), mock)
+	version, err := repo.GetBookCollectionVersion(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, &db.GetBookCollectionVersionRow{Version: 42, UpdatedAt: updatedAt, LastEffectiveAt: lastEffectiveAt}, version)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestGetIdempotencyKey(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	createdAt := pgtype.Timestamptz{Time: time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), Valid: true}
+	expiresAt := pgtype.Timestamptz{Time: time.Date(2024, 7, 2, 0, 0, 0, 0, time.UTC), Valid: true}
+	mock.ExpectQuery(`-- name: GetIdempotencyKey :one`).
+		WithArgs("key-1").
+		WillReturnRows(pgxmock.NewRows([]string{"idempotency_key", "request_hash", "response_status", "book_id", "created_at", "expires_at"}).
+			AddRow("key-1", "hash", int32(201), int32(1), createdAt, expiresAt))
+
+	repo := repository.NewBookRepository(db.New(mock), mock)
+	key, err := repo.GetIdempotencyKey(context.Background(), "key-1")
+	assert.NoError(t, err)
+	assert.Equal(t, &db.IdempotencyKey{
+		IdempotencyKey: "key-1",
+		RequestHash:    "hash",
+		ResponseStatus: 201,
+		BookID:         1,
+		CreatedAt:      createdAt,
+		ExpiresAt:      expiresAt,
+	}, key)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestSaveIdempotencyKey(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	param := db.CreateIdempotencyKeyParams{
+		IdempotencyKey: "key-1",
+		RequestHash:    "hash",
+		ResponseStatus: 201,
+		BookID:         1,
+		TtlSeconds:     86400,
+	}
+	// 有効期限内の同じキーがあれば保存しない
+	mock.ExpectExec(`-- name: CreateIdempotencyKey :execrows`).
+		WithArgs("key-1", "hash", int32(201), int32(1), float64(86400)).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectExec(`-- name: CreateIdempotencyKey :execrows`).
+		WithArgs("key-1", "hash", int32(201), int32(1), float64(86400)).
+		WillReturnResult(pgxmock.NewResult("INSERT", 0))
+
+	repo := repository.NewBookRepository(db.New(mock), mock)
+	saved, err := repo.SaveIdempotencyKey(context.Background(), &param)
+	assert.NoError(t, err)
+	assert.True(t, saved)
+	saved, err = repo.SaveIdempotencyKey(context.Background(), &param)
+	assert.NoError(t, err)
+	assert.False(t, saved)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestDeleteExpiredIdempotencyKeys(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	mock.ExpectExec(`-- name: DeleteExpiredIdempotencyKeys :execrows`).
+		WillReturnResult(pgxmock.NewResult("DELETE", 3))
+
+	repo := repository.NewBookRepository(db.New(mock), mock)
+	n, err := repo.DeleteExpiredIdempotencyKeys(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
@@ -0,0 +1,228 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+)
+
+var (
+	ErrCategoryNotFound       = errors.New("category not found")
+	ErrParentCategoryNotFound = errors.New("parent category not found")
+	ErrCategoryCycle          = errors.New("category cannot be moved into its own subtree")
+)
+
+type CategoryRepository interface {
+	CreateCategory(ctx context.Context, param *db.CreateCategoryParams) (*db.Category, error)
+	GetCategoryById(ctx context.Context, id int) (*db.Category, error)
+	ListCategoryTree(ctx context.Context) ([]db.ListCategoryTreeRow, error)
+	ListCategoryAncestors(ctx context.Context, id int) ([]db.Category, error)
+	ListChildCategories(ctx context.Context, id int) ([]db.Category, error)
+	RenameCategory(ctx context.Context, id int, name string) (*db.Category, error)
+	MoveCategory(ctx context.Context, id int, parentId pgtype.Int4) (*db.Category, error)
+	DeleteCategory(ctx context.Context, id int) error
+	ListBookCategories(ctx context.Context, bookId int) ([]db.Category, error)
+	SetBookCategories(ctx context.Context, bookId int, categoryIds []int32) ([]db.Category, error)
+}
+
+type categoryRepositoryImpl struct {
+	queries  *db.Queries
+	beginner TxBeginner
+}
+
+func NewCategoryRepository(db *db.Queries, beginner TxBeginner) CategoryRepository {
+	return &categoryRepositoryImpl{
+		queries:  db,
+		beginner: beginner,
+	}
+}
+
+func (r *categoryRepositoryImpl) CreateCategory(ctx context.Context, param *db.CreateCategoryParams) (*db.Category, error) {
+	category, err := r.queries.CreateCategory(ctx, *param)
+	if err != nil {
+		log.Printf("Unable to execute CategoryRepositoryCreateCategory: %d\n", err)
+		return nil, err
+	}
+
+	return &category, nil
+}
+
+func (r *categoryRepositoryImpl) GetCategoryById(ctx context.Context, id int) (*db.Category, error) {
+	category, err := r.queries.GetCategoryByID(ctx, int32(id))
+	if err != nil {
+		log.Printf("Unable to execute CategoryRepositoryGetCategoryById: %d\n", err)
+		return nil, err
+	}
+
+	return &category, nil
+}
+
+// ListCategoryTree は全てのカテゴリを深さ優先の順で返す
+// 同じ親を持つカテゴリは名前順に並ぶ
+func (r *categoryRepositoryImpl) ListCategoryTree(ctx context.Context) ([]db.ListCategoryTreeRow, error) {
+	categories, err := r.queries.ListCategoryTree(ctx)
+	if err != nil {
+		log.Printf("Unable to execute CategoryRepositoryListCategoryTree: %d\n", err)
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+// ListCategoryAncestors はカテゴリの祖先をルートから順に返す
+func (r *categoryRepositoryImpl) ListCategoryAncestors(ctx context.Context, id int) ([]db.Category, error) {
+	rows, err := r.queries.ListCategoryAncestors(ctx, int32(id))
+	if err != nil {
+		log.Printf("Unable to execute CategoryRepositoryListCategoryAncestors: %d\n", err)
+		return nil, err
+	}
+
+	ancestors := make([]db.Category, 0, len(rows))
+	for _, row := range rows {
+		ancestors = append(ancestors, db.Category(row))
+	}
+
+	return ancestors, nil
+}
+
+func (r *categoryRepositoryImpl) ListChildCategories(ctx context.Context, id int) ([]db.Category, error) {
+	children, err := r.queries.ListChildCategories(ctx, pgtype.Int4{Int32: int32(id), Valid: true})
+	if err != nil {
+		log.Printf("Unable to execute CategoryRepositoryListChildCategories: %d\n", err)
+		return nil, err
+	}
+
+	return children, nil
+}
+
+func (r *categoryRepositoryImpl) RenameCategory(ctx context.Context, id int, name string) (*db.Category, error) {
+	category, err := r.queries.UpdateCategoryName(ctx, db.UpdateCategoryNameParams{
+		ID:   int32(id),
+		Name: name,
+	})
+	if err != nil {
+		log.Printf("Unable to execute CategoryRepositoryRenameCategory: %d\n", err)
+		return nil, err
+	}
+
+	return &category, nil
+}
+
+// MoveCategory はカテゴリを配下のカテゴリごと別の親の下へ移動する
+// 親が無効値の場合はルートへ移動し、自身または自身の子孫の下へは移動できない
+// 同時に逆向きの移動が行われて循環が生じないよう、移動はツリー全体で直列化する
+func (r *categoryRepositoryImpl) MoveCategory(ctx context.Context, id int, parentId pgtype.Int4) (*db.Category, error) {
+	var category db.Category
+	err := withTx(ctx, r.beginner, r.queries, func(q *db.Queries) error {
+		if err := q.LockCategoryTree(ctx); err != nil {
+			return err
+		}
+		if _, err := q.GetCategoryByIDForUpdate(ctx, int32(id)); err != nil {
+			return err
+		}
+
+		if parentId.Valid {
+			if _, err := q.GetCategoryByID(ctx, parentId.Int32); err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return ErrParentCategoryNotFound
+				}
+				return err
+			}
+			inSubtree, err := q.IsCategoryInSubtree(ctx, db.IsCategoryInSubtreeParams{
+				RootID:     int32(id),
+				CategoryID: parentId.Int32,
+			})
+			if err != nil {
+				return err
+			}
+			if inSubtree {
+				return ErrCategoryCycle
+			}
+		}
+
+		var err error
+		category, err = q.UpdateCategoryParent(ctx, db.UpdateCategoryParentParams{
+			ID:       int32(id),
+			ParentID: parentId,
+		})
+		return err
+	})
+	if err != nil {
+		log.Printf("Unable to execute CategoryRepositoryMoveCategory: %d\n", err)
+		return nil, err
+	}
+
+	return &category, nil
+}
+
+// DeleteCategory は削除対象が存在しない場合にpgx.ErrNoRowsを返す
+// 子カテゴリを持つカテゴリは外部キー制約により削除できない
+func (r *categoryRepositoryImpl) DeleteCategory(ctx context.Context, id int) error {
+	count, err := r.queries.DeleteCategoryByID(ctx, int32(id))
+	if err != nil {
+		log.Printf("Unable to execute CategoryRepositoryDeleteCategory: %d\n", err)
+		return err
+	}
+	if count == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *categoryRepositoryImpl) ListBookCategories(ctx context.Context, bookId int) ([]db.Category, error) {
+	categories, err := r.queries.ListCategoriesByBookID(ctx, int32(bookId))
+	if err != nil {
+		log.Printf("Unable to execute CategoryRepositoryListBookCategories: %d\n", err)
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+// SetBookCategories は書籍のカテゴリを指定されたカテゴリで置き換える
+// 存在しないカテゴリが含まれる場合はErrCategoryNotFoundを返す
+func (r *categoryRepositoryImpl) SetBookCategories(ctx context.Context, bookId int, categoryIds []int32) ([]db.Category, error) {
+	categoryIds = slices.Clone(categoryIds)
+	slices.Sort(categoryIds)
+	categoryIds = slices.Compact(categoryIds)
+
+	var categories []db.Category
+	err := withTx(ctx, r.beginner, r.queries, func(q *db.Queries) error {
+		if _, err := q.GetBookByIDForUpdate(ctx, int32(bookId)); err != nil {
+			return err
+		}
+
+		count, err := q.CountCategoriesByIDs(ctx, categoryIds)
+		if err != nil {
+			return err
+		}
+		if count != int64(len(categoryIds)) {
+			return ErrCategoryNotFound
+		}
+
+		if err := q.DeleteBookCategories(ctx, int32(bookId)); err != nil {
+			return err
+		}
+		if err := q.CreateBookCategories(ctx, db.CreateBookCategoriesParams{
+			BookID:      int32(bookId),
+			CategoryIds: categoryIds,
+		}); err != nil {
+			return err
+		}
+
+		categories, err = q.ListCategoriesByBookID(ctx, int32(bookId))
+		return err
+	})
+	if err != nil {
+		log.Printf("Unable to execute CategoryRepositorySetBookCategories: %d\n", err)
+		return nil, err
+	}
+
+	return categories, nil
+}
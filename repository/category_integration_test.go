@@ -0,0 +1,134 @@
+//go:build integration
+
+package repository_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seedCategory はカテゴリを登録し、テスト終了時に削除する
+// 後から登録したカテゴリから削除されるため、子カテゴリは親より先に削除される
+func seedCategory(t *testing.T, pool *pgxpool.Pool, name string, parentId pgtype.Int4) db.Category {
+	t.Helper()
+	ctx := context.Background()
+
+	category, err := db.New(pool).CreateCategory(ctx, db.CreateCategoryParams{
+		Name:     fmt.Sprintf("%s-%d", name, time.Now().UnixNano()),
+		ParentID: parentId,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_, _ = pool.Exec(ctx, "UPDATE categories SET parent_id = NULL WHERE id = $1", category.ID)
+		_, _ = pool.Exec(ctx, "DELETE FROM categories WHERE id = $1", category.ID)
+	})
+
+	return category
+}
+
+func seedBook(t *testing.T, pool *pgxpool.Pool) db.Book {
+	t.Helper()
+	ctx := context.Background()
+
+	book, err := db.New(pool).CreateBook(ctx, db.CreateBookParams{})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_, _ = pool.Exec(ctx, "DELETE FROM books WHERE id = $1", book.ID)
+	})
+
+	return book
+}
+
+func parentOf(category db.Category) pgtype.Int4 {
+	return pgtype.Int4{Int32: category.ID, Valid: true}
+}
+
+func TestIntegrationCategoryTree(t *testing.T) {
+	pool := newIntegrationPool(t)
+	ctx := context.Background()
+	repo := repository.NewCategoryRepository(db.New(pool), pool)
+	bookRepo := repository.NewBookRepository(db.New(pool), pool)
+
+	fiction := seedCategory(t, pool, "fiction", pgtype.Int4{})
+	mystery := seedCategory(t, pool, "mystery", parentOf(fiction))
+	cozy := seedCategory(t, pool, "cozy", parentOf(mystery))
+	history := seedCategory(t, pool, "history", pgtype.Int4{})
+
+	cozyBook := seedBook(t, pool)
+	mysteryBook := seedBook(t, pool)
+	historyBook := seedBook(t, pool)
+	_, err := repo.SetBookCategories(ctx, int(cozyBook.ID), []int32{cozy.ID})
+	require.NoError(t, err)
+	_, err = repo.SetBookCategories(ctx, int(mysteryBook.ID), []int32{mystery.ID, cozy.ID})
+	require.NoError(t, err)
+	_, err = repo.SetBookCategories(ctx, int(historyBook.ID), []int32{history.ID})
+	require.NoError(t, err)
+
+	// 祖先はルートから順に返る
+	ancestors, err := repo.ListCategoryAncestors(ctx, int(cozy.ID))
+	require.NoError(t, err)
+	assert.Equal(t, []db.Category{fiction, mystery}, ancestors)
+
+	// カテゴリでの絞り込みには子孫のカテゴリに属する書籍も含まれ、書籍は重複しない
+	bookIds := func(categoryId int32) []int32 {
+		books, err := bookRepo.FilterBooks(ctx, &db.FilterBooksParams{CategoryID: pgtype.Int4{Int32: categoryId, Valid: true}})
+		require.NoError(t, err)
+		ids := []int32{}
+		for _, book := range books {
+			ids = append(ids, book.ID)
+		}
+		return ids
+	}
+	assert.Equal(t, []int32{cozyBook.ID, mysteryBook.ID}, bookIds(fiction.ID))
+	assert.Equal(t, []int32{cozyBook.ID, mysteryBook.ID}, bookIds(cozy.ID))
+	assert.Equal(t, []int32{historyBook.ID}, bookIds(history.ID))
+
+	// 自身の子孫の下へは移動できない
+	_, err = repo.MoveCategory(ctx, int(fiction.ID), parentOf(cozy))
+	assert.ErrorIs(t, err, repository.ErrCategoryCycle)
+
+	// 配下のカテゴリごと移動すると、移動先の祖先での絞り込みに含まれる
+	_, err = repo.MoveCategory(ctx, int(mystery.ID), parentOf(history))
+	require.NoError(t, err)
+	assert.Equal(t, []int32{cozyBook.ID, mysteryBook.ID, historyBook.ID}, bookIds(history.ID))
+	assert.Equal(t, []int32{}, bookIds(fiction.ID))
+}
+
+func TestIntegrationMoveCategoryRace(t *testing.T) {
+	pool := newIntegrationPool(t)
+	repo := repository.NewCategoryRepository(db.New(pool), pool)
+
+	a := seedCategory(t, pool, "a", pgtype.Int4{})
+	b := seedCategory(t, pool, "b", pgtype.Int4{})
+
+	// 互いの下へ同時に移動しても、循環が生じるのはどちらか一方だけで拒否される
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i, move := range [][2]db.Category{{a, b}, {b, a}} {
+		wg.Add(1)
+		go func(i int, child, parent db.Category) {
+			defer wg.Done()
+			_, errs[i] = repo.MoveCategory(context.Background(), int(child.ID), parentOf(parent))
+		}(i, move[0], move[1])
+	}
+	wg.Wait()
+
+	failed := 0
+	for _, err := range errs {
+		if err != nil {
+			assert.ErrorIs(t, err, repository.ErrCategoryCycle)
+			failed++
+		}
+	}
+	assert.Equal(t, 1, failed)
+}
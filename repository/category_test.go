@@ -0,0 +1,157 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+var categoryColumns = []string{"id", "name", "parent_id", "created_at"}
+
+func TestMoveCategory(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	createdAt := pgtype.Timestamptz{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Valid: true}
+	parentId := pgtype.Int4{Int32: 3, Valid: true}
+	expect := db.Category{ID: 2, Name: "mystery", ParentID: parentId, CreatedAt: createdAt}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`-- name: LockCategoryTree :exec`).
+		WillReturnResult(pgxmock.NewResult("SELECT", 1))
+	mock.ExpectQuery(`-- name: GetCategoryByIDForUpdate :one`).
+		WithArgs(int32(2)).
+		WillReturnRows(pgxmock.NewRows(categoryColumns).AddRow(int32(2), "mystery", pgtype.Int4{Int32: 1, Valid: true}, createdAt))
+	mock.ExpectQuery(`-- name: GetCategoryByID :one`).
+		WithArgs(int32(3)).
+		WillReturnRows(pgxmock.NewRows(categoryColumns).AddRow(int32(3), "fiction", pgtype.Int4{}, createdAt))
+	mock.ExpectQuery(`-- name: IsCategoryInSubtree :one`).
+		WithArgs(int32(3), int32(2)).
+		WillReturnRows(pgxmock.NewRows([]string{"in_subtree"}).AddRow(false))
+	mock.ExpectQuery(`-- name: UpdateCategoryParent :one`).
+		WithArgs(int32(2), parentId).
+		WillReturnRows(pgxmock.NewRows(categoryColumns).AddRow(expect.ID, expect.Name, expect.ParentID, expect.CreatedAt))
+	mock.ExpectCommit()
+
+	repo := repository.NewCategoryRepository(db.New(mock), mock)
+	category, err := repo.MoveCategory(context.Background(), 2, parentId)
+	assert.NoError(t, err)
+	assert.Equal(t, &expect, category)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestMoveCategoryFailureCycle(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	parentId := pgtype.Int4{Int32: 5, Valid: true}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`-- name: LockCategoryTree :exec`).
+		WillReturnResult(pgxmock.NewResult("SELECT", 1))
+	mock.ExpectQuery(`-- name: GetCategoryByIDForUpdate :one`).
+		WithArgs(int32(2)).
+		WillReturnRows(pgxmock.NewRows(categoryColumns).AddRow(int32(2), "mystery", pgtype.Int4{}, pgtype.Timestamptz{}))
+	mock.ExpectQuery(`-- name: GetCategoryByID :one`).
+		WithArgs(int32(5)).
+		WillReturnRows(pgxmock.NewRows(categoryColumns).AddRow(int32(5), "cozy mystery", pgtype.Int4{Int32: 2, Valid: true}, pgtype.Timestamptz{}))
+	mock.ExpectQuery(`-- name: IsCategoryInSubtree :one`).
+		WithArgs(int32(5), int32(2)).
+		WillReturnRows(pgxmock.NewRows([]string{"in_subtree"}).AddRow(true))
+	mock.ExpectRollback()
+
+	repo := repository.NewCategoryRepository(db.New(mock), mock)
+	category, err := repo.MoveCategory(context.Background(), 2, parentId)
+	assert.ErrorIs(t, err, repository.ErrCategoryCycle)
+	assert.Nil(t, category)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestSetBookCategories(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	expects := []db.Category{
+		{ID: 2, Name: "mystery", ParentID: pgtype.Int4{Int32: 1, Valid: true}},
+		{ID: 4, Name: "history"},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`-- name: GetBookByIDForUpdate :one`).
+		WithArgs(int32(1)).
+		WillReturnRows(bookRow(db.Book{ID: int32(1)}))
+	// 重複したIDは取り除いてから存在を確認する
+	mock.ExpectQuery(`-- name: CountCategoriesByIDs :one`).
+		WithArgs([]int32{2, 4}).
+		WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(int64(2)))
+	mock.ExpectExec(`-- name: DeleteBookCategories :exec`).
+		WithArgs(int32(1)).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	mock.ExpectExec(`-- name: CreateBookCategories :exec`).
+		WithArgs(int32(1), []int32{2, 4}).
+		WillReturnResult(pgxmock.NewResult("INSERT", 2))
+	rows := pgxmock.NewRows(categoryColumns)
+	for _, expect := range expects {
+		rows.AddRow(expect.ID, expect.Name, expect.ParentID, expect.CreatedAt)
+	}
+	mock.ExpectQuery(`-- name: ListCategoriesByBookID :many`).
+		WithArgs(int32(1)).
+		WillReturnRows(rows)
+	mock.ExpectCommit()
+
+	repo := repository.NewCategoryRepository(db.New(mock), mock)
+	categories, err := repo.SetBookCategories(context.Background(), 1, []int32{4, 2, 4})
+	assert.NoError(t, err)
+	assert.Equal(t, expects, categories)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestSetBookCategoriesFailureCategoryNotFound(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`-- name: GetBookByIDForUpdate :one`).
+		WithArgs(int32(1)).
+		WillReturnRows(bookRow(db.Book{ID: int32(1)}))
+	mock.ExpectQuery(`-- name: CountCategoriesByIDs :one`).
+		WithArgs([]int32{2, 99}).
+		WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(int64(1)))
+	mock.ExpectRollback()
+
+	repo := repository.NewCategoryRepository(db.New(mock), mock)
+	categories, err := repo.SetBookCategories(context.Background(), 1, []int32{2, 99})
+	assert.ErrorIs(t, err, repository.ErrCategoryNotFound)
+	assert.Nil(t, categories)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
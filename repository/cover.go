@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+)
+
+type CoverRepository interface {
+	GetCover(ctx context.Context, bookId int) (*db.BookCover, error)
+	SaveCover(ctx context.Context, param *db.UpsertBookCoverParams) (*db.BookCover, string, error)
+}
+
+type coverRepositoryImpl struct {
+	queries  *db.Queries
+	beginner TxBeginner
+}
+
+func NewCoverRepository(db *db.Queries, beginner TxBeginner) CoverRepository {
+	return &coverRepositoryImpl{
+		queries:  db,
+		beginner: beginner,
+	}
+}
+
+func (r *coverRepositoryImpl) GetCover(ctx context.Context, bookId int) (*db.BookCover, error) {
+	cover, err := r.queries.GetBookCoverByBookID(ctx, int32(bookId))
+	if err != nil {
+		log.Printf("Unable to execute CoverRepositoryGetCover: %d\n", err)
+		return nil, err
+	}
+
+	return &cover, nil
+}
+
+// SaveCover は書籍の表紙画像の情報を登録または置き換え、置き換える前の画像のチェックサムを返す
+// 表紙画像が未登録だった場合のチェックサムは空文字列となり、書籍が存在しない場合はpgx.ErrNoRowsを返す
+func (r *coverRepositoryImpl) SaveCover(ctx context.Context, param *db.UpsertBookCoverParams) (*db.BookCover, string, error) {
+	var cover db.BookCover
+	var previous string
+	err := withTx(ctx, r.beginner, r.queries, func(q *db.Queries) error {
+		if _, err := q.GetBookByIDForUpdate(ctx, param.BookID); err != nil {
+			return err
+		}
+
+		current, err := q.GetBookCoverByBookIDForUpdate(ctx, param.BookID)
+		switch {
+		case err == nil:
+			previous = current.Checksum
+		case !errors.Is(err, pgx.ErrNoRows):
+			return err
+		}
+
+		cover, err = q.UpsertBookCover(ctx, *param)
+		return err
+	})
+	if err != nil {
+		log.Printf("Unable to execute CoverRepositorySaveCover: %d\n", err)
+		return nil, "", err
+	}
+
+	return &cover, previous, nil
+}
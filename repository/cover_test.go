@@ -0,0 +1,85 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+var bookCoverColumns = []string{"book_id", "content_type", "width", "height", "byte_size", "checksum", "updated_at"}
+
+func bookCoverRow(cover db.BookCover) *pgxmock.Rows {
+	return pgxmock.NewRows(bookCoverColumns).
+		AddRow(cover.BookID, cover.ContentType, cover.Width, cover.Height, cover.ByteSize, cover.Checksum, cover.UpdatedAt)
+}
+
+func TestSaveCoverReplacesPrevious(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	param := db.UpsertBookCoverParams{BookID: 1, ContentType: "image/png", Width: 800, Height: 1200, ByteSize: 2048, Checksum: "new"}
+	expect := db.BookCover{BookID: 1, ContentType: "image/png", Width: 800, Height: 1200, ByteSize: 2048, Checksum: "new"}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`-- name: GetBookByIDForUpdate :one`).
+		WithArgs(int32(1)).
+		WillReturnRows(bookRow(db.Book{ID: int32(1)}))
+	mock.ExpectQuery(`-- name: GetBookCoverByBookIDForUpdate :one`).
+		WithArgs(int32(1)).
+		WillReturnRows(bookCoverRow(db.BookCover{BookID: 1, ContentType: "image/jpeg", Width: 10, Height: 10, ByteSize: 100, Checksum: "old"}))
+	mock.ExpectQuery(`-- name: UpsertBookCover :one`).
+		WithArgs(param.BookID, param.ContentType, param.Width, param.Height, param.ByteSize, param.Checksum).
+		WillReturnRows(bookCoverRow(expect))
+	mock.ExpectCommit()
+
+	repo := repository.NewCoverRepository(db.New(mock), mock)
+	cover, previous, err := repo.SaveCover(context.Background(), &param)
+	assert.NoError(t, err)
+	assert.Equal(t, &expect, cover)
+	assert.Equal(t, "old", previous)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestSaveCoverFirstUpload(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	param := db.UpsertBookCoverParams{BookID: 1, ContentType: "image/png", Width: 800, Height: 1200, ByteSize: 2048, Checksum: "new"}
+	expect := db.BookCover{BookID: 1, ContentType: "image/png", Width: 800, Height: 1200, ByteSize: 2048, Checksum: "new"}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`-- name: GetBookByIDForUpdate :one`).
+		WithArgs(int32(1)).
+		WillReturnRows(bookRow(db.Book{ID: int32(1)}))
+	mock.ExpectQuery(`-- name: GetBookCoverByBookIDForUpdate :one`).
+		WithArgs(int32(1)).
+		WillReturnError(pgx.ErrNoRows)
+	mock.ExpectQuery(`-- name: UpsertBookCover :one`).
+		WithArgs(param.BookID, param.ContentType, param.Width, param.Height, param.ByteSize, param.Checksum).
+		WillReturnRows(bookCoverRow(expect))
+	mock.ExpectCommit()
+
+	repo := repository.NewCoverRepository(db.New(mock), mock)
+	cover, previous, err := repo.SaveCover(context.Background(), &param)
+	assert.NoError(t, err)
+	assert.Equal(t, &expect, cover)
+	assert.Equal(t, "", previous)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
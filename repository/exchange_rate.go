@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+)
+
+type ExchangeRateRepository interface {
+	ImportRates(ctx context.Context, rates []db.UpsertExchangeRateParams) (int, error)
+	GetRate(ctx context.Context, from string, to string, on time.Time) (*db.ExchangeRate, error)
+}
+
+type exchangeRateRepositoryImpl struct {
+	queries  *db.Queries
+	beginner TxBeginner
+}
+
+func NewExchangeRateRepository(db *db.Queries, beginner TxBeginner) ExchangeRateRepository {
+	return &exchangeRateRepositoryImpl{
+		queries:  db,
+		beginner: beginner,
+	}
+}
+
+// ImportRates はレートを一括で登録する
+// 同じ通貨の組と適用日のレートが登録済みの場合は上書きし、1件でも失敗した場合は全て取り消す
+func (r *exchangeRateRepositoryImpl) ImportRates(ctx context.Context, rates []db.UpsertExchangeRateParams) (int, error) {
+	err := withTx(ctx, r.beginner, r.queries, func(q *db.Queries) error {
+		for _, rate := range rates {
+			if err := q.UpsertExchangeRate(ctx, rate); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("Unable to execute ExchangeRateRepositoryImportRates: %d\n", err)
+		return 0, err
+	}
+
+	return len(rates), nil
+}
+
+// GetRate は指定日に有効な通貨の組のレートを返す
+// 逆向きの組（換算先から換算元へのレート）も対象とし、同じ適用日であれば順向きの組を優先する
+func (r *exchangeRateRepositoryImpl) GetRate(ctx context.Context, from string, to string, on time.Time) (*db.ExchangeRate, error) {
+	rate, err := r.queries.GetExchangeRate(ctx, db.GetExchangeRateParams{
+		FromCurrency: from,
+		ToCurrency:   to,
+		OnDate:       pgtype.Date{Time: on, Valid: true},
+	})
+	if err != nil {
+		log.Printf("Unable to execute ExchangeRateRepositoryGetRate: %d\n", err)
+		return nil, err
+	}
+
+	return &rate, nil
+}
@@ -0,0 +1,110 @@
+package repository_test
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+var exchangeRateColumns = []string{"id", "base_currency", "quote_currency", "rate", "effective_date", "imported_at"}
+
+func TestImportRates(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	effectiveDate := pgtype.Date{Time: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), Valid: true}
+	rates := []db.UpsertExchangeRateParams{
+		{BaseCurrency: "USD", QuoteCurrency: "JPY", Rate: pgtype.Numeric{Int: big.NewInt(15423), Exp: -2, Valid: true}, EffectiveDate: effectiveDate},
+		{BaseCurrency: "EUR", QuoteCurrency: "JPY", Rate: pgtype.Numeric{Int: big.NewInt(16512), Exp: -2, Valid: true}, EffectiveDate: effectiveDate},
+	}
+
+	mock.ExpectBegin()
+	for _, rate := range rates {
+		mock.ExpectExec(`-- name: UpsertExchangeRate :exec`).
+			WithArgs(rate.BaseCurrency, rate.QuoteCurrency, rate.Rate, rate.EffectiveDate).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	}
+	mock.ExpectCommit()
+
+	repo := repository.NewExchangeRateRepository(db.New(mock), mock)
+	count, err := repo.ImportRates(context.Background(), rates)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestImportRatesFailure(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	rate := db.UpsertExchangeRateParams{
+		BaseCurrency:  "USD",
+		QuoteCurrency: "JPY",
+		Rate:          pgtype.Numeric{Int: big.NewInt(15423), Exp: -2, Valid: true},
+		EffectiveDate: pgtype.Date{Time: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`-- name: UpsertExchangeRate :exec`).
+		WithArgs(rate.BaseCurrency, rate.QuoteCurrency, rate.Rate, rate.EffectiveDate).
+		WillReturnError(fmt.Errorf("query error"))
+	mock.ExpectRollback()
+
+	repo := repository.NewExchangeRateRepository(db.New(mock), mock)
+	count, err := repo.ImportRates(context.Background(), []db.UpsertExchangeRateParams{rate})
+	assert.Error(t, err)
+	assert.Equal(t, 0, count)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestGetRate(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	on := time.Date(2024, 5, 3, 10, 0, 0, 0, time.UTC)
+	expect := db.ExchangeRate{
+		ID:            1,
+		BaseCurrency:  "USD",
+		QuoteCurrency: "JPY",
+		Rate:          pgtype.Numeric{Int: big.NewInt(15423), Exp: -2, Valid: true},
+		EffectiveDate: pgtype.Date{Time: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+		ImportedAt:    pgtype.Timestamptz{Time: on, Valid: true},
+	}
+
+	mock.ExpectQuery(`-- name: GetExchangeRate :one`).
+		WithArgs("JPY", "USD", pgtype.Date{Time: on, Valid: true}).
+		WillReturnRows(pgxmock.NewRows(exchangeRateColumns).
+			AddRow(expect.ID, expect.BaseCurrency, expect.QuoteCurrency, expect.Rate, expect.EffectiveDate, expect.ImportedAt))
+
+	repo := repository.NewExchangeRateRepository(db.New(mock), mock)
+	rate, err := repo.GetRate(context.Background(), "JPY", "USD", on)
+	assert.NoError(t, err)
+	assert.Equal(t, &expect, rate)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
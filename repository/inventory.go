@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math"
+
+	"github.com/rentaro-m-b/ai-model-exam/db"
+)
+
+var ErrInsufficientStock = errors.New("insufficient stock")
+var ErrStockOverflow = errors.New("stock quantity overflow")
+
+type AdjustStockParams struct {
+	BookID         int32
+	Location       string
+	QuantityChange int32
+	Reason         string
+}
+
+type InventoryRepository interface {
+	ListInventories(ctx context.Context, bookId int) ([]db.Inventory, error)
+	ListStockMovements(ctx context.Context, bookId int) ([]db.StockMovement, error)
+	AdjustStock(ctx context.Context, param *AdjustStockParams) (*db.StockMovement, error)
+}
+
+type inventoryRepositoryImpl struct {
+	queries  *db.Queries
+	beginner TxBeginner
+}
+
+func NewInventoryRepository(db *db.Queries, beginner TxBeginner) InventoryRepository {
+	return &inventoryRepositoryImpl{
+		queries:  db,
+		beginner: beginner,
+	}
+}
+
+func (r *inventoryRepositoryImpl) ListInventories(ctx context.Context, bookId int) ([]db.Inventory, error) {
+	inventories, err := r.queries.ListInventoriesByBookID(ctx, int32(bookId))
+	if err != nil {
+		log.Printf("Unable to execute InventoryRepositoryListInventories: %d\n", err)
+		return nil, err
+	}
+
+	return inventories, nil
+}
+
+func (r *inventoryRepositoryImpl) ListStockMovements(ctx context.Context, bookId int) ([]db.StockMovement, error) {
+	movements, err := r.queries.ListStockMovementsByBookID(ctx, int32(bookId))
+	if err != nil {
+		log.Printf("Unable to execute InventoryRepositoryListStockMovements: %d\n", err)
+		return nil, err
+	}
+
+	return movements, nil
+}
+
+// AdjustStock は在庫行をロックしてから数量を更新し、入出庫履歴を記録する
+// 更新後の数量が負になる場合はロールバックしてErrInsufficientStockを返す
+// 更新後の数量がint32に収まらない場合はロールバックしてErrStockOverflowを返す
+func (r *inventoryRepositoryImpl) AdjustStock(ctx context.Context, param *AdjustStockParams) (*db.StockMovement, error) {
+	var movement db.StockMovement
+	err := withTx(ctx, r.beginner, r.queries, func(q *db.Queries) error {
+		err := q.EnsureInventory(ctx, db.EnsureInventoryParams{
+			BookID:   param.BookID,
+			Location: param.Location,
+		})
+		if err != nil {
+			return err
+		}
+
+		inventory, err := q.GetInventoryForUpdate(ctx, db.GetInventoryForUpdateParams{
+			BookID:   param.BookID,
+			Location: param.Location,
+		})
+		if err != nil {
+			return err
+		}
+
+		if param.QuantityChange > 0 && inventory.Quantity > math.MaxInt32-param.QuantityChange {
+			return ErrStockOverflow
+		}
+		quantity := inventory.Quantity + param.QuantityChange
+		if quantity < 0 {
+			return ErrInsufficientStock
+		}
+
+		_, err = q.UpdateInventoryQuantity(ctx, db.UpdateInventoryQuantityParams{
+			BookID:   param.BookID,
+			Location: param.Location,
+			Quantity: quantity,
+		})
+		if err != nil {
+			return err
+		}
+
+		movement, err = q.CreateStockMovement(ctx, db.CreateStockMovementParams{
+			BookID:         param.BookID,
+			Location:       param.Location,
+			QuantityChange: param.QuantityChange,
+			QuantityAfter:  quantity,
+			Reason:         param.Reason,
+		})
+		return err
+	})
+	if err != nil {
+		log.Printf("Unable to execute InventoryRepositoryAdjustStock: %d\n", err)
+		return nil, err
+	}
+
+	return &movement, nil
+}
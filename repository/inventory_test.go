@@ -0,0 +1,193 @@
+package repository_test
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListInventories(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	id := 1
+	expects := []db.Inventory{
+		{BookID: 1, Location: "tokyo", Quantity: 3},
+		{BookID: 1, Location: "osaka", Quantity: 0},
+	}
+
+	rows := pgxmock.NewRows([]string{"book_id", "location", "quantity"})
+	for _, expect := range expects {
+		rows.AddRow(expect.BookID, expect.Location, expect.Quantity)
+	}
+	sql := `-- name: ListInventoriesByBookID :many
+	SELECT book_id, location, quantity
+		FROM inventories
+		WHERE book_id = \$1
+		ORDER BY location
+	`
+	mock.ExpectQuery(sql).
+		WithArgs(int32(id)).
+		WillReturnRows(rows)
+
+	repo := repository.NewInventoryRepository(db.New(mock), mock)
+	inventories, err := repo.ListInventories(context.Background(), id)
+	assert.NoError(t, err)
+	assert.Equal(t, expects, inventories)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestListStockMovementsFailure(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	id := 1
+
+	sql := `-- name: ListStockMovementsByBookID :many`
+	mock.ExpectQuery(sql).
+		WithArgs(int32(id)).
+		WillReturnError(fmt.Errorf("query error"))
+
+	repo := repository.NewInventoryRepository(db.New(mock), mock)
+	movements, err := repo.ListStockMovements(context.Background(), id)
+	assert.Error(t, err)
+	assert.Nil(t, movements)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestAdjustStock(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	param := repository.AdjustStockParams{
+		BookID:         1,
+		Location:       "tokyo",
+		QuantityChange: -2,
+		Reason:         "sold",
+	}
+	expect := db.StockMovement{
+		ID:             1,
+		BookID:         1,
+		Location:       "tokyo",
+		QuantityChange: -2,
+		QuantityAfter:  1,
+		Reason:         "sold",
+		CreatedAt:      pgtype.Timestamptz{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`-- name: EnsureInventory :exec`).
+		WithArgs(param.BookID, param.Location).
+		WillReturnResult(pgxmock.NewResult("INSERT", 0))
+	mock.ExpectQuery(`-- name: GetInventoryForUpdate :one(.|\n)*FOR UPDATE`).
+		WithArgs(param.BookID, param.Location).
+		WillReturnRows(pgxmock.NewRows([]string{"book_id", "location", "quantity"}).AddRow(int32(1), "tokyo", int32(3)))
+	mock.ExpectQuery(`-- name: UpdateInventoryQuantity :one`).
+		WithArgs(param.BookID, param.Location, int32(1)).
+		WillReturnRows(pgxmock.NewRows([]string{"book_id", "location", "quantity"}).AddRow(int32(1), "tokyo", int32(1)))
+	mock.ExpectQuery(`-- name: CreateStockMovement :one`).
+		WithArgs(param.BookID, param.Location, param.QuantityChange, int32(1), param.Reason).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "book_id", "location", "quantity_change", "quantity_after", "reason", "created_at"}).
+			AddRow(expect.ID, expect.BookID, expect.Location, expect.QuantityChange, expect.QuantityAfter, expect.Reason, expect.CreatedAt))
+	mock.ExpectCommit()
+
+	repo := repository.NewInventoryRepository(db.New(mock), mock)
+	movement, err := repo.AdjustStock(context.Background(), &param)
+	assert.NoError(t, err)
+	assert.Equal(t, &expect, movement)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestAdjustStockFailureInsufficientStock(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	param := repository.AdjustStockParams{
+		BookID:         1,
+		Location:       "tokyo",
+		QuantityChange: -5,
+		Reason:         "sold",
+	}
+
+	// 在庫数が負になるため、更新せずにロールバックされる
+	mock.ExpectBegin()
+	mock.ExpectExec(`-- name: EnsureInventory :exec`).
+		WithArgs(param.BookID, param.Location).
+		WillReturnResult(pgxmock.NewResult("INSERT", 0))
+	mock.ExpectQuery(`-- name: GetInventoryForUpdate :one`).
+		WithArgs(param.BookID, param.Location).
+		WillReturnRows(pgxmock.NewRows([]string{"book_id", "location", "quantity"}).AddRow(int32(1), "tokyo", int32(3)))
+	mock.ExpectRollback()
+
+	repo := repository.NewInventoryRepository(db.New(mock), mock)
+	movement, err := repo.AdjustStock(context.Background(), &param)
+	assert.ErrorIs(t, err, repository.ErrInsufficientStock)
+	assert.Nil(t, movement)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestAdjustStockFailureOverflow(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	param := repository.AdjustStockParams{
+		BookID:         1,
+		Location:       "tokyo",
+		QuantityChange: 10,
+		Reason:         "received",
+	}
+
+	// 在庫数がint32に収まらないため、更新せずにロールバックされる
+	mock.ExpectBegin()
+	mock.ExpectExec(`-- name: EnsureInventory :exec`).
+		WithArgs(param.BookID, param.Location).
+		WillReturnResult(pgxmock.NewResult("INSERT", 0))
+	mock.ExpectQuery(`-- name: GetInventoryForUpdate :one`).
+		WithArgs(param.BookID, param.Location).
+		WillReturnRows(pgxmock.NewRows([]string{"book_id", "location", "quantity"}).AddRow(int32(1), "tokyo", int32(math.MaxInt32-5)))
+	mock.ExpectRollback()
+
+	repo := repository.NewInventoryRepository(db.New(mock), mock)
+	movement, err := repo.AdjustStock(context.Background(), &param)
+	assert.ErrorIs(t, err, repository.ErrStockOverflow)
+	assert.Nil(t, movement)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/job"
+)
+
+// JobRepository はジョブの登録・参照・キャンセルに加え、job.Storeを実装する
+type JobRepository interface {
+	CreateJob(ctx context.Context, kind string, params []byte) (*db.Job, error)
+	GetJobById(ctx context.Context, id int64) (*db.Job, error)
+	CancelJob(ctx context.Context, id int64) (*db.Job, error)
+	ClaimJob(ctx context.Context, kinds []string, lease time.Duration) (*job.Job, error)
+	ExtendLease(ctx context.Context, j *job.Job, lease time.Duration) (bool, error)
+	ReportProgress(ctx context.Context, j *job.Job, done int, total int) (bool, error)
+	Finish(ctx context.Context, j *job.Job, outcome *job.Outcome) error
+}
+
+type jobRepositoryImpl struct {
+	queries *db.Queries
+}
+
+func NewJobRepository(db *db.Queries) JobRepository {
+	return &jobRepositoryImpl{
+		queries: db,
+	}
+}
+
+func (r *jobRepositoryImpl) CreateJob(ctx context.Context, kind string, params []byte) (*db.Job, error) {
+	created, err := r.queries.CreateJob(ctx, db.CreateJobParams{
+		Kind:   kind,
+		Params: params,
+	})
+	if err != nil {
+		log.Printf("Unable to execute JobRepositoryCreateJob: %d\n", err)
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+func (r *jobRepositoryImpl) GetJobById(ctx context.Context, id int64) (*db.Job, error) {
+	j, err := r.queries.GetJobByID(ctx, id)
+	if err != nil {
+		log.Printf("Unable to execute JobRepositoryGetJobById: %d\n", err)
+		return nil, err
+	}
+
+	return &j, nil
+}
+
+// CancelJob は実行待ちのジョブをキャンセル済みにし、実行中のジョブにはキャンセルを要求する
+// 終了したジョブや存在しないジョブの場合はpgx.ErrNoRowsを返す
+func (r *jobRepositoryImpl) CancelJob(ctx context.Context, id int64) (*db.Job, error) {
+	j, err := r.queries.CancelJob(ctx, id)
+	if err != nil {
+		log.Printf("Unable to execute JobRepositoryCancelJob: %d\n", err)
+		return nil, err
+	}
+
+	return &j, nil
+}
+
+// ClaimJob は他のワーカーがロック中のジョブを飛ばして取得するため、複数のプロセスから同時に呼び出せる
+// ClaimJob はリースの期限を、期限切れの判定と同じくデータベースの時計で求める
+func (r *jobRepositoryImpl) ClaimJob(ctx context.Context, kinds []string, lease time.Duration) (*job.Job, error) {
+	row, err := r.queries.ClaimJob(ctx, db.ClaimJobParams{
+		Lease: pgtype.Interval{Microseconds: lease.Microseconds(), Valid: true},
+		Kinds: kinds,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		log.Printf("Unable to execute JobRepositoryClaimJob: %d\n", err)
+		return nil, err
+	}
+
+	return &job.Job{
+		ID:              row.ID,
+		Kind:            row.Kind,
+		Params:          row.Params,
+		Attempts:        int(row.Attempts),
+		CancelRequested: row.CancelRequested,
+		CommittedResult: row.CommittedResult,
+	}, nil
+}
+
+func (r *jobRepositoryImpl) ExtendLease(ctx context.Context, j *job.Job, lease time.Duration) (bool, error) {
+	cancelRequested, err := r.queries.ExtendJobLease(ctx, db.ExtendJobLeaseParams{
+		Lease:    pgtype.Interval{Microseconds: lease.Microseconds(), Valid: true},
+		ID:       j.ID,
+		Attempts: int32(j.Attempts),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, job.ErrLeaseLost
+	}
+	if err != nil {
+		log.Printf("Unable to execute JobRepositoryExtendLease: %d\n", err)
+		return false, err
+	}
+
+	return cancelRequested, nil
+}
+
+func (r *jobRepositoryImpl) ReportProgress(ctx context.Context, j *job.Job, done int, total int) (bool, error) {
+	cancelRequested, err := r.queries.UpdateJobProgress(ctx, db.UpdateJobProgressParams{
+		ProgressDone:  int32(done),
+		ProgressTotal: pgtype.Int4{Int32: int32(total), Valid: true},
+		ID:            j.ID,
+		Attempts:      int32(j.Attempts),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, job.ErrLeaseLost
+	}
+	if err != nil {
+		log.Printf("Unable to execute JobRepositoryReportProgress: %d\n", err)
+		return false, err
+	}
+
+	return cancelRequested, nil
+}
+
+func (r *jobRepositoryImpl) Finish(ctx context.Context, j *job.Job, outcome *job.Outcome) error {
+	n, err := r.queries.FinishJob(ctx, db.FinishJobParams{
+		Status:       outcome.Status,
+		Result:       outcome.Result,
+		ArtifactKey:  pgtype.Text{String: outcome.ArtifactKey, Valid: outcome.ArtifactKey != ""},
+		ArtifactType: pgtype.Text{String: outcome.ArtifactType, Valid: outcome.ArtifactType != ""},
+		LastError:    pgtype.Text{String: outcome.Error, Valid: outcome.Error != ""},
+		ID:           j.ID,
+		Attempts:     int32(j.Attempts),
+	})
+	if err != nil {
+		log.Printf("Unable to execute JobRepositoryFinish: %d\n", err)
+		return err
+	}
+	if n == 0 {
+		return job.ErrLeaseLost
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/job"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClaimJob(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	params := []byte(`{"ids":[1]}`)
+	committed := []byte(`{"affected":1}`)
+	// リースの期限はデータベースの時計で求めるため、期間を渡す
+	mock.ExpectQuery(`-- name: ClaimJob :one`).
+		WithArgs(pgtype.Interval{Microseconds: time.Minute.Microseconds(), Valid: true}, []string{"book_bulk_delete", "book_export"}).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "kind", "params", "attempts", "cancel_requested", "committed_result"}).
+			AddRow(int64(5), "book_bulk_delete", params, int32(2), false, committed))
+
+	repo := repository.NewJobRepository(db.New(mock))
+	claimed, err := repo.ClaimJob(context.Background(), []string{"book_bulk_delete", "book_export"}, time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, &job.Job{ID: 5, Kind: "book_bulk_delete", Params: params, Attempts: 2, CommittedResult: committed}, claimed)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestClaimJobEmpty(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	// 実行待ちのジョブが無ければnilを返す
+	mock.ExpectQuery(`-- name: ClaimJob :one`).
+		WithArgs(pgxmock.AnyArg(), []string{"book_export"}).
+		WillReturnError(pgx.ErrNoRows)
+
+	repo := repository.NewJobRepository(db.New(mock))
+	claimed, err := repo.ClaimJob(context.Background(), []string{"book_export"}, time.Minute)
+	assert.NoError(t, err)
+	assert.Nil(t, claimed)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestReportProgressLeaseLost(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	// 試行回数が一致しなければ、他のワーカーに取得されている
+	mock.ExpectQuery(`-- name: UpdateJobProgress :one`).
+		WithArgs(int32(3), pgtype.Int4{Int32: 10, Valid: true}, int64(5), int32(1)).
+		WillReturnError(pgx.ErrNoRows)
+
+	repo := repository.NewJobRepository(db.New(mock))
+	_, err = repo.ReportProgress(context.Background(), &job.Job{ID: 5, Attempts: 1}, 3, 10)
+	assert.ErrorIs(t, err, job.ErrLeaseLost)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestFinishJob(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	result := []byte(`{"exported":2}`)
+	mock.ExpectExec(`-- name: FinishJob :execrows`).
+		WithArgs(job.StatusSucceeded, result, pgtype.Text{String: "jobs/5", Valid: true}, pgtype.Text{String: "text/csv", Valid: true},
+			pgtype.Text{}, int64(5), int32(1)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec(`-- name: FinishJob :execrows`).
+		WithArgs(job.StatusFailed, []byte(nil), pgtype.Text{}, pgtype.Text{}, pgtype.Text{String: "error", Valid: true}, int64(6), int32(2)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+	repo := repository.NewJobRepository(db.New(mock))
+	err = repo.Finish(context.Background(), &job.Job{ID: 5, Attempts: 1}, &job.Outcome{
+		Status:       job.StatusSucceeded,
+		Result:       result,
+		ArtifactKey:  "jobs/5",
+		ArtifactType: "text/csv",
+	})
+	assert.NoError(t, err)
+	err = repo.Finish(context.Background(), &job.Job{ID: 6, Attempts: 2}, &job.Outcome{Status: job.StatusFailed, Error: "error"})
+	assert.ErrorIs(t, err, job.ErrLeaseLost)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+)
+
+var (
+	ErrNoCopyAvailable     = errors.New("no copy available")
+	ErrLoanLimitExceeded   = errors.New("loan limit exceeded")
+	ErrLoanAlreadyReturned = errors.New("loan already returned")
+)
+
+type CheckoutParams struct {
+	BookID     int32
+	MemberID   int32
+	LoanedAt   time.Time
+	DueAt      time.Time
+	MaxLoans   int
+	HoldPeriod time.Duration
+}
+
+type LoanRepository interface {
+	Checkout(ctx context.Context, param *CheckoutParams) (*db.Loan, error)
+	ReturnLoan(ctx context.Context, id int, returnedAt time.Time, holdPeriod time.Duration) (*db.Loan, error)
+	ListOverdueLoans(ctx context.Context, now time.Time) ([]db.Loan, error)
+	GetAvailability(ctx context.Context, bookId int) (*db.GetBookAvailabilityRow, error)
+}
+
+type loanRepositoryImpl struct {
+	queries  *db.Queries
+	beginner TxBeginner
+}
+
+func NewLoanRepository(db *db.Queries, beginner TxBeginner) LoanRepository {
+	return &loanRepositoryImpl{
+		queries:  db,
+		beginner: beginner,
+	}
+}
+
+// Checkout は書籍と会員の行をロックした上で、貸出可能数と会員ごとの貸出上限を確認してから貸出を登録する
+// 同じ書籍・同じ会員への貸出はロックにより直列化されるため、在庫や上限を超えて貸し出すことはない
+// 会員に取置中の予約があれば、その取置分を貸し出して予約を完了にする
+func (r *loanRepositoryImpl) Checkout(ctx context.Context, param *CheckoutParams) (*db.Loan, error) {
+	var loan db.Loan
+	err := withTx(ctx, r.beginner, r.queries, func(q *db.Queries) error {
+		if _, err := q.GetBookByIDForUpdate(ctx, param.BookID); err != nil {
+			return err
+		}
+		if _, err := q.GetMemberByIDForUpdate(ctx, param.MemberID); err != nil {
+			return err
+		}
+
+		active, err := q.CountActiveLoansByMemberID(ctx, param.MemberID)
+		if err != nil {
+			return err
+		}
+		if int(active) >= param.MaxLoans {
+			return ErrLoanLimitExceeded
+		}
+
+		if err := advanceReservationQueue(ctx, q, param.BookID, param.LoanedAt, param.HoldPeriod); err != nil {
+			return err
+		}
+
+		hold, err := q.GetHeldReservationForUpdate(ctx, db.GetHeldReservationForUpdateParams{
+			BookID:   param.BookID,
+			MemberID: param.MemberID,
+		})
+		switch {
+		case err == nil:
+			if err := q.FulfillReservation(ctx, hold.ID); err != nil {
+				return err
+			}
+		case errors.Is(err, pgx.ErrNoRows):
+			availability, err := q.GetBookAvailability(ctx, param.BookID)
+			if err != nil {
+				return err
+			}
+			if availability.Total-availability.OnLoan-availability.OnHold <= 0 {
+				return ErrNoCopyAvailable
+			}
+		default:
+			return err
+		}
+
+		loan, err = q.CreateLoan(ctx, db.CreateLoanParams{
+			BookID:   param.BookID,
+			MemberID: param.MemberID,
+			LoanedAt: pgtype.Timestamptz{Time: param.LoanedAt, Valid: true},
+			DueAt:    pgtype.Timestamptz{Time: param.DueAt, Valid: true},
+		})
+		return err
+	})
+	if err != nil {
+		log.Printf("Unable to execute LoanRepositoryCheckout: %d\n", err)
+		return nil, err
+	}
+
+	return &loan, nil
+}
+
+// ReturnLoan は貸出を返却済みにし、返却された冊数を予約の待ち行列の先頭に取置として割り当てる
+func (r *loanRepositoryImpl) ReturnLoan(ctx context.Context, id int, returnedAt time.Time, holdPeriod time.Duration) (*db.Loan, error) {
+	var loan db.Loan
+	err := withTx(ctx, r.beginner, r.queries, func(q *db.Queries) error {
+		current, err := q.GetLoanByIDForUpdate(ctx, int32(id))
+		if err != nil {
+			return err
+		}
+		if current.ReturnedAt.Valid {
+			return ErrLoanAlreadyReturned
+		}
+		if _, err := q.GetBookByIDForUpdate(ctx, current.BookID); err != nil {
+			return err
+		}
+
+		loan, err = q.ReturnLoan(ctx, db.ReturnLoanParams{
+			ID:         int32(id),
+			ReturnedAt: pgtype.Timestamptz{Time: returnedAt, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		return advanceReservationQueue(ctx, q, current.BookID, returnedAt, holdPeriod)
+	})
+	if err != nil {
+		log.Printf("Unable to execute LoanRepositoryReturnLoan: %d\n", err)
+		return nil, err
+	}
+
+	return &loan, nil
+}
+
+func (r *loanRepositoryImpl) ListOverdueLoans(ctx context.Context, now time.Time) ([]db.Loan, error) {
+	loans, err := r.queries.ListOverdueLoans(ctx, pgtype.Timestamptz{Time: now, Valid: true})
+	if err != nil {
+		log.Printf("Unable to execute LoanRepositoryListOverdueLoans: %d\n", err)
+		return nil, err
+	}
+
+	return loans, nil
+}
+
+func (r *loanRepositoryImpl) GetAvailability(ctx context.Context, bookId int) (*db.GetBookAvailabilityRow, error) {
+	availability, err := r.queries.GetBookAvailability(ctx, int32(bookId))
+	if err != nil {
+		log.Printf("Unable to execute LoanRepositoryGetAvailability: %d\n", err)
+		return nil, err
+	}
+
+	return &availability, nil
+}
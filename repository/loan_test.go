@@ -0,0 +1,355 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	bookColumns = []string{
+		"id", "title", "author", "publisher", "price",
+		"subtitle", "edition", "publication_date", "language", "page_count", "format", "description", "series", "series_volume", "work_id", "isbn", "updated_at",
+	}
+	memberColumns       = []string{"id", "name", "email", "created_at"}
+	loanColumns         = []string{"id", "book_id", "member_id", "loaned_at", "due_at", "returned_at"}
+	availabilityColumns = []string{"total", "on_loan", "on_hold"}
+	reservationColumns  = []string{"id", "book_id", "member_id", "status", "created_at", "held_at", "hold_expires_at"}
+)
+
+// bookRow はbooksテーブルの全カラムを持つモック行を組み立てる
+func bookRow(books ...db.Book) *pgxmock.Rows {
+	rows := pgxmock.NewRows(bookColumns)
+	for _, b := range books {
+		rows.AddRow(
+			b.ID, b.Title, b.Author, b.Publisher, b.Price,
+			b.Subtitle, b.Edition, b.PublicationDate, b.Language, b.PageCount, b.Format, b.Description, b.Series, b.SeriesVolume, b.WorkID, b.Isbn, b.UpdatedAt,
+		)
+	}
+	return rows
+}
+
+func expectCheckoutLocks(mock pgxmock.PgxPoolIface, param *repository.CheckoutParams) {
+	mock.ExpectQuery(`-- name: GetBookByIDForUpdate :one`).
+		WithArgs(param.BookID).
+		WillReturnRows(bookRow(db.Book{ID: param.BookID}))
+	mock.ExpectQuery(`-- name: GetMemberByIDForUpdate :one`).
+		WithArgs(param.MemberID).
+		WillReturnRows(pgxmock.NewRows(memberColumns).AddRow(param.MemberID, "test member 1", "member1@example.com", pgtype.Timestamptz{}))
+}
+
+func TestCheckout(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	loanedAt := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	param := repository.CheckoutParams{
+		BookID:   1,
+		MemberID: 2,
+		LoanedAt: loanedAt,
+		DueAt:    loanedAt.AddDate(0, 0, 14),
+		MaxLoans: 5,
+	}
+	expect := db.Loan{
+		ID:       1,
+		BookID:   1,
+		MemberID: 2,
+		LoanedAt: pgtype.Timestamptz{Time: param.LoanedAt, Valid: true},
+		DueAt:    pgtype.Timestamptz{Time: param.DueAt, Valid: true},
+	}
+
+	mock.ExpectBegin()
+	expectCheckoutLocks(mock, &param)
+	mock.ExpectQuery(`-- name: CountActiveLoansByMemberID :one`).
+		WithArgs(param.MemberID).
+		WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(int32(4)))
+	mock.ExpectExec(`-- name: ExpireHolds :execrows`).
+		WithArgs(param.BookID, pgtype.Timestamptz{Time: param.LoanedAt, Valid: true}).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+	mock.ExpectQuery(`-- name: GetBookAvailability :one`).
+		WithArgs(param.BookID).
+		WillReturnRows(pgxmock.NewRows(availabilityColumns).AddRow(int32(3), int32(2), int32(0)))
+	mock.ExpectQuery(`-- name: GetNextWaitingReservation :one`).
+		WithArgs(param.BookID).
+		WillReturnError(pgx.ErrNoRows)
+	mock.ExpectQuery(`-- name: GetHeldReservationForUpdate :one`).
+		WithArgs(param.BookID, param.MemberID).
+		WillReturnError(pgx.ErrNoRows)
+	mock.ExpectQuery(`-- name: GetBookAvailability :one`).
+		WithArgs(param.BookID).
+		WillReturnRows(pgxmock.NewRows(availabilityColumns).AddRow(int32(3), int32(2), int32(0)))
+	mock.ExpectQuery(`-- name: CreateLoan :one`).
+		WithArgs(param.BookID, param.MemberID, expect.LoanedAt, expect.DueAt).
+		WillReturnRows(pgxmock.NewRows(loanColumns).
+			AddRow(expect.ID, expect.BookID, expect.MemberID, expect.LoanedAt, expect.DueAt, expect.ReturnedAt))
+	mock.ExpectCommit()
+
+	repo := repository.NewLoanRepository(db.New(mock), mock)
+	loan, err := repo.Checkout(context.Background(), &param)
+	assert.NoError(t, err)
+	assert.Equal(t, &expect, loan)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestCheckoutFailureLoanLimitExceeded(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	param := repository.CheckoutParams{
+		BookID:   1,
+		MemberID: 2,
+		MaxLoans: 5,
+	}
+
+	mock.ExpectBegin()
+	expectCheckoutLocks(mock, &param)
+	mock.ExpectQuery(`-- name: CountActiveLoansByMemberID :one`).
+		WithArgs(param.MemberID).
+		WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(int32(5)))
+	mock.ExpectRollback()
+
+	repo := repository.NewLoanRepository(db.New(mock), mock)
+	loan, err := repo.Checkout(context.Background(), &param)
+	assert.ErrorIs(t, err, repository.ErrLoanLimitExceeded)
+	assert.Nil(t, loan)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestCheckoutFailureNoCopyAvailable(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	param := repository.CheckoutParams{
+		BookID:   1,
+		MemberID: 2,
+		MaxLoans: 5,
+	}
+
+	// 在庫数3冊のうち2冊が貸出中、1冊が他の会員の取置中のため、貸出できない
+	mock.ExpectBegin()
+	expectCheckoutLocks(mock, &param)
+	mock.ExpectQuery(`-- name: CountActiveLoansByMemberID :one`).
+		WithArgs(param.MemberID).
+		WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(int32(0)))
+	mock.ExpectExec(`-- name: ExpireHolds :execrows`).
+		WithArgs(param.BookID, pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+	mock.ExpectQuery(`-- name: GetBookAvailability :one`).
+		WithArgs(param.BookID).
+		WillReturnRows(pgxmock.NewRows(availabilityColumns).AddRow(int32(3), int32(2), int32(1)))
+	mock.ExpectQuery(`-- name: GetHeldReservationForUpdate :one`).
+		WithArgs(param.BookID, param.MemberID).
+		WillReturnError(pgx.ErrNoRows)
+	mock.ExpectQuery(`-- name: GetBookAvailability :one`).
+		WithArgs(param.BookID).
+		WillReturnRows(pgxmock.NewRows(availabilityColumns).AddRow(int32(3), int32(2), int32(1)))
+	mock.ExpectRollback()
+
+	repo := repository.NewLoanRepository(db.New(mock), mock)
+	loan, err := repo.Checkout(context.Background(), &param)
+	assert.ErrorIs(t, err, repository.ErrNoCopyAvailable)
+	assert.Nil(t, loan)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestCheckoutHeldReservation(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	loanedAt := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	param := repository.CheckoutParams{
+		BookID:     1,
+		MemberID:   2,
+		LoanedAt:   loanedAt,
+		DueAt:      loanedAt.AddDate(0, 0, 14),
+		MaxLoans:   5,
+		HoldPeriod: 72 * time.Hour,
+	}
+	expect := db.Loan{
+		ID:       1,
+		BookID:   1,
+		MemberID: 2,
+		LoanedAt: pgtype.Timestamptz{Time: param.LoanedAt, Valid: true},
+		DueAt:    pgtype.Timestamptz{Time: param.DueAt, Valid: true},
+	}
+
+	// 空きはないが、会員自身の取置があるため取置分を貸し出す
+	mock.ExpectBegin()
+	expectCheckoutLocks(mock, &param)
+	mock.ExpectQuery(`-- name: CountActiveLoansByMemberID :one`).
+		WithArgs(param.MemberID).
+		WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(int32(0)))
+	mock.ExpectExec(`-- name: ExpireHolds :execrows`).
+		WithArgs(param.BookID, pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+	mock.ExpectQuery(`-- name: GetBookAvailability :one`).
+		WithArgs(param.BookID).
+		WillReturnRows(pgxmock.NewRows(availabilityColumns).AddRow(int32(1), int32(0), int32(1)))
+	mock.ExpectQuery(`-- name: GetHeldReservationForUpdate :one`).
+		WithArgs(param.BookID, param.MemberID).
+		WillReturnRows(pgxmock.NewRows(reservationColumns).
+			AddRow(int32(10), param.BookID, param.MemberID, "held", pgtype.Timestamptz{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}))
+	mock.ExpectExec(`-- name: FulfillReservation :exec`).
+		WithArgs(int32(10)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectQuery(`-- name: CreateLoan :one`).
+		WithArgs(param.BookID, param.MemberID, expect.LoanedAt, expect.DueAt).
+		WillReturnRows(pgxmock.NewRows(loanColumns).
+			AddRow(expect.ID, expect.BookID, expect.MemberID, expect.LoanedAt, expect.DueAt, expect.ReturnedAt))
+	mock.ExpectCommit()
+
+	repo := repository.NewLoanRepository(db.New(mock), mock)
+	loan, err := repo.Checkout(context.Background(), &param)
+	assert.NoError(t, err)
+	assert.Equal(t, &expect, loan)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestReturnLoanAssignsHold(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	id := 1
+	holdPeriod := 72 * time.Hour
+	returnedAt := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	loanedAt := pgtype.Timestamptz{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Valid: true}
+	dueAt := pgtype.Timestamptz{Time: time.Date(2024, 1, 15, 23, 59, 59, 0, time.UTC), Valid: true}
+	expect := db.Loan{
+		ID:         1,
+		BookID:     1,
+		MemberID:   2,
+		LoanedAt:   loanedAt,
+		DueAt:      dueAt,
+		ReturnedAt: pgtype.Timestamptz{Time: returnedAt, Valid: true},
+	}
+
+	// 返却された1冊が待ち行列の先頭の予約に取置として割り当てられる
+	mock.ExpectBegin()
+	mock.ExpectQuery(`-- name: GetLoanByIDForUpdate :one`).
+		WithArgs(int32(id)).
+		WillReturnRows(pgxmock.NewRows(loanColumns).
+			AddRow(int32(id), int32(1), int32(2), loanedAt, dueAt, pgtype.Timestamptz{}))
+	mock.ExpectQuery(`-- name: GetBookByIDForUpdate :one`).
+		WithArgs(int32(1)).
+		WillReturnRows(bookRow(db.Book{ID: int32(1)}))
+	mock.ExpectQuery(`-- name: ReturnLoan :one`).
+		WithArgs(int32(id), expect.ReturnedAt).
+		WillReturnRows(pgxmock.NewRows(loanColumns).
+			AddRow(expect.ID, expect.BookID, expect.MemberID, expect.LoanedAt, expect.DueAt, expect.ReturnedAt))
+	mock.ExpectExec(`-- name: ExpireHolds :execrows`).
+		WithArgs(int32(1), expect.ReturnedAt).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+	mock.ExpectQuery(`-- name: GetBookAvailability :one`).
+		WithArgs(int32(1)).
+		WillReturnRows(pgxmock.NewRows(availabilityColumns).AddRow(int32(1), int32(0), int32(0)))
+	mock.ExpectQuery(`-- name: GetNextWaitingReservation :one`).
+		WithArgs(int32(1)).
+		WillReturnRows(pgxmock.NewRows(reservationColumns).
+			AddRow(int32(10), int32(1), int32(3), "waiting", pgtype.Timestamptz{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}))
+	mock.ExpectQuery(`-- name: HoldReservation :one`).
+		WithArgs(int32(10), expect.ReturnedAt, pgtype.Timestamptz{Time: returnedAt.Add(holdPeriod), Valid: true}).
+		WillReturnRows(pgxmock.NewRows(reservationColumns).
+			AddRow(int32(10), int32(1), int32(3), "held", pgtype.Timestamptz{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}))
+	mock.ExpectQuery(`-- name: GetBookAvailability :one`).
+		WithArgs(int32(1)).
+		WillReturnRows(pgxmock.NewRows(availabilityColumns).AddRow(int32(1), int32(0), int32(1)))
+	mock.ExpectCommit()
+
+	repo := repository.NewLoanRepository(db.New(mock), mock)
+	loan, err := repo.ReturnLoan(context.Background(), id, returnedAt, holdPeriod)
+	assert.NoError(t, err)
+	assert.Equal(t, &expect, loan)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestReturnLoanFailureAlreadyReturned(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	id := 1
+	returnedAt := pgtype.Timestamptz{Time: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), Valid: true}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`-- name: GetLoanByIDForUpdate :one`).
+		WithArgs(int32(id)).
+		WillReturnRows(pgxmock.NewRows(loanColumns).
+			AddRow(int32(id), int32(1), int32(2), pgtype.Timestamptz{}, pgtype.Timestamptz{}, returnedAt))
+	mock.ExpectRollback()
+
+	repo := repository.NewLoanRepository(db.New(mock), mock)
+	loan, err := repo.ReturnLoan(context.Background(), id, time.Now(), 72*time.Hour)
+	assert.ErrorIs(t, err, repository.ErrLoanAlreadyReturned)
+	assert.Nil(t, loan)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestGetAvailability(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	id := 1
+	expect := db.GetBookAvailabilityRow{
+		Total:  3,
+		OnLoan: 1,
+		OnHold: 1,
+	}
+
+	mock.ExpectQuery(`-- name: GetBookAvailability :one`).
+		WithArgs(int32(id)).
+		WillReturnRows(pgxmock.NewRows(availabilityColumns).AddRow(expect.Total, expect.OnLoan, expect.OnHold))
+
+	repo := repository.NewLoanRepository(db.New(mock), mock)
+	availability, err := repo.GetAvailability(context.Background(), id)
+	assert.NoError(t, err)
+	assert.Equal(t, &expect, availability)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
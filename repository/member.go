@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"log"
+
+	"github.com/rentaro-m-b/ai-model-exam/db"
+)
+
+type MemberRepository interface {
+	CreateMember(ctx context.Context, param *db.CreateMemberParams) (*db.Member, error)
+	GetMemberById(ctx context.Context, id int) (*db.Member, error)
+}
+
+type memberRepositoryImpl struct {
+	queries *db.Queries
+}
+
+func NewMemberRepository(db *db.Queries) MemberRepository {
+	return &memberRepositoryImpl{
+		queries: db,
+	}
+}
+
+func (r *memberRepositoryImpl) CreateMember(ctx context.Context, param *db.CreateMemberParams) (*db.Member, error) {
+	member, err := r.queries.CreateMember(ctx, *param)
+	if err != nil {
+		log.Printf("Unable to execute MemberRepositoryCreateMember: %d\n", err)
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+func (r *memberRepositoryImpl) GetMemberById(ctx context.Context, id int) (*db.Member, error) {
+	member, err := r.queries.GetMemberByID(ctx, int32(id))
+	if err != nil {
+		log.Printf("Unable to execute MemberRepositoryGetMemberById: %d\n", err)
+		return nil, err
+	}
+
+	return &member, nil
+}
@@ -0,0 +1,77 @@
+package repository_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateMember(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	param := db.CreateMemberParams{
+		Name:  "test member 1",
+		Email: "member1@example.com",
+	}
+	expect := db.Member{
+		ID:        1,
+		Name:      "test member 1",
+		Email:     "member1@example.com",
+		CreatedAt: pgtype.Timestamptz{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+	}
+
+	rows := pgxmock.NewRows([]string{"id", "name", "email", "created_at"}).
+		AddRow(expect.ID, expect.Name, expect.Email, expect.CreatedAt)
+	sql := `-- name: CreateMember :one
+	INSERT INTO members \(name, email\)
+		VALUES \(\$1, \$2\)
+		RETURNING id, name, email, created_at
+	`
+	mock.ExpectQuery(sql).
+		WithArgs(param.Name, param.Email).
+		WillReturnRows(rows)
+
+	repo := repository.NewMemberRepository(db.New(mock))
+	member, err := repo.CreateMember(context.Background(), &param)
+	assert.NoError(t, err)
+	assert.Equal(t, &expect, member)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestGetMemberByIdFailure(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	id := 1
+
+	sql := `-- name: GetMemberByID :one`
+	mock.ExpectQuery(sql).
+		WithArgs(int32(id)).
+		WillReturnError(fmt.Errorf("query error"))
+
+	repo := repository.NewMemberRepository(db.New(mock))
+	member, err := repo.GetMemberById(context.Background(), id)
+	assert.Error(t, err)
+	assert.Nil(t, member)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
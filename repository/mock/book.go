@@ -10,6 +10,9 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	db "github.com/rentaro-m-b/ai-model-exam/db"
+	event "github.com/rentaro-m-b/ai-model-exam/event"
+	job "github.com/rentaro-m-b/ai-model-exam/job"
+	repository "github.com/rentaro-m-b/ai-model-exam/repository"
 )
 
 // MockBookRepository is a mock of BookRepository interface.
@@ -35,6 +38,54 @@ func (m *MockBookRepository) EXPECT() *MockBookRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AddEvents mocks base method.
+func (m *MockBookRepository) AddEvents(ctx context.Context, events ...event.Event) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range events {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddEvents", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddEvents indicates an expected call of AddEvents.
+func (mr *MockBookRepositoryMockRecorder) AddEvents(ctx interface{}, events ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, events...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEvents", reflect.TypeOf((*MockBookRepository)(nil).AddEvents), varargs...)
+}
+
+// BulkUpdateBooks mocks base method.
+func (m *MockBookRepository) BulkUpdateBooks(ctx context.Context, filter *db.ListBooksByFilterParams, patch *repository.BookPatch) ([]db.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkUpdateBooks", ctx, filter, patch)
+	ret0, _ := ret[0].([]db.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkUpdateBooks indicates an expected call of BulkUpdateBooks.
+func (mr *MockBookRepositoryMockRecorder) BulkUpdateBooks(ctx, filter, patch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUpdateBooks", reflect.TypeOf((*MockBookRepository)(nil).BulkUpdateBooks), ctx, filter, patch)
+}
+
+// CommitJobResult mocks base method.
+func (m *MockBookRepository) CommitJobResult(ctx context.Context, j *job.Job, result []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitJobResult", ctx, j, result)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CommitJobResult indicates an expected call of CommitJobResult.
+func (mr *MockBookRepositoryMockRecorder) CommitJobResult(ctx, j, result interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitJobResult", reflect.TypeOf((*MockBookRepository)(nil).CommitJobResult), ctx, j, result)
+}
+
 // CreateBook mocks base method.
 func (m *MockBookRepository) CreateBook(ctx context.Context, param *db.CreateBookParams) (*db.Book, error) {
 	m.ctrl.T.Helper()
@@ -50,6 +101,51 @@ func (mr *MockBookRepositoryMockRecorder) CreateBook(ctx, param interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBook", reflect.TypeOf((*MockBookRepository)(nil).CreateBook), ctx, param)
 }
 
+// DeleteBooks mocks base method.
+func (m *MockBookRepository) DeleteBooks(ctx context.Context, filter *db.ListBooksByFilterParams) ([]db.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBooks", ctx, filter)
+	ret0, _ := ret[0].([]db.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteBooks indicates an expected call of DeleteBooks.
+func (mr *MockBookRepositoryMockRecorder) DeleteBooks(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBooks", reflect.TypeOf((*MockBookRepository)(nil).DeleteBooks), ctx, filter)
+}
+
+// DeleteExpiredIdempotencyKeys mocks base method.
+func (m *MockBookRepository) DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredIdempotencyKeys", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredIdempotencyKeys indicates an expected call of DeleteExpiredIdempotencyKeys.
+func (mr *MockBookRepositoryMockRecorder) DeleteExpiredIdempotencyKeys(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredIdempotencyKeys", reflect.TypeOf((*MockBookRepository)(nil).DeleteExpiredIdempotencyKeys), ctx)
+}
+
+// FilterBooks mocks base method.
+func (m *MockBookRepository) FilterBooks(ctx context.Context, param *db.FilterBooksParams) ([]db.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FilterBooks", ctx, param)
+	ret0, _ := ret[0].([]db.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FilterBooks indicates an expected call of FilterBooks.
+func (mr *MockBookRepositoryMockRecorder) FilterBooks(ctx, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FilterBooks", reflect.TypeOf((*MockBookRepository)(nil).FilterBooks), ctx, param)
+}
+
 // GetBookById mocks base method.
 func (m *MockBookRepository) GetBookById(ctx context.Context, id int) (*db.Book, error) {
 	m.ctrl.T.Helper()
@@ -65,6 +161,96 @@ func (mr *MockBookRepositoryMockRecorder) GetBookById(ctx, id interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookById", reflect.TypeOf((*MockBookRepository)(nil).GetBookById), ctx, id)
 }
 
+// GetBookCollectionVersion mocks base method.
+func (m *MockBookRepository) GetBookCollectionVersion(ctx context.Context) (*db.GetBookCollectionVersionRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookCollectionVersion", ctx)
+	ret0, _ := ret[0].(*db.GetBookCollectionVersionRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookCollectionVersion indicates an expected call of GetBookCollectionVersion.
+func (mr *MockBookRepositoryMockRecorder) GetBookCollectionVersion(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookCollectionVersion", reflect.TypeOf((*MockBookRepository)(nil).GetBookCollectionVersion), ctx)
+}
+
+// GetBookRedirect mocks base method.
+func (m *MockBookRepository) GetBookRedirect(ctx context.Context, id int) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookRedirect", ctx, id)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookRedirect indicates an expected call of GetBookRedirect.
+func (mr *MockBookRepositoryMockRecorder) GetBookRedirect(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookRedirect", reflect.TypeOf((*MockBookRepository)(nil).GetBookRedirect), ctx, id)
+}
+
+// GetIdempotencyKey mocks base method.
+func (m *MockBookRepository) GetIdempotencyKey(ctx context.Context, key string) (*db.IdempotencyKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIdempotencyKey", ctx, key)
+	ret0, _ := ret[0].(*db.IdempotencyKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIdempotencyKey indicates an expected call of GetIdempotencyKey.
+func (mr *MockBookRepositoryMockRecorder) GetIdempotencyKey(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdempotencyKey", reflect.TypeOf((*MockBookRepository)(nil).GetIdempotencyKey), ctx, key)
+}
+
+// LinkWork mocks base method.
+func (m *MockBookRepository) LinkWork(ctx context.Context, id, workId int) (*db.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkWork", ctx, id, workId)
+	ret0, _ := ret[0].(*db.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LinkWork indicates an expected call of LinkWork.
+func (mr *MockBookRepositoryMockRecorder) LinkWork(ctx, id, workId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkWork", reflect.TypeOf((*MockBookRepository)(nil).LinkWork), ctx, id, workId)
+}
+
+// ListBookAuditLogs mocks base method.
+func (m *MockBookRepository) ListBookAuditLogs(ctx context.Context, bookId int) ([]db.BookAuditLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBookAuditLogs", ctx, bookId)
+	ret0, _ := ret[0].([]db.BookAuditLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBookAuditLogs indicates an expected call of ListBookAuditLogs.
+func (mr *MockBookRepositoryMockRecorder) ListBookAuditLogs(ctx, bookId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBookAuditLogs", reflect.TypeOf((*MockBookRepository)(nil).ListBookAuditLogs), ctx, bookId)
+}
+
+// ListBookRatings mocks base method.
+func (m *MockBookRepository) ListBookRatings(ctx context.Context, bookIds []int32) ([]db.BookRating, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBookRatings", ctx, bookIds)
+	ret0, _ := ret[0].([]db.BookRating)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBookRatings indicates an expected call of ListBookRatings.
+func (mr *MockBookRepositoryMockRecorder) ListBookRatings(ctx, bookIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBookRatings", reflect.TypeOf((*MockBookRepository)(nil).ListBookRatings), ctx, bookIds)
+}
+
 // ListBooks mocks base method.
 func (m *MockBookRepository) ListBooks(ctx context.Context) ([]db.Book, error) {
 	m.ctrl.T.Helper()
@@ -79,3 +265,181 @@ func (mr *MockBookRepositoryMockRecorder) ListBooks(ctx interface{}) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBooks", reflect.TypeOf((*MockBookRepository)(nil).ListBooks), ctx)
 }
+
+// ListBooksByAuthors mocks base method.
+func (m *MockBookRepository) ListBooksByAuthors(ctx context.Context, authors []string) ([]db.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBooksByAuthors", ctx, authors)
+	ret0, _ := ret[0].([]db.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBooksByAuthors indicates an expected call of ListBooksByAuthors.
+func (mr *MockBookRepositoryMockRecorder) ListBooksByAuthors(ctx, authors interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBooksByAuthors", reflect.TypeOf((*MockBookRepository)(nil).ListBooksByAuthors), ctx, authors)
+}
+
+// ListBooksByFilter mocks base method.
+func (m *MockBookRepository) ListBooksByFilter(ctx context.Context, filter *db.ListBooksByFilterParams) ([]db.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBooksByFilter", ctx, filter)
+	ret0, _ := ret[0].([]db.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBooksByFilter indicates an expected call of ListBooksByFilter.
+func (mr *MockBookRepositoryMockRecorder) ListBooksByFilter(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBooksByFilter", reflect.TypeOf((*MockBookRepository)(nil).ListBooksByFilter), ctx, filter)
+}
+
+// ListBooksByIds mocks base method.
+func (m *MockBookRepository) ListBooksByIds(ctx context.Context, ids []int32) ([]db.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBooksByIds", ctx, ids)
+	ret0, _ := ret[0].([]db.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBooksByIds indicates an expected call of ListBooksByIds.
+func (mr *MockBookRepositoryMockRecorder) ListBooksByIds(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBooksByIds", reflect.TypeOf((*MockBookRepository)(nil).ListBooksByIds), ctx, ids)
+}
+
+// ListBooksByPublishers mocks base method.
+func (m *MockBookRepository) ListBooksByPublishers(ctx context.Context, publishers []string) ([]db.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBooksByPublishers", ctx, publishers)
+	ret0, _ := ret[0].([]db.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBooksByPublishers indicates an expected call of ListBooksByPublishers.
+func (mr *MockBookRepositoryMockRecorder) ListBooksByPublishers(ctx, publishers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBooksByPublishers", reflect.TypeOf((*MockBookRepository)(nil).ListBooksByPublishers), ctx, publishers)
+}
+
+// ListBooksByStock mocks base method.
+func (m *MockBookRepository) ListBooksByStock(ctx context.Context, inStock bool) ([]db.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBooksByStock", ctx, inStock)
+	ret0, _ := ret[0].([]db.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBooksByStock indicates an expected call of ListBooksByStock.
+func (mr *MockBookRepositoryMockRecorder) ListBooksByStock(ctx, inStock interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBooksByStock", reflect.TypeOf((*MockBookRepository)(nil).ListBooksByStock), ctx, inStock)
+}
+
+// ListDuplicateCandidates mocks base method.
+func (m *MockBookRepository) ListDuplicateCandidates(ctx context.Context, param *db.ListDuplicateBookCandidatesParams) ([]db.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDuplicateCandidates", ctx, param)
+	ret0, _ := ret[0].([]db.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDuplicateCandidates indicates an expected call of ListDuplicateCandidates.
+func (mr *MockBookRepositoryMockRecorder) ListDuplicateCandidates(ctx, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDuplicateCandidates", reflect.TypeOf((*MockBookRepository)(nil).ListDuplicateCandidates), ctx, param)
+}
+
+// MergeBook mocks base method.
+func (m *MockBookRepository) MergeBook(ctx context.Context, sourceId, targetId int) (*db.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeBook", ctx, sourceId, targetId)
+	ret0, _ := ret[0].(*db.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeBook indicates an expected call of MergeBook.
+func (mr *MockBookRepositoryMockRecorder) MergeBook(ctx, sourceId, targetId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeBook", reflect.TypeOf((*MockBookRepository)(nil).MergeBook), ctx, sourceId, targetId)
+}
+
+// SaveIdempotencyKey mocks base method.
+func (m *MockBookRepository) SaveIdempotencyKey(ctx context.Context, param *db.CreateIdempotencyKeyParams) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveIdempotencyKey", ctx, param)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveIdempotencyKey indicates an expected call of SaveIdempotencyKey.
+func (mr *MockBookRepositoryMockRecorder) SaveIdempotencyKey(ctx, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveIdempotencyKey", reflect.TypeOf((*MockBookRepository)(nil).SaveIdempotencyKey), ctx, param)
+}
+
+// SearchBooks mocks base method.
+func (m *MockBookRepository) SearchBooks(ctx context.Context, param *db.SearchBooksParams) ([]db.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchBooks", ctx, param)
+	ret0, _ := ret[0].([]db.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchBooks indicates an expected call of SearchBooks.
+func (mr *MockBookRepositoryMockRecorder) SearchBooks(ctx, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchBooks", reflect.TypeOf((*MockBookRepository)(nil).SearchBooks), ctx, param)
+}
+
+// UnlinkWork mocks base method.
+func (m *MockBookRepository) UnlinkWork(ctx context.Context, id, workId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlinkWork", ctx, id, workId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlinkWork indicates an expected call of UnlinkWork.
+func (mr *MockBookRepositoryMockRecorder) UnlinkWork(ctx, id, workId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlinkWork", reflect.TypeOf((*MockBookRepository)(nil).UnlinkWork), ctx, id, workId)
+}
+
+// UpdateBook mocks base method.
+func (m *MockBookRepository) UpdateBook(ctx context.Context, param *db.UpdateBookParams) (*db.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBook", ctx, param)
+	ret0, _ := ret[0].(*db.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBook indicates an expected call of UpdateBook.
+func (mr *MockBookRepositoryMockRecorder) UpdateBook(ctx, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBook", reflect.TypeOf((*MockBookRepository)(nil).UpdateBook), ctx, param)
+}
+
+// WithTx mocks base method.
+func (m *MockBookRepository) WithTx(ctx context.Context, fn func(repository.BookRepository) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTx", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithTx indicates an expected call of WithTx.
+func (mr *MockBookRepositoryMockRecorder) WithTx(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTx", reflect.TypeOf((*MockBookRepository)(nil).WithTx), ctx, fn)
+}
@@ -0,0 +1,186 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/category.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	pgtype "github.com/jackc/pgx/v5/pgtype"
+	db "github.com/rentaro-m-b/ai-model-exam/db"
+)
+
+// MockCategoryRepository is a mock of CategoryRepository interface.
+type MockCategoryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCategoryRepositoryMockRecorder
+}
+
+// MockCategoryRepositoryMockRecorder is the mock recorder for MockCategoryRepository.
+type MockCategoryRepositoryMockRecorder struct {
+	mock *MockCategoryRepository
+}
+
+// NewMockCategoryRepository creates a new mock instance.
+func NewMockCategoryRepository(ctrl *gomock.Controller) *MockCategoryRepository {
+	mock := &MockCategoryRepository{ctrl: ctrl}
+	mock.recorder = &MockCategoryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCategoryRepository) EXPECT() *MockCategoryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateCategory mocks base method.
+func (m *MockCategoryRepository) CreateCategory(ctx context.Context, param *db.CreateCategoryParams) (*db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCategory", ctx, param)
+	ret0, _ := ret[0].(*db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCategory indicates an expected call of CreateCategory.
+func (mr *MockCategoryRepositoryMockRecorder) CreateCategory(ctx, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockCategoryRepository)(nil).CreateCategory), ctx, param)
+}
+
+// DeleteCategory mocks base method.
+func (m *MockCategoryRepository) DeleteCategory(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategory", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCategory indicates an expected call of DeleteCategory.
+func (mr *MockCategoryRepositoryMockRecorder) DeleteCategory(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockCategoryRepository)(nil).DeleteCategory), ctx, id)
+}
+
+// GetCategoryById mocks base method.
+func (m *MockCategoryRepository) GetCategoryById(ctx context.Context, id int) (*db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoryById", ctx, id)
+	ret0, _ := ret[0].(*db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoryById indicates an expected call of GetCategoryById.
+func (mr *MockCategoryRepositoryMockRecorder) GetCategoryById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryById", reflect.TypeOf((*MockCategoryRepository)(nil).GetCategoryById), ctx, id)
+}
+
+// ListBookCategories mocks base method.
+func (m *MockCategoryRepository) ListBookCategories(ctx context.Context, bookId int) ([]db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBookCategories", ctx, bookId)
+	ret0, _ := ret[0].([]db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBookCategories indicates an expected call of ListBookCategories.
+func (mr *MockCategoryRepositoryMockRecorder) ListBookCategories(ctx, bookId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBookCategories", reflect.TypeOf((*MockCategoryRepository)(nil).ListBookCategories), ctx, bookId)
+}
+
+// ListCategoryAncestors mocks base method.
+func (m *MockCategoryRepository) ListCategoryAncestors(ctx context.Context, id int) ([]db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCategoryAncestors", ctx, id)
+	ret0, _ := ret[0].([]db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCategoryAncestors indicates an expected call of ListCategoryAncestors.
+func (mr *MockCategoryRepositoryMockRecorder) ListCategoryAncestors(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategoryAncestors", reflect.TypeOf((*MockCategoryRepository)(nil).ListCategoryAncestors), ctx, id)
+}
+
+// ListCategoryTree mocks base method.
+func (m *MockCategoryRepository) ListCategoryTree(ctx context.Context) ([]db.ListCategoryTreeRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCategoryTree", ctx)
+	ret0, _ := ret[0].([]db.ListCategoryTreeRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCategoryTree indicates an expected call of ListCategoryTree.
+func (mr *MockCategoryRepositoryMockRecorder) ListCategoryTree(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategoryTree", reflect.TypeOf((*MockCategoryRepository)(nil).ListCategoryTree), ctx)
+}
+
+// ListChildCategories mocks base method.
+func (m *MockCategoryRepository) ListChildCategories(ctx context.Context, id int) ([]db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListChildCategories", ctx, id)
+	ret0, _ := ret[0].([]db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListChildCategories indicates an expected call of ListChildCategories.
+func (mr *MockCategoryRepositoryMockRecorder) ListChildCategories(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListChildCategories", reflect.TypeOf((*MockCategoryRepository)(nil).ListChildCategories), ctx, id)
+}
+
+// MoveCategory mocks base method.
+func (m *MockCategoryRepository) MoveCategory(ctx context.Context, id int, parentId pgtype.Int4) (*db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveCategory", ctx, id, parentId)
+	ret0, _ := ret[0].(*db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveCategory indicates an expected call of MoveCategory.
+func (mr *MockCategoryRepositoryMockRecorder) MoveCategory(ctx, id, parentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveCategory", reflect.TypeOf((*MockCategoryRepository)(nil).MoveCategory), ctx, id, parentId)
+}
+
+// RenameCategory mocks base method.
+func (m *MockCategoryRepository) RenameCategory(ctx context.Context, id int, name string) (*db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameCategory", ctx, id, name)
+	ret0, _ := ret[0].(*db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenameCategory indicates an expected call of RenameCategory.
+func (mr *MockCategoryRepositoryMockRecorder) RenameCategory(ctx, id, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameCategory", reflect.TypeOf((*MockCategoryRepository)(nil).RenameCategory), ctx, id, name)
+}
+
+// SetBookCategories mocks base method.
+func (m *MockCategoryRepository) SetBookCategories(ctx context.Context, bookId int, categoryIds []int32) ([]db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBookCategories", ctx, bookId, categoryIds)
+	ret0, _ := ret[0].([]db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetBookCategories indicates an expected call of SetBookCategories.
+func (mr *MockCategoryRepositoryMockRecorder) SetBookCategories(ctx, bookId, categoryIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBookCategories", reflect.TypeOf((*MockCategoryRepository)(nil).SetBookCategories), ctx, bookId, categoryIds)
+}
@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/cover.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	db "github.com/rentaro-m-b/ai-model-exam/db"
+)
+
+// MockCoverRepository is a mock of CoverRepository interface.
+type MockCoverRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCoverRepositoryMockRecorder
+}
+
+// MockCoverRepositoryMockRecorder is the mock recorder for MockCoverRepository.
+type MockCoverRepositoryMockRecorder struct {
+	mock *MockCoverRepository
+}
+
+// NewMockCoverRepository creates a new mock instance.
+func NewMockCoverRepository(ctrl *gomock.Controller) *MockCoverRepository {
+	mock := &MockCoverRepository{ctrl: ctrl}
+	mock.recorder = &MockCoverRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCoverRepository) EXPECT() *MockCoverRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetCover mocks base method.
+func (m *MockCoverRepository) GetCover(ctx context.Context, bookId int) (*db.BookCover, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCover", ctx, bookId)
+	ret0, _ := ret[0].(*db.BookCover)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCover indicates an expected call of GetCover.
+func (mr *MockCoverRepositoryMockRecorder) GetCover(ctx, bookId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCover", reflect.TypeOf((*MockCoverRepository)(nil).GetCover), ctx, bookId)
+}
+
+// SaveCover mocks base method.
+func (m *MockCoverRepository) SaveCover(ctx context.Context, param *db.UpsertBookCoverParams) (*db.BookCover, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCover", ctx, param)
+	ret0, _ := ret[0].(*db.BookCover)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SaveCover indicates an expected call of SaveCover.
+func (mr *MockCoverRepositoryMockRecorder) SaveCover(ctx, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCover", reflect.TypeOf((*MockCoverRepository)(nil).SaveCover), ctx, param)
+}
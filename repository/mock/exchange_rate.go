@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/exchange_rate.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	db "github.com/rentaro-m-b/ai-model-exam/db"
+)
+
+// MockExchangeRateRepository is a mock of ExchangeRateRepository interface.
+type MockExchangeRateRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockExchangeRateRepositoryMockRecorder
+}
+
+// MockExchangeRateRepositoryMockRecorder is the mock recorder for MockExchangeRateRepository.
+type MockExchangeRateRepositoryMockRecorder struct {
+	mock *MockExchangeRateRepository
+}
+
+// NewMockExchangeRateRepository creates a new mock instance.
+func NewMockExchangeRateRepository(ctrl *gomock.Controller) *MockExchangeRateRepository {
+	mock := &MockExchangeRateRepository{ctrl: ctrl}
+	mock.recorder = &MockExchangeRateRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExchangeRateRepository) EXPECT() *MockExchangeRateRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetRate mocks base method.
+func (m *MockExchangeRateRepository) GetRate(ctx context.Context, from, to string, on time.Time) (*db.ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRate", ctx, from, to, on)
+	ret0, _ := ret[0].(*db.ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRate indicates an expected call of GetRate.
+func (mr *MockExchangeRateRepositoryMockRecorder) GetRate(ctx, from, to, on interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRate", reflect.TypeOf((*MockExchangeRateRepository)(nil).GetRate), ctx, from, to, on)
+}
+
+// ImportRates mocks base method.
+func (m *MockExchangeRateRepository) ImportRates(ctx context.Context, rates []db.UpsertExchangeRateParams) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportRates", ctx, rates)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportRates indicates an expected call of ImportRates.
+func (mr *MockExchangeRateRepositoryMockRecorder) ImportRates(ctx, rates interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportRates", reflect.TypeOf((*MockExchangeRateRepository)(nil).ImportRates), ctx, rates)
+}
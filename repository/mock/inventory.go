@@ -0,0 +1,82 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/inventory.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	db "github.com/rentaro-m-b/ai-model-exam/db"
+	repository "github.com/rentaro-m-b/ai-model-exam/repository"
+)
+
+// MockInventoryRepository is a mock of InventoryRepository interface.
+type MockInventoryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockInventoryRepositoryMockRecorder
+}
+
+// MockInventoryRepositoryMockRecorder is the mock recorder for MockInventoryRepository.
+type MockInventoryRepositoryMockRecorder struct {
+	mock *MockInventoryRepository
+}
+
+// NewMockInventoryRepository creates a new mock instance.
+func NewMockInventoryRepository(ctrl *gomock.Controller) *MockInventoryRepository {
+	mock := &MockInventoryRepository{ctrl: ctrl}
+	mock.recorder = &MockInventoryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInventoryRepository) EXPECT() *MockInventoryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AdjustStock mocks base method.
+func (m *MockInventoryRepository) AdjustStock(ctx context.Context, param *repository.AdjustStockParams) (*db.StockMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdjustStock", ctx, param)
+	ret0, _ := ret[0].(*db.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdjustStock indicates an expected call of AdjustStock.
+func (mr *MockInventoryRepositoryMockRecorder) AdjustStock(ctx, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustStock", reflect.TypeOf((*MockInventoryRepository)(nil).AdjustStock), ctx, param)
+}
+
+// ListInventories mocks base method.
+func (m *MockInventoryRepository) ListInventories(ctx context.Context, bookId int) ([]db.Inventory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInventories", ctx, bookId)
+	ret0, _ := ret[0].([]db.Inventory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInventories indicates an expected call of ListInventories.
+func (mr *MockInventoryRepositoryMockRecorder) ListInventories(ctx, bookId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInventories", reflect.TypeOf((*MockInventoryRepository)(nil).ListInventories), ctx, bookId)
+}
+
+// ListStockMovements mocks base method.
+func (m *MockInventoryRepository) ListStockMovements(ctx context.Context, bookId int) ([]db.StockMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStockMovements", ctx, bookId)
+	ret0, _ := ret[0].([]db.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStockMovements indicates an expected call of ListStockMovements.
+func (mr *MockInventoryRepositoryMockRecorder) ListStockMovements(ctx, bookId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStockMovements", reflect.TypeOf((*MockInventoryRepository)(nil).ListStockMovements), ctx, bookId)
+}
@@ -0,0 +1,142 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/job.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	db "github.com/rentaro-m-b/ai-model-exam/db"
+	job "github.com/rentaro-m-b/ai-model-exam/job"
+)
+
+// MockJobRepository is a mock of JobRepository interface.
+type MockJobRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockJobRepositoryMockRecorder
+}
+
+// MockJobRepositoryMockRecorder is the mock recorder for MockJobRepository.
+type MockJobRepositoryMockRecorder struct {
+	mock *MockJobRepository
+}
+
+// NewMockJobRepository creates a new mock instance.
+func NewMockJobRepository(ctrl *gomock.Controller) *MockJobRepository {
+	mock := &MockJobRepository{ctrl: ctrl}
+	mock.recorder = &MockJobRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJobRepository) EXPECT() *MockJobRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CancelJob mocks base method.
+func (m *MockJobRepository) CancelJob(ctx context.Context, id int64) (*db.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelJob", ctx, id)
+	ret0, _ := ret[0].(*db.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelJob indicates an expected call of CancelJob.
+func (mr *MockJobRepositoryMockRecorder) CancelJob(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelJob", reflect.TypeOf((*MockJobRepository)(nil).CancelJob), ctx, id)
+}
+
+// ClaimJob mocks base method.
+func (m *MockJobRepository) ClaimJob(ctx context.Context, kinds []string, lease time.Duration) (*job.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimJob", ctx, kinds, lease)
+	ret0, _ := ret[0].(*job.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimJob indicates an expected call of ClaimJob.
+func (mr *MockJobRepositoryMockRecorder) ClaimJob(ctx, kinds, lease interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimJob", reflect.TypeOf((*MockJobRepository)(nil).ClaimJob), ctx, kinds, lease)
+}
+
+// CreateJob mocks base method.
+func (m *MockJobRepository) CreateJob(ctx context.Context, kind string, params []byte) (*db.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateJob", ctx, kind, params)
+	ret0, _ := ret[0].(*db.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateJob indicates an expected call of CreateJob.
+func (mr *MockJobRepositoryMockRecorder) CreateJob(ctx, kind, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJob", reflect.TypeOf((*MockJobRepository)(nil).CreateJob), ctx, kind, params)
+}
+
+// ExtendLease mocks base method.
+func (m *MockJobRepository) ExtendLease(ctx context.Context, j *job.Job, lease time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExtendLease", ctx, j, lease)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExtendLease indicates an expected call of ExtendLease.
+func (mr *MockJobRepositoryMockRecorder) ExtendLease(ctx, j, lease interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExtendLease", reflect.TypeOf((*MockJobRepository)(nil).ExtendLease), ctx, j, lease)
+}
+
+// Finish mocks base method.
+func (m *MockJobRepository) Finish(ctx context.Context, j *job.Job, outcome *job.Outcome) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Finish", ctx, j, outcome)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Finish indicates an expected call of Finish.
+func (mr *MockJobRepositoryMockRecorder) Finish(ctx, j, outcome interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Finish", reflect.TypeOf((*MockJobRepository)(nil).Finish), ctx, j, outcome)
+}
+
+// GetJobById mocks base method.
+func (m *MockJobRepository) GetJobById(ctx context.Context, id int64) (*db.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJobById", ctx, id)
+	ret0, _ := ret[0].(*db.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJobById indicates an expected call of GetJobById.
+func (mr *MockJobRepositoryMockRecorder) GetJobById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJobById", reflect.TypeOf((*MockJobRepository)(nil).GetJobById), ctx, id)
+}
+
+// ReportProgress mocks base method.
+func (m *MockJobRepository) ReportProgress(ctx context.Context, j *job.Job, done, total int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReportProgress", ctx, j, done, total)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReportProgress indicates an expected call of ReportProgress.
+func (mr *MockJobRepositoryMockRecorder) ReportProgress(ctx, j, done, total interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportProgress", reflect.TypeOf((*MockJobRepository)(nil).ReportProgress), ctx, j, done, total)
+}
@@ -0,0 +1,98 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/loan.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	db "github.com/rentaro-m-b/ai-model-exam/db"
+	repository "github.com/rentaro-m-b/ai-model-exam/repository"
+)
+
+// MockLoanRepository is a mock of LoanRepository interface.
+type MockLoanRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoanRepositoryMockRecorder
+}
+
+// MockLoanRepositoryMockRecorder is the mock recorder for MockLoanRepository.
+type MockLoanRepositoryMockRecorder struct {
+	mock *MockLoanRepository
+}
+
+// NewMockLoanRepository creates a new mock instance.
+func NewMockLoanRepository(ctrl *gomock.Controller) *MockLoanRepository {
+	mock := &MockLoanRepository{ctrl: ctrl}
+	mock.recorder = &MockLoanRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoanRepository) EXPECT() *MockLoanRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Checkout mocks base method.
+func (m *MockLoanRepository) Checkout(ctx context.Context, param *repository.CheckoutParams) (*db.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Checkout", ctx, param)
+	ret0, _ := ret[0].(*db.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Checkout indicates an expected call of Checkout.
+func (mr *MockLoanRepositoryMockRecorder) Checkout(ctx, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Checkout", reflect.TypeOf((*MockLoanRepository)(nil).Checkout), ctx, param)
+}
+
+// GetAvailability mocks base method.
+func (m *MockLoanRepository) GetAvailability(ctx context.Context, bookId int) (*db.GetBookAvailabilityRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAvailability", ctx, bookId)
+	ret0, _ := ret[0].(*db.GetBookAvailabilityRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAvailability indicates an expected call of GetAvailability.
+func (mr *MockLoanRepositoryMockRecorder) GetAvailability(ctx, bookId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvailability", reflect.TypeOf((*MockLoanRepository)(nil).GetAvailability), ctx, bookId)
+}
+
+// ListOverdueLoans mocks base method.
+func (m *MockLoanRepository) ListOverdueLoans(ctx context.Context, now time.Time) ([]db.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOverdueLoans", ctx, now)
+	ret0, _ := ret[0].([]db.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOverdueLoans indicates an expected call of ListOverdueLoans.
+func (mr *MockLoanRepositoryMockRecorder) ListOverdueLoans(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOverdueLoans", reflect.TypeOf((*MockLoanRepository)(nil).ListOverdueLoans), ctx, now)
+}
+
+// ReturnLoan mocks base method.
+func (m *MockLoanRepository) ReturnLoan(ctx context.Context, id int, returnedAt time.Time, holdPeriod time.Duration) (*db.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReturnLoan", ctx, id, returnedAt, holdPeriod)
+	ret0, _ := ret[0].(*db.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReturnLoan indicates an expected call of ReturnLoan.
+func (mr *MockLoanRepositoryMockRecorder) ReturnLoan(ctx, id, returnedAt, holdPeriod interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReturnLoan", reflect.TypeOf((*MockLoanRepository)(nil).ReturnLoan), ctx, id, returnedAt, holdPeriod)
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/member.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	db "github.com/rentaro-m-b/ai-model-exam/db"
+)
+
+// MockMemberRepository is a mock of MemberRepository interface.
+type MockMemberRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMemberRepositoryMockRecorder
+}
+
+// MockMemberRepositoryMockRecorder is the mock recorder for MockMemberRepository.
+type MockMemberRepositoryMockRecorder struct {
+	mock *MockMemberRepository
+}
+
+// NewMockMemberRepository creates a new mock instance.
+func NewMockMemberRepository(ctrl *gomock.Controller) *MockMemberRepository {
+	mock := &MockMemberRepository{ctrl: ctrl}
+	mock.recorder = &MockMemberRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMemberRepository) EXPECT() *MockMemberRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateMember mocks base method.
+func (m *MockMemberRepository) CreateMember(ctx context.Context, param *db.CreateMemberParams) (*db.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMember", ctx, param)
+	ret0, _ := ret[0].(*db.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMember indicates an expected call of CreateMember.
+func (mr *MockMemberRepositoryMockRecorder) CreateMember(ctx, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMember", reflect.TypeOf((*MockMemberRepository)(nil).CreateMember), ctx, param)
+}
+
+// GetMemberById mocks base method.
+func (m *MockMemberRepository) GetMemberById(ctx context.Context, id int) (*db.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMemberById", ctx, id)
+	ret0, _ := ret[0].(*db.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMemberById indicates an expected call of GetMemberById.
+func (mr *MockMemberRepositoryMockRecorder) GetMemberById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemberById", reflect.TypeOf((*MockMemberRepository)(nil).GetMemberById), ctx, id)
+}
@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/outbox.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	event "github.com/rentaro-m-b/ai-model-exam/event"
+)
+
+// MockOutboxRepository is a mock of OutboxRepository interface.
+type MockOutboxRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxRepositoryMockRecorder
+}
+
+// MockOutboxRepositoryMockRecorder is the mock recorder for MockOutboxRepository.
+type MockOutboxRepositoryMockRecorder struct {
+	mock *MockOutboxRepository
+}
+
+// NewMockOutboxRepository creates a new mock instance.
+func NewMockOutboxRepository(ctrl *gomock.Controller) *MockOutboxRepository {
+	mock := &MockOutboxRepository{ctrl: ctrl}
+	mock.recorder = &MockOutboxRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxRepository) EXPECT() *MockOutboxRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimEvents mocks base method.
+func (m *MockOutboxRepository) ClaimEvents(ctx context.Context, limit int, leaseUntil time.Time) ([]event.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimEvents", ctx, limit, leaseUntil)
+	ret0, _ := ret[0].([]event.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimEvents indicates an expected call of ClaimEvents.
+func (mr *MockOutboxRepositoryMockRecorder) ClaimEvents(ctx, limit, leaseUntil interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimEvents", reflect.TypeOf((*MockOutboxRepository)(nil).ClaimEvents), ctx, limit, leaseUntil)
+}
+
+// LatestCursor mocks base method.
+func (m *MockOutboxRepository) LatestCursor(ctx context.Context) (event.Cursor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LatestCursor", ctx)
+	ret0, _ := ret[0].(event.Cursor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LatestCursor indicates an expected call of LatestCursor.
+func (mr *MockOutboxRepositoryMockRecorder) LatestCursor(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LatestCursor", reflect.TypeOf((*MockOutboxRepository)(nil).LatestCursor), ctx)
+}
+
+// ListEventsAfter mocks base method.
+func (m *MockOutboxRepository) ListEventsAfter(ctx context.Context, after event.Cursor, limit int) ([]event.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEventsAfter", ctx, after, limit)
+	ret0, _ := ret[0].([]event.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEventsAfter indicates an expected call of ListEventsAfter.
+func (mr *MockOutboxRepositoryMockRecorder) ListEventsAfter(ctx, after, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEventsAfter", reflect.TypeOf((*MockOutboxRepository)(nil).ListEventsAfter), ctx, after, limit)
+}
+
+// MarkDelivered mocks base method.
+func (m *MockOutboxRepository) MarkDelivered(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDelivered", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkDelivered indicates an expected call of MarkDelivered.
+func (mr *MockOutboxRepositoryMockRecorder) MarkDelivered(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDelivered", reflect.TypeOf((*MockOutboxRepository)(nil).MarkDelivered), ctx, id)
+}
+
+// Reschedule mocks base method.
+func (m *MockOutboxRepository) Reschedule(ctx context.Context, id int64, retryAt time.Time, cause string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reschedule", ctx, id, retryAt, cause)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reschedule indicates an expected call of Reschedule.
+func (mr *MockOutboxRepositoryMockRecorder) Reschedule(ctx, id, retryAt, cause interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reschedule", reflect.TypeOf((*MockOutboxRepository)(nil).Reschedule), ctx, id, retryAt, cause)
+}
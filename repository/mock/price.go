@@ -0,0 +1,83 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/price.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	db "github.com/rentaro-m-b/ai-model-exam/db"
+	repository "github.com/rentaro-m-b/ai-model-exam/repository"
+)
+
+// MockPriceRepository is a mock of PriceRepository interface.
+type MockPriceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPriceRepositoryMockRecorder
+}
+
+// MockPriceRepositoryMockRecorder is the mock recorder for MockPriceRepository.
+type MockPriceRepositoryMockRecorder struct {
+	mock *MockPriceRepository
+}
+
+// NewMockPriceRepository creates a new mock instance.
+func NewMockPriceRepository(ctrl *gomock.Controller) *MockPriceRepository {
+	mock := &MockPriceRepository{ctrl: ctrl}
+	mock.recorder = &MockPriceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPriceRepository) EXPECT() *MockPriceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ListPrices mocks base method.
+func (m *MockPriceRepository) ListPrices(ctx context.Context, bookId int) ([]db.BookPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPrices", ctx, bookId)
+	ret0, _ := ret[0].([]db.BookPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPrices indicates an expected call of ListPrices.
+func (mr *MockPriceRepositoryMockRecorder) ListPrices(ctx, bookId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPrices", reflect.TypeOf((*MockPriceRepository)(nil).ListPrices), ctx, bookId)
+}
+
+// ListPricesInEffect mocks base method.
+func (m *MockPriceRepository) ListPricesInEffect(ctx context.Context, bookIds []int32, at time.Time) ([]db.BookPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPricesInEffect", ctx, bookIds, at)
+	ret0, _ := ret[0].([]db.BookPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPricesInEffect indicates an expected call of ListPricesInEffect.
+func (mr *MockPriceRepositoryMockRecorder) ListPricesInEffect(ctx, bookIds, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPricesInEffect", reflect.TypeOf((*MockPriceRepository)(nil).ListPricesInEffect), ctx, bookIds, at)
+}
+
+// SchedulePrice mocks base method.
+func (m *MockPriceRepository) SchedulePrice(ctx context.Context, param *repository.SchedulePriceParams) (*db.BookPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SchedulePrice", ctx, param)
+	ret0, _ := ret[0].(*db.BookPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SchedulePrice indicates an expected call of SchedulePrice.
+func (mr *MockPriceRepositoryMockRecorder) SchedulePrice(ctx, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SchedulePrice", reflect.TypeOf((*MockPriceRepository)(nil).SchedulePrice), ctx, param)
+}
@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/promotion.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	db "github.com/rentaro-m-b/ai-model-exam/db"
+)
+
+// MockPromotionRepository is a mock of PromotionRepository interface.
+type MockPromotionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPromotionRepositoryMockRecorder
+}
+
+// MockPromotionRepositoryMockRecorder is the mock recorder for MockPromotionRepository.
+type MockPromotionRepositoryMockRecorder struct {
+	mock *MockPromotionRepository
+}
+
+// NewMockPromotionRepository creates a new mock instance.
+func NewMockPromotionRepository(ctrl *gomock.Controller) *MockPromotionRepository {
+	mock := &MockPromotionRepository{ctrl: ctrl}
+	mock.recorder = &MockPromotionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPromotionRepository) EXPECT() *MockPromotionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreatePromotion mocks base method.
+func (m *MockPromotionRepository) CreatePromotion(ctx context.Context, param *db.CreatePromotionParams) (*db.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePromotion", ctx, param)
+	ret0, _ := ret[0].(*db.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePromotion indicates an expected call of CreatePromotion.
+func (mr *MockPromotionRepositoryMockRecorder) CreatePromotion(ctx, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePromotion", reflect.TypeOf((*MockPromotionRepository)(nil).CreatePromotion), ctx, param)
+}
+
+// DeletePromotion mocks base method.
+func (m *MockPromotionRepository) DeletePromotion(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePromotion", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePromotion indicates an expected call of DeletePromotion.
+func (mr *MockPromotionRepositoryMockRecorder) DeletePromotion(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePromotion", reflect.TypeOf((*MockPromotionRepository)(nil).DeletePromotion), ctx, id)
+}
+
+// GetPromotionById mocks base method.
+func (m *MockPromotionRepository) GetPromotionById(ctx context.Context, id int) (*db.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPromotionById", ctx, id)
+	ret0, _ := ret[0].(*db.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPromotionById indicates an expected call of GetPromotionById.
+func (mr *MockPromotionRepositoryMockRecorder) GetPromotionById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPromotionById", reflect.TypeOf((*MockPromotionRepository)(nil).GetPromotionById), ctx, id)
+}
+
+// ListActivePromotions mocks base method.
+func (m *MockPromotionRepository) ListActivePromotions(ctx context.Context, bookIds []int32, at time.Time) ([]db.ListActivePromotionsByBookIDsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActivePromotions", ctx, bookIds, at)
+	ret0, _ := ret[0].([]db.ListActivePromotionsByBookIDsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActivePromotions indicates an expected call of ListActivePromotions.
+func (mr *MockPromotionRepositoryMockRecorder) ListActivePromotions(ctx, bookIds, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActivePromotions", reflect.TypeOf((*MockPromotionRepository)(nil).ListActivePromotions), ctx, bookIds, at)
+}
+
+// ListPromotions mocks base method.
+func (m *MockPromotionRepository) ListPromotions(ctx context.Context) ([]db.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPromotions", ctx)
+	ret0, _ := ret[0].([]db.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPromotions indicates an expected call of ListPromotions.
+func (mr *MockPromotionRepositoryMockRecorder) ListPromotions(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPromotions", reflect.TypeOf((*MockPromotionRepository)(nil).ListPromotions), ctx)
+}
@@ -0,0 +1,69 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/reservation.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	db "github.com/rentaro-m-b/ai-model-exam/db"
+	repository "github.com/rentaro-m-b/ai-model-exam/repository"
+)
+
+// MockReservationRepository is a mock of ReservationRepository interface.
+type MockReservationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReservationRepositoryMockRecorder
+}
+
+// MockReservationRepositoryMockRecorder is the mock recorder for MockReservationRepository.
+type MockReservationRepositoryMockRecorder struct {
+	mock *MockReservationRepository
+}
+
+// NewMockReservationRepository creates a new mock instance.
+func NewMockReservationRepository(ctrl *gomock.Controller) *MockReservationRepository {
+	mock := &MockReservationRepository{ctrl: ctrl}
+	mock.recorder = &MockReservationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReservationRepository) EXPECT() *MockReservationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ListReservations mocks base method.
+func (m *MockReservationRepository) ListReservations(ctx context.Context, bookId int, now time.Time, holdPeriod time.Duration) ([]db.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReservations", ctx, bookId, now, holdPeriod)
+	ret0, _ := ret[0].([]db.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReservations indicates an expected call of ListReservations.
+func (mr *MockReservationRepositoryMockRecorder) ListReservations(ctx, bookId, now, holdPeriod interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReservations", reflect.TypeOf((*MockReservationRepository)(nil).ListReservations), ctx, bookId, now, holdPeriod)
+}
+
+// Reserve mocks base method.
+func (m *MockReservationRepository) Reserve(ctx context.Context, param *repository.ReserveParams) (*db.Reservation, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reserve", ctx, param)
+	ret0, _ := ret[0].(*db.Reservation)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Reserve indicates an expected call of Reserve.
+func (mr *MockReservationRepositoryMockRecorder) Reserve(ctx, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reserve", reflect.TypeOf((*MockReservationRepository)(nil).Reserve), ctx, param)
+}
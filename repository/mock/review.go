@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/review.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	db "github.com/rentaro-m-b/ai-model-exam/db"
+)
+
+// MockReviewRepository is a mock of ReviewRepository interface.
+type MockReviewRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReviewRepositoryMockRecorder
+}
+
+// MockReviewRepositoryMockRecorder is the mock recorder for MockReviewRepository.
+type MockReviewRepositoryMockRecorder struct {
+	mock *MockReviewRepository
+}
+
+// NewMockReviewRepository creates a new mock instance.
+func NewMockReviewRepository(ctrl *gomock.Controller) *MockReviewRepository {
+	mock := &MockReviewRepository{ctrl: ctrl}
+	mock.recorder = &MockReviewRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReviewRepository) EXPECT() *MockReviewRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateReview mocks base method.
+func (m *MockReviewRepository) CreateReview(ctx context.Context, param *db.CreateReviewParams) (*db.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReview", ctx, param)
+	ret0, _ := ret[0].(*db.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReview indicates an expected call of CreateReview.
+func (mr *MockReviewRepositoryMockRecorder) CreateReview(ctx, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReview", reflect.TypeOf((*MockReviewRepository)(nil).CreateReview), ctx, param)
+}
+
+// DeleteReview mocks base method.
+func (m *MockReviewRepository) DeleteReview(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteReview", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteReview indicates an expected call of DeleteReview.
+func (mr *MockReviewRepositoryMockRecorder) DeleteReview(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReview", reflect.TypeOf((*MockReviewRepository)(nil).DeleteReview), ctx, id)
+}
+
+// ListBookReviews mocks base method.
+func (m *MockReviewRepository) ListBookReviews(ctx context.Context, bookId int) ([]db.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBookReviews", ctx, bookId)
+	ret0, _ := ret[0].([]db.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBookReviews indicates an expected call of ListBookReviews.
+func (mr *MockReviewRepositoryMockRecorder) ListBookReviews(ctx, bookId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBookReviews", reflect.TypeOf((*MockReviewRepository)(nil).ListBookReviews), ctx, bookId)
+}
+
+// UpdateReview mocks base method.
+func (m *MockReviewRepository) UpdateReview(ctx context.Context, param *db.UpdateReviewParams) (*db.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateReview", ctx, param)
+	ret0, _ := ret[0].(*db.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateReview indicates an expected call of UpdateReview.
+func (mr *MockReviewRepositoryMockRecorder) UpdateReview(ctx, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReview", reflect.TypeOf((*MockReviewRepository)(nil).UpdateReview), ctx, param)
+}
@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/tag.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	db "github.com/rentaro-m-b/ai-model-exam/db"
+)
+
+// MockTagRepository is a mock of TagRepository interface.
+type MockTagRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagRepositoryMockRecorder
+}
+
+// MockTagRepositoryMockRecorder is the mock recorder for MockTagRepository.
+type MockTagRepositoryMockRecorder struct {
+	mock *MockTagRepository
+}
+
+// NewMockTagRepository creates a new mock instance.
+func NewMockTagRepository(ctrl *gomock.Controller) *MockTagRepository {
+	mock := &MockTagRepository{ctrl: ctrl}
+	mock.recorder = &MockTagRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTagRepository) EXPECT() *MockTagRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddBookTag mocks base method.
+func (m *MockTagRepository) AddBookTag(ctx context.Context, bookId int, name string) (*db.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddBookTag", ctx, bookId, name)
+	ret0, _ := ret[0].(*db.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddBookTag indicates an expected call of AddBookTag.
+func (mr *MockTagRepositoryMockRecorder) AddBookTag(ctx, bookId, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBookTag", reflect.TypeOf((*MockTagRepository)(nil).AddBookTag), ctx, bookId, name)
+}
+
+// ListBookTags mocks base method.
+func (m *MockTagRepository) ListBookTags(ctx context.Context, bookId int) ([]db.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBookTags", ctx, bookId)
+	ret0, _ := ret[0].([]db.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBookTags indicates an expected call of ListBookTags.
+func (mr *MockTagRepositoryMockRecorder) ListBookTags(ctx, bookId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBookTags", reflect.TypeOf((*MockTagRepository)(nil).ListBookTags), ctx, bookId)
+}
+
+// RemoveBookTag mocks base method.
+func (m *MockTagRepository) RemoveBookTag(ctx context.Context, bookId int, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveBookTag", ctx, bookId, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveBookTag indicates an expected call of RemoveBookTag.
+func (mr *MockTagRepositoryMockRecorder) RemoveBookTag(ctx, bookId, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveBookTag", reflect.TypeOf((*MockTagRepository)(nil).RemoveBookTag), ctx, bookId, name)
+}
+
+// SearchTags mocks base method.
+func (m *MockTagRepository) SearchTags(ctx context.Context, prefix string, limit int) ([]db.SearchTagsByPrefixRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchTags", ctx, prefix, limit)
+	ret0, _ := ret[0].([]db.SearchTagsByPrefixRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchTags indicates an expected call of SearchTags.
+func (mr *MockTagRepositoryMockRecorder) SearchTags(ctx, prefix, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchTags", reflect.TypeOf((*MockTagRepository)(nil).SearchTags), ctx, prefix, limit)
+}
@@ -0,0 +1,229 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/webhook.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	db "github.com/rentaro-m-b/ai-model-exam/db"
+	event "github.com/rentaro-m-b/ai-model-exam/event"
+	webhook "github.com/rentaro-m-b/ai-model-exam/webhook"
+)
+
+// MockWebhookRepository is a mock of WebhookRepository interface.
+type MockWebhookRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookRepositoryMockRecorder
+}
+
+// MockWebhookRepositoryMockRecorder is the mock recorder for MockWebhookRepository.
+type MockWebhookRepositoryMockRecorder struct {
+	mock *MockWebhookRepository
+}
+
+// NewMockWebhookRepository creates a new mock instance.
+func NewMockWebhookRepository(ctrl *gomock.Controller) *MockWebhookRepository {
+	mock := &MockWebhookRepository{ctrl: ctrl}
+	mock.recorder = &MockWebhookRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookRepository) EXPECT() *MockWebhookRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimDeliveries mocks base method.
+func (m *MockWebhookRepository) ClaimDeliveries(ctx context.Context, limit int, leaseUntil time.Time) ([]webhook.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDeliveries", ctx, limit, leaseUntil)
+	ret0, _ := ret[0].([]webhook.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDeliveries indicates an expected call of ClaimDeliveries.
+func (mr *MockWebhookRepositoryMockRecorder) ClaimDeliveries(ctx, limit, leaseUntil interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDeliveries", reflect.TypeOf((*MockWebhookRepository)(nil).ClaimDeliveries), ctx, limit, leaseUntil)
+}
+
+// CreateWebhook mocks base method.
+func (m *MockWebhookRepository) CreateWebhook(ctx context.Context, param *db.CreateWebhookSubscriptionParams) (*db.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhook", ctx, param)
+	ret0, _ := ret[0].(*db.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhook indicates an expected call of CreateWebhook.
+func (mr *MockWebhookRepositoryMockRecorder) CreateWebhook(ctx, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhook", reflect.TypeOf((*MockWebhookRepository)(nil).CreateWebhook), ctx, param)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockWebhookRepository) DeleteWebhook(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockWebhookRepositoryMockRecorder) DeleteWebhook(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockWebhookRepository)(nil).DeleteWebhook), ctx, id)
+}
+
+// EnqueueDeliveries mocks base method.
+func (m *MockWebhookRepository) EnqueueDeliveries(ctx context.Context, eventId int64, eventType event.Type, body []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqueueDeliveries", ctx, eventId, eventType, body)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnqueueDeliveries indicates an expected call of EnqueueDeliveries.
+func (mr *MockWebhookRepositoryMockRecorder) EnqueueDeliveries(ctx, eventId, eventType, body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueDeliveries", reflect.TypeOf((*MockWebhookRepository)(nil).EnqueueDeliveries), ctx, eventId, eventType, body)
+}
+
+// GetWebhookById mocks base method.
+func (m *MockWebhookRepository) GetWebhookById(ctx context.Context, id int) (*db.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookById", ctx, id)
+	ret0, _ := ret[0].(*db.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookById indicates an expected call of GetWebhookById.
+func (mr *MockWebhookRepositoryMockRecorder) GetWebhookById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookById", reflect.TypeOf((*MockWebhookRepository)(nil).GetWebhookById), ctx, id)
+}
+
+// ListDeadDeliveries mocks base method.
+func (m *MockWebhookRepository) ListDeadDeliveries(ctx context.Context, limit int) ([]db.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeadDeliveries", ctx, limit)
+	ret0, _ := ret[0].([]db.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeadDeliveries indicates an expected call of ListDeadDeliveries.
+func (mr *MockWebhookRepositoryMockRecorder) ListDeadDeliveries(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeadDeliveries", reflect.TypeOf((*MockWebhookRepository)(nil).ListDeadDeliveries), ctx, limit)
+}
+
+// ListDeliveries mocks base method.
+func (m *MockWebhookRepository) ListDeliveries(ctx context.Context, param *db.ListWebhookDeliveriesParams) ([]db.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeliveries", ctx, param)
+	ret0, _ := ret[0].([]db.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeliveries indicates an expected call of ListDeliveries.
+func (mr *MockWebhookRepositoryMockRecorder) ListDeliveries(ctx, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeliveries", reflect.TypeOf((*MockWebhookRepository)(nil).ListDeliveries), ctx, param)
+}
+
+// ListWebhooks mocks base method.
+func (m *MockWebhookRepository) ListWebhooks(ctx context.Context) ([]db.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhooks", ctx)
+	ret0, _ := ret[0].([]db.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhooks indicates an expected call of ListWebhooks.
+func (mr *MockWebhookRepositoryMockRecorder) ListWebhooks(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhooks", reflect.TypeOf((*MockWebhookRepository)(nil).ListWebhooks), ctx)
+}
+
+// MarkFailed mocks base method.
+func (m *MockWebhookRepository) MarkFailed(ctx context.Context, id int64, dead bool, retryAt time.Time, statusCode int, cause string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkFailed", ctx, id, dead, retryAt, statusCode, cause)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkFailed indicates an expected call of MarkFailed.
+func (mr *MockWebhookRepositoryMockRecorder) MarkFailed(ctx, id, dead, retryAt, statusCode, cause interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkFailed", reflect.TypeOf((*MockWebhookRepository)(nil).MarkFailed), ctx, id, dead, retryAt, statusCode, cause)
+}
+
+// MarkSucceeded mocks base method.
+func (m *MockWebhookRepository) MarkSucceeded(ctx context.Context, id int64, statusCode int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkSucceeded", ctx, id, statusCode)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkSucceeded indicates an expected call of MarkSucceeded.
+func (mr *MockWebhookRepositoryMockRecorder) MarkSucceeded(ctx, id, statusCode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkSucceeded", reflect.TypeOf((*MockWebhookRepository)(nil).MarkSucceeded), ctx, id, statusCode)
+}
+
+// ReleaseDeliveries mocks base method.
+func (m *MockWebhookRepository) ReleaseDeliveries(ctx context.Context, ids []int64, leaseUntil time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseDeliveries", ctx, ids, leaseUntil)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseDeliveries indicates an expected call of ReleaseDeliveries.
+func (mr *MockWebhookRepositoryMockRecorder) ReleaseDeliveries(ctx, ids, leaseUntil interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseDeliveries", reflect.TypeOf((*MockWebhookRepository)(nil).ReleaseDeliveries), ctx, ids, leaseUntil)
+}
+
+// RequeueDelivery mocks base method.
+func (m *MockWebhookRepository) RequeueDelivery(ctx context.Context, id int, deliveryId int64) (*db.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequeueDelivery", ctx, id, deliveryId)
+	ret0, _ := ret[0].(*db.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequeueDelivery indicates an expected call of RequeueDelivery.
+func (mr *MockWebhookRepositoryMockRecorder) RequeueDelivery(ctx, id, deliveryId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequeueDelivery", reflect.TypeOf((*MockWebhookRepository)(nil).RequeueDelivery), ctx, id, deliveryId)
+}
+
+// UpdateWebhook mocks base method.
+func (m *MockWebhookRepository) UpdateWebhook(ctx context.Context, param *db.UpdateWebhookSubscriptionParams) (*db.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhook", ctx, param)
+	ret0, _ := ret[0].(*db.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWebhook indicates an expected call of UpdateWebhook.
+func (mr *MockWebhookRepositoryMockRecorder) UpdateWebhook(ctx, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhook", reflect.TypeOf((*MockWebhookRepository)(nil).UpdateWebhook), ctx, param)
+}
@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository/work.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	db "github.com/rentaro-m-b/ai-model-exam/db"
+)
+
+// MockWorkRepository is a mock of WorkRepository interface.
+type MockWorkRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkRepositoryMockRecorder
+}
+
+// MockWorkRepositoryMockRecorder is the mock recorder for MockWorkRepository.
+type MockWorkRepositoryMockRecorder struct {
+	mock *MockWorkRepository
+}
+
+// NewMockWorkRepository creates a new mock instance.
+func NewMockWorkRepository(ctrl *gomock.Controller) *MockWorkRepository {
+	mock := &MockWorkRepository{ctrl: ctrl}
+	mock.recorder = &MockWorkRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkRepository) EXPECT() *MockWorkRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateWork mocks base method.
+func (m *MockWorkRepository) CreateWork(ctx context.Context, param *db.CreateWorkParams) (*db.Work, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWork", ctx, param)
+	ret0, _ := ret[0].(*db.Work)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWork indicates an expected call of CreateWork.
+func (mr *MockWorkRepositoryMockRecorder) CreateWork(ctx, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWork", reflect.TypeOf((*MockWorkRepository)(nil).CreateWork), ctx, param)
+}
+
+// GetWorkById mocks base method.
+func (m *MockWorkRepository) GetWorkById(ctx context.Context, id int) (*db.Work, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkById", ctx, id)
+	ret0, _ := ret[0].(*db.Work)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkById indicates an expected call of GetWorkById.
+func (mr *MockWorkRepositoryMockRecorder) GetWorkById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkById", reflect.TypeOf((*MockWorkRepository)(nil).GetWorkById), ctx, id)
+}
+
+// ListWorkEditions mocks base method.
+func (m *MockWorkRepository) ListWorkEditions(ctx context.Context, id int) ([]db.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWorkEditions", ctx, id)
+	ret0, _ := ret[0].([]db.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWorkEditions indicates an expected call of ListWorkEditions.
+func (mr *MockWorkRepositoryMockRecorder) ListWorkEditions(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkEditions", reflect.TypeOf((*MockWorkRepository)(nil).ListWorkEditions), ctx, id)
+}
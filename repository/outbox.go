@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/event"
+)
+
+// OutboxRepository はアウトボックスのイベントを配信するためのevent.Storeと、読み出すためのevent.Feedの実装
+type OutboxRepository interface {
+	ClaimEvents(ctx context.Context, limit int, leaseUntil time.Time) ([]event.Event, error)
+	MarkDelivered(ctx context.Context, id int64) error
+	Reschedule(ctx context.Context, id int64, retryAt time.Time, cause string) error
+	LatestCursor(ctx context.Context) (event.Cursor, error)
+	ListEventsAfter(ctx context.Context, after event.Cursor, limit int) ([]event.Event, error)
+}
+
+type outboxRepositoryImpl struct {
+	queries *db.Queries
+}
+
+func NewOutboxRepository(db *db.Queries) OutboxRepository {
+	return &outboxRepositoryImpl{
+		queries: db,
+	}
+}
+
+// ClaimEvents は他の配信処理がロック中のイベントを飛ばして取得するため、複数のプロセスから同時に呼び出せる
+func (r *outboxRepositoryImpl) ClaimEvents(ctx context.Context, limit int, leaseUntil time.Time) ([]event.Event, error) {
+	rows, err := r.queries.ClaimOutboxEvents(ctx, db.ClaimOutboxEventsParams{
+		LeaseUntil: pgtype.Timestamptz{Time: leaseUntil, Valid: true},
+		MaxEvents:  int32(limit),
+	})
+	if err != nil {
+		log.Printf("Unable to execute OutboxRepositoryClaimEvents: %d\n", err)
+		return nil, err
+	}
+
+	return parseOutboxEvents(rows), nil
+}
+
+func (r *outboxRepositoryImpl) MarkDelivered(ctx context.Context, id int64) error {
+	if err := r.queries.MarkOutboxEventDelivered(ctx, id); err != nil {
+		log.Printf("Unable to execute OutboxRepositoryMarkDelivered: %d\n", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *outboxRepositoryImpl) Reschedule(ctx context.Context, id int64, retryAt time.Time, cause string) error {
+	err := r.queries.RescheduleOutboxEvent(ctx, db.RescheduleOutboxEventParams{
+		ID:            id,
+		NextAttemptAt: pgtype.Timestamptz{Time: retryAt, Valid: true},
+		LastError:     pgtype.Text{String: cause, Valid: true},
+	})
+	if err != nil {
+		log.Printf("Unable to execute OutboxRepositoryReschedule: %d\n", err)
+		return err
+	}
+
+	return nil
+}
+
+// LatestCursor は実行中のトランザクションより前にコミットされたイベントのうち、最後の位置を返す
+func (r *outboxRepositoryImpl) LatestCursor(ctx context.Context) (event.Cursor, error) {
+	row, err := r.queries.GetLatestOutboxEventCursor(ctx)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return event.Cursor{}, nil
+	}
+	if err != nil {
+		log.Printf("Unable to execute OutboxRepositoryLatestCursor: %d\n", err)
+		return event.Cursor{}, err
+	}
+
+	return event.Cursor{TxID: row.TxID, ID: row.ID}, nil
+}
+
+// ListEventsAfter は配信済みのイベントも含めて返すため、受け手が取りこぼしたイベントの読み直しに使える
+// 実行中のトランザクションのうち最も古いものより前のトランザクションが登録したイベントのみを返すため、
+// 長く実行中のトランザクションがある間は、その後にコミットされたイベントも返さずに待つ
+func (r *outboxRepositoryImpl) ListEventsAfter(ctx context.Context, after event.Cursor, limit int) ([]event.Event, error) {
+	rows, err := r.queries.ListOutboxEventsAfter(ctx, db.ListOutboxEventsAfterParams{
+		AfterTxID: after.TxID,
+		AfterID:   after.ID,
+		MaxEvents: int32(limit),
+	})
+	if err != nil {
+		log.Printf("Unable to execute OutboxRepositoryListEventsAfter: %d\n", err)
+		return nil, err
+	}
+
+	return parseOutboxEvents(rows), nil
+}
+
+func parseOutboxEvents(rows []db.OutboxEvent) []event.Event {
+	events := make([]event.Event, 0, len(rows))
+	for _, row := range rows {
+		events = append(events, event.Event{
+			ID:         row.ID,
+			TxID:       row.TxID,
+			Type:       event.Type(row.EventType),
+			BookID:     row.BookID,
+			Payload:    row.Payload,
+			OccurredAt: row.OccurredAt.Time,
+			Attempts:   int(row.Attempts),
+		})
+	}
+
+	return events
+}
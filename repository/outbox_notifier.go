@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// outboxEventsChannel はアウトボックスへのイベントの登録をトリガーが通知するチャネル
+const outboxEventsChannel = "outbox_events"
+
+// OutboxNotifier はPostgreSQLのLISTEN/NOTIFYでアウトボックスへのイベントの登録を待つevent.Notifierの実装
+// 通知はイベントを登録したトランザクションのコミット時に届く
+type OutboxNotifier struct {
+	pool *pgxpool.Pool
+	// conn は通知を受け取るためにプールから借りたままにする接続で、接続前や切断後はnil
+	conn *pgxpool.Conn
+}
+
+func NewOutboxNotifier(pool *pgxpool.Pool) *OutboxNotifier {
+	return &OutboxNotifier{
+		pool: pool,
+	}
+}
+
+// WaitForEvent は同時に1つの処理からのみ呼び出せる
+// 接続が切れた場合は次の呼び出しで接続し直すため、その間の通知は届かない
+func (n *OutboxNotifier) WaitForEvent(ctx context.Context) error {
+	if n.conn == nil {
+		conn, err := n.pool.Acquire(ctx)
+		if err != nil {
+			log.Printf("Unable to execute OutboxNotifierWaitForEvent: %d\n", err)
+			return err
+		}
+		if _, err := conn.Exec(ctx, "LISTEN "+outboxEventsChannel); err != nil {
+			log.Printf("Unable to execute OutboxNotifierWaitForEvent: %d\n", err)
+			conn.Release()
+			return err
+		}
+		n.conn = conn
+	}
+
+	_, err := n.conn.Conn().WaitForNotification(ctx)
+	// 待機の時間切れでは接続は切れないため、そのまま次の待機に使う
+	if err != nil && (n.conn.Conn().IsClosed() || errors.Is(err, context.Canceled)) {
+		n.release()
+	}
+
+	return err
+}
+
+// release は他の処理へ通知が届かないよう、購読をやめてから接続をプールへ返す
+func (n *OutboxNotifier) release() {
+	if !n.conn.Conn().IsClosed() {
+		if _, err := n.conn.Exec(context.Background(), "UNLISTEN "+outboxEventsChannel); err != nil {
+			log.Printf("Unable to execute OutboxNotifierRelease: %d\n", err)
+		}
+	}
+	n.conn.Release()
+	n.conn = nil
+}
@@ -0,0 +1,139 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/event"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+var outboxEventColumns = []string{
+	"id", "event_type", "book_id", "payload", "occurred_at", "attempts", "next_attempt_at", "delivered_at", "last_error", "tx_id",
+}
+
+func TestClaimEvents(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	occurredAt := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	leaseUntil := occurredAt.Add(time.Minute)
+	payload := []byte(`{"id": 1}`)
+
+	mock.ExpectQuery(`-- name: ClaimOutboxEvents :many`).
+		WithArgs(pgtype.Timestamptz{Time: leaseUntil, Valid: true}, int32(10)).
+		WillReturnRows(pgxmock.NewRows(outboxEventColumns).AddRow(
+			int64(1), "book.created", int32(1), payload, pgtype.Timestamptz{Time: occurredAt, Valid: true},
+			int32(1), pgtype.Timestamptz{Time: leaseUntil, Valid: true}, pgtype.Timestamptz{}, pgtype.Text{}, int64(100),
+		))
+
+	repo := repository.NewOutboxRepository(db.New(mock))
+	events, err := repo.ClaimEvents(context.Background(), 10, leaseUntil)
+	assert.NoError(t, err)
+	assert.Equal(t, []event.Event{
+		{ID: 1, TxID: 100, Type: event.TypeBookCreated, BookID: 1, Payload: payload, OccurredAt: occurredAt, Attempts: 1},
+	}, events)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestReschedule(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	retryAt := time.Date(2024, 7, 1, 0, 0, 1, 0, time.UTC)
+	mock.ExpectExec(`-- name: RescheduleOutboxEvent :exec`).
+		WithArgs(int64(1), pgtype.Timestamptz{Time: retryAt, Valid: true}, pgtype.Text{String: "sink error", Valid: true}).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	repo := repository.NewOutboxRepository(db.New(mock))
+	assert.NoError(t, repo.Reschedule(context.Background(), 1, retryAt, "sink error"))
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestListEventsAfter(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	occurredAt := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	payload := []byte(`{"id": 1}`)
+
+	// 配信済みのイベントも返す
+	mock.ExpectQuery(`-- name: ListOutboxEventsAfter :many`).
+		WithArgs(int64(100), int64(5), int32(100)).
+		WillReturnRows(pgxmock.NewRows(outboxEventColumns).AddRow(
+			int64(6), "book.updated", int32(1), payload, pgtype.Timestamptz{Time: occurredAt, Valid: true},
+			int32(1), pgtype.Timestamptz{Time: occurredAt, Valid: true}, pgtype.Timestamptz{Time: occurredAt, Valid: true}, pgtype.Text{}, int64(101),
+		))
+
+	repo := repository.NewOutboxRepository(db.New(mock))
+	events, err := repo.ListEventsAfter(context.Background(), event.Cursor{TxID: 100, ID: 5}, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, []event.Event{
+		{ID: 6, TxID: 101, Type: event.TypeBookUpdated, BookID: 1, Payload: payload, OccurredAt: occurredAt, Attempts: 1},
+	}, events)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestLatestCursor(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	mock.ExpectQuery(`-- name: GetLatestOutboxEventCursor :one`).
+		WillReturnRows(pgxmock.NewRows([]string{"tx_id", "id"}).AddRow(int64(101), int64(7)))
+
+	repo := repository.NewOutboxRepository(db.New(mock))
+	cursor, err := repo.LatestCursor(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, event.Cursor{TxID: 101, ID: 7}, cursor)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestLatestCursorEmpty(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	// イベントが無い場合はゼロ値を返す
+	mock.ExpectQuery(`-- name: GetLatestOutboxEventCursor :one`).
+		WillReturnRows(pgxmock.NewRows([]string{"tx_id", "id"}))
+
+	repo := repository.NewOutboxRepository(db.New(mock))
+	cursor, err := repo.LatestCursor(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, event.Cursor{}, cursor)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+)
+
+type SchedulePriceParams struct {
+	BookID        int32
+	Currency      string
+	Amount        int64
+	EffectiveFrom time.Time
+}
+
+type PriceRepository interface {
+	ListPrices(ctx context.Context, bookId int) ([]db.BookPrice, error)
+	ListPricesInEffect(ctx context.Context, bookIds []int32, at time.Time) ([]db.BookPrice, error)
+	SchedulePrice(ctx context.Context, param *SchedulePriceParams) (*db.BookPrice, error)
+}
+
+type priceRepositoryImpl struct {
+	queries  *db.Queries
+	beginner TxBeginner
+}
+
+func NewPriceRepository(db *db.Queries, beginner TxBeginner) PriceRepository {
+	return &priceRepositoryImpl{
+		queries:  db,
+		beginner: beginner,
+	}
+}
+
+func (r *priceRepositoryImpl) ListPrices(ctx context.Context, bookId int) ([]db.BookPrice, error) {
+	prices, err := r.queries.ListBookPricesByBookID(ctx, int32(bookId))
+	if err != nil {
+		log.Printf("Unable to execute PriceRepositoryListPrices: %d\n", err)
+		return nil, err
+	}
+
+	return prices, nil
+}
+
+func (r *priceRepositoryImpl) ListPricesInEffect(ctx context.Context, bookIds []int32, at time.Time) ([]db.BookPrice, error) {
+	prices, err := r.queries.ListBookPricesInEffect(ctx, db.ListBookPricesInEffectParams{
+		BookIds: bookIds,
+		At:      pgtype.Timestamptz{Time: at, Valid: true},
+	})
+	if err != nil {
+		log.Printf("Unable to execute PriceRepositoryListPricesInEffect: %d\n", err)
+		return nil, err
+	}
+
+	return prices, nil
+}
+
+// SchedulePrice は指定日時から有効になる価格を登録する
+// 直前の価格の有効期間を登録する価格の開始日時で打ち切り、後に予定された価格があればその開始日時までを有効期間とする
+func (r *priceRepositoryImpl) SchedulePrice(ctx context.Context, param *SchedulePriceParams) (*db.BookPrice, error) {
+	var price db.BookPrice
+	err := withTx(ctx, r.beginner, r.queries, func(q *db.Queries) error {
+		if _, err := q.GetBookByIDForUpdate(ctx, param.BookID); err != nil {
+			return err
+		}
+
+		var err error
+		price, err = schedulePrice(ctx, q, param)
+		return err
+	})
+	if err != nil {
+		log.Printf("Unable to execute PriceRepositorySchedulePrice: %d\n", err)
+		return nil, err
+	}
+
+	return &price, nil
+}
+
+// schedulePrice は書籍をロックしたトランザクションの中で、SchedulePriceと同じく価格を登録する
+func schedulePrice(ctx context.Context, q *db.Queries, param *SchedulePriceParams) (db.BookPrice, error) {
+	effectiveFrom := pgtype.Timestamptz{Time: param.EffectiveFrom, Valid: true}
+	effectiveTo, err := q.GetNextBookPriceStart(ctx, db.GetNextBookPriceStartParams{
+		BookID:        param.BookID,
+		EffectiveFrom: effectiveFrom,
+	})
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return db.BookPrice{}, err
+	}
+
+	err = q.CloseBookPrice(ctx, db.CloseBookPriceParams{
+		EffectiveFrom: effectiveFrom,
+		BookID:        param.BookID,
+	})
+	if err != nil {
+		return db.BookPrice{}, err
+	}
+
+	return q.CreateBookPrice(ctx, db.CreateBookPriceParams{
+		BookID:        param.BookID,
+		Currency:      param.Currency,
+		Amount:        param.Amount,
+		EffectiveFrom: effectiveFrom,
+		EffectiveTo:   effectiveTo,
+	})
+}
@@ -0,0 +1,210 @@
+package repository_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+var priceColumns = []string{"id", "book_id", "currency", "amount", "effective_from", "effective_to"}
+
+func TestListPrices(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	bookId := 1
+	changedAt := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	expects := []db.BookPrice{
+		{ID: 2, BookID: 1, Currency: "USD", Amount: 1999, EffectiveFrom: pgtype.Timestamptz{Time: changedAt, Valid: true}},
+		{
+			ID:            1,
+			BookID:        1,
+			Currency:      "JPY",
+			Amount:        3080,
+			EffectiveFrom: pgtype.Timestamptz{Time: changedAt.AddDate(0, -3, 0), Valid: true},
+			EffectiveTo:   pgtype.Timestamptz{Time: changedAt, Valid: true},
+		},
+	}
+
+	rows := pgxmock.NewRows(priceColumns)
+	for _, expect := range expects {
+		rows.AddRow(expect.ID, expect.BookID, expect.Currency, expect.Amount, expect.EffectiveFrom, expect.EffectiveTo)
+	}
+	mock.ExpectQuery(`-- name: ListBookPricesByBookID :many`).
+		WithArgs(int32(bookId)).
+		WillReturnRows(rows)
+
+	repo := repository.NewPriceRepository(db.New(mock), mock)
+	prices, err := repo.ListPrices(context.Background(), bookId)
+	assert.NoError(t, err)
+	assert.Equal(t, expects, prices)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestListPricesInEffect(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	at := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	expect := db.BookPrice{
+		ID:            2,
+		BookID:        1,
+		Currency:      "USD",
+		Amount:        1999,
+		EffectiveFrom: pgtype.Timestamptz{Time: at.AddDate(0, -1, 0), Valid: true},
+	}
+
+	mock.ExpectQuery(`-- name: ListBookPricesInEffect :many`).
+		WithArgs([]int32{1, 2}, pgtype.Timestamptz{Time: at, Valid: true}).
+		WillReturnRows(pgxmock.NewRows(priceColumns).
+			AddRow(expect.ID, expect.BookID, expect.Currency, expect.Amount, expect.EffectiveFrom, expect.EffectiveTo))
+
+	repo := repository.NewPriceRepository(db.New(mock), mock)
+	prices, err := repo.ListPricesInEffect(context.Background(), []int32{1, 2}, at)
+	assert.NoError(t, err)
+	assert.Equal(t, []db.BookPrice{expect}, prices)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestSchedulePrice(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	param := repository.SchedulePriceParams{
+		BookID:        1,
+		Currency:      "USD",
+		Amount:        1999,
+		EffectiveFrom: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+	}
+	effectiveFrom := pgtype.Timestamptz{Time: param.EffectiveFrom, Valid: true}
+	nextStart := pgtype.Timestamptz{Time: param.EffectiveFrom.AddDate(0, 1, 0), Valid: true}
+	expect := db.BookPrice{
+		ID:            3,
+		BookID:        param.BookID,
+		Currency:      param.Currency,
+		Amount:        param.Amount,
+		EffectiveFrom: effectiveFrom,
+		EffectiveTo:   nextStart,
+	}
+
+	// 後に予定された価格があれば、その開始日時までを有効期間とする
+	mock.ExpectBegin()
+	mock.ExpectQuery(`-- name: GetBookByIDForUpdate :one`).
+		WithArgs(param.BookID).
+		WillReturnRows(bookRow(db.Book{ID: param.BookID}))
+	mock.ExpectQuery(`-- name: GetNextBookPriceStart :one`).
+		WithArgs(param.BookID, effectiveFrom).
+		WillReturnRows(pgxmock.NewRows([]string{"effective_from"}).AddRow(nextStart))
+	mock.ExpectExec(`-- name: CloseBookPrice :exec`).
+		WithArgs(effectiveFrom, param.BookID).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectQuery(`-- name: CreateBookPrice :one`).
+		WithArgs(param.BookID, param.Currency, param.Amount, effectiveFrom, nextStart).
+		WillReturnRows(pgxmock.NewRows(priceColumns).
+			AddRow(expect.ID, expect.BookID, expect.Currency, expect.Amount, expect.EffectiveFrom, expect.EffectiveTo))
+	mock.ExpectCommit()
+
+	repo := repository.NewPriceRepository(db.New(mock), mock)
+	price, err := repo.SchedulePrice(context.Background(), &param)
+	assert.NoError(t, err)
+	assert.Equal(t, &expect, price)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestSchedulePriceOpenEnded(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	param := repository.SchedulePriceParams{
+		BookID:        1,
+		Currency:      "JPY",
+		Amount:        2800,
+		EffectiveFrom: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+	}
+	effectiveFrom := pgtype.Timestamptz{Time: param.EffectiveFrom, Valid: true}
+
+	// 後に予定された価格がなければ、終了日時を持たない価格として登録する
+	mock.ExpectBegin()
+	mock.ExpectQuery(`-- name: GetBookByIDForUpdate :one`).
+		WithArgs(param.BookID).
+		WillReturnRows(bookRow(db.Book{ID: param.BookID}))
+	mock.ExpectQuery(`-- name: GetNextBookPriceStart :one`).
+		WithArgs(param.BookID, effectiveFrom).
+		WillReturnError(pgx.ErrNoRows)
+	mock.ExpectExec(`-- name: CloseBookPrice :exec`).
+		WithArgs(effectiveFrom, param.BookID).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectQuery(`-- name: CreateBookPrice :one`).
+		WithArgs(param.BookID, param.Currency, param.Amount, effectiveFrom, pgtype.Timestamptz{}).
+		WillReturnRows(pgxmock.NewRows(priceColumns).
+			AddRow(int32(3), param.BookID, param.Currency, param.Amount, effectiveFrom, pgtype.Timestamptz{}))
+	mock.ExpectCommit()
+
+	repo := repository.NewPriceRepository(db.New(mock), mock)
+	price, err := repo.SchedulePrice(context.Background(), &param)
+	assert.NoError(t, err)
+	assert.False(t, price.EffectiveTo.Valid)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestSchedulePriceFailure(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	param := repository.SchedulePriceParams{
+		BookID:        1,
+		Currency:      "JPY",
+		Amount:        2800,
+		EffectiveFrom: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`-- name: GetBookByIDForUpdate :one`).
+		WithArgs(param.BookID).
+		WillReturnError(fmt.Errorf("query error"))
+	mock.ExpectRollback()
+
+	repo := repository.NewPriceRepository(db.New(mock), mock)
+	price, err := repo.SchedulePrice(context.Background(), &param)
+	assert.Error(t, err)
+	assert.Nil(t, price)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+)
+
+type PromotionRepository interface {
+	CreatePromotion(ctx context.Context, param *db.CreatePromotionParams) (*db.Promotion, error)
+	GetPromotionById(ctx context.Context, id int) (*db.Promotion, error)
+	ListPromotions(ctx context.Context) ([]db.Promotion, error)
+	DeletePromotion(ctx context.Context, id int) error
+	ListActivePromotions(ctx context.Context, bookIds []int32, at time.Time) ([]db.ListActivePromotionsByBookIDsRow, error)
+}
+
+type promotionRepositoryImpl struct {
+	queries *db.Queries
+}
+
+func NewPromotionRepository(db *db.Queries) PromotionRepository {
+	return &promotionRepositoryImpl{
+		queries: db,
+	}
+}
+
+func (r *promotionRepositoryImpl) CreatePromotion(ctx context.Context, param *db.CreatePromotionParams) (*db.Promotion, error) {
+	promotion, err := r.queries.CreatePromotion(ctx, *param)
+	if err != nil {
+		log.Printf("Unable to execute PromotionRepositoryCreatePromotion: %d\n", err)
+		return nil, err
+	}
+
+	return &promotion, nil
+}
+
+func (r *promotionRepositoryImpl) GetPromotionById(ctx context.Context, id int) (*db.Promotion, error) {
+	promotion, err := r.queries.GetPromotionByID(ctx, int32(id))
+	if err != nil {
+		log.Printf("Unable to execute PromotionRepositoryGetPromotionById: %d\n", err)
+		return nil, err
+	}
+
+	return &promotion, nil
+}
+
+func (r *promotionRepositoryImpl) ListPromotions(ctx context.Context) ([]db.Promotion, error) {
+	promotions, err := r.queries.ListPromotions(ctx)
+	if err != nil {
+		log.Printf("Unable to execute PromotionRepositoryListPromotions: %d\n", err)
+		return nil, err
+	}
+
+	return promotions, nil
+}
+
+// DeletePromotion は削除対象が存在しない場合にpgx.ErrNoRowsを返す
+func (r *promotionRepositoryImpl) DeletePromotion(ctx context.Context, id int) error {
+	count, err := r.queries.DeletePromotionByID(ctx, int32(id))
+	if err != nil {
+		log.Printf("Unable to execute PromotionRepositoryDeletePromotion: %d\n", err)
+		return err
+	}
+	if count == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+// ListActivePromotions は指定日時に有効なプロモーションを、対象の書籍ごとに優先度の高い順で返す
+func (r *promotionRepositoryImpl) ListActivePromotions(ctx context.Context, bookIds []int32, at time.Time) ([]db.ListActivePromotionsByBookIDsRow, error) {
+	promotions, err := r.queries.ListActivePromotionsByBookIDs(ctx, db.ListActivePromotionsByBookIDsParams{
+		BookIds: bookIds,
+		At:      pgtype.Timestamptz{Time: at, Valid: true},
+	})
+	if err != nil {
+		log.Printf("Unable to execute PromotionRepositoryListActivePromotions: %d\n", err)
+		return nil, err
+	}
+
+	return promotions, nil
+}
@@ -0,0 +1,120 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+var promotionColumns = []string{"id", "name", "discount_type", "discount_value", "currency", "target_type", "target_book_id", "target_name", "starts_at", "ends_at", "priority", "stackable", "created_at"}
+
+func TestCreatePromotion(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	param := db.CreatePromotionParams{
+		Name:          "spring sale",
+		DiscountType:  "percentage",
+		DiscountValue: 10,
+		TargetType:    "publisher",
+		TargetName:    pgtype.Text{String: "test publisher 1", Valid: true},
+		StartsAt:      pgtype.Timestamptz{Time: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+		Priority:      1,
+	}
+	expect := db.Promotion{
+		ID:            1,
+		Name:          param.Name,
+		DiscountType:  param.DiscountType,
+		DiscountValue: param.DiscountValue,
+		TargetType:    param.TargetType,
+		TargetName:    param.TargetName,
+		StartsAt:      param.StartsAt,
+		Priority:      param.Priority,
+		CreatedAt:     pgtype.Timestamptz{Time: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+	}
+
+	rows := pgxmock.NewRows(promotionColumns).
+		AddRow(expect.ID, expect.Name, expect.DiscountType, expect.DiscountValue, expect.Currency, expect.TargetType, expect.TargetBookID, expect.TargetName, expect.StartsAt, expect.EndsAt, expect.Priority, expect.Stackable, expect.CreatedAt)
+	sql := `-- name: CreatePromotion :one`
+	mock.ExpectQuery(sql).
+		WithArgs(param.Name, param.DiscountType, param.DiscountValue, param.Currency, param.TargetType, param.TargetBookID, param.TargetName, param.StartsAt, param.EndsAt, param.Priority, param.Stackable).
+		WillReturnRows(rows)
+
+	repo := repository.NewPromotionRepository(db.New(mock))
+	promotion, err := repo.CreatePromotion(context.Background(), &param)
+	assert.NoError(t, err)
+	assert.Equal(t, &expect, promotion)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestDeletePromotionNotFound(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	sql := `-- name: DeletePromotionByID :execrows`
+	mock.ExpectExec(sql).
+		WithArgs(int32(1)).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	repo := repository.NewPromotionRepository(db.New(mock))
+	err = repo.DeletePromotion(context.Background(), 1)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestListActivePromotions(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	at := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	promotion := db.Promotion{
+		ID:            2,
+		Name:          "book sale",
+		DiscountType:  "fixed_amount",
+		DiscountValue: 100,
+		Currency:      pgtype.Text{String: "JPY", Valid: true},
+		TargetType:    "book",
+		TargetBookID:  pgtype.Int4{Int32: 1, Valid: true},
+		StartsAt:      pgtype.Timestamptz{Time: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+		Stackable:     true,
+		CreatedAt:     pgtype.Timestamptz{Time: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+	}
+
+	rows := pgxmock.NewRows(append([]string{"book_id"}, promotionColumns...)).
+		AddRow(int32(1), promotion.ID, promotion.Name, promotion.DiscountType, promotion.DiscountValue, promotion.Currency, promotion.TargetType, promotion.TargetBookID, promotion.TargetName, promotion.StartsAt, promotion.EndsAt, promotion.Priority, promotion.Stackable, promotion.CreatedAt)
+	sql := `-- name: ListActivePromotionsByBookIDs :many`
+	mock.ExpectQuery(sql).
+		WithArgs([]int32{1, 2}, pgtype.Timestamptz{Time: at, Valid: true}).
+		WillReturnRows(rows)
+
+	repo := repository.NewPromotionRepository(db.New(mock))
+	promotions, err := repo.ListActivePromotions(context.Background(), []int32{1, 2}, at)
+	assert.NoError(t, err)
+	assert.Equal(t, []db.ListActivePromotionsByBookIDsRow{{BookID: 1, Promotion: promotion}}, promotions)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+)
+
+var ErrCopyAvailable = errors.New("copy available")
+
+type ReserveParams struct {
+	BookID     int32
+	MemberID   int32
+	Now        time.Time
+	HoldPeriod time.Duration
+}
+
+type ReservationRepository interface {
+	Reserve(ctx context.Context, param *ReserveParams) (*db.Reservation, int, error)
+	ListReservations(ctx context.Context, bookId int, now time.Time, holdPeriod time.Duration) ([]db.Reservation, error)
+}
+
+type reservationRepositoryImpl struct {
+	queries  *db.Queries
+	beginner TxBeginner
+}
+
+func NewReservationRepository(db *db.Queries, beginner TxBeginner) ReservationRepository {
+	return &reservationRepositoryImpl{
+		queries:  db,
+		beginner: beginner,
+	}
+}
+
+// Reserve は全ての冊数が貸出中または取置中の場合に限り、待ち行列の末尾に予約を追加する
+// 戻り値の整数は待ち行列内の順番（1始まり）
+func (r *reservationRepositoryImpl) Reserve(ctx context.Context, param *ReserveParams) (*db.Reservation, int, error) {
+	var reservation db.Reservation
+	var position int32
+	err := withTx(ctx, r.beginner, r.queries, func(q *db.Queries) error {
+		if _, err := q.GetBookByIDForUpdate(ctx, param.BookID); err != nil {
+			return err
+		}
+		if _, err := q.GetMemberByID(ctx, param.MemberID); err != nil {
+			return err
+		}
+		if err := advanceReservationQueue(ctx, q, param.BookID, param.Now, param.HoldPeriod); err != nil {
+			return err
+		}
+
+		availability, err := q.GetBookAvailability(ctx, param.BookID)
+		if err != nil {
+			return err
+		}
+		if availability.Total-availability.OnLoan-availability.OnHold > 0 {
+			return ErrCopyAvailable
+		}
+
+		reservation, err = q.CreateReservation(ctx, db.CreateReservationParams{
+			BookID:    param.BookID,
+			MemberID:  param.MemberID,
+			CreatedAt: pgtype.Timestamptz{Time: param.Now, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		position, err = q.GetReservationPosition(ctx, db.GetReservationPositionParams{
+			BookID: param.BookID,
+			ID:     reservation.ID,
+		})
+		return err
+	})
+	if err != nil {
+		log.Printf("Unable to execute ReservationRepositoryReserve: %d\n", err)
+		return nil, 0, err
+	}
+
+	return &reservation, int(position), nil
+}
+
+// ListReservations は期限切れの取置を処理して待ち行列を進めた上で、有効な予約を取置中・予約順に返す
+func (r *reservationRepositoryImpl) ListReservations(ctx context.Context, bookId int, now time.Time, holdPeriod time.Duration) ([]db.Reservation, error) {
+	var reservations []db.Reservation
+	err := withTx(ctx, r.beginner, r.queries, func(q *db.Queries) error {
+		if _, err := q.GetBookByIDForUpdate(ctx, int32(bookId)); err != nil {
+			return err
+		}
+		if err := advanceReservationQueue(ctx, q, int32(bookId), now, holdPeriod); err != nil {
+			return err
+		}
+
+		var err error
+		reservations, err = q.ListActiveReservationsByBookID(ctx, int32(bookId))
+		return err
+	})
+	if err != nil {
+		log.Printf("Unable to execute ReservationRepositoryListReservations: %d\n", err)
+		return nil, err
+	}
+
+	return reservations, nil
+}
+
+// advanceReservationQueue は期限切れの取置を失効させ、空いた冊数の分だけ先頭の予約を取置に進める
+// 呼び出し側は同じトランザクション内で書籍の行をロックしておくこと
+func advanceReservationQueue(ctx context.Context, q *db.Queries, bookID int32, now time.Time, holdPeriod time.Duration) error {
+	_, err := q.ExpireHolds(ctx, db.ExpireHoldsParams{
+		BookID:        bookID,
+		HoldExpiresAt: pgtype.Timestamptz{Time: now, Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		availability, err := q.GetBookAvailability(ctx, bookID)
+		if err != nil {
+			return err
+		}
+		if availability.Total-availability.OnLoan-availability.OnHold <= 0 {
+			return nil
+		}
+
+		next, err := q.GetNextWaitingReservation(ctx, bookID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		_, err = q.HoldReservation(ctx, db.HoldReservationParams{
+			ID:            next.ID,
+			HeldAt:        pgtype.Timestamptz{Time: now, Valid: true},
+			HoldExpiresAt: pgtype.Timestamptz{Time: now.Add(holdPeriod), Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,176 @@
+//go:build integration
+
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// マイグレーション済みのデータベースに対して実行する
+// go test -tags integration ./repository/...
+
+func newIntegrationPool(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+	dbURL := os.Getenv("DATABASE_URL")
+	if dbURL == "" {
+		t.Skip("DATABASE_URL not set")
+	}
+	pool, err := pgxpool.New(context.Background(), dbURL)
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+
+	return pool
+}
+
+// seedBookWithMembers は1冊だけ在庫のある書籍と、指定人数の会員を登録する
+func seedBookWithMembers(t *testing.T, pool *pgxpool.Pool, members int) (int32, []int32) {
+	t.Helper()
+	ctx := context.Background()
+	q := db.New(pool)
+
+	book, err := q.CreateBook(ctx, db.CreateBookParams{})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_, _ = pool.Exec(ctx, "DELETE FROM books WHERE id = $1", book.ID)
+	})
+	_, err = pool.Exec(ctx, "INSERT INTO inventories (book_id, location, quantity) VALUES ($1, 'main', 1)", book.ID)
+	require.NoError(t, err)
+
+	ids := make([]int32, members)
+	for i := range ids {
+		member, err := q.CreateMember(ctx, db.CreateMemberParams{
+			Name:  "race member",
+			Email: fmt.Sprintf("race-%d-%d-%d@example.com", book.ID, i, time.Now().UnixNano()),
+		})
+		require.NoError(t, err)
+		ids[i] = member.ID
+		t.Cleanup(func() {
+			_, _ = pool.Exec(ctx, "DELETE FROM members WHERE id = $1", member.ID)
+		})
+	}
+
+	return book.ID, ids
+}
+
+func TestIntegrationCheckoutLastCopyRace(t *testing.T) {
+	pool := newIntegrationPool(t)
+	bookID, memberIDs := seedBookWithMembers(t, pool, 8)
+	repo := repository.NewLoanRepository(db.New(pool), pool)
+
+	// 最後の1冊を同時に借りようとしても、貸出は1件だけ成立する
+	now := time.Now()
+	var wg sync.WaitGroup
+	errs := make([]error, len(memberIDs))
+	for i, memberID := range memberIDs {
+		wg.Add(1)
+		go func(i int, memberID int32) {
+			defer wg.Done()
+			_, errs[i] = repo.Checkout(context.Background(), &repository.CheckoutParams{
+				BookID:     bookID,
+				MemberID:   memberID,
+				LoanedAt:   now,
+				DueAt:      now.AddDate(0, 0, 14),
+				MaxLoans:   5,
+				HoldPeriod: 72 * time.Hour,
+			})
+		}(i, memberID)
+	}
+	wg.Wait()
+
+	succeeded := 0
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		assert.ErrorIs(t, err, repository.ErrNoCopyAvailable)
+	}
+	assert.Equal(t, 1, succeeded)
+}
+
+func TestIntegrationReserveQueueRace(t *testing.T) {
+	pool := newIntegrationPool(t)
+	bookID, memberIDs := seedBookWithMembers(t, pool, 9)
+	loanRepo := repository.NewLoanRepository(db.New(pool), pool)
+	repo := repository.NewReservationRepository(db.New(pool), pool)
+
+	now := time.Now()
+	loan, err := loanRepo.Checkout(context.Background(), &repository.CheckoutParams{
+		BookID:     bookID,
+		MemberID:   memberIDs[0],
+		LoanedAt:   now,
+		DueAt:      now.AddDate(0, 0, 14),
+		MaxLoans:   5,
+		HoldPeriod: 72 * time.Hour,
+	})
+	require.NoError(t, err)
+
+	// 同時に予約しても、待ち行列の順番は重複も欠番もなく割り当てられる
+	waiting := memberIDs[1:]
+	var wg sync.WaitGroup
+	positions := make([]int, len(waiting))
+	for i, memberID := range waiting {
+		wg.Add(1)
+		go func(i int, memberID int32) {
+			defer wg.Done()
+			_, position, err := repo.Reserve(context.Background(), &repository.ReserveParams{
+				BookID:     bookID,
+				MemberID:   memberID,
+				Now:        now,
+				HoldPeriod: 72 * time.Hour,
+			})
+			assert.NoError(t, err)
+			positions[i] = position
+		}(i, memberID)
+	}
+	wg.Wait()
+	assert.ElementsMatch(t, []int{1, 2, 3, 4, 5, 6, 7, 8}, positions)
+
+	// 返却と同時に予約一覧を取得しても、取置は先頭の1件だけに割り当てられる
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_, err := loanRepo.ReturnLoan(context.Background(), int(loan.ID), now, 72*time.Hour)
+		assert.NoError(t, err)
+	}()
+	go func() {
+		defer wg.Done()
+		_, err := repo.ListReservations(context.Background(), int(bookID), now, 72*time.Hour)
+		assert.NoError(t, err)
+	}()
+	wg.Wait()
+
+	reservations, err := repo.ListReservations(context.Background(), int(bookID), now, 72*time.Hour)
+	require.NoError(t, err)
+	held := 0
+	for _, reservation := range reservations {
+		if reservation.Status == "held" {
+			held++
+		}
+	}
+	assert.Equal(t, 1, held)
+
+	// 同じ会員による重複予約は一意制約で拒否される
+	_, _, err = repo.Reserve(context.Background(), &repository.ReserveParams{
+		BookID:     bookID,
+		MemberID:   waiting[len(waiting)-1],
+		Now:        now,
+		HoldPeriod: 72 * time.Hour,
+	})
+	var pgErr *pgconn.PgError
+	assert.True(t, errors.As(err, &pgErr))
+	assert.Equal(t, "23505", pgErr.Code)
+}
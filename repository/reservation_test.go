@@ -0,0 +1,170 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func expectReserveLocks(mock pgxmock.PgxPoolIface, param *repository.ReserveParams) {
+	mock.ExpectQuery(`-- name: GetBookByIDForUpdate :one`).
+		WithArgs(param.BookID).
+		WillReturnRows(bookRow(db.Book{ID: param.BookID}))
+	mock.ExpectQuery(`-- name: GetMemberByID :one`).
+		WithArgs(param.MemberID).
+		WillReturnRows(pgxmock.NewRows(memberColumns).AddRow(param.MemberID, "test member 1", "member1@example.com", pgtype.Timestamptz{}))
+	mock.ExpectExec(`-- name: ExpireHolds :execrows`).
+		WithArgs(param.BookID, pgtype.Timestamptz{Time: param.Now, Valid: true}).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+}
+
+func TestReserve(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	param := repository.ReserveParams{
+		BookID:     1,
+		MemberID:   2,
+		Now:        time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+		HoldPeriod: 72 * time.Hour,
+	}
+	expect := db.Reservation{
+		ID:        3,
+		BookID:    1,
+		MemberID:  2,
+		Status:    "waiting",
+		CreatedAt: pgtype.Timestamptz{Time: param.Now, Valid: true},
+	}
+
+	mock.ExpectBegin()
+	expectReserveLocks(mock, &param)
+	mock.ExpectQuery(`-- name: GetBookAvailability :one`).
+		WithArgs(param.BookID).
+		WillReturnRows(pgxmock.NewRows(availabilityColumns).AddRow(int32(2), int32(2), int32(0)))
+	mock.ExpectQuery(`-- name: GetBookAvailability :one`).
+		WithArgs(param.BookID).
+		WillReturnRows(pgxmock.NewRows(availabilityColumns).AddRow(int32(2), int32(2), int32(0)))
+	mock.ExpectQuery(`-- name: CreateReservation :one`).
+		WithArgs(param.BookID, param.MemberID, expect.CreatedAt).
+		WillReturnRows(pgxmock.NewRows(reservationColumns).
+			AddRow(expect.ID, expect.BookID, expect.MemberID, expect.Status, expect.CreatedAt, expect.HeldAt, expect.HoldExpiresAt))
+	mock.ExpectQuery(`-- name: GetReservationPosition :one`).
+		WithArgs(param.BookID, expect.ID).
+		WillReturnRows(pgxmock.NewRows([]string{"position"}).AddRow(int32(2)))
+	mock.ExpectCommit()
+
+	repo := repository.NewReservationRepository(db.New(mock), mock)
+	reservation, position, err := repo.Reserve(context.Background(), &param)
+	assert.NoError(t, err)
+	assert.Equal(t, &expect, reservation)
+	assert.Equal(t, 2, position)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestReserveFailureCopyAvailable(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	param := repository.ReserveParams{
+		BookID:     1,
+		MemberID:   2,
+		Now:        time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+		HoldPeriod: 72 * time.Hour,
+	}
+
+	mock.ExpectBegin()
+	expectReserveLocks(mock, &param)
+	mock.ExpectQuery(`-- name: GetBookAvailability :one`).
+		WithArgs(param.BookID).
+		WillReturnRows(pgxmock.NewRows(availabilityColumns).AddRow(int32(2), int32(1), int32(0)))
+	mock.ExpectQuery(`-- name: GetNextWaitingReservation :one`).
+		WithArgs(param.BookID).
+		WillReturnError(pgx.ErrNoRows)
+	mock.ExpectQuery(`-- name: GetBookAvailability :one`).
+		WithArgs(param.BookID).
+		WillReturnRows(pgxmock.NewRows(availabilityColumns).AddRow(int32(2), int32(1), int32(0)))
+	mock.ExpectRollback()
+
+	repo := repository.NewReservationRepository(db.New(mock), mock)
+	reservation, _, err := repo.Reserve(context.Background(), &param)
+	assert.ErrorIs(t, err, repository.ErrCopyAvailable)
+	assert.Nil(t, reservation)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestListReservationsExpiresHold(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	bookId := 1
+	now := time.Date(2024, 1, 5, 10, 0, 0, 0, time.UTC)
+	holdPeriod := 72 * time.Hour
+	held := db.Reservation{
+		ID:            4,
+		BookID:        1,
+		MemberID:      3,
+		Status:        "held",
+		CreatedAt:     pgtype.Timestamptz{Time: now.AddDate(0, 0, -3), Valid: true},
+		HeldAt:        pgtype.Timestamptz{Time: now, Valid: true},
+		HoldExpiresAt: pgtype.Timestamptz{Time: now.Add(holdPeriod), Valid: true},
+	}
+
+	// 期限切れの取置が失効し、次の予約者に取置が移る
+	mock.ExpectBegin()
+	mock.ExpectQuery(`-- name: GetBookByIDForUpdate :one`).
+		WithArgs(int32(bookId)).
+		WillReturnRows(bookRow(db.Book{ID: int32(bookId)}))
+	mock.ExpectExec(`-- name: ExpireHolds :execrows`).
+		WithArgs(int32(bookId), pgtype.Timestamptz{Time: now, Valid: true}).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectQuery(`-- name: GetBookAvailability :one`).
+		WithArgs(int32(bookId)).
+		WillReturnRows(pgxmock.NewRows(availabilityColumns).AddRow(int32(1), int32(0), int32(0)))
+	mock.ExpectQuery(`-- name: GetNextWaitingReservation :one`).
+		WithArgs(int32(bookId)).
+		WillReturnRows(pgxmock.NewRows(reservationColumns).
+			AddRow(held.ID, held.BookID, held.MemberID, "waiting", held.CreatedAt, pgtype.Timestamptz{}, pgtype.Timestamptz{}))
+	mock.ExpectQuery(`-- name: HoldReservation :one`).
+		WithArgs(held.ID, held.HeldAt, held.HoldExpiresAt).
+		WillReturnRows(pgxmock.NewRows(reservationColumns).
+			AddRow(held.ID, held.BookID, held.MemberID, held.Status, held.CreatedAt, held.HeldAt, held.HoldExpiresAt))
+	mock.ExpectQuery(`-- name: GetBookAvailability :one`).
+		WithArgs(int32(bookId)).
+		WillReturnRows(pgxmock.NewRows(availabilityColumns).AddRow(int32(1), int32(0), int32(1)))
+	mock.ExpectQuery(`-- name: ListActiveReservationsByBookID :many`).
+		WithArgs(int32(bookId)).
+		WillReturnRows(pgxmock.NewRows(reservationColumns).
+			AddRow(held.ID, held.BookID, held.MemberID, held.Status, held.CreatedAt, held.HeldAt, held.HoldExpiresAt))
+	mock.ExpectCommit()
+
+	repo := repository.NewReservationRepository(db.New(mock), mock)
+	reservations, err := repo.ListReservations(context.Background(), bookId, now, holdPeriod)
+	assert.NoError(t, err)
+	assert.Equal(t, []db.Reservation{held}, reservations)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"log"
+
+	"github.com/rentaro-m-b/ai-model-exam/db"
+)
+
+type ReviewRepository interface {
+	CreateReview(ctx context.Context, param *db.CreateReviewParams) (*db.Review, error)
+	UpdateReview(ctx context.Context, param *db.UpdateReviewParams) (*db.Review, error)
+	DeleteReview(ctx context.Context, id int) error
+	ListBookReviews(ctx context.Context, bookId int) ([]db.Review, error)
+}
+
+type reviewRepositoryImpl struct {
+	queries  *db.Queries
+	beginner TxBeginner
+}
+
+func NewReviewRepository(db *db.Queries, beginner TxBeginner) ReviewRepository {
+	return &reviewRepositoryImpl{
+		queries:  db,
+		beginner: beginner,
+	}
+}
+
+// CreateReview はレビューを登録し、同じトランザクションで書籍の評価件数と評価の合計に加算する
+// 書籍または会員が存在しない場合はpgx.ErrNoRowsを返す
+func (r *reviewRepositoryImpl) CreateReview(ctx context.Context, param *db.CreateReviewParams) (*db.Review, error) {
+	var review db.Review
+	err := withTx(ctx, r.beginner, r.queries, func(q *db.Queries) error {
+		if _, err := q.GetBookByID(ctx, param.BookID); err != nil {
+			return err
+		}
+		if _, err := q.GetMemberByID(ctx, param.MemberID); err != nil {
+			return err
+		}
+
+		var err error
+		review, err = q.CreateReview(ctx, *param)
+		if err != nil {
+			return err
+		}
+
+		return q.AdjustBookRating(ctx, db.AdjustBookRatingParams{
+			BookID:     review.BookID,
+			CountDelta: 1,
+			SumDelta:   int32(review.Rating),
+		})
+	})
+	if err != nil {
+		log.Printf("Unable to execute ReviewRepositoryCreateReview: %d\n", err)
+		return nil, err
+	}
+
+	return &review, nil
+}
+
+// UpdateReview はレビューを更新し、評価が変わった場合は変更前との差分を書籍の評価の合計に反映する
+func (r *reviewRepositoryImpl) UpdateReview(ctx context.Context, param *db.UpdateReviewParams) (*db.Review, error) {
+	var review db.Review
+	err := withTx(ctx, r.beginner, r.queries, func(q *db.Queries) error {
+		current, err := q.GetReviewByIDForUpdate(ctx, param.ID)
+		if err != nil {
+			return err
+		}
+
+		review, err = q.UpdateReview(ctx, *param)
+		if err != nil {
+			return err
+		}
+		if review.Rating == current.Rating {
+			return nil
+		}
+
+		return q.AdjustBookRating(ctx, db.AdjustBookRatingParams{
+			BookID:     review.BookID,
+			CountDelta: 0,
+			SumDelta:   int32(review.Rating - current.Rating),
+		})
+	})
+	if err != nil {
+		log.Printf("Unable to execute ReviewRepositoryUpdateReview: %d\n", err)
+		return nil, err
+	}
+
+	return &review, nil
+}
+
+// DeleteReview はレビューを削除し、同じトランザクションで書籍の評価件数と評価の合計から差し引く
+// 削除対象が存在しない場合はpgx.ErrNoRowsを返す
+func (r *reviewRepositoryImpl) DeleteReview(ctx context.Context, id int) error {
+	err := withTx(ctx, r.beginner, r.queries, func(q *db.Queries) error {
+		review, err := q.DeleteReviewByID(ctx, int32(id))
+		if err != nil {
+			return err
+		}
+
+		return q.AdjustBookRating(ctx, db.AdjustBookRatingParams{
+			BookID:     review.BookID,
+			CountDelta: -1,
+			SumDelta:   -int32(review.Rating),
+		})
+	})
+	if err != nil {
+		log.Printf("Unable to execute ReviewRepositoryDeleteReview: %d\n", err)
+		return err
+	}
+
+	return nil
+}
+
+// ListBookReviews は書籍のレビューを新しい順に返す
+func (r *reviewRepositoryImpl) ListBookReviews(ctx context.Context, bookId int) ([]db.Review, error) {
+	reviews, err := r.queries.ListReviewsByBookID(ctx, int32(bookId))
+	if err != nil {
+		log.Printf("Unable to execute ReviewRepositoryListBookReviews: %d\n", err)
+		return nil, err
+	}
+
+	return reviews, nil
+}
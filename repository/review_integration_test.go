@@ -0,0 +1,74 @@
+//go:build integration
+
+package repository_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// 同じ書籍へのレビューの投稿・編集・削除が同時に行われても、評価の集計がレビューの実数と一致することを確認する
+func TestIntegrationBookRatingAggregates(t *testing.T) {
+	pool := newIntegrationPool(t)
+	ctx := context.Background()
+	bookId, memberIds := seedBookWithMembers(t, pool, 8)
+	queries := db.New(pool)
+	repo := repository.NewReviewRepository(queries, pool)
+	bookRepo := repository.NewBookRepository(queries, pool)
+
+	reviews := make([]*db.Review, len(memberIds))
+	var wg sync.WaitGroup
+	for i, memberId := range memberIds {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			review, err := repo.CreateReview(ctx, &db.CreateReviewParams{
+				BookID:   bookId,
+				MemberID: memberId,
+				Rating:   int16(i%5 + 1),
+			})
+			assert.NoError(t, err)
+			reviews[i] = review
+		}()
+	}
+	wg.Wait()
+
+	// 同じ会員による2件目のレビューは一意制約により登録できない
+	_, err := repo.CreateReview(ctx, &db.CreateReviewParams{BookID: bookId, MemberID: memberIds[0], Rating: 5})
+	assert.Error(t, err)
+
+	for i, review := range reviews[:4] {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if i%2 == 0 {
+				assert.NoError(t, repo.DeleteReview(ctx, int(review.ID)))
+				return
+			}
+			_, err := repo.UpdateReview(ctx, &db.UpdateReviewParams{
+				ID:     review.ID,
+				Rating: pgtype.Int2{Int16: 5, Valid: true},
+			})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	var count, sum int32
+	require.NoError(t, pool.QueryRow(ctx,
+		"SELECT count(*)::integer, coalesce(sum(rating), 0)::integer FROM reviews WHERE book_id = $1", bookId,
+	).Scan(&count, &sum))
+
+	ratings, err := bookRepo.ListBookRatings(ctx, []int32{bookId})
+	require.NoError(t, err)
+	require.Len(t, ratings, 1)
+	assert.Equal(t, count, ratings[0].ReviewCount)
+	assert.Equal(t, sum, ratings[0].RatingSum)
+}
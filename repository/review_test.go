@@ -0,0 +1,168 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+var reviewColumns = []string{"id", "book_id", "member_id", "rating", "body", "created_at", "updated_at"}
+
+func reviewRow(review db.Review) *pgxmock.Rows {
+	return pgxmock.NewRows(reviewColumns).
+		AddRow(review.ID, review.BookID, review.MemberID, review.Rating, review.Body, review.CreatedAt, review.UpdatedAt)
+}
+
+func TestCreateReview(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	param := db.CreateReviewParams{BookID: 1, MemberID: 2, Rating: 4, Body: "good"}
+	expect := db.Review{ID: 10, BookID: 1, MemberID: 2, Rating: 4, Body: "good"}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`-- name: GetBookByID :one`).
+		WithArgs(param.BookID).
+		WillReturnRows(bookRow(db.Book{ID: param.BookID}))
+	mock.ExpectQuery(`-- name: GetMemberByID :one`).
+		WithArgs(param.MemberID).
+		WillReturnRows(pgxmock.NewRows(memberColumns).AddRow(param.MemberID, "test member 1", "member1@example.com", pgtype.Timestamptz{}))
+	mock.ExpectQuery(`-- name: CreateReview :one`).
+		WithArgs(param.BookID, param.MemberID, param.Rating, param.Body).
+		WillReturnRows(reviewRow(expect))
+	// 評価件数に1を、評価の合計に評価を加算する
+	mock.ExpectExec(`-- name: AdjustBookRating :exec`).
+		WithArgs(int32(1), int32(1), int32(4)).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectCommit()
+
+	repo := repository.NewReviewRepository(db.New(mock), mock)
+	review, err := repo.CreateReview(context.Background(), &param)
+	assert.NoError(t, err)
+	assert.Equal(t, &expect, review)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestUpdateReviewAdjustsRatingSum(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	param := db.UpdateReviewParams{ID: 10, Rating: pgtype.Int2{Int16: 2, Valid: true}}
+	current := db.Review{ID: 10, BookID: 1, MemberID: 2, Rating: 5, Body: "good"}
+	expect := db.Review{ID: 10, BookID: 1, MemberID: 2, Rating: 2, Body: "good"}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`-- name: GetReviewByIDForUpdate :one`).
+		WithArgs(param.ID).
+		WillReturnRows(reviewRow(current))
+	mock.ExpectQuery(`-- name: UpdateReview :one`).
+		WithArgs(param.Rating, param.Body, param.ID).
+		WillReturnRows(reviewRow(expect))
+	// 評価件数は変えず、変更前との差分を評価の合計に反映する
+	mock.ExpectExec(`-- name: AdjustBookRating :exec`).
+		WithArgs(int32(1), int32(0), int32(-3)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectCommit()
+
+	repo := repository.NewReviewRepository(db.New(mock), mock)
+	review, err := repo.UpdateReview(context.Background(), &param)
+	assert.NoError(t, err)
+	assert.Equal(t, &expect, review)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestUpdateReviewBodyOnly(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	param := db.UpdateReviewParams{ID: 10, Body: pgtype.Text{String: "great", Valid: true}}
+	current := db.Review{ID: 10, BookID: 1, MemberID: 2, Rating: 5, Body: "good"}
+	expect := db.Review{ID: 10, BookID: 1, MemberID: 2, Rating: 5, Body: "great"}
+
+	// 評価が変わらない場合は集計を更新しない
+	mock.ExpectBegin()
+	mock.ExpectQuery(`-- name: GetReviewByIDForUpdate :one`).
+		WithArgs(param.ID).
+		WillReturnRows(reviewRow(current))
+	mock.ExpectQuery(`-- name: UpdateReview :one`).
+		WithArgs(param.Rating, param.Body, param.ID).
+		WillReturnRows(reviewRow(expect))
+	mock.ExpectCommit()
+
+	repo := repository.NewReviewRepository(db.New(mock), mock)
+	review, err := repo.UpdateReview(context.Background(), &param)
+	assert.NoError(t, err)
+	assert.Equal(t, &expect, review)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestDeleteReview(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`-- name: DeleteReviewByID :one`).
+		WithArgs(int32(10)).
+		WillReturnRows(reviewRow(db.Review{ID: 10, BookID: 1, MemberID: 2, Rating: 3}))
+	mock.ExpectExec(`-- name: AdjustBookRating :exec`).
+		WithArgs(int32(1), int32(-1), int32(-3)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectCommit()
+
+	repo := repository.NewReviewRepository(db.New(mock), mock)
+	err = repo.DeleteReview(context.Background(), 10)
+	assert.NoError(t, err)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestDeleteReviewNotFound(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`-- name: DeleteReviewByID :one`).
+		WithArgs(int32(99)).
+		WillReturnError(pgx.ErrNoRows)
+	mock.ExpectRollback()
+
+	repo := repository.NewReviewRepository(db.New(mock), mock)
+	err = repo.DeleteReview(context.Background(), 99)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
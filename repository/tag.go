@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/rentaro-m-b/ai-model-exam/db"
+)
+
+var ErrTagNotFound = errors.New("tag not found")
+
+type TagRepository interface {
+	AddBookTag(ctx context.Context, bookId int, name string) (*db.Tag, error)
+	RemoveBookTag(ctx context.Context, bookId int, name string) error
+	ListBookTags(ctx context.Context, bookId int) ([]db.Tag, error)
+	SearchTags(ctx context.Context, prefix string, limit int) ([]db.SearchTagsByPrefixRow, error)
+}
+
+type tagRepositoryImpl struct {
+	queries  *db.Queries
+	beginner TxBeginner
+}
+
+func NewTagRepository(db *db.Queries, beginner TxBeginner) TagRepository {
+	return &tagRepositoryImpl{
+		queries:  db,
+		beginner: beginner,
+	}
+}
+
+// AddBookTag は書籍にタグを付ける
+// タグが未登録であれば登録し、既に付いているタグであれば何もしない
+func (r *tagRepositoryImpl) AddBookTag(ctx context.Context, bookId int, name string) (*db.Tag, error) {
+	var tag db.Tag
+	err := withTx(ctx, r.beginner, r.queries, func(q *db.Queries) error {
+		if _, err := q.GetBookByIDForUpdate(ctx, int32(bookId)); err != nil {
+			return err
+		}
+
+		var err error
+		tag, err = q.UpsertTag(ctx, name)
+		if err != nil {
+			return err
+		}
+
+		return q.CreateBookTag(ctx, db.CreateBookTagParams{
+			BookID: int32(bookId),
+			TagID:  tag.ID,
+		})
+	})
+	if err != nil {
+		log.Printf("Unable to execute TagRepositoryAddBookTag: %d\n", err)
+		return nil, err
+	}
+
+	return &tag, nil
+}
+
+// RemoveBookTag は書籍にそのタグが付いていない場合にErrTagNotFoundを返す
+func (r *tagRepositoryImpl) RemoveBookTag(ctx context.Context, bookId int, name string) error {
+	count, err := r.queries.DeleteBookTagByName(ctx, db.DeleteBookTagByNameParams{
+		BookID: int32(bookId),
+		Name:   name,
+	})
+	if err != nil {
+		log.Printf("Unable to execute TagRepositoryRemoveBookTag: %d\n", err)
+		return err
+	}
+	if count == 0 {
+		return ErrTagNotFound
+	}
+
+	return nil
+}
+
+func (r *tagRepositoryImpl) ListBookTags(ctx context.Context, bookId int) ([]db.Tag, error) {
+	tags, err := r.queries.ListTagsByBookID(ctx, int32(bookId))
+	if err != nil {
+		log.Printf("Unable to execute TagRepositoryListBookTags: %d\n", err)
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// SearchTags は名前が前方一致するタグを、付けられている書籍の多い順に返す
+func (r *tagRepositoryImpl) SearchTags(ctx context.Context, prefix string, limit int) ([]db.SearchTagsByPrefixRow, error) {
+	tags, err := r.queries.SearchTagsByPrefix(ctx, db.SearchTagsByPrefixParams{
+		Pattern: escapeLikePattern(prefix) + "%",
+		Limit:   int32(limit),
+	})
+	if err != nil {
+		log.Printf("Unable to execute TagRepositorySearchTags: %d\n", err)
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+var likePatternReplacer = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// escapeLikePattern はLIKE演算子で特別な意味を持つ文字をエスケープする
+func escapeLikePattern(s string) string {
+	return likePatternReplacer.Replace(s)
+}
@@ -0,0 +1,121 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+var tagColumns = []string{"id", "name", "created_at"}
+
+func TestAddBookTag(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	expect := db.Tag{ID: 3, Name: "golang"}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`-- name: GetBookByIDForUpdate :one`).
+		WithArgs(int32(1)).
+		WillReturnRows(bookRow(db.Book{ID: int32(1)}))
+	mock.ExpectQuery(`-- name: UpsertTag :one`).
+		WithArgs("golang").
+		WillReturnRows(pgxmock.NewRows(tagColumns).AddRow(expect.ID, expect.Name, expect.CreatedAt))
+	mock.ExpectExec(`-- name: CreateBookTag :exec`).
+		WithArgs(int32(1), expect.ID).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectCommit()
+
+	repo := repository.NewTagRepository(db.New(mock), mock)
+	tag, err := repo.AddBookTag(context.Background(), 1, "golang")
+	assert.NoError(t, err)
+	assert.Equal(t, &expect, tag)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestRemoveBookTagNotFound(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	mock.ExpectExec(`-- name: DeleteBookTagByName :execrows`).
+		WithArgs(int32(1), "golang").
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	repo := repository.NewTagRepository(db.New(mock), mock)
+	err = repo.RemoveBookTag(context.Background(), 1, "golang")
+	assert.ErrorIs(t, err, repository.ErrTagNotFound)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestSearchTagsEscapesPrefix(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	expects := []db.SearchTagsByPrefixRow{{ID: 1, Name: "50%_off", UsageCount: 2}}
+
+	// 前方一致の検索でワイルドカードとして扱われないよう、%と_はエスケープする
+	mock.ExpectQuery(`-- name: SearchTagsByPrefix :many`).
+		WithArgs(`50\%\_%`, int32(10)).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "name", "usage_count"}).AddRow(int32(1), "50%_off", int64(2)))
+
+	repo := repository.NewTagRepository(db.New(mock), mock)
+	tags, err := repo.SearchTags(context.Background(), "50%_", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, expects, tags)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestFilterBooks(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	param := db.FilterBooksParams{
+		CategoryID:   pgtype.Int4{Int32: 1, Valid: true},
+		Tags:         []string{"golang", "database"},
+		MatchAllTags: true,
+		InStock:      pgtype.Bool{Bool: true, Valid: true},
+	}
+	expects := []db.Book{
+		{ID: 1, Title: pgtype.Text{String: "test title 1", Valid: true}},
+	}
+
+	rows := bookRow(expects...)
+	mock.ExpectQuery(`-- name: FilterBooks :many`).
+		WithArgs(param.CategoryID, param.Tags, param.MatchAllTags, param.InStock).
+		WillReturnRows(rows)
+
+	repo := repository.NewBookRepository(db.New(mock), mock)
+	books, err := repo.FilterBooks(context.Background(), &param)
+	assert.NoError(t, err)
+	assert.Equal(t, expects, books)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+)
+
+// TxBeginner はトランザクションを開始できるDB接続（pgxpool.Pool など）を表す
+type TxBeginner interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// withTx はfnをトランザクション内で実行し、エラーがなければコミットする
+func withTx(ctx context.Context, beginner TxBeginner, queries *db.Queries, fn func(q *db.Queries) error) error {
+	tx, err := beginner.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(queries.WithTx(tx)); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,226 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/event"
+	"github.com/rentaro-m-b/ai-model-exam/webhook"
+)
+
+var ErrWebhookNotFound = errors.New("webhook not found")
+
+// Webhookの配信の状態
+const (
+	WebhookDeliveryStatusPending   = "pending"
+	WebhookDeliveryStatusSucceeded = "succeeded"
+	WebhookDeliveryStatusDead      = "dead"
+)
+
+// WebhookRepository はWebhookの購読の管理に加え、webhook.Enqueuerとwebhook.Storeを実装する
+type WebhookRepository interface {
+	CreateWebhook(ctx context.Context, param *db.CreateWebhookSubscriptionParams) (*db.WebhookSubscription, error)
+	ListWebhooks(ctx context.Context) ([]db.WebhookSubscription, error)
+	GetWebhookById(ctx context.Context, id int) (*db.WebhookSubscription, error)
+	UpdateWebhook(ctx context.Context, param *db.UpdateWebhookSubscriptionParams) (*db.WebhookSubscription, error)
+	DeleteWebhook(ctx context.Context, id int) error
+	ListDeliveries(ctx context.Context, param *db.ListWebhookDeliveriesParams) ([]db.WebhookDelivery, error)
+	ListDeadDeliveries(ctx context.Context, limit int) ([]db.WebhookDelivery, error)
+	RequeueDelivery(ctx context.Context, id int, deliveryId int64) (*db.WebhookDelivery, error)
+	EnqueueDeliveries(ctx context.Context, eventId int64, eventType event.Type, body []byte) error
+	ClaimDeliveries(ctx context.Context, limit int, leaseUntil time.Time) ([]webhook.Delivery, error)
+	ReleaseDeliveries(ctx context.Context, ids []int64, leaseUntil time.Time) error
+	MarkSucceeded(ctx context.Context, id int64, statusCode int) error
+	MarkFailed(ctx context.Context, id int64, dead bool, retryAt time.Time, statusCode int, cause string) error
+}
+
+type webhookRepositoryImpl struct {
+	queries *db.Queries
+}
+
+func NewWebhookRepository(db *db.Queries) WebhookRepository {
+	return &webhookRepositoryImpl{
+		queries: db,
+	}
+}
+
+func (r *webhookRepositoryImpl) CreateWebhook(ctx context.Context, param *db.CreateWebhookSubscriptionParams) (*db.WebhookSubscription, error) {
+	subscription, err := r.queries.CreateWebhookSubscription(ctx, *param)
+	if err != nil {
+		log.Printf("Unable to execute WebhookRepositoryCreateWebhook: %d\n", err)
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+func (r *webhookRepositoryImpl) ListWebhooks(ctx context.Context) ([]db.WebhookSubscription, error) {
+	subscriptions, err := r.queries.ListWebhookSubscriptions(ctx)
+	if err != nil {
+		log.Printf("Unable to execute WebhookRepositoryListWebhooks: %d\n", err)
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+func (r *webhookRepositoryImpl) GetWebhookById(ctx context.Context, id int) (*db.WebhookSubscription, error) {
+	subscription, err := r.queries.GetWebhookSubscriptionByID(ctx, int32(id))
+	if err != nil {
+		log.Printf("Unable to execute WebhookRepositoryGetWebhookById: %d\n", err)
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+func (r *webhookRepositoryImpl) UpdateWebhook(ctx context.Context, param *db.UpdateWebhookSubscriptionParams) (*db.WebhookSubscription, error) {
+	subscription, err := r.queries.UpdateWebhookSubscription(ctx, *param)
+	if err != nil {
+		log.Printf("Unable to execute WebhookRepositoryUpdateWebhook: %d\n", err)
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+// DeleteWebhook は購読を配信の記録ごと削除し、購読が存在しない場合はErrWebhookNotFoundを返す
+func (r *webhookRepositoryImpl) DeleteWebhook(ctx context.Context, id int) error {
+	n, err := r.queries.DeleteWebhookSubscription(ctx, int32(id))
+	if err != nil {
+		log.Printf("Unable to execute WebhookRepositoryDeleteWebhook: %d\n", err)
+		return err
+	}
+	if n == 0 {
+		return ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+func (r *webhookRepositoryImpl) ListDeliveries(ctx context.Context, param *db.ListWebhookDeliveriesParams) ([]db.WebhookDelivery, error) {
+	deliveries, err := r.queries.ListWebhookDeliveries(ctx, *param)
+	if err != nil {
+		log.Printf("Unable to execute WebhookRepositoryListDeliveries: %d\n", err)
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (r *webhookRepositoryImpl) ListDeadDeliveries(ctx context.Context, limit int) ([]db.WebhookDelivery, error) {
+	deliveries, err := r.queries.ListDeadWebhookDeliveries(ctx, int32(limit))
+	if err != nil {
+		log.Printf("Unable to execute WebhookRepositoryListDeadDeliveries: %d\n", err)
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// RequeueDelivery は配信を試行回数を戻して配信待ちに戻し、購読の配信でない場合はpgx.ErrNoRowsを返す
+func (r *webhookRepositoryImpl) RequeueDelivery(ctx context.Context, id int, deliveryId int64) (*db.WebhookDelivery, error) {
+	delivery, err := r.queries.RequeueWebhookDelivery(ctx, db.RequeueWebhookDeliveryParams{
+		ID:             deliveryId,
+		SubscriptionID: int32(id),
+	})
+	if err != nil {
+		log.Printf("Unable to execute WebhookRepositoryRequeueDelivery: %d\n", err)
+		return nil, err
+	}
+
+	return &delivery, nil
+}
+
+// EnqueueDeliveries はイベントの種類を購読している有効な購読ごとに配信を登録する
+func (r *webhookRepositoryImpl) EnqueueDeliveries(ctx context.Context, eventId int64, eventType event.Type, body []byte) error {
+	_, err := r.queries.CreateWebhookDeliveries(ctx, db.CreateWebhookDeliveriesParams{
+		EventID:   eventId,
+		EventType: string(eventType),
+		Payload:   body,
+	})
+	if err != nil {
+		log.Printf("Unable to execute WebhookRepositoryEnqueueDeliveries: %d\n", err)
+		return err
+	}
+
+	return nil
+}
+
+// ClaimDeliveries は他の送信処理がロック中の配信を飛ばして取得するため、複数のプロセスから同時に呼び出せる
+func (r *webhookRepositoryImpl) ClaimDeliveries(ctx context.Context, limit int, leaseUntil time.Time) ([]webhook.Delivery, error) {
+	rows, err := r.queries.ClaimWebhookDeliveries(ctx, db.ClaimWebhookDeliveriesParams{
+		LeaseUntil:    pgtype.Timestamptz{Time: leaseUntil, Valid: true},
+		MaxDeliveries: int32(limit),
+	})
+	if err != nil {
+		log.Printf("Unable to execute WebhookRepositoryClaimDeliveries: %d\n", err)
+		return nil, err
+	}
+
+	deliveries := make([]webhook.Delivery, 0, len(rows))
+	for _, row := range rows {
+		deliveries = append(deliveries, webhook.Delivery{
+			ID:        row.ID,
+			EventID:   row.EventID,
+			EventType: event.Type(row.EventType),
+			Body:      row.Payload,
+			Attempts:  int(row.Attempts),
+			URL:       row.Url,
+			Secret:    row.Secret,
+		})
+	}
+
+	return deliveries, nil
+}
+
+// ReleaseDeliveries はリースの期限がleaseUntilのままの配信のみを戻すため、リースが切れて他の送信処理が取得した配信は変更しない
+func (r *webhookRepositoryImpl) ReleaseDeliveries(ctx context.Context, ids []int64, leaseUntil time.Time) error {
+	err := r.queries.ReleaseWebhookDeliveries(ctx, db.ReleaseWebhookDeliveriesParams{
+		Ids:        ids,
+		LeaseUntil: pgtype.Timestamptz{Time: leaseUntil, Valid: true},
+	})
+	if err != nil {
+		log.Printf("Unable to execute WebhookRepositoryReleaseDeliveries: %d\n", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *webhookRepositoryImpl) MarkSucceeded(ctx context.Context, id int64, statusCode int) error {
+	err := r.queries.MarkWebhookDeliverySucceeded(ctx, db.MarkWebhookDeliverySucceededParams{
+		ID:             id,
+		ResponseStatus: pgtype.Int4{Int32: int32(statusCode), Valid: true},
+	})
+	if err != nil {
+		log.Printf("Unable to execute WebhookRepositoryMarkSucceeded: %d\n", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *webhookRepositoryImpl) MarkFailed(ctx context.Context, id int64, dead bool, retryAt time.Time, statusCode int, cause string) error {
+	status := WebhookDeliveryStatusPending
+	if dead {
+		status = WebhookDeliveryStatusDead
+	}
+	err := r.queries.MarkWebhookDeliveryFailed(ctx, db.MarkWebhookDeliveryFailedParams{
+		ID:             id,
+		Status:         status,
+		NextAttemptAt:  pgtype.Timestamptz{Time: retryAt, Valid: true},
+		ResponseStatus: pgtype.Int4{Int32: int32(statusCode), Valid: statusCode != 0},
+		LastError:      pgtype.Text{String: cause, Valid: true},
+	})
+	if err != nil {
+		log.Printf("Unable to execute WebhookRepositoryMarkFailed: %d\n", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/event"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	"github.com/rentaro-m-b/ai-model-exam/webhook"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteWebhookFailureNotFound(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	mock.ExpectExec(`-- name: DeleteWebhookSubscription :execrows`).
+		WithArgs(int32(99)).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	repo := repository.NewWebhookRepository(db.New(mock))
+	err = repo.DeleteWebhook(context.Background(), 99)
+	assert.ErrorIs(t, err, repository.ErrWebhookNotFound)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestEnqueueDeliveries(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	body := []byte(`{"id":10}`)
+	mock.ExpectExec(`-- name: CreateWebhookDeliveries :execrows`).
+		WithArgs(int64(10), "book.created", body).
+		WillReturnResult(pgxmock.NewResult("INSERT", 2))
+
+	repo := repository.NewWebhookRepository(db.New(mock))
+	err = repo.EnqueueDeliveries(context.Background(), 10, event.TypeBookCreated, body)
+	assert.NoError(t, err)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestClaimDeliveries(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	leaseUntil := time.Date(2024, 7, 1, 0, 1, 0, 0, time.UTC)
+	body := []byte(`{"id":10}`)
+	mock.ExpectQuery(`-- name: ClaimWebhookDeliveries :many`).
+		WithArgs(pgtype.Timestamptz{Time: leaseUntil, Valid: true}, int32(50)).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "event_id", "event_type", "payload", "attempts", "url", "secret"}).
+			AddRow(int64(1), int64(10), "book.created", body, int32(1), "https://example.com/hook", "0123456789abcdef"))
+
+	repo := repository.NewWebhookRepository(db.New(mock))
+	deliveries, err := repo.ClaimDeliveries(context.Background(), 50, leaseUntil)
+	assert.NoError(t, err)
+	assert.Equal(t, []webhook.Delivery{
+		{
+			ID: 1, EventID: 10, EventType: event.TypeBookCreated, Body: body, Attempts: 1,
+			URL: "https://example.com/hook", Secret: "0123456789abcdef",
+		},
+	}, deliveries)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestReleaseDeliveries(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	leaseUntil := time.Date(2024, 7, 1, 0, 1, 0, 0, time.UTC)
+	mock.ExpectExec(`-- name: ReleaseWebhookDeliveries :exec`).
+		WithArgs([]int64{2, 3}, pgtype.Timestamptz{Time: leaseUntil, Valid: true}).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 2))
+
+	repo := repository.NewWebhookRepository(db.New(mock))
+	err = repo.ReleaseDeliveries(context.Background(), []int64{2, 3}, leaseUntil)
+	assert.NoError(t, err)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestMarkFailed(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	retryAt := time.Date(2024, 7, 1, 0, 1, 0, 0, time.UTC)
+	mock.ExpectExec(`-- name: MarkWebhookDeliveryFailed :exec`).
+		WithArgs(int64(1), repository.WebhookDeliveryStatusDead, pgtype.Timestamptz{Time: retryAt, Valid: true},
+			pgtype.Int4{}, pgtype.Text{String: "connection refused", Valid: true}).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	repo := repository.NewWebhookRepository(db.New(mock))
+	err = repo.MarkFailed(context.Background(), 1, true, retryAt, 0, "connection refused")
+	assert.NoError(t, err)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+)
+
+var (
+	ErrWorkNotFound    = errors.New("work not found")
+	ErrEditionNotFound = errors.New("book is not an edition of the work")
+)
+
+type WorkRepository interface {
+	CreateWork(ctx context.Context, param *db.CreateWorkParams) (*db.Work, error)
+	GetWorkById(ctx context.Context, id int) (*db.Work, error)
+	ListWorkEditions(ctx context.Context, id int) ([]db.Book, error)
+}
+
+type workRepositoryImpl struct {
+	queries *db.Queries
+}
+
+func NewWorkRepository(db *db.Queries) WorkRepository {
+	return &workRepositoryImpl{
+		queries: db,
+	}
+}
+
+func (r *workRepositoryImpl) CreateWork(ctx context.Context, param *db.CreateWorkParams) (*db.Work, error) {
+	work, err := r.queries.CreateWork(ctx, *param)
+	if err != nil {
+		log.Printf("Unable to execute WorkRepositoryCreateWork: %d\n", err)
+		return nil, err
+	}
+
+	return &work, nil
+}
+
+func (r *workRepositoryImpl) GetWorkById(ctx context.Context, id int) (*db.Work, error) {
+	work, err := r.queries.GetWorkByID(ctx, int32(id))
+	if err != nil {
+		log.Printf("Unable to execute WorkRepositoryGetWorkById: %d\n", err)
+		return nil, err
+	}
+
+	return &work, nil
+}
+
+// ListWorkEditions は作品に属する書籍を出版日の古い順に返す
+// 出版日が未登録の書籍は末尾に並ぶ
+func (r *workRepositoryImpl) ListWorkEditions(ctx context.Context, id int) ([]db.Book, error) {
+	books, err := r.queries.ListBooksByWorkID(ctx, pgtype.Int4{Int32: int32(id), Valid: true})
+	if err != nil {
+		log.Printf("Unable to execute WorkRepositoryListWorkEditions: %d\n", err)
+		return nil, err
+	}
+
+	return books, nil
+}
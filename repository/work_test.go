@@ -0,0 +1,68 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+var workColumns = []string{"id", "title", "author", "created_at"}
+
+func TestCreateWork(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	param := db.CreateWorkParams{
+		Title:  "test work",
+		Author: pgtype.Text{String: "test author", Valid: true},
+	}
+	expect := db.Work{ID: 1, Title: param.Title, Author: param.Author}
+
+	mock.ExpectQuery(`-- name: CreateWork :one`).
+		WithArgs(param.Title, param.Author).
+		WillReturnRows(pgxmock.NewRows(workColumns).AddRow(expect.ID, expect.Title, expect.Author, expect.CreatedAt))
+
+	repo := repository.NewWorkRepository(db.New(mock))
+	work, err := repo.CreateWork(context.Background(), &param)
+	assert.NoError(t, err)
+	assert.Equal(t, &expect, work)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
+
+func TestListWorkEditions(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Errorf("the error '%s' when opening a stub database connection", err)
+	}
+	defer mock.Close()
+
+	workId := pgtype.Int4{Int32: 1, Valid: true}
+	expects := []db.Book{
+		{ID: 3, Title: pgtype.Text{String: "test title 1", Valid: true}, WorkID: workId},
+		{ID: 5, Title: pgtype.Text{String: "test title 1 (2nd)", Valid: true}, WorkID: workId},
+	}
+
+	mock.ExpectQuery(`-- name: ListBooksByWorkID :many`).
+		WithArgs(workId).
+		WillReturnRows(bookRow(expects...))
+
+	repo := repository.NewWorkRepository(db.New(mock))
+	books, err := repo.ListWorkEditions(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, expects, books)
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("didn't execute query: %v", err)
+	}
+}
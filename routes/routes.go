@@ -1,19 +1,179 @@
 package routes
 
 import (
+	"expvar"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/labstack/echo/v4"
 	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/event"
+	"github.com/rentaro-m-b/ai-model-exam/graph"
 	"github.com/rentaro-m-b/ai-model-exam/handler"
+	"github.com/rentaro-m-b/ai-model-exam/money"
+	"github.com/rentaro-m-b/ai-model-exam/ratelimit"
 	"github.com/rentaro-m-b/ai-model-exam/repository"
+	"github.com/rentaro-m-b/ai-model-exam/storage"
 	"github.com/rentaro-m-b/ai-model-exam/usecase"
 )
 
-func Init(e *echo.Echo, db *db.Queries) {
-	bookRepository := repository.NewBookRepository(db)
-	bookUsecase := usecase.NewBookUsecase(bookRepository)
-	bookHandler := handler.NewBookHandler(bookUsecase)
+// Config はサーバ起動時に環境変数などから読み込む設定値
+type Config struct {
+	// PriceRounding は通貨換算で補助単位未満の端数を丸める方法
+	PriceRounding money.RoundingMode
+	// CoverStorage は書籍の表紙画像とサムネイルの保存先
+	CoverStorage storage.BlobStorage
+	// BookEvents は書籍の変更イベントをServer-Sent Eventsの接続へ配信する
+	BookEvents *event.Broker
+	// BookRepository はジョブのワーカーと共有する、キャッシュを通す書籍のリポジトリ
+	// キャッシュを削除した際に取得中の古い値を書き戻させないよう、同じキャッシュには1つのリポジトリのみを使う
+	// nilの場合はキャッシュしない
+	BookRepository repository.BookRepository
+	// RateLimitStore はクライアントごとの流量を数える保存先
+	RateLimitStore ratelimit.Store
+	// BookWriteLimit は書籍の登録の、AdminLimit は管理用のエンドポイントのクライアントごとの流量の上限
+	BookWriteLimit ratelimit.Limit
+	AdminLimit     ratelimit.Limit
+	// BookUsecaseConfig は書籍の登録の冪等キーを保存しておく期間と、IDを指定して一度に取得できる書籍の最大件数
+	BookUsecaseConfig usecase.BookUsecaseConfig
+	// LoanUsecaseConfig・ReservationUsecaseConfig は返却された書籍を予約者のために取り置く期間で、同じ期間を指定する
+	LoanUsecaseConfig        usecase.LoanUsecaseConfig
+	ReservationUsecaseConfig usecase.ReservationUsecaseConfig
+	// JobArtifactStorage はジョブが生成したファイルの保存先で、ワーカーと同じ保存先を指定する
+	JobArtifactStorage storage.BlobStorage
+}
+
+// bookEventHeartbeat はServer-Sent Eventsの接続を途中のプロキシに切られないよう、コメントを送る間隔
+const bookEventHeartbeat = 15 * time.Second
 
+func Init(e *echo.Echo, pool *pgxpool.Pool, cfg *Config) {
+	db := db.New(pool)
+
+	bookRepository := cfg.BookRepository
+	if bookRepository == nil {
+		bookRepository = repository.NewBookRepository(db, pool)
+	}
+	bookUsecase := usecase.NewBookUsecase(bookRepository, &cfg.BookUsecaseConfig)
+	priceRepository := repository.NewPriceRepository(db, pool)
+	exchangeRateRepository := repository.NewExchangeRateRepository(db, pool)
+	promotionRepository := repository.NewPromotionRepository(db)
+	priceUsecase := usecase.NewPriceUsecase(priceRepository, bookRepository, exchangeRateRepository, promotionRepository, cfg.PriceRounding)
+	priceHandler := handler.NewPriceHandler(priceUsecase)
+	bookHandler := handler.NewBookHandler(bookUsecase, priceUsecase)
+	inventoryRepository := repository.NewInventoryRepository(db, pool)
+	inventoryUsecase := usecase.NewInventoryUsecase(inventoryRepository, bookRepository)
+	inventoryHandler := handler.NewInventoryHandler(inventoryUsecase)
+	memberRepository := repository.NewMemberRepository(db)
+	memberUsecase := usecase.NewMemberUsecase(memberRepository)
+	memberHandler := handler.NewMemberHandler(memberUsecase)
+	loanRepository := repository.NewLoanRepository(db, pool)
+	loanUsecase := usecase.NewLoanUsecase(loanRepository, bookRepository, &cfg.LoanUsecaseConfig)
+	loanHandler := handler.NewLoanHandler(loanUsecase)
+	reservationRepository := repository.NewReservationRepository(db, pool)
+	reservationUsecase := usecase.NewReservationUsecase(reservationRepository, &cfg.ReservationUsecaseConfig)
+	reservationHandler := handler.NewReservationHandler(reservationUsecase)
+	exchangeRateUsecase := usecase.NewExchangeRateUsecase(exchangeRateRepository)
+	exchangeRateHandler := handler.NewExchangeRateHandler(exchangeRateUsecase)
+	promotionUsecase := usecase.NewPromotionUsecase(promotionRepository, bookRepository)
+	promotionHandler := handler.NewPromotionHandler(promotionUsecase)
+	categoryRepository := repository.NewCategoryRepository(db, pool)
+	categoryUsecase := usecase.NewCategoryUsecase(categoryRepository, bookRepository)
+	categoryHandler := handler.NewCategoryHandler(categoryUsecase)
+	tagRepository := repository.NewTagRepository(db, pool)
+	tagUsecase := usecase.NewTagUsecase(tagRepository, bookRepository)
+	tagHandler := handler.NewTagHandler(tagUsecase)
+	reviewRepository := repository.NewReviewRepository(db, pool)
+	reviewUsecase := usecase.NewReviewUsecase(reviewRepository, bookRepository)
+	reviewHandler := handler.NewReviewHandler(reviewUsecase)
+	coverRepository := repository.NewCoverRepository(db, pool)
+	coverUsecase := usecase.NewCoverUsecase(coverRepository, bookRepository, cfg.CoverStorage)
+	coverHandler := handler.NewCoverHandler(coverUsecase)
+	workRepository := repository.NewWorkRepository(db)
+	workUsecase := usecase.NewWorkUsecase(workRepository, bookRepository)
+	workHandler := handler.NewWorkHandler(workUsecase)
+	bookEventUsecase := usecase.NewBookEventUsecase(repository.NewOutboxRepository(db), cfg.BookEvents)
+	bookEventHandler := handler.NewBookEventHandler(bookEventUsecase, bookEventHeartbeat)
+	webhookRepository := repository.NewWebhookRepository(db)
+	webhookUsecase := usecase.NewWebhookUsecase(webhookRepository)
+	webhookHandler := handler.NewWebhookHandler(webhookUsecase)
+	jobRepository := repository.NewJobRepository(db)
+	jobUsecase := usecase.NewJobUsecase(jobRepository, cfg.JobArtifactStorage)
+	jobHandler := handler.NewJobHandler(jobUsecase)
+	graphServer, err := graph.NewServer(bookUsecase)
+	if err != nil {
+		log.Fatalf("Unable to build GraphQL schema: %v\n", err)
+	}
+	graphQLHandler := handler.NewGraphQLHandler(graphServer)
+
+	bookWriteLimit := ratelimit.Middleware(ratelimit.Config{Name: "book-writes", Limit: cfg.BookWriteLimit, Store: cfg.RateLimitStore})
 	e.GET("/books", bookHandler.FetchBooks)
-	e.POST("/books", bookHandler.CreateBook)
+	e.POST("/books", bookHandler.CreateBook, bookWriteLimit)
+	e.PATCH("/books", bookHandler.BulkUpdateBooks, bookWriteLimit)
+	e.DELETE("/books", bookHandler.DeleteBooks, bookWriteLimit)
+	e.POST("/books/exports", jobHandler.EnqueueBookExport)
+	e.POST("/books/bulk-updates", jobHandler.EnqueueBookBulkUpdate, bookWriteLimit)
+	e.POST("/books/bulk-deletes", jobHandler.EnqueueBookBulkDelete, bookWriteLimit)
+	e.GET("/books/events", bookEventHandler.StreamEvents)
 	e.GET("/books/:id", bookHandler.FindBookById)
+	e.PATCH("/books/:id", bookHandler.UpdateBook)
+	e.POST("/books/:id/merge", bookHandler.MergeBook)
+	e.GET("/books/:id/audit-logs", bookHandler.FetchBookAuditLogs)
+	e.GET("/books/:id/cover", coverHandler.FetchCover)
+	e.PUT("/books/:id/cover", coverHandler.UploadCover)
+	e.GET("/books/:id/prices", priceHandler.FetchPrices)
+	e.POST("/books/:id/prices", priceHandler.SchedulePrice)
+	e.GET("/books/:id/inventories", inventoryHandler.FetchInventories)
+	e.POST("/books/:id/inventories/adjustments", inventoryHandler.AdjustStock)
+	e.GET("/books/:id/stock-movements", inventoryHandler.FetchStockMovements)
+	e.GET("/books/:id/availability", loanHandler.FindAvailability)
+	e.POST("/books/:id/loans", loanHandler.Checkout)
+	e.GET("/books/:id/reservations", reservationHandler.FetchReservations)
+	e.POST("/books/:id/reservations", reservationHandler.Reserve)
+	e.GET("/books/:id/categories", categoryHandler.FetchBookCategories)
+	e.PUT("/books/:id/categories", categoryHandler.SetBookCategories)
+	e.GET("/books/:id/tags", tagHandler.FetchBookTags)
+	e.POST("/books/:id/tags", tagHandler.AddBookTag)
+	e.DELETE("/books/:id/tags/:name", tagHandler.RemoveBookTag)
+	e.GET("/books/:id/reviews", reviewHandler.FetchBookReviews)
+	e.POST("/books/:id/reviews", reviewHandler.CreateReview)
+	e.POST("/loans/:id/return", loanHandler.ReturnLoan)
+	e.GET("/loans/overdue", loanHandler.FetchOverdueLoans)
+	e.PATCH("/reviews/:id", reviewHandler.UpdateReview)
+	e.DELETE("/reviews/:id", reviewHandler.DeleteReview)
+	e.POST("/members", memberHandler.CreateMember)
+	e.GET("/members/:id", memberHandler.FindMemberById)
+	e.POST("/promotions", promotionHandler.CreatePromotion)
+	e.GET("/promotions", promotionHandler.FetchPromotions)
+	e.GET("/promotions/:id", promotionHandler.FindPromotionById)
+	e.DELETE("/promotions/:id", promotionHandler.DeletePromotion)
+	e.POST("/categories", categoryHandler.CreateCategory)
+	e.GET("/categories", categoryHandler.FetchCategories)
+	e.GET("/categories/:id", categoryHandler.FindCategoryById)
+	e.PATCH("/categories/:id", categoryHandler.UpdateCategory)
+	e.DELETE("/categories/:id", categoryHandler.DeleteCategory)
+	e.POST("/categories/:id/move", categoryHandler.MoveCategory)
+	e.GET("/tags", tagHandler.SuggestTags)
+	e.POST("/works", workHandler.CreateWork)
+	e.GET("/works/:id", workHandler.FindWorkById)
+	e.PUT("/works/:id/books/:book_id", workHandler.LinkBook)
+	e.DELETE("/works/:id/books/:book_id", workHandler.UnlinkBook)
+	e.POST("/webhooks", webhookHandler.CreateWebhook)
+	e.GET("/webhooks", webhookHandler.FetchWebhooks)
+	e.GET("/webhooks/dead-letters", webhookHandler.FetchDeadLetters)
+	e.GET("/webhooks/:id", webhookHandler.FindWebhookById)
+	e.PATCH("/webhooks/:id", webhookHandler.UpdateWebhook)
+	e.DELETE("/webhooks/:id", webhookHandler.DeleteWebhook)
+	e.GET("/webhooks/:id/deliveries", webhookHandler.FetchDeliveries)
+	e.POST("/webhooks/:id/deliveries/:delivery_id/redeliver", webhookHandler.Redeliver)
+	e.GET("/jobs/:id", jobHandler.FindJobById)
+	e.POST("/jobs/:id/cancel", jobHandler.CancelJob)
+	e.GET("/jobs/:id/artifact", jobHandler.FetchJobArtifact)
+	// createBookなどの変更も受け付けるため、書籍の登録と同じ上限を共有する
+	e.POST("/graphql", graphQLHandler.Query, bookWriteLimit)
+
+	admin := e.Group("/admin", ratelimit.Middleware(ratelimit.Config{Name: "admin", Limit: cfg.AdminLimit, Store: cfg.RateLimitStore}))
+	admin.POST("/exchange-rates/import", exchangeRateHandler.ImportRates)
+	admin.POST("/exchange-rates/import-jobs", jobHandler.EnqueueExchangeRateImport)
+	admin.GET("/metrics", echo.WrapHandler(expvar.Handler()))
 }
@@ -0,0 +1,96 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+type localStorageImpl struct {
+	root string
+}
+
+// NewLocalStorage はrootディレクトリ配下にキーをパスとしてデータを保存するBlobStorageを返す
+func NewLocalStorage(root string) (BlobStorage, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &localStorageImpl{
+		root: root,
+	}, nil
+}
+
+// path はキーをroot配下のファイルパスに変換する
+// rootの外を指すキーは受け付けない
+func (s *localStorageImpl) path(key string) (string, error) {
+	cleaned := path.Clean(key)
+	if key == "" || cleaned != key || path.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("invalid blob key: %q", key)
+	}
+
+	return filepath.Join(s.root, filepath.FromSlash(cleaned)), nil
+}
+
+// Put は一時ファイルに書き込んでから置き換えるため、読み込み中のデータが途中で切り替わることはない
+func (s *localStorageImpl) Put(ctx context.Context, key string, r io.Reader) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(p), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), p)
+}
+
+func (s *localStorageImpl) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	p, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(p)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrBlobNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func (s *localStorageImpl) Delete(ctx context.Context, key string) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(p); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package storage_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/rentaro-m-b/ai-model-exam/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStorage(t *testing.T) {
+	ctx := context.Background()
+	s, err := storage.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+
+	// 保存したデータを取得できる
+	require.NoError(t, s.Put(ctx, "covers/1/original", strings.NewReader("first")))
+	require.NoError(t, s.Put(ctx, "covers/1/original", strings.NewReader("second")))
+	r, err := s.Get(ctx, "covers/1/original")
+	require.NoError(t, err)
+	data, err := io.ReadAll(r)
+	r.Close()
+	assert.NoError(t, err)
+	assert.Equal(t, "second", string(data))
+
+	// 削除したデータは取得できず、存在しないデータの削除はエラーにならない
+	assert.NoError(t, s.Delete(ctx, "covers/1/original"))
+	assert.NoError(t, s.Delete(ctx, "covers/1/original"))
+	_, err = s.Get(ctx, "covers/1/original")
+	assert.ErrorIs(t, err, storage.ErrBlobNotFound)
+}
+
+func TestLocalStorageRejectsInvalidKey(t *testing.T) {
+	ctx := context.Background()
+	s, err := storage.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+
+	for _, key := range []string{"", "../outside", "/etc/passwd", "covers/../../outside", "covers//1"} {
+		assert.Error(t, s.Put(ctx, key, strings.NewReader("data")), key)
+		_, err := s.Get(ctx, key)
+		assert.Error(t, err, key)
+	}
+}
@@ -0,0 +1,20 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+var ErrBlobNotFound = errors.New("blob not found")
+
+// BlobStorage は画像などのバイナリデータを、スラッシュ区切りのキーで保存する
+// 保存先（ローカルのファイルシステム、オブジェクトストレージなど）は実装ごとに差し替えられる
+type BlobStorage interface {
+	// Put はキーにデータを保存し、既に存在する場合は置き換える
+	Put(ctx context.Context, key string, r io.Reader) error
+	// Get はキーのデータを返し、存在しない場合はErrBlobNotFoundを返す
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete はキーのデータを削除し、存在しない場合は何もしない
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,92 @@
+package thumbnail
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"io"
+)
+
+// Size はサムネイルの大きさの名前と、長辺の最大ピクセル数の組を表す
+type Size struct {
+	Name    string
+	MaxSide int
+}
+
+// Sizes は生成するサムネイルの大きさの一覧
+var Sizes = []Size{
+	{Name: "small", MaxSide: 100},
+	{Name: "medium", MaxSide: 300},
+	{Name: "large", MaxSide: 600},
+}
+
+// LookupSize は名前に対応するサムネイルの大きさを返す
+func LookupSize(name string) (Size, bool) {
+	for _, size := range Sizes {
+		if size.Name == name {
+			return size, true
+		}
+	}
+	return Size{}, false
+}
+
+// jpegQuality はサムネイルをJPEGで保存する際の品質
+const jpegQuality = 85
+
+// Resize は縦横比を保ったまま、長辺がmaxSide以下になるよう画像を縮小する
+// 元の画像がmaxSide以下の場合は拡大しない
+// 透過部分は白で塗りつぶし、縮小には対応する範囲の画素の平均（面積平均法）を用いる
+func Resize(src image.Image, maxSide int) *image.RGBA {
+	bounds := src.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+	dstW, dstH := srcW, srcH
+	if srcW > maxSide || srcH > maxSide {
+		if srcW >= srcH {
+			dstW = maxSide
+			dstH = max(1, srcH*maxSide/srcW)
+		} else {
+			dstH = maxSide
+			dstW = max(1, srcW*maxSide/srcH)
+		}
+	}
+
+	flat := image.NewRGBA(image.Rect(0, 0, srcW, srcH))
+	draw.Draw(flat, flat.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(flat, flat.Bounds(), src, bounds.Min, draw.Over)
+	if dstW == srcW && dstH == srcH {
+		return flat
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+	for y := 0; y < dstH; y++ {
+		y0, y1 := y*srcH/dstH, max((y+1)*srcH/dstH, y*srcH/dstH+1)
+		for x := 0; x < dstW; x++ {
+			x0, x1 := x*srcW/dstW, max((x+1)*srcW/dstW, x*srcW/dstW+1)
+
+			var r, g, b, n int
+			for sy := y0; sy < y1; sy++ {
+				row := flat.Pix[sy*flat.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					r += int(row[sx*4])
+					g += int(row[sx*4+1])
+					b += int(row[sx*4+2])
+					n++
+				}
+			}
+
+			i := y*dst.Stride + x*4
+			dst.Pix[i] = uint8(r / n)
+			dst.Pix[i+1] = uint8(g / n)
+			dst.Pix[i+2] = uint8(b / n)
+			dst.Pix[i+3] = 0xff
+		}
+	}
+
+	return dst
+}
+
+// Encode は画像をサムネイル用の品質のJPEGで書き出す
+func Encode(w io.Writer, img image.Image) error {
+	return jpeg.Encode(w, img, &jpeg.Options{Quality: jpegQuality})
+}
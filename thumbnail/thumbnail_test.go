@@ -0,0 +1,67 @@
+package thumbnail_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+
+	"github.com/rentaro-m-b/ai-model-exam/thumbnail"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResize(t *testing.T) {
+	tests := []struct {
+		name    string
+		width   int
+		height  int
+		maxSide int
+		expectW int
+		expectH int
+	}{
+		{name: "横長の画像は幅を基準に縮小する", width: 800, height: 400, maxSide: 100, expectW: 100, expectH: 50},
+		{name: "縦長の画像は高さを基準に縮小する", width: 600, height: 900, maxSide: 300, expectW: 200, expectH: 300},
+		{name: "小さい画像は拡大しない", width: 80, height: 120, maxSide: 300, expectW: 80, expectH: 120},
+		{name: "極端に細長い画像も1ピクセル以上にする", width: 1000, height: 2, maxSide: 100, expectW: 100, expectH: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := image.NewRGBA(image.Rect(0, 0, tt.width, tt.height))
+			dst := thumbnail.Resize(src, tt.maxSide)
+			assert.Equal(t, tt.expectW, dst.Bounds().Dx())
+			assert.Equal(t, tt.expectH, dst.Bounds().Dy())
+		})
+	}
+}
+
+func TestResizeAveragesPixels(t *testing.T) {
+	// 左半分が黒、右半分が白の画像を1x1に縮小すると灰色になる
+	src := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	for y := 0; y < 2; y++ {
+		src.Set(0, y, color.Black)
+		src.Set(1, y, color.White)
+	}
+
+	dst := thumbnail.Resize(src, 1)
+	assert.Equal(t, color.RGBA{R: 127, G: 127, B: 127, A: 255}, dst.RGBAAt(0, 0))
+}
+
+func TestResizeFillsTransparentPixelsWithWhite(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+
+	dst := thumbnail.Resize(src, 2)
+	assert.Equal(t, color.RGBA{R: 255, G: 255, B: 255, A: 255}, dst.RGBAAt(1, 1))
+}
+
+func TestEncode(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, thumbnail.Encode(&buf, thumbnail.Resize(image.NewRGBA(image.Rect(0, 0, 40, 20)), 10)))
+
+	cfg, err := jpeg.DecodeConfig(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, cfg.Width)
+	assert.Equal(t, 5, cfg.Height)
+}
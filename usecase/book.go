@@ -2,25 +2,169 @@ package usecase
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
 	"log"
+	"net/http"
+	"strings"
+	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/event"
+	"github.com/rentaro-m-b/ai-model-exam/job"
 	"github.com/rentaro-m-b/ai-model-exam/repository"
 )
 
 type BookUsecase interface {
 	FetchBooks(ctx context.Context) ([]db.Book, error)
-	CreateBook(ctx context.Context, param *db.CreateBookParams) (*db.Book, error)
+	CreateBook(ctx context.Context, param *db.CreateBookParams, force bool) (*db.Book, error)
 	FindBookById(ctx context.Context, id int) (*db.Book, error)
+	UpdateBook(ctx context.Context, param *db.UpdateBookParams) (*db.Book, error)
+	SearchBooks(ctx context.Context, param *db.SearchBooksParams) ([]db.Book, error)
+	FetchBooksByIds(ctx context.Context, ids []int32) (*BookBatch, error)
+	FetchBooksByAuthors(ctx context.Context, authors []string) ([]db.Book, error)
+	FetchBooksByPublishers(ctx context.Context, publishers []string) ([]db.Book, error)
+	FetchBooksByStock(ctx context.Context, inStock bool) ([]db.Book, error)
+	FilterBooks(ctx context.Context, param *db.FilterBooksParams) ([]db.Book, error)
+	FetchBookRatings(ctx context.Context, bookIds []int32) (map[int32]db.BookRating, error)
+	MergeBook(ctx context.Context, sourceId int, targetId int) (*db.Book, error)
+	BulkUpdateBooks(ctx context.Context, filter *db.ListBooksByFilterParams, patch *repository.BookPatch, dryRun bool) (*BulkResult, error)
+	DeleteBooks(ctx context.Context, filter *db.ListBooksByFilterParams, dryRun bool) (*BulkResult, error)
+	BulkUpdateBooksInJob(ctx context.Context, j *job.Job, filter *db.ListBooksByFilterParams, patch *repository.BookPatch) (*BulkResult, error)
+	DeleteBooksInJob(ctx context.Context, j *job.Job, filter *db.ListBooksByFilterParams) (*BulkResult, error)
+	FetchBookAuditLogs(ctx context.Context, bookId int) ([]db.BookAuditLog, error)
+	FetchBookCollectionVersion(ctx context.Context, variant string) (*BookCollectionVersion, error)
+	CreateBookIdempotently(ctx context.Context, key string, param *db.CreateBookParams, force bool) (*IdempotentBookCreation, error)
+	PurgeExpiredIdempotencyKeys(ctx context.Context) (int64, error)
+}
+
+// BookCollectionVersion は書籍の一覧のキャッシュの検証に用いる情報を表す
+type BookCollectionVersion struct {
+	ETag         string
+	LastModified time.Time
+}
+
+var ErrMergeIntoSelf = errors.New("cannot merge a book into itself")
+
+// ErrIdempotencyKeyReused は冪等キーが内容の異なるリクエストで再利用されたことを表す
+var ErrIdempotencyKeyReused = errors.New("idempotency key was reused with a different request")
+
+// errIdempotencyKeyTaken は同じ冪等キーでの登録が先にコミットされたため、登録を取り消すことを表す
+var errIdempotencyKeyTaken = errors.New("idempotency key was taken by a concurrent request")
+
+const (
+	// defaultIdempotencyKeyTTL は冪等キーを保存しておく既定の期間
+	defaultIdempotencyKeyTTL = 24 * time.Hour
+	defaultMaxBatchSize      = 100
+)
+
+// BatchTooLargeError は一度に取得できる件数を超えるIDが指定されたことを表す
+type BatchTooLargeError struct {
+	Max int
+}
+
+func (e *BatchTooLargeError) Error() string {
+	return fmt.Sprintf("too many book ids (max %d)", e.Max)
+}
+
+// BulkResult は一括更新・一括削除の対象になった書籍を表す
+type BulkResult struct {
+	// Affected は更新・削除した（DryRunの場合は対象になる）書籍の件数
+	Affected int
+	BookIDs  []int32
+	DryRun   bool
+}
+
+func newBulkResult(books []db.Book, dryRun bool) *BulkResult {
+	res := &BulkResult{Affected: len(books), BookIDs: make([]int32, 0, len(books)), DryRun: dryRun}
+	for _, book := range books {
+		res.BookIDs = append(res.BookIDs, book.ID)
+	}
+
+	return res
+}
+
+// BookBatch はIDを指定して取得した書籍と、見つからなかったIDを表す
+type BookBatch struct {
+	Books      []db.Book
+	MissingIDs []int32
+}
+
+// IdempotentBookCreation は冪等キーを指定した書籍の登録の結果を表す
+type IdempotentBookCreation struct {
+	BookID int32
+	// Replayed は同じキーで登録済みだったため、登録せずに保存した結果を返したことを表す
+	Replayed bool
+}
+
+// BookMergedError は参照された書籍が統合により削除され、統合先へ移ったことを表す
+type BookMergedError struct {
+	TargetID int32
+}
+
+func (e *BookMergedError) Error() string {
+	return fmt.Sprintf("book was merged into book %d", e.TargetID)
+}
+
+const (
+	// duplicateMinSimilarity は重複の疑いありとみなすタイトルと著者のトライグラム類似度の下限
+	duplicateMinSimilarity = 0.6
+	maxDuplicateCandidates = 5
+)
+
+// DuplicateBookError は登録しようとした書籍と重複の疑われる既存の書籍を表す
+type DuplicateBookError struct {
+	Candidates []db.Book
+}
+
+func (e *DuplicateBookError) Error() string {
+	ids := make([]string, 0, len(e.Candidates))
+	for _, book := range e.Candidates {
+		ids = append(ids, fmt.Sprint(book.ID))
+	}
+
+	return fmt.Sprintf("possible duplicate of books %s", strings.Join(ids, ", "))
+}
+
+// normalizeTitleAuthor はタイトルと著者を、重複の判定に使う小文字・単一空白区切りの文字列にする
+// 書籍側の索引と同じく、タイトルと著者を空白で連結する
+func normalizeTitleAuthor(title string, author string) string {
+	return strings.ToLower(strings.Join(strings.Fields(title+" "+author), " "))
+}
+
+// BookUsecaseConfig の未指定（ゼロ値）の項目には既定値を使う
+type BookUsecaseConfig struct {
+	// IdempotencyKeyTTL は冪等キーを保存しておく期間で、過ぎたキーは新しいリクエストに使える
+	IdempotencyKeyTTL time.Duration
+	// MaxBatchSize はIDを指定して一度に取得できる書籍の最大件数
+	MaxBatchSize int
 }
 
 type bookUsecaseImpl struct {
 	repository repository.BookRepository
+	config     BookUsecaseConfig
 }
 
-func NewBookUsecase(repository repository.BookRepository) BookUsecase {
+func NewBookUsecase(repository repository.BookRepository, config *BookUsecaseConfig) BookUsecase {
+	c := BookUsecaseConfig{}
+	if config != nil {
+		c = *config
+	}
+	if c.IdempotencyKeyTTL <= 0 {
+		c.IdempotencyKeyTTL = defaultIdempotencyKeyTTL
+	}
+	if c.MaxBatchSize <= 0 {
+		c.MaxBatchSize = defaultMaxBatchSize
+	}
+
 	return &bookUsecaseImpl{
 		repository: repository,
+		config:     c,
 	}
 }
 
@@ -34,18 +178,169 @@ func (u *bookUsecaseImpl) FetchBooks(ctx context.Context) ([]db.Book, error) {
 	return books, nil
 }
 
-func (u *bookUsecaseImpl) CreateBook(ctx context.Context, param *db.CreateBookParams) (*db.Book, error) {
-	book, err := u.repository.CreateBook(ctx, param)
+// CreateBook は重複の疑われる書籍があればDuplicateBookErrorを返し、forceが真の場合は確認せずに登録する
+// 登録と同じトランザクションでBookCreatedイベントをアウトボックスに登録する
+// 確認と登録は同じトランザクションで行わないため、同時に登録された重複は検出できない
+func (u *bookUsecaseImpl) CreateBook(ctx context.Context, param *db.CreateBookParams, force bool) (*db.Book, error) {
+	book, err := u.createBook(ctx, param, force, nil)
 	if err != nil {
-		log.Printf("Unable to execute BookUsecaseCreateBook: %d\n", err)
+		var duplicateErr *DuplicateBookError
+		if !errors.As(err, &duplicateErr) {
+			log.Printf("Unable to execute BookUsecaseCreateBook: %d\n", err)
+		}
 		return nil, err
 	}
 
 	return book, nil
 }
 
+// CreateBookIdempotently はCreateBookと同じく書籍を登録し、登録の結果を冪等キーに保存する
+// 有効期限内の同じキーで登録済みであれば登録せずに保存した結果を返し、リクエストの内容が異なればErrIdempotencyKeyReusedを返す
+// 登録に失敗した場合（重複の疑いを含む）は保存しないため、同じキーで再試行できる
+func (u *bookUsecaseImpl) CreateBookIdempotently(ctx context.Context, key string, param *db.CreateBookParams, force bool) (*IdempotentBookCreation, error) {
+	requestHash, err := hashCreateBookRequest(param, force)
+	if err != nil {
+		log.Printf("Unable to execute BookUsecaseCreateBookIdempotently: %d\n", err)
+		return nil, err
+	}
+
+	creation, err := u.replayIdempotencyKey(ctx, key, requestHash)
+	if creation != nil || err != nil {
+		return creation, err
+	}
+
+	book, err := u.createBook(ctx, param, force, func(repo repository.BookRepository, book *db.Book) error {
+		saved, err := repo.SaveIdempotencyKey(ctx, &db.CreateIdempotencyKeyParams{
+			IdempotencyKey: key,
+			RequestHash:    requestHash,
+			ResponseStatus: http.StatusCreated,
+			BookID:         book.ID,
+			TtlSeconds:     u.config.IdempotencyKeyTTL.Seconds(),
+		})
+		if err != nil {
+			return err
+		}
+		if !saved {
+			return errIdempotencyKeyTaken
+		}
+		return nil
+	})
+	var duplicateErr *DuplicateBookError
+	if errors.Is(err, errIdempotencyKeyTaken) || errors.As(err, &duplicateErr) {
+		// 同じキーの登録が同時に行われ、先にコミットされた場合は、その結果を返す
+		creation, replayErr := u.replayIdempotencyKey(ctx, key, requestHash)
+		if creation != nil || replayErr != nil {
+			return creation, replayErr
+		}
+	}
+	if err != nil {
+		if duplicateErr == nil {
+			log.Printf("Unable to execute BookUsecaseCreateBookIdempotently: %d\n", err)
+		}
+		return nil, err
+	}
+
+	return &IdempotentBookCreation{BookID: book.ID}, nil
+}
+
+// replayIdempotencyKey は有効期限内の冪等キーに保存した登録の結果を返し、キーが無ければnilを返す
+func (u *bookUsecaseImpl) replayIdempotencyKey(ctx context.Context, key string, requestHash string) (*IdempotentBookCreation, error) {
+	idempotencyKey, err := u.repository.GetIdempotencyKey(ctx, key)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		log.Printf("Unable to execute BookUsecaseCreateBookIdempotently: %d\n", err)
+		return nil, err
+	}
+	if idempotencyKey.RequestHash != requestHash {
+		return nil, ErrIdempotencyKeyReused
+	}
+
+	return &IdempotentBookCreation{BookID: idempotencyKey.BookID, Replayed: true}, nil
+}
+
+// hashCreateBookRequest は冪等キーの再利用を検出するため、登録の内容をSHA-256で要約する
+func hashCreateBookRequest(param *db.CreateBookParams, force bool) (string, error) {
+	b, err := json.Marshal(struct {
+		Param *db.CreateBookParams
+		Force bool
+	}{param, force})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// createBook は書籍を登録し、afterCreateがあれば登録と同じトランザクションで呼び出す
+func (u *bookUsecaseImpl) createBook(ctx context.Context, param *db.CreateBookParams, force bool, afterCreate func(repo repository.BookRepository, book *db.Book) error) (*db.Book, error) {
+	if !force {
+		candidates, err := u.repository.ListDuplicateCandidates(ctx, &db.ListDuplicateBookCandidatesParams{
+			Isbn:          param.Isbn,
+			TitleAuthor:   normalizeTitleAuthor(param.Title.String, param.Author.String),
+			MinSimilarity: duplicateMinSimilarity,
+			MaxCandidates: maxDuplicateCandidates,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(candidates) > 0 {
+			return nil, &DuplicateBookError{Candidates: candidates}
+		}
+	}
+
+	var book *db.Book
+	err := u.repository.WithTx(ctx, func(repo repository.BookRepository) error {
+		var err error
+		book, err = repo.CreateBook(ctx, param)
+		if err != nil {
+			return err
+		}
+		ev, err := event.NewBookCreated(book)
+		if err != nil {
+			return err
+		}
+		if err := repo.AddEvents(ctx, ev); err != nil {
+			return err
+		}
+		if afterCreate == nil {
+			return nil
+		}
+
+		return afterCreate(repo, book)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return book, nil
+}
+
+// PurgeExpiredIdempotencyKeys は有効期限を過ぎた冪等キーを削除し、削除した件数を返す
+func (u *bookUsecaseImpl) PurgeExpiredIdempotencyKeys(ctx context.Context) (int64, error) {
+	n, err := u.repository.DeleteExpiredIdempotencyKeys(ctx)
+	if err != nil {
+		log.Printf("Unable to execute BookUsecasePurgeExpiredIdempotencyKeys: %d\n", err)
+		return 0, err
+	}
+
+	return n, nil
+}
+
+// FindBookById は書籍が見つからない場合、統合により削除された書籍であればBookMergedErrorを返す
 func (u *bookUsecaseImpl) FindBookById(ctx context.Context, id int) (*db.Book, error) {
 	book, err := u.repository.GetBookById(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		targetId, redirectErr := u.repository.GetBookRedirect(ctx, id)
+		if redirectErr == nil {
+			return nil, &BookMergedError{TargetID: targetId}
+		}
+		if !errors.Is(redirectErr, pgx.ErrNoRows) {
+			err = redirectErr
+		}
+	}
 	if err != nil {
 		log.Printf("Unable to execute BookUsecaseFindBookById: %d\n", err)
 		return nil, err
@@ -53,3 +348,337 @@ func (u *bookUsecaseImpl) FindBookById(ctx context.Context, id int) (*db.Book, e
 
 	return book, nil
 }
+
+// BulkUpdateBooks は条件に一致する書籍をpatchの値に一括で更新し、更新した書籍ごとにBookUpdatedイベントを同じトランザクションでアウトボックスに登録する
+// dryRunが真の場合は更新せずに、更新の対象になる書籍を返す
+func (u *bookUsecaseImpl) BulkUpdateBooks(ctx context.Context, filter *db.ListBooksByFilterParams, patch *repository.BookPatch, dryRun bool) (*BulkResult, error) {
+	if dryRun {
+		books, err := u.repository.ListBooksByFilter(ctx, filter)
+		if err != nil {
+			log.Printf("Unable to execute BookUsecaseBulkUpdateBooks: %d\n", err)
+			return nil, err
+		}
+		return newBulkResult(books, true), nil
+	}
+
+	return u.bulkUpdateBooks(ctx, filter, patch, nil)
+}
+
+// BulkUpdateBooksInJob はBulkUpdateBooksと同じく一括で更新し、同じトランザクションでジョブの結果を記録する
+// 記録した結果は実行し直したジョブが使うため、ワーカーが途中で止まっても更新・価格履歴・監査ログ・イベントは重複しない
+// ジョブのリースを失っていた場合は更新を取り消して、job.ErrLeaseLostを返す
+func (u *bookUsecaseImpl) BulkUpdateBooksInJob(ctx context.Context, j *job.Job, filter *db.ListBooksByFilterParams, patch *repository.BookPatch) (*BulkResult, error) {
+	return u.bulkUpdateBooks(ctx, filter, patch, j)
+}
+
+// bulkUpdateBooks は一括で更新し、jがnilでなければジョブの結果も同じトランザクションで記録する
+func (u *bookUsecaseImpl) bulkUpdateBooks(ctx context.Context, filter *db.ListBooksByFilterParams, patch *repository.BookPatch, j *job.Job) (*BulkResult, error) {
+	var res *BulkResult
+	err := u.repository.WithTx(ctx, func(repo repository.BookRepository) error {
+		books, err := repo.BulkUpdateBooks(ctx, filter, patch)
+		if err != nil {
+			return err
+		}
+		events := make([]event.Event, 0, len(books))
+		for _, book := range books {
+			ev, err := event.NewBookUpdated(&book)
+			if err != nil {
+				return err
+			}
+			events = append(events, ev)
+		}
+		if err := repo.AddEvents(ctx, events...); err != nil {
+			return err
+		}
+		res = newBulkResult(books, false)
+
+		return commitJobResult(ctx, repo, j, res)
+	})
+	if err != nil {
+		log.Printf("Unable to execute BookUsecaseBulkUpdateBooks: %d\n", err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// DeleteBooks は条件に一致する書籍を一括で削除し、削除した書籍ごとにBookDeletedイベントを同じトランザクションでアウトボックスに登録する
+// dryRunが真の場合は削除せずに、削除の対象になる書籍を返す
+func (u *bookUsecaseImpl) DeleteBooks(ctx context.Context, filter *db.ListBooksByFilterParams, dryRun bool) (*BulkResult, error) {
+	if dryRun {
+		books, err := u.repository.ListBooksByFilter(ctx, filter)
+		if err != nil {
+			log.Printf("Unable to execute BookUsecaseDeleteBooks: %d\n", err)
+			return nil, err
+		}
+		return newBulkResult(books, true), nil
+	}
+
+	return u.deleteBooks(ctx, filter, nil)
+}
+
+// DeleteBooksInJob はBulkUpdateBooksInJobと同じく、一括で削除してジョブの結果を同じトランザクションで記録する
+func (u *bookUsecaseImpl) DeleteBooksInJob(ctx context.Context, j *job.Job, filter *db.ListBooksByFilterParams) (*BulkResult, error) {
+	return u.deleteBooks(ctx, filter, j)
+}
+
+// deleteBooks は一括で削除し、jがnilでなければジョブの結果も同じトランザクションで記録する
+func (u *bookUsecaseImpl) deleteBooks(ctx context.Context, filter *db.ListBooksByFilterParams, j *job.Job) (*BulkResult, error) {
+	var res *BulkResult
+	err := u.repository.WithTx(ctx, func(repo repository.BookRepository) error {
+		books, err := repo.DeleteBooks(ctx, filter)
+		if err != nil {
+			return err
+		}
+		events := make([]event.Event, 0, len(books))
+		for _, book := range books {
+			ev, err := event.NewBookDeleted(book.ID)
+			if err != nil {
+				return err
+			}
+			events = append(events, ev)
+		}
+		if err := repo.AddEvents(ctx, events...); err != nil {
+			return err
+		}
+		res = newBulkResult(books, false)
+
+		return commitJobResult(ctx, repo, j, res)
+	})
+	if err != nil {
+		log.Printf("Unable to execute BookUsecaseDeleteBooks: %d\n", err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// commitJobResult はジョブから一括更新・一括削除した場合に、ジョブの結果を記録する
+func commitJobResult(ctx context.Context, repo repository.BookRepository, j *job.Job, res *BulkResult) error {
+	if j == nil {
+		return nil
+	}
+	b, err := json.Marshal(bulkJobResult{Affected: res.Affected, BookIDs: res.BookIDs})
+	if err != nil {
+		return err
+	}
+
+	return repo.CommitJobResult(ctx, j, b)
+}
+
+// UpdateBook は更新と同じトランザクションでBookUpdatedイベントをアウトボックスに登録する
+func (u *bookUsecaseImpl) UpdateBook(ctx context.Context, param *db.UpdateBookParams) (*db.Book, error) {
+	var book *db.Book
+	err := u.repository.WithTx(ctx, func(repo repository.BookRepository) error {
+		var err error
+		book, err = repo.UpdateBook(ctx, param)
+		if err != nil {
+			return err
+		}
+		ev, err := event.NewBookUpdated(book)
+		if err != nil {
+			return err
+		}
+
+		return repo.AddEvents(ctx, ev)
+	})
+	if err != nil {
+		log.Printf("Unable to execute BookUsecaseUpdateBook: %d\n", err)
+		return nil, err
+	}
+
+	return book, nil
+}
+
+func (u *bookUsecaseImpl) SearchBooks(ctx context.Context, param *db.SearchBooksParams) ([]db.Book, error) {
+	books, err := u.repository.SearchBooks(ctx, param)
+	if err != nil {
+		log.Printf("Unable to execute BookUsecaseSearchBooks: %d\n", err)
+		return nil, err
+	}
+
+	return books, nil
+}
+
+// FetchBooksByIds は指定したIDの書籍を指定した順に返し、見つからなかったIDも指定した順に返す
+// 重複したIDは1件として扱い、統合により削除された書籍は見つからなかったものとする
+func (u *bookUsecaseImpl) FetchBooksByIds(ctx context.Context, ids []int32) (*BookBatch, error) {
+	ids = uniqueIds(ids)
+	if len(ids) > u.config.MaxBatchSize {
+		return nil, &BatchTooLargeError{Max: u.config.MaxBatchSize}
+	}
+
+	books, err := u.repository.ListBooksByIds(ctx, ids)
+	if err != nil {
+		log.Printf("Unable to execute BookUsecaseFetchBooksByIds: %d\n", err)
+		return nil, err
+	}
+
+	found := make(map[int32]db.Book, len(books))
+	for _, book := range books {
+		found[book.ID] = book
+	}
+	batch := &BookBatch{Books: make([]db.Book, 0, len(books)), MissingIDs: []int32{}}
+	for _, id := range ids {
+		if book, ok := found[id]; ok {
+			batch.Books = append(batch.Books, book)
+		} else {
+			batch.MissingIDs = append(batch.MissingIDs, id)
+		}
+	}
+
+	return batch, nil
+}
+
+// uniqueIds は重複したIDを最初の1件だけ残して取り除く
+func uniqueIds(ids []int32) []int32 {
+	seen := make(map[int32]bool, len(ids))
+	unique := make([]int32, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	return unique
+}
+
+func (u *bookUsecaseImpl) FetchBooksByAuthors(ctx context.Context, authors []string) ([]db.Book, error) {
+	books, err := u.repository.ListBooksByAuthors(ctx, authors)
+	if err != nil {
+		log.Printf("Unable to execute BookUsecaseFetchBooksByAuthors: %d\n", err)
+		return nil, err
+	}
+
+	return books, nil
+}
+
+func (u *bookUsecaseImpl) FetchBooksByPublishers(ctx context.Context, publishers []string) ([]db.Book, error) {
+	books, err := u.repository.ListBooksByPublishers(ctx, publishers)
+	if err != nil {
+		log.Printf("Unable to execute BookUsecaseFetchBooksByPublishers: %d\n", err)
+		return nil, err
+	}
+
+	return books, nil
+}
+
+func (u *bookUsecaseImpl) FetchBooksByStock(ctx context.Context, inStock bool) ([]db.Book, error) {
+	books, err := u.repository.ListBooksByStock(ctx, inStock)
+	if err != nil {
+		log.Printf("Unable to execute BookUsecaseFetchBooksByStock: %d\n", err)
+		return nil, err
+	}
+
+	return books, nil
+}
+
+// FilterBooks はタグ名を正規化して重複を取り除いてから、書籍を絞り込む
+func (u *bookUsecaseImpl) FilterBooks(ctx context.Context, param *db.FilterBooksParams) ([]db.Book, error) {
+	filter := *param
+	if filter.Tags != nil {
+		filter.Tags = normalizeTagNames(filter.Tags)
+	}
+
+	books, err := u.repository.FilterBooks(ctx, &filter)
+	if err != nil {
+		log.Printf("Unable to execute BookUsecaseFilterBooks: %d\n", err)
+		return nil, err
+	}
+
+	return books, nil
+}
+
+// FetchBookRatings は書籍IDをキーとして、書籍ごとのレビュー件数と評価の合計を返す
+func (u *bookUsecaseImpl) FetchBookRatings(ctx context.Context, bookIds []int32) (map[int32]db.BookRating, error) {
+	ratings, err := u.repository.ListBookRatings(ctx, bookIds)
+	if err != nil {
+		log.Printf("Unable to execute BookUsecaseFetchBookRatings: %d\n", err)
+		return nil, err
+	}
+
+	res := make(map[int32]db.BookRating, len(ratings))
+	for _, rating := range ratings {
+		res[rating.BookID] = rating
+	}
+
+	return res, nil
+}
+
+// MergeBook は統合元の書籍を統合先へまとめ、統合先の書籍を返す
+// 統合と同じトランザクションで、統合元のBookDeletedイベントと統合先のBookUpdatedイベントをアウトボックスに登録する
+func (u *bookUsecaseImpl) MergeBook(ctx context.Context, sourceId int, targetId int) (*db.Book, error) {
+	if sourceId == targetId {
+		return nil, ErrMergeIntoSelf
+	}
+
+	var book *db.Book
+	err := u.repository.WithTx(ctx, func(repo repository.BookRepository) error {
+		var err error
+		book, err = repo.MergeBook(ctx, sourceId, targetId)
+		if err != nil {
+			return err
+		}
+		deleted, err := event.NewBookMerged(int32(sourceId), book.ID)
+		if err != nil {
+			return err
+		}
+		updated, err := event.NewBookUpdated(book)
+		if err != nil {
+			return err
+		}
+
+		return repo.AddEvents(ctx, deleted, updated)
+	})
+	if err != nil {
+		log.Printf("Unable to execute BookUsecaseMergeBook: %d\n", err)
+		return nil, err
+	}
+
+	return book, nil
+}
+
+// FetchBookAuditLogs は書籍の監査ログを新しい順に返す
+// 一括削除した書籍の監査ログも返せるよう、監査ログが無く書籍も存在しない場合のみpgx.ErrNoRowsとする
+// 統合元の監査ログは統合先へ付け替えるため、統合元の書籍もpgx.ErrNoRowsとなる
+func (u *bookUsecaseImpl) FetchBookAuditLogs(ctx context.Context, bookId int) ([]db.BookAuditLog, error) {
+	logs, err := u.repository.ListBookAuditLogs(ctx, bookId)
+	if err != nil {
+		log.Printf("Unable to execute BookUsecaseFetchBookAuditLogs: %d\n", err)
+		return nil, err
+	}
+	if len(logs) > 0 {
+		return logs, nil
+	}
+
+	if _, err := u.repository.GetBookById(ctx, bookId); err != nil {
+		log.Printf("Unable to execute BookUsecaseFetchBookAuditLogs: %d\n", err)
+		return nil, err
+	}
+
+	return logs, nil
+}
+
+// FetchBookCollectionVersion は一覧に関わる表の版と、有効になった価格などの切り替わり日時から、一覧のキャッシュの検証に用いる情報を返す
+// variantは絞り込みや通貨など一覧の内容を変えるクエリパラメータを正規化したもので、ETagにハッシュとして含める
+// 本文のバイト列の一致までは保証しないため、ETagは弱い検証子とする
+func (u *bookUsecaseImpl) FetchBookCollectionVersion(ctx context.Context, variant string) (*BookCollectionVersion, error) {
+	version, err := u.repository.GetBookCollectionVersion(ctx)
+	if err != nil {
+		log.Printf("Unable to execute BookUsecaseFetchBookCollectionVersion: %d\n", err)
+		return nil, err
+	}
+
+	lastEffectiveAt := version.LastEffectiveAt.Time.UTC()
+	lastModified := version.UpdatedAt.Time.UTC()
+	if lastEffectiveAt.After(lastModified) {
+		lastModified = lastEffectiveAt
+	}
+	h := fnv.New64a()
+	h.Write([]byte(variant))
+	return &BookCollectionVersion{
+		ETag:         fmt.Sprintf(`W/"books-%d-%d-%x"`, version.Version, lastEffectiveAt.UnixMicro(), h.Sum64()),
+		LastModified: lastModified,
+	}, nil
+}
@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"context"
+	"log"
+
+	"github.com/rentaro-m-b/ai-model-exam/event"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+)
+
+const (
+	bookEventBatchSize = 100
+)
+
+type BookEventUsecase interface {
+	Subscribe() *event.Subscription
+	Unsubscribe(sub *event.Subscription)
+	FetchEventsAfter(ctx context.Context, after event.Cursor) ([]event.Event, error)
+}
+
+type bookEventUsecaseImpl struct {
+	repository repository.OutboxRepository
+	broker     *event.Broker
+}
+
+func NewBookEventUsecase(repository repository.OutboxRepository, broker *event.Broker) BookEventUsecase {
+	return &bookEventUsecaseImpl{
+		repository: repository,
+		broker:     broker,
+	}
+}
+
+// Subscribe は以降に登録される書籍の変更イベントの購読を始める
+func (u *bookEventUsecaseImpl) Subscribe() *event.Subscription {
+	return u.broker.Subscribe()
+}
+
+func (u *bookEventUsecaseImpl) Unsubscribe(sub *event.Subscription) {
+	u.broker.Unsubscribe(sub)
+}
+
+// FetchEventsAfter はafterより後の位置のイベントを最大100件返す
+func (u *bookEventUsecaseImpl) FetchEventsAfter(ctx context.Context, after event.Cursor) ([]event.Event, error) {
+	events, err := u.repository.ListEventsAfter(ctx, after, bookEventBatchSize)
+	if err != nil {
+		log.Printf("Unable to execute BookEventUsecaseFetchEventsAfter: %d\n", err)
+		return nil, err
+	}
+
+	return events, nil
+}
@@ -0,0 +1,43 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rentaro-m-b/ai-model-exam/event"
+	mock_repository "github.com/rentaro-m-b/ai-model-exam/repository/mock"
+	"github.com/rentaro-m-b/ai-model-exam/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFetchEventsAfter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockOutboxRepository(ctrl)
+	uc := usecase.NewBookEventUsecase(mockRepo, event.NewBroker(1))
+
+	expects := []event.Event{{ID: 6, Type: event.TypeBookUpdated, BookID: 1}}
+	mockRepo.EXPECT().ListEventsAfter(gomock.Any(), event.Cursor{TxID: 100, ID: 5}, 100).Return(expects, nil)
+
+	events, err := uc.FetchEventsAfter(context.Background(), event.Cursor{TxID: 100, ID: 5})
+	assert.NoError(t, err)
+	assert.Equal(t, expects, events)
+}
+
+func TestSubscribeBookEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	broker := event.NewBroker(1)
+	uc := usecase.NewBookEventUsecase(mock_repository.NewMockOutboxRepository(ctrl), broker)
+
+	sub := uc.Subscribe()
+	broker.Publish(event.Event{ID: 1})
+	assert.Equal(t, int64(1), (<-sub.Events()).ID)
+
+	uc.Unsubscribe(sub)
+	_, ok := <-sub.Events()
+	assert.False(t, ok)
+}
@@ -4,10 +4,14 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/event"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
 	mock_repository "github.com/rentaro-m-b/ai-model-exam/repository/mock"
 	"github.com/rentaro-m-b/ai-model-exam/usecase"
 	"github.com/stretchr/testify/assert"
@@ -36,7 +40,7 @@ func TestFetchBooks(t *testing.T) {
 	}
 	mockRepo.EXPECT().ListBooks(gomock.Any()).Return(expects, nil)
 
-	uc := usecase.NewBookUsecase(mockRepo)
+	uc := usecase.NewBookUsecase(mockRepo, nil)
 	books, err := uc.FetchBooks(context.Background())
 	assert.NoError(t, err)
 	assert.Equal(t, expects, books)
@@ -47,7 +51,7 @@ func TestListBooksFailure(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mock_repository.NewMockBookRepository(ctrl)
-	uc := usecase.NewBookUsecase(mockRepo)
+	uc := usecase.NewBookUsecase(mockRepo, nil)
 
 	mockRepo.EXPECT().ListBooks(gomock.Any()).Return(nil, errors.New("error"))
 
@@ -56,12 +60,21 @@ func TestListBooksFailure(t *testing.T) {
 	assert.Nil(t, books)
 }
 
+// expectBookTx はWithTxに渡された関数を、同じモックをトランザクション内のリポジトリとして実行させる
+func expectBookTx(mockRepo *mock_repository.MockBookRepository) {
+	mockRepo.EXPECT().WithTx(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(repo repository.BookRepository) error) error {
+			return fn(mockRepo)
+		},
+	)
+}
+
 func TestCreateBook(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockRepo := mock_repository.NewMockBookRepository(ctrl)
-	uc := usecase.NewBookUsecase(mockRepo)
+	uc := usecase.NewBookUsecase(mockRepo, nil)
 
 	param := db.CreateBookParams{
 		Title:     pgtype.Text{String: "test title 1", Valid: true},
@@ -77,9 +90,27 @@ func TestCreateBook(t *testing.T) {
 		Price:     pgtype.Int4{Int32: 200, Valid: true},
 	}
 
+	mockRepo.EXPECT().ListDuplicateCandidates(gomock.Any(), &db.ListDuplicateBookCandidatesParams{
+		TitleAuthor:   "test title 1 test author 1",
+		MinSimilarity: 0.6,
+		MaxCandidates: 5,
+	}).Return([]db.Book{}, nil)
+	expectBookTx(mockRepo)
 	mockRepo.EXPECT().CreateBook(gomock.Any(), &param).Return(&expect, nil)
+	mockRepo.EXPECT().AddEvents(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, events ...event.Event) error {
+			assert.Len(t, events, 1)
+			assert.Equal(t, event.TypeBookCreated, events[0].Type)
+			assert.Equal(t, int32(1), events[0].BookID)
+			assert.JSONEq(t, `{
+				"id": 1, "title": "test title 1", "author": "test author 1", "publisher": "test publisher 1",
+				"price": 200, "isbn": null, "work_id": null
+			}`, string(events[0].Payload))
+			return nil
+		},
+	)
 
-	book, err := uc.CreateBook(context.Background(), &param)
+	book, err := uc.CreateBook(context.Background(), &param, false)
 	assert.NoError(t, err)
 	assert.Equal(t, &expect, book)
 }
@@ -89,7 +120,7 @@ func TestCreateBookFailure(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mock_repository.NewMockBookRepository(ctrl)
-	uc := usecase.NewBookUsecase(mockRepo)
+	uc := usecase.NewBookUsecase(mockRepo, nil)
 
 	param := db.CreateBookParams{
 		Title:     pgtype.Text{String: "test title 1", Valid: true},
@@ -98,19 +129,170 @@ func TestCreateBookFailure(t *testing.T) {
 		Price:     pgtype.Int4{Int32: 200, Valid: true},
 	}
 
+	expectBookTx(mockRepo)
 	mockRepo.EXPECT().CreateBook(gomock.Any(), &param).Return(nil, errors.New("error"))
 
-	book, err := uc.CreateBook(context.Background(), &param)
+	book, err := uc.CreateBook(context.Background(), &param, true)
 	assert.Error(t, err)
 	assert.Nil(t, book)
 }
 
+func TestCreateBookFailureDuplicate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewBookUsecase(mockRepo, nil)
+
+	param := db.CreateBookParams{
+		Title:  pgtype.Text{String: "  Test   Title 1", Valid: true},
+		Author: pgtype.Text{String: "Test Author 1 ", Valid: true},
+		Isbn:   pgtype.Text{String: "9784274217883", Valid: true},
+	}
+	candidates := []db.Book{{ID: 3, Isbn: param.Isbn}}
+
+	mockRepo.EXPECT().ListDuplicateCandidates(gomock.Any(), &db.ListDuplicateBookCandidatesParams{
+		Isbn:          param.Isbn,
+		TitleAuthor:   "test title 1 test author 1",
+		MinSimilarity: 0.6,
+		MaxCandidates: 5,
+	}).Return(candidates, nil)
+
+	book, err := uc.CreateBook(context.Background(), &param, false)
+	var duplicateErr *usecase.DuplicateBookError
+	assert.ErrorAs(t, err, &duplicateErr)
+	assert.Equal(t, candidates, duplicateErr.Candidates)
+	assert.Nil(t, book)
+}
+
+func TestCreateBookIdempotently(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewBookUsecase(mockRepo, &usecase.BookUsecaseConfig{IdempotencyKeyTTL: time.Hour})
+
+	param := db.CreateBookParams{
+		Title:  pgtype.Text{String: "test title 1", Valid: true},
+		Author: pgtype.Text{String: "test author 1", Valid: true},
+	}
+	expect := db.Book{ID: 1, Title: param.Title, Author: param.Author}
+
+	// 初回は登録し、登録と同じトランザクションで結果をキーに保存する
+	var saved db.CreateIdempotencyKeyParams
+	mockRepo.EXPECT().GetIdempotencyKey(gomock.Any(), "key-1").Return(nil, pgx.ErrNoRows)
+	expectBookTx(mockRepo)
+	mockRepo.EXPECT().CreateBook(gomock.Any(), &param).Return(&expect, nil)
+	mockRepo.EXPECT().AddEvents(gomock.Any(), gomock.Any()).Return(nil)
+	mockRepo.EXPECT().SaveIdempotencyKey(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, param *db.CreateIdempotencyKeyParams) (bool, error) {
+			saved = *param
+			return true, nil
+		},
+	)
+
+	creation, err := uc.CreateBookIdempotently(context.Background(), "key-1", &param, true)
+	assert.NoError(t, err)
+	assert.Equal(t, &usecase.IdempotentBookCreation{BookID: 1}, creation)
+	assert.Equal(t, "key-1", saved.IdempotencyKey)
+	assert.Len(t, saved.RequestHash, 64)
+	assert.Equal(t, int32(201), saved.ResponseStatus)
+	assert.Equal(t, int32(1), saved.BookID)
+	assert.Equal(t, float64(3600), saved.TtlSeconds)
+
+	// 同じ内容の再試行には登録せずに保存した結果を返す
+	stored := db.IdempotencyKey{IdempotencyKey: "key-1", RequestHash: saved.RequestHash, ResponseStatus: 201, BookID: 1}
+	mockRepo.EXPECT().GetIdempotencyKey(gomock.Any(), "key-1").Return(&stored, nil)
+
+	creation, err = uc.CreateBookIdempotently(context.Background(), "key-1", &param, true)
+	assert.NoError(t, err)
+	assert.Equal(t, &usecase.IdempotentBookCreation{BookID: 1, Replayed: true}, creation)
+
+	// 内容の異なるリクエストには使えない
+	mockRepo.EXPECT().GetIdempotencyKey(gomock.Any(), "key-1").Return(&stored, nil)
+
+	creation, err = uc.CreateBookIdempotently(context.Background(), "key-1", &param, false)
+	assert.ErrorIs(t, err, usecase.ErrIdempotencyKeyReused)
+	assert.Nil(t, creation)
+}
+
+func TestCreateBookIdempotentlyConcurrent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewBookUsecase(mockRepo, nil)
+
+	param := db.CreateBookParams{
+		Title:  pgtype.Text{String: "test title 1", Valid: true},
+		Author: pgtype.Text{String: "test author 1", Valid: true},
+	}
+
+	// 同じキーの登録が先にコミットされた場合は、登録を取り消してその結果を返す
+	var requestHash string
+	mockRepo.EXPECT().GetIdempotencyKey(gomock.Any(), "key-1").Return(nil, pgx.ErrNoRows)
+	expectBookTx(mockRepo)
+	mockRepo.EXPECT().CreateBook(gomock.Any(), &param).Return(&db.Book{ID: 2}, nil)
+	mockRepo.EXPECT().AddEvents(gomock.Any(), gomock.Any()).Return(nil)
+	mockRepo.EXPECT().SaveIdempotencyKey(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, param *db.CreateIdempotencyKeyParams) (bool, error) {
+			requestHash = param.RequestHash
+			return false, nil
+		},
+	)
+	mockRepo.EXPECT().GetIdempotencyKey(gomock.Any(), "key-1").DoAndReturn(
+		func(ctx context.Context, key string) (*db.IdempotencyKey, error) {
+			return &db.IdempotencyKey{IdempotencyKey: key, RequestHash: requestHash, ResponseStatus: 201, BookID: 1}, nil
+		},
+	)
+
+	creation, err := uc.CreateBookIdempotently(context.Background(), "key-1", &param, true)
+	assert.NoError(t, err)
+	assert.Equal(t, &usecase.IdempotentBookCreation{BookID: 1, Replayed: true}, creation)
+}
+
+func TestCreateBookIdempotentlyFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewBookUsecase(mockRepo, nil)
+
+	param := db.CreateBookParams{
+		Title:  pgtype.Text{String: "test title 1", Valid: true},
+		Author: pgtype.Text{String: "test author 1", Valid: true},
+	}
+
+	// 登録に失敗した場合はキーを保存しない
+	mockRepo.EXPECT().GetIdempotencyKey(gomock.Any(), "key-1").Return(nil, pgx.ErrNoRows)
+	expectBookTx(mockRepo)
+	mockRepo.EXPECT().CreateBook(gomock.Any(), &param).Return(nil, errors.New("error"))
+
+	creation, err := uc.CreateBookIdempotently(context.Background(), "key-1", &param, true)
+	assert.Error(t, err)
+	assert.Nil(t, creation)
+}
+
+func TestPurgeExpiredIdempotencyKeys(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewBookUsecase(mockRepo, nil)
+
+	mockRepo.EXPECT().DeleteExpiredIdempotencyKeys(gomock.Any()).Return(int64(3), nil)
+
+	n, err := uc.PurgeExpiredIdempotencyKeys(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+}
+
 func TestFindBookById(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockRepo := mock_repository.NewMockBookRepository(ctrl)
-	uc := usecase.NewBookUsecase(mockRepo)
+	uc := usecase.NewBookUsecase(mockRepo, nil)
 
 	id := 1
 	expect := db.Book{
@@ -133,7 +315,7 @@ func TestFindBookByIdFailure(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mock_repository.NewMockBookRepository(ctrl)
-	uc := usecase.NewBookUsecase(mockRepo)
+	uc := usecase.NewBookUsecase(mockRepo, nil)
 
 	id := 1
 
@@ -143,3 +325,503 @@ func TestFindBookByIdFailure(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, book)
 }
+
+func TestFindBookByIdMerged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewBookUsecase(mockRepo, nil)
+
+	mockRepo.EXPECT().GetBookById(gomock.Any(), 2).Return(nil, pgx.ErrNoRows)
+	mockRepo.EXPECT().GetBookRedirect(gomock.Any(), 2).Return(int32(1), nil)
+
+	book, err := uc.FindBookById(context.Background(), 2)
+	var mergedErr *usecase.BookMergedError
+	assert.ErrorAs(t, err, &mergedErr)
+	assert.Equal(t, int32(1), mergedErr.TargetID)
+	assert.Nil(t, book)
+}
+
+func TestFindBookByIdFailureNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewBookUsecase(mockRepo, nil)
+
+	mockRepo.EXPECT().GetBookById(gomock.Any(), 99).Return(nil, pgx.ErrNoRows)
+	mockRepo.EXPECT().GetBookRedirect(gomock.Any(), 99).Return(int32(0), pgx.ErrNoRows)
+
+	book, err := uc.FindBookById(context.Background(), 99)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+	assert.Nil(t, book)
+}
+
+func TestSearchBooks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewBookUsecase(mockRepo, nil)
+
+	param := db.SearchBooksParams{
+		Author: pgtype.Text{String: "test author 1", Valid: true},
+		Limit:  20,
+		Offset: 0,
+	}
+	expects := []db.Book{
+		{
+			ID:        1,
+			Title:     pgtype.Text{String: "test title 1", Valid: true},
+			Author:    pgtype.Text{String: "test author 1", Valid: true},
+			Publisher: pgtype.Text{String: "test publisher 1", Valid: true},
+			Price:     pgtype.Int4{Int32: 100, Valid: true},
+		},
+	}
+
+	mockRepo.EXPECT().SearchBooks(gomock.Any(), &param).Return(expects, nil)
+
+	books, err := uc.SearchBooks(context.Background(), &param)
+	assert.NoError(t, err)
+	assert.Equal(t, expects, books)
+}
+
+func TestSearchBooksFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewBookUsecase(mockRepo, nil)
+
+	param := db.SearchBooksParams{
+		Limit:  20,
+		Offset: 0,
+	}
+
+	mockRepo.EXPECT().SearchBooks(gomock.Any(), &param).Return(nil, errors.New("error"))
+
+	books, err := uc.SearchBooks(context.Background(), &param)
+	assert.Error(t, err)
+	assert.Nil(t, books)
+}
+
+func TestFetchBooksByIds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewBookUsecase(mockRepo, nil)
+
+	book1 := db.Book{ID: 1, Title: pgtype.Text{String: "test title 1", Valid: true}}
+	book3 := db.Book{ID: 3, Title: pgtype.Text{String: "test title 3", Valid: true}}
+
+	// 重複したIDは1件として問い合わせる
+	mockRepo.EXPECT().ListBooksByIds(gomock.Any(), []int32{3, 2, 1, 4}).Return([]db.Book{book1, book3}, nil)
+
+	// 書籍と見つからなかったIDを、指定した順に返す
+	batch, err := uc.FetchBooksByIds(context.Background(), []int32{3, 2, 3, 1, 4})
+	assert.NoError(t, err)
+	assert.Equal(t, &usecase.BookBatch{
+		Books:      []db.Book{book3, book1},
+		MissingIDs: []int32{2, 4},
+	}, batch)
+}
+
+func TestFetchBooksByIdsFailureTooLarge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewBookUsecase(mockRepo, &usecase.BookUsecaseConfig{MaxBatchSize: 2})
+
+	batch, err := uc.FetchBooksByIds(context.Background(), []int32{1, 2, 3})
+	var tooLargeErr *usecase.BatchTooLargeError
+	assert.ErrorAs(t, err, &tooLargeErr)
+	assert.Equal(t, 2, tooLargeErr.Max)
+	assert.Nil(t, batch)
+}
+
+func TestFetchBooksByIdsFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewBookUsecase(mockRepo, nil)
+
+	mockRepo.EXPECT().ListBooksByIds(gomock.Any(), []int32{1}).Return(nil, errors.New("error"))
+
+	batch, err := uc.FetchBooksByIds(context.Background(), []int32{1})
+	assert.Error(t, err)
+	assert.Nil(t, batch)
+}
+
+func TestFetchBooksByAuthors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewBookUsecase(mockRepo, nil)
+
+	authors := []string{"test author 1"}
+	expects := []db.Book{
+		{
+			ID:        1,
+			Title:     pgtype.Text{String: "test title 1", Valid: true},
+			Author:    pgtype.Text{String: "test author 1", Valid: true},
+			Publisher: pgtype.Text{String: "test publisher 1", Valid: true},
+			Price:     pgtype.Int4{Int32: 100, Valid: true},
+		},
+	}
+
+	mockRepo.EXPECT().ListBooksByAuthors(gomock.Any(), authors).Return(expects, nil)
+
+	books, err := uc.FetchBooksByAuthors(context.Background(), authors)
+	assert.NoError(t, err)
+	assert.Equal(t, expects, books)
+}
+
+func TestFetchBooksByPublishers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewBookUsecase(mockRepo, nil)
+
+	publishers := []string{"test publisher 1"}
+
+	mockRepo.EXPECT().ListBooksByPublishers(gomock.Any(), publishers).Return(nil, errors.New("error"))
+
+	books, err := uc.FetchBooksByPublishers(context.Background(), publishers)
+	assert.Error(t, err)
+	assert.Nil(t, books)
+}
+
+func TestFetchBooksByStock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewBookUsecase(mockRepo, nil)
+
+	expects := []db.Book{
+		{
+			ID:        1,
+			Title:     pgtype.Text{String: "test title 1", Valid: true},
+			Author:    pgtype.Text{String: "test author 1", Valid: true},
+			Publisher: pgtype.Text{String: "test publisher 1", Valid: true},
+			Price:     pgtype.Int4{Int32: 100, Valid: true},
+		},
+	}
+
+	mockRepo.EXPECT().ListBooksByStock(gomock.Any(), true).Return(expects, nil)
+
+	books, err := uc.FetchBooksByStock(context.Background(), true)
+	assert.NoError(t, err)
+	assert.Equal(t, expects, books)
+}
+
+func TestFilterBooksNormalizesTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewBookUsecase(mockRepo, nil)
+
+	expects := []db.Book{{ID: 1}}
+
+	// タグ名は小文字にして前後の空白を除き、空のものと重複を取り除く
+	mockRepo.EXPECT().FilterBooks(gomock.Any(), &db.FilterBooksParams{
+		Tags:         []string{"go", "database"},
+		MatchAllTags: true,
+	}).Return(expects, nil)
+
+	books, err := uc.FilterBooks(context.Background(), &db.FilterBooksParams{
+		Tags:         []string{"Go", " database ", "", "go"},
+		MatchAllTags: true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, expects, books)
+}
+
+func TestFetchBookRatings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewBookUsecase(mockRepo, nil)
+
+	mockRepo.EXPECT().ListBookRatings(gomock.Any(), []int32{1, 2}).Return([]db.BookRating{
+		{BookID: 2, ReviewCount: 3, RatingSum: 12},
+	}, nil)
+
+	// レビューの無い書籍はマップに含まれない
+	ratings, err := uc.FetchBookRatings(context.Background(), []int32{1, 2})
+	assert.NoError(t, err)
+	assert.Equal(t, map[int32]db.BookRating{
+		2: {BookID: 2, ReviewCount: 3, RatingSum: 12},
+	}, ratings)
+}
+
+func TestBulkUpdateBooks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewBookUsecase(mockRepo, nil)
+
+	filter := db.ListBooksByFilterParams{Publisher: pgtype.Text{String: "old publisher", Valid: true}}
+	patch := repository.BookPatch{Publisher: pgtype.Text{String: "new publisher", Valid: true}}
+	books := []db.Book{
+		{ID: 1, Publisher: pgtype.Text{String: "new publisher", Valid: true}},
+		{ID: 3, Publisher: pgtype.Text{String: "new publisher", Valid: true}},
+	}
+	expectBookTx(mockRepo)
+	mockRepo.EXPECT().BulkUpdateBooks(gomock.Any(), &filter, &patch).Return(books, nil)
+	mockRepo.EXPECT().AddEvents(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, events ...event.Event) error {
+			assert.Equal(t, event.TypeBookUpdated, events[0].Type)
+			assert.Equal(t, int32(1), events[0].BookID)
+			assert.Equal(t, event.TypeBookUpdated, events[1].Type)
+			assert.Equal(t, int32(3), events[1].BookID)
+			return nil
+		},
+	)
+
+	res, err := uc.BulkUpdateBooks(context.Background(), &filter, &patch, false)
+	assert.NoError(t, err)
+	assert.Equal(t, &usecase.BulkResult{Affected: 2, BookIDs: []int32{1, 3}}, res)
+}
+
+func TestBulkUpdateBooksDryRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewBookUsecase(mockRepo, nil)
+
+	filter := db.ListBooksByFilterParams{Ids: []int32{1, 2}}
+	patch := repository.BookPatch{Price: pgtype.Int4{Int32: 300, Valid: true}}
+	// 更新せずに、対象になる書籍のみを返す
+	mockRepo.EXPECT().ListBooksByFilter(gomock.Any(), &filter).Return([]db.Book{{ID: 1}}, nil)
+
+	res, err := uc.BulkUpdateBooks(context.Background(), &filter, &patch, true)
+	assert.NoError(t, err)
+	assert.Equal(t, &usecase.BulkResult{Affected: 1, BookIDs: []int32{1}, DryRun: true}, res)
+}
+
+func TestBulkUpdateBooksFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewBookUsecase(mockRepo, nil)
+
+	filter := db.ListBooksByFilterParams{Ids: []int32{1}}
+	patch := repository.BookPatch{Price: pgtype.Int4{Int32: 300, Valid: true}}
+	expectBookTx(mockRepo)
+	mockRepo.EXPECT().BulkUpdateBooks(gomock.Any(), &filter, &patch).Return(nil, errors.New("error"))
+
+	res, err := uc.BulkUpdateBooks(context.Background(), &filter, &patch, false)
+	assert.Error(t, err)
+	assert.Nil(t, res)
+}
+
+func TestDeleteBooks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewBookUsecase(mockRepo, nil)
+
+	filter := db.ListBooksByFilterParams{Author: pgtype.Text{String: "test author", Valid: true}}
+	expectBookTx(mockRepo)
+	mockRepo.EXPECT().DeleteBooks(gomock.Any(), &filter).Return([]db.Book{{ID: 2}}, nil)
+	mockRepo.EXPECT().AddEvents(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, events ...event.Event) error {
+			assert.Equal(t, event.TypeBookDeleted, events[0].Type)
+			assert.Equal(t, int32(2), events[0].BookID)
+			assert.JSONEq(t, `{"id": 2, "merged_into": null}`, string(events[0].Payload))
+			return nil
+		},
+	)
+
+	res, err := uc.DeleteBooks(context.Background(), &filter, false)
+	assert.NoError(t, err)
+	assert.Equal(t, &usecase.BulkResult{Affected: 1, BookIDs: []int32{2}}, res)
+}
+
+func TestDeleteBooksDryRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewBookUsecase(mockRepo, nil)
+
+	filter := db.ListBooksByFilterParams{Author: pgtype.Text{String: "test author", Valid: true}}
+	// 削除せずに、対象になる書籍のみを返す
+	mockRepo.EXPECT().ListBooksByFilter(gomock.Any(), &filter).Return([]db.Book{}, nil)
+
+	res, err := uc.DeleteBooks(context.Background(), &filter, true)
+	assert.NoError(t, err)
+	assert.Equal(t, &usecase.BulkResult{Affected: 0, BookIDs: []int32{}, DryRun: true}, res)
+}
+
+func TestMergeBook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewBookUsecase(mockRepo, nil)
+
+	expect := db.Book{
+		ID:        1,
+		Title:     pgtype.Text{String: "test title 1", Valid: true},
+		Author:    pgtype.Text{String: "test author 1", Valid: true},
+		Publisher: pgtype.Text{String: "test publisher 1", Valid: true},
+		Price:     pgtype.Int4{Int32: 100, Valid: true},
+	}
+	expectBookTx(mockRepo)
+	mockRepo.EXPECT().MergeBook(gomock.Any(), 2, 1).Return(&expect, nil)
+	mockRepo.EXPECT().AddEvents(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, events ...event.Event) error {
+			assert.Equal(t, event.TypeBookDeleted, events[0].Type)
+			assert.Equal(t, int32(2), events[0].BookID)
+			assert.JSONEq(t, `{"id": 2, "merged_into": 1}`, string(events[0].Payload))
+			assert.Equal(t, event.TypeBookUpdated, events[1].Type)
+			assert.Equal(t, int32(1), events[1].BookID)
+			return nil
+		},
+	)
+
+	book, err := uc.MergeBook(context.Background(), 2, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, &expect, book)
+}
+
+func TestMergeBookFailureSelf(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewBookUsecase(mockRepo, nil)
+
+	book, err := uc.MergeBook(context.Background(), 1, 1)
+	assert.ErrorIs(t, err, usecase.ErrMergeIntoSelf)
+	assert.Nil(t, book)
+}
+
+func TestUpdateBookFailureEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewBookUsecase(mockRepo, nil)
+
+	param := db.UpdateBookParams{
+		ID:    1,
+		Title: pgtype.Text{String: "test title 2", Valid: true},
+	}
+	book := db.Book{
+		ID:    1,
+		Title: pgtype.Text{String: "test title 2", Valid: true},
+	}
+	expectBookTx(mockRepo)
+	mockRepo.EXPECT().UpdateBook(gomock.Any(), &param).Return(&book, nil)
+	mockRepo.EXPECT().AddEvents(gomock.Any(), gomock.Any()).Return(errors.New("error"))
+
+	res, err := uc.UpdateBook(context.Background(), &param)
+	assert.Error(t, err)
+	assert.Nil(t, res)
+}
+
+func TestFetchBookAuditLogsDeletedBook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewBookUsecase(mockRepo, nil)
+
+	// 一括削除した書籍は存在しなくても監査ログを返す
+	expect := []db.BookAuditLog{{ID: 1, BookID: 1, Action: repository.BookAuditActionBulkDelete}}
+	mockRepo.EXPECT().ListBookAuditLogs(gomock.Any(), 1).Return(expect, nil)
+
+	logs, err := uc.FetchBookAuditLogs(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, expect, logs)
+}
+
+func TestFetchBookAuditLogsNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewBookUsecase(mockRepo, nil)
+
+	// 監査ログが無く書籍も存在しない場合はpgx.ErrNoRowsを返す
+	mockRepo.EXPECT().ListBookAuditLogs(gomock.Any(), 99).Return([]db.BookAuditLog{}, nil)
+	mockRepo.EXPECT().GetBookById(gomock.Any(), 99).Return(nil, pgx.ErrNoRows)
+
+	logs, err := uc.FetchBookAuditLogs(context.Background(), 99)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+	assert.Nil(t, logs)
+}
+
+func TestFetchBookCollectionVersion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewBookUsecase(mockRepo, nil)
+
+	updatedAt := time.Date(2024, 7, 1, 9, 0, 0, 123456000, time.FixedZone("JST", 9*60*60))
+	lastEffectiveAt := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	mockRepo.EXPECT().GetBookCollectionVersion(gomock.Any()).Return(&db.GetBookCollectionVersionRow{
+		Version:         42,
+		UpdatedAt:       pgtype.Timestamptz{Time: updatedAt, Valid: true},
+		LastEffectiveAt: pgtype.Timestamptz{Time: lastEffectiveAt, Valid: true},
+	}, nil).Times(3)
+
+	version, err := uc.FetchBookCollectionVersion(context.Background(), "in_stock=true")
+	assert.NoError(t, err)
+	assert.Regexp(t, `^W/"books-42-1717200000000000-[0-9a-f]+"$`, version.ETag)
+	assert.Equal(t, time.Date(2024, 7, 1, 0, 0, 0, 123456000, time.UTC), version.LastModified)
+
+	// クエリパラメータが異なる一覧には別のETagを返す
+	same, err := uc.FetchBookCollectionVersion(context.Background(), "in_stock=true")
+	assert.NoError(t, err)
+	assert.Equal(t, version.ETag, same.ETag)
+	other, err := uc.FetchBookCollectionVersion(context.Background(), "in_stock=false")
+	assert.NoError(t, err)
+	assert.NotEqual(t, version.ETag, other.ETag)
+}
+
+func TestFetchBookCollectionVersionPriceTakesEffect(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewBookUsecase(mockRepo, nil)
+
+	// 予約していた価格が有効になると、表の変更が無くても版が変わる
+	updatedAt := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	effectiveAt := time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC)
+	mockRepo.EXPECT().GetBookCollectionVersion(gomock.Any()).Return(&db.GetBookCollectionVersionRow{
+		Version:         42,
+		UpdatedAt:       pgtype.Timestamptz{Time: updatedAt, Valid: true},
+		LastEffectiveAt: pgtype.Timestamptz{Time: time.Unix(0, 0), Valid: true},
+	}, nil)
+	mockRepo.EXPECT().GetBookCollectionVersion(gomock.Any()).Return(&db.GetBookCollectionVersionRow{
+		Version:         42,
+		UpdatedAt:       pgtype.Timestamptz{Time: updatedAt, Valid: true},
+		LastEffectiveAt: pgtype.Timestamptz{Time: effectiveAt, Valid: true},
+	}, nil)
+
+	before, err := uc.FetchBookCollectionVersion(context.Background(), "")
+	assert.NoError(t, err)
+	after, err := uc.FetchBookCollectionVersion(context.Background(), "")
+	assert.NoError(t, err)
+	assert.NotEqual(t, before.ETag, after.ETag)
+	assert.Equal(t, updatedAt, before.LastModified)
+	assert.Equal(t, effectiveAt, after.LastModified)
+}
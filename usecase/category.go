@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+)
+
+type CategoryUsecase interface {
+	CreateCategory(ctx context.Context, param *db.CreateCategoryParams) (*db.Category, error)
+	FindCategoryById(ctx context.Context, id int) (*CategoryDetail, error)
+	FetchCategoryTree(ctx context.Context) ([]db.ListCategoryTreeRow, error)
+	RenameCategory(ctx context.Context, id int, name string) (*db.Category, error)
+	MoveCategory(ctx context.Context, id int, parentId pgtype.Int4) (*db.Category, error)
+	DeleteCategory(ctx context.Context, id int) error
+	FetchBookCategories(ctx context.Context, bookId int) ([]db.Category, error)
+	SetBookCategories(ctx context.Context, bookId int, categoryIds []int32) ([]db.Category, error)
+}
+
+// CategoryDetail はカテゴリと、ルートから順に並べた祖先、直下の子カテゴリを表す
+type CategoryDetail struct {
+	Category  db.Category
+	Ancestors []db.Category
+	Children  []db.Category
+}
+
+type categoryUsecaseImpl struct {
+	repository     repository.CategoryRepository
+	bookRepository repository.BookRepository
+}
+
+func NewCategoryUsecase(repository repository.CategoryRepository, bookRepository repository.BookRepository) CategoryUsecase {
+	return &categoryUsecaseImpl{
+		repository:     repository,
+		bookRepository: bookRepository,
+	}
+}
+
+func (u *categoryUsecaseImpl) CreateCategory(ctx context.Context, param *db.CreateCategoryParams) (*db.Category, error) {
+	if param.ParentID.Valid {
+		if _, err := u.repository.GetCategoryById(ctx, int(param.ParentID.Int32)); err != nil {
+			log.Printf("Unable to execute CategoryUsecaseCreateCategory: %d\n", err)
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, repository.ErrParentCategoryNotFound
+			}
+			return nil, err
+		}
+	}
+
+	category, err := u.repository.CreateCategory(ctx, param)
+	if err != nil {
+		log.Printf("Unable to execute CategoryUsecaseCreateCategory: %d\n", err)
+		return nil, err
+	}
+
+	return category, nil
+}
+
+func (u *categoryUsecaseImpl) FindCategoryById(ctx context.Context, id int) (*CategoryDetail, error) {
+	category, err := u.repository.GetCategoryById(ctx, id)
+	if err != nil {
+		log.Printf("Unable to execute CategoryUsecaseFindCategoryById: %d\n", err)
+		return nil, err
+	}
+
+	ancestors, err := u.repository.ListCategoryAncestors(ctx, id)
+	if err != nil {
+		log.Printf("Unable to execute CategoryUsecaseFindCategoryById: %d\n", err)
+		return nil, err
+	}
+
+	children, err := u.repository.ListChildCategories(ctx, id)
+	if err != nil {
+		log.Printf("Unable to execute CategoryUsecaseFindCategoryById: %d\n", err)
+		return nil, err
+	}
+
+	return &CategoryDetail{
+		Category:  *category,
+		Ancestors: ancestors,
+		Children:  children,
+	}, nil
+}
+
+func (u *categoryUsecaseImpl) FetchCategoryTree(ctx context.Context) ([]db.ListCategoryTreeRow, error) {
+	categories, err := u.repository.ListCategoryTree(ctx)
+	if err != nil {
+		log.Printf("Unable to execute CategoryUsecaseFetchCategoryTree: %d\n", err)
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+func (u *categoryUsecaseImpl) RenameCategory(ctx context.Context, id int, name string) (*db.Category, error) {
+	category, err := u.repository.RenameCategory(ctx, id, name)
+	if err != nil {
+		log.Printf("Unable to execute CategoryUsecaseRenameCategory: %d\n", err)
+		return nil, err
+	}
+
+	return category, nil
+}
+
+func (u *categoryUsecaseImpl) MoveCategory(ctx context.Context, id int, parentId pgtype.Int4) (*db.Category, error) {
+	category, err := u.repository.MoveCategory(ctx, id, parentId)
+	if err != nil {
+		log.Printf("Unable to execute CategoryUsecaseMoveCategory: %d\n", err)
+		return nil, err
+	}
+
+	return category, nil
+}
+
+func (u *categoryUsecaseImpl) DeleteCategory(ctx context.Context, id int) error {
+	if err := u.repository.DeleteCategory(ctx, id); err != nil {
+		log.Printf("Unable to execute CategoryUsecaseDeleteCategory: %d\n", err)
+		return err
+	}
+
+	return nil
+}
+
+func (u *categoryUsecaseImpl) FetchBookCategories(ctx context.Context, bookId int) ([]db.Category, error) {
+	if _, err := u.bookRepository.GetBookById(ctx, bookId); err != nil {
+		log.Printf("Unable to execute CategoryUsecaseFetchBookCategories: %d\n", err)
+		return nil, err
+	}
+
+	categories, err := u.repository.ListBookCategories(ctx, bookId)
+	if err != nil {
+		log.Printf("Unable to execute CategoryUsecaseFetchBookCategories: %d\n", err)
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+func (u *categoryUsecaseImpl) SetBookCategories(ctx context.Context, bookId int, categoryIds []int32) ([]db.Category, error) {
+	categories, err := u.repository.SetBookCategories(ctx, bookId, categoryIds)
+	if err != nil {
+		log.Printf("Unable to execute CategoryUsecaseSetBookCategories: %d\n", err)
+		return nil, err
+	}
+
+	return categories, nil
+}
@@ -0,0 +1,73 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	mock_repository "github.com/rentaro-m-b/ai-model-exam/repository/mock"
+	"github.com/rentaro-m-b/ai-model-exam/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateCategoryFailureParentNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockCategoryRepository(ctrl)
+	mockBookRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewCategoryUsecase(mockRepo, mockBookRepo)
+
+	mockRepo.EXPECT().GetCategoryById(gomock.Any(), 99).Return(nil, pgx.ErrNoRows)
+
+	category, err := uc.CreateCategory(context.Background(), &db.CreateCategoryParams{
+		Name:     "mystery",
+		ParentID: pgtype.Int4{Int32: 99, Valid: true},
+	})
+	assert.ErrorIs(t, err, repository.ErrParentCategoryNotFound)
+	assert.Nil(t, category)
+}
+
+func TestFindCategoryById(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockCategoryRepository(ctrl)
+	mockBookRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewCategoryUsecase(mockRepo, mockBookRepo)
+
+	category := db.Category{ID: 2, Name: "mystery", ParentID: pgtype.Int4{Int32: 1, Valid: true}}
+	ancestors := []db.Category{{ID: 1, Name: "fiction"}}
+	children := []db.Category{{ID: 3, Name: "cozy", ParentID: pgtype.Int4{Int32: 2, Valid: true}}}
+
+	mockRepo.EXPECT().GetCategoryById(gomock.Any(), 2).Return(&category, nil)
+	mockRepo.EXPECT().ListCategoryAncestors(gomock.Any(), 2).Return(ancestors, nil)
+	mockRepo.EXPECT().ListChildCategories(gomock.Any(), 2).Return(children, nil)
+
+	detail, err := uc.FindCategoryById(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.Equal(t, &usecase.CategoryDetail{
+		Category:  category,
+		Ancestors: ancestors,
+		Children:  children,
+	}, detail)
+}
+
+func TestFetchBookCategoriesFailureBookNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockCategoryRepository(ctrl)
+	mockBookRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewCategoryUsecase(mockRepo, mockBookRepo)
+
+	mockBookRepo.EXPECT().GetBookById(gomock.Any(), 99).Return(nil, pgx.ErrNoRows)
+
+	categories, err := uc.FetchBookCategories(context.Background(), 99)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+	assert.Nil(t, categories)
+}
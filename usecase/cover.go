@@ -0,0 +1,202 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	"github.com/rentaro-m-b/ai-model-exam/storage"
+	"github.com/rentaro-m-b/ai-model-exam/thumbnail"
+)
+
+var (
+	ErrUnsupportedImageType = errors.New("unsupported image type")
+	ErrInvalidImage         = errors.New("invalid image")
+	ErrImageTooLarge        = errors.New("image dimensions too large")
+	ErrUnsupportedCoverSize = errors.New("unsupported cover size")
+)
+
+const (
+	// CoverSizeOriginal はアップロードされたままの表紙画像を表す大きさの名前
+	CoverSizeOriginal = "original"
+
+	// maxCoverPixels は表紙画像の画素数の上限
+	// 展開後のメモリ使用量を抑えるため、デコード前に画像の大きさを確認する
+	maxCoverPixels = 25_000_000
+)
+
+// coverContentTypes は表紙画像として受け付ける画像の形式
+var coverContentTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+type CoverUsecase interface {
+	UploadCover(ctx context.Context, bookId int, data []byte) (*db.BookCover, error)
+	FetchCover(ctx context.Context, bookId int, size string) (*CoverImage, error)
+}
+
+// CoverImage は配信する表紙画像のデータと、キャッシュの検証に用いる情報を表す
+type CoverImage struct {
+	Data        []byte
+	ContentType string
+	ETag        string
+	UpdatedAt   time.Time
+}
+
+type coverUsecaseImpl struct {
+	repository     repository.CoverRepository
+	bookRepository repository.BookRepository
+	blobs          storage.BlobStorage
+}
+
+func NewCoverUsecase(repository repository.CoverRepository, bookRepository repository.BookRepository, blobs storage.BlobStorage) CoverUsecase {
+	return &coverUsecaseImpl{
+		repository:     repository,
+		bookRepository: bookRepository,
+		blobs:          blobs,
+	}
+}
+
+// coverKey は表紙画像の保存先のキーを返す
+// キーにチェックサムを含めることで、置き換え中でも登録済みの情報と異なる画像を配信しないようにする
+func coverKey(bookId int32, checksum string, size string) string {
+	return fmt.Sprintf("covers/%d/%s/%s", bookId, checksum, size)
+}
+
+// UploadCover は画像の形式を内容から判定し、元の画像と各大きさのサムネイルを保存してから表紙画像の情報を登録する
+// 置き換えた場合は以前の画像を削除する
+func (u *coverUsecaseImpl) UploadCover(ctx context.Context, bookId int, data []byte) (*db.BookCover, error) {
+	contentType := http.DetectContentType(data)
+	if !coverContentTypes[contentType] {
+		log.Printf("Unable to execute CoverUsecaseUploadCover: %d\n", ErrUnsupportedImageType)
+		return nil, ErrUnsupportedImageType
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		log.Printf("Unable to execute CoverUsecaseUploadCover: %d\n", err)
+		return nil, ErrInvalidImage
+	}
+	if cfg.Width*cfg.Height > maxCoverPixels {
+		log.Printf("Unable to execute CoverUsecaseUploadCover: %d\n", ErrImageTooLarge)
+		return nil, ErrImageTooLarge
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		log.Printf("Unable to execute CoverUsecaseUploadCover: %d\n", err)
+		return nil, ErrInvalidImage
+	}
+
+	if _, err := u.bookRepository.GetBookById(ctx, bookId); err != nil {
+		log.Printf("Unable to execute CoverUsecaseUploadCover: %d\n", err)
+		return nil, err
+	}
+
+	sum := sha256.Sum256(data)
+	checksum := hex.EncodeToString(sum[:])
+	if err := u.blobs.Put(ctx, coverKey(int32(bookId), checksum, CoverSizeOriginal), bytes.NewReader(data)); err != nil {
+		log.Printf("Unable to execute CoverUsecaseUploadCover: %d\n", err)
+		return nil, err
+	}
+	for _, size := range thumbnail.Sizes {
+		var buf bytes.Buffer
+		if err := thumbnail.Encode(&buf, thumbnail.Resize(img, size.MaxSide)); err != nil {
+			log.Printf("Unable to execute CoverUsecaseUploadCover: %d\n", err)
+			return nil, err
+		}
+		if err := u.blobs.Put(ctx, coverKey(int32(bookId), checksum, size.Name), &buf); err != nil {
+			log.Printf("Unable to execute CoverUsecaseUploadCover: %d\n", err)
+			return nil, err
+		}
+	}
+
+	cover, previous, err := u.repository.SaveCover(ctx, &db.UpsertBookCoverParams{
+		BookID:      int32(bookId),
+		ContentType: contentType,
+		Width:       int32(cfg.Width),
+		Height:      int32(cfg.Height),
+		ByteSize:    int32(len(data)),
+		Checksum:    checksum,
+	})
+	if err != nil {
+		log.Printf("Unable to execute CoverUsecaseUploadCover: %d\n", err)
+		return nil, err
+	}
+
+	if previous != "" && previous != checksum {
+		u.deleteCoverBlobs(ctx, cover.BookID, previous)
+	}
+
+	return cover, nil
+}
+
+// deleteCoverBlobs は置き換えられた画像を削除する
+// 削除に失敗しても表紙画像の登録は完了しているため、ログに記録するのみとする
+func (u *coverUsecaseImpl) deleteCoverBlobs(ctx context.Context, bookId int32, checksum string) {
+	keys := []string{coverKey(bookId, checksum, CoverSizeOriginal)}
+	for _, size := range thumbnail.Sizes {
+		keys = append(keys, coverKey(bookId, checksum, size.Name))
+	}
+	for _, key := range keys {
+		if err := u.blobs.Delete(ctx, key); err != nil {
+			log.Printf("Unable to execute CoverUsecaseDeleteCoverBlobs: %d\n", err)
+		}
+	}
+}
+
+// FetchCover は指定された大きさの表紙画像を返す
+// 大きさが空文字列の場合はアップロードされたままの画像を返し、サムネイルはJPEG形式となる
+func (u *coverUsecaseImpl) FetchCover(ctx context.Context, bookId int, size string) (*CoverImage, error) {
+	if size == "" {
+		size = CoverSizeOriginal
+	}
+	if _, ok := thumbnail.LookupSize(size); !ok && size != CoverSizeOriginal {
+		log.Printf("Unable to execute CoverUsecaseFetchCover: %d\n", ErrUnsupportedCoverSize)
+		return nil, ErrUnsupportedCoverSize
+	}
+
+	cover, err := u.repository.GetCover(ctx, bookId)
+	if err != nil {
+		log.Printf("Unable to execute CoverUsecaseFetchCover: %d\n", err)
+		return nil, err
+	}
+
+	r, err := u.blobs.Get(ctx, coverKey(cover.BookID, cover.Checksum, size))
+	if err != nil {
+		log.Printf("Unable to execute CoverUsecaseFetchCover: %d\n", err)
+		return nil, err
+	}
+	defer r.Close()
+	data, err := io.ReadAll(r)
+	if err != nil {
+		log.Printf("Unable to execute CoverUsecaseFetchCover: %d\n", err)
+		return nil, err
+	}
+
+	contentType := "image/jpeg"
+	if size == CoverSizeOriginal {
+		contentType = cover.ContentType
+	}
+
+	return &CoverImage{
+		Data:        data,
+		ContentType: contentType,
+		ETag:        fmt.Sprintf(`"%s-%s"`, cover.Checksum[:16], size),
+		UpdatedAt:   cover.UpdatedAt.Time,
+	}, nil
+}
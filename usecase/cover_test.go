@@ -0,0 +1,163 @@
+package usecase_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	mock_repository "github.com/rentaro-m-b/ai-model-exam/repository/mock"
+	"github.com/rentaro-m-b/ai-model-exam/storage"
+	"github.com/rentaro-m-b/ai-model-exam/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))))
+	return buf.Bytes()
+}
+
+func checksumOf(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestUploadCover(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	blobs, err := storage.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+	mockRepo := mock_repository.NewMockCoverRepository(ctrl)
+	mockBookRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewCoverUsecase(mockRepo, mockBookRepo, blobs)
+
+	// 以前の表紙画像を保存しておく
+	previous := []byte("previous cover")
+	previousChecksum := checksumOf(previous)
+	require.NoError(t, blobs.Put(ctx, "covers/1/"+previousChecksum+"/original", bytes.NewReader(previous)))
+
+	data := encodePNG(t, 800, 1200)
+	param := db.UpsertBookCoverParams{
+		BookID:      1,
+		ContentType: "image/png",
+		Width:       800,
+		Height:      1200,
+		ByteSize:    int32(len(data)),
+		Checksum:    checksumOf(data),
+	}
+	expect := db.BookCover{
+		BookID:      param.BookID,
+		ContentType: param.ContentType,
+		Width:       param.Width,
+		Height:      param.Height,
+		ByteSize:    param.ByteSize,
+		Checksum:    param.Checksum,
+	}
+	mockBookRepo.EXPECT().GetBookById(gomock.Any(), 1).Return(&db.Book{ID: 1}, nil)
+	mockRepo.EXPECT().SaveCover(gomock.Any(), &param).Return(&expect, previousChecksum, nil)
+
+	cover, err := uc.UploadCover(ctx, 1, data)
+	require.NoError(t, err)
+	assert.Equal(t, &expect, cover)
+
+	// サムネイルは長辺を各大きさに縮小したJPEGで保存される
+	r, err := blobs.Get(ctx, "covers/1/"+param.Checksum+"/medium")
+	require.NoError(t, err)
+	cfg, err := jpeg.DecodeConfig(r)
+	r.Close()
+	require.NoError(t, err)
+	assert.Equal(t, 200, cfg.Width)
+	assert.Equal(t, 300, cfg.Height)
+
+	// 置き換えられた画像は削除される
+	_, err = blobs.Get(ctx, "covers/1/"+previousChecksum+"/original")
+	assert.ErrorIs(t, err, storage.ErrBlobNotFound)
+}
+
+func TestUploadCoverFailureUnsupportedType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	blobs, err := storage.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+	mockRepo := mock_repository.NewMockCoverRepository(ctrl)
+	mockBookRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewCoverUsecase(mockRepo, mockBookRepo, blobs)
+
+	// 画像の形式はファイル名やContent-Typeではなく内容から判定する
+	_, err = uc.UploadCover(context.Background(), 1, []byte("<html><body>not an image</body></html>"))
+	assert.ErrorIs(t, err, usecase.ErrUnsupportedImageType)
+}
+
+func TestUploadCoverFailureInvalidImage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	blobs, err := storage.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+	mockRepo := mock_repository.NewMockCoverRepository(ctrl)
+	mockBookRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewCoverUsecase(mockRepo, mockBookRepo, blobs)
+
+	// PNGのシグネチャのみで中身が壊れている
+	_, err = uc.UploadCover(context.Background(), 1, []byte("\x89PNG\r\n\x1a\nbroken"))
+	assert.ErrorIs(t, err, usecase.ErrInvalidImage)
+}
+
+func TestFetchCover(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	blobs, err := storage.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+	mockRepo := mock_repository.NewMockCoverRepository(ctrl)
+	mockBookRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewCoverUsecase(mockRepo, mockBookRepo, blobs)
+
+	checksum := checksumOf([]byte("cover"))
+	updatedAt := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, blobs.Put(ctx, "covers/1/"+checksum+"/small", bytes.NewReader([]byte("small cover"))))
+	mockRepo.EXPECT().GetCover(gomock.Any(), 1).Return(&db.BookCover{
+		BookID:      1,
+		ContentType: "image/png",
+		Checksum:    checksum,
+		UpdatedAt:   pgtype.Timestamptz{Time: updatedAt, Valid: true},
+	}, nil)
+
+	cover, err := uc.FetchCover(ctx, 1, "small")
+	require.NoError(t, err)
+	assert.Equal(t, &usecase.CoverImage{
+		Data:        []byte("small cover"),
+		ContentType: "image/jpeg",
+		ETag:        `"` + checksum[:16] + `-small"`,
+		UpdatedAt:   updatedAt,
+	}, cover)
+}
+
+func TestFetchCoverFailureUnsupportedSize(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	blobs, err := storage.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+	mockRepo := mock_repository.NewMockCoverRepository(ctrl)
+	mockBookRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewCoverUsecase(mockRepo, mockBookRepo, blobs)
+
+	_, err = uc.FetchCover(context.Background(), 1, "huge")
+	assert.ErrorIs(t, err, usecase.ErrUnsupportedCoverSize)
+}
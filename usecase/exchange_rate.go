@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/money"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+)
+
+var ErrSameCurrencyPair = errors.New("base and quote currency must differ")
+
+type ExchangeRateUsecase interface {
+	ImportRates(ctx context.Context, rates []db.UpsertExchangeRateParams) (int, error)
+}
+
+type exchangeRateUsecaseImpl struct {
+	repository repository.ExchangeRateRepository
+}
+
+func NewExchangeRateUsecase(repository repository.ExchangeRateRepository) ExchangeRateUsecase {
+	return &exchangeRateUsecaseImpl{
+		repository: repository,
+	}
+}
+
+func (u *exchangeRateUsecaseImpl) ImportRates(ctx context.Context, rates []db.UpsertExchangeRateParams) (int, error) {
+	if err := validateExchangeRates(rates); err != nil {
+		log.Printf("Unable to execute ExchangeRateUsecaseImportRates: %d\n", err)
+		return 0, err
+	}
+
+	count, err := u.repository.ImportRates(ctx, rates)
+	if err != nil {
+		log.Printf("Unable to execute ExchangeRateUsecaseImportRates: %d\n", err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// validateExchangeRates は通貨がいずれも対応しており、基準通貨と相手通貨が異なることを確認する
+func validateExchangeRates(rates []db.UpsertExchangeRateParams) error {
+	for _, rate := range rates {
+		if _, ok := money.LookupCurrency(rate.BaseCurrency); !ok {
+			return ErrUnsupportedCurrency
+		}
+		if _, ok := money.LookupCurrency(rate.QuoteCurrency); !ok {
+			return ErrUnsupportedCurrency
+		}
+		if rate.BaseCurrency == rate.QuoteCurrency {
+			return ErrSameCurrencyPair
+		}
+	}
+
+	return nil
+}
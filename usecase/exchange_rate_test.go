@@ -0,0 +1,76 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	mock_repository "github.com/rentaro-m-b/ai-model-exam/repository/mock"
+	"github.com/rentaro-m-b/ai-model-exam/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportRates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockExchangeRateRepository(ctrl)
+	uc := usecase.NewExchangeRateUsecase(mockRepo)
+
+	rates := []db.UpsertExchangeRateParams{
+		{BaseCurrency: "USD", QuoteCurrency: "JPY"},
+		{BaseCurrency: "EUR", QuoteCurrency: "JPY"},
+	}
+
+	mockRepo.EXPECT().ImportRates(gomock.Any(), rates).Return(2, nil)
+
+	count, err := uc.ImportRates(context.Background(), rates)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
+
+func TestImportRatesFailureUnsupportedCurrency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockExchangeRateRepository(ctrl)
+	uc := usecase.NewExchangeRateUsecase(mockRepo)
+
+	count, err := uc.ImportRates(context.Background(), []db.UpsertExchangeRateParams{
+		{BaseCurrency: "USD", QuoteCurrency: "XXX"},
+	})
+	assert.ErrorIs(t, err, usecase.ErrUnsupportedCurrency)
+	assert.Equal(t, 0, count)
+}
+
+func TestImportRatesFailureSameCurrencyPair(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockExchangeRateRepository(ctrl)
+	uc := usecase.NewExchangeRateUsecase(mockRepo)
+
+	count, err := uc.ImportRates(context.Background(), []db.UpsertExchangeRateParams{
+		{BaseCurrency: "JPY", QuoteCurrency: "JPY"},
+	})
+	assert.ErrorIs(t, err, usecase.ErrSameCurrencyPair)
+	assert.Equal(t, 0, count)
+}
+
+func TestImportRatesFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockExchangeRateRepository(ctrl)
+	uc := usecase.NewExchangeRateUsecase(mockRepo)
+
+	mockRepo.EXPECT().ImportRates(gomock.Any(), gomock.Any()).Return(0, errors.New("error"))
+
+	count, err := uc.ImportRates(context.Background(), []db.UpsertExchangeRateParams{
+		{BaseCurrency: "USD", QuoteCurrency: "JPY"},
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 0, count)
+}
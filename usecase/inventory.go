@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+)
+
+const (
+	StockReasonReceived = "received"
+	StockReasonSold     = "sold"
+	StockReasonDamaged  = "damaged"
+	StockReasonReturned = "returned"
+)
+
+var ErrInvalidStockReason = errors.New("invalid stock reason")
+
+type InventoryUsecase interface {
+	FetchInventories(ctx context.Context, bookId int) ([]db.Inventory, error)
+	FetchStockMovements(ctx context.Context, bookId int) ([]db.StockMovement, error)
+	AdjustStock(ctx context.Context, bookId int, location string, quantity int, reason string) (*db.StockMovement, error)
+}
+
+type inventoryUsecaseImpl struct {
+	repository     repository.InventoryRepository
+	bookRepository repository.BookRepository
+}
+
+func NewInventoryUsecase(repository repository.InventoryRepository, bookRepository repository.BookRepository) InventoryUsecase {
+	return &inventoryUsecaseImpl{
+		repository:     repository,
+		bookRepository: bookRepository,
+	}
+}
+
+func (u *inventoryUsecaseImpl) FetchInventories(ctx context.Context, bookId int) ([]db.Inventory, error) {
+	if _, err := u.bookRepository.GetBookById(ctx, bookId); err != nil {
+		log.Printf("Unable to execute InventoryUsecaseFetchInventories: %d\n", err)
+		return nil, err
+	}
+
+	inventories, err := u.repository.ListInventories(ctx, bookId)
+	if err != nil {
+		log.Printf("Unable to execute InventoryUsecaseFetchInventories: %d\n", err)
+		return nil, err
+	}
+
+	return inventories, nil
+}
+
+func (u *inventoryUsecaseImpl) FetchStockMovements(ctx context.Context, bookId int) ([]db.StockMovement, error) {
+	if _, err := u.bookRepository.GetBookById(ctx, bookId); err != nil {
+		log.Printf("Unable to execute InventoryUsecaseFetchStockMovements: %d\n", err)
+		return nil, err
+	}
+
+	movements, err := u.repository.ListStockMovements(ctx, bookId)
+	if err != nil {
+		log.Printf("Unable to execute InventoryUsecaseFetchStockMovements: %d\n", err)
+		return nil, err
+	}
+
+	return movements, nil
+}
+
+// AdjustStock は理由に応じて在庫数を増減させる
+// 入荷・返品は加算、販売・破損は減算として扱う
+func (u *inventoryUsecaseImpl) AdjustStock(ctx context.Context, bookId int, location string, quantity int, reason string) (*db.StockMovement, error) {
+	var change int
+	switch reason {
+	case StockReasonReceived, StockReasonReturned:
+		change = quantity
+	case StockReasonSold, StockReasonDamaged:
+		change = -quantity
+	default:
+		return nil, ErrInvalidStockReason
+	}
+
+	if _, err := u.bookRepository.GetBookById(ctx, bookId); err != nil {
+		log.Printf("Unable to execute InventoryUsecaseAdjustStock: %d\n", err)
+		return nil, err
+	}
+
+	movement, err := u.repository.AdjustStock(ctx, &repository.AdjustStockParams{
+		BookID:         int32(bookId),
+		Location:       location,
+		QuantityChange: int32(change),
+		Reason:         reason,
+	})
+	if err != nil {
+		log.Printf("Unable to execute InventoryUsecaseAdjustStock: %d\n", err)
+		return nil, err
+	}
+
+	return movement, nil
+}
@@ -0,0 +1,123 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	mock_repository "github.com/rentaro-m-b/ai-model-exam/repository/mock"
+	"github.com/rentaro-m-b/ai-model-exam/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFetchInventories(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockInventoryRepository(ctrl)
+	mockBookRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewInventoryUsecase(mockRepo, mockBookRepo)
+
+	id := 1
+	expects := []db.Inventory{
+		{BookID: 1, Location: "tokyo", Quantity: 3},
+	}
+
+	mockBookRepo.EXPECT().GetBookById(gomock.Any(), id).Return(&db.Book{ID: 1}, nil)
+	mockRepo.EXPECT().ListInventories(gomock.Any(), id).Return(expects, nil)
+
+	inventories, err := uc.FetchInventories(context.Background(), id)
+	assert.NoError(t, err)
+	assert.Equal(t, expects, inventories)
+}
+
+func TestFetchInventoriesFailureBookNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockInventoryRepository(ctrl)
+	mockBookRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewInventoryUsecase(mockRepo, mockBookRepo)
+
+	id := 1
+
+	mockBookRepo.EXPECT().GetBookById(gomock.Any(), id).Return(nil, pgx.ErrNoRows)
+
+	inventories, err := uc.FetchInventories(context.Background(), id)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+	assert.Nil(t, inventories)
+}
+
+func TestAdjustStock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockInventoryRepository(ctrl)
+	mockBookRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewInventoryUsecase(mockRepo, mockBookRepo)
+
+	id := 1
+	// 販売は在庫数を減らす方向に変換される
+	param := repository.AdjustStockParams{
+		BookID:         1,
+		Location:       "tokyo",
+		QuantityChange: -2,
+		Reason:         "sold",
+	}
+	expect := db.StockMovement{
+		ID:             1,
+		BookID:         1,
+		Location:       "tokyo",
+		QuantityChange: -2,
+		QuantityAfter:  1,
+		Reason:         "sold",
+	}
+
+	mockBookRepo.EXPECT().GetBookById(gomock.Any(), id).Return(&db.Book{ID: 1}, nil)
+	mockRepo.EXPECT().AdjustStock(gomock.Any(), &param).Return(&expect, nil)
+
+	movement, err := uc.AdjustStock(context.Background(), id, "tokyo", 2, "sold")
+	assert.NoError(t, err)
+	assert.Equal(t, &expect, movement)
+}
+
+func TestAdjustStockFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockInventoryRepository(ctrl)
+	mockBookRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewInventoryUsecase(mockRepo, mockBookRepo)
+
+	id := 1
+	param := repository.AdjustStockParams{
+		BookID:         1,
+		Location:       "tokyo",
+		QuantityChange: 2,
+		Reason:         "received",
+	}
+
+	mockBookRepo.EXPECT().GetBookById(gomock.Any(), id).Return(&db.Book{ID: 1}, nil)
+	mockRepo.EXPECT().AdjustStock(gomock.Any(), &param).Return(nil, errors.New("error"))
+
+	movement, err := uc.AdjustStock(context.Background(), id, "tokyo", 2, "received")
+	assert.Error(t, err)
+	assert.Nil(t, movement)
+}
+
+func TestAdjustStockFailureInvalidReason(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockInventoryRepository(ctrl)
+	mockBookRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewInventoryUsecase(mockRepo, mockBookRepo)
+
+	movement, err := uc.AdjustStock(context.Background(), 1, "tokyo", 2, "lost")
+	assert.ErrorIs(t, err, usecase.ErrInvalidStockReason)
+	assert.Nil(t, movement)
+}
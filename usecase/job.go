@@ -0,0 +1,226 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	"github.com/rentaro-m-b/ai-model-exam/storage"
+)
+
+var (
+	ErrJobFinished         = errors.New("job already finished")
+	ErrJobArtifactNotFound = errors.New("job artifact not found")
+)
+
+// ジョブの種類
+const (
+	JobKindBookExport         = "book_export"
+	JobKindBookBulkUpdate     = "book_bulk_update"
+	JobKindBookBulkDelete     = "book_bulk_delete"
+	JobKindExchangeRateImport = "exchange_rate_import"
+)
+
+type JobUsecase interface {
+	EnqueueBookExport(ctx context.Context) (*db.Job, error)
+	EnqueueBookBulkUpdate(ctx context.Context, filter *db.ListBooksByFilterParams, patch *repository.BookPatch) (*db.Job, error)
+	EnqueueBookBulkDelete(ctx context.Context, filter *db.ListBooksByFilterParams) (*db.Job, error)
+	EnqueueExchangeRateImport(ctx context.Context, rates []db.UpsertExchangeRateParams) (*db.Job, error)
+	FindJobById(ctx context.Context, id int64) (*db.Job, error)
+	CancelJob(ctx context.Context, id int64) (*db.Job, error)
+	FetchJobArtifact(ctx context.Context, id int64) (*JobArtifact, error)
+}
+
+// JobArtifact はジョブが生成したファイルのデータと形式を表す
+type JobArtifact struct {
+	Data        []byte
+	ContentType string
+}
+
+// bookFilterJobParams は一括更新・一括削除のジョブで対象の書籍を選ぶ条件
+// ジョブの引数は実行までデータベースに保存されるため、JSONの形式を固定しておく
+type bookFilterJobParams struct {
+	Ids       []int32     `json:"ids"`
+	Author    pgtype.Text `json:"author"`
+	Publisher pgtype.Text `json:"publisher"`
+}
+
+func newBookFilterJobParams(filter *db.ListBooksByFilterParams) bookFilterJobParams {
+	return bookFilterJobParams{Ids: filter.Ids, Author: filter.Author, Publisher: filter.Publisher}
+}
+
+func (p *bookFilterJobParams) filter() *db.ListBooksByFilterParams {
+	return &db.ListBooksByFilterParams{Ids: p.Ids, Author: p.Author, Publisher: p.Publisher}
+}
+
+// bookBulkUpdateJobParams は一括更新のジョブの引数
+type bookBulkUpdateJobParams struct {
+	Filter    bookFilterJobParams `json:"filter"`
+	Publisher pgtype.Text         `json:"publisher"`
+	Price     pgtype.Int4         `json:"price"`
+}
+
+// bookBulkDeleteJobParams は一括削除のジョブの引数
+type bookBulkDeleteJobParams struct {
+	Filter bookFilterJobParams `json:"filter"`
+}
+
+// exchangeRateJobParams は為替レートの取り込みのジョブで取り込む1件のレート
+type exchangeRateJobParams struct {
+	BaseCurrency  string         `json:"base_currency"`
+	QuoteCurrency string         `json:"quote_currency"`
+	Rate          pgtype.Numeric `json:"rate"`
+	EffectiveDate pgtype.Date    `json:"effective_date"`
+}
+
+// exchangeRateImportJobParams は為替レートの取り込みのジョブの引数
+type exchangeRateImportJobParams struct {
+	Rates []exchangeRateJobParams `json:"rates"`
+}
+
+type jobUsecaseImpl struct {
+	repository repository.JobRepository
+	artifacts  storage.BlobStorage
+}
+
+func NewJobUsecase(repository repository.JobRepository, artifacts storage.BlobStorage) JobUsecase {
+	return &jobUsecaseImpl{
+		repository: repository,
+		artifacts:  artifacts,
+	}
+}
+
+// enqueue は引数をJSONに変換し、ジョブを実行待ちとして登録する
+func (u *jobUsecaseImpl) enqueue(ctx context.Context, kind string, params any) (*db.Job, error) {
+	b, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.repository.CreateJob(ctx, kind, b)
+}
+
+// EnqueueBookExport は全ての書籍をCSVに書き出すジョブを登録する
+func (u *jobUsecaseImpl) EnqueueBookExport(ctx context.Context) (*db.Job, error) {
+	j, err := u.enqueue(ctx, JobKindBookExport, struct{}{})
+	if err != nil {
+		log.Printf("Unable to execute JobUsecaseEnqueueBookExport: %d\n", err)
+		return nil, err
+	}
+
+	return j, nil
+}
+
+// EnqueueBookBulkUpdate はBookUsecase.BulkUpdateBooksと同じ一括更新を行うジョブを登録する
+func (u *jobUsecaseImpl) EnqueueBookBulkUpdate(ctx context.Context, filter *db.ListBooksByFilterParams, patch *repository.BookPatch) (*db.Job, error) {
+	j, err := u.enqueue(ctx, JobKindBookBulkUpdate, bookBulkUpdateJobParams{
+		Filter:    newBookFilterJobParams(filter),
+		Publisher: patch.Publisher,
+		Price:     patch.Price,
+	})
+	if err != nil {
+		log.Printf("Unable to execute JobUsecaseEnqueueBookBulkUpdate: %d\n", err)
+		return nil, err
+	}
+
+	return j, nil
+}
+
+// EnqueueBookBulkDelete はBookUsecase.DeleteBooksと同じ一括削除を行うジョブを登録する
+func (u *jobUsecaseImpl) EnqueueBookBulkDelete(ctx context.Context, filter *db.ListBooksByFilterParams) (*db.Job, error) {
+	j, err := u.enqueue(ctx, JobKindBookBulkDelete, bookBulkDeleteJobParams{
+		Filter: newBookFilterJobParams(filter),
+	})
+	if err != nil {
+		log.Printf("Unable to execute JobUsecaseEnqueueBookBulkDelete: %d\n", err)
+		return nil, err
+	}
+
+	return j, nil
+}
+
+// EnqueueExchangeRateImport は為替レートを取り込むジョブを登録する
+// 通貨の誤りはジョブの実行を待たずに、ExchangeRateUsecase.ImportRatesと同じエラーで返す
+func (u *jobUsecaseImpl) EnqueueExchangeRateImport(ctx context.Context, rates []db.UpsertExchangeRateParams) (*db.Job, error) {
+	if err := validateExchangeRates(rates); err != nil {
+		log.Printf("Unable to execute JobUsecaseEnqueueExchangeRateImport: %d\n", err)
+		return nil, err
+	}
+
+	params := exchangeRateImportJobParams{Rates: make([]exchangeRateJobParams, 0, len(rates))}
+	for _, rate := range rates {
+		params.Rates = append(params.Rates, exchangeRateJobParams(rate))
+	}
+	j, err := u.enqueue(ctx, JobKindExchangeRateImport, params)
+	if err != nil {
+		log.Printf("Unable to execute JobUsecaseEnqueueExchangeRateImport: %d\n", err)
+		return nil, err
+	}
+
+	return j, nil
+}
+
+func (u *jobUsecaseImpl) FindJobById(ctx context.Context, id int64) (*db.Job, error) {
+	j, err := u.repository.GetJobById(ctx, id)
+	if err != nil {
+		log.Printf("Unable to execute JobUsecaseFindJobById: %d\n", err)
+		return nil, err
+	}
+
+	return j, nil
+}
+
+// CancelJob は実行待ちのジョブをキャンセルし、実行中のジョブにはキャンセルを要求する
+// 実行中のジョブはワーカーが要求を受けて中断した時点でキャンセル済みになり、終了したジョブの場合はErrJobFinishedを返す
+func (u *jobUsecaseImpl) CancelJob(ctx context.Context, id int64) (*db.Job, error) {
+	j, err := u.repository.CancelJob(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// 終了したジョブと存在しないジョブを区別する
+		if _, err := u.repository.GetJobById(ctx, id); err != nil {
+			log.Printf("Unable to execute JobUsecaseCancelJob: %d\n", err)
+			return nil, err
+		}
+		return nil, ErrJobFinished
+	}
+	if err != nil {
+		log.Printf("Unable to execute JobUsecaseCancelJob: %d\n", err)
+		return nil, err
+	}
+
+	return j, nil
+}
+
+// FetchJobArtifact はジョブが生成したファイルを返し、ファイルが無いジョブの場合はErrJobArtifactNotFoundを返す
+func (u *jobUsecaseImpl) FetchJobArtifact(ctx context.Context, id int64) (*JobArtifact, error) {
+	j, err := u.repository.GetJobById(ctx, id)
+	if err != nil {
+		log.Printf("Unable to execute JobUsecaseFetchJobArtifact: %d\n", err)
+		return nil, err
+	}
+	if !j.ArtifactKey.Valid {
+		return nil, ErrJobArtifactNotFound
+	}
+
+	r, err := u.artifacts.Get(ctx, j.ArtifactKey.String)
+	if err != nil {
+		log.Printf("Unable to execute JobUsecaseFetchJobArtifact: %d\n", err)
+		return nil, err
+	}
+	defer r.Close()
+	data, err := io.ReadAll(r)
+	if err != nil {
+		log.Printf("Unable to execute JobUsecaseFetchJobArtifact: %d\n", err)
+		return nil, err
+	}
+
+	return &JobArtifact{
+		Data:        data,
+		ContentType: j.ArtifactType.String,
+	}, nil
+}
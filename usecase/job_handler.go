@@ -0,0 +1,184 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/job"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+)
+
+const (
+	// bookExportContentType は書籍の書き出しのジョブが生成するファイルの形式
+	bookExportContentType = "text/csv; charset=utf-8"
+	// exportProgressInterval は書籍の書き出しで進捗を記録する件数の間隔
+	exportProgressInterval = 100
+)
+
+// bookExportHeader は書籍の書き出しのCSVの見出し
+var bookExportHeader = []string{
+	"id", "title", "author", "publisher", "price", "isbn", "subtitle", "edition", "publication_date",
+	"language", "page_count", "format", "series", "series_volume", "work_id",
+}
+
+// bulkJobResult は一括更新・一括削除のジョブの結果
+type bulkJobResult struct {
+	Affected int     `json:"affected"`
+	BookIDs  []int32 `json:"book_ids"`
+}
+
+type jobHandlers struct {
+	bookUsecase         BookUsecase
+	exchangeRateUsecase ExchangeRateUsecase
+}
+
+// NewJobHandlers はジョブの種類ごとに、ワーカーで実行するjob.Handlerを返す
+func NewJobHandlers(bookUsecase BookUsecase, exchangeRateUsecase ExchangeRateUsecase) map[string]job.Handler {
+	h := &jobHandlers{
+		bookUsecase:         bookUsecase,
+		exchangeRateUsecase: exchangeRateUsecase,
+	}
+
+	return map[string]job.Handler{
+		JobKindBookExport:         h.exportBooks,
+		JobKindBookBulkUpdate:     h.bulkUpdateBooks,
+		JobKindBookBulkDelete:     h.bulkDeleteBooks,
+		JobKindExchangeRateImport: h.importExchangeRates,
+	}
+}
+
+// exportBooks は全ての書籍をID順にCSVへ書き出し、exportProgressInterval件ごとに進捗を記録する
+func (h *jobHandlers) exportBooks(ctx context.Context, j *job.Job, progress job.Progress) (*job.Result, error) {
+	books, err := h.bookUsecase.FetchBooks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	progress.Report(ctx, 0, len(books))
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(bookExportHeader); err != nil {
+		return nil, err
+	}
+	for i, book := range books {
+		if err := w.Write(bookExportRecord(&book)); err != nil {
+			return nil, err
+		}
+		if (i+1)%exportProgressInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			progress.Report(ctx, i+1, len(books))
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	progress.Report(ctx, len(books), len(books))
+
+	return &job.Result{
+		Data:         map[string]int{"exported": len(books)},
+		Artifact:     buf.Bytes(),
+		ArtifactType: bookExportContentType,
+	}, nil
+}
+
+// bookExportRecord は書籍をbookExportHeaderの順の値にし、値の無い項目は空文字列とする
+func bookExportRecord(book *db.Book) []string {
+	text := func(v pgtype.Text) string {
+		return v.String
+	}
+	int4 := func(v pgtype.Int4) string {
+		if !v.Valid {
+			return ""
+		}
+		return strconv.Itoa(int(v.Int32))
+	}
+	publicationDate := ""
+	if book.PublicationDate.Valid {
+		publicationDate = book.PublicationDate.Time.Format(time.DateOnly)
+	}
+
+	return []string{
+		strconv.Itoa(int(book.ID)), text(book.Title), text(book.Author), text(book.Publisher), int4(book.Price),
+		text(book.Isbn), text(book.Subtitle), text(book.Edition), publicationDate, text(book.Language),
+		int4(book.PageCount), text(book.Format), text(book.Series), int4(book.SeriesVolume), int4(book.WorkID),
+	}
+}
+
+// bulkUpdateBooks は対象の件数を進捗の全体として記録してから、1つのトランザクションで一括更新する
+// 更新はトランザクションの終了まで途中経過が見えないため、進捗は開始時と終了時のみ記録する
+// 更新と同じトランザクションで結果を記録し、コミット後にワーカーが止まっても実行し直さない
+func (h *jobHandlers) bulkUpdateBooks(ctx context.Context, j *job.Job, progress job.Progress) (*job.Result, error) {
+	var params bookBulkUpdateJobParams
+	if err := json.Unmarshal(j.Params, &params); err != nil {
+		return nil, err
+	}
+	filter := params.Filter.filter()
+	patch := &repository.BookPatch{Publisher: params.Publisher, Price: params.Price}
+
+	target, err := h.bookUsecase.BulkUpdateBooks(ctx, filter, patch, true)
+	if err != nil {
+		return nil, err
+	}
+	progress.Report(ctx, 0, target.Affected)
+
+	res, err := h.bookUsecase.BulkUpdateBooksInJob(ctx, j, filter, patch)
+	if err != nil {
+		return nil, err
+	}
+	progress.Report(ctx, res.Affected, res.Affected)
+
+	return &job.Result{Data: bulkJobResult{Affected: res.Affected, BookIDs: res.BookIDs}}, nil
+}
+
+// bulkDeleteBooks はbulkUpdateBooksと同じく、対象の件数を記録してから1つのトランザクションで一括削除する
+func (h *jobHandlers) bulkDeleteBooks(ctx context.Context, j *job.Job, progress job.Progress) (*job.Result, error) {
+	var params bookBulkDeleteJobParams
+	if err := json.Unmarshal(j.Params, &params); err != nil {
+		return nil, err
+	}
+	filter := params.Filter.filter()
+
+	target, err := h.bookUsecase.DeleteBooks(ctx, filter, true)
+	if err != nil {
+		return nil, err
+	}
+	progress.Report(ctx, 0, target.Affected)
+
+	res, err := h.bookUsecase.DeleteBooksInJob(ctx, j, filter)
+	if err != nil {
+		return nil, err
+	}
+	progress.Report(ctx, res.Affected, res.Affected)
+
+	return &job.Result{Data: bulkJobResult{Affected: res.Affected, BookIDs: res.BookIDs}}, nil
+}
+
+// importExchangeRates は全てのレートを1つのトランザクションで取り込む
+func (h *jobHandlers) importExchangeRates(ctx context.Context, j *job.Job, progress job.Progress) (*job.Result, error) {
+	var params exchangeRateImportJobParams
+	if err := json.Unmarshal(j.Params, &params); err != nil {
+		return nil, err
+	}
+	rates := make([]db.UpsertExchangeRateParams, 0, len(params.Rates))
+	for _, rate := range params.Rates {
+		rates = append(rates, db.UpsertExchangeRateParams(rate))
+	}
+	progress.Report(ctx, 0, len(rates))
+
+	count, err := h.exchangeRateUsecase.ImportRates(ctx, rates)
+	if err != nil {
+		return nil, err
+	}
+	progress.Report(ctx, len(rates), len(rates))
+
+	return &job.Result{Data: map[string]int{"imported": count}}, nil
+}
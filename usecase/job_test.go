@@ -0,0 +1,261 @@
+package usecase_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/job"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	mock_repository "github.com/rentaro-m-b/ai-model-exam/repository/mock"
+	"github.com/rentaro-m-b/ai-model-exam/storage"
+	"github.com/rentaro-m-b/ai-model-exam/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordedProgress はジョブが記録した進捗を順に保持するjob.Progressの実装
+type recordedProgress [][2]int
+
+func (p *recordedProgress) Report(ctx context.Context, done int, total int) {
+	*p = append(*p, [2]int{done, total})
+}
+
+func TestEnqueueBookBulkUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockJobRepository(ctrl)
+	uc := usecase.NewJobUsecase(mockRepo, nil)
+
+	filter := db.ListBooksByFilterParams{Ids: []int32{1, 2}}
+	patch := repository.BookPatch{Price: pgtype.Int4{Int32: 300, Valid: true}}
+	expect := db.Job{ID: 1, Kind: usecase.JobKindBookBulkUpdate, Status: job.StatusQueued}
+	mockRepo.EXPECT().CreateJob(gomock.Any(), usecase.JobKindBookBulkUpdate, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, kind string, params []byte) (*db.Job, error) {
+			assert.JSONEq(t, `{"filter": {"ids": [1, 2], "author": null, "publisher": null}, "publisher": null, "price": 300}`, string(params))
+			return &expect, nil
+		},
+	)
+
+	j, err := uc.EnqueueBookBulkUpdate(context.Background(), &filter, &patch)
+	assert.NoError(t, err)
+	assert.Equal(t, &expect, j)
+}
+
+func TestEnqueueExchangeRateImportFailureUnsupportedCurrency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockJobRepository(ctrl)
+	uc := usecase.NewJobUsecase(mockRepo, nil)
+
+	// 通貨の誤りはジョブを登録せずに返す
+	j, err := uc.EnqueueExchangeRateImport(context.Background(), []db.UpsertExchangeRateParams{
+		{BaseCurrency: "XXX", QuoteCurrency: "JPY"},
+	})
+	assert.ErrorIs(t, err, usecase.ErrUnsupportedCurrency)
+	assert.Nil(t, j)
+}
+
+func TestCancelJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockJobRepository(ctrl)
+	uc := usecase.NewJobUsecase(mockRepo, nil)
+
+	expect := db.Job{ID: 1, Status: job.StatusRunning, CancelRequested: true}
+	mockRepo.EXPECT().CancelJob(gomock.Any(), int64(1)).Return(&expect, nil)
+
+	j, err := uc.CancelJob(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, &expect, j)
+}
+
+func TestCancelJobFailureFinished(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockJobRepository(ctrl)
+	uc := usecase.NewJobUsecase(mockRepo, nil)
+
+	mockRepo.EXPECT().CancelJob(gomock.Any(), int64(1)).Return(nil, pgx.ErrNoRows)
+	mockRepo.EXPECT().GetJobById(gomock.Any(), int64(1)).Return(&db.Job{ID: 1, Status: job.StatusSucceeded}, nil)
+	mockRepo.EXPECT().CancelJob(gomock.Any(), int64(99)).Return(nil, pgx.ErrNoRows)
+	mockRepo.EXPECT().GetJobById(gomock.Any(), int64(99)).Return(nil, pgx.ErrNoRows)
+
+	_, err := uc.CancelJob(context.Background(), 1)
+	assert.ErrorIs(t, err, usecase.ErrJobFinished)
+	_, err = uc.CancelJob(context.Background(), 99)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestFetchJobArtifact(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockJobRepository(ctrl)
+	artifacts, err := storage.NewLocalStorage(t.TempDir())
+	assert.NoError(t, err)
+	assert.NoError(t, artifacts.Put(context.Background(), "jobs/1", bytes.NewReader([]byte("id\n1\n"))))
+	uc := usecase.NewJobUsecase(mockRepo, artifacts)
+
+	mockRepo.EXPECT().GetJobById(gomock.Any(), int64(1)).Return(&db.Job{
+		ID:           1,
+		Status:       job.StatusSucceeded,
+		ArtifactKey:  pgtype.Text{String: "jobs/1", Valid: true},
+		ArtifactType: pgtype.Text{String: "text/csv; charset=utf-8", Valid: true},
+	}, nil)
+	mockRepo.EXPECT().GetJobById(gomock.Any(), int64(2)).Return(&db.Job{ID: 2, Status: job.StatusRunning}, nil)
+
+	artifact, err := uc.FetchJobArtifact(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, &usecase.JobArtifact{Data: []byte("id\n1\n"), ContentType: "text/csv; charset=utf-8"}, artifact)
+
+	// 実行中のジョブにはファイルが無い
+	_, err = uc.FetchJobArtifact(context.Background(), 2)
+	assert.ErrorIs(t, err, usecase.ErrJobArtifactNotFound)
+}
+
+func TestExportBooksJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBookRepo := mock_repository.NewMockBookRepository(ctrl)
+	mockRateRepo := mock_repository.NewMockExchangeRateRepository(ctrl)
+	handlers := usecase.NewJobHandlers(usecase.NewBookUsecase(mockBookRepo, nil), usecase.NewExchangeRateUsecase(mockRateRepo))
+
+	mockBookRepo.EXPECT().ListBooks(gomock.Any()).Return([]db.Book{
+		{
+			ID:              1,
+			Title:           pgtype.Text{String: "test title 1", Valid: true},
+			Author:          pgtype.Text{String: "test author 1", Valid: true},
+			Publisher:       pgtype.Text{String: "test publisher 1", Valid: true},
+			Price:           pgtype.Int4{Int32: 100, Valid: true},
+			Isbn:            pgtype.Text{String: "9784873119045", Valid: true},
+			PublicationDate: pgtype.Date{Time: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+		},
+		{
+			ID:    2,
+			Title: pgtype.Text{String: "title, with comma", Valid: true},
+		},
+	}, nil)
+
+	var progress recordedProgress
+	res, err := handlers[usecase.JobKindBookExport](context.Background(), &job.Job{ID: 1, Params: []byte(`{}`)}, &progress)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"exported": 2}, res.Data)
+	assert.Equal(t, "text/csv; charset=utf-8", res.ArtifactType)
+	assert.Equal(t, "id,title,author,publisher,price,isbn,subtitle,edition,publication_date,language,page_count,format,series,series_volume,work_id\n"+
+		"1,test title 1,test author 1,test publisher 1,100,9784873119045,,,2024-04-01,,,,,,\n"+
+		"2,\"title, with comma\",,,,,,,,,,,,,\n", string(res.Artifact))
+	assert.Equal(t, recordedProgress{{0, 2}, {2, 2}}, progress)
+}
+
+func TestBulkUpdateBooksJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBookRepo := mock_repository.NewMockBookRepository(ctrl)
+	mockRateRepo := mock_repository.NewMockExchangeRateRepository(ctrl)
+	handlers := usecase.NewJobHandlers(usecase.NewBookUsecase(mockBookRepo, nil), usecase.NewExchangeRateUsecase(mockRateRepo))
+
+	filter := db.ListBooksByFilterParams{Publisher: pgtype.Text{String: "old publisher", Valid: true}}
+	patch := repository.BookPatch{Publisher: pgtype.Text{String: "new publisher", Valid: true}}
+	books := []db.Book{{ID: 1}, {ID: 3}}
+	// 対象の件数を記録してから更新する
+	mockBookRepo.EXPECT().ListBooksByFilter(gomock.Any(), &filter).Return(books, nil)
+	expectBookTx(mockBookRepo)
+	mockBookRepo.EXPECT().BulkUpdateBooks(gomock.Any(), &filter, &patch).Return(books, nil)
+	mockBookRepo.EXPECT().AddEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	// 実行し直した場合に更新を繰り返さないよう、同じトランザクションで結果を記録する
+	params := []byte(`{"filter": {"ids": null, "author": null, "publisher": "old publisher"}, "publisher": "new publisher", "price": null}`)
+	j := &job.Job{ID: 1, Params: params, Attempts: 1}
+	mockBookRepo.EXPECT().CommitJobResult(gomock.Any(), j, []byte(`{"affected":2,"book_ids":[1,3]}`)).Return(nil)
+
+	var progress recordedProgress
+	res, err := handlers[usecase.JobKindBookBulkUpdate](context.Background(), j, &progress)
+	assert.NoError(t, err)
+	assert.Equal(t, recordedProgress{{0, 2}, {2, 2}}, progress)
+	b, err := json.Marshal(res.Data)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"affected": 2, "book_ids": [1, 3]}`, string(b))
+}
+
+func TestBulkDeleteBooksJobFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBookRepo := mock_repository.NewMockBookRepository(ctrl)
+	mockRateRepo := mock_repository.NewMockExchangeRateRepository(ctrl)
+	handlers := usecase.NewJobHandlers(usecase.NewBookUsecase(mockBookRepo, nil), usecase.NewExchangeRateUsecase(mockRateRepo))
+
+	filter := db.ListBooksByFilterParams{Ids: []int32{1}}
+	mockBookRepo.EXPECT().ListBooksByFilter(gomock.Any(), &filter).Return([]db.Book{{ID: 1}}, nil)
+	expectBookTx(mockBookRepo)
+	mockBookRepo.EXPECT().DeleteBooks(gomock.Any(), &filter).Return(nil, errors.New("error"))
+
+	var progress recordedProgress
+	params := []byte(`{"filter": {"ids": [1], "author": null, "publisher": null}}`)
+	res, err := handlers[usecase.JobKindBookBulkDelete](context.Background(), &job.Job{ID: 1, Params: params}, &progress)
+	assert.Error(t, err)
+	assert.Nil(t, res)
+	assert.Equal(t, recordedProgress{{0, 1}}, progress)
+}
+
+func TestBulkDeleteBooksJobFailureLeaseLost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBookRepo := mock_repository.NewMockBookRepository(ctrl)
+	mockRateRepo := mock_repository.NewMockExchangeRateRepository(ctrl)
+	handlers := usecase.NewJobHandlers(usecase.NewBookUsecase(mockBookRepo, nil), usecase.NewExchangeRateUsecase(mockRateRepo))
+
+	// リースを失っていた場合は結果を記録できず、削除を取り消す
+	filter := db.ListBooksByFilterParams{Ids: []int32{1}}
+	mockBookRepo.EXPECT().ListBooksByFilter(gomock.Any(), &filter).Return([]db.Book{{ID: 1}}, nil)
+	expectBookTx(mockBookRepo)
+	mockBookRepo.EXPECT().DeleteBooks(gomock.Any(), &filter).Return([]db.Book{{ID: 1}}, nil)
+	mockBookRepo.EXPECT().AddEvents(gomock.Any(), gomock.Any()).Return(nil)
+	mockBookRepo.EXPECT().CommitJobResult(gomock.Any(), gomock.Any(), []byte(`{"affected":1,"book_ids":[1]}`)).Return(job.ErrLeaseLost)
+
+	var progress recordedProgress
+	params := []byte(`{"filter": {"ids": [1], "author": null, "publisher": null}}`)
+	res, err := handlers[usecase.JobKindBookBulkDelete](context.Background(), &job.Job{ID: 1, Params: params, Attempts: 2}, &progress)
+	assert.ErrorIs(t, err, job.ErrLeaseLost)
+	assert.Nil(t, res)
+}
+
+func TestImportExchangeRatesJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBookRepo := mock_repository.NewMockBookRepository(ctrl)
+	mockRateRepo := mock_repository.NewMockExchangeRateRepository(ctrl)
+	handlers := usecase.NewJobHandlers(usecase.NewBookUsecase(mockBookRepo, nil), usecase.NewExchangeRateUsecase(mockRateRepo))
+
+	rates := []db.UpsertExchangeRateParams{
+		{
+			BaseCurrency:  "USD",
+			QuoteCurrency: "JPY",
+			Rate:          pgtype.Numeric{Int: big.NewInt(15725), Exp: -2, Valid: true},
+			EffectiveDate: pgtype.Date{Time: time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+		},
+	}
+	mockRateRepo.EXPECT().ImportRates(gomock.Any(), rates).Return(1, nil)
+
+	var progress recordedProgress
+	params := []byte(`{"rates": [{"base_currency": "USD", "quote_currency": "JPY", "rate": 157.25, "effective_date": "2024-07-01"}]}`)
+	res, err := handlers[usecase.JobKindExchangeRateImport](context.Background(), &job.Job{ID: 1, Params: params}, &progress)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"imported": 1}, res.Data)
+	assert.Equal(t, recordedProgress{{0, 1}, {1, 1}}, progress)
+}
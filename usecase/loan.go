@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+)
+
+const (
+	LoanDays          = 14
+	MaxLoansPerMember = 5
+)
+
+type LoanUsecase interface {
+	Checkout(ctx context.Context, bookId int, memberId int) (*db.Loan, error)
+	ReturnLoan(ctx context.Context, id int) (*db.Loan, error)
+	FetchOverdueLoans(ctx context.Context) ([]db.Loan, error)
+	FindAvailability(ctx context.Context, bookId int) (*db.GetBookAvailabilityRow, error)
+}
+
+// LoanUsecaseConfig の未指定（ゼロ値）の項目には既定値を使う
+type LoanUsecaseConfig struct {
+	// HoldPeriod は返却された書籍を予約者のために取り置く期間で、予約のユースケースと同じ期間を指定する
+	HoldPeriod time.Duration
+}
+
+type loanUsecaseImpl struct {
+	repository     repository.LoanRepository
+	bookRepository repository.BookRepository
+	config         LoanUsecaseConfig
+	now            func() time.Time
+}
+
+func NewLoanUsecase(repository repository.LoanRepository, bookRepository repository.BookRepository, config *LoanUsecaseConfig) LoanUsecase {
+	c := LoanUsecaseConfig{}
+	if config != nil {
+		c = *config
+	}
+	if c.HoldPeriod <= 0 {
+		c.HoldPeriod = defaultHoldPeriod
+	}
+
+	return &loanUsecaseImpl{
+		repository:     repository,
+		bookRepository: bookRepository,
+		config:         c,
+		now:            time.Now,
+	}
+}
+
+// DueDate は貸出日からLoanDays日後の終わりを返却期限とする
+func DueDate(loanedAt time.Time) time.Time {
+	y, m, d := loanedAt.Date()
+	return time.Date(y, m, d+LoanDays, 23, 59, 59, 0, loanedAt.Location())
+}
+
+func (u *loanUsecaseImpl) Checkout(ctx context.Context, bookId int, memberId int) (*db.Loan, error) {
+	now := u.now()
+	loan, err := u.repository.Checkout(ctx, &repository.CheckoutParams{
+		BookID:     int32(bookId),
+		MemberID:   int32(memberId),
+		LoanedAt:   now,
+		DueAt:      DueDate(now),
+		MaxLoans:   MaxLoansPerMember,
+		HoldPeriod: u.config.HoldPeriod,
+	})
+	if err != nil {
+		log.Printf("Unable to execute LoanUsecaseCheckout: %d\n", err)
+		return nil, err
+	}
+
+	return loan, nil
+}
+
+func (u *loanUsecaseImpl) ReturnLoan(ctx context.Context, id int) (*db.Loan, error) {
+	loan, err := u.repository.ReturnLoan(ctx, id, u.now(), u.config.HoldPeriod)
+	if err != nil {
+		log.Printf("Unable to execute LoanUsecaseReturnLoan: %d\n", err)
+		return nil, err
+	}
+
+	return loan, nil
+}
+
+func (u *loanUsecaseImpl) FetchOverdueLoans(ctx context.Context) ([]db.Loan, error) {
+	loans, err := u.repository.ListOverdueLoans(ctx, u.now())
+	if err != nil {
+		log.Printf("Unable to execute LoanUsecaseFetchOverdueLoans: %d\n", err)
+		return nil, err
+	}
+
+	return loans, nil
+}
+
+func (u *loanUsecaseImpl) FindAvailability(ctx context.Context, bookId int) (*db.GetBookAvailabilityRow, error) {
+	if _, err := u.bookRepository.GetBookById(ctx, bookId); err != nil {
+		log.Printf("Unable to execute LoanUsecaseFindAvailability: %d\n", err)
+		return nil, err
+	}
+
+	availability, err := u.repository.GetAvailability(ctx, bookId)
+	if err != nil {
+		log.Printf("Unable to execute LoanUsecaseFindAvailability: %d\n", err)
+		return nil, err
+	}
+
+	return availability, nil
+}
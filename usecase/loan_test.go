@@ -0,0 +1,127 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	mock_repository "github.com/rentaro-m-b/ai-model-exam/repository/mock"
+	"github.com/rentaro-m-b/ai-model-exam/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDueDate(t *testing.T) {
+	loanedAt := time.Date(2024, 1, 25, 10, 30, 0, 0, time.UTC)
+	expect := time.Date(2024, 2, 8, 23, 59, 59, 0, time.UTC)
+
+	assert.Equal(t, expect, usecase.DueDate(loanedAt))
+}
+
+func TestCheckout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	config := &usecase.LoanUsecaseConfig{HoldPeriod: 48 * time.Hour}
+	mockRepo := mock_repository.NewMockLoanRepository(ctrl)
+	mockBookRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewLoanUsecase(mockRepo, mockBookRepo, config)
+
+	expect := db.Loan{
+		ID:       1,
+		BookID:   1,
+		MemberID: 2,
+	}
+
+	mockRepo.EXPECT().Checkout(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, param *repository.CheckoutParams) (*db.Loan, error) {
+			assert.Equal(t, int32(1), param.BookID)
+			assert.Equal(t, int32(2), param.MemberID)
+			assert.Equal(t, usecase.MaxLoansPerMember, param.MaxLoans)
+			assert.Equal(t, usecase.DueDate(param.LoanedAt), param.DueAt)
+			assert.Equal(t, config.HoldPeriod, param.HoldPeriod)
+			return &expect, nil
+		})
+
+	loan, err := uc.Checkout(context.Background(), 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, &expect, loan)
+}
+
+func TestCheckoutFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockLoanRepository(ctrl)
+	mockBookRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewLoanUsecase(mockRepo, mockBookRepo, nil)
+
+	mockRepo.EXPECT().Checkout(gomock.Any(), gomock.Any()).Return(nil, repository.ErrNoCopyAvailable)
+
+	loan, err := uc.Checkout(context.Background(), 1, 2)
+	assert.ErrorIs(t, err, repository.ErrNoCopyAvailable)
+	assert.Nil(t, loan)
+}
+
+func TestReturnLoan(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	config := &usecase.LoanUsecaseConfig{HoldPeriod: 48 * time.Hour}
+	mockRepo := mock_repository.NewMockLoanRepository(ctrl)
+	mockBookRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewLoanUsecase(mockRepo, mockBookRepo, config)
+
+	id := 1
+	expect := db.Loan{
+		ID:       1,
+		BookID:   1,
+		MemberID: 2,
+	}
+
+	mockRepo.EXPECT().ReturnLoan(gomock.Any(), id, gomock.Any(), config.HoldPeriod).Return(&expect, nil)
+
+	loan, err := uc.ReturnLoan(context.Background(), id)
+	assert.NoError(t, err)
+	assert.Equal(t, &expect, loan)
+}
+
+func TestFetchOverdueLoansFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockLoanRepository(ctrl)
+	mockBookRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewLoanUsecase(mockRepo, mockBookRepo, nil)
+
+	mockRepo.EXPECT().ListOverdueLoans(gomock.Any(), gomock.Any()).Return(nil, errors.New("error"))
+
+	loans, err := uc.FetchOverdueLoans(context.Background())
+	assert.Error(t, err)
+	assert.Nil(t, loans)
+}
+
+func TestFindAvailability(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockLoanRepository(ctrl)
+	mockBookRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewLoanUsecase(mockRepo, mockBookRepo, nil)
+
+	id := 1
+	expect := db.GetBookAvailabilityRow{
+		Total:  3,
+		OnLoan: 1,
+	}
+
+	mockBookRepo.EXPECT().GetBookById(gomock.Any(), id).Return(&db.Book{ID: 1}, nil)
+	mockRepo.EXPECT().GetAvailability(gomock.Any(), id).Return(&expect, nil)
+
+	availability, err := uc.FindAvailability(context.Background(), id)
+	assert.NoError(t, err)
+	assert.Equal(t, &expect, availability)
+}
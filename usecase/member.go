@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"context"
+	"log"
+
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+)
+
+type MemberUsecase interface {
+	CreateMember(ctx context.Context, param *db.CreateMemberParams) (*db.Member, error)
+	FindMemberById(ctx context.Context, id int) (*db.Member, error)
+}
+
+type memberUsecaseImpl struct {
+	repository repository.MemberRepository
+}
+
+func NewMemberUsecase(repository repository.MemberRepository) MemberUsecase {
+	return &memberUsecaseImpl{
+		repository: repository,
+	}
+}
+
+func (u *memberUsecaseImpl) CreateMember(ctx context.Context, param *db.CreateMemberParams) (*db.Member, error) {
+	member, err := u.repository.CreateMember(ctx, param)
+	if err != nil {
+		log.Printf("Unable to execute MemberUsecaseCreateMember: %d\n", err)
+		return nil, err
+	}
+
+	return member, nil
+}
+
+func (u *memberUsecaseImpl) FindMemberById(ctx context.Context, id int) (*db.Member, error) {
+	member, err := u.repository.GetMemberById(ctx, id)
+	if err != nil {
+		log.Printf("Unable to execute MemberUsecaseFindMemberById: %d\n", err)
+		return nil, err
+	}
+
+	return member, nil
+}
@@ -0,0 +1,53 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	mock_repository "github.com/rentaro-m-b/ai-model-exam/repository/mock"
+	"github.com/rentaro-m-b/ai-model-exam/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateMember(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockMemberRepository(ctrl)
+	uc := usecase.NewMemberUsecase(mockRepo)
+
+	param := db.CreateMemberParams{
+		Name:  "test member 1",
+		Email: "member1@example.com",
+	}
+	expect := db.Member{
+		ID:    1,
+		Name:  "test member 1",
+		Email: "member1@example.com",
+	}
+
+	mockRepo.EXPECT().CreateMember(gomock.Any(), &param).Return(&expect, nil)
+
+	member, err := uc.CreateMember(context.Background(), &param)
+	assert.NoError(t, err)
+	assert.Equal(t, &expect, member)
+}
+
+func TestFindMemberByIdFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockMemberRepository(ctrl)
+	uc := usecase.NewMemberUsecase(mockRepo)
+
+	id := 1
+
+	mockRepo.EXPECT().GetMemberById(gomock.Any(), id).Return(nil, errors.New("error"))
+
+	member, err := uc.FindMemberById(context.Background(), id)
+	assert.Error(t, err)
+	assert.Nil(t, member)
+}
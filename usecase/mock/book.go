@@ -10,6 +10,9 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	db "github.com/rentaro-m-b/ai-model-exam/db"
+	job "github.com/rentaro-m-b/ai-model-exam/job"
+	repository "github.com/rentaro-m-b/ai-model-exam/repository"
+	usecase "github.com/rentaro-m-b/ai-model-exam/usecase"
 )
 
 // MockBookUsecase is a mock of BookUsecase interface.
@@ -35,19 +38,139 @@ func (m *MockBookUsecase) EXPECT() *MockBookUsecaseMockRecorder {
 	return m.recorder
 }
 
+// BulkUpdateBooks mocks base method.
+func (m *MockBookUsecase) BulkUpdateBooks(ctx context.Context, filter *db.ListBooksByFilterParams, patch *repository.BookPatch, dryRun bool) (*usecase.BulkResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkUpdateBooks", ctx, filter, patch, dryRun)
+	ret0, _ := ret[0].(*usecase.BulkResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkUpdateBooks indicates an expected call of BulkUpdateBooks.
+func (mr *MockBookUsecaseMockRecorder) BulkUpdateBooks(ctx, filter, patch, dryRun interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUpdateBooks", reflect.TypeOf((*MockBookUsecase)(nil).BulkUpdateBooks), ctx, filter, patch, dryRun)
+}
+
+// BulkUpdateBooksInJob mocks base method.
+func (m *MockBookUsecase) BulkUpdateBooksInJob(ctx context.Context, j *job.Job, filter *db.ListBooksByFilterParams, patch *repository.BookPatch) (*usecase.BulkResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkUpdateBooksInJob", ctx, j, filter, patch)
+	ret0, _ := ret[0].(*usecase.BulkResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkUpdateBooksInJob indicates an expected call of BulkUpdateBooksInJob.
+func (mr *MockBookUsecaseMockRecorder) BulkUpdateBooksInJob(ctx, j, filter, patch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUpdateBooksInJob", reflect.TypeOf((*MockBookUsecase)(nil).BulkUpdateBooksInJob), ctx, j, filter, patch)
+}
+
 // CreateBook mocks base method.
-func (m *MockBookUsecase) CreateBook(ctx context.Context, param *db.CreateBookParams) (*db.Book, error) {
+func (m *MockBookUsecase) CreateBook(ctx context.Context, param *db.CreateBookParams, force bool) (*db.Book, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateBook", ctx, param)
+	ret := m.ctrl.Call(m, "CreateBook", ctx, param, force)
 	ret0, _ := ret[0].(*db.Book)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateBook indicates an expected call of CreateBook.
-func (mr *MockBookUsecaseMockRecorder) CreateBook(ctx, param interface{}) *gomock.Call {
+func (mr *MockBookUsecaseMockRecorder) CreateBook(ctx, param, force interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBook", reflect.TypeOf((*MockBookUsecase)(nil).CreateBook), ctx, param, force)
+}
+
+// CreateBookIdempotently mocks base method.
+func (m *MockBookUsecase) CreateBookIdempotently(ctx context.Context, key string, param *db.CreateBookParams, force bool) (*usecase.IdempotentBookCreation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBookIdempotently", ctx, key, param, force)
+	ret0, _ := ret[0].(*usecase.IdempotentBookCreation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBookIdempotently indicates an expected call of CreateBookIdempotently.
+func (mr *MockBookUsecaseMockRecorder) CreateBookIdempotently(ctx, key, param, force interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBookIdempotently", reflect.TypeOf((*MockBookUsecase)(nil).CreateBookIdempotently), ctx, key, param, force)
+}
+
+// DeleteBooks mocks base method.
+func (m *MockBookUsecase) DeleteBooks(ctx context.Context, filter *db.ListBooksByFilterParams, dryRun bool) (*usecase.BulkResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBooks", ctx, filter, dryRun)
+	ret0, _ := ret[0].(*usecase.BulkResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteBooks indicates an expected call of DeleteBooks.
+func (mr *MockBookUsecaseMockRecorder) DeleteBooks(ctx, filter, dryRun interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBooks", reflect.TypeOf((*MockBookUsecase)(nil).DeleteBooks), ctx, filter, dryRun)
+}
+
+// DeleteBooksInJob mocks base method.
+func (m *MockBookUsecase) DeleteBooksInJob(ctx context.Context, j *job.Job, filter *db.ListBooksByFilterParams) (*usecase.BulkResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBooksInJob", ctx, j, filter)
+	ret0, _ := ret[0].(*usecase.BulkResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteBooksInJob indicates an expected call of DeleteBooksInJob.
+func (mr *MockBookUsecaseMockRecorder) DeleteBooksInJob(ctx, j, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBooksInJob", reflect.TypeOf((*MockBookUsecase)(nil).DeleteBooksInJob), ctx, j, filter)
+}
+
+// FetchBookAuditLogs mocks base method.
+func (m *MockBookUsecase) FetchBookAuditLogs(ctx context.Context, bookId int) ([]db.BookAuditLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchBookAuditLogs", ctx, bookId)
+	ret0, _ := ret[0].([]db.BookAuditLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchBookAuditLogs indicates an expected call of FetchBookAuditLogs.
+func (mr *MockBookUsecaseMockRecorder) FetchBookAuditLogs(ctx, bookId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchBookAuditLogs", reflect.TypeOf((*MockBookUsecase)(nil).FetchBookAuditLogs), ctx, bookId)
+}
+
+// FetchBookCollectionVersion mocks base method.
+func (m *MockBookUsecase) FetchBookCollectionVersion(ctx context.Context, variant string) (*usecase.BookCollectionVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchBookCollectionVersion", ctx, variant)
+	ret0, _ := ret[0].(*usecase.BookCollectionVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchBookCollectionVersion indicates an expected call of FetchBookCollectionVersion.
+func (mr *MockBookUsecaseMockRecorder) FetchBookCollectionVersion(ctx, variant interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchBookCollectionVersion", reflect.TypeOf((*MockBookUsecase)(nil).FetchBookCollectionVersion), ctx, variant)
+}
+
+// FetchBookRatings mocks base method.
+func (m *MockBookUsecase) FetchBookRatings(ctx context.Context, bookIds []int32) (map[int32]db.BookRating, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchBookRatings", ctx, bookIds)
+	ret0, _ := ret[0].(map[int32]db.BookRating)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchBookRatings indicates an expected call of FetchBookRatings.
+func (mr *MockBookUsecaseMockRecorder) FetchBookRatings(ctx, bookIds interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBook", reflect.TypeOf((*MockBookUsecase)(nil).CreateBook), ctx, param)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchBookRatings", reflect.TypeOf((*MockBookUsecase)(nil).FetchBookRatings), ctx, bookIds)
 }
 
 // FetchBooks mocks base method.
@@ -65,6 +188,81 @@ func (mr *MockBookUsecaseMockRecorder) FetchBooks(ctx interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchBooks", reflect.TypeOf((*MockBookUsecase)(nil).FetchBooks), ctx)
 }
 
+// FetchBooksByAuthors mocks base method.
+func (m *MockBookUsecase) FetchBooksByAuthors(ctx context.Context, authors []string) ([]db.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchBooksByAuthors", ctx, authors)
+	ret0, _ := ret[0].([]db.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchBooksByAuthors indicates an expected call of FetchBooksByAuthors.
+func (mr *MockBookUsecaseMockRecorder) FetchBooksByAuthors(ctx, authors interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchBooksByAuthors", reflect.TypeOf((*MockBookUsecase)(nil).FetchBooksByAuthors), ctx, authors)
+}
+
+// FetchBooksByIds mocks base method.
+func (m *MockBookUsecase) FetchBooksByIds(ctx context.Context, ids []int32) (*usecase.BookBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchBooksByIds", ctx, ids)
+	ret0, _ := ret[0].(*usecase.BookBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchBooksByIds indicates an expected call of FetchBooksByIds.
+func (mr *MockBookUsecaseMockRecorder) FetchBooksByIds(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchBooksByIds", reflect.TypeOf((*MockBookUsecase)(nil).FetchBooksByIds), ctx, ids)
+}
+
+// FetchBooksByPublishers mocks base method.
+func (m *MockBookUsecase) FetchBooksByPublishers(ctx context.Context, publishers []string) ([]db.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchBooksByPublishers", ctx, publishers)
+	ret0, _ := ret[0].([]db.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchBooksByPublishers indicates an expected call of FetchBooksByPublishers.
+func (mr *MockBookUsecaseMockRecorder) FetchBooksByPublishers(ctx, publishers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchBooksByPublishers", reflect.TypeOf((*MockBookUsecase)(nil).FetchBooksByPublishers), ctx, publishers)
+}
+
+// FetchBooksByStock mocks base method.
+func (m *MockBookUsecase) FetchBooksByStock(ctx context.Context, inStock bool) ([]db.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchBooksByStock", ctx, inStock)
+	ret0, _ := ret[0].([]db.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchBooksByStock indicates an expected call of FetchBooksByStock.
+func (mr *MockBookUsecaseMockRecorder) FetchBooksByStock(ctx, inStock interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchBooksByStock", reflect.TypeOf((*MockBookUsecase)(nil).FetchBooksByStock), ctx, inStock)
+}
+
+// FilterBooks mocks base method.
+func (m *MockBookUsecase) FilterBooks(ctx context.Context, param *db.FilterBooksParams) ([]db.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FilterBooks", ctx, param)
+	ret0, _ := ret[0].([]db.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FilterBooks indicates an expected call of FilterBooks.
+func (mr *MockBookUsecaseMockRecorder) FilterBooks(ctx, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FilterBooks", reflect.TypeOf((*MockBookUsecase)(nil).FilterBooks), ctx, param)
+}
+
 // FindBookById mocks base method.
 func (m *MockBookUsecase) FindBookById(ctx context.Context, id int) (*db.Book, error) {
 	m.ctrl.T.Helper()
@@ -79,3 +277,63 @@ func (mr *MockBookUsecaseMockRecorder) FindBookById(ctx, id interface{}) *gomock
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBookById", reflect.TypeOf((*MockBookUsecase)(nil).FindBookById), ctx, id)
 }
+
+// MergeBook mocks base method.
+func (m *MockBookUsecase) MergeBook(ctx context.Context, sourceId, targetId int) (*db.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeBook", ctx, sourceId, targetId)
+	ret0, _ := ret[0].(*db.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeBook indicates an expected call of MergeBook.
+func (mr *MockBookUsecaseMockRecorder) MergeBook(ctx, sourceId, targetId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeBook", reflect.TypeOf((*MockBookUsecase)(nil).MergeBook), ctx, sourceId, targetId)
+}
+
+// PurgeExpiredIdempotencyKeys mocks base method.
+func (m *MockBookUsecase) PurgeExpiredIdempotencyKeys(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpiredIdempotencyKeys", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeExpiredIdempotencyKeys indicates an expected call of PurgeExpiredIdempotencyKeys.
+func (mr *MockBookUsecaseMockRecorder) PurgeExpiredIdempotencyKeys(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpiredIdempotencyKeys", reflect.TypeOf((*MockBookUsecase)(nil).PurgeExpiredIdempotencyKeys), ctx)
+}
+
+// SearchBooks mocks base method.
+func (m *MockBookUsecase) SearchBooks(ctx context.Context, param *db.SearchBooksParams) ([]db.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchBooks", ctx, param)
+	ret0, _ := ret[0].([]db.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchBooks indicates an expected call of SearchBooks.
+func (mr *MockBookUsecaseMockRecorder) SearchBooks(ctx, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchBooks", reflect.TypeOf((*MockBookUsecase)(nil).SearchBooks), ctx, param)
+}
+
+// UpdateBook mocks base method.
+func (m *MockBookUsecase) UpdateBook(ctx context.Context, param *db.UpdateBookParams) (*db.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBook", ctx, param)
+	ret0, _ := ret[0].(*db.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBook indicates an expected call of UpdateBook.
+func (mr *MockBookUsecaseMockRecorder) UpdateBook(ctx, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBook", reflect.TypeOf((*MockBookUsecase)(nil).UpdateBook), ctx, param)
+}
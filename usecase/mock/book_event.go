@@ -0,0 +1,77 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase/book_event.go
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	event "github.com/rentaro-m-b/ai-model-exam/event"
+)
+
+// MockBookEventUsecase is a mock of BookEventUsecase interface.
+type MockBookEventUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockBookEventUsecaseMockRecorder
+}
+
+// MockBookEventUsecaseMockRecorder is the mock recorder for MockBookEventUsecase.
+type MockBookEventUsecaseMockRecorder struct {
+	mock *MockBookEventUsecase
+}
+
+// NewMockBookEventUsecase creates a new mock instance.
+func NewMockBookEventUsecase(ctrl *gomock.Controller) *MockBookEventUsecase {
+	mock := &MockBookEventUsecase{ctrl: ctrl}
+	mock.recorder = &MockBookEventUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBookEventUsecase) EXPECT() *MockBookEventUsecaseMockRecorder {
+	return m.recorder
+}
+
+// FetchEventsAfter mocks base method.
+func (m *MockBookEventUsecase) FetchEventsAfter(ctx context.Context, after event.Cursor) ([]event.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchEventsAfter", ctx, after)
+	ret0, _ := ret[0].([]event.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchEventsAfter indicates an expected call of FetchEventsAfter.
+func (mr *MockBookEventUsecaseMockRecorder) FetchEventsAfter(ctx, after interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchEventsAfter", reflect.TypeOf((*MockBookEventUsecase)(nil).FetchEventsAfter), ctx, after)
+}
+
+// Subscribe mocks base method.
+func (m *MockBookEventUsecase) Subscribe() *event.Subscription {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe")
+	ret0, _ := ret[0].(*event.Subscription)
+	return ret0
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockBookEventUsecaseMockRecorder) Subscribe() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockBookEventUsecase)(nil).Subscribe))
+}
+
+// Unsubscribe mocks base method.
+func (m *MockBookEventUsecase) Unsubscribe(sub *event.Subscription) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Unsubscribe", sub)
+}
+
+// Unsubscribe indicates an expected call of Unsubscribe.
+func (mr *MockBookEventUsecaseMockRecorder) Unsubscribe(sub interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsubscribe", reflect.TypeOf((*MockBookEventUsecase)(nil).Unsubscribe), sub)
+}
@@ -0,0 +1,157 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase/category.go
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	pgtype "github.com/jackc/pgx/v5/pgtype"
+	db "github.com/rentaro-m-b/ai-model-exam/db"
+	usecase "github.com/rentaro-m-b/ai-model-exam/usecase"
+)
+
+// MockCategoryUsecase is a mock of CategoryUsecase interface.
+type MockCategoryUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockCategoryUsecaseMockRecorder
+}
+
+// MockCategoryUsecaseMockRecorder is the mock recorder for MockCategoryUsecase.
+type MockCategoryUsecaseMockRecorder struct {
+	mock *MockCategoryUsecase
+}
+
+// NewMockCategoryUsecase creates a new mock instance.
+func NewMockCategoryUsecase(ctrl *gomock.Controller) *MockCategoryUsecase {
+	mock := &MockCategoryUsecase{ctrl: ctrl}
+	mock.recorder = &MockCategoryUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCategoryUsecase) EXPECT() *MockCategoryUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreateCategory mocks base method.
+func (m *MockCategoryUsecase) CreateCategory(ctx context.Context, param *db.CreateCategoryParams) (*db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCategory", ctx, param)
+	ret0, _ := ret[0].(*db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCategory indicates an expected call of CreateCategory.
+func (mr *MockCategoryUsecaseMockRecorder) CreateCategory(ctx, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockCategoryUsecase)(nil).CreateCategory), ctx, param)
+}
+
+// DeleteCategory mocks base method.
+func (m *MockCategoryUsecase) DeleteCategory(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategory", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCategory indicates an expected call of DeleteCategory.
+func (mr *MockCategoryUsecaseMockRecorder) DeleteCategory(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockCategoryUsecase)(nil).DeleteCategory), ctx, id)
+}
+
+// FetchBookCategories mocks base method.
+func (m *MockCategoryUsecase) FetchBookCategories(ctx context.Context, bookId int) ([]db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchBookCategories", ctx, bookId)
+	ret0, _ := ret[0].([]db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchBookCategories indicates an expected call of FetchBookCategories.
+func (mr *MockCategoryUsecaseMockRecorder) FetchBookCategories(ctx, bookId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchBookCategories", reflect.TypeOf((*MockCategoryUsecase)(nil).FetchBookCategories), ctx, bookId)
+}
+
+// FetchCategoryTree mocks base method.
+func (m *MockCategoryUsecase) FetchCategoryTree(ctx context.Context) ([]db.ListCategoryTreeRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchCategoryTree", ctx)
+	ret0, _ := ret[0].([]db.ListCategoryTreeRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchCategoryTree indicates an expected call of FetchCategoryTree.
+func (mr *MockCategoryUsecaseMockRecorder) FetchCategoryTree(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchCategoryTree", reflect.TypeOf((*MockCategoryUsecase)(nil).FetchCategoryTree), ctx)
+}
+
+// FindCategoryById mocks base method.
+func (m *MockCategoryUsecase) FindCategoryById(ctx context.Context, id int) (*usecase.CategoryDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCategoryById", ctx, id)
+	ret0, _ := ret[0].(*usecase.CategoryDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCategoryById indicates an expected call of FindCategoryById.
+func (mr *MockCategoryUsecaseMockRecorder) FindCategoryById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCategoryById", reflect.TypeOf((*MockCategoryUsecase)(nil).FindCategoryById), ctx, id)
+}
+
+// MoveCategory mocks base method.
+func (m *MockCategoryUsecase) MoveCategory(ctx context.Context, id int, parentId pgtype.Int4) (*db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveCategory", ctx, id, parentId)
+	ret0, _ := ret[0].(*db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveCategory indicates an expected call of MoveCategory.
+func (mr *MockCategoryUsecaseMockRecorder) MoveCategory(ctx, id, parentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveCategory", reflect.TypeOf((*MockCategoryUsecase)(nil).MoveCategory), ctx, id, parentId)
+}
+
+// RenameCategory mocks base method.
+func (m *MockCategoryUsecase) RenameCategory(ctx context.Context, id int, name string) (*db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameCategory", ctx, id, name)
+	ret0, _ := ret[0].(*db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenameCategory indicates an expected call of RenameCategory.
+func (mr *MockCategoryUsecaseMockRecorder) RenameCategory(ctx, id, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameCategory", reflect.TypeOf((*MockCategoryUsecase)(nil).RenameCategory), ctx, id, name)
+}
+
+// SetBookCategories mocks base method.
+func (m *MockCategoryUsecase) SetBookCategories(ctx context.Context, bookId int, categoryIds []int32) ([]db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBookCategories", ctx, bookId, categoryIds)
+	ret0, _ := ret[0].([]db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetBookCategories indicates an expected call of SetBookCategories.
+func (mr *MockCategoryUsecaseMockRecorder) SetBookCategories(ctx, bookId, categoryIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBookCategories", reflect.TypeOf((*MockCategoryUsecase)(nil).SetBookCategories), ctx, bookId, categoryIds)
+}
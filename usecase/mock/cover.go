@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase/cover.go
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	db "github.com/rentaro-m-b/ai-model-exam/db"
+	usecase "github.com/rentaro-m-b/ai-model-exam/usecase"
+)
+
+// MockCoverUsecase is a mock of CoverUsecase interface.
+type MockCoverUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockCoverUsecaseMockRecorder
+}
+
+// MockCoverUsecaseMockRecorder is the mock recorder for MockCoverUsecase.
+type MockCoverUsecaseMockRecorder struct {
+	mock *MockCoverUsecase
+}
+
+// NewMockCoverUsecase creates a new mock instance.
+func NewMockCoverUsecase(ctrl *gomock.Controller) *MockCoverUsecase {
+	mock := &MockCoverUsecase{ctrl: ctrl}
+	mock.recorder = &MockCoverUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCoverUsecase) EXPECT() *MockCoverUsecaseMockRecorder {
+	return m.recorder
+}
+
+// FetchCover mocks base method.
+func (m *MockCoverUsecase) FetchCover(ctx context.Context, bookId int, size string) (*usecase.CoverImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchCover", ctx, bookId, size)
+	ret0, _ := ret[0].(*usecase.CoverImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchCover indicates an expected call of FetchCover.
+func (mr *MockCoverUsecaseMockRecorder) FetchCover(ctx, bookId, size interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchCover", reflect.TypeOf((*MockCoverUsecase)(nil).FetchCover), ctx, bookId, size)
+}
+
+// UploadCover mocks base method.
+func (m *MockCoverUsecase) UploadCover(ctx context.Context, bookId int, data []byte) (*db.BookCover, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadCover", ctx, bookId, data)
+	ret0, _ := ret[0].(*db.BookCover)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadCover indicates an expected call of UploadCover.
+func (mr *MockCoverUsecaseMockRecorder) UploadCover(ctx, bookId, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadCover", reflect.TypeOf((*MockCoverUsecase)(nil).UploadCover), ctx, bookId, data)
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase/exchange_rate.go
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	db "github.com/rentaro-m-b/ai-model-exam/db"
+)
+
+// MockExchangeRateUsecase is a mock of ExchangeRateUsecase interface.
+type MockExchangeRateUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockExchangeRateUsecaseMockRecorder
+}
+
+// MockExchangeRateUsecaseMockRecorder is the mock recorder for MockExchangeRateUsecase.
+type MockExchangeRateUsecaseMockRecorder struct {
+	mock *MockExchangeRateUsecase
+}
+
+// NewMockExchangeRateUsecase creates a new mock instance.
+func NewMockExchangeRateUsecase(ctrl *gomock.Controller) *MockExchangeRateUsecase {
+	mock := &MockExchangeRateUsecase{ctrl: ctrl}
+	mock.recorder = &MockExchangeRateUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExchangeRateUsecase) EXPECT() *MockExchangeRateUsecaseMockRecorder {
+	return m.recorder
+}
+
+// ImportRates mocks base method.
+func (m *MockExchangeRateUsecase) ImportRates(ctx context.Context, rates []db.UpsertExchangeRateParams) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportRates", ctx, rates)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportRates indicates an expected call of ImportRates.
+func (mr *MockExchangeRateUsecaseMockRecorder) ImportRates(ctx, rates interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportRates", reflect.TypeOf((*MockExchangeRateUsecase)(nil).ImportRates), ctx, rates)
+}
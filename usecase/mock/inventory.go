@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase/inventory.go
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	db "github.com/rentaro-m-b/ai-model-exam/db"
+)
+
+// MockInventoryUsecase is a mock of InventoryUsecase interface.
+type MockInventoryUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockInventoryUsecaseMockRecorder
+}
+
+// MockInventoryUsecaseMockRecorder is the mock recorder for MockInventoryUsecase.
+type MockInventoryUsecaseMockRecorder struct {
+	mock *MockInventoryUsecase
+}
+
+// NewMockInventoryUsecase creates a new mock instance.
+func NewMockInventoryUsecase(ctrl *gomock.Controller) *MockInventoryUsecase {
+	mock := &MockInventoryUsecase{ctrl: ctrl}
+	mock.recorder = &MockInventoryUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInventoryUsecase) EXPECT() *MockInventoryUsecaseMockRecorder {
+	return m.recorder
+}
+
+// AdjustStock mocks base method.
+func (m *MockInventoryUsecase) AdjustStock(ctx context.Context, bookId int, location string, quantity int, reason string) (*db.StockMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdjustStock", ctx, bookId, location, quantity, reason)
+	ret0, _ := ret[0].(*db.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdjustStock indicates an expected call of AdjustStock.
+func (mr *MockInventoryUsecaseMockRecorder) AdjustStock(ctx, bookId, location, quantity, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustStock", reflect.TypeOf((*MockInventoryUsecase)(nil).AdjustStock), ctx, bookId, location, quantity, reason)
+}
+
+// FetchInventories mocks base method.
+func (m *MockInventoryUsecase) FetchInventories(ctx context.Context, bookId int) ([]db.Inventory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchInventories", ctx, bookId)
+	ret0, _ := ret[0].([]db.Inventory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchInventories indicates an expected call of FetchInventories.
+func (mr *MockInventoryUsecaseMockRecorder) FetchInventories(ctx, bookId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchInventories", reflect.TypeOf((*MockInventoryUsecase)(nil).FetchInventories), ctx, bookId)
+}
+
+// FetchStockMovements mocks base method.
+func (m *MockInventoryUsecase) FetchStockMovements(ctx context.Context, bookId int) ([]db.StockMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchStockMovements", ctx, bookId)
+	ret0, _ := ret[0].([]db.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchStockMovements indicates an expected call of FetchStockMovements.
+func (mr *MockInventoryUsecaseMockRecorder) FetchStockMovements(ctx, bookId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchStockMovements", reflect.TypeOf((*MockInventoryUsecase)(nil).FetchStockMovements), ctx, bookId)
+}
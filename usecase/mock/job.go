@@ -0,0 +1,143 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase/job.go
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	db "github.com/rentaro-m-b/ai-model-exam/db"
+	repository "github.com/rentaro-m-b/ai-model-exam/repository"
+	usecase "github.com/rentaro-m-b/ai-model-exam/usecase"
+)
+
+// MockJobUsecase is a mock of JobUsecase interface.
+type MockJobUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockJobUsecaseMockRecorder
+}
+
+// MockJobUsecaseMockRecorder is the mock recorder for MockJobUsecase.
+type MockJobUsecaseMockRecorder struct {
+	mock *MockJobUsecase
+}
+
+// NewMockJobUsecase creates a new mock instance.
+func NewMockJobUsecase(ctrl *gomock.Controller) *MockJobUsecase {
+	mock := &MockJobUsecase{ctrl: ctrl}
+	mock.recorder = &MockJobUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJobUsecase) EXPECT() *MockJobUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CancelJob mocks base method.
+func (m *MockJobUsecase) CancelJob(ctx context.Context, id int64) (*db.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelJob", ctx, id)
+	ret0, _ := ret[0].(*db.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelJob indicates an expected call of CancelJob.
+func (mr *MockJobUsecaseMockRecorder) CancelJob(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelJob", reflect.TypeOf((*MockJobUsecase)(nil).CancelJob), ctx, id)
+}
+
+// EnqueueBookBulkDelete mocks base method.
+func (m *MockJobUsecase) EnqueueBookBulkDelete(ctx context.Context, filter *db.ListBooksByFilterParams) (*db.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqueueBookBulkDelete", ctx, filter)
+	ret0, _ := ret[0].(*db.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnqueueBookBulkDelete indicates an expected call of EnqueueBookBulkDelete.
+func (mr *MockJobUsecaseMockRecorder) EnqueueBookBulkDelete(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueBookBulkDelete", reflect.TypeOf((*MockJobUsecase)(nil).EnqueueBookBulkDelete), ctx, filter)
+}
+
+// EnqueueBookBulkUpdate mocks base method.
+func (m *MockJobUsecase) EnqueueBookBulkUpdate(ctx context.Context, filter *db.ListBooksByFilterParams, patch *repository.BookPatch) (*db.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqueueBookBulkUpdate", ctx, filter, patch)
+	ret0, _ := ret[0].(*db.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnqueueBookBulkUpdate indicates an expected call of EnqueueBookBulkUpdate.
+func (mr *MockJobUsecaseMockRecorder) EnqueueBookBulkUpdate(ctx, filter, patch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueBookBulkUpdate", reflect.TypeOf((*MockJobUsecase)(nil).EnqueueBookBulkUpdate), ctx, filter, patch)
+}
+
+// EnqueueBookExport mocks base method.
+func (m *MockJobUsecase) EnqueueBookExport(ctx context.Context) (*db.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqueueBookExport", ctx)
+	ret0, _ := ret[0].(*db.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnqueueBookExport indicates an expected call of EnqueueBookExport.
+func (mr *MockJobUsecaseMockRecorder) EnqueueBookExport(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueBookExport", reflect.TypeOf((*MockJobUsecase)(nil).EnqueueBookExport), ctx)
+}
+
+// EnqueueExchangeRateImport mocks base method.
+func (m *MockJobUsecase) EnqueueExchangeRateImport(ctx context.Context, rates []db.UpsertExchangeRateParams) (*db.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqueueExchangeRateImport", ctx, rates)
+	ret0, _ := ret[0].(*db.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnqueueExchangeRateImport indicates an expected call of EnqueueExchangeRateImport.
+func (mr *MockJobUsecaseMockRecorder) EnqueueExchangeRateImport(ctx, rates interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueExchangeRateImport", reflect.TypeOf((*MockJobUsecase)(nil).EnqueueExchangeRateImport), ctx, rates)
+}
+
+// FetchJobArtifact mocks base method.
+func (m *MockJobUsecase) FetchJobArtifact(ctx context.Context, id int64) (*usecase.JobArtifact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchJobArtifact", ctx, id)
+	ret0, _ := ret[0].(*usecase.JobArtifact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchJobArtifact indicates an expected call of FetchJobArtifact.
+func (mr *MockJobUsecaseMockRecorder) FetchJobArtifact(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchJobArtifact", reflect.TypeOf((*MockJobUsecase)(nil).FetchJobArtifact), ctx, id)
+}
+
+// FindJobById mocks base method.
+func (m *MockJobUsecase) FindJobById(ctx context.Context, id int64) (*db.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindJobById", ctx, id)
+	ret0, _ := ret[0].(*db.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindJobById indicates an expected call of FindJobById.
+func (mr *MockJobUsecaseMockRecorder) FindJobById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindJobById", reflect.TypeOf((*MockJobUsecase)(nil).FindJobById), ctx, id)
+}
@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase/loan.go
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	db "github.com/rentaro-m-b/ai-model-exam/db"
+)
+
+// MockLoanUsecase is a mock of LoanUsecase interface.
+type MockLoanUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoanUsecaseMockRecorder
+}
+
+// MockLoanUsecaseMockRecorder is the mock recorder for MockLoanUsecase.
+type MockLoanUsecaseMockRecorder struct {
+	mock *MockLoanUsecase
+}
+
+// NewMockLoanUsecase creates a new mock instance.
+func NewMockLoanUsecase(ctrl *gomock.Controller) *MockLoanUsecase {
+	mock := &MockLoanUsecase{ctrl: ctrl}
+	mock.recorder = &MockLoanUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoanUsecase) EXPECT() *MockLoanUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Checkout mocks base method.
+func (m *MockLoanUsecase) Checkout(ctx context.Context, bookId, memberId int) (*db.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Checkout", ctx, bookId, memberId)
+	ret0, _ := ret[0].(*db.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Checkout indicates an expected call of Checkout.
+func (mr *MockLoanUsecaseMockRecorder) Checkout(ctx, bookId, memberId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Checkout", reflect.TypeOf((*MockLoanUsecase)(nil).Checkout), ctx, bookId, memberId)
+}
+
+// FetchOverdueLoans mocks base method.
+func (m *MockLoanUsecase) FetchOverdueLoans(ctx context.Context) ([]db.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchOverdueLoans", ctx)
+	ret0, _ := ret[0].([]db.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchOverdueLoans indicates an expected call of FetchOverdueLoans.
+func (mr *MockLoanUsecaseMockRecorder) FetchOverdueLoans(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchOverdueLoans", reflect.TypeOf((*MockLoanUsecase)(nil).FetchOverdueLoans), ctx)
+}
+
+// FindAvailability mocks base method.
+func (m *MockLoanUsecase) FindAvailability(ctx context.Context, bookId int) (*db.GetBookAvailabilityRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAvailability", ctx, bookId)
+	ret0, _ := ret[0].(*db.GetBookAvailabilityRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAvailability indicates an expected call of FindAvailability.
+func (mr *MockLoanUsecaseMockRecorder) FindAvailability(ctx, bookId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAvailability", reflect.TypeOf((*MockLoanUsecase)(nil).FindAvailability), ctx, bookId)
+}
+
+// ReturnLoan mocks base method.
+func (m *MockLoanUsecase) ReturnLoan(ctx context.Context, id int) (*db.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReturnLoan", ctx, id)
+	ret0, _ := ret[0].(*db.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReturnLoan indicates an expected call of ReturnLoan.
+func (mr *MockLoanUsecaseMockRecorder) ReturnLoan(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReturnLoan", reflect.TypeOf((*MockLoanUsecase)(nil).ReturnLoan), ctx, id)
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase/member.go
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	db "github.com/rentaro-m-b/ai-model-exam/db"
+)
+
+// MockMemberUsecase is a mock of MemberUsecase interface.
+type MockMemberUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockMemberUsecaseMockRecorder
+}
+
+// MockMemberUsecaseMockRecorder is the mock recorder for MockMemberUsecase.
+type MockMemberUsecaseMockRecorder struct {
+	mock *MockMemberUsecase
+}
+
+// NewMockMemberUsecase creates a new mock instance.
+func NewMockMemberUsecase(ctrl *gomock.Controller) *MockMemberUsecase {
+	mock := &MockMemberUsecase{ctrl: ctrl}
+	mock.recorder = &MockMemberUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMemberUsecase) EXPECT() *MockMemberUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreateMember mocks base method.
+func (m *MockMemberUsecase) CreateMember(ctx context.Context, param *db.CreateMemberParams) (*db.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMember", ctx, param)
+	ret0, _ := ret[0].(*db.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMember indicates an expected call of CreateMember.
+func (mr *MockMemberUsecaseMockRecorder) CreateMember(ctx, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMember", reflect.TypeOf((*MockMemberUsecase)(nil).CreateMember), ctx, param)
+}
+
+// FindMemberById mocks base method.
+func (m *MockMemberUsecase) FindMemberById(ctx context.Context, id int) (*db.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindMemberById", ctx, id)
+	ret0, _ := ret[0].(*db.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindMemberById indicates an expected call of FindMemberById.
+func (mr *MockMemberUsecaseMockRecorder) FindMemberById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindMemberById", reflect.TypeOf((*MockMemberUsecase)(nil).FindMemberById), ctx, id)
+}
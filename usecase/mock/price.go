@@ -0,0 +1,114 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase/price.go
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	db "github.com/rentaro-m-b/ai-model-exam/db"
+	money "github.com/rentaro-m-b/ai-model-exam/money"
+	usecase "github.com/rentaro-m-b/ai-model-exam/usecase"
+)
+
+// MockPriceUsecase is a mock of PriceUsecase interface.
+type MockPriceUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockPriceUsecaseMockRecorder
+}
+
+// MockPriceUsecaseMockRecorder is the mock recorder for MockPriceUsecase.
+type MockPriceUsecaseMockRecorder struct {
+	mock *MockPriceUsecase
+}
+
+// NewMockPriceUsecase creates a new mock instance.
+func NewMockPriceUsecase(ctrl *gomock.Controller) *MockPriceUsecase {
+	mock := &MockPriceUsecase{ctrl: ctrl}
+	mock.recorder = &MockPriceUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPriceUsecase) EXPECT() *MockPriceUsecaseMockRecorder {
+	return m.recorder
+}
+
+// ApplyPromotions mocks base method.
+func (m *MockPriceUsecase) ApplyPromotions(ctx context.Context, prices map[int32]db.BookPrice, at time.Time) (map[int32]usecase.DiscountedPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyPromotions", ctx, prices, at)
+	ret0, _ := ret[0].(map[int32]usecase.DiscountedPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyPromotions indicates an expected call of ApplyPromotions.
+func (mr *MockPriceUsecaseMockRecorder) ApplyPromotions(ctx, prices, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyPromotions", reflect.TypeOf((*MockPriceUsecase)(nil).ApplyPromotions), ctx, prices, at)
+}
+
+// ConvertPrices mocks base method.
+func (m *MockPriceUsecase) ConvertPrices(ctx context.Context, prices map[int32]db.BookPrice, currency string) (map[int32]money.Conversion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConvertPrices", ctx, prices, currency)
+	ret0, _ := ret[0].(map[int32]money.Conversion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConvertPrices indicates an expected call of ConvertPrices.
+func (mr *MockPriceUsecaseMockRecorder) ConvertPrices(ctx, prices, currency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConvertPrices", reflect.TypeOf((*MockPriceUsecase)(nil).ConvertPrices), ctx, prices, currency)
+}
+
+// FetchPrices mocks base method.
+func (m *MockPriceUsecase) FetchPrices(ctx context.Context, bookId int) ([]db.BookPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchPrices", ctx, bookId)
+	ret0, _ := ret[0].([]db.BookPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchPrices indicates an expected call of FetchPrices.
+func (mr *MockPriceUsecaseMockRecorder) FetchPrices(ctx, bookId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchPrices", reflect.TypeOf((*MockPriceUsecase)(nil).FetchPrices), ctx, bookId)
+}
+
+// FetchPricesInEffect mocks base method.
+func (m *MockPriceUsecase) FetchPricesInEffect(ctx context.Context, bookIds []int32, at time.Time) (map[int32]db.BookPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchPricesInEffect", ctx, bookIds, at)
+	ret0, _ := ret[0].(map[int32]db.BookPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchPricesInEffect indicates an expected call of FetchPricesInEffect.
+func (mr *MockPriceUsecaseMockRecorder) FetchPricesInEffect(ctx, bookIds, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchPricesInEffect", reflect.TypeOf((*MockPriceUsecase)(nil).FetchPricesInEffect), ctx, bookIds, at)
+}
+
+// SchedulePrice mocks base method.
+func (m *MockPriceUsecase) SchedulePrice(ctx context.Context, bookId int, currency string, amount int64, effectiveFrom time.Time) (*db.BookPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SchedulePrice", ctx, bookId, currency, amount, effectiveFrom)
+	ret0, _ := ret[0].(*db.BookPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SchedulePrice indicates an expected call of SchedulePrice.
+func (mr *MockPriceUsecaseMockRecorder) SchedulePrice(ctx, bookId, currency, amount, effectiveFrom interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SchedulePrice", reflect.TypeOf((*MockPriceUsecase)(nil).SchedulePrice), ctx, bookId, currency, amount, effectiveFrom)
+}
@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase/promotion.go
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	db "github.com/rentaro-m-b/ai-model-exam/db"
+)
+
+// MockPromotionUsecase is a mock of PromotionUsecase interface.
+type MockPromotionUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockPromotionUsecaseMockRecorder
+}
+
+// MockPromotionUsecaseMockRecorder is the mock recorder for MockPromotionUsecase.
+type MockPromotionUsecaseMockRecorder struct {
+	mock *MockPromotionUsecase
+}
+
+// NewMockPromotionUsecase creates a new mock instance.
+func NewMockPromotionUsecase(ctrl *gomock.Controller) *MockPromotionUsecase {
+	mock := &MockPromotionUsecase{ctrl: ctrl}
+	mock.recorder = &MockPromotionUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPromotionUsecase) EXPECT() *MockPromotionUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreatePromotion mocks base method.
+func (m *MockPromotionUsecase) CreatePromotion(ctx context.Context, param *db.CreatePromotionParams) (*db.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePromotion", ctx, param)
+	ret0, _ := ret[0].(*db.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePromotion indicates an expected call of CreatePromotion.
+func (mr *MockPromotionUsecaseMockRecorder) CreatePromotion(ctx, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePromotion", reflect.TypeOf((*MockPromotionUsecase)(nil).CreatePromotion), ctx, param)
+}
+
+// DeletePromotion mocks base method.
+func (m *MockPromotionUsecase) DeletePromotion(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePromotion", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePromotion indicates an expected call of DeletePromotion.
+func (mr *MockPromotionUsecaseMockRecorder) DeletePromotion(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePromotion", reflect.TypeOf((*MockPromotionUsecase)(nil).DeletePromotion), ctx, id)
+}
+
+// FetchPromotions mocks base method.
+func (m *MockPromotionUsecase) FetchPromotions(ctx context.Context) ([]db.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchPromotions", ctx)
+	ret0, _ := ret[0].([]db.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchPromotions indicates an expected call of FetchPromotions.
+func (mr *MockPromotionUsecaseMockRecorder) FetchPromotions(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchPromotions", reflect.TypeOf((*MockPromotionUsecase)(nil).FetchPromotions), ctx)
+}
+
+// FindPromotionById mocks base method.
+func (m *MockPromotionUsecase) FindPromotionById(ctx context.Context, id int) (*db.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPromotionById", ctx, id)
+	ret0, _ := ret[0].(*db.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPromotionById indicates an expected call of FindPromotionById.
+func (mr *MockPromotionUsecaseMockRecorder) FindPromotionById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPromotionById", reflect.TypeOf((*MockPromotionUsecase)(nil).FindPromotionById), ctx, id)
+}
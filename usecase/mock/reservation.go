@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase/reservation.go
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	db "github.com/rentaro-m-b/ai-model-exam/db"
+)
+
+// MockReservationUsecase is a mock of ReservationUsecase interface.
+type MockReservationUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockReservationUsecaseMockRecorder
+}
+
+// MockReservationUsecaseMockRecorder is the mock recorder for MockReservationUsecase.
+type MockReservationUsecaseMockRecorder struct {
+	mock *MockReservationUsecase
+}
+
+// NewMockReservationUsecase creates a new mock instance.
+func NewMockReservationUsecase(ctrl *gomock.Controller) *MockReservationUsecase {
+	mock := &MockReservationUsecase{ctrl: ctrl}
+	mock.recorder = &MockReservationUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReservationUsecase) EXPECT() *MockReservationUsecaseMockRecorder {
+	return m.recorder
+}
+
+// FetchReservations mocks base method.
+func (m *MockReservationUsecase) FetchReservations(ctx context.Context, bookId int) ([]db.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchReservations", ctx, bookId)
+	ret0, _ := ret[0].([]db.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchReservations indicates an expected call of FetchReservations.
+func (mr *MockReservationUsecaseMockRecorder) FetchReservations(ctx, bookId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchReservations", reflect.TypeOf((*MockReservationUsecase)(nil).FetchReservations), ctx, bookId)
+}
+
+// Reserve mocks base method.
+func (m *MockReservationUsecase) Reserve(ctx context.Context, bookId, memberId int) (*db.Reservation, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reserve", ctx, bookId, memberId)
+	ret0, _ := ret[0].(*db.Reservation)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Reserve indicates an expected call of Reserve.
+func (mr *MockReservationUsecaseMockRecorder) Reserve(ctx, bookId, memberId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reserve", reflect.TypeOf((*MockReservationUsecase)(nil).Reserve), ctx, bookId, memberId)
+}
@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase/review.go
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	db "github.com/rentaro-m-b/ai-model-exam/db"
+)
+
+// MockReviewUsecase is a mock of ReviewUsecase interface.
+type MockReviewUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockReviewUsecaseMockRecorder
+}
+
+// MockReviewUsecaseMockRecorder is the mock recorder for MockReviewUsecase.
+type MockReviewUsecaseMockRecorder struct {
+	mock *MockReviewUsecase
+}
+
+// NewMockReviewUsecase creates a new mock instance.
+func NewMockReviewUsecase(ctrl *gomock.Controller) *MockReviewUsecase {
+	mock := &MockReviewUsecase{ctrl: ctrl}
+	mock.recorder = &MockReviewUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReviewUsecase) EXPECT() *MockReviewUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreateReview mocks base method.
+func (m *MockReviewUsecase) CreateReview(ctx context.Context, param *db.CreateReviewParams) (*db.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReview", ctx, param)
+	ret0, _ := ret[0].(*db.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReview indicates an expected call of CreateReview.
+func (mr *MockReviewUsecaseMockRecorder) CreateReview(ctx, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReview", reflect.TypeOf((*MockReviewUsecase)(nil).CreateReview), ctx, param)
+}
+
+// DeleteReview mocks base method.
+func (m *MockReviewUsecase) DeleteReview(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteReview", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteReview indicates an expected call of DeleteReview.
+func (mr *MockReviewUsecaseMockRecorder) DeleteReview(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReview", reflect.TypeOf((*MockReviewUsecase)(nil).DeleteReview), ctx, id)
+}
+
+// FetchBookReviews mocks base method.
+func (m *MockReviewUsecase) FetchBookReviews(ctx context.Context, bookId int) ([]db.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchBookReviews", ctx, bookId)
+	ret0, _ := ret[0].([]db.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchBookReviews indicates an expected call of FetchBookReviews.
+func (mr *MockReviewUsecaseMockRecorder) FetchBookReviews(ctx, bookId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchBookReviews", reflect.TypeOf((*MockReviewUsecase)(nil).FetchBookReviews), ctx, bookId)
+}
+
+// UpdateReview mocks base method.
+func (m *MockReviewUsecase) UpdateReview(ctx context.Context, param *db.UpdateReviewParams) (*db.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateReview", ctx, param)
+	ret0, _ := ret[0].(*db.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateReview indicates an expected call of UpdateReview.
+func (mr *MockReviewUsecaseMockRecorder) UpdateReview(ctx, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReview", reflect.TypeOf((*MockReviewUsecase)(nil).UpdateReview), ctx, param)
+}
@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase/tag.go
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	db "github.com/rentaro-m-b/ai-model-exam/db"
+)
+
+// MockTagUsecase is a mock of TagUsecase interface.
+type MockTagUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagUsecaseMockRecorder
+}
+
+// MockTagUsecaseMockRecorder is the mock recorder for MockTagUsecase.
+type MockTagUsecaseMockRecorder struct {
+	mock *MockTagUsecase
+}
+
+// NewMockTagUsecase creates a new mock instance.
+func NewMockTagUsecase(ctrl *gomock.Controller) *MockTagUsecase {
+	mock := &MockTagUsecase{ctrl: ctrl}
+	mock.recorder = &MockTagUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTagUsecase) EXPECT() *MockTagUsecaseMockRecorder {
+	return m.recorder
+}
+
+// AddBookTag mocks base method.
+func (m *MockTagUsecase) AddBookTag(ctx context.Context, bookId int, name string) (*db.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddBookTag", ctx, bookId, name)
+	ret0, _ := ret[0].(*db.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddBookTag indicates an expected call of AddBookTag.
+func (mr *MockTagUsecaseMockRecorder) AddBookTag(ctx, bookId, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBookTag", reflect.TypeOf((*MockTagUsecase)(nil).AddBookTag), ctx, bookId, name)
+}
+
+// FetchBookTags mocks base method.
+func (m *MockTagUsecase) FetchBookTags(ctx context.Context, bookId int) ([]db.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchBookTags", ctx, bookId)
+	ret0, _ := ret[0].([]db.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchBookTags indicates an expected call of FetchBookTags.
+func (mr *MockTagUsecaseMockRecorder) FetchBookTags(ctx, bookId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchBookTags", reflect.TypeOf((*MockTagUsecase)(nil).FetchBookTags), ctx, bookId)
+}
+
+// RemoveBookTag mocks base method.
+func (m *MockTagUsecase) RemoveBookTag(ctx context.Context, bookId int, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveBookTag", ctx, bookId, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveBookTag indicates an expected call of RemoveBookTag.
+func (mr *MockTagUsecaseMockRecorder) RemoveBookTag(ctx, bookId, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveBookTag", reflect.TypeOf((*MockTagUsecase)(nil).RemoveBookTag), ctx, bookId, name)
+}
+
+// SuggestTags mocks base method.
+func (m *MockTagUsecase) SuggestTags(ctx context.Context, prefix string, limit int) ([]db.SearchTagsByPrefixRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuggestTags", ctx, prefix, limit)
+	ret0, _ := ret[0].([]db.SearchTagsByPrefixRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SuggestTags indicates an expected call of SuggestTags.
+func (mr *MockTagUsecaseMockRecorder) SuggestTags(ctx, prefix, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuggestTags", reflect.TypeOf((*MockTagUsecase)(nil).SuggestTags), ctx, prefix, limit)
+}
@@ -0,0 +1,155 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase/webhook.go
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	db "github.com/rentaro-m-b/ai-model-exam/db"
+)
+
+// MockWebhookUsecase is a mock of WebhookUsecase interface.
+type MockWebhookUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookUsecaseMockRecorder
+}
+
+// MockWebhookUsecaseMockRecorder is the mock recorder for MockWebhookUsecase.
+type MockWebhookUsecaseMockRecorder struct {
+	mock *MockWebhookUsecase
+}
+
+// NewMockWebhookUsecase creates a new mock instance.
+func NewMockWebhookUsecase(ctrl *gomock.Controller) *MockWebhookUsecase {
+	mock := &MockWebhookUsecase{ctrl: ctrl}
+	mock.recorder = &MockWebhookUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookUsecase) EXPECT() *MockWebhookUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreateWebhook mocks base method.
+func (m *MockWebhookUsecase) CreateWebhook(ctx context.Context, param *db.CreateWebhookSubscriptionParams) (*db.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhook", ctx, param)
+	ret0, _ := ret[0].(*db.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhook indicates an expected call of CreateWebhook.
+func (mr *MockWebhookUsecaseMockRecorder) CreateWebhook(ctx, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhook", reflect.TypeOf((*MockWebhookUsecase)(nil).CreateWebhook), ctx, param)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockWebhookUsecase) DeleteWebhook(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockWebhookUsecaseMockRecorder) DeleteWebhook(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockWebhookUsecase)(nil).DeleteWebhook), ctx, id)
+}
+
+// FetchDeadLetters mocks base method.
+func (m *MockWebhookUsecase) FetchDeadLetters(ctx context.Context) ([]db.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchDeadLetters", ctx)
+	ret0, _ := ret[0].([]db.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchDeadLetters indicates an expected call of FetchDeadLetters.
+func (mr *MockWebhookUsecaseMockRecorder) FetchDeadLetters(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchDeadLetters", reflect.TypeOf((*MockWebhookUsecase)(nil).FetchDeadLetters), ctx)
+}
+
+// FetchDeliveries mocks base method.
+func (m *MockWebhookUsecase) FetchDeliveries(ctx context.Context, id int, status string) ([]db.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchDeliveries", ctx, id, status)
+	ret0, _ := ret[0].([]db.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchDeliveries indicates an expected call of FetchDeliveries.
+func (mr *MockWebhookUsecaseMockRecorder) FetchDeliveries(ctx, id, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchDeliveries", reflect.TypeOf((*MockWebhookUsecase)(nil).FetchDeliveries), ctx, id, status)
+}
+
+// FetchWebhooks mocks base method.
+func (m *MockWebhookUsecase) FetchWebhooks(ctx context.Context) ([]db.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchWebhooks", ctx)
+	ret0, _ := ret[0].([]db.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchWebhooks indicates an expected call of FetchWebhooks.
+func (mr *MockWebhookUsecaseMockRecorder) FetchWebhooks(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchWebhooks", reflect.TypeOf((*MockWebhookUsecase)(nil).FetchWebhooks), ctx)
+}
+
+// FindWebhookById mocks base method.
+func (m *MockWebhookUsecase) FindWebhookById(ctx context.Context, id int) (*db.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindWebhookById", ctx, id)
+	ret0, _ := ret[0].(*db.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindWebhookById indicates an expected call of FindWebhookById.
+func (mr *MockWebhookUsecaseMockRecorder) FindWebhookById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindWebhookById", reflect.TypeOf((*MockWebhookUsecase)(nil).FindWebhookById), ctx, id)
+}
+
+// Redeliver mocks base method.
+func (m *MockWebhookUsecase) Redeliver(ctx context.Context, id int, deliveryId int64) (*db.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redeliver", ctx, id, deliveryId)
+	ret0, _ := ret[0].(*db.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Redeliver indicates an expected call of Redeliver.
+func (mr *MockWebhookUsecaseMockRecorder) Redeliver(ctx, id, deliveryId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeliver", reflect.TypeOf((*MockWebhookUsecase)(nil).Redeliver), ctx, id, deliveryId)
+}
+
+// UpdateWebhook mocks base method.
+func (m *MockWebhookUsecase) UpdateWebhook(ctx context.Context, param *db.UpdateWebhookSubscriptionParams) (*db.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhook", ctx, param)
+	ret0, _ := ret[0].(*db.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWebhook indicates an expected call of UpdateWebhook.
+func (mr *MockWebhookUsecaseMockRecorder) UpdateWebhook(ctx, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhook", reflect.TypeOf((*MockWebhookUsecase)(nil).UpdateWebhook), ctx, param)
+}
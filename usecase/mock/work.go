@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase/work.go
+
+// Package mock_usecase is a generated GoMock package.
+package mock_usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	db "github.com/rentaro-m-b/ai-model-exam/db"
+	usecase "github.com/rentaro-m-b/ai-model-exam/usecase"
+)
+
+// MockWorkUsecase is a mock of WorkUsecase interface.
+type MockWorkUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkUsecaseMockRecorder
+}
+
+// MockWorkUsecaseMockRecorder is the mock recorder for MockWorkUsecase.
+type MockWorkUsecaseMockRecorder struct {
+	mock *MockWorkUsecase
+}
+
+// NewMockWorkUsecase creates a new mock instance.
+func NewMockWorkUsecase(ctrl *gomock.Controller) *MockWorkUsecase {
+	mock := &MockWorkUsecase{ctrl: ctrl}
+	mock.recorder = &MockWorkUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkUsecase) EXPECT() *MockWorkUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreateWork mocks base method.
+func (m *MockWorkUsecase) CreateWork(ctx context.Context, param *db.CreateWorkParams) (*db.Work, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWork", ctx, param)
+	ret0, _ := ret[0].(*db.Work)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWork indicates an expected call of CreateWork.
+func (mr *MockWorkUsecaseMockRecorder) CreateWork(ctx, param interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWork", reflect.TypeOf((*MockWorkUsecase)(nil).CreateWork), ctx, param)
+}
+
+// FindWorkById mocks base method.
+func (m *MockWorkUsecase) FindWorkById(ctx context.Context, id int) (*usecase.WorkDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindWorkById", ctx, id)
+	ret0, _ := ret[0].(*usecase.WorkDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindWorkById indicates an expected call of FindWorkById.
+func (mr *MockWorkUsecaseMockRecorder) FindWorkById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindWorkById", reflect.TypeOf((*MockWorkUsecase)(nil).FindWorkById), ctx, id)
+}
+
+// LinkBook mocks base method.
+func (m *MockWorkUsecase) LinkBook(ctx context.Context, id, bookId int) (*db.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkBook", ctx, id, bookId)
+	ret0, _ := ret[0].(*db.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LinkBook indicates an expected call of LinkBook.
+func (mr *MockWorkUsecaseMockRecorder) LinkBook(ctx, id, bookId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkBook", reflect.TypeOf((*MockWorkUsecase)(nil).LinkBook), ctx, id, bookId)
+}
+
+// UnlinkBook mocks base method.
+func (m *MockWorkUsecase) UnlinkBook(ctx context.Context, id, bookId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlinkBook", ctx, id, bookId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlinkBook indicates an expected call of UnlinkBook.
+func (mr *MockWorkUsecaseMockRecorder) UnlinkBook(ctx, id, bookId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlinkBook", reflect.TypeOf((*MockWorkUsecase)(nil).UnlinkBook), ctx, id, bookId)
+}
@@ -0,0 +1,265 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/money"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+)
+
+var (
+	ErrUnsupportedCurrency  = errors.New("unsupported currency")
+	ErrPriceInPast          = errors.New("price must not take effect in the past")
+	ErrExchangeRateNotFound = errors.New("exchange rate not found")
+)
+
+type PriceUsecase interface {
+	FetchPrices(ctx context.Context, bookId int) ([]db.BookPrice, error)
+	FetchPricesInEffect(ctx context.Context, bookIds []int32, at time.Time) (map[int32]db.BookPrice, error)
+	SchedulePrice(ctx context.Context, bookId int, currency string, amount int64, effectiveFrom time.Time) (*db.BookPrice, error)
+	ConvertPrices(ctx context.Context, prices map[int32]db.BookPrice, currency string) (map[int32]money.Conversion, error)
+	ApplyPromotions(ctx context.Context, prices map[int32]db.BookPrice, at time.Time) (map[int32]DiscountedPrice, error)
+}
+
+// DiscountedPrice はプロモーションを適用した後の価格と、適用したプロモーションのIDを表す
+type DiscountedPrice struct {
+	Original     money.Money
+	Money        money.Money
+	PromotionIDs []int32
+}
+
+type priceUsecaseImpl struct {
+	repository             repository.PriceRepository
+	bookRepository         repository.BookRepository
+	exchangeRateRepository repository.ExchangeRateRepository
+	promotionRepository    repository.PromotionRepository
+	rounding               money.RoundingMode
+	now                    func() time.Time
+}
+
+func NewPriceUsecase(repository repository.PriceRepository, bookRepository repository.BookRepository, exchangeRateRepository repository.ExchangeRateRepository, promotionRepository repository.PromotionRepository, rounding money.RoundingMode) PriceUsecase {
+	return &priceUsecaseImpl{
+		repository:             repository,
+		bookRepository:         bookRepository,
+		exchangeRateRepository: exchangeRateRepository,
+		promotionRepository:    promotionRepository,
+		rounding:               rounding,
+		now:                    time.Now,
+	}
+}
+
+func (u *priceUsecaseImpl) FetchPrices(ctx context.Context, bookId int) ([]db.BookPrice, error) {
+	if _, err := u.bookRepository.GetBookById(ctx, bookId); err != nil {
+		log.Printf("Unable to execute PriceUsecaseFetchPrices: %d\n", err)
+		return nil, err
+	}
+
+	prices, err := u.repository.ListPrices(ctx, bookId)
+	if err != nil {
+		log.Printf("Unable to execute PriceUsecaseFetchPrices: %d\n", err)
+		return nil, err
+	}
+
+	return prices, nil
+}
+
+// FetchPricesInEffect は指定日時（ゼロ値の場合は現在）に有効な価格を書籍IDごとに返す
+// 有効な価格がない書籍は結果に含まれない
+func (u *priceUsecaseImpl) FetchPricesInEffect(ctx context.Context, bookIds []int32, at time.Time) (map[int32]db.BookPrice, error) {
+	if at.IsZero() {
+		at = u.now()
+	}
+	prices, err := u.repository.ListPricesInEffect(ctx, bookIds, at)
+	if err != nil {
+		log.Printf("Unable to execute PriceUsecaseFetchPricesInEffect: %d\n", err)
+		return nil, err
+	}
+
+	res := make(map[int32]db.BookPrice, len(prices))
+	for _, price := range prices {
+		res[price.BookID] = price
+	}
+
+	return res, nil
+}
+
+// SchedulePrice は価格の変更を予約する
+// 開始日時が未指定（ゼロ値）の場合は即時に有効とし、過去の日時は履歴を書き換えるため受け付けない
+func (u *priceUsecaseImpl) SchedulePrice(ctx context.Context, bookId int, currency string, amount int64, effectiveFrom time.Time) (*db.BookPrice, error) {
+	if _, ok := money.LookupCurrency(currency); !ok {
+		log.Printf("Unable to execute PriceUsecaseSchedulePrice: %d\n", ErrUnsupportedCurrency)
+		return nil, ErrUnsupportedCurrency
+	}
+
+	now := u.now()
+	if effectiveFrom.IsZero() {
+		effectiveFrom = now
+	} else if effectiveFrom.Before(now) {
+		log.Printf("Unable to execute PriceUsecaseSchedulePrice: %d\n", ErrPriceInPast)
+		return nil, ErrPriceInPast
+	}
+
+	price, err := u.repository.SchedulePrice(ctx, &repository.SchedulePriceParams{
+		BookID:        int32(bookId),
+		Currency:      currency,
+		Amount:        amount,
+		EffectiveFrom: effectiveFrom,
+	})
+	if err != nil {
+		log.Printf("Unable to execute PriceUsecaseSchedulePrice: %d\n", err)
+		return nil, err
+	}
+
+	return price, nil
+}
+
+// ConvertPrices は価格を指定した通貨に換算する
+// レートはリクエスト時点で有効なものを用い、換算元の通貨ごとに1回だけ取得する
+func (u *priceUsecaseImpl) ConvertPrices(ctx context.Context, prices map[int32]db.BookPrice, currency string) (map[int32]money.Conversion, error) {
+	to, ok := money.LookupCurrency(currency)
+	if !ok {
+		log.Printf("Unable to execute PriceUsecaseConvertPrices: %d\n", ErrUnsupportedCurrency)
+		return nil, ErrUnsupportedCurrency
+	}
+
+	now := u.now()
+	rates := map[string]*db.ExchangeRate{}
+	res := make(map[int32]money.Conversion, len(prices))
+	for bookId, price := range prices {
+		from, ok := money.LookupCurrency(price.Currency)
+		if !ok {
+			log.Printf("Unable to execute PriceUsecaseConvertPrices: %d\n", ErrUnsupportedCurrency)
+			return nil, ErrUnsupportedCurrency
+		}
+		original := money.Money{Amount: price.Amount, Currency: from}
+		if from.Code == to.Code {
+			res[bookId] = money.Conversion{Original: original, Money: original, Rate: big.NewRat(1, 1)}
+			continue
+		}
+
+		rate, ok := rates[from.Code]
+		if !ok {
+			var err error
+			rate, err = u.exchangeRateRepository.GetRate(ctx, from.Code, to.Code, now)
+			if errors.Is(err, pgx.ErrNoRows) {
+				log.Printf("Unable to execute PriceUsecaseConvertPrices: %d\n", err)
+				return nil, fmt.Errorf("%w: %s to %s", ErrExchangeRateNotFound, from.Code, to.Code)
+			}
+			if err != nil {
+				log.Printf("Unable to execute PriceUsecaseConvertPrices: %d\n", err)
+				return nil, err
+			}
+			rates[from.Code] = rate
+		}
+
+		r := numericToRat(rate.Rate)
+		if rate.BaseCurrency != from.Code {
+			r.Inv(r)
+		}
+		res[bookId] = money.Conversion{
+			Original: original,
+			Money:    money.Convert(original, r, to, u.rounding),
+			Rate:     r,
+			RateDate: rate.EffectiveDate.Time,
+		}
+	}
+
+	return res, nil
+}
+
+// ApplyPromotions は指定日時（ゼロ値の場合は現在）に有効なプロモーションを価格に適用する
+// 価格のある全ての書籍について結果を返し、適用できるプロモーションがない書籍は元の価格のままとする
+func (u *priceUsecaseImpl) ApplyPromotions(ctx context.Context, prices map[int32]db.BookPrice, at time.Time) (map[int32]DiscountedPrice, error) {
+	if at.IsZero() {
+		at = u.now()
+	}
+
+	res := make(map[int32]DiscountedPrice, len(prices))
+	if len(prices) == 0 {
+		return res, nil
+	}
+
+	bookIds := make([]int32, 0, len(prices))
+	for bookId := range prices {
+		bookIds = append(bookIds, bookId)
+	}
+	slices.Sort(bookIds)
+
+	rows, err := u.promotionRepository.ListActivePromotions(ctx, bookIds, at)
+	if err != nil {
+		log.Printf("Unable to execute PriceUsecaseApplyPromotions: %d\n", err)
+		return nil, err
+	}
+	promotions := map[int32][]db.Promotion{}
+	for _, row := range rows {
+		promotions[row.BookID] = append(promotions[row.BookID], row.Promotion)
+	}
+
+	for bookId, price := range prices {
+		currency, ok := money.LookupCurrency(price.Currency)
+		if !ok {
+			log.Printf("Unable to execute PriceUsecaseApplyPromotions: %d\n", ErrUnsupportedCurrency)
+			return nil, ErrUnsupportedCurrency
+		}
+		original := money.Money{Amount: price.Amount, Currency: currency}
+		discounted, promotionIds := applyPromotions(original, promotions[bookId], u.rounding)
+		res[bookId] = DiscountedPrice{
+			Original:     original,
+			Money:        discounted,
+			PromotionIDs: promotionIds,
+		}
+	}
+
+	return res, nil
+}
+
+// applyPromotions は優先度の高い順に並んだプロモーションを価格に適用する
+// 併用不可のプロモーションは他に適用したものがない場合に限り単独で適用し、併用可のプロモーションは値引き後の価格に順に重ねて適用する
+// 定額値引きは価格と同じ通貨のものだけを適用し、値引き後の価格は0未満にしない
+func applyPromotions(price money.Money, promotions []db.Promotion, rounding money.RoundingMode) (money.Money, []int32) {
+	applied := []int32{}
+	for _, promotion := range promotions {
+		if !promotion.Stackable && len(applied) > 0 {
+			continue
+		}
+
+		switch promotion.DiscountType {
+		case DiscountTypePercentage:
+			price = money.Convert(price, big.NewRat(100-promotion.DiscountValue, 100), price.Currency, rounding)
+		case DiscountTypeFixedAmount:
+			if promotion.Currency.String != price.Currency.Code {
+				continue
+			}
+			price.Amount = max(price.Amount-promotion.DiscountValue, 0)
+		default:
+			continue
+		}
+		applied = append(applied, promotion.ID)
+
+		if !promotion.Stackable {
+			break
+		}
+	}
+
+	return price, applied
+}
+
+// numericToRat はnumeric型の値を有理数に変換する
+func numericToRat(n pgtype.Numeric) *big.Rat {
+	r := new(big.Rat).SetInt(n.Int)
+	exp := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(max(n.Exp, -n.Exp))), nil)
+	if n.Exp >= 0 {
+		return r.Mul(r, new(big.Rat).SetInt(exp))
+	}
+
+	return r.Quo(r, new(big.Rat).SetInt(exp))
+}
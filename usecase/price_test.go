@@ -0,0 +1,321 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/money"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	mock_repository "github.com/rentaro-m-b/ai-model-exam/repository/mock"
+	"github.com/rentaro-m-b/ai-model-exam/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFetchPrices(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockPriceRepository(ctrl)
+	mockBookRepo := mock_repository.NewMockBookRepository(ctrl)
+	mockRateRepo := mock_repository.NewMockExchangeRateRepository(ctrl)
+	mockPromotionRepo := mock_repository.NewMockPromotionRepository(ctrl)
+	uc := usecase.NewPriceUsecase(mockRepo, mockBookRepo, mockRateRepo, mockPromotionRepo, money.RoundHalfUp)
+
+	expects := []db.BookPrice{
+		{ID: 1, BookID: 1, Currency: "JPY", Amount: 3080},
+	}
+
+	mockBookRepo.EXPECT().GetBookById(gomock.Any(), 1).Return(&db.Book{ID: 1}, nil)
+	mockRepo.EXPECT().ListPrices(gomock.Any(), 1).Return(expects, nil)
+
+	prices, err := uc.FetchPrices(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, expects, prices)
+}
+
+func TestFetchPricesFailureBookNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockPriceRepository(ctrl)
+	mockBookRepo := mock_repository.NewMockBookRepository(ctrl)
+	mockRateRepo := mock_repository.NewMockExchangeRateRepository(ctrl)
+	mockPromotionRepo := mock_repository.NewMockPromotionRepository(ctrl)
+	uc := usecase.NewPriceUsecase(mockRepo, mockBookRepo, mockRateRepo, mockPromotionRepo, money.RoundHalfUp)
+
+	mockBookRepo.EXPECT().GetBookById(gomock.Any(), 1).Return(nil, pgx.ErrNoRows)
+
+	prices, err := uc.FetchPrices(context.Background(), 1)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+	assert.Nil(t, prices)
+}
+
+func TestFetchPricesInEffect(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockPriceRepository(ctrl)
+	mockBookRepo := mock_repository.NewMockBookRepository(ctrl)
+	mockRateRepo := mock_repository.NewMockExchangeRateRepository(ctrl)
+	mockPromotionRepo := mock_repository.NewMockPromotionRepository(ctrl)
+	uc := usecase.NewPriceUsecase(mockRepo, mockBookRepo, mockRateRepo, mockPromotionRepo, money.RoundHalfUp)
+
+	at := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	prices := []db.BookPrice{
+		{ID: 1, BookID: 1, Currency: "JPY", Amount: 3080},
+		{ID: 5, BookID: 3, Currency: "USD", Amount: 1999},
+	}
+
+	mockRepo.EXPECT().ListPricesInEffect(gomock.Any(), []int32{1, 2, 3}, at).Return(prices, nil)
+
+	res, err := uc.FetchPricesInEffect(context.Background(), []int32{1, 2, 3}, at)
+	assert.NoError(t, err)
+	assert.Equal(t, map[int32]db.BookPrice{1: prices[0], 3: prices[1]}, res)
+}
+
+func TestFetchPricesInEffectDefaultsToNow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockPriceRepository(ctrl)
+	mockBookRepo := mock_repository.NewMockBookRepository(ctrl)
+	mockRateRepo := mock_repository.NewMockExchangeRateRepository(ctrl)
+	mockPromotionRepo := mock_repository.NewMockPromotionRepository(ctrl)
+	uc := usecase.NewPriceUsecase(mockRepo, mockBookRepo, mockRateRepo, mockPromotionRepo, money.RoundHalfUp)
+
+	mockRepo.EXPECT().ListPricesInEffect(gomock.Any(), []int32{1}, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ []int32, at time.Time) ([]db.BookPrice, error) {
+			assert.False(t, at.IsZero())
+			return nil, nil
+		})
+
+	res, err := uc.FetchPricesInEffect(context.Background(), []int32{1}, time.Time{})
+	assert.NoError(t, err)
+	assert.Empty(t, res)
+}
+
+func TestSchedulePrice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockPriceRepository(ctrl)
+	mockBookRepo := mock_repository.NewMockBookRepository(ctrl)
+	mockRateRepo := mock_repository.NewMockExchangeRateRepository(ctrl)
+	mockPromotionRepo := mock_repository.NewMockPromotionRepository(ctrl)
+	uc := usecase.NewPriceUsecase(mockRepo, mockBookRepo, mockRateRepo, mockPromotionRepo, money.RoundHalfUp)
+
+	effectiveFrom := time.Now().AddDate(0, 1, 0)
+	expect := db.BookPrice{ID: 1, BookID: 1, Currency: "USD", Amount: 1999}
+
+	mockRepo.EXPECT().SchedulePrice(gomock.Any(), &repository.SchedulePriceParams{
+		BookID:        1,
+		Currency:      "USD",
+		Amount:        1999,
+		EffectiveFrom: effectiveFrom,
+	}).Return(&expect, nil)
+
+	price, err := uc.SchedulePrice(context.Background(), 1, "USD", 1999, effectiveFrom)
+	assert.NoError(t, err)
+	assert.Equal(t, &expect, price)
+}
+
+func TestSchedulePriceFailureUnsupportedCurrency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockPriceRepository(ctrl)
+	mockBookRepo := mock_repository.NewMockBookRepository(ctrl)
+	mockRateRepo := mock_repository.NewMockExchangeRateRepository(ctrl)
+	mockPromotionRepo := mock_repository.NewMockPromotionRepository(ctrl)
+	uc := usecase.NewPriceUsecase(mockRepo, mockBookRepo, mockRateRepo, mockPromotionRepo, money.RoundHalfUp)
+
+	price, err := uc.SchedulePrice(context.Background(), 1, "XXX", 1999, time.Time{})
+	assert.ErrorIs(t, err, usecase.ErrUnsupportedCurrency)
+	assert.Nil(t, price)
+}
+
+func TestSchedulePriceFailureInPast(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockPriceRepository(ctrl)
+	mockBookRepo := mock_repository.NewMockBookRepository(ctrl)
+	mockRateRepo := mock_repository.NewMockExchangeRateRepository(ctrl)
+	mockPromotionRepo := mock_repository.NewMockPromotionRepository(ctrl)
+	uc := usecase.NewPriceUsecase(mockRepo, mockBookRepo, mockRateRepo, mockPromotionRepo, money.RoundHalfUp)
+
+	price, err := uc.SchedulePrice(context.Background(), 1, "JPY", 2800, time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.ErrorIs(t, err, usecase.ErrPriceInPast)
+	assert.Nil(t, price)
+}
+
+func TestSchedulePriceFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockPriceRepository(ctrl)
+	mockBookRepo := mock_repository.NewMockBookRepository(ctrl)
+	mockRateRepo := mock_repository.NewMockExchangeRateRepository(ctrl)
+	mockPromotionRepo := mock_repository.NewMockPromotionRepository(ctrl)
+	uc := usecase.NewPriceUsecase(mockRepo, mockBookRepo, mockRateRepo, mockPromotionRepo, money.RoundHalfUp)
+
+	mockRepo.EXPECT().SchedulePrice(gomock.Any(), gomock.Any()).Return(nil, errors.New("error"))
+
+	price, err := uc.SchedulePrice(context.Background(), 1, "JPY", 2800, time.Time{})
+	assert.Error(t, err)
+	assert.Nil(t, price)
+}
+
+func TestConvertPrices(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockPriceRepository(ctrl)
+	mockBookRepo := mock_repository.NewMockBookRepository(ctrl)
+	mockRateRepo := mock_repository.NewMockExchangeRateRepository(ctrl)
+	mockPromotionRepo := mock_repository.NewMockPromotionRepository(ctrl)
+	uc := usecase.NewPriceUsecase(mockRepo, mockBookRepo, mockRateRepo, mockPromotionRepo, money.RoundHalfUp)
+
+	jpy, _ := money.LookupCurrency("JPY")
+	usd, _ := money.LookupCurrency("USD")
+	rateDate := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	prices := map[int32]db.BookPrice{
+		1: {ID: 1, BookID: 1, Currency: "JPY", Amount: 3080},
+		2: {ID: 2, BookID: 2, Currency: "JPY", Amount: 2860},
+		3: {ID: 3, BookID: 3, Currency: "USD", Amount: 1999},
+	}
+
+	// USD/JPYのレートのみ登録されている場合は、逆数をJPYからUSDへのレートとして用いる
+	mockRateRepo.EXPECT().GetRate(gomock.Any(), "JPY", "USD", gomock.Any()).Return(&db.ExchangeRate{
+		BaseCurrency:  "USD",
+		QuoteCurrency: "JPY",
+		Rate:          pgtype.Numeric{Int: big.NewInt(154), Exp: 0, Valid: true},
+		EffectiveDate: pgtype.Date{Time: rateDate, Valid: true},
+	}, nil).Times(1)
+
+	conversions, err := uc.ConvertPrices(context.Background(), prices, "USD")
+	assert.NoError(t, err)
+	assert.Equal(t, money.Conversion{
+		Original: money.Money{Amount: 3080, Currency: jpy},
+		Money:    money.Money{Amount: 2000, Currency: usd},
+		Rate:     big.NewRat(1, 154),
+		RateDate: rateDate,
+	}, conversions[1])
+	assert.Equal(t, int64(1857), conversions[2].Money.Amount)
+	assert.Equal(t, money.Conversion{
+		Original: money.Money{Amount: 1999, Currency: usd},
+		Money:    money.Money{Amount: 1999, Currency: usd},
+		Rate:     big.NewRat(1, 1),
+	}, conversions[3])
+}
+
+func TestConvertPricesFailureRateNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockPriceRepository(ctrl)
+	mockBookRepo := mock_repository.NewMockBookRepository(ctrl)
+	mockRateRepo := mock_repository.NewMockExchangeRateRepository(ctrl)
+	mockPromotionRepo := mock_repository.NewMockPromotionRepository(ctrl)
+	uc := usecase.NewPriceUsecase(mockRepo, mockBookRepo, mockRateRepo, mockPromotionRepo, money.RoundHalfUp)
+
+	mockRateRepo.EXPECT().GetRate(gomock.Any(), "JPY", "EUR", gomock.Any()).Return(nil, pgx.ErrNoRows)
+
+	conversions, err := uc.ConvertPrices(context.Background(), map[int32]db.BookPrice{
+		1: {ID: 1, BookID: 1, Currency: "JPY", Amount: 3080},
+	}, "EUR")
+	assert.ErrorIs(t, err, usecase.ErrExchangeRateNotFound)
+	assert.Nil(t, conversions)
+}
+
+func TestConvertPricesFailureUnsupportedCurrency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockPriceRepository(ctrl)
+	mockBookRepo := mock_repository.NewMockBookRepository(ctrl)
+	mockRateRepo := mock_repository.NewMockExchangeRateRepository(ctrl)
+	mockPromotionRepo := mock_repository.NewMockPromotionRepository(ctrl)
+	uc := usecase.NewPriceUsecase(mockRepo, mockBookRepo, mockRateRepo, mockPromotionRepo, money.RoundHalfUp)
+
+	conversions, err := uc.ConvertPrices(context.Background(), map[int32]db.BookPrice{}, "XXX")
+	assert.ErrorIs(t, err, usecase.ErrUnsupportedCurrency)
+	assert.Nil(t, conversions)
+}
+
+func TestApplyPromotions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockPriceRepository(ctrl)
+	mockBookRepo := mock_repository.NewMockBookRepository(ctrl)
+	mockRateRepo := mock_repository.NewMockExchangeRateRepository(ctrl)
+	mockPromotionRepo := mock_repository.NewMockPromotionRepository(ctrl)
+	uc := usecase.NewPriceUsecase(mockRepo, mockBookRepo, mockRateRepo, mockPromotionRepo, money.RoundHalfUp)
+
+	jpy, _ := money.LookupCurrency("JPY")
+	usd, _ := money.LookupCurrency("USD")
+	at := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	prices := map[int32]db.BookPrice{
+		1: {ID: 1, BookID: 1, Currency: "JPY", Amount: 3080},
+		2: {ID: 2, BookID: 2, Currency: "JPY", Amount: 2000},
+		3: {ID: 3, BookID: 3, Currency: "USD", Amount: 1999},
+		4: {ID: 4, BookID: 4, Currency: "JPY", Amount: 1000},
+	}
+	percentage := func(id int32, value int64, stackable bool) db.Promotion {
+		return db.Promotion{ID: id, DiscountType: usecase.DiscountTypePercentage, DiscountValue: value, Stackable: stackable}
+	}
+	fixed := func(id int32, value int64, currency string, stackable bool) db.Promotion {
+		return db.Promotion{ID: id, DiscountType: usecase.DiscountTypeFixedAmount, DiscountValue: value, Currency: pgtype.Text{String: currency, Valid: true}, Stackable: stackable}
+	}
+
+	mockPromotionRepo.EXPECT().ListActivePromotions(gomock.Any(), []int32{1, 2, 3, 4}, at).Return([]db.ListActivePromotionsByBookIDsRow{
+		// 併用可のプロモーションは値引き後の価格に重ねて適用する
+		{BookID: 1, Promotion: percentage(1, 10, true)},
+		{BookID: 1, Promotion: fixed(2, 100, "JPY", true)},
+		// 併用不可のプロモーションを適用した後は、以降のプロモーションを適用しない
+		{BookID: 2, Promotion: percentage(3, 20, false)},
+		{BookID: 2, Promotion: percentage(4, 50, true)},
+		// 通貨の異なる定額値引きは適用せず、次のプロモーションを適用する
+		{BookID: 3, Promotion: fixed(5, 500, "JPY", true)},
+		{BookID: 3, Promotion: percentage(6, 15, true)},
+		// 併用可のプロモーションを適用した後は併用不可のプロモーションを適用せず、値引き後の価格は0未満にしない
+		{BookID: 4, Promotion: fixed(7, 1500, "JPY", true)},
+		{BookID: 4, Promotion: percentage(8, 30, false)},
+	}, nil)
+
+	discounts, err := uc.ApplyPromotions(context.Background(), prices, at)
+	assert.NoError(t, err)
+	assert.Equal(t, map[int32]usecase.DiscountedPrice{
+		1: {Original: money.Money{Amount: 3080, Currency: jpy}, Money: money.Money{Amount: 2672, Currency: jpy}, PromotionIDs: []int32{1, 2}},
+		2: {Original: money.Money{Amount: 2000, Currency: jpy}, Money: money.Money{Amount: 1600, Currency: jpy}, PromotionIDs: []int32{3}},
+		3: {Original: money.Money{Amount: 1999, Currency: usd}, Money: money.Money{Amount: 1699, Currency: usd}, PromotionIDs: []int32{6}},
+		4: {Original: money.Money{Amount: 1000, Currency: jpy}, Money: money.Money{Amount: 0, Currency: jpy}, PromotionIDs: []int32{7}},
+	}, discounts)
+}
+
+func TestApplyPromotionsFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockPriceRepository(ctrl)
+	mockBookRepo := mock_repository.NewMockBookRepository(ctrl)
+	mockRateRepo := mock_repository.NewMockExchangeRateRepository(ctrl)
+	mockPromotionRepo := mock_repository.NewMockPromotionRepository(ctrl)
+	uc := usecase.NewPriceUsecase(mockRepo, mockBookRepo, mockRateRepo, mockPromotionRepo, money.RoundHalfUp)
+
+	mockPromotionRepo.EXPECT().ListActivePromotions(gomock.Any(), []int32{1}, gomock.Any()).Return(nil, errors.New("error"))
+
+	discounts, err := uc.ApplyPromotions(context.Background(), map[int32]db.BookPrice{
+		1: {ID: 1, BookID: 1, Currency: "JPY", Amount: 3080},
+	}, time.Time{})
+	assert.Error(t, err)
+	assert.Nil(t, discounts)
+}
@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+	"log"
+
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/money"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+)
+
+const (
+	DiscountTypePercentage  = "percentage"
+	DiscountTypeFixedAmount = "fixed_amount"
+
+	PromotionTargetBook      = "book"
+	PromotionTargetAuthor    = "author"
+	PromotionTargetPublisher = "publisher"
+)
+
+type PromotionUsecase interface {
+	CreatePromotion(ctx context.Context, param *db.CreatePromotionParams) (*db.Promotion, error)
+	FindPromotionById(ctx context.Context, id int) (*db.Promotion, error)
+	FetchPromotions(ctx context.Context) ([]db.Promotion, error)
+	DeletePromotion(ctx context.Context, id int) error
+}
+
+type promotionUsecaseImpl struct {
+	repository     repository.PromotionRepository
+	bookRepository repository.BookRepository
+}
+
+func NewPromotionUsecase(repository repository.PromotionRepository, bookRepository repository.BookRepository) PromotionUsecase {
+	return &promotionUsecaseImpl{
+		repository:     repository,
+		bookRepository: bookRepository,
+	}
+}
+
+func (u *promotionUsecaseImpl) CreatePromotion(ctx context.Context, param *db.CreatePromotionParams) (*db.Promotion, error) {
+	if param.Currency.Valid {
+		if _, ok := money.LookupCurrency(param.Currency.String); !ok {
+			log.Printf("Unable to execute PromotionUsecaseCreatePromotion: %d\n", ErrUnsupportedCurrency)
+			return nil, ErrUnsupportedCurrency
+		}
+	}
+	if param.TargetBookID.Valid {
+		if _, err := u.bookRepository.GetBookById(ctx, int(param.TargetBookID.Int32)); err != nil {
+			log.Printf("Unable to execute PromotionUsecaseCreatePromotion: %d\n", err)
+			return nil, err
+		}
+	}
+
+	promotion, err := u.repository.CreatePromotion(ctx, param)
+	if err != nil {
+		log.Printf("Unable to execute PromotionUsecaseCreatePromotion: %d\n", err)
+		return nil, err
+	}
+
+	return promotion, nil
+}
+
+func (u *promotionUsecaseImpl) FindPromotionById(ctx context.Context, id int) (*db.Promotion, error) {
+	promotion, err := u.repository.GetPromotionById(ctx, id)
+	if err != nil {
+		log.Printf("Unable to execute PromotionUsecaseFindPromotionById: %d\n", err)
+		return nil, err
+	}
+
+	return promotion, nil
+}
+
+func (u *promotionUsecaseImpl) FetchPromotions(ctx context.Context) ([]db.Promotion, error) {
+	promotions, err := u.repository.ListPromotions(ctx)
+	if err != nil {
+		log.Printf("Unable to execute PromotionUsecaseFetchPromotions: %d\n", err)
+		return nil, err
+	}
+
+	return promotions, nil
+}
+
+func (u *promotionUsecaseImpl) DeletePromotion(ctx context.Context, id int) error {
+	if err := u.repository.DeletePromotion(ctx, id); err != nil {
+		log.Printf("Unable to execute PromotionUsecaseDeletePromotion: %d\n", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	mock_repository "github.com/rentaro-m-b/ai-model-exam/repository/mock"
+	"github.com/rentaro-m-b/ai-model-exam/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreatePromotion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockPromotionRepository(ctrl)
+	mockBookRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewPromotionUsecase(mockRepo, mockBookRepo)
+
+	param := db.CreatePromotionParams{
+		Name:          "book sale",
+		DiscountType:  usecase.DiscountTypeFixedAmount,
+		DiscountValue: 100,
+		Currency:      pgtype.Text{String: "JPY", Valid: true},
+		TargetType:    usecase.PromotionTargetBook,
+		TargetBookID:  pgtype.Int4{Int32: 1, Valid: true},
+	}
+	expect := db.Promotion{ID: 1, Name: "book sale"}
+
+	mockBookRepo.EXPECT().GetBookById(gomock.Any(), 1).Return(&db.Book{ID: 1}, nil)
+	mockRepo.EXPECT().CreatePromotion(gomock.Any(), &param).Return(&expect, nil)
+
+	promotion, err := uc.CreatePromotion(context.Background(), &param)
+	assert.NoError(t, err)
+	assert.Equal(t, &expect, promotion)
+}
+
+func TestCreatePromotionFailureUnsupportedCurrency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockPromotionRepository(ctrl)
+	mockBookRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewPromotionUsecase(mockRepo, mockBookRepo)
+
+	promotion, err := uc.CreatePromotion(context.Background(), &db.CreatePromotionParams{
+		DiscountType: usecase.DiscountTypeFixedAmount,
+		Currency:     pgtype.Text{String: "XXX", Valid: true},
+	})
+	assert.ErrorIs(t, err, usecase.ErrUnsupportedCurrency)
+	assert.Nil(t, promotion)
+}
+
+func TestCreatePromotionFailureBookNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockPromotionRepository(ctrl)
+	mockBookRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewPromotionUsecase(mockRepo, mockBookRepo)
+
+	mockBookRepo.EXPECT().GetBookById(gomock.Any(), 99).Return(nil, pgx.ErrNoRows)
+
+	promotion, err := uc.CreatePromotion(context.Background(), &db.CreatePromotionParams{
+		TargetType:   usecase.PromotionTargetBook,
+		TargetBookID: pgtype.Int4{Int32: 99, Valid: true},
+	})
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+	assert.Nil(t, promotion)
+}
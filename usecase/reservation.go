@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+)
+
+// defaultHoldPeriod は返却された書籍を予約者のために取り置く既定の期間
+const defaultHoldPeriod = 3 * 24 * time.Hour
+
+// ReservationUsecaseConfig の未指定（ゼロ値）の項目には既定値を使う
+type ReservationUsecaseConfig struct {
+	// HoldPeriod は返却された書籍を予約者のために取り置く期間
+	// 期限を過ぎた取置は失効し、待ち行列の次の予約者に割り当てられる
+	HoldPeriod time.Duration
+}
+
+type ReservationUsecase interface {
+	Reserve(ctx context.Context, bookId int, memberId int) (*db.Reservation, int, error)
+	FetchReservations(ctx context.Context, bookId int) ([]db.Reservation, error)
+}
+
+type reservationUsecaseImpl struct {
+	repository repository.ReservationRepository
+	config     ReservationUsecaseConfig
+	now        func() time.Time
+}
+
+func NewReservationUsecase(repository repository.ReservationRepository, config *ReservationUsecaseConfig) ReservationUsecase {
+	c := ReservationUsecaseConfig{}
+	if config != nil {
+		c = *config
+	}
+	if c.HoldPeriod <= 0 {
+		c.HoldPeriod = defaultHoldPeriod
+	}
+
+	return &reservationUsecaseImpl{
+		repository: repository,
+		config:     c,
+		now:        time.Now,
+	}
+}
+
+func (u *reservationUsecaseImpl) Reserve(ctx context.Context, bookId int, memberId int) (*db.Reservation, int, error) {
+	reservation, position, err := u.repository.Reserve(ctx, &repository.ReserveParams{
+		BookID:     int32(bookId),
+		MemberID:   int32(memberId),
+		Now:        u.now(),
+		HoldPeriod: u.config.HoldPeriod,
+	})
+	if err != nil {
+		log.Printf("Unable to execute ReservationUsecaseReserve: %d\n", err)
+		return nil, 0, err
+	}
+
+	return reservation, position, nil
+}
+
+func (u *reservationUsecaseImpl) FetchReservations(ctx context.Context, bookId int) ([]db.Reservation, error) {
+	reservations, err := u.repository.ListReservations(ctx, bookId, u.now(), u.config.HoldPeriod)
+	if err != nil {
+		log.Printf("Unable to execute ReservationUsecaseFetchReservations: %d\n", err)
+		return nil, err
+	}
+
+	return reservations, nil
+}
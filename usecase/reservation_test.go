@@ -0,0 +1,110 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	mock_repository "github.com/rentaro-m-b/ai-model-exam/repository/mock"
+	"github.com/rentaro-m-b/ai-model-exam/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReserve(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	config := &usecase.ReservationUsecaseConfig{HoldPeriod: 48 * time.Hour}
+	mockRepo := mock_repository.NewMockReservationRepository(ctrl)
+	uc := usecase.NewReservationUsecase(mockRepo, config)
+
+	expect := db.Reservation{
+		ID:       1,
+		BookID:   1,
+		MemberID: 2,
+		Status:   "waiting",
+	}
+
+	mockRepo.EXPECT().Reserve(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, param *repository.ReserveParams) (*db.Reservation, int, error) {
+			assert.Equal(t, int32(1), param.BookID)
+			assert.Equal(t, int32(2), param.MemberID)
+			assert.Equal(t, config.HoldPeriod, param.HoldPeriod)
+			return &expect, 3, nil
+		})
+
+	reservation, position, err := uc.Reserve(context.Background(), 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, &expect, reservation)
+	assert.Equal(t, 3, position)
+}
+
+func TestReserveDefaultHoldPeriod(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockReservationRepository(ctrl)
+	uc := usecase.NewReservationUsecase(mockRepo, nil)
+
+	// 未指定の場合は3日間取り置く
+	mockRepo.EXPECT().Reserve(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, param *repository.ReserveParams) (*db.Reservation, int, error) {
+			assert.Equal(t, 72*time.Hour, param.HoldPeriod)
+			return &db.Reservation{ID: 1}, 1, nil
+		})
+
+	_, _, err := uc.Reserve(context.Background(), 1, 2)
+	assert.NoError(t, err)
+}
+
+func TestReserveFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockReservationRepository(ctrl)
+	uc := usecase.NewReservationUsecase(mockRepo, nil)
+
+	mockRepo.EXPECT().Reserve(gomock.Any(), gomock.Any()).Return(nil, 0, repository.ErrCopyAvailable)
+
+	reservation, _, err := uc.Reserve(context.Background(), 1, 2)
+	assert.ErrorIs(t, err, repository.ErrCopyAvailable)
+	assert.Nil(t, reservation)
+}
+
+func TestFetchReservations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	config := &usecase.ReservationUsecaseConfig{HoldPeriod: 48 * time.Hour}
+	mockRepo := mock_repository.NewMockReservationRepository(ctrl)
+	uc := usecase.NewReservationUsecase(mockRepo, config)
+
+	expect := []db.Reservation{
+		{ID: 1, BookID: 1, MemberID: 2, Status: "held"},
+		{ID: 2, BookID: 1, MemberID: 3, Status: "waiting"},
+	}
+
+	mockRepo.EXPECT().ListReservations(gomock.Any(), 1, gomock.Any(), config.HoldPeriod).Return(expect, nil)
+
+	reservations, err := uc.FetchReservations(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, expect, reservations)
+}
+
+func TestFetchReservationsFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockReservationRepository(ctrl)
+	uc := usecase.NewReservationUsecase(mockRepo, nil)
+
+	mockRepo.EXPECT().ListReservations(gomock.Any(), 1, gomock.Any(), gomock.Any()).Return(nil, errors.New("error"))
+
+	reservations, err := uc.FetchReservations(context.Background(), 1)
+	assert.Error(t, err)
+	assert.Nil(t, reservations)
+}
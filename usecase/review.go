@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"context"
+	"log"
+
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+)
+
+type ReviewUsecase interface {
+	CreateReview(ctx context.Context, param *db.CreateReviewParams) (*db.Review, error)
+	UpdateReview(ctx context.Context, param *db.UpdateReviewParams) (*db.Review, error)
+	DeleteReview(ctx context.Context, id int) error
+	FetchBookReviews(ctx context.Context, bookId int) ([]db.Review, error)
+}
+
+type reviewUsecaseImpl struct {
+	repository     repository.ReviewRepository
+	bookRepository repository.BookRepository
+}
+
+func NewReviewUsecase(repository repository.ReviewRepository, bookRepository repository.BookRepository) ReviewUsecase {
+	return &reviewUsecaseImpl{
+		repository:     repository,
+		bookRepository: bookRepository,
+	}
+}
+
+func (u *reviewUsecaseImpl) CreateReview(ctx context.Context, param *db.CreateReviewParams) (*db.Review, error) {
+	review, err := u.repository.CreateReview(ctx, param)
+	if err != nil {
+		log.Printf("Unable to execute ReviewUsecaseCreateReview: %d\n", err)
+		return nil, err
+	}
+
+	return review, nil
+}
+
+func (u *reviewUsecaseImpl) UpdateReview(ctx context.Context, param *db.UpdateReviewParams) (*db.Review, error) {
+	review, err := u.repository.UpdateReview(ctx, param)
+	if err != nil {
+		log.Printf("Unable to execute ReviewUsecaseUpdateReview: %d\n", err)
+		return nil, err
+	}
+
+	return review, nil
+}
+
+func (u *reviewUsecaseImpl) DeleteReview(ctx context.Context, id int) error {
+	if err := u.repository.DeleteReview(ctx, id); err != nil {
+		log.Printf("Unable to execute ReviewUsecaseDeleteReview: %d\n", err)
+		return err
+	}
+
+	return nil
+}
+
+func (u *reviewUsecaseImpl) FetchBookReviews(ctx context.Context, bookId int) ([]db.Review, error) {
+	if _, err := u.bookRepository.GetBookById(ctx, bookId); err != nil {
+		log.Printf("Unable to execute ReviewUsecaseFetchBookReviews: %d\n", err)
+		return nil, err
+	}
+
+	reviews, err := u.repository.ListBookReviews(ctx, bookId)
+	if err != nil {
+		log.Printf("Unable to execute ReviewUsecaseFetchBookReviews: %d\n", err)
+		return nil, err
+	}
+
+	return reviews, nil
+}
@@ -0,0 +1,45 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	mock_repository "github.com/rentaro-m-b/ai-model-exam/repository/mock"
+	"github.com/rentaro-m-b/ai-model-exam/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFetchBookReviews(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockReviewRepository(ctrl)
+	mockBookRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewReviewUsecase(mockRepo, mockBookRepo)
+
+	expects := []db.Review{{ID: 2, BookID: 1, MemberID: 3, Rating: 4}}
+
+	mockBookRepo.EXPECT().GetBookById(gomock.Any(), 1).Return(&db.Book{ID: 1}, nil)
+	mockRepo.EXPECT().ListBookReviews(gomock.Any(), 1).Return(expects, nil)
+
+	reviews, err := uc.FetchBookReviews(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, expects, reviews)
+}
+
+func TestFetchBookReviewsFailureBookNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockReviewRepository(ctrl)
+	mockBookRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewReviewUsecase(mockRepo, mockBookRepo)
+
+	mockBookRepo.EXPECT().GetBookById(gomock.Any(), 99).Return(nil, pgx.ErrNoRows)
+
+	_, err := uc.FetchBookReviews(context.Background(), 99)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+}
@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+)
+
+const (
+	defaultTagSuggestionLimit = 10
+	maxTagSuggestionLimit     = 50
+)
+
+type TagUsecase interface {
+	AddBookTag(ctx context.Context, bookId int, name string) (*db.Tag, error)
+	RemoveBookTag(ctx context.Context, bookId int, name string) error
+	FetchBookTags(ctx context.Context, bookId int) ([]db.Tag, error)
+	SuggestTags(ctx context.Context, prefix string, limit int) ([]db.SearchTagsByPrefixRow, error)
+}
+
+type tagUsecaseImpl struct {
+	repository     repository.TagRepository
+	bookRepository repository.BookRepository
+}
+
+func NewTagUsecase(repository repository.TagRepository, bookRepository repository.BookRepository) TagUsecase {
+	return &tagUsecaseImpl{
+		repository:     repository,
+		bookRepository: bookRepository,
+	}
+}
+
+// normalizeTagName は表記揺れで別のタグにならないよう、前後の空白を除いて小文字にする
+func normalizeTagName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// normalizeTagNames はタグ名を正規化し、空のものと重複を取り除く
+func normalizeTagNames(names []string) []string {
+	res := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		name = normalizeTagName(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		res = append(res, name)
+	}
+
+	return res
+}
+
+func (u *tagUsecaseImpl) AddBookTag(ctx context.Context, bookId int, name string) (*db.Tag, error) {
+	tag, err := u.repository.AddBookTag(ctx, bookId, normalizeTagName(name))
+	if err != nil {
+		log.Printf("Unable to execute TagUsecaseAddBookTag: %d\n", err)
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+func (u *tagUsecaseImpl) RemoveBookTag(ctx context.Context, bookId int, name string) error {
+	if _, err := u.bookRepository.GetBookById(ctx, bookId); err != nil {
+		log.Printf("Unable to execute TagUsecaseRemoveBookTag: %d\n", err)
+		return err
+	}
+
+	if err := u.repository.RemoveBookTag(ctx, bookId, normalizeTagName(name)); err != nil {
+		log.Printf("Unable to execute TagUsecaseRemoveBookTag: %d\n", err)
+		return err
+	}
+
+	return nil
+}
+
+func (u *tagUsecaseImpl) FetchBookTags(ctx context.Context, bookId int) ([]db.Tag, error) {
+	if _, err := u.bookRepository.GetBookById(ctx, bookId); err != nil {
+		log.Printf("Unable to execute TagUsecaseFetchBookTags: %d\n", err)
+		return nil, err
+	}
+
+	tags, err := u.repository.ListBookTags(ctx, bookId)
+	if err != nil {
+		log.Printf("Unable to execute TagUsecaseFetchBookTags: %d\n", err)
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// SuggestTags は入力途中のタグ名に前方一致するタグを、使われている数の多い順に返す
+// 件数は未指定（0）の場合に既定値とし、上限を超える場合は上限に丸める
+func (u *tagUsecaseImpl) SuggestTags(ctx context.Context, prefix string, limit int) ([]db.SearchTagsByPrefixRow, error) {
+	if limit <= 0 {
+		limit = defaultTagSuggestionLimit
+	}
+	limit = min(limit, maxTagSuggestionLimit)
+
+	tags, err := u.repository.SearchTags(ctx, normalizeTagName(prefix), limit)
+	if err != nil {
+		log.Printf("Unable to execute TagUsecaseSuggestTags: %d\n", err)
+		return nil, err
+	}
+
+	return tags, nil
+}
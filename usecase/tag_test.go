@@ -0,0 +1,78 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	mock_repository "github.com/rentaro-m-b/ai-model-exam/repository/mock"
+	"github.com/rentaro-m-b/ai-model-exam/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddBookTag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockTagRepository(ctrl)
+	mockBookRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewTagUsecase(mockRepo, mockBookRepo)
+
+	expect := db.Tag{ID: 1, Name: "golang"}
+
+	mockRepo.EXPECT().AddBookTag(gomock.Any(), 1, "golang").Return(&expect, nil)
+
+	tag, err := uc.AddBookTag(context.Background(), 1, "  GoLang ")
+	assert.NoError(t, err)
+	assert.Equal(t, &expect, tag)
+}
+
+func TestRemoveBookTagFailureBookNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockTagRepository(ctrl)
+	mockBookRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewTagUsecase(mockRepo, mockBookRepo)
+
+	mockBookRepo.EXPECT().GetBookById(gomock.Any(), 99).Return(nil, pgx.ErrNoRows)
+
+	err := uc.RemoveBookTag(context.Background(), 99, "golang")
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestSuggestTags(t *testing.T) {
+	tests := []struct {
+		name   string
+		limit  int
+		expect int
+	}{
+		{name: "未指定の場合は既定の件数", limit: 0, expect: 10},
+		{name: "指定した件数", limit: 5, expect: 5},
+		{name: "上限を超える場合は上限の件数", limit: 1000, expect: 50},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock_repository.NewMockTagRepository(ctrl)
+			mockBookRepo := mock_repository.NewMockBookRepository(ctrl)
+			uc := usecase.NewTagUsecase(mockRepo, mockBookRepo)
+
+			expects := []db.SearchTagsByPrefixRow{
+				{ID: 2, Name: "golang", UsageCount: 12},
+				{ID: 1, Name: "google", UsageCount: 3},
+			}
+
+			mockRepo.EXPECT().SearchTags(gomock.Any(), "go", tt.expect).Return(expects, nil)
+
+			tags, err := uc.SuggestTags(context.Background(), "Go", tt.limit)
+			assert.NoError(t, err)
+			assert.Equal(t, expects, tags)
+		})
+	}
+}
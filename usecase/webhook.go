@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+)
+
+const (
+	maxWebhookDeliveries = 100
+)
+
+var ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+
+type WebhookUsecase interface {
+	CreateWebhook(ctx context.Context, param *db.CreateWebhookSubscriptionParams) (*db.WebhookSubscription, error)
+	FetchWebhooks(ctx context.Context) ([]db.WebhookSubscription, error)
+	FindWebhookById(ctx context.Context, id int) (*db.WebhookSubscription, error)
+	UpdateWebhook(ctx context.Context, param *db.UpdateWebhookSubscriptionParams) (*db.WebhookSubscription, error)
+	DeleteWebhook(ctx context.Context, id int) error
+	FetchDeliveries(ctx context.Context, id int, status string) ([]db.WebhookDelivery, error)
+	FetchDeadLetters(ctx context.Context) ([]db.WebhookDelivery, error)
+	Redeliver(ctx context.Context, id int, deliveryId int64) (*db.WebhookDelivery, error)
+}
+
+type webhookUsecaseImpl struct {
+	repository repository.WebhookRepository
+}
+
+func NewWebhookUsecase(repository repository.WebhookRepository) WebhookUsecase {
+	return &webhookUsecaseImpl{
+		repository: repository,
+	}
+}
+
+func (u *webhookUsecaseImpl) CreateWebhook(ctx context.Context, param *db.CreateWebhookSubscriptionParams) (*db.WebhookSubscription, error) {
+	subscription, err := u.repository.CreateWebhook(ctx, param)
+	if err != nil {
+		log.Printf("Unable to execute WebhookUsecaseCreateWebhook: %d\n", err)
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+func (u *webhookUsecaseImpl) FetchWebhooks(ctx context.Context) ([]db.WebhookSubscription, error) {
+	subscriptions, err := u.repository.ListWebhooks(ctx)
+	if err != nil {
+		log.Printf("Unable to execute WebhookUsecaseFetchWebhooks: %d\n", err)
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+func (u *webhookUsecaseImpl) FindWebhookById(ctx context.Context, id int) (*db.WebhookSubscription, error) {
+	subscription, err := u.repository.GetWebhookById(ctx, id)
+	if err != nil {
+		log.Printf("Unable to execute WebhookUsecaseFindWebhookById: %d\n", err)
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+func (u *webhookUsecaseImpl) UpdateWebhook(ctx context.Context, param *db.UpdateWebhookSubscriptionParams) (*db.WebhookSubscription, error) {
+	subscription, err := u.repository.UpdateWebhook(ctx, param)
+	if err != nil {
+		log.Printf("Unable to execute WebhookUsecaseUpdateWebhook: %d\n", err)
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+func (u *webhookUsecaseImpl) DeleteWebhook(ctx context.Context, id int) error {
+	if err := u.repository.DeleteWebhook(ctx, id); err != nil {
+		log.Printf("Unable to execute WebhookUsecaseDeleteWebhook: %d\n", err)
+		return err
+	}
+
+	return nil
+}
+
+// FetchDeliveries は購読の配信を新しい順に最大100件返し、statusが空でなければその状態の配信に絞り込む
+func (u *webhookUsecaseImpl) FetchDeliveries(ctx context.Context, id int, status string) ([]db.WebhookDelivery, error) {
+	if _, err := u.repository.GetWebhookById(ctx, id); err != nil {
+		log.Printf("Unable to execute WebhookUsecaseFetchDeliveries: %d\n", err)
+		return nil, err
+	}
+
+	param := db.ListWebhookDeliveriesParams{
+		SubscriptionID: int32(id),
+		MaxDeliveries:  maxWebhookDeliveries,
+	}
+	if status != "" {
+		param.Status.String, param.Status.Valid = status, true
+	}
+	deliveries, err := u.repository.ListDeliveries(ctx, &param)
+	if err != nil {
+		log.Printf("Unable to execute WebhookUsecaseFetchDeliveries: %d\n", err)
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// FetchDeadLetters は再送を止めた配信を全ての購読から新しい順に最大100件返す
+func (u *webhookUsecaseImpl) FetchDeadLetters(ctx context.Context) ([]db.WebhookDelivery, error) {
+	deliveries, err := u.repository.ListDeadDeliveries(ctx, maxWebhookDeliveries)
+	if err != nil {
+		log.Printf("Unable to execute WebhookUsecaseFetchDeadLetters: %d\n", err)
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// Redeliver は配信を状態によらず配信待ちに戻し、デッドレターの配信も改めて最大回数まで再送する
+// 購読が存在しない場合はpgx.ErrNoRowsを、購読の配信でない場合はErrWebhookDeliveryNotFoundを返す
+func (u *webhookUsecaseImpl) Redeliver(ctx context.Context, id int, deliveryId int64) (*db.WebhookDelivery, error) {
+	if _, err := u.repository.GetWebhookById(ctx, id); err != nil {
+		log.Printf("Unable to execute WebhookUsecaseRedeliver: %d\n", err)
+		return nil, err
+	}
+
+	delivery, err := u.repository.RequeueDelivery(ctx, id, deliveryId)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrWebhookDeliveryNotFound
+	}
+	if err != nil {
+		log.Printf("Unable to execute WebhookUsecaseRedeliver: %d\n", err)
+		return nil, err
+	}
+
+	return delivery, nil
+}
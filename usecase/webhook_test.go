@@ -0,0 +1,66 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	mock_repository "github.com/rentaro-m-b/ai-model-exam/repository/mock"
+	"github.com/rentaro-m-b/ai-model-exam/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFetchDeliveries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockWebhookRepository(ctrl)
+	uc := usecase.NewWebhookUsecase(mockRepo)
+
+	expects := []db.WebhookDelivery{{ID: 1, SubscriptionID: 1, Status: repository.WebhookDeliveryStatusDead}}
+	mockRepo.EXPECT().GetWebhookById(gomock.Any(), 1).Return(&db.WebhookSubscription{ID: 1}, nil)
+	mockRepo.EXPECT().ListDeliveries(gomock.Any(), &db.ListWebhookDeliveriesParams{
+		SubscriptionID: 1,
+		Status:         pgtype.Text{String: repository.WebhookDeliveryStatusDead, Valid: true},
+		MaxDeliveries:  100,
+	}).Return(expects, nil)
+
+	deliveries, err := uc.FetchDeliveries(context.Background(), 1, repository.WebhookDeliveryStatusDead)
+	assert.NoError(t, err)
+	assert.Equal(t, expects, deliveries)
+}
+
+func TestRedeliver(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockWebhookRepository(ctrl)
+	uc := usecase.NewWebhookUsecase(mockRepo)
+
+	expect := db.WebhookDelivery{ID: 5, SubscriptionID: 1, Status: repository.WebhookDeliveryStatusPending}
+	mockRepo.EXPECT().GetWebhookById(gomock.Any(), 1).Return(&db.WebhookSubscription{ID: 1}, nil)
+	mockRepo.EXPECT().RequeueDelivery(gomock.Any(), 1, int64(5)).Return(&expect, nil)
+
+	delivery, err := uc.Redeliver(context.Background(), 1, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, &expect, delivery)
+}
+
+func TestRedeliverFailureDeliveryNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockWebhookRepository(ctrl)
+	uc := usecase.NewWebhookUsecase(mockRepo)
+
+	mockRepo.EXPECT().GetWebhookById(gomock.Any(), 1).Return(&db.WebhookSubscription{ID: 1}, nil)
+	mockRepo.EXPECT().RequeueDelivery(gomock.Any(), 1, int64(99)).Return(nil, pgx.ErrNoRows)
+
+	delivery, err := uc.Redeliver(context.Background(), 1, 99)
+	assert.ErrorIs(t, err, usecase.ErrWebhookDeliveryNotFound)
+	assert.Nil(t, delivery)
+}
@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+)
+
+type WorkUsecase interface {
+	CreateWork(ctx context.Context, param *db.CreateWorkParams) (*db.Work, error)
+	FindWorkById(ctx context.Context, id int) (*WorkDetail, error)
+	LinkBook(ctx context.Context, id int, bookId int) (*db.Book, error)
+	UnlinkBook(ctx context.Context, id int, bookId int) error
+}
+
+// WorkDetail は作品と、出版日の古い順に並べたその版を表す
+type WorkDetail struct {
+	Work     db.Work
+	Editions []db.Book
+}
+
+type workUsecaseImpl struct {
+	repository     repository.WorkRepository
+	bookRepository repository.BookRepository
+}
+
+func NewWorkUsecase(repository repository.WorkRepository, bookRepository repository.BookRepository) WorkUsecase {
+	return &workUsecaseImpl{
+		repository:     repository,
+		bookRepository: bookRepository,
+	}
+}
+
+func (u *workUsecaseImpl) CreateWork(ctx context.Context, param *db.CreateWorkParams) (*db.Work, error) {
+	work, err := u.repository.CreateWork(ctx, param)
+	if err != nil {
+		log.Printf("Unable to execute WorkUsecaseCreateWork: %d\n", err)
+		return nil, err
+	}
+
+	return work, nil
+}
+
+func (u *workUsecaseImpl) FindWorkById(ctx context.Context, id int) (*WorkDetail, error) {
+	work, err := u.repository.GetWorkById(ctx, id)
+	if err != nil {
+		log.Printf("Unable to execute WorkUsecaseFindWorkById: %d\n", err)
+		return nil, err
+	}
+
+	editions, err := u.repository.ListWorkEditions(ctx, id)
+	if err != nil {
+		log.Printf("Unable to execute WorkUsecaseFindWorkById: %d\n", err)
+		return nil, err
+	}
+
+	return &WorkDetail{
+		Work:     *work,
+		Editions: editions,
+	}, nil
+}
+
+// LinkBook は作品が存在しない場合にErrWorkNotFoundを、書籍が存在しない場合にpgx.ErrNoRowsを返す
+func (u *workUsecaseImpl) LinkBook(ctx context.Context, id int, bookId int) (*db.Book, error) {
+	if _, err := u.repository.GetWorkById(ctx, id); err != nil {
+		log.Printf("Unable to execute WorkUsecaseLinkBook: %d\n", err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrWorkNotFound
+		}
+		return nil, err
+	}
+
+	book, err := u.bookRepository.LinkWork(ctx, bookId, id)
+	if err != nil {
+		log.Printf("Unable to execute WorkUsecaseLinkBook: %d\n", err)
+		return nil, err
+	}
+
+	return book, nil
+}
+
+func (u *workUsecaseImpl) UnlinkBook(ctx context.Context, id int, bookId int) error {
+	if err := u.bookRepository.UnlinkWork(ctx, bookId, id); err != nil {
+		log.Printf("Unable to execute WorkUsecaseUnlinkBook: %d\n", err)
+		return err
+	}
+
+	return nil
+}
+
+// CollapseByWork は同じ作品に属する書籍を代表する1冊にまとめる
+// 代表には出版日の最も古い版（出版日が未登録の版は後回し、同日ならIDの小さい版）を選び、
+// 作品に属さない書籍はそのまま残す。並び順は元の一覧での代表の位置に従う
+func CollapseByWork(books []db.Book) []db.Book {
+	representatives := make(map[int32]int, len(books))
+	for i, book := range books {
+		if !book.WorkID.Valid {
+			continue
+		}
+		j, ok := representatives[book.WorkID.Int32]
+		if !ok || precedesEdition(book, books[j]) {
+			representatives[book.WorkID.Int32] = i
+		}
+	}
+
+	res := make([]db.Book, 0, len(books))
+	for i, book := range books {
+		if book.WorkID.Valid && representatives[book.WorkID.Int32] != i {
+			continue
+		}
+		res = append(res, book)
+	}
+
+	return res
+}
+
+// precedesEdition は版aが版bより先に刊行されたものとして扱われるかを返す
+func precedesEdition(a, b db.Book) bool {
+	switch {
+	case a.PublicationDate.Valid && b.PublicationDate.Valid && !a.PublicationDate.Time.Equal(b.PublicationDate.Time):
+		return a.PublicationDate.Time.Before(b.PublicationDate.Time)
+	case a.PublicationDate.Valid != b.PublicationDate.Valid:
+		return a.PublicationDate.Valid
+	}
+
+	return a.ID < b.ID
+}
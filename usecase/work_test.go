@@ -0,0 +1,91 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rentaro-m-b/ai-model-exam/db"
+	"github.com/rentaro-m-b/ai-model-exam/repository"
+	mock_repository "github.com/rentaro-m-b/ai-model-exam/repository/mock"
+	"github.com/rentaro-m-b/ai-model-exam/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindWorkById(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockWorkRepository(ctrl)
+	uc := usecase.NewWorkUsecase(mockRepo, mock_repository.NewMockBookRepository(ctrl))
+
+	work := db.Work{ID: 1, Title: "test work"}
+	editions := []db.Book{{ID: 3}, {ID: 5}}
+	mockRepo.EXPECT().GetWorkById(gomock.Any(), 1).Return(&work, nil)
+	mockRepo.EXPECT().ListWorkEditions(gomock.Any(), 1).Return(editions, nil)
+
+	detail, err := uc.FindWorkById(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, &usecase.WorkDetail{Work: work, Editions: editions}, detail)
+}
+
+func TestLinkBookFailureWorkNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockWorkRepository(ctrl)
+	uc := usecase.NewWorkUsecase(mockRepo, mock_repository.NewMockBookRepository(ctrl))
+
+	mockRepo.EXPECT().GetWorkById(gomock.Any(), 99).Return(nil, pgx.ErrNoRows)
+
+	book, err := uc.LinkBook(context.Background(), 99, 3)
+	assert.ErrorIs(t, err, repository.ErrWorkNotFound)
+	assert.Nil(t, book)
+}
+
+func TestLinkBook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockWorkRepository(ctrl)
+	mockBookRepo := mock_repository.NewMockBookRepository(ctrl)
+	uc := usecase.NewWorkUsecase(mockRepo, mockBookRepo)
+
+	// 書籍のキャッシュを削除できるよう、紐付けは書籍のリポジトリで行う
+	expect := db.Book{ID: 3, WorkID: pgtype.Int4{Int32: 1, Valid: true}}
+	mockRepo.EXPECT().GetWorkById(gomock.Any(), 1).Return(&db.Work{ID: 1}, nil)
+	mockBookRepo.EXPECT().LinkWork(gomock.Any(), 3, 1).Return(&expect, nil)
+
+	book, err := uc.LinkBook(context.Background(), 1, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, &expect, book)
+}
+
+func TestCollapseByWork(t *testing.T) {
+	date := func(year int) pgtype.Date {
+		return pgtype.Date{Time: time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC), Valid: true}
+	}
+	work := func(id int32) pgtype.Int4 {
+		return pgtype.Int4{Int32: id, Valid: true}
+	}
+	books := []db.Book{
+		{ID: 1, WorkID: work(10), PublicationDate: date(2010)},
+		{ID: 2},
+		{ID: 3, WorkID: work(10), PublicationDate: date(1999)},
+		{ID: 4, WorkID: work(20)},
+		{ID: 5, WorkID: work(20), PublicationDate: date(2020)},
+		{ID: 6, WorkID: work(30)},
+		{ID: 7, WorkID: work(30)},
+	}
+
+	collapsed := usecase.CollapseByWork(books)
+	ids := make([]int32, 0, len(collapsed))
+	for _, book := range collapsed {
+		ids = append(ids, book.ID)
+	}
+	// 作品10は出版日の古い3、作品20は出版日のある5、作品30は出版日がないためIDの小さい6が代表となる
+	assert.Equal(t, []int32{2, 3, 5, 6}, ids)
+}
@@ -0,0 +1,250 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/rentaro-m-b/ai-model-exam/event"
+)
+
+const (
+	defaultBatchSize    = 50
+	defaultPollInterval = time.Second
+	defaultLease        = time.Minute
+	defaultTimeout      = 10 * time.Second
+	defaultBaseBackoff  = 10 * time.Second
+	defaultMaxBackoff   = time.Hour
+	defaultMaxAttempts  = 8
+)
+
+// ErrDisallowedAddress は送信先がループバック・プライベート・リンクローカル・未指定のアドレスに解決されたことを表す
+var ErrDisallowedAddress = errors.New("webhook destination address is not allowed")
+
+// Delivery は購読先へ送る1件の配信
+type Delivery struct {
+	ID        int64
+	EventID   int64
+	EventType event.Type
+	Body      []byte
+	// Attempts は今回を含めた送信の試行回数
+	Attempts int
+	URL      string
+	Secret   string
+}
+
+// Store は配信待ちのWebhookの取得と送信結果の記録を行う
+type Store interface {
+	// ClaimDeliveries は配信待ちの配信を最大limit件取得し、leaseUntilまで他の送信処理に渡さないようにする
+	ClaimDeliveries(ctx context.Context, limit int, leaseUntil time.Time) ([]Delivery, error)
+	// ReleaseDeliveries は取得したが送信しなかった配信の試行回数を戻し、直ちに配信待ちにする
+	ReleaseDeliveries(ctx context.Context, ids []int64, leaseUntil time.Time) error
+	MarkSucceeded(ctx context.Context, id int64, statusCode int) error
+	// MarkFailed は送信に失敗した配信を、deadが真であれば再送しない配信として、偽であればretryAtに再送する配信として記録する
+	// statusCodeは応答が無かった場合は0
+	MarkFailed(ctx context.Context, id int64, dead bool, retryAt time.Time, statusCode int, cause string) error
+}
+
+// DelivererConfig の未指定（ゼロ値）の項目には既定値を使う
+type DelivererConfig struct {
+	// BatchSize は1回に取得する配信の最大件数で、全ての送信がLease内に終わるようLease/Timeout件を上限とする
+	BatchSize int
+	// PollInterval は配信待ちが無い場合に次の取得まで待つ時間
+	PollInterval time.Duration
+	// Lease は取得した配信の送信にかけられる時間で、Timeoutより短い場合はTimeoutの2倍とする
+	Lease time.Duration
+	// Timeout は1回の送信の応答を待つ時間
+	Timeout time.Duration
+	// BaseBackoff は初回の送信失敗から再送までの時間で、失敗するごとに倍にする
+	BaseBackoff time.Duration
+	// MaxBackoff は再送までの時間の上限
+	MaxBackoff time.Duration
+	// MaxAttempts はデッドレターとするまでに送信を試みる回数
+	MaxAttempts int
+	// AllowPrivateNetworks はループバックなどの内部のアドレスへの送信を許可するかどうかで、テストの受け手に送る場合のみ使う
+	AllowPrivateNetworks bool
+}
+
+// Deliverer はWebhookの配信を購読先のURLへ署名付きのPOSTで送信する
+// 2xx以外の応答やタイムアウトは失敗とし、MaxAttempts回失敗した配信はデッドレターとして再送を止める
+type Deliverer struct {
+	store  Store
+	client *http.Client
+	config DelivererConfig
+	now    func() time.Time
+}
+
+func NewDeliverer(store Store, config *DelivererConfig) *Deliverer {
+	c := DelivererConfig{}
+	if config != nil {
+		c = *config
+	}
+	if c.BatchSize <= 0 {
+		c.BatchSize = defaultBatchSize
+	}
+	if c.PollInterval <= 0 {
+		c.PollInterval = defaultPollInterval
+	}
+	if c.Lease <= 0 {
+		c.Lease = defaultLease
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = defaultTimeout
+	}
+	if c.Lease < c.Timeout {
+		c.Lease = 2 * c.Timeout
+	}
+	// 1件ずつ送信するため、応答を待つ時間の合計がリースを超えないようにする
+	c.BatchSize = max(1, min(c.BatchSize, int(c.Lease/c.Timeout)))
+	if c.BaseBackoff <= 0 {
+		c.BaseBackoff = defaultBaseBackoff
+	}
+	if c.MaxBackoff <= 0 {
+		c.MaxBackoff = defaultMaxBackoff
+	}
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = defaultMaxAttempts
+	}
+
+	return &Deliverer{
+		store: store,
+		client: &http.Client{
+			Transport: newTransport(c.AllowPrivateNetworks),
+			Timeout:   c.Timeout,
+			// リダイレクト先は購読時に登録されたURLではないため、追わずに失敗とする
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		config: c,
+		now:    time.Now,
+	}
+}
+
+// newTransport は接続の直前に接続先のアドレスを検証するTransportを返す
+// 購読時のURLの検証だけでは、名前解決の結果によって内部のネットワークへ送信させられるため、名前解決後のアドレスで検証する
+func newTransport(allowPrivateNetworks bool) *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+	if !allowPrivateNetworks {
+		dialer.Control = checkDialAddress
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// プロキシを経由すると接続先がプロキシのアドレスとなり、送信先のアドレスを検証できないため使わない
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return transport
+}
+
+// checkDialAddress は名前解決後の接続先がループバック・プライベート・リンクローカル・未指定のアドレスであればErrDisallowedAddressを返す
+func checkDialAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	addr = addr.Unmap()
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsUnspecified() {
+		return fmt.Errorf("%w: %s", ErrDisallowedAddress, addr)
+	}
+
+	return nil
+}
+
+// Run はctxが終了するまで配信を送信し続ける
+func (d *Deliverer) Run(ctx context.Context) {
+	for {
+		n, err := d.DeliverOnce(ctx)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			log.Printf("Unable to execute DelivererRun: %d\n", err)
+		}
+		if err == nil && n == d.config.BatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(d.config.PollInterval):
+		}
+	}
+}
+
+// DeliverOnce は配信待ちの配信を1回分取得して送信し、取得した件数を返す
+// リースの残りが1回の送信の応答を待つ時間に満たなくなった場合は、残りの配信を送信せずに配信待ちに戻す
+// リースが切れた配信は他の送信処理が取得し直すため、送信を続けると同じ配信を重ねて送ることになる
+func (d *Deliverer) DeliverOnce(ctx context.Context) (int, error) {
+	leaseUntil := d.now().Add(d.config.Lease)
+	deliveries, err := d.store.ClaimDeliveries(ctx, d.config.BatchSize, leaseUntil)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, delivery := range deliveries {
+		if d.now().Add(d.config.Timeout).After(leaseUntil) {
+			ids := make([]int64, 0, len(deliveries)-i)
+			for _, rest := range deliveries[i:] {
+				ids = append(ids, rest.ID)
+			}
+			return len(deliveries), d.store.ReleaseDeliveries(ctx, ids, leaseUntil)
+		}
+
+		statusCode, err := d.send(ctx, &delivery)
+		if err == nil {
+			err = d.store.MarkSucceeded(ctx, delivery.ID, statusCode)
+		} else {
+			log.Printf("Unable to deliver webhook %d: %d\n", delivery.ID, err)
+			dead := delivery.Attempts >= d.config.MaxAttempts
+			retryAt := d.now().Add(event.Backoff(d.config.BaseBackoff, d.config.MaxBackoff, delivery.Attempts))
+			err = d.store.MarkFailed(ctx, delivery.ID, dead, retryAt, statusCode, err.Error())
+		}
+		if err != nil {
+			return len(deliveries), err
+		}
+	}
+
+	return len(deliveries), nil
+}
+
+// send は配信を送信し、応答のステータスコードを返す
+func (d *Deliverer) send(ctx context.Context, delivery *Delivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Body))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := d.now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEventID, strconv.FormatInt(delivery.EventID, 10))
+	req.Header.Set(HeaderEventType, string(delivery.EventType))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(delivery.Secret, timestamp, delivery.Body))
+
+	res, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	// 接続を再利用できるよう、応答のボディは読み捨てる
+	io.Copy(io.Discard, io.LimitReader(res.Body, 64<<10))
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return res.StatusCode, fmt.Errorf("unexpected status code %d", res.StatusCode)
+	}
+
+	return res.StatusCode, nil
+}
@@ -0,0 +1,271 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rentaro-m-b/ai-model-exam/event"
+	"github.com/rentaro-m-b/ai-model-exam/webhook"
+	"github.com/stretchr/testify/assert"
+)
+
+// failure は送信に失敗した配信の記録
+type failure struct {
+	dead       bool
+	retryAt    time.Time
+	statusCode int
+	cause      string
+}
+
+// memoryStore は配信をメモリ上に保持するwebhook.Storeの実装
+type memoryStore struct {
+	pending   []webhook.Delivery
+	succeeded map[int64]int
+	failed    map[int64]failure
+	// limit は最後に取得した際の最大件数で、claimDelay は取得にかかる時間
+	limit      int
+	claimDelay time.Duration
+}
+
+func newMemoryStore(deliveries ...webhook.Delivery) *memoryStore {
+	return &memoryStore{
+		pending:   deliveries,
+		succeeded: map[int64]int{},
+		failed:    map[int64]failure{},
+	}
+}
+
+func (s *memoryStore) ClaimDeliveries(ctx context.Context, limit int, leaseUntil time.Time) ([]webhook.Delivery, error) {
+	time.Sleep(s.claimDelay)
+	s.limit = limit
+	n := min(limit, len(s.pending))
+	claimed := s.pending[:n]
+	s.pending = s.pending[n:]
+	for i := range claimed {
+		claimed[i].Attempts++
+	}
+
+	return claimed, nil
+}
+
+func (s *memoryStore) ReleaseDeliveries(ctx context.Context, ids []int64, leaseUntil time.Time) error {
+	released := make([]webhook.Delivery, 0, len(ids))
+	for _, id := range ids {
+		released = append(released, webhook.Delivery{ID: id})
+	}
+	s.pending = append(released, s.pending...)
+	return nil
+}
+
+func (s *memoryStore) MarkSucceeded(ctx context.Context, id int64, statusCode int) error {
+	s.succeeded[id] = statusCode
+	return nil
+}
+
+func (s *memoryStore) MarkFailed(ctx context.Context, id int64, dead bool, retryAt time.Time, statusCode int, cause string) error {
+	s.failed[id] = failure{dead: dead, retryAt: retryAt, statusCode: statusCode, cause: cause}
+	return nil
+}
+
+func TestSignAndVerify(t *testing.T) {
+	body := []byte(`{"id":1}`)
+	signature := webhook.Sign("0123456789abcdef", 1719792000, body)
+
+	assert.Regexp(t, `^sha256=[0-9a-f]{64}$`, signature)
+	assert.True(t, webhook.Verify("0123456789abcdef", 1719792000, body, signature))
+	assert.False(t, webhook.Verify("0123456789abcdeX", 1719792000, body, signature))
+	assert.False(t, webhook.Verify("0123456789abcdef", 1719792001, body, signature))
+	assert.False(t, webhook.Verify("0123456789abcdef", 1719792000, []byte(`{"id":2}`), signature))
+}
+
+func TestSink(t *testing.T) {
+	var enqueued []byte
+	enqueuer := enqueuerFunc(func(ctx context.Context, eventId int64, eventType event.Type, body []byte) error {
+		assert.Equal(t, int64(10), eventId)
+		assert.Equal(t, event.TypeBookUpdated, eventType)
+		enqueued = body
+		return nil
+	})
+
+	occurredAt := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	err := webhook.NewSink(enqueuer).Deliver(context.Background(), event.Event{
+		ID:         10,
+		Type:       event.TypeBookUpdated,
+		BookID:     1,
+		Payload:    json.RawMessage(`{"id": 1, "title": "test title 1"}`),
+		OccurredAt: occurredAt,
+	})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"id": 10, "type": "book.updated", "book_id": 1, "occurred_at": "2024-07-01T00:00:00Z",
+		"data": {"id": 1, "title": "test title 1"}
+	}`, string(enqueued))
+}
+
+type enqueuerFunc func(ctx context.Context, eventId int64, eventType event.Type, body []byte) error
+
+func (f enqueuerFunc) EnqueueDeliveries(ctx context.Context, eventId int64, eventType event.Type, body []byte) error {
+	return f(ctx, eventId, eventType, body)
+}
+
+// localConfig はhttptestの受け手へ送信できるよう、ループバックのアドレスへの送信を許可する
+var localConfig = &webhook.DelivererConfig{AllowPrivateNetworks: true}
+
+func TestDeliverOnce(t *testing.T) {
+	secret := "0123456789abcdef"
+	body := []byte(`{"id":10,"type":"book.created"}`)
+
+	// 受け手として署名とヘッダを検証する
+	var received int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received++
+		b, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		timestamp, err := strconv.ParseInt(r.Header.Get(webhook.HeaderTimestamp), 10, 64)
+		assert.NoError(t, err)
+
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "10", r.Header.Get(webhook.HeaderEventID))
+		assert.Equal(t, "book.created", r.Header.Get(webhook.HeaderEventType))
+		assert.Equal(t, body, b)
+		assert.True(t, webhook.Verify(secret, timestamp, b, r.Header.Get(webhook.HeaderSignature)))
+		assert.WithinDuration(t, time.Now(), time.Unix(timestamp, 0), time.Minute)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	store := newMemoryStore(webhook.Delivery{
+		ID: 1, EventID: 10, EventType: event.TypeBookCreated, Body: body, URL: server.URL, Secret: secret,
+	})
+	n, err := webhook.NewDeliverer(store, localConfig).DeliverOnce(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, 1, received)
+	assert.Equal(t, map[int64]int{1: http.StatusNoContent}, store.succeeded)
+	assert.Empty(t, store.failed)
+}
+
+func TestDeliverOnceRetry(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	// リダイレクトは追わずに失敗とする
+	redirect := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, server.URL, http.StatusFound)
+	}))
+	defer redirect.Close()
+
+	store := newMemoryStore(
+		webhook.Delivery{ID: 1, EventID: 10, Body: []byte(`{}`), URL: server.URL, Secret: "0123456789abcdef"},
+		webhook.Delivery{ID: 2, EventID: 10, Body: []byte(`{}`), URL: redirect.URL, Secret: "0123456789abcdef", Attempts: 2},
+		// 最大回数に達した配信はデッドレターにする
+		webhook.Delivery{ID: 3, EventID: 10, Body: []byte(`{}`), URL: server.URL, Secret: "0123456789abcdef", Attempts: 4},
+	)
+	d := webhook.NewDeliverer(store, &webhook.DelivererConfig{
+		AllowPrivateNetworks: true,
+		BaseBackoff:          time.Second,
+		MaxBackoff:           time.Minute,
+		MaxAttempts:          5,
+	})
+	before := time.Now()
+	n, err := d.DeliverOnce(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Empty(t, store.succeeded)
+
+	assert.False(t, store.failed[1].dead)
+	assert.Equal(t, http.StatusInternalServerError, store.failed[1].statusCode)
+	assert.WithinDuration(t, before.Add(time.Second), store.failed[1].retryAt, time.Second)
+
+	assert.False(t, store.failed[2].dead)
+	assert.Equal(t, http.StatusFound, store.failed[2].statusCode)
+	assert.WithinDuration(t, before.Add(4*time.Second), store.failed[2].retryAt, time.Second)
+
+	assert.True(t, store.failed[3].dead)
+}
+
+func TestDeliverOnceFailureUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	store := newMemoryStore(webhook.Delivery{ID: 1, EventID: 10, Body: []byte(`{}`), URL: url, Secret: "0123456789abcdef"})
+	_, err := webhook.NewDeliverer(store, localConfig).DeliverOnce(context.Background())
+	assert.NoError(t, err)
+	// 応答が無い場合のステータスコードは0
+	assert.Equal(t, 0, store.failed[1].statusCode)
+	assert.False(t, store.failed[1].dead)
+}
+
+func TestDeliverOnceFailureDisallowedAddress(t *testing.T) {
+	var received int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received++
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	// 受け手は127.0.0.1で待ち受けるため、接続前に拒否される
+	assert.True(t, strings.HasPrefix(server.URL, "http://127.0.0.1:"))
+	store := newMemoryStore(webhook.Delivery{ID: 1, EventID: 10, Body: []byte(`{}`), URL: server.URL, Secret: "0123456789abcdef"})
+	_, err := webhook.NewDeliverer(store, nil).DeliverOnce(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, received)
+	assert.Empty(t, store.succeeded)
+	assert.Equal(t, 0, store.failed[1].statusCode)
+	assert.Contains(t, store.failed[1].cause, webhook.ErrDisallowedAddress.Error())
+}
+
+func TestDeliverOnceBatchWithinLease(t *testing.T) {
+	store := newMemoryStore()
+
+	// 1件ずつ送信するため、応答を待つ時間の合計がリースに収まる件数のみ取得する
+	d := webhook.NewDeliverer(store, &webhook.DelivererConfig{
+		BatchSize: 50,
+		Lease:     time.Minute,
+		Timeout:   20 * time.Second,
+	})
+	n, err := d.DeliverOnce(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+	assert.Equal(t, 3, store.limit)
+}
+
+func TestDeliverOnceLeaseExpiring(t *testing.T) {
+	var received int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received++
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	// 取得に時間がかかり、リースの残りが1回の送信の応答を待つ時間に満たない
+	store := newMemoryStore(
+		webhook.Delivery{ID: 1, EventID: 10, Body: []byte(`{}`), URL: server.URL, Secret: "0123456789abcdef"},
+		webhook.Delivery{ID: 2, EventID: 10, Body: []byte(`{}`), URL: server.URL, Secret: "0123456789abcdef"},
+	)
+	store.claimDelay = 150 * time.Millisecond
+	d := webhook.NewDeliverer(store, &webhook.DelivererConfig{
+		AllowPrivateNetworks: true,
+		Lease:                200 * time.Millisecond,
+		Timeout:              100 * time.Millisecond,
+	})
+	n, err := d.DeliverOnce(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	// リースが切れた後に他の送信処理が取得し直すため、送信せずに配信待ちに戻す
+	assert.Equal(t, 0, received)
+	assert.Empty(t, store.succeeded)
+	assert.Empty(t, store.failed)
+	assert.Equal(t, []int64{1, 2}, []int64{store.pending[0].ID, store.pending[1].ID})
+}
@@ -0,0 +1,80 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/rentaro-m-b/ai-model-exam/event"
+)
+
+const (
+	HeaderEventID   = "X-Webhook-Id"
+	HeaderEventType = "X-Webhook-Event"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+
+	signaturePrefix = "sha256="
+)
+
+// Payload はWebhookで送るリクエストボディ
+type Payload struct {
+	ID         int64           `json:"id"`
+	Type       event.Type      `json:"type"`
+	BookID     int32           `json:"book_id"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// Sign はタイムスタンプとリクエストボディを "." で連結した文字列の、secretによるHMAC-SHA256署名を返す
+// タイムスタンプを署名に含めることで、受け手は古いリクエストの再送を拒否できる
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify は署名がsecretによるものかを検証する
+func Verify(secret string, timestamp int64, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+// Enqueuer はイベントを購読しているWebhookごとに配信を登録する
+type Enqueuer interface {
+	// EnqueueDeliveries は同じイベントの配信が登録済みの購読には何もしない
+	EnqueueDeliveries(ctx context.Context, eventId int64, eventType event.Type, body []byte) error
+}
+
+// Sink はアウトボックスのイベントをWebhookの配信として登録するevent.Sink
+// 送信は購読ごとにDelivererが行うため、一部の購読先の失敗が他の購読先やSinkへ影響しない
+type Sink struct {
+	enqueuer Enqueuer
+}
+
+func NewSink(enqueuer Enqueuer) *Sink {
+	return &Sink{
+		enqueuer: enqueuer,
+	}
+}
+
+func (s *Sink) Deliver(ctx context.Context, ev event.Event) error {
+	body, err := json.Marshal(Payload{
+		ID:         ev.ID,
+		Type:       ev.Type,
+		BookID:     ev.BookID,
+		OccurredAt: ev.OccurredAt,
+		Data:       ev.Payload,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.enqueuer.EnqueueDeliveries(ctx, ev.ID, ev.Type, body)
+}